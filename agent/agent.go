@@ -172,6 +172,16 @@ const (
 	LogSinkDBLoggerFlushInterval = "LOGSINK_DBLOGGER_FLUSH_INTERVAL"
 	LogSinkRateLimitBurst        = "LOGSINK_RATELIMIT_BURST"
 	LogSinkRateLimitRefill       = "LOGSINK_RATELIMIT_REFILL"
+
+	// HookTraceLimit is the number of hook execution traces a unit
+	// agent keeps for inspection and replay. Hooks are only traced
+	// if it is set to a positive number.
+	HookTraceLimit = "HOOK_TRACE_LIMIT"
+
+	// HookTraceRedactEnv is a comma separated list of the names of
+	// environment variables whose values are removed from hook traces,
+	// in addition to those whose names suggest they hold credentials.
+	HookTraceRedactEnv = "HOOK_TRACE_REDACT_ENV"
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -336,9 +337,10 @@ func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
 				countPtr = &count
 			}
 			storageConstraints[name] = params.StorageConstraints{
-				Pool:  cons.Pool,
-				Size:  sizePtr,
-				Count: countPtr,
+				Pool:     cons.Pool,
+				Size:     sizePtr,
+				Count:    countPtr,
+				Snapshot: cons.Snapshot,
 			}
 		}
 	}
@@ -1020,3 +1022,156 @@ func (c *Client) MergeBindings(req params.ApplicationMergeBindingsArgs) error {
 	}
 	return results.OneError()
 }
+
+// TransferLeadership moves leadership of the application from its
+// current leader to the named unit. If unitName is empty, the controller
+// chooses the next healthy unit of the application. The name of the
+// new leader is returned.
+func (c *Client) TransferLeadership(application, unitName string) (string, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 12 {
+		return "", errors.NotSupportedf("TransferLeadership for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return "", errors.NotValidf("application name %q", application)
+	}
+	arg := params.TransferLeadershipArg{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+	}
+	if unitName != "" {
+		if !names.IsValidUnit(unitName) {
+			return "", errors.NotValidf("unit name %q", unitName)
+		}
+		arg.UnitTag = names.NewUnitTag(unitName).String()
+	}
+	args := params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{arg},
+	}
+	var results params.TransferLeadershipResults
+	if err := c.facade.FacadeCall("TransferLeadership", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Leader, nil
+}
+
+// SetUnitsMaintenanceMode puts the named units into, or takes them out of,
+// maintenance mode.
+func (c *Client) SetUnitsMaintenanceMode(maintenance bool, unitNames ...string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return errors.NotSupportedf("SetUnitsMaintenanceMode for Application facade v%v", apiVersion)
+	}
+	args := params.UnitsMaintenanceModeArgs{
+		Args: make([]params.UnitMaintenanceModeArg, len(unitNames)),
+	}
+	for i, name := range unitNames {
+		if !names.IsValidUnit(name) {
+			return errors.NotValidf("unit name %q", name)
+		}
+		args.Args[i] = params.UnitMaintenanceModeArg{
+			UnitTag:         names.NewUnitTag(name).String(),
+			MaintenanceMode: maintenance,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetUnitsMaintenanceMode", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// AllowEgress adds egress rules to the named application, restricting
+// its egress. Once an application's egress is restricted, its workloads
+// may only send outgoing traffic matching one of its egress rules.
+func (c *Client) AllowEgress(application string, rules ...network.EgressRule) error {
+	return c.changeEgress("AllowEgress", application, rules)
+}
+
+// RevokeEgress removes egress rules from the named application. The
+// application's egress stays restricted, even without egress rules.
+func (c *Client) RevokeEgress(application string, rules ...network.EgressRule) error {
+	return c.changeEgress("RevokeEgress", application, rules)
+}
+
+// ResetEgress removes all egress rules from the named application and
+// lifts the restriction on its egress.
+func (c *Client) ResetEgress(application string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("ResetEgress for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResetEgress", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+func (c *Client) changeEgress(method, application string, rules []network.EgressRule) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("%s for Application facade v%v", method, apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	arg := params.ApplicationEgressRules{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+		Rules:          make([]params.EgressRule, len(rules)),
+	}
+	for i, rule := range rules {
+		arg.Rules[i] = params.EgressRule{
+			PortRange:        params.FromNetworkPortRange(rule.PortRange),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	args := params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{arg},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// EgressRules returns the egress rules of the named application, and
+// whether its egress is restricted to them.
+func (c *Client) EgressRules(application string) ([]network.EgressRule, bool, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return nil, false, errors.NotSupportedf("GetEgressRules for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return nil, false, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.EgressRulesResults
+	if err := c.facade.FacadeCall("GetEgressRules", args, &results); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	var rules []network.EgressRule
+	for _, rule := range results.Results[0].Rules {
+		rules = append(rules, network.EgressRule{
+			PortRange:        rule.PortRange.NetworkPortRange(),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		})
+	}
+	return rules, results.Results[0].Restricted, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -1559,3 +1560,231 @@ func (s *applicationSuite) TestApplicationsInfoResultMismatch(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestTransferLeadership(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "TransferLeadership")
+				c.Assert(a, jc.DeepEquals, params.TransferLeadershipArgs{
+					Args: []params.TransferLeadershipArg{{
+						ApplicationTag: "application-foo",
+						UnitTag:        "unit-foo-1",
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.TransferLeadershipResults{})
+				out := response.(*params.TransferLeadershipResults)
+				out.Results = []params.TransferLeadershipResult{{Leader: "foo/1"}}
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+	leader, err := client.TransferLeadership("foo", "foo/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leader, gc.Equals, "foo/1")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestTransferLeadershipError(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(a, jc.DeepEquals, params.TransferLeadershipArgs{
+					Args: []params.TransferLeadershipArg{{
+						ApplicationTag: "application-foo",
+					}},
+				})
+				out := response.(*params.TransferLeadershipResults)
+				out.Results = []params.TransferLeadershipResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+	_, err := client.TransferLeadership("foo", "")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestTransferLeadershipNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.TransferLeadership("foo", "")
+	c.Assert(err, gc.ErrorMatches, "TransferLeadership for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestSetUnitsMaintenanceMode(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "SetUnitsMaintenanceMode")
+				c.Assert(a, jc.DeepEquals, params.UnitsMaintenanceModeArgs{
+					Args: []params.UnitMaintenanceModeArg{{
+						UnitTag:         "unit-foo-0",
+						MaintenanceMode: true,
+					}, {
+						UnitTag:         "unit-foo-1",
+						MaintenanceMode: true,
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+				out := response.(*params.ErrorResults)
+				out.Results = []params.ErrorResult{{}, {}}
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	err := client.SetUnitsMaintenanceMode(true, "foo/0", "foo/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetUnitsMaintenanceModeNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.SetUnitsMaintenanceMode(true, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "SetUnitsMaintenanceMode for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestAllowEgress(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "AllowEgress")
+				c.Assert(a, jc.DeepEquals, params.ApplicationEgressRulesArgs{
+					Args: []params.ApplicationEgressRules{{
+						ApplicationTag: "application-foo",
+						Rules: []params.EgressRule{{
+							PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+							DestinationCIDRs: []string{"10.0.0.0/24"},
+						}},
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+				out := response.(*params.ErrorResults)
+				out.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	err := client.AllowEgress("foo", network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestRevokeEgress(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "RevokeEgress")
+				c.Assert(a, jc.DeepEquals, params.ApplicationEgressRulesArgs{
+					Args: []params.ApplicationEgressRules{{
+						ApplicationTag: "application-foo",
+						Rules: []params.EgressRule{{
+							PortRange: params.PortRange{FromPort: 53, ToPort: 53, Protocol: "udp"},
+						}},
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+				out := response.(*params.ErrorResults)
+				out.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	err := client.RevokeEgress("foo", network.MustNewEgressRule("udp", 53, 53))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestEgressRules(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "GetEgressRules")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.EgressRulesResults{})
+				out := response.(*params.EgressRulesResults)
+				out.Results = []params.EgressRulesResult{{
+					Restricted: true,
+					Rules: []params.EgressRule{{
+						PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+						DestinationCIDRs: []string{"10.0.0.0/24"},
+					}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	rules, restricted, err := client.EgressRules("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+	c.Assert(restricted, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestResetEgress(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(objType, gc.Equals, "Application")
+				c.Check(request, gc.Equals, "ResetEgress")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+				out := response.(*params.ErrorResults)
+				out.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	err := client.ResetEgress("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestEgressRulesNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.AllowEgress("foo", network.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, gc.ErrorMatches, "AllowEgress for Application facade v8 not supported")
+	_, _, err = client.EgressRules("foo")
+	c.Assert(err, gc.ErrorMatches, "GetEgressRules for Application facade v8 not supported")
+	err = client.ResetEgress("foo")
+	c.Assert(err, gc.ErrorMatches, "ResetEgress for Application facade v8 not supported")
+}
@@ -13,13 +13,31 @@ import (
 // returns the metadata associated with the resulting backup and a
 // filename for download.
 func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
-	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{
+	return c.create(params.BackupsCreateArgs{
 		Notes:      notes,
 		KeepCopy:   keepCopy,
 		NoDownload: noDownload,
+	})
+}
+
+// CreateEncrypted is like Create, but asks for the backup archive to
+// be encrypted with either the passphrase or the armored OpenPGP
+// public key.
+func (c *Client) CreateEncrypted(notes string, keepCopy, noDownload bool, passphrase, publicKey string) (*params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("backup encryption by this version of Juju")
 	}
+	return c.create(params.BackupsCreateArgs{
+		Notes:      notes,
+		KeepCopy:   keepCopy,
+		NoDownload: noDownload,
+		Passphrase: passphrase,
+		PublicKey:  publicKey,
+	})
+}
 
+func (c *Client) create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
+	var result params.BackupsMetadataResult
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -47,3 +47,31 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Notes, gc.Equals, "important")
+			c.Check(p.Passphrase, gc.Equals, "")
+			c.Check(p.PublicKey, gc.Equals, backupstesting.PublicKey)
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
+				result.Notes = p.Notes
+				result.Encryption = "public-key"
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateEncrypted("important", false, false, "", backupstesting.PublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encryption, gc.Equals, "public-key")
+}
@@ -83,7 +83,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 	list := results.List
 	for _, b := range list {
 		if b.Checksum == meta.Checksum {
-			return c.restore(b.ID, newClient)
+			return c.restore(params.RestoreArgs{BackupId: b.ID}, newClient)
 		}
 	}
 
@@ -95,7 +95,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
 
-	return c.restore(backupId, newClient)
+	return c.restore(params.RestoreArgs{BackupId: backupId}, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
@@ -104,7 +104,25 @@ func (c *Client) Restore(backupId string, newClient ClientConnection) error {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(params.RestoreArgs{BackupId: backupId}, newClient)
+}
+
+// RestoreEncrypted is like Restore, but the stored backup is encrypted
+// and is decrypted on the server with the passphrase or the armored
+// OpenPGP private key.
+func (c *Client) RestoreEncrypted(backupId, passphrase, privateKey string, newClient ClientConnection) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("restoring encrypted backups by this version of Juju")
+	}
+	if err := prepareRestore(newClient); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("Server in 'about to restore' mode")
+	return c.restore(params.RestoreArgs{
+		BackupId:   backupId,
+		Passphrase: passphrase,
+		PrivateKey: privateKey,
+	}, newClient)
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -117,20 +135,15 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
+// It takes restoreArgs identifying the remote backup file and a
 // client connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(restoreArgs params.RestoreArgs, newClient ClientConnection) error {
 	var err, remoteError error
 
-	// Restore
-	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
-	}
-
 	cleanExit := false
 	for a := restoreStrategy.Start(); a.Next(); {
-		logger.Debugf("Attempting Restore of %q", backupId)
+		logger.Debugf("Attempting Restore of %q", restoreArgs.BackupId)
 		var restoreClient *Client
 		restoreClient, err = newClient()
 		if err != nil {
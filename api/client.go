@@ -112,6 +112,20 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 	return history, nil
 }
 
+// ModelStatusHistory returns a page of the status history of every
+// entity in the model, oldest first. To fetch the following page, call
+// it again with args.After set to the Next value of the result.
+func (c *Client) ModelStatusHistory(args params.ModelStatusHistoryRequest) (params.ModelStatusHistoryResult, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return params.ModelStatusHistoryResult{}, errors.NotSupportedf("exporting model status history by this controller")
+	}
+	var result params.ModelStatusHistoryResult
+	if err := c.facade.FacadeCall("ModelStatusHistory", args, &result); err != nil {
+		return params.ModelStatusHistoryResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // Resolved clears errors on a unit.
 func (c *Client) Resolved(unit string, retry bool) error {
 	p := params.Resolved{
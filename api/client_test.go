@@ -34,6 +34,7 @@ import (
 	"github.com/juju/juju/api/common"
 	servercommon "github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
 	jujunames "github.com/juju/juju/juju/names"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
@@ -62,6 +63,30 @@ func (s *clientSuite) TestCloseMultipleOk(c *gc.C) {
 	c.Assert(client.Close(), gc.IsNil)
 }
 
+func (s *clientSuite) TestModelStatusHistory(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	since := time.Now().Add(time.Hour).Round(time.Second)
+	err := app.SetStatus(status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: "installing",
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	from := since.Add(-time.Second)
+	result, err := client.ModelStatusHistory(params.ModelStatusHistoryRequest{From: &from})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Next, gc.Equals, "")
+	c.Assert(result.Entries, gc.HasLen, 1)
+	entry := result.Entries[0]
+	c.Check(entry.Kind, gc.Equals, "application")
+	c.Check(entry.Entity, gc.Equals, app.Name())
+	c.Check(entry.Status, gc.Equals, "maintenance")
+	c.Check(entry.Info, gc.Equals, "installing")
+	c.Check(entry.Since.Equal(since), jc.IsTrue)
+}
+
 func (s *clientSuite) TestUploadToolsOtherModel(c *gc.C) {
 	otherSt, otherAPISt := s.otherModel(c)
 	defer otherSt.Close()
@@ -614,16 +639,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
 
 	params := common.DebugLogParams{
-		IncludeEntity: []string{"a", "b"},
-		IncludeModule: []string{"c", "d"},
-		ExcludeEntity: []string{"e", "f"},
-		ExcludeModule: []string{"g", "h"},
-		Limit:         100,
-		Backlog:       200,
-		Level:         loggo.ERROR,
-		Replay:        true,
-		NoTail:        true,
-		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		IncludeEntity:  []string{"a", "b"},
+		IncludeModule:  []string{"c", "d"},
+		ExcludeEntity:  []string{"e", "f"},
+		ExcludeModule:  []string{"g", "h"},
+		Limit:          100,
+		Backlog:        200,
+		Level:          loggo.ERROR,
+		Replay:         true,
+		NoTail:         true,
+		StartTime:      time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		EndTime:        time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
+		IncludeMessage: []string{"^hook", "failed$"},
+		ExcludeMessage: []string{"update-status"},
+		AgentKinds:     []string{"unit"},
 	}
 
 	client := s.APIState.Client()
@@ -635,16 +664,20 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 
 	values := connectURL.Query()
 	c.Assert(values, jc.DeepEquals, url.Values{
-		"includeEntity": params.IncludeEntity,
-		"includeModule": params.IncludeModule,
-		"excludeEntity": params.ExcludeEntity,
-		"excludeModule": params.ExcludeModule,
-		"maxLines":      {"100"},
-		"backlog":       {"200"},
-		"level":         {"ERROR"},
-		"replay":        {"true"},
-		"noTail":        {"true"},
-		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"includeEntity":  params.IncludeEntity,
+		"includeModule":  params.IncludeModule,
+		"excludeEntity":  params.ExcludeEntity,
+		"excludeModule":  params.ExcludeModule,
+		"maxLines":       {"100"},
+		"backlog":        {"200"},
+		"level":          {"ERROR"},
+		"replay":         {"true"},
+		"noTail":         {"true"},
+		"startTime":      {"2016-11-30T11:48:00.0000001Z"},
+		"endTime":        {"2016-11-30T12:48:00Z"},
+		"includeMessage": params.IncludeMessage,
+		"excludeMessage": params.ExcludeMessage,
+		"agentKind":      params.AgentKinds,
 	})
 }
 
@@ -53,14 +53,32 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time on or before
+	// EndTime will be returned. If EndTime is in the past, the server
+	// does not wait for new logs to arrive.
+	EndTime time.Time
+	// IncludeMessage lists regular expressions matched against the log
+	// message. If any are set, only lines whose message matches one of
+	// them are returned.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions matched against the log
+	// message. Lines whose message matches any of them are not returned.
+	ExcludeMessage []string
+	// AgentKinds lists the kinds of agent (machine, unit or
+	// application) whose lines are returned. If none are set, lines
+	// from all agents are considered included.
+	AgentKinds []string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
 	attrs := url.Values{
-		"includeEntity": args.IncludeEntity,
-		"includeModule": args.IncludeModule,
-		"excludeEntity": args.ExcludeEntity,
-		"excludeModule": args.ExcludeModule,
+		"includeEntity":  args.IncludeEntity,
+		"includeModule":  args.IncludeModule,
+		"excludeEntity":  args.ExcludeEntity,
+		"excludeModule":  args.ExcludeModule,
+		"includeMessage": args.IncludeMessage,
+		"excludeMessage": args.ExcludeMessage,
+		"agentKind":      args.AgentKinds,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -80,6 +98,9 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -73,6 +74,34 @@ func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
 	return cfg, ok, nil
 }
 
+// LogForwardSinkConfig returns the current configuration for the given
+// kind of log forwarding sink (see logfwd.SinkSyslog etc).
+func (e *ModelWatcher) LogForwardSinkConfig(kind string) (logfwd.SinkConfig, bool, error) {
+	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
+	// For now, we'll piggyback off the ModelConfig API.
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	switch kind {
+	case logfwd.SinkSyslog:
+		if cfg, ok := modelConfig.LogFwdSyslog(); ok {
+			return cfg, true, nil
+		}
+	case logfwd.SinkJSON:
+		if cfg, ok := modelConfig.LogFwdJSON(); ok {
+			return cfg, true, nil
+		}
+	case logfwd.SinkLoki:
+		if cfg, ok := modelConfig.LogFwdLoki(); ok {
+			return cfg, true, nil
+		}
+	default:
+		return nil, false, errors.NotValidf("log forwarding sink kind %q", kind)
+	}
+	return nil, false, nil
+}
+
 // UpdateStatusHookInterval returns the current update status hook interval.
 func (e *ModelWatcher) UpdateStatusHookInterval() (time.Duration, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
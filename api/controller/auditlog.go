@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+// AuditLog returns the records in the audit log of the controller
+// machine this client is connected to that match the filter.
+func (c *Client) AuditLog(filter auditlog.Filter) ([]auditlog.Record, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("AuditLog not supported by this version of Juju")
+	}
+	args := params.AuditLogQuery{
+		User:           filter.Who,
+		ModelUUID:      filter.ModelUUID,
+		ConversationID: filter.ConversationID,
+		Facade:         filter.Facade,
+		Method:         filter.Method,
+		Limit:          filter.Limit,
+	}
+	if !filter.After.IsZero() {
+		args.After = &filter.After
+	}
+	if !filter.Before.IsZero() {
+		args.Before = &filter.Before
+	}
+	var result params.AuditLogResult
+	if err := c.facade.FacadeCall("AuditLog", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	records := make([]auditlog.Record, len(result.Records))
+	for i, record := range result.Records {
+		records[i] = auditRecordFromParams(record)
+	}
+	return records, nil
+}
+
+func auditRecordFromParams(record params.AuditLogRecord) auditlog.Record {
+	var result auditlog.Record
+	if c := record.Conversation; c != nil {
+		result.Conversation = &auditlog.Conversation{
+			Who:            c.Who,
+			What:           c.What,
+			When:           c.When,
+			ModelName:      c.ModelName,
+			ModelUUID:      c.ModelUUID,
+			ConversationID: c.ConversationID,
+			ConnectionID:   c.ConnectionID,
+		}
+	}
+	if r := record.Request; r != nil {
+		result.Request = &auditlog.Request{
+			ConversationID: r.ConversationID,
+			ConnectionID:   r.ConnectionID,
+			RequestID:      r.RequestID,
+			When:           r.When,
+			Facade:         r.Facade,
+			Method:         r.Method,
+			Version:        r.Version,
+			Args:           r.Args,
+		}
+	}
+	if r := record.Errors; r != nil {
+		errs := make([]*auditlog.Error, len(r.Errors))
+		for i, e := range r.Errors {
+			if e != nil {
+				errs[i] = &auditlog.Error{Message: e.Message, Code: e.Code}
+			}
+		}
+		result.Errors = &auditlog.ResponseErrors{
+			ConversationID: r.ConversationID,
+			ConnectionID:   r.ConnectionID,
+			RequestID:      r.RequestID,
+			When:           r.When,
+			Errors:         errs,
+		}
+	}
+	return result
+}
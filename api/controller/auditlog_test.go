@@ -0,0 +1,124 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+func (s *Suite) TestAuditLogPriorV9(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.AuditLog(auditlog.Filter{})
+	c.Assert(err, gc.ErrorMatches, "AuditLog not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestAuditLogCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.AuditLog(auditlog.Filter{})
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestAuditLog(c *gc.C) {
+	after := time.Date(2017, 12, 12, 0, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AuditLog")
+			c.Check(arg, jc.DeepEquals, params.AuditLogQuery{
+				User:   "deerhoof",
+				Facade: "Application",
+				After:  &after,
+				Limit:  10,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogResult{})
+
+			out := result.(*params.AuditLogResult)
+			out.Records = []params.AuditLogRecord{{
+				Conversation: &params.AuditConversation{
+					Who:            "deerhoof",
+					What:           "juju deploy prometheus",
+					When:           "2017-12-12T11:34:50Z",
+					ConversationID: "0123456789abcdef",
+				},
+			}, {
+				Request: &params.AuditRequest{
+					ConversationID: "0123456789abcdef",
+					RequestID:      25,
+					When:           "2017-12-12T11:34:56Z",
+					Facade:         "Application",
+					Method:         "Deploy",
+					Version:        4,
+				},
+			}, {
+				Errors: &params.AuditResponseErrors{
+					ConversationID: "0123456789abcdef",
+					RequestID:      25,
+					When:           "2017-12-12T11:35:11Z",
+					Errors:         []*params.AuditError{{Message: "oops", Code: "unauthorized access"}},
+				},
+			}}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	records, err := client.AuditLog(auditlog.Filter{
+		Who:    "deerhoof",
+		Facade: "Application",
+		After:  after,
+		Limit:  10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []auditlog.Record{{
+		Conversation: &auditlog.Conversation{
+			Who:            "deerhoof",
+			What:           "juju deploy prometheus",
+			When:           "2017-12-12T11:34:50Z",
+			ConversationID: "0123456789abcdef",
+		},
+	}, {
+		Request: &auditlog.Request{
+			ConversationID: "0123456789abcdef",
+			RequestID:      25,
+			When:           "2017-12-12T11:34:56Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        4,
+		},
+	}, {
+		Errors: &auditlog.ResponseErrors{
+			ConversationID: "0123456789abcdef",
+			RequestID:      25,
+			When:           "2017-12-12T11:35:11Z",
+			Errors:         []*auditlog.Error{{Message: "oops", Code: "unauthorized access"}},
+		},
+	}})
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// BackupStatus holds the backup schedule of a controller and the
+// outcome of its most recent scheduled backups. Times are zero when
+// the corresponding event hasn't happened.
+type BackupStatus struct {
+	Schedule     string
+	NextBackup   time.Time
+	LastSuccess  time.Time
+	LastBackupID string
+	LastFailure  time.Time
+	LastError    string
+}
+
+// BackupStatus returns the scheduled backup status of the controller.
+func (c *Client) BackupStatus() (BackupStatus, error) {
+	if c.BestAPIVersion() < 10 {
+		return BackupStatus{}, errors.NotSupportedf("BackupStatus not supported by this version of Juju")
+	}
+	var result params.BackupStatusResult
+	if err := c.facade.FacadeCall("BackupStatus", nil, &result); err != nil {
+		return BackupStatus{}, errors.Trace(err)
+	}
+	status := BackupStatus{
+		Schedule:     result.Schedule,
+		LastBackupID: result.LastBackupID,
+		LastError:    result.LastError,
+	}
+	if result.NextBackup != nil {
+		status.NextBackup = *result.NextBackup
+	}
+	if result.LastSuccess != nil {
+		status.LastSuccess = *result.LastSuccess
+	}
+	if result.LastFailure != nil {
+		status.LastFailure = *result.LastFailure
+	}
+	return status, nil
+}
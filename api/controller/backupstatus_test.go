@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestBackupStatusPriorV10(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "BackupStatus not supported by this version of Juju not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestBackupStatusCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestBackupStatus(c *gc.C) {
+	next := time.Date(2020, 3, 19, 2, 0, 0, 0, time.UTC)
+	success := time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "BackupStatus")
+			c.Check(arg, gc.IsNil)
+			out := result.(*params.BackupStatusResult)
+			*out = params.BackupStatusResult{
+				Schedule:     "0 2 * * *",
+				NextBackup:   &next,
+				LastSuccess:  &success,
+				LastBackupID: "backup-id",
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, controller.BackupStatus{
+		Schedule:     "0 2 * * *",
+		NextBackup:   next,
+		LastSuccess:  success,
+		LastBackupID: "backup-id",
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package debugshell implements the API for debugging the hooks of
+// a unit through a shell relayed by the controller, for operators
+// that cannot reach the unit's machine over SSH.
+package debugshell
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common/stream"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Stream carries the messages of a debug shell session.
+type Stream interface {
+	// Send sends the message to the other end of the session.
+	Send(params.DebugShellMessage) error
+
+	// Receive blocks until a message is received from the
+	// other end of the session.
+	Receive() (params.DebugShellMessage, error)
+
+	// Close ends the session.
+	Close() error
+}
+
+// API provides access to the debug shell API.
+type API struct {
+	connector base.StreamConnector
+}
+
+// NewAPI creates a new client-side debug shell API.
+func NewAPI(connector base.StreamConnector) *API {
+	return &API{connector: connector}
+}
+
+// Open starts a session debugging the named hooks of the unit, or
+// the next hook to run if none are named. It fails if the unit's
+// agent is not waiting for a session on the controller.
+func (api *API) Open(unitName string, hooks []string) (Stream, error) {
+	conn, err := stream.Open(api.connector, "/debug-shell", params.DebugShellConfig{
+		Unit:  unitName,
+		Hooks: hooks,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &sessionStream{conn}, nil
+}
+
+// Wait is called by agents to wait for an operator to start a
+// debug session for the unit. It blocks until an operator does
+// so, and returns the session's stream and the hooks to debug.
+// Agents of a single unit may leave the unit name empty.
+func (api *API) Wait(unitName string) (Stream, []string, error) {
+	conn, err := stream.Open(api.connector, "/debug-shell/agent", params.DebugShellConfig{
+		Unit: unitName,
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	s := &sessionStream{conn}
+	m, err := s.Receive()
+	if err != nil {
+		s.Close()
+		return nil, nil, errors.Annotate(err, "waiting for debug session")
+	}
+	return s, m.Hooks, nil
+}
+
+type sessionStream struct {
+	conn base.Stream
+}
+
+// Send is part of the Stream interface.
+func (s *sessionStream) Send(m params.DebugShellMessage) error {
+	return errors.Trace(s.conn.WriteJSON(m))
+}
+
+// Receive is part of the Stream interface.
+func (s *sessionStream) Receive() (params.DebugShellMessage, error) {
+	var m params.DebugShellMessage
+	err := s.conn.ReadJSON(&m)
+	return m, errors.Trace(err)
+}
+
+// Close is part of the Stream interface.
+func (s *sessionStream) Close() error {
+	return s.conn.Close()
+}
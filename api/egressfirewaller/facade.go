@@ -0,0 +1,77 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
+)
+
+// Facade provides access to the EgressFirewaller API facade.
+type Facade struct {
+	caller base.FacadeCaller
+	tag    names.MachineTag
+}
+
+// NewFacade creates a new client-side EgressFirewaller facade for the
+// specified machine.
+func NewFacade(caller base.APICaller, tag names.MachineTag) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "EgressFirewaller"),
+		tag:    tag,
+	}
+}
+
+// Watch returns a NotifyWatcher which notifies of changes to the
+// machine, including its egress rules.
+func (f *Facade) Watch() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: f.tag.String()}},
+	}
+	if err := f.caller.FacadeCall("Watch", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// EgressRules returns the egress rules the machine agent must enforce,
+// and whether the egress of the machine is restricted to them.
+func (f *Facade) EgressRules() ([]network.EgressRule, bool, error) {
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: f.tag.String()}},
+	}
+	if err := f.caller.FacadeCall("EgressRules", args, &results); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	var rules []network.EgressRule
+	for _, rule := range result.Rules {
+		rules = append(rules, network.EgressRule{
+			PortRange:        rule.PortRange.NetworkPortRange(),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		})
+	}
+	return rules, result.Restricted, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller_test
+
+import (
+	"errors"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/egressfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestEgressRules(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "EgressFirewaller")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.EgressRulesResults) = params.EgressRulesResults{
+			Results: []params.EgressRulesResult{{
+				Restricted: true,
+				Rules: []params.EgressRule{{
+					PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+					DestinationCIDRs: []string{"10.0.0.0/24"},
+				}},
+			}},
+		}
+		return nil
+	})
+	facade := egressfirewaller.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	rules, restricted, err := facade.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restricted, jc.IsTrue)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+	stub.CheckCalls(c, []testing.StubCall{{
+		"EgressRules", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestEgressRulesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.EgressRulesResults) = params.EgressRulesResults{
+			Results: []params.EgressRulesResult{{
+				Error: &params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := egressfirewaller.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	_, _, err := facade.EgressRules()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestWatchCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(request, gc.Equals, "Watch")
+		return errors.New("blam")
+	})
+	facade := egressfirewaller.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	_, err := facade.Watch()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
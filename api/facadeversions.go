@@ -18,10 +18,10 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  11,
+	"Application":                  14,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
-	"Backups":                      2,
+	"Backups":                      3,
 	"Block":                        2,
 	"Bundle":                       4,
 	"CAASAgent":                    1,
@@ -33,22 +33,23 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       2,
+	"Client":                       3,
 	"Cloud":                        6,
-	"Controller":                   8,
+	"Controller":                   10,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
 	"CrossModelRelations":          2,
 	"Deployer":                     1,
 	"DiskManager":                  2,
+	"EgressFirewaller":             1,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
+	"Firewaller":                   6,
 	"FirewallRules":                1,
-	"HighAvailability":             2,
+	"HighAvailability":             3,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
@@ -75,8 +76,8 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  2,
-	"ModelGeneration":              4,
-	"ModelManager":                 8,
+	"ModelGeneration":              5,
+	"ModelManager":                 9,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
@@ -98,19 +99,20 @@ var facadeVersions = map[string]int{
 	"Spaces":                       6,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      6,
-	"StorageProvisioner":           4,
+	"Storage":                      8,
+	"StorageProvisioner":           6,
 	"StringsWatcher":               1,
 	"Subnets":                      3,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       15,
+	"Uniter":                       17,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UpgradeSteps":                 1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
 	"VolumeAttachmentPlansWatcher": 1,
+	"WaitFor":                      1,
 }
 
 // bestVersion tries to find the newest version in the version list that we can
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
 )
 
 // Application represents the state of an application.
@@ -64,3 +65,38 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// EgressRules returns the egress rules of this application, and
+// whether its egress is restricted to them. An application whose
+// egress isn't restricted may send outgoing traffic anywhere.
+func (s *Application) EgressRules() ([]network.EgressRule, bool, error) {
+	if apiVersion := s.st.BestAPIVersion(); apiVersion < 6 {
+		return nil, false, errors.NotSupportedf("GetEgressRules for Firewaller facade v%v", apiVersion)
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetEgressRules", args, &results)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(results.Results) != 1 {
+		return nil, false, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return nil, false, errors.NewNotFound(result.Error, "")
+		}
+		return nil, false, result.Error
+	}
+	var rules []network.EgressRule
+	for _, rule := range result.Rules {
+		rules = append(rules, network.EgressRule{
+			PortRange:        rule.PortRange.NetworkPortRange(),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		})
+	}
+	return rules, result.Restricted, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network"
 )
 
 type applicationSuite struct {
@@ -78,3 +79,20 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestEgressRules(c *gc.C) {
+	rules, restricted, err := s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+	c.Assert(restricted, jc.IsFalse)
+
+	err = s.application.AllowEgress(network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, restricted, err = s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+	c.Assert(restricted, jc.IsTrue)
+}
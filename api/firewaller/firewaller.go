@@ -25,6 +25,7 @@ const firewallerFacade = "Firewaller"
 type Client struct {
 	facade base.FacadeCaller
 	*common.ModelWatcher
+	*common.APIAddresser
 	*cloudspec.CloudSpecAPI
 }
 
@@ -38,6 +39,7 @@ func NewClient(caller base.APICaller) (*Client, error) {
 	return &Client{
 		facade:       facadeCaller,
 		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		APIAddresser: common.NewAPIAddresser(facadeCaller),
 		CloudSpecAPI: cloudspec.NewCloudSpecAPI(facadeCaller, modelTag),
 	}, nil
 }
@@ -6,6 +6,7 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
 	apiwatcher "github.com/juju/juju/api/watcher"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher"
+	jujunetwork "github.com/juju/juju/network"
 )
 
 // Machine represents a juju machine as seen by the firewaller worker.
@@ -164,3 +166,29 @@ func (m *Machine) IsManual() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// SetEgressRules records the egress firewall to be enforced by the
+// machine's agent, for machines whose provider can't enforce egress
+// rules.
+func (m *Machine) SetEgressRules(restricted bool, rules []jujunetwork.EgressRule) error {
+	if apiVersion := m.st.BestAPIVersion(); apiVersion < 6 {
+		return errors.NotSupportedf("SetMachineEgressRules for Firewaller facade v%v", apiVersion)
+	}
+	arg := params.MachineEgressRules{
+		MachineTag: m.tag.String(),
+		Restricted: restricted,
+	}
+	for _, rule := range rules {
+		arg.Rules = append(arg.Rules, params.EgressRule{
+			PortRange:        params.FromNetworkPortRange(rule.PortRange),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		})
+	}
+	var results params.ErrorResults
+	args := params.MachineEgressRulesArgs{Args: []params.MachineEgressRules{arg}}
+	err := m.st.facade.FacadeCall("SetMachineEgressRules", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher/watchertest"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
 
@@ -152,3 +153,14 @@ func (s *machineSuite) TestIsManual(c *gc.C) {
 	c.Assert(answer, jc.IsTrue)
 
 }
+
+func (s *machineSuite) TestSetEgressRules(c *gc.C) {
+	rules := []jujunetwork.EgressRule{jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24")}
+	err := s.apiMachine.SetEgressRules(true, rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machines[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[0].EgressRestricted(), jc.IsTrue)
+	c.Assert(s.machines[0].EgressRules(), jc.DeepEquals, rules)
+}
@@ -4,8 +4,12 @@
 package highavailability
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -52,3 +56,71 @@ func (c *Client) EnableHA(
 	}
 	return result.Result, nil
 }
+
+// ControllerHealth reports the health of each controller machine as a
+// member of the mongo replica set and the raft cluster, and whether
+// its API server can be reached.
+func (c *Client) ControllerHealth() (params.ControllerMembersHealth, error) {
+	var result params.ControllerMembersHealth
+	if c.BestAPIVersion() < 3 {
+		return result, errors.NotSupportedf("reporting controller health by this controller")
+	}
+	err := c.facade.FacadeCall("ControllerHealth", nil, &result)
+	return result, errors.Trace(err)
+}
+
+// DemoteControllers removes the votes of the controller machines with
+// the given ids from the replica set.
+func (c *Client) DemoteControllers(ids []string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("demoting controllers by this controller")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(ids))}
+	for i, id := range ids {
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DemoteControllers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return controllerErrors(ids, results)
+}
+
+// RemoveControllers removes the controller machines with the given
+// ids. If force is true, the machines are removed even if their agents
+// are not running.
+func (c *Client) RemoveControllers(ids []string, force bool) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("removing controllers by this controller")
+	}
+	args := params.RemoveControllersArgs{
+		Machines: make([]string, len(ids)),
+		Force:    force,
+	}
+	for i, id := range ids {
+		args.Machines[i] = names.NewMachineTag(id).String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveControllers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return controllerErrors(ids, results)
+}
+
+// controllerErrors combines the errors in the results of an operation
+// on the controller machines with the given ids into a single error.
+func controllerErrors(ids []string, results params.ErrorResults) error {
+	if len(results.Results) != len(ids) {
+		return errors.Errorf("expected %d results, got %d", len(ids), len(results.Results))
+	}
+	var messages []string
+	for i, result := range results.Results {
+		if result.Error != nil {
+			messages = append(messages, fmt.Sprintf("controller %s: %v", ids[i], result.Error))
+		}
+	}
+	if len(messages) > 0 {
+		return errors.New(strings.Join(messages, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type membersSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&membersSuite{})
+
+func newClient(c *gc.C, version int, request string, check func(arg interface{}), result interface{}) *highavailability.Client {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, v int, id, req string, arg, res interface{}) error {
+			c.Check(objType, gc.Equals, "HighAvailability")
+			c.Check(v, gc.Equals, version)
+			c.Check(req, gc.Equals, request)
+			check(arg)
+			switch r := res.(type) {
+			case *params.ControllerMembersHealth:
+				*r = result.(params.ControllerMembersHealth)
+			case *params.ErrorResults:
+				*r = result.(params.ErrorResults)
+			}
+			return nil
+		}),
+		BestVersion: version,
+	}
+	return highavailability.NewClient(apiCaller)
+}
+
+func (s *membersSuite) TestControllerHealth(c *gc.C) {
+	expected := params.ControllerMembersHealth{
+		Members: []params.ControllerMemberHealth{{Tag: "machine-0", MongoState: "PRIMARY"}},
+	}
+	client := newClient(c, 3, "ControllerHealth", func(arg interface{}) {
+		c.Check(arg, gc.IsNil)
+	}, expected)
+	result, err := client.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *membersSuite) TestDemoteControllers(c *gc.C) {
+	client := newClient(c, 3, "DemoteControllers", func(arg interface{}) {
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-2"}},
+		})
+	}, params.ErrorResults{Results: []params.ErrorResult{{}, {
+		Error: &params.Error{Message: "not a majority"},
+	}}})
+	err := client.DemoteControllers([]string{"1", "2"})
+	c.Assert(err, gc.ErrorMatches, "controller 2: not a majority")
+}
+
+func (s *membersSuite) TestRemoveControllers(c *gc.C) {
+	client := newClient(c, 3, "RemoveControllers", func(arg interface{}) {
+		c.Check(arg, jc.DeepEquals, params.RemoveControllersArgs{
+			Machines: []string{"machine-1"},
+			Force:    true,
+		})
+	}, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	err := client.RemoveControllers([]string{"1"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *membersSuite) TestNotSupported(c *gc.C) {
+	client := newClient(c, 2, "", func(interface{}) {
+		c.Fatalf("unexpected call")
+	}, nil)
+	_, err := client.ControllerHealth()
+	c.Check(err, gc.ErrorMatches, "reporting controller health by this controller not supported")
+	err = client.DemoteControllers([]string{"1"})
+	c.Check(err, gc.ErrorMatches, "demoting controllers by this controller not supported")
+	err = client.RemoveControllers([]string{"1"}, false)
+	c.Check(err, gc.ErrorMatches, "removing controllers by this controller not supported")
+}
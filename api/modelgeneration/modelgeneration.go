@@ -69,6 +69,25 @@ func (c *Client) CommitBranch(branchName string) (int, error) {
 	return result.Result, nil
 }
 
+// PreviewCommitBranch reports what committing the branch with the input
+// name would do, without changing the model: which units would see which
+// configuration changes and the hooks that would run as a result, and
+// any changed settings that are invalid for the current charm.
+func (c *Client) PreviewCommitBranch(branchName string) ([]model.CommitPreviewApplication, error) {
+	if c.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("previewing branch commits by this controller")
+	}
+	var result params.CommitPreviewResult
+	err := c.facade.FacadeCall("PreviewCommit", argForBranch(branchName), &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return commitPreviewFromResult(result), nil
+}
+
 // ListCommits returns the details of all committed model branches.
 func (c *Client) ListCommits() (model.GenerationCommits, error) {
 	var result params.BranchResults
@@ -202,6 +221,26 @@ func generationInfoFromResult(
 	return summaries
 }
 
+func commitPreviewFromResult(result params.CommitPreviewResult) []model.CommitPreviewApplication {
+	apps := make([]model.CommitPreviewApplication, len(result.Applications))
+	for i, a := range result.Applications {
+		units := make([]model.CommitPreviewUnit, len(a.Units))
+		for j, u := range a.Units {
+			units[j] = model.CommitPreviewUnit{
+				UnitName:      u.UnitName,
+				ConfigChanges: u.ConfigChanges,
+				Hooks:         u.Hooks,
+			}
+		}
+		apps[i] = model.CommitPreviewApplication{
+			ApplicationName:   a.ApplicationName,
+			InvalidConfigKeys: a.InvalidConfigKeys,
+			Units:             units,
+		}
+	}
+	return apps
+}
+
 func generationCommitsFromResults(results params.BranchResults) model.GenerationCommits {
 	commits := make(model.GenerationCommits, len(results.Generations))
 	for i, gen := range results.Generations {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -111,6 +112,47 @@ func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	c.Check(newGenID, gc.Equals, 2)
 }
 
+func (s *modelGenerationSuite) TestPreviewCommitBranch(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.CommitPreviewResult{Applications: []params.CommitPreviewApplication{{
+		ApplicationName:   "redis",
+		InvalidConfigKeys: []string{"no-such-key"},
+		Units: []params.CommitPreviewUnit{{
+			UnitName:      "redis/1",
+			ConfigChanges: map[string]interface{}{"port": 8000},
+			Hooks:         []string{"config-changed"},
+		}},
+	}}}
+	arg := params.BranchArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+	s.fCaller.EXPECT().FacadeCall("PreviewCommit", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	preview, err := api.PreviewCommitBranch(s.branchName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(preview, jc.DeepEquals, []model.CommitPreviewApplication{{
+		ApplicationName:   "redis",
+		InvalidConfigKeys: []string{"no-such-key"},
+		Units: []model.CommitPreviewUnit{{
+			UnitName:      "redis/1",
+			ConfigChanges: map[string]interface{}{"port": 8000},
+			Hooks:         []string{"config-changed"},
+		}},
+	}})
+}
+
+func (s *modelGenerationSuite) TestPreviewCommitBranchNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.fCaller.EXPECT().BestAPIVersion().Return(4)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	_, err := api.PreviewCommitBranch(s.branchName)
+	c.Assert(err, gc.ErrorMatches, "previewing branch commits by this controller not supported")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -603,3 +603,25 @@ func (c *Client) ChangeModelCredential(model names.ModelTag, credential names.Cl
 	}
 	return out.OneError()
 }
+
+// SetModelQuotas sets the given resource limits on a model and removes
+// the limits named in unset.
+func (c *Client) SetModelQuotas(model names.ModelTag, set map[string]uint64, unset []string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 9 {
+		return errors.NotImplementedf("SetModelQuotas in version %v", bestVer)
+	}
+
+	var out params.ErrorResults
+	in := params.SetModelQuotasArgs{
+		Models: []params.SetModelQuotas{{
+			ModelTag: model.String(),
+			Set:      set,
+			Unset:    unset,
+		}},
+	}
+	err := c.facade.FacadeCall("SetModelQuotas", in, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
@@ -639,6 +639,47 @@ func (s *modelmanagerSuite) TestChangeModelCredential(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestSetModelQuotas(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "SetModelQuotas")
+			c.Check(arg, jc.DeepEquals, params.SetModelQuotasArgs{
+				Models: []params.SetModelQuotas{{
+					ModelTag: coretesting.ModelTag.String(),
+					Set:      map[string]uint64{"units": 20},
+					Unset:    []string{"machines"},
+				}},
+			})
+			called = true
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelQuotas(coretesting.ModelTag, map[string]uint64{"units": 20}, []string{"machines"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestSetModelQuotasNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelQuotas(coretesting.ModelTag, map[string]uint64{"units": 20}, nil)
+	c.Assert(err, gc.ErrorMatches, "SetModelQuotas in version 8 not implemented")
+}
+
 func (s *modelmanagerSuite) TestChangeModelCredentialManyResults(c *gc.C) {
 	credentialTag := names.NewCloudCredentialTag("foo/bob/bar")
 	called := false
@@ -325,6 +325,103 @@ func (c *Client) Detach(storageIds []string, force *bool, maxWait *time.Duration
 	return results.Results, nil
 }
 
+// Resize requests that the volume or filesystem of the specified
+// storage entity be grown to the given size in MiB.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("resizing storage")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.ResizeStorage{[]params.ResizeStorageInstance{{
+		Tag:  names.NewStorageTag(storageId).String(),
+		Size: size,
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CancelResize cancels any pending resize of the volume or filesystem
+// of the specified storage entity.
+func (c *Client) CancelResize(storageId string) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("cancelling storage resizes")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.Entities{[]params.Entity{{
+		Tag: names.NewStorageTag(storageId).String(),
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CancelStorageResize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CreateSnapshots requests point-in-time snapshots of the volumes of
+// the specified storage instances, returning the ID of each snapshot.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.StringResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("storage snapshots")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(storageIds))}
+	for i, storageId := range storageIds {
+		if !names.IsValidStorage(storageId) {
+			return nil, errors.NotValidf("storage ID %q", storageId)
+		}
+		args.Entities[i].Tag = names.NewStorageTag(storageId).String()
+	}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("CreateStorageSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in the
+// model.
+func (c *Client) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("storage snapshots")
+	}
+	var result params.StorageSnapshotDetailsList
+	if err := c.facade.FacadeCall("ListStorageSnapshots", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Snapshots, nil
+}
+
+// RemoveSnapshots destroys the volume snapshots with the specified IDs.
+func (c *Client) RemoveSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("storage snapshots")
+	}
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveStorageSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(ids), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
 // Import imports storage into the model.
 func (c *Client) Import(
 	kind storage.StorageKind,
@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -854,6 +855,233 @@ func (s *storageMockSuite) TestDetachArityMismatch(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `expected 2 result\(s\), got 3`)
 }
 
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ResizeStorage")
+				c.Check(a, jc.DeepEquals, params.ResizeStorage{[]params.ResizeStorageInstance{{
+					Tag:  "storage-foo-0",
+					Size: 2048,
+				}}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestCancelResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CancelStorageResize")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.CancelResize("foo/0")
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestCancelResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.CancelResize("foo/0")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateStorageSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+					{Tag: "storage-bar-1"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+				results := result.(*params.StringResults)
+				results.Results = []params.StringResult{
+					{Result: "0"},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"foo/0", "bar/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(results, jc.DeepEquals, []params.StringResult{
+		{Result: "0"},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsInvalidStorageId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo"})
+	c.Check(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	created := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListStorageSnapshots")
+				c.Check(a, gc.IsNil)
+				c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotDetailsList{})
+				results := result.(*params.StorageSnapshotDetailsList)
+				results.Snapshots = []params.StorageSnapshotDetails{{
+					Id:         "0",
+					VolumeTag:  "volume-0",
+					SnapshotId: "snap-0",
+					Created:    created,
+				}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	snapshots, err := client.ListSnapshots()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(snapshots, jc.DeepEquals, []params.StorageSnapshotDetails{{
+		Id:         "0",
+		VolumeTag:  "volume-0",
+		SnapshotId: "snap-0",
+		Created:    created,
+	}})
+}
+
+func (s *storageMockSuite) TestRemoveSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemoveStorageSnapshots")
+				c.Check(a, jc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"0", "1/2"}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.RemoveSnapshots([]string{"0", "1/2"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo/0"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ListSnapshots()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.RemoveSnapshots([]string{"0"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *storageMockSuite) TestAttach(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -484,6 +484,211 @@ func (s *provisionerSuite) TestVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 5)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchVolumeResizes")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-123"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		},
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestWatchFilesystemResizesNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchFilesystemResizes(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "resizing filesystems not supported")
+}
+
+func (s *provisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ResizeVolumeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ResizeVolumeParamsResults{})
+		*(result.(*params.ResizeVolumeParamsResults)) = params.ResizeVolumeParamsResults{
+			Results: []params.ResizeVolumeParamsResult{{
+				Result: params.ResizeVolumeParams{
+					Provider: "foo",
+					VolumeId: "bar",
+					Size:     2048,
+				},
+			}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeParams, err := st.ResizeVolumeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(volumeParams, jc.DeepEquals, []params.ResizeVolumeParamsResult{{
+		Result: params.ResizeVolumeParams{
+			Provider: "foo",
+			VolumeId: "bar",
+			Size:     2048,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 6)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchVolumeSnapshots")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-123"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		},
+		BestVersion: 6,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeSnapshots(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestWatchVolumeSnapshotsNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeSnapshots(names.NewMachineTag("123"))
+	c.Check(err, gc.ErrorMatches, "volume snapshots not supported")
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotParams")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"100"}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotParamsResults{})
+		*(result.(*params.VolumeSnapshotParamsResults)) = params.VolumeSnapshotParamsResults{
+			Results: []params.VolumeSnapshotParamsResult{{
+				Result: params.VolumeSnapshotParams{
+					Id:        "100",
+					Life:      "alive",
+					VolumeTag: "volume-1",
+					VolumeId:  "bar",
+					Provider:  "foo",
+				},
+			}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotParams, err := st.VolumeSnapshotParams([]string{"100"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(snapshotParams, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{
+		Result: params.VolumeSnapshotParams{
+			Id:        "100",
+			Life:      "alive",
+			VolumeTag: "volume-1",
+			VolumeId:  "bar",
+			Provider:  "foo",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	snapshots := []params.VolumeSnapshotInfo{{
+		Id:         "100",
+		SnapshotId: "snap-100",
+		Size:       1024,
+	}}
+
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSnapshotInfo")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotInfos{Snapshots: snapshots})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: nil}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSnapshotInfo(snapshots)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.IsNil)
+}
+
+func (s *provisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RemoveVolumeSnapshots")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"100", "101"}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: nil}},
+		}
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.RemoveVolumeSnapshots([]string{"100", "101"})
+	c.Check(err, gc.ErrorMatches, "expected 2 result\\(s\\), got 1")
+}
+
 func (s *provisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, "StorageProvisioner")
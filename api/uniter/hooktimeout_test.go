@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type hookTimeoutSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&hookTimeoutSuite{})
+
+func (s *hookTimeoutSuite) TestHookTimeoutPolicy(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Assert(objType, gc.Equals, "Uniter")
+			c.Assert(version, gc.Equals, 16)
+			c.Assert(id, gc.Equals, "")
+			c.Assert(request, gc.Equals, "HookTimeoutPolicy")
+			c.Assert(arg, gc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.HookTimeoutPolicyResults{})
+			*(result.(*params.HookTimeoutPolicyResults)) = params.HookTimeoutPolicyResults{
+				Results: []params.HookTimeoutPolicyResult{{
+					Result: &params.HookTimeoutPolicy{
+						Default:         time.Minute,
+						ByKind:          map[string]time.Duration{"install": time.Hour},
+						KillGracePeriod: time.Second,
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 16,
+	}
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	policy, err := st.HookTimeoutPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookTimeoutPolicy{
+		Default:         time.Minute,
+		ByKind:          map[hooks.Kind]time.Duration{hooks.Install: time.Hour},
+		KillGracePeriod: time.Second,
+	})
+}
+
+func (s *hookTimeoutSuite) TestHookTimeoutPolicyError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.HookTimeoutPolicyResults)) = params.HookTimeoutPolicyResults{
+				Results: []params.HookTimeoutPolicyResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 16,
+	}
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	_, err := st.HookTimeoutPolicy()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *hookTimeoutSuite) TestHookTimeoutPolicyOldFacadeVersion(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fail()
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	policy, err := st.HookTimeoutPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookTimeoutPolicy{})
+}
@@ -19,11 +19,12 @@ import (
 
 // Unit represents a juju unit as seen by a uniter worker.
 type Unit struct {
-	st           *State
-	tag          names.UnitTag
-	life         life.Value
-	resolvedMode params.ResolvedMode
-	providerID   string
+	st              *State
+	tag             names.UnitTag
+	life            life.Value
+	resolvedMode    params.ResolvedMode
+	providerID      string
+	maintenanceMode bool
 }
 
 // Tag returns the unit's tag.
@@ -56,6 +57,12 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// MaintenanceMode returns whether the unit is in maintenance mode,
+// in which case hooks should not be run.
+func (u *Unit) MaintenanceMode() bool {
+	return u.maintenanceMode
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -79,6 +86,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.providerID = result.ProviderID
+	u.maintenanceMode = result.MaintenanceMode
 	return nil
 }
 
@@ -888,3 +896,23 @@ func (u *Unit) SetState(unitState map[string]string) error {
 	}
 	return nil
 }
+
+// PublishCharmMetrics sends the charm metrics published by the unit
+// to the controller, for export to its Prometheus endpoint.
+func (u *Unit) PublishCharmMetrics(metrics []params.CharmMetric) error {
+	if u.st.BestAPIVersion() < 17 {
+		return errors.NotImplementedf("PublishCharmMetrics() (need V17+)")
+	}
+	var results params.ErrorResults
+	args := params.PublishCharmMetricsArgs{
+		Args: []params.PublishCharmMetricsArg{{
+			Tag:     u.tag.String(),
+			Metrics: metrics,
+		}},
+	}
+	err := u.st.facade.FacadeCall("PublishCharmMetrics", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
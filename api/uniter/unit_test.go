@@ -260,6 +260,18 @@ func (s *unitSuite) TestRefreshResolve(c *gc.C) {
 	c.Assert(mode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *unitSuite) TestRefreshMaintenanceMode(c *gc.C) {
+	c.Assert(s.apiUnit.MaintenanceMode(), jc.IsFalse)
+
+	err := s.wordpressUnit.SetMaintenanceMode(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.MaintenanceMode(), jc.IsFalse)
+
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.MaintenanceMode(), jc.IsTrue)
+}
+
 func (s *unitSuite) TestRefreshUpdateProviderID(c *gc.C) {
 	c.Assert(s.apiUnit.ProviderID(), gc.Equals, "")
 
@@ -1016,6 +1028,26 @@ func (s *unitSuite) TestUpgradeSeriesStatusSingleResult(c *gc.C) {
 	c.Check(sts, gc.Equals, model.UpgradeSeriesCompleted)
 }
 
+func (s *unitSuite) TestPublishCharmMetrics(c *gc.C) {
+	err := s.apiUnit.PublishCharmMetrics([]params.CharmMetric{
+		{Name: "requests", Type: "counter", Help: "Requests served", Value: 2},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	metrics, err := s.wordpressUnit.CharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metrics.Metrics, jc.DeepEquals, []state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricCounter, Help: "Requests served", Value: 2},
+	})
+}
+
+func (s *unitSuite) TestPublishCharmMetricsResultError(c *gc.C) {
+	err := s.apiUnit.PublishCharmMetrics([]params.CharmMetric{
+		{Name: "requests", Type: "summary", Value: 2},
+	})
+	c.Assert(err, gc.ErrorMatches, `charm metric type "summary" not valid`)
+}
+
 func (s *unitSuite) TestSetStateSingleResult(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "SetState",
 		func(results interface{}) error {
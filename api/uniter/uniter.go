@@ -5,9 +5,11 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/api/base"
@@ -517,6 +519,41 @@ func goalStateFromParams(paramsGoalState *params.GoalState) application.GoalStat
 	return goalState
 }
 
+// HookTimeoutPolicy returns the hook timeout policy of the
+// application of the authenticated unit. Controllers that predate
+// hook timeouts yield a policy under which hooks may run forever.
+func (st *State) HookTimeoutPolicy() (application.HookTimeoutPolicy, error) {
+	if st.BestAPIVersion() < 16 {
+		return application.HookTimeoutPolicy{}, nil
+	}
+	var results params.HookTimeoutPolicyResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.unitTag.String()}},
+	}
+	err := st.facade.FacadeCall("HookTimeoutPolicy", args, &results)
+	if err != nil {
+		return application.HookTimeoutPolicy{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return application.HookTimeoutPolicy{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return application.HookTimeoutPolicy{}, errors.Trace(result.Error)
+	}
+	policy := application.HookTimeoutPolicy{
+		Default:         result.Result.Default,
+		KillGracePeriod: result.Result.KillGracePeriod,
+	}
+	if len(result.Result.ByKind) > 0 {
+		policy.ByKind = make(map[hooks.Kind]time.Duration)
+		for kind, timeout := range result.Result.ByKind {
+			policy.ByKind[hooks.Kind(kind)] = timeout
+		}
+	}
+	return policy, nil
+}
+
 // SetPodSpec sets the pod spec of the specified application.
 func (st *State) SetPodSpec(appName string, spec string) error {
 	if !names.IsValidApplication(appName) {
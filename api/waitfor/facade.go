@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the WaitFor API facade.
+type Facade struct {
+	base.ClientFacade
+	caller base.FacadeCaller
+}
+
+// NewFacade returns a new Facade based on an existing API connection.
+func NewFacade(callCloser base.APICallCloser) *Facade {
+	clientFacade, caller := base.NewClientFacade(callCloser, "WaitFor")
+	return &Facade{
+		ClientFacade: clientFacade,
+		caller:       caller,
+	}
+}
+
+// Result describes the outcome of a WaitFor call.
+type Result struct {
+	// Satisfied is true if the query matched before the call
+	// timed out.
+	Satisfied bool
+
+	// Values holds the entity's field values when the call
+	// returned, keyed by field name.
+	Values map[string][]string
+}
+
+// WaitFor blocks until the entity of the given kind and name satisfies
+// the query, or the timeout expires. The controller caps the time a
+// single call may block, so callers waiting longer should call again
+// while Satisfied is false.
+func (f *Facade) WaitFor(kind, name, query string, timeout time.Duration) (Result, error) {
+	args := params.WaitForArgs{
+		Kind:    kind,
+		Name:    name,
+		Query:   query,
+		Timeout: timeout,
+	}
+	var out params.WaitForResult
+	if err := f.caller.FacadeCall("WaitFor", args, &out); err != nil {
+		return Result{}, errors.Trace(err)
+	}
+	return Result{
+		Satisfied: out.Satisfied,
+		Values:    out.Values,
+	}, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/waitfor"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type FacadeSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) TestWaitFor(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		*result.(*params.WaitForResult) = params.WaitForResult{
+			Satisfied: true,
+			Values:    map[string][]string{"status": {"active"}},
+		}
+		return nil
+	})
+
+	facade := waitfor.NewFacade(apiCaller)
+	result, err := facade.WaitFor("application", "mysql", "status == active", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, waitfor.Result{
+		Satisfied: true,
+		Values:    map[string][]string{"status": {"active"}},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{{"WaitFor.WaitFor", []interface{}{params.WaitForArgs{
+		Kind:    "application",
+		Name:    "mysql",
+		Query:   "status == active",
+		Timeout: time.Minute,
+	}}}})
+}
+
+func (s *FacadeSuite) TestWaitForError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+
+	facade := waitfor.NewFacade(apiCaller)
+	_, err := facade.WaitFor("model", "", "status == available", time.Minute)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
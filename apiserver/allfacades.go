@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/credentialvalidator"
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/facades/agent/diskmanager"
+	"github.com/juju/juju/apiserver/facades/agent/egressfirewaller"
 	"github.com/juju/juju/apiserver/facades/agent/fanconfigurer"
 	"github.com/juju/juju/apiserver/facades/agent/hostkeyreporter"
 	"github.com/juju/juju/apiserver/facades/agent/instancemutater"
@@ -71,6 +72,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	"github.com/juju/juju/apiserver/facades/client/waitfor"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
@@ -154,12 +156,16 @@ func AllFacades() *facade.Registry {
 	reg("Application", 9, application.NewFacadeV9)   // ApplicationInfo; generational config; Force on App, Relation and Unit Removal.
 	reg("Application", 10, application.NewFacadeV10) // --force and --no-wait parameters
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
+	reg("Application", 12, application.NewFacadeV12) // Adds TransferLeadership
+	reg("Application", 13, application.NewFacadeV13) // Adds SetUnitsMaintenanceMode
+	reg("Application", 14, application.NewFacadeV14) // Adds egress rules
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
+	reg("Backups", 3, backups.NewFacadeV3)
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacadeV1)
 	reg("Bundle", 2, bundle.NewFacadeV2)
@@ -169,7 +175,8 @@ func AllFacades() *facade.Registry {
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
-	reg("Client", 2, client.NewFacade)
+	reg("Client", 2, client.NewFacadeV2)
+	reg("Client", 3, client.NewFacade)
 	reg("Cloud", 1, cloud.NewFacadeV1)
 	reg("Cloud", 2, cloud.NewFacadeV2) // adds AddCloud, AddCredentials, CredentialContents, RemoveClouds
 	reg("Cloud", 3, cloud.NewFacadeV3) // changes signature of UpdateCredentials, adds ModifyCloudAccess
@@ -192,6 +199,8 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 6, controller.NewControllerAPIv6)
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
+	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Controller", 10, controller.NewControllerAPIv10) // adds BackupStatus
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
 	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
@@ -202,12 +211,15 @@ func AllFacades() *facade.Registry {
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("EgressFirewaller", 1, egressfirewaller.NewStateEgressFirewallerAPI)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6)
 	reg("FirewallRules", 1, firewallrules.NewFacade)
-	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
+	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPIV2)
+	reg("HighAvailability", 3, highavailability.NewFacadeV3)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
@@ -256,6 +268,7 @@ func AllFacades() *facade.Registry {
 	reg("ModelGeneration", 2, modelgeneration.NewModelGenerationFacadeV2)
 	reg("ModelGeneration", 3, modelgeneration.NewModelGenerationFacadeV3)
 	reg("ModelGeneration", 4, modelgeneration.NewModelGenerationFacadeV4)
+	reg("ModelGeneration", 5, modelgeneration.NewModelGenerationFacadeV5)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
@@ -263,6 +276,7 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 6, modelmanager.NewFacadeV6) // adds cloud specific default config
 	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
 	reg("ModelManager", 8, modelmanager.NewFacadeV8) // ModelInfo gains credential validity in return.
+	reg("ModelManager", 9, modelmanager.NewFacadeV9) // adds SetModelQuotas, ModelInfo gains quotas.
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -309,10 +323,14 @@ func AllFacades() *facade.Registry {
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
 	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
-	reg("Storage", 6, storage.NewStorageAPI)   // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 6, storage.NewStorageAPIV6) // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 7, storage.NewStorageAPIV7) // Adds ResizeStorage.
+	reg("Storage", 8, storage.NewStorageAPI)   // Adds storage snapshots.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5) // Adds resize watchers and params.
+	reg("StorageProvisioner", 6, storageprovisioner.NewFacadeV6) // Adds volume snapshots.
 	reg("Subnets", 2, subnets.NewAPIv2)
 	reg("Subnets", 3, subnets.NewAPI)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
@@ -329,13 +347,16 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 12, uniter.NewUniterAPIV12)
 	reg("Uniter", 13, uniter.NewUniterAPIV13)
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
-	reg("Uniter", 15, uniter.NewUniterAPI)
+	reg("Uniter", 15, uniter.NewUniterAPIV15)
+	reg("Uniter", 16, uniter.NewUniterAPIV16)
+	reg("Uniter", 17, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
 	reg("UpgradeSteps", 1, upgradesteps.NewFacadeV1)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
+	reg("WaitFor", 1, waitfor.NewFacade)
 
 	regRaw("AllWatcher", 1, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
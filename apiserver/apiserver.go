@@ -84,6 +84,7 @@ type Server struct {
 	restoreStatus          func() state.RestoreStatus
 	mux                    *apiserverhttp.Mux
 	metricsCollector       *Collector
+	debugShells            *debugShellBroker
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -281,6 +282,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		leaseManager:        cfg.LeaseManager,
 		controllerConfig:    controllerConfig,
 		logger:              loggo.GetLogger("juju.apiserver"),
+		logDir:              cfg.LogDir,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -364,11 +366,17 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
 
+	srv.debugShells, err = newDebugShellBroker(cfg.Hub, cfg.Tag.String(), srv.tomb.Dying())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	ready := make(chan struct{})
 	srv.tomb.Go(func() error {
 		defer srv.dbloggers.dispose()
 		defer srv.logSinkWriter.Close()
 		defer srv.shared.Close()
+		defer srv.debugShells.unsubscribe()
 		defer unsubscribe()
 		defer unsubscribeControllerConfig()
 		return srv.loop(ready)
@@ -587,6 +595,8 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.ControllerAgentTagKind, names.UserTagKind, names.ApplicationTagKind})
 	pubsubHandler := newPubSubHandler(httpCtxt, srv.shared.centralHub)
+	debugShellHandler := &debugShellHandler{ctxt: httpCtxt, broker: srv.debugShells}
+	debugShellAgentHandler := &debugShellAgentHandler{ctxt: httpCtxt, broker: srv.debugShells}
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
 		httpCtxt.stop(),
@@ -719,6 +729,14 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		// The authentication is handled within the debugLogHandler in order
 		// for discharge required errors to be handled correctly.
 		unauthenticated: true,
+	}, {
+		pattern: modelRoutePrefix + "/debug-shell",
+		handler: debugShellHandler,
+		tracked: true,
+	}, {
+		pattern: modelRoutePrefix + "/debug-shell/agent",
+		handler: debugShellAgentHandler,
+		tracked: true,
 	}, {
 		pattern:    modelRoutePrefix + "/logsink",
 		handler:    logSinkHandler,
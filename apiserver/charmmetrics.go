@@ -0,0 +1,230 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+const charmMetricsSubsystemNamespace = "charm"
+
+// CharmMetricLabelNames defines the labels of the metrics published
+// by charms.
+var CharmMetricLabelNames = []string{
+	MetricLabelModelUUID,
+	"model",
+	"application",
+	"unit",
+}
+
+// DefaultCharmMetricsRefreshInterval is how often the controller reads
+// the metrics published by charms, to be served on its Prometheus
+// endpoint.
+const DefaultCharmMetricsRefreshInterval = 30 * time.Second
+
+// CharmMetricsCollector is a prometheus.Collector that collects the
+// metrics published by the units of every model in the controller.
+//
+// The metrics are not known in advance, so the collector describes
+// none of them, making it an unchecked collector. Unchecked collectors
+// can't be unregistered, so the collector is registered once for the
+// lifetime of the agent, and serves the metrics of whichever
+// CharmMetricsWorker is set as its source.
+type CharmMetricsCollector struct {
+	mu     sync.RWMutex
+	source *CharmMetricsWorker
+}
+
+// NewCharmMetricsCollector returns a new CharmMetricsCollector with no
+// source, which collects no metrics.
+func NewCharmMetricsCollector() *CharmMetricsCollector {
+	return &CharmMetricsCollector{}
+}
+
+// SetSource sets the worker whose metrics are collected, replacing any
+// previous source; a nil source stops collection. SetSource waits for
+// collections from the previous source to finish, so the previous
+// source may be stopped once it returns.
+func (c *CharmMetricsCollector) SetSource(source *CharmMetricsWorker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.source = source
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *CharmMetricsCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *CharmMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.source == nil {
+		return
+	}
+	for _, metric := range c.source.metrics() {
+		ch <- metric
+	}
+}
+
+// CharmMetricsWorker reads the metrics published by the units of every
+// model in the controller. Reading the metrics of every model is too
+// expensive to do for each scrape, so the worker refreshes a snapshot
+// of the metrics in the background that scrapes are served from.
+type CharmMetricsWorker struct {
+	tomb     tomb.Tomb
+	pool     *state.StatePool
+	clock    clock.Clock
+	interval time.Duration
+
+	mu       sync.Mutex
+	snapshot []prometheus.Metric
+	taken    bool
+}
+
+// NewCharmMetricsWorker returns a new CharmMetricsWorker reading from
+// the models in the given pool every interval. The first snapshot is
+// taken by the first scrape.
+func NewCharmMetricsWorker(pool *state.StatePool, clock clock.Clock, interval time.Duration) *CharmMetricsWorker {
+	w := &CharmMetricsWorker{
+		pool:     pool,
+		clock:    clock,
+		interval: interval,
+	}
+	w.tomb.Go(w.loop)
+	return w
+}
+
+func (w *CharmMetricsWorker) loop() error {
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.clock.After(w.interval):
+			w.refresh()
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *CharmMetricsWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *CharmMetricsWorker) Wait() error {
+	return w.tomb.Wait()
+}
+
+// metrics returns the current snapshot, taking it if it hasn't been
+// taken yet. Nothing is read once the worker is stopping, as the state
+// pool may already have been released.
+func (w *CharmMetricsWorker) metrics() []prometheus.Metric {
+	w.mu.Lock()
+	snapshot, taken := w.snapshot, w.taken
+	w.mu.Unlock()
+	if taken {
+		return snapshot
+	}
+	select {
+	case <-w.tomb.Dying():
+		return nil
+	default:
+	}
+	return w.refresh()
+}
+
+// refresh replaces the snapshot with the metrics currently published,
+// and returns it. Models whose metrics can't be read are left out until
+// the next refresh.
+func (w *CharmMetricsWorker) refresh() []prometheus.Metric {
+	modelUUIDs, err := w.pool.SystemState().AllModelUUIDs()
+	if err != nil {
+		logger.Errorf("cannot list models for charm metrics: %v", err)
+		return nil
+	}
+	// Metrics with the same name must have the same type and help
+	// text; the first published is taken to define them.
+	var snapshot []prometheus.Metric
+	descs := make(map[string]*prometheus.Desc)
+	valueTypes := make(map[string]prometheus.ValueType)
+	for _, modelUUID := range modelUUIDs {
+		metrics, err := w.collectModel(modelUUID, descs, valueTypes)
+		if err != nil {
+			logger.Errorf("cannot collect charm metrics for model %q: %v", modelUUID, err)
+			continue
+		}
+		snapshot = append(snapshot, metrics...)
+	}
+	w.mu.Lock()
+	w.snapshot = snapshot
+	w.taken = true
+	w.mu.Unlock()
+	return snapshot
+}
+
+func (w *CharmMetricsWorker) collectModel(
+	modelUUID string,
+	descs map[string]*prometheus.Desc,
+	valueTypes map[string]prometheus.ValueType,
+) ([]prometheus.Metric, error) {
+	st, err := w.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	allMetrics, err := st.AllCharmMetrics()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var metrics []prometheus.Metric
+	for _, unitMetrics := range allMetrics {
+		for _, m := range unitMetrics.Metrics {
+			valueType := prometheus.GaugeValue
+			if m.Type == state.CharmMetricCounter {
+				valueType = prometheus.CounterValue
+			}
+			desc, ok := descs[m.Name]
+			if !ok {
+				help := m.Help
+				if help == "" {
+					help = "Charm metric " + m.Name + "."
+				}
+				desc = prometheus.NewDesc(
+					prometheus.BuildFQName(apiserverMetricsNamespace, charmMetricsSubsystemNamespace, m.Name),
+					help,
+					CharmMetricLabelNames,
+					nil,
+				)
+				descs[m.Name] = desc
+				valueTypes[m.Name] = valueType
+			} else if valueTypes[m.Name] != valueType {
+				logger.Debugf("skipping %s metric %q of unit %q: type differs from other units", m.Type, m.Name, unitMetrics.Unit)
+				continue
+			}
+			metric, err := prometheus.NewConstMetric(
+				desc, valueType, m.Value,
+				modelUUID, model.Name(), unitMetrics.Application, unitMetrics.Unit,
+			)
+			if err != nil {
+				logger.Debugf("skipping metric %q of unit %q: %v", m.Name, unitMetrics.Unit, err)
+				continue
+			}
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type charmMetricsSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&charmMetricsSuite{})
+
+type charmMetricSample struct {
+	desc   string
+	labels map[string]string
+	value  float64
+}
+
+func collectCharmMetrics(c *gc.C, collector *apiserver.CharmMetricsCollector) []charmMetricSample {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		collector.Collect(ch)
+	}()
+	var samples []charmMetricSample
+	for metric := range ch {
+		var m dto.Metric
+		err := metric.Write(&m)
+		c.Assert(err, jc.ErrorIsNil)
+		labels := make(map[string]string)
+		for _, pair := range m.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		value := m.GetGauge().GetValue()
+		if m.Counter != nil {
+			value = m.GetCounter().GetValue()
+		}
+		samples = append(samples, charmMetricSample{metric.Desc().String(), labels, value})
+	}
+	return samples
+}
+
+func (s *charmMetricsSuite) newCollector(c *gc.C, clock *testclock.Clock) *apiserver.CharmMetricsCollector {
+	w := apiserver.NewCharmMetricsWorker(s.StatePool, clock, time.Minute)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	collector := apiserver.NewCharmMetricsCollector()
+	collector.SetSource(w)
+	return collector
+}
+
+func (s *charmMetricsSuite) TestCollect(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	err := unit0.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricCounter, Help: "Requests served.", Value: 3},
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Value: 2},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// A metric whose type differs from another unit's is skipped.
+	err = unit1.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricGauge, Value: 7},
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Value: 5},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	collector := s.newCollector(c, testclock.NewClock(time.Time{}))
+	samples := collectCharmMetrics(c, collector)
+	c.Assert(samples, gc.HasLen, 3)
+
+	labels := func(unit string) map[string]string {
+		return map[string]string{
+			"model_uuid":  s.State.ModelUUID(),
+			"model":       s.Model.Name(),
+			"application": "wordpress",
+			"unit":        unit,
+		}
+	}
+	c.Check(samples[0].desc, gc.Matches, `.*fqName: "juju_charm_queue_depth", help: "Charm metric queue_depth.".*`)
+	c.Check(samples[0].labels, jc.DeepEquals, labels(unit0.Name()))
+	c.Check(samples[0].value, gc.Equals, 2.0)
+	c.Check(samples[1].desc, gc.Matches, `.*fqName: "juju_charm_requests", help: "Requests served.".*`)
+	c.Check(samples[1].labels, jc.DeepEquals, labels(unit0.Name()))
+	c.Check(samples[1].value, gc.Equals, 3.0)
+	c.Check(samples[2].desc, gc.Matches, `.*fqName: "juju_charm_queue_depth".*`)
+	c.Check(samples[2].labels, jc.DeepEquals, labels(unit1.Name()))
+	c.Check(samples[2].value, gc.Equals, 5.0)
+}
+
+func (s *charmMetricsSuite) TestCollectRefreshesSnapshot(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Value: 2},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	clock := testclock.NewClock(time.Time{})
+	collector := s.newCollector(c, clock)
+	samples := collectCharmMetrics(c, collector)
+	c.Assert(samples, gc.HasLen, 1)
+	c.Assert(samples[0].value, gc.Equals, 2.0)
+
+	// Scrapes are served from the snapshot until it is refreshed.
+	err = unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Value: 5},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	samples = collectCharmMetrics(c, collector)
+	c.Assert(samples, gc.HasLen, 1)
+	c.Assert(samples[0].value, gc.Equals, 2.0)
+
+	err = clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		samples = collectCharmMetrics(c, collector)
+		c.Assert(samples, gc.HasLen, 1)
+		if samples[0].value == 5.0 {
+			return
+		}
+	}
+	c.Fatalf("snapshot not refreshed")
+}
+
+func (s *charmMetricsSuite) TestCollectWithoutSource(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	err := unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Value: 2},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	collector := apiserver.NewCharmMetricsCollector()
+	c.Assert(collectCharmMetrics(c, collector), gc.HasLen, 0)
+
+	w := apiserver.NewCharmMetricsWorker(s.StatePool, testclock.NewClock(time.Time{}), time.Minute)
+	defer workertest.CleanKill(c, w)
+	collector.SetSource(w)
+	c.Assert(collectCharmMetrics(c, collector), gc.HasLen, 1)
+
+	// Once the source is removed, nothing more is collected.
+	collector.SetSource(nil)
+	c.Assert(collectCharmMetrics(c, collector), gc.HasLen, 0)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
 )
@@ -285,6 +286,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeForbidden
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case model.IsQuotaExceeded(err):
+		code = params.CodeQuotaExceeded
 	case IsDischargeRequiredError(err):
 		dischErr := errors.Cause(err).(*DischargeRequiredError)
 		code = params.CodeDischargeRequired
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -151,6 +152,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeOperationBlocked,
 	status:     http.StatusBadRequest,
 	helperFunc: params.IsCodeOperationBlocked,
+}, {
+	err:        &model.QuotaExceededError{Resource: "units", Limit: 2, Current: 2, Requested: 1},
+	code:       params.CodeQuotaExceeded,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeQuotaExceeded,
 }, {
 	err:        errors.NotSupportedf("needed feature"),
 	code:       params.CodeNotSupported,
@@ -275,7 +281,8 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
 			params.CodeRetry,
-			params.CodeRedirect:
+			params.CodeRedirect,
+			params.CodeQuotaExceeded:
 			continue
 		case params.CodeOperationBlocked:
 			// ServerError doesn't actually have a case for this code.
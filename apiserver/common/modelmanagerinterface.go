@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
@@ -106,6 +107,9 @@ type Model interface {
 	AddUser(state.UserAccessSpec) (permission.UserAccess, error)
 	AutoConfigureContainerNetworking(environ environs.BootstrapEnviron) error
 	SetCloudCredential(tag names.CloudCredentialTag) (bool, error)
+	Quotas() model.Quotas
+	SetQuotas(model.Quotas) error
+	QuotaUsage() (model.Usage, error)
 }
 
 var _ ModelManagerBackend = (*modelManagerStateShim)(nil)
@@ -57,7 +57,7 @@ func (s *modelStatusSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPIv8(
+	controller, err := controller.NewControllerAPIv10(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -171,6 +171,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		volumeInfo.Size,
 	}, nil
 }
 
@@ -198,9 +199,16 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	var size uint64
+	if filesystemInfo, err := filesystem.Info(); err == nil {
+		size = filesystemInfo.Size
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		size,
 	}, nil
 }
 
@@ -56,9 +56,32 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		"",  // snapshot ID set by the caller
 	}, nil
 }
 
+// VolumeSnapshotId returns the provider ID of the snapshot from which
+// the given volume is to be created, or the empty string if the volume
+// is not being restored from a snapshot.
+func VolumeSnapshotId(
+	v state.Volume,
+	getVolumeSnapshot func(string) (state.VolumeSnapshot, error),
+) (string, error) {
+	stateVolumeParams, ok := v.Params()
+	if !ok || stateVolumeParams.Snapshot == "" {
+		return "", nil
+	}
+	snapshot, err := getVolumeSnapshot(stateVolumeParams.Snapshot)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	snapshotInfo, err := snapshot.Info()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return snapshotInfo.SnapshotId, nil
+}
+
 // StoragePoolConfig returns the storage provider type and
 // configuration for a named storage pool. If there is no
 // such pool with the specified name, but it identifies a
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/apiserver/params"
@@ -85,6 +87,16 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time, only send lines logged at or after this time
+//   endTime -> string - RFC3339 time, only send lines logged at or before this time
+//      - if the time has passed, existing logs are sent back but the command
+//      - does not wait for new ones.
+//   includeMessage -> []string - regular expressions, only send lines whose
+//      - message matches one of them
+//   excludeMessage -> []string - regular expressions, do not send lines whose
+//      - message matches any of them
+//   agentKind -> []string - only send lines from these kinds of agent
+//      - (machine, unit or application)
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -180,16 +192,20 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeMessage []string
+	excludeMessage []string
+	agentKinds     []string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -245,10 +261,41 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before start time", value)
+		}
+		params.endTime = endTime
+	}
+
+	for _, name := range []string{"includeMessage", "excludeMessage"} {
+		for _, value := range queryMap[name] {
+			if _, err := regexp.Compile(value); err != nil {
+				return params, errors.Errorf("%s value %q is not a valid regular expression: %v", name, value, err)
+			}
+		}
+	}
+
+	for _, value := range queryMap["agentKind"] {
+		switch value {
+		case names.MachineTagKind, names.UnitTagKind, names.ApplicationTagKind:
+		default:
+			return params, errors.Errorf("agentKind value %q is not one of %q, %q, %q",
+				value, names.MachineTagKind, names.UnitTagKind, names.ApplicationTagKind)
+		}
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeMessage = queryMap["includeMessage"]
+	params.excludeMessage = queryMap["excludeMessage"]
+	params.agentKinds = queryMap["agentKind"]
 
 	return params, nil
 }
@@ -32,6 +32,12 @@ func handleDebugLogDBRequest(
 	stop <-chan struct{},
 ) error {
 	params := makeLogTailerParams(reqParams)
+	if !reqParams.endTime.IsZero() && !reqParams.endTime.After(clock.Now()) {
+		// Nothing logged from now on can fall within the time range.
+		// Otherwise, the tailer stops once it sees a log written
+		// after the end time.
+		params.NoTail = true
+	}
 	tailer, err := newLogTailer(st, params)
 	if err != nil {
 		return errors.Trace(err)
@@ -69,14 +75,18 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		StartTime:      reqParams.startTime,
+		EndTime:        reqParams.endTime,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		IncludeMessage: reqParams.includeMessage,
+		ExcludeMessage: reqParams.excludeMessage,
+		AgentKinds:     reqParams.agentKinds,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -36,16 +36,21 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC)
 	reqParams := debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		startTime:     t1,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		startTime:      t1,
+		endTime:        t2,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		includeMessage: []string{"^hook"},
+		excludeMessage: []string{"update-status"},
+		agentKinds:     []string{"unit"},
 	}
 
 	called := false
@@ -55,6 +60,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		// Start time will be used once the client is extended to send
 		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t2)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -62,6 +68,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeMessage, jc.DeepEquals, []string{"^hook"})
+		c.Assert(params.ExcludeMessage, jc.DeepEquals, []string{"update-status"})
+		c.Assert(params.AgentKinds, jc.DeepEquals, []string{"unit"})
 
 		return newFakeLogTailer(), nil
 	})
@@ -73,6 +82,44 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestPastEndTimeDisablesTailing(c *gc.C) {
+	reqParams := debugLogParams{
+		endTime: s.clock.Now().Add(-time.Hour),
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+		c.Assert(params.NoTail, jc.IsTrue)
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(s.clock, s.timeout, nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestFutureEndTimeTails(c *gc.C) {
+	reqParams := debugLogParams{
+		endTime: s.clock.Now().Add(time.Hour),
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+		c.Assert(params.NoTail, jc.IsFalse)
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(s.clock, s.timeout, nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	reqParams := debugLogParams{
 		fromTheStart: true,
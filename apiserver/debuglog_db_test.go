@@ -35,6 +35,33 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, conn)
 }
 
+func (s *debugLogDBSuite) TestBadMessagePattern(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"includeMessage": {"hook (start"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `includeMessage value "hook \(start" is not a valid regular expression: .*`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugLogDBSuite) TestBadAgentKind(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"agentKind": {"user"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `agentKind value "user" is not one of "machine", "unit", "application"`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugLogDBSuite) TestEndTimeBeforeStartTime(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{
+		"startTime": {"2020-03-02T10:00:00Z"},
+		"endTime":   {"2020-03-02T09:00:00Z"},
+	})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `end time "2020-03-02T09:00:00Z" is before start time`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL("http", nil).String()
 	apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
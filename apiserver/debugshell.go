@@ -0,0 +1,649 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/schema"
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/pubsub/apiserver"
+)
+
+// debugShellClaimTimeout is how long to wait for another controller
+// to claim the stream of a unit agent that is not waiting on this one.
+var debugShellClaimTimeout = 5 * time.Second
+
+// debugShellBroker pairs operators debugging the hooks of a unit
+// with the agent of that unit. Unit agents that can serve a debug
+// shell keep a stream open, waiting for an operator; when one
+// connects, the broker hands over the waiting stream and relays
+// messages between the two.
+//
+// In HA, an operator may connect to a different controller from the
+// one the unit agent is waiting on. The broker then asks the other
+// controllers, over the central hub, for the agent's stream, and the
+// controller that has it relays the session over the hub.
+type debugShellBroker struct {
+	hub         *pubsub.StructuredHub
+	origin      string
+	stop        <-chan struct{}
+	unsubscribe func()
+
+	mu     sync.Mutex
+	agents map[string]*debugShellAgent
+}
+
+// debugShellEnd is one end of a debug session relayed by the
+// controller.
+type debugShellEnd interface {
+	// Send sends the message to the end.
+	Send(params.DebugShellMessage) error
+
+	// Messages returns a channel on which the messages sent by
+	// the end are received. It is closed if the end can no longer
+	// be read.
+	Messages() <-chan params.DebugShellMessage
+}
+
+// debugShellPeer is the unit agent end of an operator's debug session,
+// waiting on this controller or relayed from another one.
+type debugShellPeer interface {
+	debugShellEnd
+
+	// Done returns a channel that is closed when the session ends.
+	Done() <-chan struct{}
+
+	// Close ends the session.
+	Close()
+}
+
+// debugShellAgent is a unit agent stream waiting for an operator.
+type debugShellAgent struct {
+	conn *websocket.Conn
+
+	// messages receives the messages sent by the agent.
+	messages <-chan params.DebugShellMessage
+
+	// claimed is closed when an operator takes the stream.
+	claimed chan struct{}
+
+	// done is closed by finish when the agent stops waiting
+	// or the session with the operator ends.
+	done      chan struct{}
+	closeDone sync.Once
+}
+
+// finish records that the agent stream is no longer in use.
+func (a *debugShellAgent) finish() {
+	a.closeDone.Do(func() { close(a.done) })
+}
+
+// Send is part of the debugShellEnd interface.
+func (a *debugShellAgent) Send(m params.DebugShellMessage) error {
+	return a.conn.WriteJSON(m)
+}
+
+// Messages is part of the debugShellEnd interface.
+func (a *debugShellAgent) Messages() <-chan params.DebugShellMessage {
+	return a.messages
+}
+
+// Done is part of the debugShellPeer interface.
+func (a *debugShellAgent) Done() <-chan struct{} {
+	return a.done
+}
+
+// Close is part of the debugShellPeer interface.
+func (a *debugShellAgent) Close() {
+	a.conn.Close()
+	a.finish()
+}
+
+// newDebugShellBroker returns a broker for the controller identified
+// by origin, which also serves the sessions of operators connected to
+// other controllers until its unsubscribe method is called. The
+// sessions it relays end when stop is closed.
+func newDebugShellBroker(hub *pubsub.StructuredHub, origin string, stop <-chan struct{}) (*debugShellBroker, error) {
+	b := &debugShellBroker{
+		hub:    hub,
+		origin: origin,
+		stop:   stop,
+		agents: make(map[string]*debugShellAgent),
+	}
+	unsubscribe, err := hub.Subscribe(apiserver.DebugShellClaimTopic, b.onClaim)
+	if err != nil {
+		return nil, errors.Annotate(err, "subscribing to debug shell claims")
+	}
+	b.unsubscribe = unsubscribe
+	return b, nil
+}
+
+func debugShellKey(modelUUID, unitName string) string {
+	return modelUUID + ":" + unitName
+}
+
+// register records that the agent is waiting for an operator,
+// replacing any stream the unit agent had previously registered.
+func (b *debugShellBroker) register(key string, agent *debugShellAgent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if existing, ok := b.agents[key]; ok {
+		existing.conn.Close()
+	}
+	b.agents[key] = agent
+}
+
+// unregister removes the agent if it is still waiting.
+func (b *debugShellBroker) unregister(key string, agent *debugShellAgent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.agents[key] == agent {
+		delete(b.agents, key)
+	}
+}
+
+// claim removes and returns the agent waiting under the key.
+func (b *debugShellBroker) claim(key string) (*debugShellAgent, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	agent, ok := b.agents[key]
+	if ok {
+		delete(b.agents, key)
+		close(agent.claimed)
+	}
+	return agent, ok
+}
+
+// onClaim relays the debug session requested by an operator connected
+// to another controller, if the unit agent is waiting on this one.
+func (b *debugShellBroker) onClaim(_ string, claim apiserver.DebugShellClaim, err error) {
+	if err != nil {
+		logger.Errorf("invalid debug shell claim: %v", err)
+		return
+	}
+	if claim.Origin == b.origin {
+		// The operator's controller has already looked
+		// for the agent itself.
+		return
+	}
+	agent, ok := b.claim(debugShellKey(claim.ModelUUID, claim.Unit))
+	if !ok {
+		return
+	}
+	go b.relayToController(agent, claim)
+}
+
+// relayToController relays the session between the unit agent and the
+// controller the operator is connected to, until either end closes it.
+func (b *debugShellBroker) relayToController(agent *debugShellAgent, claim apiserver.DebugShellClaim) {
+	defer agent.Close()
+	stream, err := b.subscribeSession(claim.Session, claim.Origin)
+	if err != nil {
+		logger.Errorf("cannot relay debug session for %q: %v", claim.Unit, err)
+		return
+	}
+	defer stream.Close()
+	if err := stream.publish(apiserver.DebugShellData{Claimed: true}); err != nil {
+		logger.Errorf("cannot relay debug session for %q: %v", claim.Unit, err)
+		return
+	}
+	if err := agent.Send(params.DebugShellMessage{Hooks: claim.Hooks}); err != nil {
+		logger.Debugf("cannot send debug shell request to unit %q: %v", claim.Unit, err)
+		return
+	}
+	relayDebugShell(agent, stream, stream.Done(), b.stop)
+}
+
+// claimFromController asks the other controllers for the stream of
+// the unit agent, and returns the session relayed from the controller
+// that has it. An error satisfying errors.IsNotFound is returned if
+// no controller claims the stream in time.
+func (b *debugShellBroker) claimFromController(modelUUID string, cfg params.DebugShellConfig) (debugShellPeer, error) {
+	session, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stream, err := b.subscribeSession(session.String(), "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	claim := apiserver.DebugShellClaim{
+		Origin:    b.origin,
+		Session:   stream.session,
+		ModelUUID: modelUUID,
+		Unit:      cfg.Unit,
+		Hooks:     cfg.Hooks,
+	}
+	if _, err := b.hub.Publish(apiserver.DebugShellClaimTopic, claim); err != nil {
+		stream.Close()
+		return nil, errors.Annotate(err, "publishing debug shell claim")
+	}
+	select {
+	case <-stream.claimed:
+		return stream, nil
+	case <-time.After(debugShellClaimTimeout):
+	case <-b.stop:
+	}
+	// Closing the stream releases the agent's stream if it is
+	// claimed after all.
+	stream.Close()
+	return nil, errors.NotFoundf("unit agent for %q waiting for a debug session", cfg.Unit)
+}
+
+// debugShellHubStream carries the messages of a debug session between
+// this controller and the one at the other end, over the central hub.
+type debugShellHubStream struct {
+	broker  *debugShellBroker
+	session string
+
+	// claimed receives the origin of the first controller to
+	// claim the session, if it was not known when the stream
+	// was subscribed.
+	claimed chan string
+
+	messages    chan params.DebugShellMessage
+	unsubscribe func()
+
+	done      chan struct{}
+	closeDone sync.Once
+	closeOnce sync.Once
+}
+
+// subscribeSession returns a stream for the messages of the session
+// sent by the peer controller, or by the first controller to claim
+// the session if peer is empty.
+func (b *debugShellBroker) subscribeSession(session, peer string) (*debugShellHubStream, error) {
+	s := &debugShellHubStream{
+		broker:   b,
+		session:  session,
+		claimed:  make(chan string, 1),
+		messages: make(chan params.DebugShellMessage),
+		done:     make(chan struct{}),
+	}
+	// The peer is only accessed by the handler from here on,
+	// which the hub calls from a single goroutine.
+	handler := func(_ string, data apiserver.DebugShellData, err error) {
+		if err != nil {
+			logger.Errorf("invalid debug shell message: %v", err)
+			return
+		}
+		if data.Session != session || data.Origin == b.origin {
+			return
+		}
+		if peer == "" && data.Claimed {
+			peer = data.Origin
+			s.claimed <- peer
+			return
+		}
+		if data.Origin != peer {
+			if data.Claimed {
+				// Another controller has also claimed a
+				// stream for the unit, and only one can be
+				// relayed, so it is told to let go.
+				b.hub.Publish(apiserver.DebugShellDataTopic, apiserver.DebugShellData{
+					Origin:  b.origin,
+					Session: session,
+					Closed:  true,
+				})
+			}
+			return
+		}
+		if data.Closed {
+			s.finish()
+			return
+		}
+		var m params.DebugShellMessage
+		if err := json.Unmarshal([]byte(data.Message), &m); err != nil {
+			logger.Debugf("cannot decode relayed debug shell message: %v", err)
+			return
+		}
+		select {
+		case s.messages <- m:
+		case <-s.done:
+		}
+	}
+	unsubscribe, err := b.hub.Subscribe(apiserver.DebugShellDataTopic, handler)
+	if err != nil {
+		return nil, errors.Annotate(err, "subscribing to debug shell messages")
+	}
+	s.unsubscribe = unsubscribe
+	return s, nil
+}
+
+func (s *debugShellHubStream) publish(data apiserver.DebugShellData) error {
+	data.Origin = s.broker.origin
+	data.Session = s.session
+	_, err := s.broker.hub.Publish(apiserver.DebugShellDataTopic, data)
+	return errors.Trace(err)
+}
+
+func (s *debugShellHubStream) finish() {
+	s.closeDone.Do(func() { close(s.done) })
+}
+
+// Send is part of the debugShellEnd interface.
+func (s *debugShellHubStream) Send(m params.DebugShellMessage) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return s.publish(apiserver.DebugShellData{Message: string(data)})
+}
+
+// Messages is part of the debugShellEnd interface.
+func (s *debugShellHubStream) Messages() <-chan params.DebugShellMessage {
+	return s.messages
+}
+
+// Done is part of the debugShellPeer interface.
+func (s *debugShellHubStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close is part of the debugShellPeer interface. The controller at
+// the other end is told that the session has ended.
+func (s *debugShellHubStream) Close() {
+	s.closeOnce.Do(func() {
+		if err := s.publish(apiserver.DebugShellData{Closed: true}); err != nil {
+			logger.Debugf("cannot close relayed debug session: %v", err)
+		}
+		s.finish()
+		s.unsubscribe()
+	})
+}
+
+// debugShellAgentHandler serves the streams opened by unit agents
+// to wait for operators.
+type debugShellAgentHandler struct {
+	ctxt   httpContext
+	broker *debugShellBroker
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *debugShellAgentHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+		modelUUID, unitName, err := h.agentUnit(req)
+		if err != nil {
+			sendDebugShellError(conn, req, err)
+			return
+		}
+		sendDebugShellError(conn, req, nil)
+
+		agent := &debugShellAgent{
+			conn:    conn,
+			claimed: make(chan struct{}),
+			done:    make(chan struct{}),
+		}
+		keepDebugShellAlive(conn, h.ctxt.stop(), agent.done)
+		// The agent sends nothing until it has been sent the
+		// operator's request, so any earlier input is discarded.
+		var readerDone <-chan struct{}
+		agent.messages, readerDone = receiveDebugShellMessages(conn, agent.claimed, agent.done)
+
+		key := debugShellKey(modelUUID, unitName)
+		h.broker.register(key, agent)
+		defer h.broker.unregister(key, agent)
+		select {
+		case <-h.ctxt.stop():
+			agent.finish()
+		case <-agent.claimed:
+			// The operator's handler owns the stream
+			// until the session ends.
+			<-agent.done
+		case <-readerDone:
+			agent.finish()
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// agentUnit returns the model UUID and the name of the unit served
+// by the agent making the request. Unit agents serve their own unit;
+// application operator agents, which run the hooks of all the
+// application's units, must name the unit.
+func (h *debugShellAgentHandler) agentUnit(req *http.Request) (string, string, error) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedTag(req, names.UnitTagKind, names.ApplicationTagKind)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	defer st.Release()
+
+	unitName := req.URL.Query().Get("unit")
+	switch tag := entity.Tag().(type) {
+	case names.UnitTag:
+		if unitName != "" && unitName != tag.Id() {
+			return "", "", common.ErrPerm
+		}
+		unitName = tag.Id()
+	case names.ApplicationTag:
+		if !names.IsValidUnit(unitName) {
+			return "", "", errors.NotValidf("unit name %q", unitName)
+		}
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return "", "", errors.Trace(err)
+		}
+		if appName != tag.Id() {
+			return "", "", common.ErrPerm
+		}
+	}
+	return st.ModelUUID(), unitName, nil
+}
+
+// debugShellHandler serves the streams opened by operators to
+// debug the hooks of a unit.
+type debugShellHandler struct {
+	ctxt   httpContext
+	broker *debugShellBroker
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *debugShellHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+		agent, err := h.claimAgent(req)
+		if err != nil {
+			sendDebugShellError(conn, req, err)
+			return
+		}
+		defer agent.Close()
+		sendDebugShellError(conn, req, nil)
+		keepDebugShellAlive(conn, h.ctxt.stop(), agent.Done())
+
+		operatorMessages, _ := receiveDebugShellMessages(conn, nil, agent.Done())
+		operator := debugShellConn{conn: conn, messages: operatorMessages}
+		relayDebugShell(operator, agent, agent.Done(), h.ctxt.stop())
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// claimAgent returns the unit agent end of the session requested by
+// the operator, having sent the agent the hooks to debug.
+func (h *debugShellHandler) claimAgent(req *http.Request) (debugShellPeer, error) {
+	modelUUID, cfg, err := h.sessionConfig(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	agent, ok := h.broker.claim(debugShellKey(modelUUID, cfg.Unit))
+	if !ok {
+		// The agent may be waiting on another controller,
+		// which sends it the hooks itself.
+		return h.broker.claimFromController(modelUUID, cfg)
+	}
+	if err := agent.Send(params.DebugShellMessage{Hooks: cfg.Hooks}); err != nil {
+		agent.Close()
+		return nil, errors.Annotatef(err, "sending debug shell request to unit %q", cfg.Unit)
+	}
+	return agent, nil
+}
+
+// sessionConfig returns the model UUID and the configuration of the
+// session requested by the operator, if they may open one.
+func (h *debugShellHandler) sessionConfig(req *http.Request) (string, params.DebugShellConfig, error) {
+	var cfg params.DebugShellConfig
+	st, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+	if err != nil {
+		return "", cfg, errors.Trace(err)
+	}
+	defer st.Release()
+
+	// A debug shell runs with the privileges of the unit
+	// agent, so only model administrators may open one.
+	ok, err := common.HasPermission(
+		st.UserPermission,
+		entity.Tag(),
+		permission.AdminAccess,
+		names.NewModelTag(st.ModelUUID()),
+	)
+	if err != nil {
+		return "", cfg, errors.Trace(err)
+	}
+	if !ok {
+		return "", cfg, common.ErrPerm
+	}
+
+	query := req.URL.Query()
+	query.Del(":modeluuid")
+	if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+		return "", cfg, errors.Annotate(err, "decoding schema")
+	}
+	if !names.IsValidUnit(cfg.Unit) {
+		return "", cfg, errors.NotValidf("unit name %q", cfg.Unit)
+	}
+	return st.ModelUUID(), cfg, nil
+}
+
+// debugShellConn is the operator end of a debug session.
+type debugShellConn struct {
+	conn     *websocket.Conn
+	messages <-chan params.DebugShellMessage
+}
+
+// Send is part of the debugShellEnd interface.
+func (c debugShellConn) Send(m params.DebugShellMessage) error {
+	return c.conn.WriteJSON(m)
+}
+
+// Messages is part of the debugShellEnd interface.
+func (c debugShellConn) Messages() <-chan params.DebugShellMessage {
+	return c.messages
+}
+
+// relayDebugShell relays messages between the two ends of a session
+// until either of them can no longer be read or written, or done or
+// stop is closed.
+func relayDebugShell(a, b debugShellEnd, done, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-done:
+			return
+		case m, ok := <-a.Messages():
+			if !ok {
+				return
+			}
+			if err := b.Send(m); err != nil {
+				logger.Debugf("cannot relay debug shell message: %v", err)
+				return
+			}
+		case m, ok := <-b.Messages():
+			if !ok {
+				return
+			}
+			if err := a.Send(m); err != nil {
+				logger.Debugf("cannot relay debug shell message: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// receiveDebugShellMessages returns a channel on which the messages
+// read from the stream are sent, and a channel that is closed when
+// the stream can no longer be read or done is closed. If ready is
+// not nil, messages read before it is closed are discarded.
+func receiveDebugShellMessages(
+	conn *websocket.Conn, ready, done <-chan struct{},
+) (<-chan params.DebugShellMessage, <-chan struct{}) {
+	messages := make(chan params.DebugShellMessage)
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		defer close(messages)
+		for {
+			var m params.DebugShellMessage
+			if err := conn.ReadJSON(&m); err != nil {
+				return
+			}
+			if ready != nil {
+				select {
+				case <-ready:
+				default:
+					continue
+				}
+			}
+			select {
+			case <-done:
+				return
+			case messages <- m:
+			}
+		}
+	}()
+	return messages, readerDone
+}
+
+// keepDebugShellAlive pings the other end of the stream until either
+// channel is closed, so that a broken stream is noticed even if the
+// shell is idle. See the long note in logsink.go for the rationale.
+func keepDebugShellAlive(conn *websocket.Conn, stop, done <-chan struct{}) {
+	conn.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+		return nil
+	})
+	go func() {
+		ticker := time.NewTicker(websocket.PingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				deadline := time.Now().Add(websocket.WriteWait)
+				if err := conn.WriteControl(gorillaws.PingMessage, []byte{}, deadline); err != nil {
+					logger.Debugf("failed to write ping: %s", err)
+					return
+				}
+			}
+		}
+	}()
+}
+
+// sendDebugShellError sends a JSON-encoded error response.
+func sendDebugShellError(conn *websocket.Conn, req *http.Request, err error) {
+	if err != nil {
+		logger.Debugf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := conn.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", sendErr)
+		conn.Close()
+	}
+}
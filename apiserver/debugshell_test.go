@@ -0,0 +1,302 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket/websockettest"
+	"github.com/juju/juju/core/permission"
+	psapiserver "github.com/juju/juju/pubsub/apiserver"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type debugShellSuite struct {
+	apiserverBaseSuite
+	unitTag  names.Tag
+	password string
+}
+
+var _ = gc.Suite(&debugShellSuite{})
+
+func (s *debugShellSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+	u, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	s.unitTag = u.Tag()
+	s.password = password
+	s.PatchValue(apiserver.DebugShellClaimTimeout, 100*time.Millisecond)
+}
+
+// otherController is the origin of the messages published as though
+// they came from another controller in HA.
+const otherController = "machine-99"
+
+func (s *debugShellSuite) TestRelay(c *gc.C) {
+	agentConn := s.dialAgent(c, "")
+	defer agentConn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, agentConn)
+
+	operatorConn := s.dialOperator(c, []string{"install"})
+	defer operatorConn.Close()
+
+	// The agent is sent the hooks requested by the operator.
+	var m params.DebugShellMessage
+	err := agentConn.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugShellMessage{Hooks: []string{"install"}})
+
+	err = agentConn.WriteJSON(params.DebugShellMessage{Hook: "install"})
+	c.Assert(err, jc.ErrorIsNil)
+	m = params.DebugShellMessage{}
+	err = operatorConn.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugShellMessage{Hook: "install"})
+
+	err = operatorConn.WriteJSON(params.DebugShellMessage{Data: []byte("ls\n")})
+	c.Assert(err, jc.ErrorIsNil)
+	m = params.DebugShellMessage{}
+	err = agentConn.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugShellMessage{Data: []byte("ls\n")})
+
+	// When the operator goes away, so does the agent's stream.
+	err = operatorConn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	websockettest.AssertWebsocketClosed(c, agentConn)
+}
+
+func (s *debugShellSuite) TestNoAgentWaiting(c *gc.C) {
+	conn, _, err := dialWebsocketFromURL(c, s.operatorURL(nil), s.ownerHeader())
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn,
+		fmt.Sprintf(`unit agent for %q waiting for a debug session not found`, s.unitTag.Id()),
+	)
+}
+
+func (s *debugShellSuite) TestRelayFromOtherController(c *gc.C) {
+	agentConn := s.dialAgent(c, "")
+	defer agentConn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, agentConn)
+
+	data := s.subscribeData(c)
+	claim := psapiserver.DebugShellClaim{
+		Origin:    otherController,
+		Session:   "session-1",
+		ModelUUID: s.State.ModelUUID(),
+		Unit:      s.unitTag.Id(),
+		Hooks:     []string{"install"},
+	}
+	// The claim is repeated until the agent stream has been
+	// registered with the controller.
+	claimed := false
+	for a := coretesting.LongAttempt.Start(); !claimed && a.Next(); {
+		_, err := s.config.Hub.Publish(psapiserver.DebugShellClaimTopic, claim)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case d := <-data:
+			c.Assert(d.Claimed, jc.IsTrue)
+			c.Assert(d.Session, gc.Equals, "session-1")
+			claimed = true
+		case <-time.After(coretesting.ShortWait):
+		}
+	}
+	c.Assert(claimed, jc.IsTrue)
+
+	var m params.DebugShellMessage
+	err := agentConn.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugShellMessage{Hooks: []string{"install"}})
+
+	err = agentConn.WriteJSON(params.DebugShellMessage{Hook: "install"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextMessage(c, data), jc.DeepEquals, params.DebugShellMessage{Hook: "install"})
+
+	s.publishMessage(c, "session-1", params.DebugShellMessage{Data: []byte("ls\n")})
+	m = params.DebugShellMessage{}
+	err = agentConn.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugShellMessage{Data: []byte("ls\n")})
+
+	// When the other controller ends the session, the agent's
+	// stream is closed.
+	_, err = s.config.Hub.Publish(psapiserver.DebugShellDataTopic, psapiserver.DebugShellData{
+		Origin:  otherController,
+		Session: "session-1",
+		Closed:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	websockettest.AssertWebsocketClosed(c, agentConn)
+}
+
+func (s *debugShellSuite) TestRelayToOtherController(c *gc.C) {
+	claims := make(chan psapiserver.DebugShellClaim, 1)
+	unsubscribe, err := s.config.Hub.Subscribe(psapiserver.DebugShellClaimTopic,
+		func(_ string, claim psapiserver.DebugShellClaim, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			// The other controller has the agent's stream.
+			_, err = s.config.Hub.Publish(psapiserver.DebugShellDataTopic, psapiserver.DebugShellData{
+				Origin:  otherController,
+				Session: claim.Session,
+				Claimed: true,
+			})
+			c.Check(err, jc.ErrorIsNil)
+			claims <- claim
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+	data := s.subscribeData(c)
+
+	operatorConn, _, err := dialWebsocketFromURL(c, s.operatorURL([]string{"install"}), s.ownerHeader())
+	c.Assert(err, jc.ErrorIsNil)
+	defer operatorConn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, operatorConn)
+
+	var claim psapiserver.DebugShellClaim
+	select {
+	case claim = <-claims:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for debug shell claim")
+	}
+	c.Assert(claim.ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Assert(claim.Unit, gc.Equals, s.unitTag.Id())
+	c.Assert(claim.Hooks, jc.DeepEquals, []string{"install"})
+
+	s.publishMessage(c, claim.Session, params.DebugShellMessage{Hook: "install"})
+	var m params.DebugShellMessage
+	err = operatorConn.ReadJSON(&m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, jc.DeepEquals, params.DebugShellMessage{Hook: "install"})
+
+	err = operatorConn.WriteJSON(params.DebugShellMessage{Data: []byte("ls\n")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextMessage(c, data), jc.DeepEquals, params.DebugShellMessage{Data: []byte("ls\n")})
+
+	// When the operator goes away, the other controller is told
+	// that the session has ended.
+	err = operatorConn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case d := <-data:
+		c.Assert(d.Session, gc.Equals, claim.Session)
+		c.Assert(d.Closed, jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for session to be closed")
+	}
+}
+
+func (s *debugShellSuite) TestOperatorRequiresAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "sekrit",
+		Access:   permission.WriteAccess,
+	})
+	header := utils.BasicAuthHeader(user.Tag().String(), "sekrit")
+	conn, _, err := dialWebsocketFromURL(c, s.operatorURL(nil), header)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "permission denied")
+}
+
+func (s *debugShellSuite) TestAgentCannotServeOtherUnit(c *gc.C) {
+	conn := s.dialAgent(c, "other/0")
+	defer conn.Close()
+	websockettest.AssertJSONError(c, conn, "permission denied")
+}
+
+func (s *debugShellSuite) dialAgent(c *gc.C, unitName string) *websocket.Conn {
+	query := url.Values{}
+	if unitName != "" {
+		query.Set("unit", unitName)
+	}
+	agentURL := s.URL(fmt.Sprintf("/model/%s/debug-shell/agent", s.State.ModelUUID()), query)
+	agentURL.Scheme = "wss"
+	header := utils.BasicAuthHeader(s.unitTag.String(), s.password)
+	conn, _, err := dialWebsocketFromURL(c, agentURL.String(), header)
+	c.Assert(err, jc.ErrorIsNil)
+	return conn
+}
+
+// dialOperator opens a debug session, retrying until the agent
+// stream has been registered with the controller.
+func (s *debugShellSuite) dialOperator(c *gc.C, hooks []string) *websocket.Conn {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		conn, _, err := dialWebsocketFromURL(c, s.operatorURL(hooks), s.ownerHeader())
+		c.Assert(err, jc.ErrorIsNil)
+		result := websockettest.ReadJSONErrorLine(c, conn)
+		if result.Error == nil {
+			return conn
+		}
+		conn.Close()
+		c.Assert(result.Error.Code, gc.Equals, params.CodeNotFound)
+	}
+	c.Fatalf("timed out waiting for agent to be registered")
+	return nil
+}
+
+// subscribeData returns a channel on which the debug session messages
+// published by the controller under test are received.
+func (s *debugShellSuite) subscribeData(c *gc.C) <-chan psapiserver.DebugShellData {
+	data := make(chan psapiserver.DebugShellData, 10)
+	unsubscribe, err := s.config.Hub.Subscribe(psapiserver.DebugShellDataTopic,
+		func(_ string, d psapiserver.DebugShellData, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			if d.Origin != otherController {
+				data <- d
+			}
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsubscribe() })
+	return data
+}
+
+func (s *debugShellSuite) nextMessage(c *gc.C, data <-chan psapiserver.DebugShellData) params.DebugShellMessage {
+	select {
+	case d := <-data:
+		var m params.DebugShellMessage
+		err := json.Unmarshal([]byte(d.Message), &m)
+		c.Assert(err, jc.ErrorIsNil)
+		return m
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for relayed message")
+	}
+	return params.DebugShellMessage{}
+}
+
+func (s *debugShellSuite) publishMessage(c *gc.C, session string, m params.DebugShellMessage) {
+	message, err := json.Marshal(m)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.config.Hub.Publish(psapiserver.DebugShellDataTopic, psapiserver.DebugShellData{
+		Origin:  otherController,
+		Session: session,
+		Message: string(message),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *debugShellSuite) operatorURL(hooks []string) string {
+	query := url.Values{"unit": {s.unitTag.Id()}}
+	for _, hook := range hooks {
+		query.Add("hooks", hook)
+	}
+	operatorURL := s.URL(fmt.Sprintf("/model/%s/debug-shell", s.State.ModelUUID()), query)
+	operatorURL.Scheme = "wss"
+	return operatorURL.String()
+}
+
+func (s *debugShellSuite) ownerHeader() http.Header {
+	return utils.BasicAuthHeader(s.Owner.String(), ownerPassword)
+}
@@ -27,6 +27,8 @@ var (
 	JSMimeType            = jsMimeType
 	GUIURLPathPrefix      = guiURLPathPrefix
 	SpritePath            = spritePath
+
+	DebugShellClaimTimeout = &debugShellClaimTimeout
 )
 
 func APIHandlerWithEntity(entity state.Entity) *apiHandler {
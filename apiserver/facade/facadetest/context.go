@@ -26,12 +26,14 @@ type Context struct {
 	MultiwatcherFactory_ multiwatcher.Factory
 	ID_                  string
 	Cancel_              <-chan struct{}
-
-	LeadershipClaimer_ leadership.Claimer
-	LeadershipChecker_ leadership.Checker
-	LeadershipPinner_  leadership.Pinner
-	LeadershipReader_  leadership.Reader
-	SingularClaimer_   lease.Claimer
+	LogDir_              string
+
+	LeadershipClaimer_    leadership.Claimer
+	LeadershipChecker_    leadership.Checker
+	LeadershipPinner_     leadership.Pinner
+	LeadershipReader_     leadership.Reader
+	LeadershipTransferer_ leadership.Transferer
+	SingularClaimer_      lease.Claimer
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -123,7 +125,17 @@ func (context Context) LeadershipReader(modelUUID string) (leadership.Reader, er
 	return context.LeadershipReader_, nil
 }
 
+// LeadershipTransferer implements facade.Context.
+func (context Context) LeadershipTransferer(modelUUID string) (leadership.Transferer, error) {
+	return context.LeadershipTransferer_, nil
+}
+
 // SingularClaimer implements facade.Context.
 func (context Context) SingularClaimer() (lease.Claimer, error) {
 	return context.SingularClaimer_, nil
 }
+
+// LogDir implements facade.Context.
+func (context Context) LogDir() string {
+	return context.LogDir_
+}
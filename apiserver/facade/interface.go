@@ -92,7 +92,8 @@ type Context interface {
 	Presence() Presence
 
 	// Hub returns the central hub that the API server holds.
-	// At least at this stage, facades only need to publish events.
+	// Facades publish events on it, and may subscribe to the
+	// responses to requests they publish.
 	Hub() Hub
 
 	// ID returns a string that should almost always be "", unless
@@ -118,9 +119,17 @@ type Context interface {
 	// context's model.
 	LeadershipReader(modelUUID string) (leadership.Reader, error)
 
+	// LeadershipTransferer returns a leadership.Transferer for this
+	// context's model.
+	LeadershipTransferer(modelUUID string) (leadership.Transferer, error)
+
 	// SingularClaimer returns a lease.Claimer for singular leases for
 	// this context's model.
 	SingularClaimer() (lease.Claimer, error)
+
+	// LogDir returns the directory where the API server writes its
+	// log files, including the audit log.
+	LogDir() string
 }
 
 //go:generate mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/apiserver/facade Resources,Authorizer
@@ -204,4 +213,5 @@ type ModelPresence interface {
 // Hub represents the central hub that the API server has.
 type Hub interface {
 	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
 }
@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the EgressFirewaller facade.
+type Backend interface {
+	state.EntityFinder
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the machine methods used by the EgressFirewaller
+// facade.
+type Machine interface {
+	EgressRestricted() bool
+	EgressRules() []network.EgressRule
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EgressFirewallerAPI provides access to the EgressFirewaller API
+// facade, used by machine agents to enforce the egress rules of their
+// machine when its provider can't.
+type EgressFirewallerAPI struct {
+	*common.AgentEntityWatcher
+
+	backend   Backend
+	canAccess common.GetAuthFunc
+}
+
+// NewStateEgressFirewallerAPI creates a new server-side
+// EgressFirewaller API facade backed by state.
+func NewStateEgressFirewallerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*EgressFirewallerAPI, error) {
+	return NewEgressFirewallerAPI(stateShim{st}, resources, authorizer)
+}
+
+// NewEgressFirewallerAPI creates a new server-side EgressFirewaller
+// API facade.
+func NewEgressFirewallerAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*EgressFirewallerAPI, error) {
+	// Only machine agents enforce the egress rules of their machine.
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	canAccess := func() (common.AuthFunc, error) {
+		return authorizer.AuthOwner, nil
+	}
+	return &EgressFirewallerAPI{
+		AgentEntityWatcher: common.NewAgentEntityWatcher(backend, resources, canAccess),
+		backend:            backend,
+		canAccess:          canAccess,
+	}, nil
+}
+
+// EgressRules returns the egress rules the agent of each given machine
+// must enforce, and whether the egress of the machine is restricted to
+// them.
+func (api *EgressFirewallerAPI) EgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := api.canAccess()
+	if err != nil {
+		return params.EgressRulesResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := api.backend.Machine(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Restricted = machine.EgressRestricted()
+		for _, rule := range machine.EgressRules() {
+			result.Results[i].Rules = append(result.Results[i].Rules, params.EgressRule{
+				PortRange:        params.FromNetworkPortRange(rule.PortRange),
+				DestinationCIDRs: rule.DestinationCIDRs,
+			})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/egressfirewaller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	corenetwork "github.com/juju/juju/core/network"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+type egressFirewallerSuite struct {
+	jujutesting.JujuConnSuite
+
+	machine   *state.Machine
+	resources *common.Resources
+	api       *egressfirewaller.EgressFirewallerAPI
+}
+
+var _ = gc.Suite(&egressFirewallerSuite{})
+
+func (s *egressFirewallerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: s.machine.Tag(),
+	}
+	s.api, err = egressfirewaller.NewStateEgressFirewallerAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *egressFirewallerSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}
+	_, err := egressfirewaller.NewStateEgressFirewallerAPI(s.State, s.resources, authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *egressFirewallerSuite) TestEgressRules(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetEgressRules(true, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.EgressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.machine.Tag().String()},
+			{Tag: other.Tag().String()},
+			{Tag: "application-wordpress"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{{
+			Restricted: true,
+			Rules: []params.EgressRule{{
+				PortRange: params.FromNetworkPortRange(corenetwork.PortRange{
+					Protocol: "tcp",
+					FromPort: 443,
+					ToPort:   443,
+				}),
+				DestinationCIDRs: []string{"10.0.0.0/24"},
+			}},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+func (s *egressFirewallerSuite) TestEgressRulesUnrestricted(c *gc.C) {
+	result, err := s.api.EgressRules(params.Entities{
+		Entities: []params.Entity{{Tag: s.machine.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{{}},
+	})
+}
+
+func (s *egressFirewallerSuite) TestWatch(c *gc.C) {
+	result, err := s.api.Watch(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.machine.Tag().String()},
+			{Tag: "machine-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+}
@@ -216,6 +216,10 @@ func (api *ProvisionerAPI) machineVolumeParams(
 		if err != nil {
 			return nil, nil, errors.Annotatef(err, "getting volume %q parameters", volumeTag.Id())
 		}
+		volumeParams.SnapshotId, err = storagecommon.VolumeSnapshotId(volume, sb.VolumeSnapshot)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "getting volume %q snapshot", volumeTag.Id())
+		}
 		if _, err := env.StorageProvider(storage.ProviderType(volumeParams.Provider)); errors.IsNotFound(err) {
 			// This storage type is not managed by the environ
 			// provider, so ignore it. It'll be managed by one
@@ -21,4 +21,6 @@ type Backend interface {
 	WatchModelFilesystems() state.StringsWatcher
 	WatchModelFilesystemAttachments() state.StringsWatcher
 	WatchModelVolumeAttachments() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
 }
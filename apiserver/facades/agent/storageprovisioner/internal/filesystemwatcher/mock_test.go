@@ -19,6 +19,8 @@ type mockBackend struct {
 	modelFilesystemsW             *watchertest.StringsWatcher
 	modelFilesystemAttachmentsW   *watchertest.StringsWatcher
 	modelVolumeAttachmentsW       *watchertest.StringsWatcher
+	machineFilesystemResizesW     *watchertest.StringsWatcher
+	modelFilesystemResizesW       *watchertest.StringsWatcher
 
 	filesystems               map[string]*mockFilesystem
 	volumeAttachments         map[string]*mockVolumeAttachment
@@ -82,6 +84,14 @@ func (b *mockBackend) WatchModelVolumeAttachments() state.StringsWatcher {
 	return b.modelVolumeAttachmentsW
 }
 
+func (b *mockBackend) WatchMachineFilesystemResizes(tag names.MachineTag) state.StringsWatcher {
+	return b.machineFilesystemResizesW
+}
+
+func (b *mockBackend) WatchModelFilesystemResizes() state.StringsWatcher {
+	return b.modelFilesystemResizesW
+}
+
 func newStringsWatcher() *watchertest.StringsWatcher {
 	return watchertest.NewStringsWatcher(make(chan []string, 1))
 }
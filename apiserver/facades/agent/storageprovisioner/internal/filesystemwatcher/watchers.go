@@ -27,16 +27,27 @@ type Watchers struct {
 // model-scoped filesystems that have no backing volume. Volume-backed
 // filesystems are always managed by the host to which they are attached.
 func (fw Watchers) WatchModelManagedFilesystems() state.StringsWatcher {
-	return newFilteredStringsWatcher(fw.Backend.WatchModelFilesystems(), func(id string) (bool, error) {
-		f, err := fw.Backend.Filesystem(names.NewFilesystemTag(id))
-		if errors.IsNotFound(err) {
-			return false, nil
-		} else if err != nil {
-			return false, errors.Trace(err)
-		}
-		_, err = f.Volume()
-		return err == state.ErrNoBackingVolume, nil
-	})
+	return newFilteredStringsWatcher(fw.Backend.WatchModelFilesystems(), fw.modelManagedFilesystem)
+}
+
+// WatchModelManagedFilesystemResizes returns a strings watcher that reports
+// changes to model-scoped filesystems that have no backing volume, so that
+// the model-level storageprovisioner can check for resize requests.
+func (fw Watchers) WatchModelManagedFilesystemResizes() state.StringsWatcher {
+	return newFilteredStringsWatcher(fw.Backend.WatchModelFilesystemResizes(), fw.modelManagedFilesystem)
+}
+
+// modelManagedFilesystem reports whether the filesystem with the given ID
+// exists and has no backing volume.
+func (fw Watchers) modelManagedFilesystem(id string) (bool, error) {
+	f, err := fw.Backend.Filesystem(names.NewFilesystemTag(id))
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	_, err = f.Volume()
+	return err == state.ErrNoBackingVolume, nil
 }
 
 // WatchUnitManagedFilesystems returns a strings watcher that reports both
@@ -73,12 +84,33 @@ func (fw Watchers) WatchUnitManagedFilesystems(app names.ApplicationTag) state.S
 // machine-scoped filesystems, and model-scoped, volume-backed filesystems
 // that are attached to the specified machine.
 func (fw Watchers) WatchMachineManagedFilesystems(m names.MachineTag) state.StringsWatcher {
+	return fw.watchMachineFilesystems(m,
+		fw.Backend.WatchMachineFilesystems(m),
+		fw.Backend.WatchModelFilesystems(),
+	)
+}
+
+// WatchMachineManagedFilesystemResizes returns a strings watcher that
+// reports changes to both machine-scoped filesystems, and model-scoped,
+// volume-backed filesystems that are attached to the specified machine,
+// so that the machine's storageprovisioner can check for resize requests.
+func (fw Watchers) WatchMachineManagedFilesystemResizes(m names.MachineTag) state.StringsWatcher {
+	return fw.watchMachineFilesystems(m,
+		fw.Backend.WatchMachineFilesystemResizes(m),
+		fw.Backend.WatchModelFilesystemResizes(),
+	)
+}
+
+func (fw Watchers) watchMachineFilesystems(
+	m names.MachineTag,
+	machineFilesystems, modelFilesystems state.StringsWatcher,
+) state.StringsWatcher {
 	w := &hostFilesystemsWatcher{
 		stringsWatcherBase:     stringsWatcherBase{out: make(chan []string)},
 		backend:                fw.Backend,
 		changes:                set.NewStrings(),
-		hostFilesystems:        fw.Backend.WatchMachineFilesystems(m),
-		modelFilesystems:       fw.Backend.WatchModelFilesystems(),
+		hostFilesystems:        machineFilesystems,
+		modelFilesystems:       modelFilesystems,
 		modelVolumeAttachments: fw.Backend.WatchModelVolumeAttachments(),
 		modelVolumesAttached:   names.NewSet(),
 		modelVolumeFilesystems: make(map[names.VolumeTag]names.FilesystemTag),
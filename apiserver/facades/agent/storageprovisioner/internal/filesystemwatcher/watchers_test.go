@@ -33,6 +33,8 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 		modelFilesystemsW:             newStringsWatcher(),
 		modelFilesystemAttachmentsW:   newStringsWatcher(),
 		modelVolumeAttachmentsW:       newStringsWatcher(),
+		machineFilesystemResizesW:     newStringsWatcher(),
+		modelFilesystemResizesW:       newStringsWatcher(),
 		filesystems: map[string]*mockFilesystem{
 			// filesystem 0 has no backing volume.
 			"0": {},
@@ -53,6 +55,8 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 		s.backend.modelFilesystemsW.Stop()
 		s.backend.modelFilesystemAttachmentsW.Stop()
 		s.backend.modelVolumeAttachmentsW.Stop()
+		s.backend.machineFilesystemResizesW.Stop()
+		s.backend.modelFilesystemResizesW.Stop()
 	})
 	s.watchers.Backend = s.backend
 }
@@ -74,6 +78,17 @@ func (s *WatchersSuite) TestWatchModelManagedFilesystemsWatcherErrorsPropagate(c
 	c.Assert(w.Wait(), gc.ErrorMatches, "rah")
 }
 
+func (s *WatchersSuite) TestWatchModelManagedFilesystemResizes(c *gc.C) {
+	w := s.watchers.WatchModelManagedFilesystemResizes()
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemResizesW.C <- []string{"0", "1"}
+
+	// Filesystem 1 has a backing volume, so should not be reported.
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachments(c *gc.C) {
 	w := s.watchers.WatchModelManagedFilesystemAttachments()
 	defer statetesting.AssertKillAndWait(c, w)
@@ -103,6 +118,18 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystems(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *WatchersSuite) TestWatchMachineManagedFilesystemResizes(c *gc.C) {
+	w := s.watchers.WatchMachineManagedFilesystemResizes(names.NewMachineTag("0"))
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemResizesW.C <- []string{"0", "1"}
+	s.backend.machineFilesystemResizesW.C <- []string{"0/2"}
+	s.backend.modelVolumeAttachmentsW.C <- []string{"0:1", "0:2", "1:3"}
+
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0/2", "1")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchMachineManagedFilesystemsErrorsPropagate(c *gc.C) {
 	w := s.watchers.WatchMachineManagedFilesystems(names.NewMachineTag("0"))
 	s.backend.modelFilesystemsW.T.Kill(errors.New("rah"))
@@ -56,6 +56,24 @@ func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.
 	return NewStorageProvisionerAPIv4(v3), nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv5, error) {
+	v4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv5(v4), nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration.
+func NewFacadeV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv6, error) {
+	v5, err := NewFacadeV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv6(v5), nil
+}
+
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
@@ -84,6 +102,12 @@ type StorageBackend interface {
 	WatchUnitVolumeAttachments(tag names.ApplicationTag) state.StringsWatcher
 	WatchVolumeAttachment(names.Tag, names.VolumeTag) state.NotifyWatcher
 	WatchMachineAttachmentsPlans(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
+	WatchModelFilesystemResizes() state.StringsWatcher
+	WatchMachineFilesystemResizes(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeSnapshots() state.StringsWatcher
+	WatchMachineVolumeSnapshots(names.MachineTag) state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 	AllStorageInstances() ([]state.StorageInstance, error)
@@ -100,11 +124,13 @@ type StorageBackend interface {
 	VolumeAttachments(names.VolumeTag) ([]state.VolumeAttachment, error)
 	VolumeAttachmentPlan(names.Tag, names.VolumeTag) (state.VolumeAttachmentPlan, error)
 	VolumeAttachmentPlans(volume names.VolumeTag) ([]state.VolumeAttachmentPlan, error)
+	VolumeSnapshot(string) (state.VolumeSnapshot, error)
 
 	RemoveFilesystem(names.FilesystemTag) error
 	RemoveFilesystemAttachment(names.Tag, names.FilesystemTag) error
 	RemoveVolume(names.VolumeTag) error
 	RemoveVolumeAttachment(names.Tag, names.VolumeTag) error
+	RemoveVolumeSnapshot(string) error
 	DetachFilesystem(names.Tag, names.FilesystemTag) error
 	DestroyFilesystem(names.FilesystemTag) error
 	DetachVolume(names.Tag, names.VolumeTag) error
@@ -114,6 +140,7 @@ type StorageBackend interface {
 	SetFilesystemAttachmentInfo(names.Tag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.Tag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetVolumeSnapshotInfo(string, state.VolumeSnapshotInfo) error
 
 	CreateVolumeAttachmentPlan(names.Tag, names.VolumeTag, state.VolumeAttachmentPlanInfo) error
 	RemoveVolumeAttachmentPlan(names.Tag, names.VolumeTag) error
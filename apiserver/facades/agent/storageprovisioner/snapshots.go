@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// WatchVolumeSnapshots watches for changes to volume snapshots scoped
+// to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPIv6) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeSnapshots, s.sb.WatchMachineVolumeSnapshots, nil)
+}
+
+// getVolumeSnapshotAuthFunc returns a function that reports whether
+// the authenticated agent may access the volume snapshot with the
+// given ID. Snapshot IDs carry the machine scope of the volume they
+// were taken of, so access is granted as for a volume with that ID.
+func (s *StorageProvisionerAPIv6) getVolumeSnapshotAuthFunc() (func(string) bool, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return nil, err
+	}
+	return func(id string) bool {
+		return names.IsValidVolume(id) && canAccess(names.NewVolumeTag(id))
+	}, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking or destroying
+// the volume snapshots with the specified IDs.
+func (s *StorageProvisionerAPIv6) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		if !canAccess(id) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		}
+		snapshot, err := s.sb.VolumeSnapshot(id)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			snapshot.Pool(), s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		snapshotTags, err := storagecommon.StorageTags(
+			nil, modelCfg.UUID(), controllerCfg.ControllerUUID(), modelCfg,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, errors.Annotate(err, "computing storage tags")
+		}
+		result := params.VolumeSnapshotParams{
+			Id:        id,
+			Life:      life.Value(snapshot.Life().String()),
+			VolumeTag: snapshot.Volume().String(),
+			Provider:  string(provider),
+			Tags:      snapshotTags,
+		}
+		if snapshotInfo, err := snapshot.Info(); err == nil {
+			result.SnapshotId = snapshotInfo.SnapshotId
+		} else if !errors.IsNotProvisioned(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		// The volume may have been removed since the snapshot
+		// was taken, in which case the snapshot can still be
+		// destroyed using its provider ID alone.
+		if volume, err := s.sb.Volume(snapshot.Volume()); err == nil {
+			if volumeInfo, err := volume.Info(); err == nil {
+				result.VolumeId = volumeInfo.VolumeId
+			}
+		} else if !errors.IsNotFound(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return result, nil
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPIv6) SetVolumeSnapshotInfo(args params.VolumeSnapshotInfos) (params.ErrorResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	one := func(arg params.VolumeSnapshotInfo) error {
+		if !canAccess(arg.Id) {
+			return common.ErrPerm
+		}
+		err := s.sb.SetVolumeSnapshotInfo(arg.Id, state.VolumeSnapshotInfo{
+			SnapshotId: arg.SnapshotId,
+			Size:       arg.Size,
+		})
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Snapshots {
+		results.Results[i].Error = common.ServerError(one(arg))
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotStatus sets the status of the volume snapshots
+// with the specified IDs.
+func (s *StorageProvisionerAPIv6) SetVolumeSnapshotStatus(args params.VolumeSnapshotStatuses) (params.ErrorResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Statuses)),
+	}
+	one := func(arg params.VolumeSnapshotStatus) error {
+		if !canAccess(arg.Id) {
+			return common.ErrPerm
+		}
+		snapshot, err := s.sb.VolumeSnapshot(arg.Id)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return snapshot.SetStatus(status.StatusInfo{
+			Status:  status.Status(arg.Status),
+			Message: arg.Info,
+		})
+	}
+	for i, arg := range args.Statuses {
+		results.Results[i].Error = common.ServerError(one(arg))
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the dying volume snapshots with the
+// specified IDs from state, once they have been destroyed in the
+// storage provider.
+func (s *StorageProvisionerAPIv6) RemoveVolumeSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	canAccess, err := s.getVolumeSnapshotAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		if !canAccess(id) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err := s.sb.RemoveVolumeSnapshot(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -23,6 +23,16 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv6 provides the StorageProvisioner API v6 facade.
+type StorageProvisionerAPIv6 struct {
+	*StorageProvisionerAPIv5
+}
+
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -48,6 +58,16 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.Tag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv6 creates a new server-side StorageProvisioner v6 facade.
+func NewStorageProvisionerAPIv6(v5 *StorageProvisionerAPIv5) *StorageProvisionerAPIv6 {
+	return &StorageProvisionerAPIv6{v5}
+}
+
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -330,6 +350,24 @@ func (s *StorageProvisionerAPIv3) WatchFilesystems(args params.Entities) (params
 		w.WatchUnitManagedFilesystems)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that the caller can check
+// for volumes that have been requested to grow.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeResizes, s.sb.WatchMachineVolumeResizes, nil)
+}
+
+// WatchFilesystemResizes watches for changes to filesystems scoped to
+// the entity with the tag passed to NewState, so that the caller can
+// check for filesystems that have been requested to grow.
+func (s *StorageProvisionerAPIv5) WatchFilesystemResizes(args params.Entities) (params.StringsWatchResults, error) {
+	w := filesystemwatcher.Watchers{s.sb}
+	return s.watchStorageEntities(args,
+		w.WatchModelManagedFilesystemResizes,
+		w.WatchMachineManagedFilesystemResizes,
+		nil)
+}
+
 func (s *StorageProvisionerAPIv3) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -355,6 +393,9 @@ func (s *StorageProvisionerAPIv3) watchStorageEntities(
 		case names.ModelTag:
 			w = watchEnvironStorage()
 		case names.ApplicationTag:
+			if watchApplicationStorage == nil {
+				return "", nil, errors.NotSupportedf("watching storage for %v", tag)
+			}
 			w = watchApplicationStorage(tag)
 		default:
 			return "", nil, common.ServerError(errors.NotSupportedf("watching storage for %v", tag))
@@ -768,6 +809,10 @@ func (s *StorageProvisionerAPIv3) VolumeParams(args params.Entities) (params.Vol
 		if err != nil {
 			return params.VolumeParams{}, err
 		}
+		volumeParams.SnapshotId, err = storagecommon.VolumeSnapshotId(volume, s.sb.VolumeSnapshot)
+		if err != nil {
+			return params.VolumeParams{}, err
+		}
 		if len(volumeAttachments) == 1 {
 			// There is exactly one attachment to be made, so make
 			// it immediately. Otherwise we will defer attachments
@@ -987,6 +1032,126 @@ func (s *StorageProvisionerAPIv4) RemoveFilesystemParams(args params.Entities) (
 	return results, nil
 }
 
+// ResizeVolumeParams returns the parameters for growing the volumes
+// with the specified tags. A NotFound error is returned for volumes
+// that have no pending resize.
+func (s *StorageProvisionerAPIv5) ResizeVolumeParams(args params.Entities) (params.ResizeVolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeVolumeParamsResults{}, err
+	}
+	results := params.ResizeVolumeParamsResults{
+		Results: make([]params.ResizeVolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeVolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		}
+		volume, err := s.sb.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok || volume.Life() != state.Alive {
+			return params.ResizeVolumeParams{}, errors.NotFoundf(
+				"resize of %s", names.ReadableString(tag),
+			)
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		return params.ResizeVolumeParams{
+			Provider: string(provider),
+			VolumeId: volumeInfo.VolumeId,
+			Size:     size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeVolumeParamsResult
+		volumeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = volumeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// ResizeFilesystemParams returns the parameters for growing the
+// filesystems with the specified tags. A NotFound error is returned
+// for filesystems that have no pending resize.
+func (s *StorageProvisionerAPIv5) ResizeFilesystemParams(args params.Entities) (params.ResizeFilesystemParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeFilesystemParamsResults{}, err
+	}
+	results := params.ResizeFilesystemParamsResults{
+		Results: make([]params.ResizeFilesystemParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeFilesystemParams, error) {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeFilesystemParams{}, common.ErrPerm
+		}
+		filesystem, err := s.sb.Filesystem(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeFilesystemParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		size, ok := filesystem.RequestedSize()
+		if !ok || filesystem.Life() != state.Alive {
+			return params.ResizeFilesystemParams{}, errors.NotFoundf(
+				"resize of %s", names.ReadableString(tag),
+			)
+		}
+		filesystemInfo, err := filesystem.Info()
+		if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			filesystemInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeFilesystemParams{}, err
+		}
+		result := params.ResizeFilesystemParams{
+			Provider:     string(provider),
+			FilesystemId: filesystemInfo.FilesystemId,
+			Size:         size,
+		}
+		if volumeTag, err := filesystem.Volume(); err == nil {
+			result.VolumeTag = volumeTag.String()
+		} else if err != state.ErrNoBackingVolume {
+			return params.ResizeFilesystemParams{}, err
+		}
+		return result, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeFilesystemParamsResult
+		filesystemParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = filesystemParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // VolumeAttachmentParams returns the parameters for creating the volume
 // attachments with the specified IDs.
 func (s *StorageProvisionerAPIv3) VolumeAttachmentParams(
@@ -1316,6 +1481,14 @@ func (s *StorageProvisionerAPIv3) SetVolumeInfo(args params.Volumes) (params.Err
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		if volume, err := s.sb.Volume(volumeTag); err == nil {
+			// The pool is immutable once the volume is provisioned,
+			// and is not known to the caller; carry it over when
+			// updating the info, e.g. after resizing the volume.
+			if oldInfo, err := volume.Info(); err == nil {
+				volumeInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.sb.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -1345,6 +1518,14 @@ func (s *StorageProvisionerAPIv3) SetFilesystemInfo(args params.Filesystems) (pa
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		if filesystem, err := s.sb.Filesystem(filesystemTag); err == nil {
+			// The pool is immutable once the filesystem is provisioned,
+			// and is not known to the caller; carry it over when
+			// updating the info, e.g. after resizing the filesystem.
+			if oldInfo, err := filesystem.Info(); err == nil {
+				filesystemInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.sb.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
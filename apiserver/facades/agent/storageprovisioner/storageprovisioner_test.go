@@ -55,7 +55,7 @@ type provisionerSuite struct {
 
 	resources      *common.Resources
 	authorizer     *apiservertesting.FakeAuthorizer
-	api            *storageprovisioner.StorageProvisionerAPIv4
+	api            *storageprovisioner.StorageProvisionerAPIv6
 	storageBackend storageprovisioner.StorageBackend
 }
 
@@ -86,7 +86,9 @@ func (s *iaasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
@@ -118,7 +120,9 @@ func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv6(
+		storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3)),
+	)
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -629,6 +633,202 @@ func (s *iaasProvisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+
+	// Deploy an application that will create a storage instance,
+	// so we can request that its volume be resized.
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-block",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: application,
+	})
+	testStorage, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testStorage, gc.HasLen, 1)
+	storageVolume, err := s.storageBackend.StorageInstanceVolume(testStorage[0].StorageTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeInfo(storageVolume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "zing",
+		Size:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeStorageInstance(testStorage[0].StorageTag(), 10)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{
+			{storageVolume.Tag().String()},
+			{"volume-2"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{{
+			Result: params.ResizeVolumeParams{
+				Provider: "modelscoped",
+				VolumeId: "zing",
+				Size:     10,
+			},
+		}, {
+			Error: &params.Error{Message: `resize of volume 2 not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) setupVolumeSnapshot(c *gc.C) (names.VolumeTag, string) {
+	// Deploy an application that will create a storage instance,
+	// so we can take a snapshot of its volume.
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-block",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: application,
+	})
+	testStorage, err := s.storageBackend.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testStorage, gc.HasLen, 1)
+	storageVolume, err := s.storageBackend.StorageInstanceVolume(testStorage[0].StorageTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeInfo(storageVolume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "zing",
+		Size:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := sb.AddVolumeSnapshot(storageVolume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	return storageVolume.VolumeTag(), id
+}
+
+func (s *iaasProvisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumes(c)
+	volumeTag, id := s.setupVolumeSnapshot(c)
+
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{id, "42", "!"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{{
+			Result: params.VolumeSnapshotParams{
+				Id:        id,
+				Life:      life.Alive,
+				VolumeTag: volumeTag.String(),
+				VolumeId:  "zing",
+				Provider:  "modelscoped",
+				Tags: map[string]string{
+					tags.JujuController: testing.ControllerTag.Id(),
+					tags.JujuModel:      testing.ModelTag.Id(),
+				},
+			},
+		}, {
+			Error: &params.Error{Message: `volume snapshot "42" not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	s.setupVolumes(c)
+	_, id := s.setupVolumeSnapshot(c)
+
+	results, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshotInfos{
+		Snapshots: []params.VolumeSnapshotInfo{
+			{Id: id, SnapshotId: "snap-zing", Size: 1},
+			{Id: "42", SnapshotId: "snap-42", Size: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	snapshot, err := s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-zing",
+		Size:       1,
+	})
+}
+
+func (s *iaasProvisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	_, id := s.setupVolumeSnapshot(c)
+
+	results, err := s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{id},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "removing volume snapshot .*: volume snapshot is not dying")
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err = s.api.RemoveVolumeSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{id},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	_, err = s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *iaasProvisionerSuite) TestResizeFilesystemParamsNoResize(c *gc.C) {
+	s.setupFilesystems(c)
+	results, err := s.api.ResizeFilesystemParams(params.Entities{
+		Entities: []params.Entity{
+			{"filesystem-0-0"},
+			{"filesystem-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeFilesystemParamsResults{
+		Results: []params.ResizeFilesystemParamsResult{{
+			Error: &params.Error{Message: `resize of filesystem 0/0 not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
 func (s *iaasProvisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -1056,6 +1256,44 @@ func (s *iaasProvisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *iaasProvisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.Model.ModelTag().String()},
+		{"application-mysql"},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: &params.Error{Message: `watching storage for application-mysql not supported`, Code: "not supported"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	// Check that the Watch has consumed the initial events ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	wc = statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *iaasProvisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	// Only IAAS models support block storage right now.
 	s.setupVolumes(c)
@@ -30,6 +30,7 @@ type storageInterface interface {
 type storageVolumeInterface interface {
 	StorageInstanceVolume(names.StorageTag) (state.Volume, error)
 	BlockDevices(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	WatchVolume(names.VolumeTag) state.NotifyWatcher
 	WatchVolumeAttachment(names.Tag, names.VolumeTag) state.NotifyWatcher
 	WatchBlockDevices(names.MachineTag) state.NotifyWatcher
 	VolumeAttachment(names.Tag, names.VolumeTag) (state.VolumeAttachment, error)
@@ -39,6 +40,7 @@ type storageVolumeInterface interface {
 type storageFilesystemInterface interface {
 	StorageInstanceFilesystem(names.StorageTag) (state.Filesystem, error)
 	FilesystemAttachment(names.Tag, names.FilesystemTag) (state.FilesystemAttachment, error)
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
 	WatchFilesystemAttachment(names.Tag, names.FilesystemTag) state.NotifyWatcher
 }
 
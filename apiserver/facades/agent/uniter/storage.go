@@ -206,6 +206,7 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		params.StorageKind(stateStorageInstance.Kind()),
 		info.Location,
 		life.Value(stateStorageAttachment.Life().String()),
+		info.Size,
 	}, nil
 }
 
@@ -461,8 +462,11 @@ func watchStorageAttachment(
 		// We need to watch both the volume attachment, and the
 		// machine's block devices. A volume attachment's block
 		// device could change (most likely, become present).
+		// The volume itself is watched so that the unit learns
+		// when it has been resized.
 		watchers = []state.NotifyWatcher{
 			stVolume.WatchVolumeAttachment(hostTag, volume.VolumeTag()),
+			stVolume.WatchVolume(volume.VolumeTag()),
 		}
 
 		// TODO(caas) - we currently only support block devices on machines.
@@ -486,6 +490,7 @@ func watchStorageAttachment(
 		}
 		watchers = []state.NotifyWatcher{
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
+			stFile.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
@@ -81,6 +81,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	volumeWatcher.changes <- struct{}{}
+	volumeSizeWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	volumeSizeWatcher.changes <- struct{}{}
 	blockDevicesWatcher := &mockNotifyWatcher{
 		changes: make(chan struct{}, 1),
 	}
@@ -110,6 +114,11 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(v, gc.DeepEquals, volumeTag)
 			return volumeWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeSizeWatcher
+		},
 		watchBlockDevices: func(m names.MachineTag) state.NotifyWatcher {
 			calls = append(calls, "WatchBlockDevices")
 			c.Assert(m, gc.DeepEquals, machineTag)
@@ -135,6 +144,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		"StorageInstance",
 		"StorageInstanceVolume",
 		"WatchVolumeAttachment",
+		"WatchVolume",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
 	})
@@ -173,6 +183,10 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		changes: make(chan struct{}, 1),
 	}
 	filesystemWatcher.changes <- struct{}{}
+	filesystemSizeWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	filesystemSizeWatcher.changes <- struct{}{}
 	var calls []string
 	st := &mockStorageState{
 		assignedMachine: assignedMachine,
@@ -198,6 +212,11 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 			c.Assert(f, gc.DeepEquals, filesystemTag)
 			return filesystemWatcher
 		},
+		watchFilesystem: func(f names.FilesystemTag) state.NotifyWatcher {
+			calls = append(calls, "WatchFilesystem")
+			c.Assert(f, gc.DeepEquals, filesystemTag)
+			return filesystemSizeWatcher
+		},
 	}
 
 	storage, err := uniter.NewStorageAPI(st, st, resources, getCanAccess)
@@ -218,6 +237,7 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"WatchFilesystem",
 		"WatchStorageAttachment",
 	})
 }
@@ -488,7 +508,9 @@ type mockStorageState struct {
 	storageInstanceVolume         func(names.StorageTag) (state.Volume, error)
 	watchStorageAttachments       func(names.UnitTag) state.StringsWatcher
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
+	watchFilesystem               func(names.FilesystemTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.Tag, names.FilesystemTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.Tag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
@@ -536,6 +558,14 @@ func (m *mockStorageState) WatchStorageAttachment(s names.StorageTag, u names.Un
 	return m.watchStorageAttachment(s, u)
 }
 
+func (m *mockStorageState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return m.watchFilesystem(f)
+}
+
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
 func (m *mockStorageState) WatchFilesystemAttachment(hostTag names.Tag, f names.FilesystemTag) state.NotifyWatcher {
 	return m.watchFilesystemAttachment(hostTag, f)
 }
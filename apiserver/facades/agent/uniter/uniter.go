@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
@@ -36,8 +37,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v15) of the Uniter API,
-// which adds the State, SetState calls and changes WatchActionNotifications to notify on action changes.
+// UniterAPI implements the latest version (v17) of the Uniter API,
+// which adds PublishCharmMetrics.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -74,10 +75,22 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV16 implements version (v16) of the Uniter API,
+// which adds HookTimeoutPolicy.
+type UniterAPIV16 struct {
+	UniterAPI
+}
+
+// UniterAPIV15 implements version (v15) of the Uniter API,
+// which adds the State, SetState calls and changes WatchActionNotifications to notify on action changes.
+type UniterAPIV15 struct {
+	UniterAPIV16
+}
+
 // UniterAPIV14 implements version (v14) of the Uniter API,
 // which adds GetPodSpec
 type UniterAPIV14 struct {
-	UniterAPI
+	UniterAPIV15
 }
 
 // UniterAPIV13 implements version (v13) of the Uniter API,
@@ -234,14 +247,36 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV16 creates an instance of the V16 uniter API.
+func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV16{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV15 creates an instance of the V15 uniter API.
+func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
+	uniterAPI, err := NewUniterAPIV16(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV15{
+		UniterAPIV16: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV14 creates an instance of the V14 uniter API.
 func NewUniterAPIV14(context facade.Context) (*UniterAPIV14, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV15(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV14{
-		UniterAPI: *uniterAPI,
+		UniterAPIV15: *uniterAPI,
 	}, nil
 }
 
@@ -1429,6 +1464,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = life.Value(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].MaintenanceMode = unit.MaintenanceMode()
 
 				var err1 error
 				result.Results[i].ProviderID, err1 = u.getProviderID(unit)
@@ -3296,3 +3332,115 @@ func (u *UniterAPI) SetState(args params.SetUnitStateArgs) (params.ErrorResults,
 
 	return params.ErrorResults{Results: res}, nil
 }
+
+// HookTimeoutPolicy isn't on the v15 API.
+func (u *UniterAPIV15) HookTimeoutPolicy(_ struct{}) {}
+
+// HookTimeoutPolicy returns the hook timeout policy of the application
+// of each given unit.
+func (u *UniterAPI) HookTimeoutPolicy(args params.Entities) (params.HookTimeoutPolicyResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.HookTimeoutPolicyResults{}, errors.Trace(err)
+	}
+
+	res := make([]params.HookTimeoutPolicyResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		unitTag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			res[i].Error = common.ServerError(err)
+			continue
+		}
+
+		if !canAccess(unitTag) {
+			res[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+
+		policy, err := u.hookTimeoutPolicy(unitTag)
+		if err != nil {
+			res[i].Error = common.ServerError(err)
+			continue
+		}
+		res[i].Result = policy
+	}
+
+	return params.HookTimeoutPolicyResults{Results: res}, nil
+}
+
+func (u *UniterAPI) hookTimeoutPolicy(unitTag names.UnitTag) (*params.HookTimeoutPolicy, error) {
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := coreapplication.ParseHookTimeoutPolicy(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.HookTimeoutPolicy{
+		Default:         policy.Default,
+		KillGracePeriod: policy.KillGracePeriod,
+	}
+	if len(policy.ByKind) > 0 {
+		result.ByKind = make(map[string]time.Duration)
+		for kind, timeout := range policy.ByKind {
+			result.ByKind[string(kind)] = timeout
+		}
+	}
+	return result, nil
+}
+
+// PublishCharmMetrics isn't on the v16 API.
+func (u *UniterAPIV16) PublishCharmMetrics(_ struct{}) {}
+
+// PublishCharmMetrics aggregates the charm metrics published by each
+// given unit, for export to the controller's Prometheus endpoint.
+func (u *UniterAPI) PublishCharmMetrics(args params.PublishCharmMetricsArgs) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	res := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		unitTag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			res[i].Error = common.ServerError(err)
+			continue
+		}
+
+		if !canAccess(unitTag) {
+			res[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+
+		unit, err := u.getUnit(unitTag)
+		if err != nil {
+			res[i].Error = common.ServerError(err)
+			continue
+		}
+
+		metrics := make([]state.CharmMetric, len(arg.Metrics))
+		for j, m := range arg.Metrics {
+			metrics[j] = state.CharmMetric{
+				Name:  m.Name,
+				Type:  state.CharmMetricType(m.Type),
+				Help:  m.Help,
+				Value: m.Value,
+			}
+		}
+		if err := unit.PublishCharmMetrics(metrics); err != nil {
+			res[i].Error = common.ServerError(err)
+		}
+	}
+
+	return params.ErrorResults{Results: res}, nil
+}
@@ -323,6 +323,73 @@ func (s *uniterSuite) TestState(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestHookTimeoutPolicy(c *gc.C) {
+	schema := environschema.Fields{
+		coreapplication.HookTimeoutConfigOptionName:      environschema.Attr{Type: environschema.Tstring},
+		coreapplication.HookKindTimeoutsConfigOptionName: environschema.Attr{Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.HookTimeoutConfigOptionName:      "10m",
+		coreapplication.HookKindTimeoutsConfigOptionName: "install=1h",
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: "not-a-unit-tag"},
+			{Tag: "unit-wordpress-0"},
+			{Tag: "unit-mysql-0"}, // not accessible by current user
+			{Tag: "unit-notfound-0"},
+		},
+	}
+	result, err := s.uniter.HookTimeoutPolicy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.HookTimeoutPolicyResults{
+		Results: []params.HookTimeoutPolicyResult{
+			{Error: &params.Error{Message: `"not-a-unit-tag" is not a valid tag`}},
+			{Result: &params.HookTimeoutPolicy{
+				Default:         10 * time.Minute,
+				ByKind:          map[string]time.Duration{"install": time.Hour},
+				KillGracePeriod: coreapplication.DefaultHookKillGracePeriod,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestPublishCharmMetrics(c *gc.C) {
+	metrics := []params.CharmMetric{
+		{Name: "queue_depth", Type: "gauge", Help: "Jobs waiting", Value: 3},
+		{Name: "requests", Type: "counter", Value: 7},
+	}
+	args := params.PublishCharmMetricsArgs{
+		Args: []params.PublishCharmMetricsArg{
+			{Tag: "not-a-unit-tag", Metrics: metrics},
+			{Tag: "unit-wordpress-0", Metrics: metrics},
+			{Tag: "unit-wordpress-0", Metrics: []params.CharmMetric{{Name: "bad-name", Type: "gauge"}}},
+			{Tag: "unit-mysql-0", Metrics: metrics}, // not accessible by current user
+		},
+	}
+	result, err := s.uniter.PublishCharmMetrics(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: `"not-a-unit-tag" is not a valid tag`}},
+			{},
+			{Error: &params.Error{Message: `charm metric name "bad-name" not valid`}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	published, err := s.wordpressUnit.CharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(published.Metrics, jc.DeepEquals, []state.CharmMetric{
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Help: "Jobs waiting", Value: 3},
+		{Name: "requests", Type: state.CharmMetricCounter, Value: 7},
+	})
+}
+
 func (s *uniterSuite) TestSetAgentStatus(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
@@ -3648,6 +3715,21 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshMaintenanceMode(c *gc.C) {
+	err := s.wordpressUnit.SetMaintenanceMode(true)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{
+			{Life: life.Alive, Resolved: params.ResolvedNone, MaintenanceMode: true},
+		},
+	})
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
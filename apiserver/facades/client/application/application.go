@@ -88,6 +88,24 @@ type APIv10 struct {
 // The Get call also returns the current endpoint bindings while the SetCharm
 // call access a map of operator-defined bindings.
 type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+// It adds TransferLeadership.
+type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// It adds SetUnitsMaintenanceMode.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds AllowEgress, RevokeEgress and GetEgressRules.
+type APIv14 struct {
 	*APIBase
 }
 
@@ -117,6 +135,10 @@ type APIBase struct {
 	registry              storage.ProviderRegistry
 	caasBroker            caasBrokerInterface
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
+
+	// leadership is nil when leadership can't be transferred,
+	// such as when the controller uses legacy leases.
+	leadership Leadership
 }
 
 // NewFacadeV4 provides the signature required for facade registration
@@ -186,13 +208,37 @@ func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
 }
 
 func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV12(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv11{api}, nil
 }
 
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacadeV13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -226,6 +272,11 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 
 	resources := ctx.Resources()
 
+	leadership, err := newLeadership(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting leadership")
+	}
+
 	return NewAPIBase(
 		&stateShim{ctx.State()},
 		storageAccess,
@@ -238,6 +289,7 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 		registry,
 		resources,
 		caasBroker,
+		leadership,
 	)
 }
 
@@ -254,6 +306,7 @@ func NewAPIBase(
 	registry storage.ProviderRegistry,
 	resources facade.Resources,
 	caasBroker caasBrokerInterface,
+	leadership Leadership,
 ) (*APIBase, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -271,6 +324,7 @@ func NewAPIBase(
 		registry:              registry,
 		resources:             resources,
 		caasBroker:            caasBroker,
+		leadership:            leadership,
 	}, nil
 }
 
@@ -386,16 +440,19 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 	}
 
 	for i, arg := range args.Applications {
-		err := deployApplication(
-			api.backend,
-			api.model,
-			api.stateCharm,
-			arg,
-			api.deployApplicationFunc,
-			api.storagePoolManager,
-			api.registry,
-			api.caasBroker,
-		)
+		err := api.backend.CheckModelQuotas(model.Usage{Units: uint64(arg.NumUnits)})
+		if err == nil {
+			err = deployApplication(
+				api.backend,
+				api.model,
+				api.stateCharm,
+				arg,
+				api.deployApplicationFunc,
+				api.storagePoolManager,
+				api.registry,
+				api.caasBroker,
+			)
+		}
 		result.Results[i].Error = common.ServerError(err)
 
 		if err != nil && len(arg.Resources) != 0 {
@@ -422,7 +479,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return addHookTimeoutSchemaAndDefaults(trustFields, trustDefaults)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -430,7 +487,11 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
-	return AddTrustSchemaAndDefaults(configSchema, defaults)
+	configSchema, defaults, err = AddTrustSchemaAndDefaults(configSchema, defaults)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addHookTimeoutSchemaAndDefaults(configSchema, defaults)
 }
 
 func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[string]string) (
@@ -643,6 +704,9 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateHookTimeoutConfig(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -1061,7 +1125,7 @@ func (api *APIBase) applicationSetCharm(
 	if len(params.StorageConstraints) > 0 {
 		stateStorageConstraints = make(map[string]state.StorageConstraints)
 		for name, cons := range params.StorageConstraints {
-			stateCons := state.StorageConstraints{Pool: cons.Pool}
+			stateCons := state.StorageConstraints{Pool: cons.Pool, Snapshot: cons.Snapshot}
 			if cons.Size != nil {
 				stateCons.Size = *cons.Size
 			}
@@ -1315,6 +1379,11 @@ func addApplicationUnits(backend Backend, modelType state.ModelType, args params
 	if args.NumUnits < 1 {
 		return nil, errors.New("must add at least one unit")
 	}
+	// Check all the units up front so that a request that would
+	// exceed the model's quotas adds no units at all.
+	if err := backend.CheckModelQuotas(model.Usage{Units: uint64(args.NumUnits)}); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	assignUnits := true
 	if modelType != state.ModelTypeIAAS {
@@ -2220,6 +2289,9 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := validateHookTimeoutConfig(appConfigAttrs); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, configSchema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -49,7 +49,7 @@ type applicationSuite struct {
 	jujutesting.JujuConnSuite
 	commontesting.BlockHelper
 
-	applicationAPI *application.APIv11
+	applicationAPI *application.APIv14
 	application    *state.Application
 	authorizer     *apiservertesting.FakeAuthorizer
 	repo           *mockRepo
@@ -113,7 +113,7 @@ func (s *applicationSuite) UploadCharmMultiSeries(c *gc.C, url, name string) (*c
 	return s.UploadCharm(c, url, name)
 }
 
-func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv11 {
+func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv14 {
 	resources := common.NewResources()
 	c.Assert(resources.RegisterNamed("dataDir", common.StringResource(c.MkDir())), jc.ErrorIsNil)
 	storageAccess, err := application.GetStorageState(s.State)
@@ -135,9 +135,10 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv11 {
 		registry,
 		common.NewResources(),
 		nil, // CAAS Broker not used in this suite.
+		nil, // Leadership not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv11{api}
+	return &application.APIv14{api}
 }
 
 func (s *applicationSuite) TestCharmConfig(c *gc.C) {
@@ -161,7 +162,13 @@ func (s *applicationSuite) TestCharmConfigV8(c *gc.C) {
 	api := &application.APIv8{
 		APIv9: &application.APIv9{
 			APIv10: &application.APIv10{
-				APIv11: s.applicationAPI,
+				APIv11: &application.APIv11{
+					APIv12: &application.APIv12{
+						APIv13: &application.APIv13{
+							APIv14: s.applicationAPI,
+						},
+					},
+				},
 			},
 		},
 	}
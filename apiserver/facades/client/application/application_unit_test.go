@@ -29,10 +29,12 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
@@ -55,7 +57,8 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv11
+	leadership   mockLeadership
+	api          *application.APIv14
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -83,9 +86,10 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.registry,
 		common.NewResources(),
 		s.caasBroker,
+		&s.leadership,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv11{api}
+	s.api = &application.APIv14{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -277,6 +281,9 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	s.blockChecker = mockBlockChecker{}
+	s.leadership = mockLeadership{
+		leaders: map[string]string{"postgresql": "postgresql/0"},
+	}
 	s.setAPIUser(c, names.NewUserTag("admin"))
 }
 
@@ -887,6 +894,33 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
 }
 
+func (s *ApplicationSuite) TestAddUnitsQuotaExceeded(c *gc.C) {
+	s.backend.quotaErr = &model.QuotaExceededError{Resource: "units", Limit: 10, Current: 9, Requested: 2}
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        2,
+	})
+	c.Assert(err, gc.ErrorMatches, "model units quota exceeded: 9 in use, 2 requested, limit is 10")
+	s.backend.CheckCall(c, 0, "CheckModelQuotas", model.Usage{Units: 2})
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDeployQuotaExceeded(c *gc.C) {
+	s.backend.quotaErr = &model.QuotaExceededError{Resource: "units", Limit: 1, Current: 1, Requested: 3}
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        3,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	s.backend.CheckCallNames(c, "CheckModelQuotas")
+}
+
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.AddUnits(params.AddApplicationUnits{
@@ -1096,7 +1130,7 @@ func (s *ApplicationSuite) TestSetNonOfferRelationStatus(c *gc.C) {
 		}},
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(results.OneError(), gc.ErrorMatches, `cannot set suspend status for "mediawiki:db mysql:db" which is not associated with an offer`)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot set suspend status for "mediawiki:db mysql:db" which is not associated with an offer`)
 }
 
 func (s *ApplicationSuite) TestBlockSetRelationSuspended(c *gc.C) {
@@ -1323,7 +1357,7 @@ func (s *ApplicationSuite) TestConsumeRemoteAppExistsDifferentSourceModel(c *gc.
 		Args: []params.ConsumeApplicationArg{arg},
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(results.OneError(), gc.ErrorMatches, `remote application called "hosted-mysql" from a different model already exists`)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `remote application called "hosted-mysql" from a different model already exists`)
 }
 
 func (s *ApplicationSuite) assertConsumeWithNoSpacesInfoAvailable(c *gc.C) {
@@ -1527,6 +1561,8 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
+	schema, defaults, err = application.AddHookTimeoutSchemaAndDefaults(schema, defaults)
+	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
@@ -1559,6 +1595,8 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
+	schema, defaults, err = application.AddHookTimeoutSchemaAndDefaults(schema, defaults)
+	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
@@ -1606,6 +1644,8 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
+	schema, defaults, err = application.AddHookTimeoutSchemaAndDefaults(schema, defaults)
+	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
 		[]string{"juju-external-hostname"}, schema, defaults)
@@ -1797,3 +1837,339 @@ func (s *ApplicationSuite) TestApplicationMergeBindingsErr(c *gc.C) {
 	app.CheckCallNames(c, "MergeBindings")
 	c.Assert(*result.Results[0].Error, gc.ErrorMatches, "boom")
 }
+
+func (s *ApplicationSuite) setUnitAgentStatus(appName string, agentStatus ...status.Status) {
+	for i, unit := range s.backend.applications[appName].units {
+		unit.agentStatus = agentStatus[i]
+	}
+}
+
+func (s *ApplicationSuite) TestTransferLeadership(c *gc.C) {
+	s.setUnitAgentStatus("postgresql", status.Idle, status.Idle)
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.TransferLeadershipResult{{
+		Leader: "postgresql/1",
+	}})
+	s.leadership.CheckCalls(c, []testing.StubCall{
+		{"Leaders", nil},
+		{"TransferLeadership", []interface{}{"postgresql", "postgresql/0", "postgresql/1", 2 * time.Minute}},
+	})
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipToUnit(c *gc.C) {
+	s.leadership.leaders["postgresql"] = "postgresql/1"
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.TransferLeadershipResult{{
+		Leader: "postgresql/0",
+	}})
+	s.leadership.CheckCallNames(c, "Leaders", "TransferLeadership")
+	s.leadership.CheckCall(c, 1, "TransferLeadership", "postgresql", "postgresql/1", "postgresql/0", 2*time.Minute)
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipNoHealthyUnit(c *gc.C) {
+	s.leadership.leaders["postgresql"] = "postgresql/1"
+	s.setUnitAgentStatus("postgresql", status.Error, status.Idle)
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `choosing new leader of "postgresql": no healthy unit available`)
+	s.leadership.CheckCallNames(c, "Leaders")
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipUnitNotInApplication(c *gc.C) {
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-redis-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `unit "redis/0" is not a unit of application "postgresql"`)
+	s.leadership.CheckCallNames(c, "Leaders")
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipNoLeader(c *gc.C) {
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-redis",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `application "redis" has no leader`)
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipPinned(c *gc.C) {
+	s.leadership.SetErrors(nil, leadership.ErrLeadershipPinned)
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `leadership of "postgresql" is pinned and cannot be transferred`)
+	s.leadership.CheckCallNames(c, "Leaders", "TransferLeadership")
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipLeaderChanged(c *gc.C) {
+	s.leadership.SetErrors(nil, leadership.NewNotLeaderError("postgresql/0", "postgresql"))
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`leadership of "postgresql" moved away from "postgresql/0" before it could be transferred`)
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipNotSupported(c *gc.C) {
+	api, err := application.NewAPIBase(
+		&s.backend,
+		&s.backend,
+		s.authorizer,
+		&s.blockChecker,
+		&s.model,
+		nil, nil,
+		s.storagePoolManager,
+		s.registry,
+		common.NewResources(),
+		s.caasBroker,
+		nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.TransferLeadership(params.TransferLeadershipArgs{})
+	c.Assert(err, gc.ErrorMatches, "transferring leadership with legacy leases not supported")
+}
+
+func (s *ApplicationSuite) TestBlockTransferLeadership(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.TransferLeadership(params.TransferLeadershipArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.leadership.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipControllersNotUpgraded(c *gc.C) {
+	s.leadership.SetErrors(nil, errors.NotSupportedf("transferring leadership until all controllers are running 2.8.0"))
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`transferring leadership of "postgresql" from "postgresql/0" to "postgresql/1": transferring leadership until all controllers are running 2.8.0 not supported`)
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.TransferLeadership(params.TransferLeadershipArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.leadership.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetUnitsMaintenanceMode(c *gc.C) {
+	results, err := s.api.SetUnitsMaintenanceMode(params.UnitsMaintenanceModeArgs{
+		Args: []params.UnitMaintenanceModeArg{{
+			UnitTag:         "unit-postgresql-0",
+			MaintenanceMode: true,
+		}, {
+			UnitTag:         "unit-postgresql-1",
+			MaintenanceMode: false,
+		}, {
+			UnitTag: "unit-postgresql-2",
+		}, {
+			UnitTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `unit "postgresql/2" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+
+	s.backend.CheckCallNames(c, "Unit", "Unit", "Unit")
+	units := s.backend.applications["postgresql"].units
+	units[0].CheckCall(c, 0, "SetMaintenanceMode", true)
+	units[1].CheckCall(c, 0, "SetMaintenanceMode", false)
+}
+
+func (s *ApplicationSuite) TestBlockSetUnitsMaintenanceMode(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetUnitsMaintenanceMode(params.UnitsMaintenanceModeArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetUnitsMaintenanceModePermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetUnitsMaintenanceMode(params.UnitsMaintenanceModeArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAllowEgress(c *gc.C) {
+	results, err := s.api.AllowEgress(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-postgresql",
+			Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/24"},
+			}, {
+				PortRange: params.PortRange{FromPort: 53, ToPort: 53, Protocol: "udp"},
+			}},
+		}, {
+			ApplicationTag: "application-postgresql",
+			Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"foo"},
+			}},
+		}, {
+			ApplicationTag: "application-unknown",
+		}, {
+			ApplicationTag: "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "egress rule: invalid CIDR address: foo")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "AllowEgress", []jujunetwork.EgressRule{
+		jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+		jujunetwork.MustNewEgressRule("udp", 53, 53),
+	})
+}
+
+func (s *ApplicationSuite) TestRevokeEgress(c *gc.C) {
+	results, err := s.api.RevokeEgress(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-postgresql",
+			Rules: []params.EgressRule{{
+				PortRange: params.PortRange{FromPort: 53, ToPort: 53, Protocol: "udp"},
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "RevokeEgress", []jujunetwork.EgressRule{
+		jujunetwork.MustNewEgressRule("udp", 53, 53),
+	})
+}
+
+func (s *ApplicationSuite) TestBlockAllowEgress(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.AllowEgress(params.ApplicationEgressRulesArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAllowEgressPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.AllowEgress(params.ApplicationEgressRulesArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAllowEgressCAASModel(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	_, err := s.api.AllowEgress(params.ApplicationEgressRulesArgs{})
+	c.Assert(err, gc.ErrorMatches, "egress rules on a k8s model not supported")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAllowEgressNoFirewallMode(c *gc.C) {
+	s.model.cfg["firewall-mode"] = "none"
+	_, err := s.api.AllowEgress(params.ApplicationEgressRulesArgs{})
+	c.Assert(err, gc.ErrorMatches, `egress rules in "none" firewall mode not supported`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestRevokeEgressNoFirewallMode(c *gc.C) {
+	// Egress rules may still be revoked, whatever the firewall mode.
+	s.model.cfg["firewall-mode"] = "none"
+	results, err := s.api.RevokeEgress(params.ApplicationEgressRulesArgs{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestResetEgress(c *gc.C) {
+	results, err := s.api.ResetEgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-unknown"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "unknown" not found`)
+
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "ResetEgress")
+}
+
+func (s *ApplicationSuite) TestBlockResetEgress(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.ResetEgress(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestGetEgressRules(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.restricted = true
+	app.egressRules = []jujunetwork.EgressRule{
+		jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	}
+	results, err := s.api.GetEgressRules(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-unknown"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{{
+			Restricted: true,
+			Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/24"},
+			}},
+		}, {
+			Error: &params.Error{Code: params.CodeNotFound, Message: `application "unknown" not found`},
+		}},
+	})
+}
@@ -18,9 +18,11 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/tools"
 )
@@ -49,6 +51,7 @@ type Backend interface {
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
 	Branch(string) (Generation, error)
+	CheckModelQuotas(model.Usage) error
 	state.EndpointBinding
 }
 
@@ -66,6 +69,7 @@ type BlockChecker interface {
 // the same names.
 type Application interface {
 	AddUnit(state.AddUnitParams) (Unit, error)
+	AllowEgress(...jujunetwork.EgressRule) error
 	AllUnits() ([]Unit, error)
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Charm() (Charm, bool, error)
@@ -76,11 +80,15 @@ type Application interface {
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
+	EgressRestricted() bool
+	EgressRules() []jujunetwork.EgressRule
 	EndpointBindings() (Bindings, error)
 	Endpoints() ([]state.Endpoint, error)
 	IsExposed() bool
 	IsPrincipal() bool
 	IsRemote() bool
+	ResetEgress() error
+	RevokeEgress(...jujunetwork.EgressRule) error
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
@@ -151,6 +159,8 @@ type Unit interface {
 	Life() state.Life
 	Resolve(retryHooks bool) error
 	AgentTools() (*tools.Tools, error)
+	AgentStatus() (status.StatusInfo, error)
+	SetMaintenanceMode(bool) error
 
 	AssignedMachineId() (string, error)
 	AssignWithPolicy(state.AssignmentPolicy) error
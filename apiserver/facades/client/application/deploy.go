@@ -142,9 +142,10 @@ func stateStorageConstraints(cons map[string]storage.Constraints) map[string]sta
 	result := make(map[string]state.StorageConstraints)
 	for name, cons := range cons {
 		result[name] = state.StorageConstraints{
-			Pool:  cons.Pool,
-			Size:  cons.Size,
-			Count: cons.Count,
+			Pool:     cons.Pool,
+			Size:     cons.Size,
+			Count:    cons.Count,
+			Snapshot: cons.Snapshot,
 		}
 	}
 	return result
@@ -0,0 +1,166 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// AllowEgress isn't on the v13 API.
+func (u *APIv13) AllowEgress(_, _ struct{}) {}
+
+// RevokeEgress isn't on the v13 API.
+func (u *APIv13) RevokeEgress(_, _ struct{}) {}
+
+// GetEgressRules isn't on the v13 API.
+func (u *APIv13) GetEgressRules(_, _ struct{}) {}
+
+// ResetEgress isn't on the v13 API.
+func (u *APIv13) ResetEgress(_, _ struct{}) {}
+
+// AllowEgress adds egress rules to each of the specified applications,
+// restricting their egress. Once an application's egress is restricted,
+// the firewaller only allows its workloads to send outgoing traffic
+// matching one of its egress rules.
+func (api *APIBase) AllowEgress(args params.ApplicationEgressRulesArgs) (params.ErrorResults, error) {
+	if err := api.checkCanChangeEgress(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	// Refuse to restrict egress where no firewaller runs to enforce
+	// it, rather than giving the impression that it is.
+	cfg, err := api.model.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if mode := cfg.FirewallMode(); mode == config.FwNone {
+		return params.ErrorResults{}, errors.NotSupportedf("egress rules in %q firewall mode", mode)
+	}
+	return api.changeEgress(args, Application.AllowEgress), nil
+}
+
+// RevokeEgress removes egress rules from each of the specified
+// applications. An application's egress stays restricted when its
+// last egress rule is revoked, so that its workloads may not send
+// outgoing traffic anywhere; see ResetEgress.
+func (api *APIBase) RevokeEgress(args params.ApplicationEgressRulesArgs) (params.ErrorResults, error) {
+	if err := api.checkCanChangeEgress(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	return api.changeEgress(args, Application.RevokeEgress), nil
+}
+
+// ResetEgress removes all egress rules from each of the specified
+// applications and lifts the restriction on their egress, so that
+// their workloads may send outgoing traffic anywhere again.
+func (api *APIBase) ResetEgress(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanChangeEgress(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Error = common.ServerError(app.ResetEgress())
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *APIBase) checkCanChangeEgress() error {
+	if err := api.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	if api.modelType == state.ModelTypeCAAS {
+		return errors.NotSupportedf("egress rules on a k8s model")
+	}
+	return nil
+}
+
+func (api *APIBase) changeEgress(
+	args params.ApplicationEgressRulesArgs,
+	change func(Application, ...network.EgressRule) error,
+) params.ErrorResults {
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.changeApplicationEgress(arg, change)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}
+}
+
+func (api *APIBase) changeApplicationEgress(
+	arg params.ApplicationEgressRules,
+	change func(Application, ...network.EgressRule) error,
+) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rules := make([]network.EgressRule, len(arg.Rules))
+	for i, rule := range arg.Rules {
+		rules[i], err = network.NewEgressRule(
+			rule.PortRange.Protocol,
+			rule.PortRange.FromPort,
+			rule.PortRange.ToPort,
+			rule.DestinationCIDRs...,
+		)
+		if err != nil {
+			return errors.NewNotValid(err, "egress rule")
+		}
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(change(app, rules...))
+}
+
+// GetEgressRules returns the egress rules of each of the specified
+// applications, and whether their egress is restricted to them.
+func (api *APIBase) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.EgressRulesResults{}, errors.Trace(err)
+	}
+	results := make([]params.EgressRulesResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		results[i] = api.applicationEgressRules(entity.Tag)
+	}
+	return params.EgressRulesResults{Results: results}, nil
+}
+
+func (api *APIBase) applicationEgressRules(tagString string) params.EgressRulesResult {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return params.EgressRulesResult{Error: common.ServerError(err)}
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return params.EgressRulesResult{Error: common.ServerError(err)}
+	}
+	result := params.EgressRulesResult{Restricted: app.EgressRestricted()}
+	for _, rule := range app.EgressRules() {
+		result.Rules = append(result.Rules, params.EgressRule{
+			PortRange:        params.FromNetworkPortRange(rule.PortRange),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		})
+	}
+	return result
+}
@@ -29,7 +29,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv11
+	applicationAPI *application.APIv14
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -58,14 +58,15 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		&mockStorageRegistry{},
 		common.NewResources(),
 		nil, // CAAS Broker not used in this suite.
+		nil, // Leadership not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv11{api}
+	s.applicationAPI = &application.APIv14{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{s.applicationAPI}}}}}}}
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{s.applicationAPI}}}}}}}}}}
 	results, err := v4.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -85,7 +86,7 @@ func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV5(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{s.applicationAPI}}}}}}
+	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{s.applicationAPI}}}}}}}}}
 	results, err := v5.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -128,7 +129,29 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 				"source":      "default",
 				"type":        environschema.Tbool,
 				"value":       false,
-			}},
+			},
+			"hook-timeout": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "How long a hook may run before it is stopped, e.g. 10m (empty for no timeout)",
+				"source":      "default",
+				"type":        environschema.Tstring,
+			},
+			"hook-kind-timeouts": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "Timeouts for particular kinds of hook, overriding hook-timeout, e.g. install=1h,update-status=2m",
+				"source":      "default",
+				"type":        environschema.Tstring,
+			},
+			"hook-kill-grace-period": map[string]interface{}{
+				"value":       "30s",
+				"default":     "30s",
+				"description": "How long a timed out hook has to exit after SIGTERM before it is sent SIGKILL",
+				"source":      "default",
+				"type":        environschema.Tstring,
+			},
+		},
 		Series: "quantal",
 		EndpointBindings: map[string]string{
 			"":                network.AlphaSpaceName,
@@ -157,6 +180,8 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 
 	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
+	schemaFields, defaults, err = application.AddHookTimeoutSchemaAndDefaults(schemaFields, defaults)
+	c.Assert(err, jc.ErrorIsNil)
 
 	appConfig, err := coreapplication.NewConfig(map[string]interface{}{"juju-external-hostname": "ext"}, schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
@@ -211,9 +236,10 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 		&mockStorageRegistry{},
 		common.NewResources(),
 		nil, // CAAS Broker not used in this suite.
+		nil, // Leadership not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{api}}}}
+	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{api}}}}}}}
 
 	results, err := apiV8.Get(params.ApplicationGet{ApplicationName: "dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -297,6 +323,27 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "How long a hook may run before it is stopped, e.g. 10m (empty for no timeout)",
+				"source":      "default",
+				"type":        "string",
+			},
+			"hook-kind-timeouts": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "Timeouts for particular kinds of hook, overriding hook-timeout, e.g. install=1h,update-status=2m",
+				"source":      "default",
+				"type":        "string",
+			},
+			"hook-kill-grace-period": map[string]interface{}{
+				"value":       "30s",
+				"default":     "30s",
+				"description": "How long a timed out hook has to exit after SIGTERM before it is sent SIGKILL",
+				"source":      "default",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 		EndpointBindings: map[string]string{
@@ -358,6 +405,27 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "How long a hook may run before it is stopped, e.g. 10m (empty for no timeout)",
+				"source":      "default",
+				"type":        "string",
+			},
+			"hook-kind-timeouts": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "Timeouts for particular kinds of hook, overriding hook-timeout, e.g. install=1h,update-status=2m",
+				"source":      "default",
+				"type":        "string",
+			},
+			"hook-kill-grace-period": map[string]interface{}{
+				"value":       "30s",
+				"default":     "30s",
+				"description": "How long a timed out hook has to exit after SIGTERM before it is sent SIGKILL",
+				"source":      "default",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 		EndpointBindings: map[string]string{
@@ -378,6 +446,27 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"hook-timeout": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "How long a hook may run before it is stopped, e.g. 10m (empty for no timeout)",
+				"source":      "default",
+				"type":        "string",
+			},
+			"hook-kind-timeouts": map[string]interface{}{
+				"value":       "",
+				"default":     "",
+				"description": "Timeouts for particular kinds of hook, overriding hook-timeout, e.g. install=1h,update-status=2m",
+				"source":      "default",
+				"type":        "string",
+			},
+			"hook-kill-grace-period": map[string]interface{}{
+				"value":       "30s",
+				"default":     "30s",
+				"description": "How long a timed out hook has to exit after SIGTERM before it is sent SIGKILL",
+				"source":      "default",
+				"type":        "string",
+			},
 		},
 		EndpointBindings: map[string]string{
 			"":                  network.AlphaSpaceName,
@@ -0,0 +1,66 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var hookTimeoutFields = environschema.Fields{
+	application.HookTimeoutConfigOptionName: {
+		Description: "How long a hook may run before it is stopped, e.g. 10m (empty for no timeout)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookKindTimeoutsConfigOptionName: {
+		Description: "Timeouts for particular kinds of hook, overriding hook-timeout, e.g. install=1h,update-status=2m",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookKillGracePeriodConfigOptionName: {
+		Description: "How long a timed out hook has to exit after SIGTERM before it is sent SIGKILL",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var hookTimeoutDefaults = schema.Defaults{
+	application.HookTimeoutConfigOptionName:         "",
+	application.HookKindTimeoutsConfigOptionName:    "",
+	application.HookKillGracePeriodConfigOptionName: application.DefaultHookKillGracePeriod.String(),
+}
+
+// addHookTimeoutSchemaAndDefaults adds the hook timeout schema fields
+// and defaults to an existing set of schema fields and defaults.
+func addHookTimeoutSchemaAndDefaults(extra environschema.Fields, defaults schema.Defaults) (environschema.Fields, schema.Defaults, error) {
+	fields := make(environschema.Fields)
+	for name, field := range hookTimeoutFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := hookTimeoutFields[name]; ok {
+			return nil, nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	newDefaults := make(schema.Defaults)
+	for key, value := range hookTimeoutDefaults {
+		newDefaults[key] = value
+	}
+	for key, value := range defaults {
+		newDefaults[key] = value
+	}
+	return fields, newDefaults, nil
+}
+
+// validateHookTimeoutConfig returns an error if the hook timeout
+// options in the application config attributes are not valid.
+func validateHookTimeoutConfig(attrs map[string]interface{}) error {
+	_, err := application.ParseHookTimeoutPolicy(attrs)
+	return errors.Trace(err)
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// transferredLeadershipDuration is the length of the leadership lease
+// given to the unit receiving leadership. It is longer than the minute
+// lease managers may take to notice that the lease changed hands and
+// wake the unit's agent, which then extends the lease.
+const transferredLeadershipDuration = 2 * time.Minute
+
+// Leadership describes the leadership operations needed to transfer
+// leadership of an application from one unit to another.
+type Leadership interface {
+	leadership.Reader
+	leadership.Transferer
+}
+
+type leadershipShim struct {
+	leadership.Reader
+	leadership.Transferer
+}
+
+// newLeadership returns the Leadership used by the facade, or nil if
+// leadership can't be transferred in the model.
+func newLeadership(ctx facade.Context) (Leadership, error) {
+	modelUUID := ctx.State().ModelUUID()
+	transferer, err := ctx.LeadershipTransferer(modelUUID)
+	if errors.IsNotImplemented(err) {
+		// Legacy leases don't support transferring leadership.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reader, err := ctx.LeadershipReader(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return leadershipShim{
+		Reader:     reader,
+		Transferer: transferer,
+	}, nil
+}
+
+// TransferLeadership isn't on the v11 API.
+func (u *APIv11) TransferLeadership(_, _ struct{}) {}
+
+// TransferLeadership moves leadership of each specified application
+// from its current leader to the specified unit, or to the next healthy
+// unit of the application if no unit is specified.
+func (api *APIBase) TransferLeadership(args params.TransferLeadershipArgs) (params.TransferLeadershipResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.TransferLeadershipResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.TransferLeadershipResults{}, errors.Trace(err)
+	}
+	if api.leadership == nil {
+		return params.TransferLeadershipResults{}, errors.NotSupportedf("transferring leadership with legacy leases")
+	}
+	leaders, err := api.leadership.Leaders()
+	if err != nil {
+		return params.TransferLeadershipResults{}, errors.Trace(err)
+	}
+	results := make([]params.TransferLeadershipResult, len(args.Args))
+	for i, arg := range args.Args {
+		leader, err := api.transferLeadership(arg, leaders)
+		results[i].Leader = leader
+		results[i].Error = common.ServerError(err)
+	}
+	return params.TransferLeadershipResults{Results: results}, nil
+}
+
+func (api *APIBase) transferLeadership(arg params.TransferLeadershipArg, leaders map[string]string) (string, error) {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	name := appTag.Id()
+	app, err := api.backend.Application(name)
+	if errors.IsNotFound(err) {
+		return "", errors.Errorf("application %q does not exist", name)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	leader, ok := leaders[name]
+	if !ok {
+		return "", errors.Errorf("application %q has no leader", name)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	var target string
+	if arg.UnitTag != "" {
+		unitTag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		target = unitTag.Id()
+		if target == leader {
+			return leader, nil
+		}
+		unit := findUnit(units, target)
+		if unit == nil {
+			return "", errors.Errorf("unit %q is not a unit of application %q", target, name)
+		}
+		if unit.Life() != state.Alive {
+			return "", errors.Errorf("unit %q is not alive", target)
+		}
+	} else {
+		target, err = nextHealthyUnit(units, leader)
+		if err != nil {
+			return "", errors.Annotatef(err, "choosing new leader of %q", name)
+		}
+	}
+
+	err = api.leadership.TransferLeadership(name, leader, target, transferredLeadershipDuration)
+	if errors.Cause(err) == leadership.ErrLeadershipPinned {
+		return "", errors.Errorf("leadership of %q is pinned and cannot be transferred", name)
+	} else if leadership.IsNotLeaderError(err) {
+		return "", errors.Errorf("leadership of %q moved away from %q before it could be transferred", name, leader)
+	} else if err != nil {
+		return "", errors.Annotatef(err, "transferring leadership of %q from %q to %q", name, leader, target)
+	}
+	logger.Infof("transferring leadership of %q from %q to %q", name, leader, target)
+	return target, nil
+}
+
+func findUnit(units []Unit, name string) Unit {
+	for _, unit := range units {
+		if unit.Name() == name {
+			return unit
+		}
+	}
+	return nil
+}
+
+// nextHealthyUnit returns the name of the first healthy unit numbered
+// after the leader, wrapping around to the lowest numbered unit.
+func nextHealthyUnit(units []Unit, leader string) (string, error) {
+	sorted := make([]Unit, len(units))
+	copy(sorted, units)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UnitTag().Number() < sorted[j].UnitTag().Number()
+	})
+	start := 0
+	if names.IsValidUnit(leader) {
+		leaderNumber := names.NewUnitTag(leader).Number()
+		for i, unit := range sorted {
+			if unit.UnitTag().Number() > leaderNumber {
+				start = i
+				break
+			}
+		}
+	}
+	for i := range sorted {
+		unit := sorted[(start+i)%len(sorted)]
+		if unit.Name() == leader {
+			continue
+		}
+		healthy, err := isHealthy(unit)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if healthy {
+			return unit.Name(), nil
+		}
+	}
+	return "", errors.New("no healthy unit available")
+}
+
+func isHealthy(unit Unit) (bool, error) {
+	if unit.Life() != state.Alive {
+		return false, nil
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return false, errors.Annotatef(err, "getting agent status of %q", unit.Name())
+	}
+	switch agentStatus.Status {
+	case status.Idle, status.Executing:
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SetUnitsMaintenanceMode isn't on the v12 API.
+func (u *APIv12) SetUnitsMaintenanceMode(_, _ struct{}) {}
+
+// SetUnitsMaintenanceMode puts each of the specified units into, or takes
+// it out of, maintenance mode. Hooks aren't run for a unit in maintenance
+// mode; changes made in the meantime are acted on once it ends.
+func (api *APIBase) SetUnitsMaintenanceMode(args params.UnitsMaintenanceModeArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.setUnitMaintenanceMode(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *APIBase) setUnitMaintenanceMode(arg params.UnitMaintenanceModeArg) error {
+	tag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(unit.SetMaintenanceMode(arg.MaintenanceMode))
+}
@@ -29,10 +29,12 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/storage"
@@ -101,6 +103,8 @@ type mockApplication struct {
 	constraints constraints.Value
 	channel     csparams.Channel
 	exposed     bool
+	egressRules []jujunetwork.EgressRule
+	restricted  bool
 	remote      bool
 	agentTools  *tools.Tools
 }
@@ -243,6 +247,31 @@ func (a *mockApplication) IsExposed() bool {
 	return a.exposed
 }
 
+func (a *mockApplication) AllowEgress(rules ...jujunetwork.EgressRule) error {
+	a.MethodCall(a, "AllowEgress", rules)
+	return a.NextErr()
+}
+
+func (a *mockApplication) RevokeEgress(rules ...jujunetwork.EgressRule) error {
+	a.MethodCall(a, "RevokeEgress", rules)
+	return a.NextErr()
+}
+
+func (a *mockApplication) ResetEgress() error {
+	a.MethodCall(a, "ResetEgress")
+	return a.NextErr()
+}
+
+func (a *mockApplication) EgressRestricted() bool {
+	a.MethodCall(a, "EgressRestricted")
+	return a.restricted
+}
+
+func (a *mockApplication) EgressRules() []jujunetwork.EgressRule {
+	a.MethodCall(a, "EgressRules")
+	return a.egressRules
+}
+
 func (a *mockApplication) IsRemote() bool {
 	a.MethodCall(a, "IsRemote")
 	return a.remote
@@ -362,6 +391,7 @@ type mockBackend struct {
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
+	quotaErr                   error
 }
 
 type mockFilesystemAccess struct {
@@ -385,6 +415,11 @@ func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, err
 	return nil, false, nil
 }
 
+func (m *mockBackend) CheckModelQuotas(requested model.Usage) error {
+	m.MethodCall(m, "CheckModelQuotas", requested)
+	return m.quotaErr
+}
+
 func (m *mockBackend) Charm(curl *charm.URL) (application.Charm, error) {
 	m.MethodCall(m, "Charm", curl)
 	if err := m.NextErr(); err != nil {
@@ -763,10 +798,12 @@ func (r *mockRelation) DestroyWithForce(force bool, maxWait time.Duration) ([]er
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag        names.UnitTag
-	machineId  string
-	name       string
-	agentTools *tools.Tools
+	tag         names.UnitTag
+	machineId   string
+	name        string
+	agentTools  *tools.Tools
+	life        state.Life
+	agentStatus status.Status
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -818,6 +855,21 @@ func (u *mockUnit) AgentTools() (*tools.Tools, error) {
 	return u.agentTools, u.NextErr()
 }
 
+func (u *mockUnit) Life() state.Life {
+	u.MethodCall(u, "Life")
+	return u.life
+}
+
+func (u *mockUnit) SetMaintenanceMode(maintenance bool) error {
+	u.MethodCall(u, "SetMaintenanceMode", maintenance)
+	return u.NextErr()
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	return status.StatusInfo{Status: u.agentStatus}, u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
@@ -989,3 +1041,22 @@ func (m *mockRepo) Get(curl *charm.URL, path string) (*charm.CharmArchive, error
 	}
 	return results[0].(*charm.CharmArchive), jtesting.TypeAssertError(results[1])
 }
+
+type mockLeadership struct {
+	jtesting.Stub
+	leaders map[string]string
+}
+
+func (m *mockLeadership) Leaders() (map[string]string, error) {
+	m.MethodCall(m, "Leaders")
+	return m.leaders, m.NextErr()
+}
+
+func (m *mockLeadership) TransferLeadership(applicationName, unitName, newUnitName string, duration time.Duration) error {
+	m.MethodCall(m, "TransferLeadership", applicationName, unitName, newUnitName, duration)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.leaders[applicationName] = newUnitName
+	return nil
+}
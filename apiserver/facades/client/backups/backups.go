@@ -54,6 +54,12 @@ type APIv2 struct {
 	*API
 }
 
+// APIv3 serves backup-specific API methods for version 3, which adds
+// support for encrypted backup archives.
+type APIv3 struct {
+	*APIv2
+}
+
 func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	api, err := NewAPI(backend, resources, authorizer)
 	if err != nil {
@@ -62,6 +68,15 @@ func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Aut
 	return &APIv2{api}, nil
 }
 
+// NewAPIv3 creates a new instance of the version 3 Backups API facade.
+func NewAPIv3(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewAPIv2(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -130,9 +145,12 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 	return strRes.String(), nil
 }
 
-var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+var newBackups = func(backend Backend) (backups.Backups, io.Closer, error) {
+	stor, err := backups.OpenStorage(backend)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // CreateResult updates the result with the information in the
@@ -166,6 +184,8 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.HANodes = meta.Controller.HANodes
 	result.ControllerMachineID = meta.Controller.MachineID
 	result.ControllerMachineInstanceID = meta.Controller.MachineInstanceID
+	result.Encryption = meta.Encryption
+	result.EncryptionKeyID = meta.EncryptionKeyID
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -194,6 +214,8 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Encryption = result.Encryption
+	meta.EncryptionKeyID = result.EncryptionKeyID
 	meta.FormatVersion = result.FormatVersion
 	meta.Controller = backups.ControllerMetadata{
 		UUID:              result.ControllerUUID,
@@ -26,7 +26,7 @@ type backupsSuite struct {
 	testing.JujuConnSuite
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
-	api        *backupsAPI.APIv2
+	api        *backupsAPI.APIv3
 	meta       *backups.Metadata
 	machineTag names.MachineTag
 }
@@ -63,7 +63,7 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 		controllerNodesF: func() ([]state.ControllerNode, error) { return nil, nil },
 		machineF:         func(id string) (backupsAPI.Machine, error) { return &testMachine{}, nil },
 	}
-	s.api, err = backupsAPI.NewAPIv2(shim, s.resources, s.authorizer)
+	s.api, err = backupsAPI.NewAPIv3(shim, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.meta = backupstesting.NewMetadataStarted()
 }
@@ -80,8 +80,8 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 		fake.Error = errors.Errorf(err)
 	}
 	s.PatchValue(backupsAPI.NewBackups,
-		func(backupsAPI.Backend) (backups.Backups, io.Closer) {
-			return &fake, ioutil.NopCloser(nil)
+		func(backupsAPI.Backend) (backups.Backups, io.Closer, error) {
+			return &fake, ioutil.NopCloser(nil), nil
 		},
 	)
 	return &fake
@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -43,6 +45,26 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.meta.Encryption = statebackups.EncryptionPublicKey
+	s.meta.EncryptionKeyID = backupstesting.PublicKeyID
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		PublicKey: backupstesting.PublicKey,
+	}
+
+	result, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.EncryptionKeyArg, jc.DeepEquals, statebackups.EncryptionKey{
+		PublicKey: backupstesting.PublicKey,
+	})
+	c.Check(result.Encryption, gc.Equals, statebackups.EncryptionPublicKey)
+	c.Check(result.EncryptionKeyID, gc.Equals, backupstesting.PublicKeyID)
+}
+
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	s.PatchValue(backups.WaitUntilReady,
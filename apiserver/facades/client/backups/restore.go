@@ -24,7 +24,10 @@ func (a *API) Restore(p params.RestoreArgs) error {
 	logger.Infof("Starting server side restore")
 
 	// Get hold of a backup file Reader
-	backup, closer := newBackups(a.backend)
+	backup, closer, err := newBackups(a.backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer closer.Close()
 
 	// Obtain the address of current machine, where we will be performing restore.
@@ -66,6 +69,10 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstId:      instanceId,
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
+		Decryption: backups.DecryptionKey{
+			Passphrase: p.Passphrase,
+			PrivateKey: p.PrivateKey,
+		},
 	}
 
 	session := a.backend.MongoSession().Copy()
@@ -34,6 +34,15 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
+// NewFacadeV3 provides the required signature for version 3 facade registration.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPIv3(&stateShim{st, model}, resources, authorizer)
+}
+
 // NewFacadeV2 provides the required signature for version 2 facade registration.
 func NewFacadeV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	model, err := st.Model()
@@ -51,7 +51,11 @@ func (ctx *charmsSuiteContext) LeadershipClaimer(string) (leadership.Claimer, er
 func (ctx *charmsSuiteContext) LeadershipChecker() (leadership.Checker, error)       { return nil, nil }
 func (ctx *charmsSuiteContext) LeadershipPinner(string) (leadership.Pinner, error)   { return nil, nil }
 func (ctx *charmsSuiteContext) LeadershipReader(string) (leadership.Reader, error)   { return nil, nil }
-func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error)              { return nil, nil }
+func (ctx *charmsSuiteContext) LeadershipTransferer(string) (leadership.Transferer, error) {
+	return nil, nil
+}
+func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error) { return nil, nil }
+func (ctx *charmsSuiteContext) LogDir() string                          { return "" }
 
 func (s *charmsSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
@@ -44,6 +44,7 @@ type Backend interface {
 	AllLinkLayerDevices() ([]*state.LinkLayerDevice, error)
 	AllRelations() ([]*state.Relation, error)
 	AllSubnets() ([]*state.Subnet, error)
+	AllStatusHistory(state.ModelStatusHistoryFilter) (state.ModelStatusHistory, error)
 	Annotations(state.GlobalEntity) (map[string]string, error)
 	APIHostPortsForClients() ([]network.SpaceHostPorts, error)
 	Application(string) (*state.Application, error)
@@ -128,6 +129,10 @@ func (s *stateShim) SetAnnotations(entity state.GlobalEntity, ann map[string]str
 	return s.model.SetAnnotations(entity, ann)
 }
 
+func (s *stateShim) AllStatusHistory(filter state.ModelStatusHistoryFilter) (state.ModelStatusHistory, error) {
+	return s.model.AllStatusHistory(filter)
+}
+
 func (s *stateShim) Unit(name string) (Unit, error) {
 	u, err := s.State.Unit(name)
 	if err != nil {
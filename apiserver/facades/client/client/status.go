@@ -165,6 +165,56 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// maxModelStatusHistoryPage is the largest number of status history
+// records read by a single ModelStatusHistory call.
+const maxModelStatusHistoryPage = 1000
+
+// ModelStatusHistory returns a page of the status history of every
+// entity in the model, merged into a single timeline ordered by the
+// time each entry was recorded.
+func (c *Client) ModelStatusHistory(args params.ModelStatusHistoryRequest) (params.ModelStatusHistoryResult, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.ModelStatusHistoryResult{}, err
+	}
+	filter := state.ModelStatusHistoryFilter{
+		Kinds:   set.NewStrings(args.Kinds...),
+		Exclude: set.NewStrings(args.Exclude...),
+		After:   args.After,
+		Size:    args.Size,
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	if filter.Size <= 0 || filter.Size > maxModelStatusHistoryPage {
+		filter.Size = maxModelStatusHistoryPage
+	}
+	history, err := c.api.stateAccessor.AllStatusHistory(filter)
+	if err != nil {
+		return params.ModelStatusHistoryResult{}, errors.Trace(err)
+	}
+	result := params.ModelStatusHistoryResult{
+		Entries: make([]params.ModelStatusHistoryEntry, len(history.Entries)),
+		Next:    history.Next,
+	}
+	for i, entry := range history.Entries {
+		result.Entries[i] = params.ModelStatusHistoryEntry{
+			Kind:   string(entry.Kind),
+			Entity: entry.Entity,
+			Status: string(entry.Status),
+			Info:   entry.Message,
+			Data:   entry.Data,
+			Since:  entry.Since,
+		}
+	}
+	return result, nil
+}
+
+// ModelStatusHistory isn't on the v2 API.
+func (c *ClientV2) ModelStatusHistory(_ struct{}) {}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -1170,6 +1220,11 @@ func (context *statusContext) processApplication(application *state.Application)
 	}
 	var unitNames []string
 	for _, unit := range units {
+		// Units in maintenance mode don't contribute to the
+		// application status.
+		if unit.MaintenanceMode() {
+			continue
+		}
 		unitNames = append(unitNames, unit.Name())
 	}
 	applicationStatus, err := context.status.Application(application.Name(), unitNames)
@@ -1389,6 +1444,7 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	if leader := context.leaders[unit.ApplicationName()]; leader == unit.Name() {
 		result.Leader = true
 	}
+	result.MaintenanceMode = unit.MaintenanceMode()
 	containerInfo, err := unit.ContainerInfo()
 	if err != nil && !errors.IsNotFound(err) {
 		logger.Debugf("error fetching container info: %v", err)
@@ -6,6 +6,7 @@ package client_test
 import (
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +17,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
 
@@ -230,6 +232,14 @@ type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+
+	modelHistory       state.ModelStatusHistory
+	modelHistoryFilter state.ModelStatusHistoryFilter
+}
+
+func (m *mockState) AllStatusHistory(filter state.ModelStatusHistoryFilter) (state.ModelStatusHistory, error) {
+	m.modelHistoryFilter = filter
+	return m.modelHistory, nil
 }
 
 func (m *mockState) ModelUUID() string {
@@ -280,3 +290,45 @@ func (s statuses) StatusHistory(filter status.StatusHistoryFilter) ([]status.Sta
 	}
 	return s[:filter.Size], nil
 }
+
+func (s *statusHistoryTestSuite) TestModelStatusHistory(c *gc.C) {
+	since := time.Unix(1000, 0)
+	s.st.modelHistory = state.ModelStatusHistory{
+		Entries: []state.ModelStatusHistoryEntry{{
+			StatusInfo: status.StatusInfo{
+				Status:  status.Active,
+				Message: "ready",
+				Data:    map[string]interface{}{"foo": "bar"},
+				Since:   &since,
+			},
+			Kind:   status.KindWorkload,
+			Entity: "unit/0",
+		}},
+		Next: "cursor",
+	}
+	from := time.Unix(500, 0)
+	result, err := s.api.ModelStatusHistory(params.ModelStatusHistoryRequest{
+		From:  &from,
+		Kinds: []string{"workload"},
+		After: "previous",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelStatusHistoryResult{
+		Entries: []params.ModelStatusHistoryEntry{{
+			Kind:   "workload",
+			Entity: "unit/0",
+			Status: "active",
+			Info:   "ready",
+			Data:   map[string]interface{}{"foo": "bar"},
+			Since:  &since,
+		}},
+		Next: "cursor",
+	})
+	c.Assert(s.st.modelHistoryFilter, jc.DeepEquals, state.ModelStatusHistoryFilter{
+		From:    from,
+		Kinds:   set.NewStrings("workload"),
+		Exclude: set.NewStrings(),
+		After:   "previous",
+		Size:    1000,
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+)
+
+// AuditLog isn't on the v8 API.
+func (c *ControllerAPIv8) AuditLog(_, _ struct{}) {}
+
+// AuditLog returns the records from this controller's audit log that
+// match the query. In an HA controller each controller machine only
+// records the conversations it served, so clients wanting the whole
+// log need to ask each of them.
+func (c *ControllerAPI) AuditLog(args params.AuditLogQuery) (params.AuditLogResult, error) {
+	result := params.AuditLogResult{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	filter := auditlog.Filter{
+		Who:            args.User,
+		ModelUUID:      args.ModelUUID,
+		ConversationID: args.ConversationID,
+		Facade:         args.Facade,
+		Method:         args.Method,
+		Limit:          args.Limit,
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+	records, err := auditlog.ReadLogFiles(c.logDir, filter)
+	if err != nil {
+		return result, errors.Annotate(err, "reading audit log")
+	}
+	result.Records = make([]params.AuditLogRecord, len(records))
+	for i, record := range records {
+		result.Records[i] = auditRecordToParams(record)
+	}
+	return result, nil
+}
+
+func auditRecordToParams(record auditlog.Record) params.AuditLogRecord {
+	var result params.AuditLogRecord
+	if c := record.Conversation; c != nil {
+		result.Conversation = &params.AuditConversation{
+			Who:            c.Who,
+			What:           c.What,
+			When:           c.When,
+			ModelName:      c.ModelName,
+			ModelUUID:      c.ModelUUID,
+			ConversationID: c.ConversationID,
+			ConnectionID:   c.ConnectionID,
+		}
+	}
+	if r := record.Request; r != nil {
+		result.Request = &params.AuditRequest{
+			ConversationID: r.ConversationID,
+			ConnectionID:   r.ConnectionID,
+			RequestID:      r.RequestID,
+			When:           r.When,
+			Facade:         r.Facade,
+			Method:         r.Method,
+			Version:        r.Version,
+			Args:           r.Args,
+		}
+	}
+	if r := record.Errors; r != nil {
+		errs := make([]*params.AuditError, len(r.Errors))
+		for i, e := range r.Errors {
+			if e != nil {
+				errs[i] = &params.AuditError{Message: e.Message, Code: e.Code}
+			}
+		}
+		result.Errors = &params.AuditResponseErrors{
+			ConversationID: r.ConversationID,
+			ConnectionID:   r.ConnectionID,
+			RequestID:      r.RequestID,
+			When:           r.When,
+			Errors:         errs,
+		}
+	}
+	return result
+}
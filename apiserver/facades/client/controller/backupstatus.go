@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/schedule"
+)
+
+// BackupStatus isn't on the v9 API.
+func (c *ControllerAPIv9) BackupStatus(_, _ struct{}) {}
+
+// BackupStatus returns the backup schedule of the controller and the
+// outcome of its most recent scheduled backups.
+func (c *ControllerAPI) BackupStatus() (params.BackupStatusResult, error) {
+	result := params.BackupStatusResult{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	cfg, err := c.state.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.Schedule = cfg.BackupSchedule(); result.Schedule != "" {
+		if sched, err := schedule.Parse(result.Schedule); err == nil {
+			if next := sched.Next(time.Now().UTC()); !next.IsZero() {
+				result.NextBackup = &next
+			}
+		}
+	}
+
+	status, err := c.state.ScheduledBackupStatus()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !status.LastSuccess.IsZero() {
+		result.LastSuccess = &status.LastSuccess
+		result.LastBackupID = status.LastBackupID
+	}
+	if !status.LastFailure.IsZero() {
+		result.LastFailure = &status.LastFailure
+		result.LastError = status.LastError
+	}
+	return result, nil
+}
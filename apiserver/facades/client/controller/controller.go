@@ -52,12 +52,25 @@ type ControllerAPI struct {
 	hub        facade.Hub
 
 	multiwatcherFactory multiwatcher.Factory
+	logDir              string
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the BackupStatus method.
+type ControllerAPIv9 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv8 provides the v8 Controller API. The only difference
+// between this and v9 is that v8 doesn't have the AuditLog method.
+type ControllerAPIv8 struct {
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
 // between this and v8 is that v7 doesn't have the ControllerVersion method.
 type ControllerAPIv7 struct {
-	*ControllerAPI
+	*ControllerAPIv8
 }
 
 // ControllerAPIv6 provides the v6 Controller API. The only difference
@@ -84,8 +97,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv8 creates a new ControllerAPIv7.
-func NewControllerAPIv8(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv10 creates a new ControllerAPI.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -102,9 +115,28 @@ func NewControllerAPIv8(ctx facade.Context) (*ControllerAPI, error) {
 		presence,
 		hub,
 		factory,
+		ctx.LogDir(),
 	)
 }
 
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
+	v9, err := NewControllerAPIv9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv8{v9}, nil
+}
+
 // NewControllerAPIv7 creates a new ControllerAPIv7.
 func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
 	v8, err := NewControllerAPIv8(ctx)
@@ -160,6 +192,7 @@ func NewControllerAPI(
 	presence facade.Presence,
 	hub facade.Hub,
 	factory multiwatcher.Factory,
+	logDir string,
 ) (*ControllerAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, errors.Trace(common.ErrPerm)
@@ -196,6 +229,7 @@ func NewControllerAPI(
 		presence:            presence,
 		hub:                 hub,
 		multiwatcherFactory: factory,
+		logDir:              logDir,
 	}, nil
 }
 
@@ -229,6 +263,22 @@ func (c *ControllerAPI) ControllerVersion() (params.ControllerVersionResults, er
 // IdentityProviderURL isn't on the v6 API.
 func (c *ControllerAPIv6) IdentityProviderURL() {}
 
+// ControllerConfig returns the controller's configuration, leaving
+// out the attributes marked as secret in the config schema, such as
+// the credentials of the backup object store.
+func (c *ControllerAPI) ControllerConfig() (params.ControllerConfigResult, error) {
+	result, err := c.ControllerConfigAPI.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for key := range result.Config {
+		if corecontroller.ConfigSchema[key].Secret {
+			delete(result.Config, key)
+		}
+	}
+	return result, nil
+}
+
 // IdentityProviderURL returns the URL of the configured external identity
 // provider for this controller or an empty string if no external identity
 // provider has been configured when the controller was bootstrapped.
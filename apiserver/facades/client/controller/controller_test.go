@@ -27,6 +27,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -45,6 +46,7 @@ type controllerSuite struct {
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 	hub        *pubsub.StructuredHub
+	logDir     string
 }
 
 var _ = gc.Suite(&controllerSuite{})
@@ -73,8 +75,9 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
+	s.logDir = c.MkDir()
 
-	controller, err := controller.NewControllerAPIv8(
+	controller, err := controller.NewControllerAPIv10(
 		facadetest.Context{
 			State_:               s.State,
 			StatePool_:           s.StatePool,
@@ -82,6 +85,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 			Auth_:                s.authorizer,
 			Hub_:                 s.hub,
 			MultiwatcherFactory_: multiWatcherWorker,
+			LogDir_:              s.logDir,
 		})
 	c.Assert(err, jc.ErrorIsNil)
 	s.controller = controller
@@ -298,6 +302,23 @@ func (s *controllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestControllerConfigOmitsSecrets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-storage":       "s3",
+		"backup-s3-endpoint":   "https://s3.example.com",
+		"backup-s3-bucket":     "backups",
+		"backup-s3-access-key": "access",
+		"backup-s3-secret-key": "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.controller.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config["backup-s3-access-key"], gc.Equals, "access")
+	_, ok := cfg.Config["backup-s3-secret-key"]
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -1004,6 +1025,133 @@ func (s *controllerSuite) TestMongoVersion(c *gc.C) {
 	c.Assert(result.Result, gc.Matches, "^([0-9]{1,}).([0-9]{1,}).([0-9]{1,})$")
 }
 
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	logFile := auditlog.NewLogFile(s.logDir, 300, 10)
+	err := logFile.AddConversation(auditlog.Conversation{
+		Who:            "deerhoof",
+		What:           "juju deploy prometheus",
+		When:           "2017-12-12T11:34:50Z",
+		ModelName:      "admin/default",
+		ModelUUID:      "model-1",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for i, method := range []string{"Deploy", "Expose"} {
+		err = logFile.AddRequest(auditlog.Request{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			RequestID:      uint64(i + 1),
+			When:           "2017-12-12T11:34:56Z",
+			Facade:         "Application",
+			Method:         method,
+			Version:        4,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = logFile.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      2,
+		When:           "2017-12-12T11:34:57Z",
+		Errors:         []*auditlog.Error{{Message: "oops", Code: "unauthorized access"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	result, err := s.controller.AuditLog(params.AuditLogQuery{
+		User:   "deerhoof",
+		Method: "Expose",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Records, jc.DeepEquals, []params.AuditLogRecord{{
+		Conversation: &params.AuditConversation{
+			Who:            "deerhoof",
+			What:           "juju deploy prometheus",
+			When:           "2017-12-12T11:34:50Z",
+			ModelName:      "admin/default",
+			ModelUUID:      "model-1",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+		},
+	}, {
+		Request: &params.AuditRequest{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			RequestID:      2,
+			When:           "2017-12-12T11:34:56Z",
+			Facade:         "Application",
+			Method:         "Expose",
+			Version:        4,
+		},
+	}, {
+		Errors: &params.AuditResponseErrors{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			RequestID:      2,
+			When:           "2017-12-12T11:34:57Z",
+			Errors:         []*params.AuditError{{Message: "oops", Code: "unauthorized access"}},
+		},
+	}})
+}
+
+func (s *controllerSuite) TestAuditLogRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+			LogDir_:    s.logDir,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.AuditLog(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestBackupStatus(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		corecontroller.BackupSchedule: "@daily",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	finished := time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC)
+	err = s.State.RecordScheduledBackupSuccess("backup-id", finished)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Schedule, gc.Equals, "@daily")
+	c.Assert(result.NextBackup, gc.NotNil)
+	c.Check(result.NextBackup.After(time.Now()), jc.IsTrue)
+	c.Assert(result.LastSuccess, gc.NotNil)
+	c.Check(*result.LastSuccess, gc.Equals, finished)
+	c.Check(result.LastBackupID, gc.Equals, "backup-id")
+	c.Check(result.LastFailure, gc.IsNil)
+	c.Check(result.LastError, gc.Equals, "")
+}
+
+func (s *controllerSuite) TestBackupStatusNoSchedule(c *gc.C) {
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BackupStatusResult{})
+}
+
+func (s *controllerSuite) TestBackupStatusRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+			LogDir_:    s.logDir,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) TestIdentityProviderURL(c *gc.C) {
 	// Preserve default controller config as we will be mutating it just
 	// for this test
@@ -0,0 +1,19 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/apiserver/facade"
+)
+
+// PatchMemberHealth replaces the means by which the API determines the
+// health of the controller machines.
+func PatchMemberHealth(api *HighAvailabilityAPI, session MongoSession, hub facade.Hub, clock clock.Clock, dial func(string) error) {
+	api.session = session
+	api.hub = hub
+	api.clock = clock
+	api.dial = dial
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v3"
@@ -29,6 +30,9 @@ var logger = loggo.GetLogger("juju.apiserver.highavailability")
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	ControllerHealth() (params.ControllerMembersHealth, error)
+	DemoteControllers(args params.Entities) (params.ErrorResults, error)
+	RemoveControllers(args params.RemoveControllersArgs) (params.ErrorResults, error)
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -37,10 +41,44 @@ type HighAvailabilityAPI struct {
 	state      *state.State
 	resources  facade.Resources
 	authorizer facade.Authorizer
+
+	// The following are used to report the health of the
+	// controller machines.
+	session MongoSession
+	hub     facade.Hub
+	clock   clock.Clock
+	dial    func(address string) error
+}
+
+// HighAvailabilityAPIV2 implements v2 of the high availability facade,
+// which does not report the health of, or manage the voting of,
+// controller machines.
+type HighAvailabilityAPIV2 struct {
+	*HighAvailabilityAPI
 }
 
 var _ HighAvailability = (*HighAvailabilityAPI)(nil)
 
+// NewFacadeV3 creates a new server-side highavailability API end point.
+func NewFacadeV3(ctx facade.Context) (*HighAvailabilityAPI, error) {
+	api, err := NewHighAvailabilityAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.hub = ctx.Hub()
+	return api, nil
+}
+
+// NewHighAvailabilityAPIV2 creates a new server-side highavailability
+// API end point, version 2.
+func NewHighAvailabilityAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPIV2, error) {
+	api, err := NewHighAvailabilityAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &HighAvailabilityAPIV2{api}, nil
+}
+
 // NewHighAvailabilityAPI creates a new server-side highavailability API end point.
 func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPI, error) {
 	// Only clients can access the high availability facade.
@@ -60,20 +98,32 @@ func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authori
 		state:      st,
 		resources:  resources,
 		authorizer: authorizer,
+		session:    MongoSessionShim{st.MongoSession()},
+		clock:      clock.WallClock,
+		dial:       dialAPI,
 	}, nil
 }
 
+// checkIsSuperuser returns an error if the authenticated user is not a
+// controller superuser.
+func (api *HighAvailabilityAPI) checkIsSuperuser() error {
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !admin {
+		return common.ServerError(common.ErrPerm)
+	}
+	return nil
+}
+
 // EnableHA adds controller machines as necessary to ensure the
 // controller has the number of machines specified.
 func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error) {
 	results := params.ControllersChangeResults{}
 
-	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
-	if err != nil && !errors.IsNotFound(err) {
-		return results, errors.Trace(err)
-	}
-	if !admin {
-		return results, common.ServerError(common.ErrPerm)
+	if err := api.checkIsSuperuser(); err != nil {
+		return results, err
 	}
 
 	if len(args.Specs) == 0 {
@@ -0,0 +1,426 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// raftStatusTimeout is how long to wait for the raft leader to
+	// report the status of the raft cluster.
+	raftStatusTimeout = 5 * time.Second
+
+	// apiDialTimeout is how long to wait when checking whether a
+	// controller machine's API server is reachable.
+	apiDialTimeout = 5 * time.Second
+
+	// jujuMachineKey is the key of the replica set member tag that
+	// holds the id of the member's machine.
+	jujuMachineKey = "juju-machine-id"
+)
+
+// MongoSession provides access to the status of the controller's mongo
+// replica set.
+type MongoSession interface {
+	CurrentMembers() ([]replicaset.Member, error)
+	CurrentStatus() (*replicaset.Status, error)
+	MemberOptimes() (map[int]time.Time, error)
+}
+
+// MongoSessionShim wraps a *mgo.Session to conform to the
+// MongoSession interface.
+type MongoSessionShim struct {
+	*mgo.Session
+}
+
+// CurrentMembers returns the current members of the replica set.
+func (s MongoSessionShim) CurrentMembers() ([]replicaset.Member, error) {
+	return replicaset.CurrentMembers(s.Session)
+}
+
+// CurrentStatus returns the current status of the replica set.
+func (s MongoSessionShim) CurrentStatus() (*replicaset.Status, error) {
+	return replicaset.CurrentStatus(s.Session)
+}
+
+// MemberOptimes returns the time of the last operation applied by
+// each member of the replica set, keyed by member id.
+func (s MongoSessionShim) MemberOptimes() (map[int]time.Time, error) {
+	var status struct {
+		Members []struct {
+			Id         int       `bson:"_id"`
+			OptimeDate time.Time `bson:"optimeDate"`
+		} `bson:"members"`
+	}
+	if err := s.Session.Run("replSetGetStatus", &status); err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set status")
+	}
+	optimes := make(map[int]time.Time)
+	for _, m := range status.Members {
+		optimes[m.Id] = m.OptimeDate
+	}
+	return optimes, nil
+}
+
+// dialAPI returns an error if a TCP connection cannot be made to
+// the given address.
+func dialAPI(address string) error {
+	conn, err := net.DialTimeout("tcp", address, apiDialTimeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
+
+// replicaSetMember holds the details of the replica set member of a
+// controller machine.
+type replicaSetMember struct {
+	voting bool
+	status *replicaset.MemberStatus
+	optime time.Time
+}
+
+// healthy reports whether the member is able to take part in
+// replication.
+func (m replicaSetMember) healthy() bool {
+	return m.status != nil && m.status.Healthy &&
+		(m.status.State == replicaset.PrimaryState || m.status.State == replicaset.SecondaryState)
+}
+
+// replicaSetMembers returns the replica set members of the controller
+// machines, keyed by machine id.
+func (api *HighAvailabilityAPI) replicaSetMembers() (map[string]*replicaSetMember, error) {
+	members, err := api.session.CurrentMembers()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set members")
+	}
+	status, err := api.session.CurrentStatus()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set status")
+	}
+	statuses := make(map[int]*replicaset.MemberStatus)
+	for i := range status.Members {
+		statuses[status.Members[i].Id] = &status.Members[i]
+	}
+	result := make(map[string]*replicaSetMember)
+	for _, m := range members {
+		machineId, ok := m.Tags[jujuMachineKey]
+		if !ok {
+			continue
+		}
+		result[machineId] = &replicaSetMember{
+			voting: m.Votes == nil || *m.Votes > 0,
+			status: statuses[m.Id],
+		}
+	}
+	return result, nil
+}
+
+// raftStatus asks the raft leader for the status of the raft cluster.
+func (api *HighAvailabilityAPI) raftStatus() (*controller.RaftStatus, error) {
+	if api.hub == nil {
+		return nil, errors.NotSupportedf("raft status")
+	}
+	requestID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statuses := make(chan controller.RaftStatus, 1)
+	unsubscribe, err := api.hub.Subscribe(controller.RaftStatusTopic,
+		func(_ string, status controller.RaftStatus, err error) {
+			if err != nil || status.RequestID != requestID.String() {
+				return
+			}
+			select {
+			case statuses <- status:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "subscribing to raft status")
+	}
+	defer unsubscribe()
+
+	req := controller.RaftStatusRequest{RequestID: requestID.String()}
+	if _, err := api.hub.Publish(controller.RaftStatusRequestTopic, req); err != nil {
+		return nil, errors.Annotate(err, "requesting raft status")
+	}
+	select {
+	case status := <-statuses:
+		return &status, nil
+	case <-api.clock.After(raftStatusTimeout):
+		return nil, errors.Timeoutf("waiting for raft status")
+	}
+}
+
+// ControllerHealth reports the health of each controller machine as
+// a member of the mongo replica set and the raft cluster, and
+// whether its API server can be reached.
+func (api *HighAvailabilityAPI) ControllerHealth() (params.ControllerMembersHealth, error) {
+	var result params.ControllerMembersHealth
+	if err := api.checkIsSuperuser(); err != nil {
+		return result, err
+	}
+	controllerIds, err := api.state.ControllerIds()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	cfg, err := api.state.ControllerConfig()
+	if err != nil {
+		return result, errors.Annotate(err, "retrieving controller config")
+	}
+	members, err := api.replicaSetMembers()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var primaryOptime time.Time
+	optimes, err := api.session.MemberOptimes()
+	if err != nil {
+		logger.Warningf("cannot determine replication lag: %v", err)
+	}
+	for _, m := range members {
+		if m.status == nil {
+			continue
+		}
+		m.optime = optimes[m.status.Id]
+		if m.status.State == replicaset.PrimaryState {
+			primaryOptime = m.optime
+		}
+	}
+	raftStatus, err := api.raftStatus()
+	if err != nil {
+		result.RaftError = err.Error()
+	}
+
+	sortAsInts(controllerIds)
+	for _, id := range controllerIds {
+		health := params.ControllerMemberHealth{
+			Tag: names.NewMachineTag(id).String(),
+		}
+		node, err := api.state.ControllerNode(id)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		health.WantsVote = node.WantsVote()
+		health.HasVote = node.HasVote()
+
+		if member, ok := members[id]; ok && member.status != nil {
+			health.MongoState = member.status.State.String()
+			health.MongoHealthy = member.healthy()
+			health.MongoError = member.status.ErrMsg
+			if !primaryOptime.IsZero() && !member.optime.IsZero() && primaryOptime.After(member.optime) {
+				health.ReplicationLag = primaryOptime.Sub(member.optime)
+			}
+		}
+		if raftStatus != nil {
+			health.RaftRole = raftRole(raftStatus, id)
+		}
+
+		m, err := api.state.Machine(id)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if addr, ok := apiAddress(m, cfg.APIPort()); ok {
+			health.APIAddress = addr
+			if err := api.dial(addr); err != nil {
+				health.APIError = err.Error()
+			} else {
+				health.APIReachable = true
+			}
+		} else {
+			health.APIError = "machine has no addresses"
+		}
+		result.Members = append(result.Members, health)
+	}
+	return result, nil
+}
+
+// raftRole returns the role of the machine with the given id in the
+// raft cluster.
+func raftRole(status *controller.RaftStatus, id string) string {
+	for _, server := range status.Servers {
+		switch {
+		case server.ID != id:
+		case status.Leader == id:
+			return "leader"
+		case server.Voter:
+			return "follower"
+		default:
+			return "non-voter"
+		}
+	}
+	return ""
+}
+
+// apiAddress returns the address at which the machine's API server
+// should be reachable by other controllers.
+func apiAddress(m *state.Machine, port int) (string, bool) {
+	addr, ok := m.Addresses().OneMatchingScope(network.ScopeMatchCloudLocal)
+	if !ok {
+		return "", false
+	}
+	return net.JoinHostPort(addr.Value, strconv.Itoa(port)), true
+}
+
+// checkVoteRemovalSafe returns an error if removing the vote of the
+// controller machine with the given id would leave the replica set
+// without a healthy majority of voters.
+func (api *HighAvailabilityAPI) checkVoteRemovalSafe(id string) error {
+	members, err := api.replicaSetMembers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	member, ok := members[id]
+	if !ok || !member.voting {
+		return nil
+	}
+	var voters, healthy int
+	for otherId, other := range members {
+		if otherId == id || !other.voting {
+			continue
+		}
+		voters++
+		if other.healthy() {
+			healthy++
+		}
+	}
+	if voters == 0 {
+		return errors.Errorf("controller %s is the only voting member of the replica set", id)
+	}
+	if healthy*2 <= voters {
+		return errors.Errorf(
+			"removing the vote of controller %s would leave %d healthy of %d voting members, which is not a majority",
+			id, healthy, voters,
+		)
+	}
+	return nil
+}
+
+// controllerMachine returns the controller machine with the given tag.
+func (api *HighAvailabilityAPI) controllerMachine(tag string) (*state.Machine, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := api.state.Machine(machineTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !m.IsManager() {
+		return nil, errors.Errorf("machine %s is not a controller", m.Id())
+	}
+	return m, nil
+}
+
+// DemoteControllers removes the votes of the given controller machines
+// from the replica set. The machines remain controllers. A machine is
+// only demoted if the remaining voting members are a healthy majority.
+func (api *HighAvailabilityAPI) DemoteControllers(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := api.checkIsSuperuser(); err != nil {
+		return result, err
+	}
+	if err := common.NewBlockChecker(api.state).ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		result.Results[i].Error = common.ServerError(api.demoteController(entity.Tag))
+	}
+	return result, nil
+}
+
+func (api *HighAvailabilityAPI) demoteController(tag string) error {
+	m, err := api.controllerMachine(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.checkVoteRemovalSafe(m.Id()); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("demoting controller machine %s", m.Id())
+	return errors.Trace(api.state.DemoteController(m.Id()))
+}
+
+// RemoveControllers removes the given controller machines, after
+// their votes have been removed from the replica set. A machine is
+// only removed if the remaining voting members are a healthy
+// majority. If Force is set, the machines are removed even if their
+// agents are not running.
+func (api *HighAvailabilityAPI) RemoveControllers(args params.RemoveControllersArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+	if err := api.checkIsSuperuser(); err != nil {
+		return result, err
+	}
+	if err := common.NewBlockChecker(api.state).RemoveAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, tag := range args.Machines {
+		result.Results[i].Error = common.ServerError(api.removeController(tag, args.Force))
+	}
+	return result, nil
+}
+
+func (api *HighAvailabilityAPI) removeController(tag string, force bool) error {
+	m, err := api.controllerMachine(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerIds, err := api.state.ControllerIds()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(controllerIds) <= 1 {
+		return errors.Errorf("controller %s is the only controller", m.Id())
+	}
+	if err := api.checkVoteRemovalSafe(m.Id()); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("removing controller machine %s (force=%v)", m.Id(), force)
+	if force {
+		return errors.Trace(m.ForceDestroy(common.MaxWait(nil)))
+	}
+	return errors.Trace(m.Destroy())
+}
+
+// sortAsInts sorts the given numeric machine ids numerically.
+func sortAsInts(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		if errA != nil || errB != nil {
+			return ids[i] < ids[j]
+		}
+		return a < b
+	})
+}
+
+// ControllerHealth is not available on v2 of the facade.
+func (api *HighAvailabilityAPIV2) ControllerHealth(_ struct{}) {}
+
+// DemoteControllers is not available on v2 of the facade.
+func (api *HighAvailabilityAPIV2) DemoteControllers(_ struct{}) {}
+
+// RemoveControllers is not available on v2 of the facade.
+func (api *HighAvailabilityAPIV2) RemoveControllers(_ struct{}) {}
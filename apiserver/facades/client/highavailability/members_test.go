@@ -0,0 +1,282 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	commontesting "github.com/juju/juju/apiserver/common/testing"
+	"github.com/juju/juju/apiserver/facades/client/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+)
+
+type membersSuite struct {
+	testing.JujuConnSuite
+	commontesting.BlockHelper
+
+	haServer *highavailability.HighAvailabilityAPI
+	session  *fakeMongoSession
+	hub      *fakeHub
+	apiPort  int
+	dialed   []string
+}
+
+var _ = gc.Suite(&membersSuite{})
+
+func (s *membersSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	resources := common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { resources.StopAll() })
+	authoriser := apiservertesting.FakeAuthorizer{
+		Tag:        s.AdminUserTag(c),
+		Controller: true,
+	}
+	var err error
+	s.haServer, err = highavailability.NewHighAvailabilityAPI(s.State, resources, authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var templates []state.MachineTemplate
+	for i := 0; i < 3; i++ {
+		templates = append(templates, state.MachineTemplate{
+			Series: "quantal",
+			Jobs:   []state.MachineJob{state.JobManageModel},
+			Addresses: []network.SpaceAddress{
+				network.NewScopedSpaceAddress("127.0.0.1", network.ScopeMachineLocal),
+				network.NewScopedSpaceAddress(fmt.Sprintf("cloud-local%d.internal", i), network.ScopeCloudLocal),
+			},
+		})
+	}
+	_, err = s.State.AddMachines(templates...)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockHelper = commontesting.NewBlockHelper(s.APIState)
+	s.AddCleanup(func(*gc.C) { s.BlockHelper.Close() })
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	s.apiPort = cfg.APIPort()
+
+	now := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.session = &fakeMongoSession{
+		members: []replicaset.Member{
+			{Id: 1, Tags: map[string]string{"juju-machine-id": "0"}},
+			{Id: 2, Tags: map[string]string{"juju-machine-id": "1"}},
+			{Id: 3, Tags: map[string]string{"juju-machine-id": "2"}},
+		},
+		status: &replicaset.Status{
+			Members: []replicaset.MemberStatus{
+				{Id: 1, Healthy: true, State: replicaset.PrimaryState},
+				{Id: 2, Healthy: true, State: replicaset.SecondaryState},
+				{Id: 3, Healthy: true, State: replicaset.SecondaryState},
+			},
+		},
+		optimes: map[int]time.Time{
+			1: now,
+			2: now,
+			3: now.Add(-3 * time.Second),
+		},
+	}
+	s.hub = &fakeHub{
+		status: controller.RaftStatus{
+			Leader: "0",
+			Servers: []controller.RaftServer{
+				{ID: "0", Address: "cloud-local0.internal:17070", Voter: true},
+				{ID: "1", Address: "cloud-local1.internal:17070", Voter: true},
+				{ID: "2", Address: "cloud-local2.internal:17070", Voter: false},
+			},
+		},
+	}
+	s.dialed = nil
+	dial := func(address string) error {
+		s.dialed = append(s.dialed, address)
+		if address == s.apiAddress("2") {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	highavailability.PatchMemberHealth(s.haServer, s.session, s.hub, testclock.NewClock(now), dial)
+}
+
+func (s *membersSuite) apiAddress(id string) string {
+	return fmt.Sprintf("cloud-local%s.internal:%d", id, s.apiPort)
+}
+
+func (s *membersSuite) TestControllerHealth(c *gc.C) {
+	result, err := s.haServer.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerMembersHealth{
+		Members: []params.ControllerMemberHealth{{
+			Tag:          "machine-0",
+			WantsVote:    true,
+			MongoState:   "PRIMARY",
+			MongoHealthy: true,
+			RaftRole:     "leader",
+			APIAddress:   s.apiAddress("0"),
+			APIReachable: true,
+		}, {
+			Tag:          "machine-1",
+			WantsVote:    true,
+			MongoState:   "SECONDARY",
+			MongoHealthy: true,
+			RaftRole:     "follower",
+			APIAddress:   s.apiAddress("1"),
+			APIReachable: true,
+		}, {
+			Tag:            "machine-2",
+			WantsVote:      true,
+			MongoState:     "SECONDARY",
+			MongoHealthy:   true,
+			ReplicationLag: 3 * time.Second,
+			RaftRole:       "non-voter",
+			APIAddress:     s.apiAddress("2"),
+			APIError:       "connection refused",
+		}},
+	})
+	c.Assert(s.dialed, gc.HasLen, 3)
+}
+
+func (s *membersSuite) TestControllerHealthNoRaftStatus(c *gc.C) {
+	highavailability.PatchMemberHealth(s.haServer, s.session, nil, testclock.NewClock(time.Now()), func(string) error { return nil })
+	result, err := s.haServer.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.RaftError, gc.Equals, "raft status not supported")
+	c.Assert(result.Members, gc.HasLen, 3)
+	c.Assert(result.Members[0].RaftRole, gc.Equals, "")
+}
+
+func (s *membersSuite) TestControllerHealthMongoError(c *gc.C) {
+	s.session.err = errors.New("boom")
+	_, err := s.haServer.ControllerHealth()
+	c.Assert(err, gc.ErrorMatches, "cannot get replica set members: boom")
+}
+
+func (s *membersSuite) TestDemoteControllers(c *gc.C) {
+	result, err := s.haServer.DemoteControllers(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	node, err := s.State.ControllerNode("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.WantsVote(), jc.IsFalse)
+}
+
+func (s *membersSuite) TestDemoteControllersWouldLoseQuorum(c *gc.C) {
+	s.session.status.Members[2].Healthy = false
+	result, err := s.haServer.DemoteControllers(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches,
+		"removing the vote of controller 1 would leave 1 healthy of 2 voting members, which is not a majority")
+
+	node, err := s.State.ControllerNode("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.WantsVote(), jc.IsTrue)
+}
+
+func (s *membersSuite) TestDemoteControllersNotController(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.haServer.DemoteControllers(params.Entities{
+		Entities: []params.Entity{{Tag: m.Tag().String()}, {Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "machine 3 is not a controller")
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-mysql" is not a valid machine tag`)
+}
+
+func (s *membersSuite) TestRemoveControllers(c *gc.C) {
+	result, err := s.haServer.RemoveControllers(params.RemoveControllersArgs{
+		Machines: []string{"machine-2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	m, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Life(), gc.Equals, state.Dying)
+	node, err := s.State.ControllerNode("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.WantsVote(), jc.IsFalse)
+}
+
+func (s *membersSuite) TestRemoveControllersWouldLoseQuorum(c *gc.C) {
+	s.session.status.Members[0].State = replicaset.RecoveringState
+	result, err := s.haServer.RemoveControllers(params.RemoveControllersArgs{
+		Machines: []string{"machine-2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches,
+		"removing the vote of controller 2 would leave 1 healthy of 2 voting members, which is not a majority")
+
+	m, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Life(), gc.Equals, state.Alive)
+}
+
+func (s *membersSuite) TestBlockRemoveControllers(c *gc.C) {
+	s.BlockRemoveObject(c, "TestBlockRemoveControllers")
+	_, err := s.haServer.RemoveControllers(params.RemoveControllersArgs{
+		Machines: []string{"machine-2"},
+	})
+	s.AssertBlocked(c, err, "TestBlockRemoveControllers")
+}
+
+type fakeMongoSession struct {
+	members []replicaset.Member
+	status  *replicaset.Status
+	optimes map[int]time.Time
+	err     error
+}
+
+func (s *fakeMongoSession) CurrentMembers() ([]replicaset.Member, error) {
+	return s.members, s.err
+}
+
+func (s *fakeMongoSession) CurrentStatus() (*replicaset.Status, error) {
+	return s.status, s.err
+}
+
+func (s *fakeMongoSession) MemberOptimes() (map[int]time.Time, error) {
+	return s.optimes, s.err
+}
+
+// fakeHub answers raft status requests as the raft leader would.
+type fakeHub struct {
+	status  controller.RaftStatus
+	handler func(string, controller.RaftStatus, error)
+}
+
+func (h *fakeHub) Publish(topic string, data interface{}) (<-chan struct{}, error) {
+	if req, ok := data.(controller.RaftStatusRequest); ok && h.handler != nil {
+		status := h.status
+		status.RequestID = req.RequestID
+		h.handler(controller.RaftStatusTopic, status, nil)
+	}
+	done := make(chan struct{})
+	close(done)
+	return done, nil
+}
+
+func (h *fakeHub) Subscribe(topic string, handler interface{}) (func(), error) {
+	if topic == controller.RaftStatusTopic {
+		h.handler = handler.(func(string, controller.RaftStatus, error))
+	}
+	return func() { h.handler = nil }, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
@@ -156,6 +157,11 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	if err := mm.check.ChangeAllowed(); err != nil {
 		return results, errors.Trace(err)
 	}
+	// Check the whole batch up front so that a request that would
+	// exceed the model's quotas adds no machines at all.
+	if err := mm.st.CheckModelQuotas(addMachinesUsage(args.MachineParams)); err != nil {
+		return results, errors.Trace(err)
+	}
 	for i, p := range args.MachineParams {
 		m, err := mm.addOneMachine(p)
 		results.Machines[i].Error = common.ServerError(err)
@@ -166,6 +172,35 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	return results, nil
 }
 
+// addMachinesUsage returns the resources limited by model quotas that
+// the new top level machines in the request would use. Containers
+// added to existing machines and manually provisioned machines are
+// not counted.
+func addMachinesUsage(machineParams []params.AddMachineParams) coremodel.Usage {
+	var usage coremodel.Usage
+	for _, p := range machineParams {
+		if p.InstanceId != "" || p.ParentId != "" {
+			continue
+		}
+		if p.Placement != nil {
+			if _, err := instance.ParseContainerType(p.Placement.Scope); err == nil {
+				continue
+			}
+		}
+		usage.Machines++
+		if p.Constraints.CpuCores != nil {
+			usage.Cores += *p.Constraints.CpuCores
+		}
+		if p.Constraints.Mem != nil {
+			usage.Memory += *p.Constraints.Mem
+		}
+		for _, disk := range p.Disks {
+			usage.Storage += disk.Size * disk.Count
+		}
+	}
+	return usage
+}
+
 func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
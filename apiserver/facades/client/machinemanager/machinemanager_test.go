@@ -21,6 +21,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
@@ -127,6 +129,40 @@ func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *MachineManagerSuite) TestAddMachinesChecksQuotas(c *gc.C) {
+	cores := uint64(4)
+	s.st.ResetCalls()
+	_, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:      "trusty",
+			Constraints: constraints.Value{CpuCores: &cores},
+			Disks:       []storage.Constraints{{Size: 1024, Count: 2}},
+		}, {
+			Series: "trusty",
+		}, {
+			Series:        "trusty",
+			ContainerType: instance.LXD,
+			ParentId:      "0",
+		}, {
+			Series:     "trusty",
+			InstanceId: "manual:10.0.0.1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.CheckCall(c, 1, "CheckModelQuotas", model.Usage{Machines: 2, Cores: 4, Storage: 2048})
+}
+
+func (s *MachineManagerSuite) TestAddMachinesQuotaExceeded(c *gc.C) {
+	s.st.quotaErr = &model.QuotaExceededError{Resource: "machines", Limit: 1, Current: 1, Requested: 1}
+	_, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series: "trusty",
+		}},
+	})
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+	c.Assert(s.st.calls, gc.Equals, 0)
+}
+
 func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	s.st.err = errors.New("boom")
 	results, err := s.api.AddMachines(params.AddMachines{
@@ -790,6 +826,7 @@ type mockState struct {
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	quotaErr         error
 
 	unitStorageAttachmentsF func(tag names.UnitTag) ([]state.StorageAttachment, error)
 }
@@ -840,6 +877,11 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) CheckModelQuotas(requested model.Usage) error {
+	st.MethodCall(st, "CheckModelQuotas", requested)
+	return st.quotaErr
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	st.MethodCall(st, "GetBlockForType", t)
 	if st.block == t {
@@ -33,6 +33,10 @@ type Model interface {
 // ModelCache describes a cached model used by the model generation API.
 type ModelCache interface {
 	Branch(string) (cache.Branch, error)
+
+	// UnitConfig returns the effective charm configuration of the unit
+	// with the input name, taking into account any branch it tracks.
+	UnitConfig(string) (charm.Settings, error)
 }
 
 // Generation defines the methods used by a generation.
@@ -56,7 +60,8 @@ type Generation interface {
 type Application interface {
 	UnitNames() ([]string, error)
 
-	// DefaultCharmConfig is the only abstraction in these shims.
-	// It saves us having to shim out Charm as well.
+	// DefaultCharmConfig and CharmConfigOptions are the only abstractions
+	// in these shims. They save us having to shim out Charm as well.
 	DefaultCharmConfig() (charm.Settings, error)
+	CharmConfigOptions() (map[string]charm.Option, error)
 }
@@ -422,6 +422,21 @@ func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
 	return m.recorder
 }
 
+// CharmConfigOptions mocks base method
+func (m *MockApplication) CharmConfigOptions() (map[string]charm_v6.Option, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CharmConfigOptions")
+	ret0, _ := ret[0].(map[string]charm_v6.Option)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CharmConfigOptions indicates an expected call of CharmConfigOptions
+func (mr *MockApplicationMockRecorder) CharmConfigOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmConfigOptions", reflect.TypeOf((*MockApplication)(nil).CharmConfigOptions))
+}
+
 // DefaultCharmConfig mocks base method
 func (m *MockApplication) DefaultCharmConfig() (charm_v6.Settings, error) {
 	m.ctrl.T.Helper()
@@ -489,3 +504,18 @@ func (mr *MockModelCacheMockRecorder) Branch(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Branch", reflect.TypeOf((*MockModelCache)(nil).Branch), arg0)
 }
+
+// UnitConfig mocks base method
+func (m *MockModelCache) UnitConfig(arg0 string) (charm_v6.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnitConfig", arg0)
+	ret0, _ := ret[0].(charm_v6.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnitConfig indicates an expected call of UnitConfig
+func (mr *MockModelCacheMockRecorder) UnitConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnitConfig", reflect.TypeOf((*MockModelCache)(nil).UnitConfig), arg0)
+}
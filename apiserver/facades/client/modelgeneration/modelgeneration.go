@@ -5,10 +5,13 @@ package modelgeneration
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
@@ -16,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/settings"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.modelgeneration")
@@ -31,10 +35,14 @@ type API struct {
 	modelCache        ModelCache
 }
 
-type APIV3 struct {
+type APIV4 struct {
 	*API
 }
 
+type APIV3 struct {
+	*APIV4
+}
+
 type APIV2 struct {
 	*APIV3
 }
@@ -43,8 +51,8 @@ type APIV1 struct {
 	*APIV2
 }
 
-// NewModelGenerationFacadeV4 provides the signature required for facade registration.
-func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
+// NewModelGenerationFacadeV5 provides the signature required for facade registration.
+func NewModelGenerationFacadeV5(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
 	m, err := st.Model()
@@ -58,6 +66,15 @@ func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
 	return NewModelGenerationAPI(st, authorizer, m, &modelCacheShim{Model: mc})
 }
 
+// NewModelGenerationFacadeV4 provides the signature required for facade registration.
+func NewModelGenerationFacadeV4(ctx facade.Context) (*APIV4, error) {
+	v5, err := NewModelGenerationFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{v5}, nil
+}
+
 // NewModelGenerationFacadeV3 provides the signature required for facade registration.
 func NewModelGenerationFacadeV3(ctx facade.Context) (*APIV3, error) {
 	v4, err := NewModelGenerationFacadeV4(ctx)
@@ -220,6 +237,112 @@ func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
 	return result, nil
 }
 
+// PreviewCommit is not available on V4 and earlier.
+func (api *APIV4) PreviewCommit(_, _ struct{}) {}
+
+// PreviewCommit reports what committing the input branch would do,
+// without changing anything: the units that would see configuration
+// changes and the hooks that would run on them as a result, along with
+// any changed settings that are not valid for the current charm.
+func (api *API) PreviewCommit(arg params.BranchArg) (params.CommitPreviewResult, error) {
+	result := params.CommitPreviewResult{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		return commitPreviewResultError(err)
+	}
+
+	deltas := branch.Config()
+	appNames := make([]string, 0, len(deltas))
+	for appName, delta := range deltas {
+		if len(delta) > 0 {
+			appNames = append(appNames, appName)
+		}
+	}
+	sort.Strings(appNames)
+
+	apps := make([]params.CommitPreviewApplication, len(appNames))
+	for i, appName := range appNames {
+		if apps[i], err = api.previewAppCommit(appName, deltas[appName]); err != nil {
+			return commitPreviewResultError(err)
+		}
+	}
+	result.Applications = apps
+	return result, nil
+}
+
+// previewAppCommit determines the effect of committing the input
+// configuration delta for the input application. Each unit's current
+// configuration is read from the model cache, so that units already
+// tracking the branch are not reported as changing.
+func (api *API) previewAppCommit(appName string, delta settings.ItemChanges) (params.CommitPreviewApplication, error) {
+	preview := params.CommitPreviewApplication{ApplicationName: appName}
+
+	app, err := api.st.Application(appName)
+	if err != nil {
+		return preview, errors.Trace(err)
+	}
+	options, err := app.CharmConfigOptions()
+	if err != nil {
+		return preview, errors.Trace(err)
+	}
+	defaults, err := app.DefaultCharmConfig()
+	if err != nil {
+		return preview, errors.Trace(err)
+	}
+
+	// Determine the value of each changed setting once committed.
+	// A deleted setting reverts to the charm default, if there is one.
+	committed := make(map[string]interface{}, len(delta))
+	for _, change := range delta {
+		if _, ok := options[change.Key]; !ok {
+			preview.InvalidConfigKeys = append(preview.InvalidConfigKeys, change.Key)
+		}
+		if change.IsDeletion() {
+			committed[change.Key] = defaults[change.Key]
+		} else {
+			committed[change.Key] = change.NewValue
+		}
+	}
+	sort.Strings(preview.InvalidConfigKeys)
+
+	unitNames, err := app.UnitNames()
+	if err != nil {
+		return preview, errors.Trace(err)
+	}
+	sort.Strings(unitNames)
+
+	for _, unitName := range unitNames {
+		current, err := api.modelCache.UnitConfig(unitName)
+		if err != nil {
+			return preview, errors.Annotatef(err, "reading configuration for unit %q", unitName)
+		}
+		changes := make(map[string]interface{})
+		for key, value := range committed {
+			if !reflect.DeepEqual(current[key], value) {
+				changes[key] = value
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		preview.Units = append(preview.Units, params.CommitPreviewUnit{
+			UnitName:      unitName,
+			ConfigChanges: changes,
+			Hooks:         []string{string(hooks.ConfigChanged)},
+		})
+	}
+	return preview, nil
+}
+
 // AbortBranch aborts the input branch, marking it complete.  However no
 // changes are made applicable to the whole model.  No units may be assigned
 // to the branch when aborting.
@@ -451,6 +574,10 @@ func generationResultError(err error) (params.GenerationResult, error) {
 	return params.GenerationResult{Error: common.ServerError(err)}, nil
 }
 
+func commitPreviewResultError(err error) (params.CommitPreviewResult, error) {
+	return params.CommitPreviewResult{Error: common.ServerError(err)}, nil
+}
+
 func intResultsError(err error) (params.IntResult, error) {
 	return params.IntResult{Error: common.ServerError(err)}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/core/cache"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
 
 	facademocks "github.com/juju/juju/apiserver/facade/mocks"
@@ -136,6 +137,77 @@ func (s *modelGenerationSuite) TestCommitBranchSuccess(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.IntResult{Result: 3, Error: nil})
 }
 
+func (s *modelGenerationSuite) TestPreviewCommit(c *gc.C) {
+	ctrl := s.setupModelGenerationAPI(c)
+	defer ctrl.Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().Config().Return(map[string]settings.ItemChanges{
+		"redis": {
+			settings.MakeAddition("password", "added-pass"),
+			settings.MakeDeletion("databases", 100),
+			settings.MakeModification("port", 7000, 8000),
+			settings.MakeAddition("no-such-key", "x"),
+		},
+		"mysql": {},
+	})
+
+	mockApp := mocks.NewMockApplication(ctrl)
+	mockApp.EXPECT().CharmConfigOptions().Return(map[string]charm.Option{
+		"databases": {Type: "int"},
+		"password":  {Type: "string"},
+		"port":      {Type: "int"},
+	}, nil)
+	mockApp.EXPECT().DefaultCharmConfig().Return(map[string]interface{}{
+		"databases": 16,
+		"password":  "",
+	}, nil)
+	mockApp.EXPECT().UnitNames().Return([]string{"redis/1", "redis/0"}, nil)
+	s.mockState.EXPECT().Application("redis").Return(mockApp, nil)
+
+	// redis/0 is tracking the branch, so already sees its changes.
+	cExp := s.mockModelCache.EXPECT()
+	cExp.UnitConfig("redis/0").Return(charm.Settings{
+		"databases":   16,
+		"password":    "added-pass",
+		"port":        8000,
+		"no-such-key": "x",
+	}, nil)
+	cExp.UnitConfig("redis/1").Return(charm.Settings{
+		"databases": 100,
+		"password":  "",
+		"port":      7000,
+	}, nil)
+
+	result, err := s.api.PreviewCommit(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.CommitPreviewResult{
+		Applications: []params.CommitPreviewApplication{{
+			ApplicationName:   "redis",
+			InvalidConfigKeys: []string{"no-such-key"},
+			Units: []params.CommitPreviewUnit{{
+				UnitName: "redis/1",
+				ConfigChanges: map[string]interface{}{
+					"databases":   16,
+					"password":    "added-pass",
+					"port":        8000,
+					"no-such-key": "x",
+				},
+				Hooks: []string{"config-changed"},
+			}},
+		}},
+	})
+}
+
+func (s *modelGenerationSuite) TestPreviewCommitBranchNotFound(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.mockModel.EXPECT().Branch(s.newBranchName).Return(nil, errors.NotFoundf("branch %q", s.newBranchName))
+
+	result, err := s.api.PreviewCommit(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Equals, `branch "new-branch" not found`)
+}
+
 func (s *modelGenerationSuite) TestAbortBranchSuccess(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectAbort()
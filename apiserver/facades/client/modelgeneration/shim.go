@@ -73,6 +73,16 @@ func (a *applicationShim) DefaultCharmConfig() (charm.Settings, error) {
 	return ch.Config().DefaultSettings(), nil
 }
 
+// CharmConfigOptions returns the configuration options
+// defined by this application's charm.
+func (a *applicationShim) CharmConfigOptions() (map[string]charm.Option, error) {
+	ch, _, err := a.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch.Config().Options, nil
+}
+
 type stateShim struct {
 	*state.State
 }
@@ -96,3 +106,14 @@ func (st *stateShim) Application(name string) (Application, error) {
 type modelCacheShim struct {
 	*cache.Model
 }
+
+// UnitConfig returns the effective charm configuration
+// of the cached unit with the input name.
+func (m *modelCacheShim) UnitConfig(unitName string) (charm.Settings, error) {
+	unit, err := m.Model.Unit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := unit.ConfigSettings()
+	return cfg, errors.Trace(err)
+}
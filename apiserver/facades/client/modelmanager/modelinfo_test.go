@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
@@ -176,7 +177,7 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 }
 
 func (s *modelInfoSuite) TestModelInfoV7(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV7{s.modelmanager}
+	api := &modelmanager.ModelManagerAPIV7{&modelmanager.ModelManagerAPIV8{s.modelmanager}}
 
 	results, err := api.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -297,6 +298,7 @@ func (s *modelInfoSuite) assertModelInfo(c *gc.C, got, expected params.ModelInfo
 		{"Life", nil},
 		{"Config", nil},
 		{"Status", nil},
+		{"Quotas", nil},
 		{"Users", nil},
 		{"ModelTag", nil},
 		{"ModelTag", nil},
@@ -310,6 +312,16 @@ func (s *modelInfoSuite) assertModelInfo(c *gc.C, got, expected params.ModelInfo
 	})
 }
 
+func (s *modelInfoSuite) TestModelInfoQuotas(c *gc.C) {
+	s.st.model.quotas = coremodel.Quotas{Machines: pUint64(10), Memory: pUint64(8192)}
+	s.st.model.quotaUsage = coremodel.Usage{Machines: 2, Units: 3, Memory: 4096}
+	info := s.getModelInfo(c, s.st.model.cfg.UUID())
+	c.Assert(info.Quotas, jc.DeepEquals, []params.ModelQuota{
+		{Resource: "machines", Limit: 10, Used: 2},
+		{Resource: "memory", Limit: 8192, Used: 4096},
+	})
+}
+
 func (s *modelInfoSuite) TestModelInfoWriteAccess(c *gc.C) {
 	mary := names.NewUserTag("mary@local")
 	s.authorizer.HasWriteTag = mary
@@ -1046,6 +1058,8 @@ type mockModel struct {
 	controllerUUID      string
 	isController        bool
 	setCloudCredentialF func(tag names.CloudCredentialTag) (bool, error)
+	quotas              coremodel.Quotas
+	quotaUsage          coremodel.Usage
 }
 
 func (m *mockModel) Config() (*config.Config, error) {
@@ -1183,6 +1197,25 @@ func (m *mockModel) SetCloudCredential(tag names.CloudCredentialTag) (bool, erro
 	return m.setCloudCredentialF(tag)
 }
 
+func (m *mockModel) Quotas() coremodel.Quotas {
+	m.MethodCall(m, "Quotas")
+	return m.quotas
+}
+
+func (m *mockModel) SetQuotas(quotas coremodel.Quotas) error {
+	m.MethodCall(m, "SetQuotas", quotas)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.quotas = quotas
+	return nil
+}
+
+func (m *mockModel) QuotaUsage() (coremodel.Usage, error) {
+	m.MethodCall(m, "QuotaUsage")
+	return m.quotaUsage, m.NextErr()
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
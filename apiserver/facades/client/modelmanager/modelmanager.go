@@ -28,6 +28,7 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/core/life"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -39,6 +40,13 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV9 defines the methods on the version 9 facade for the
+// modelmanager API endpoint.
+type ModelManagerV9 interface {
+	ModelManagerV8
+	SetModelQuotas(args params.SetModelQuotasArgs) (params.ErrorResults, error)
+}
+
 // ModelManagerV8 defines the methods on the version 8 facade for the
 // modelmanager API endpoint.
 type ModelManagerV8 interface {
@@ -128,10 +136,16 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
+// ModelManagerAPIV8 provides a way to wrap the different calls between
+// version 9 and version 8 of the model manager API
+type ModelManagerAPIV8 struct {
+	*ModelManagerAPI
+}
+
 // ModelManagerAPIV7 provides a way to wrap the different calls between
 // version 8 and version 7 of the model manager API
 type ModelManagerAPIV7 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV8
 }
 
 // ModelManagerAPIV6 provides a way to wrap the different calls between
@@ -165,7 +179,8 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV9 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV8 = (*ModelManagerAPIV8)(nil)
 	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
 	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
 	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
@@ -174,8 +189,8 @@ var (
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV8 is used for API registration.
-func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV9 is used for API registration.
+func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -212,6 +227,15 @@ func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPIV8, error) {
+	v9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV8{v9}, nil
+}
+
 // NewFacadeV7 is used for API registration.
 func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
 	v8, err := NewFacadeV8(ctx)
@@ -1161,6 +1185,23 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		info.Status = entityStatus
 	}
 
+	if quotas := model.Quotas(); !quotas.IsEmpty() {
+		usage, err := model.QuotaUsage()
+		if shouldErr(err) {
+			return params.ModelInfo{}, errors.Trace(err)
+		}
+		limits := quotas.Limits()
+		for _, name := range coremodel.QuotaNames() {
+			if limit, ok := limits[name]; ok {
+				info.Quotas = append(info.Quotas, params.ModelQuota{
+					Resource: name,
+					Limit:    limit,
+					Used:     usage.Get(name),
+				})
+			}
+		}
+	}
+
 	// If the user is a controller superuser, they are considered a model
 	// admin.
 	modelAdmin := m.isAdmin
@@ -1606,6 +1647,45 @@ func (m *ModelManagerAPI) ChangeModelCredential(args params.ChangeModelCredentia
 	return params.ErrorResults{results}, nil
 }
 
+// SetModelQuotas sets or removes resource limits on models. Only
+// controller superusers may change quotas.
+func (m *ModelManagerAPI) SetModelQuotas(args params.SetModelQuotasArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Models)),
+	}
+	if !m.isAdmin {
+		return results, common.ErrPerm
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	setQuotas := func(arg params.SetModelQuotas) error {
+		modelTag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		model, releaser, err := m.state.GetModel(modelTag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer releaser()
+
+		quotas, err := model.Quotas().Update(arg.Set, arg.Unset)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(model.SetQuotas(quotas))
+	}
+
+	for i, arg := range args.Models {
+		if err := setQuotas(arg); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
 // Mask out new methods from the old API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
@@ -1615,3 +1695,6 @@ func (*ModelManagerAPIV4) ChangeModelCredential(_, _ struct{}) {}
 
 // ModelDefaultsForClouds did not exist prior to v6.
 func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
+
+// SetModelQuotas did not exist prior to v9.
+func (*ModelManagerAPIV8) SetModelQuotas(_, _ struct{}) {}
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
@@ -688,7 +689,9 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{
+								s.api,
+							},
 						},
 					},
 				},
@@ -856,7 +859,9 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{
+							s.api,
+						},
 					},
 				},
 			},
@@ -1704,7 +1709,9 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{
+								s.api,
+							},
 						},
 					},
 				},
@@ -1743,7 +1750,9 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{
+							s.api,
+						},
 					},
 				},
 			},
@@ -1889,6 +1898,52 @@ func (s *modelManagerSuite) TestChangeModelCredentialNotUpdated(c *gc.C) {
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, `model deadbeef-0bad-400d-8000-4b1d0d06f00d already uses credential foo/bob/bar`)
 }
 
+func (s *modelManagerSuite) TestSetModelQuotas(c *gc.C) {
+	s.st.model.quotas = coremodel.Quotas{Machines: pUint64(10)}
+	s.st.model.ResetCalls()
+	results, err := s.api.SetModelQuotas(params.SetModelQuotasArgs{
+		Models: []params.SetModelQuotas{{
+			ModelTag: s.st.ModelTag().String(),
+			Set:      map[string]uint64{"units": 20, "memory": 8192},
+			Unset:    []string{"machines"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.st.model.CheckCall(c, 1, "SetQuotas", coremodel.Quotas{Units: pUint64(20), Memory: pUint64(8192)})
+}
+
+func (s *modelManagerSuite) TestSetModelQuotasInvalid(c *gc.C) {
+	s.st.model.ResetCalls()
+	results, err := s.api.SetModelQuotas(params.SetModelQuotasArgs{
+		Models: []params.SetModelQuotas{{
+			ModelTag: "bad-model-tag",
+		}, {
+			ModelTag: s.st.ModelTag().String(),
+			Set:      map[string]uint64{"gpus": 1},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"bad-model-tag" is not a valid tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `quota "gpus" not valid`)
+	s.st.model.CheckCallNames(c, "Quotas")
+}
+
+func (s *modelManagerSuite) TestSetModelQuotasNotSuperuser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob@remote"))
+	s.st.model.ResetCalls()
+	_, err := s.api.SetModelQuotas(params.SetModelQuotasArgs{
+		Models: []params.SetModelQuotas{{
+			ModelTag: s.st.ModelTag().String(),
+			Set:      map[string]uint64{"units": 20},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.st.model.CheckNoCalls(c)
+}
+
 type fakeProvider struct {
 	environs.CloudEnvironProvider
 }
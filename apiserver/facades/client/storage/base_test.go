@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -44,6 +46,7 @@ type baseStorageSuite struct {
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
+	volumeSnapshot       *mockVolumeSnapshot
 	stub                 testing.Stub
 
 	registry    jujustorage.StaticProviderRegistry
@@ -75,7 +78,11 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPIv7: storage.StorageAPIv7{
+						StorageAPI: *newAPI,
+					},
+				},
 			},
 		},
 	}
@@ -111,7 +118,12 @@ const (
 	detachStorageCall                       = "detachStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	cancelStorageResizeCall                 = "cancelStorageResize"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	addVolumeSnapshotCall                   = "addVolumeSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -163,6 +175,18 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 		life:      state.Alive,
 	}
 
+	s.volumeSnapshot = &mockVolumeSnapshot{
+		id:      "0",
+		volume:  s.volumeTag,
+		pool:    "loop",
+		created: time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+		info: &state.VolumeSnapshotInfo{
+			SnapshotId: "snap-0",
+			Size:       1024,
+		},
+		life: state.Alive,
+	}
+
 	s.volumeAttachmentPlan = &mockVolumeAttachmentPlan{
 		VolumeTag: s.volumeTag,
 		HostTag:   s.machineTag,
@@ -316,10 +340,30 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(releaseStorageInstanceCall, tag, destroyAttached, force)
 			return errors.New("cannae do it")
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
+		cancelStorageResize: func(tag names.StorageTag) error {
+			s.stub.AddCall(cancelStorageResizeCall, tag)
+			return s.stub.NextErr()
+		},
 		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		addVolumeSnapshot: func(tag names.VolumeTag) (string, error) {
+			s.stub.AddCall(addVolumeSnapshotCall, tag)
+			return "0", s.stub.NextErr()
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.stub.AddCall(allVolumeSnapshotsCall)
+			return []state.VolumeSnapshot{s.volumeSnapshot}, s.stub.NextErr()
+		},
+		destroyVolumeSnapshot: func(id string) error {
+			s.stub.AddCall(destroyVolumeSnapshotCall, id)
+			return s.stub.NextErr()
+		},
 	}
 }
 
@@ -68,9 +68,14 @@ type mockStorageAccessor struct {
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag, bool, bool) error
 	releaseStorageInstance              func(names.StorageTag, bool, bool) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	cancelStorageResize                 func(names.StorageTag) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	addVolumeSnapshot                   func(names.VolumeTag) (string, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(string) error
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -181,6 +186,14 @@ func (st *mockStorageAccessor) ReleaseStorageInstance(tag names.StorageTag, dest
 	return st.releaseStorageInstance(tag, destroyAttached, force)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockStorageAccessor) CancelStorageResize(tag names.StorageTag) error {
+	return st.cancelStorageResize(tag)
+}
+
 func (st *mockStorageAccessor) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	panic("should not be called")
 }
@@ -189,6 +202,18 @@ func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockStorageAccessor) AddVolumeSnapshot(tag names.VolumeTag) (string, error) {
+	return st.addVolumeSnapshot(tag)
+}
+
+func (st *mockStorageAccessor) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockStorageAccessor) DestroyVolumeSnapshot(id string) error {
+	return st.destroyVolumeSnapshot(id)
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
@@ -230,6 +255,47 @@ func (m *mockVolume) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Attached}, nil
 }
 
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id      string
+	volume  names.VolumeTag
+	pool    string
+	created time.Time
+	info    *state.VolumeSnapshotInfo
+	life    state.Life
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return m.pool
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info != nil {
+		return *m.info, nil
+	}
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
+}
+
+func (m *mockVolumeSnapshot) Life() state.Life {
+	return m.life
+}
+
+func (m *mockVolumeSnapshot) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: status.Available}, nil
+}
+
 type mockFilesystem struct {
 	state.Filesystem
 	tag     names.FilesystemTag
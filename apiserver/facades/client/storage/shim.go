@@ -61,6 +61,14 @@ type storageInterface interface {
 
 	// ReleaseStorageInstance releases the storage instance with the specified tag.
 	ReleaseStorageInstance(names.StorageTag, bool, bool, time.Duration) error
+
+	// ResizeStorageInstance grows the storage instance with the specified
+	// tag to the specified size in MiB.
+	ResizeStorageInstance(names.StorageTag, uint64) error
+
+	// CancelStorageResize cancels any pending resize of the storage
+	// instance with the specified tag.
+	CancelStorageResize(names.StorageTag) error
 }
 
 type storageVolume interface {
@@ -82,6 +90,17 @@ type storageVolume interface {
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// AddVolumeSnapshot requests a snapshot of the volume with the
+	// specified tag, returning the ID of the snapshot.
+	AddVolumeSnapshot(names.VolumeTag) (string, error)
+
+	// AllVolumeSnapshots returns all volume snapshots in the model.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot destroys the volume snapshot with the
+	// specified ID.
+	DestroyVolumeSnapshot(string) error
 }
 
 type storageFile interface {
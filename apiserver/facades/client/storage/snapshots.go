@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/state"
+)
+
+// CreateStorageSnapshots requests point-in-time snapshots of the
+// volumes of the specified storage instances, returning the ID of
+// each snapshot. Filesystem storage may be snapshotted only if the
+// filesystem is backed by a volume.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) CreateStorageSnapshots(args params.Entities) (params.StringResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.StringResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		id, err := a.createStorageSnapshot(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = id
+	}
+	return params.StringResults{Results: results}, nil
+}
+
+func (a *StorageAPI) createStorageSnapshot(arg params.Entity) (string, error) {
+	tag, err := names.ParseStorageTag(arg.Tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	storageInstance, err := a.storageAccess.StorageInstance(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	switch storageInstance.Kind() {
+	case state.StorageKindBlock:
+		volume, err := a.storageAccess.VolumeAccess().StorageInstanceVolume(tag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		volumeTag = volume.VolumeTag()
+	case state.StorageKindFilesystem:
+		filesystem, err := a.storageAccess.FilesystemAccess().StorageInstanceFilesystem(tag)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		volumeTag, err = filesystem.Volume()
+		if err == state.ErrNoBackingVolume {
+			return "", errors.NotSupportedf(
+				"snapshotting %s without a backing volume",
+				names.ReadableString(tag),
+			)
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+	default:
+		return "", errors.Errorf("invalid storage kind %v", storageInstance.Kind())
+	}
+	return a.storageAccess.VolumeAccess().AddVolumeSnapshot(volumeTag)
+}
+
+// ListStorageSnapshots returns the details of all volume snapshots
+// in the model.
+func (a *StorageAPI) ListStorageSnapshots() (params.StorageSnapshotDetailsList, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotDetailsList{}, errors.Trace(err)
+	}
+	volumeAccess := a.storageAccess.VolumeAccess()
+	snapshots, err := volumeAccess.AllVolumeSnapshots()
+	if err != nil {
+		return params.StorageSnapshotDetailsList{}, errors.Trace(err)
+	}
+	result := params.StorageSnapshotDetailsList{
+		Snapshots: make([]params.StorageSnapshotDetails, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		details := params.StorageSnapshotDetails{
+			Id:        snapshot.Id(),
+			VolumeTag: snapshot.Volume().String(),
+			Pool:      snapshot.Pool(),
+			Created:   snapshot.Created(),
+			Life:      life.Value(snapshot.Life().String()),
+		}
+		if info, err := snapshot.Info(); err == nil {
+			details.SnapshotId = info.SnapshotId
+			details.Size = info.Size
+		} else if !errors.IsNotProvisioned(err) {
+			return params.StorageSnapshotDetailsList{}, errors.Trace(err)
+		}
+		if volume, err := volumeAccess.Volume(snapshot.Volume()); err == nil {
+			if storageTag, err := volume.StorageInstance(); err == nil {
+				details.StorageTag = storageTag.String()
+			}
+		} else if !errors.IsNotFound(err) {
+			return params.StorageSnapshotDetailsList{}, errors.Trace(err)
+		}
+		snapshotStatus, err := snapshot.Status()
+		if err != nil {
+			return params.StorageSnapshotDetailsList{}, errors.Trace(err)
+		}
+		details.Status = common.EntityStatusFromState(snapshotStatus)
+		result.Snapshots[i] = details
+	}
+	return result, nil
+}
+
+// RemoveStorageSnapshots destroys the volume snapshots with the
+// specified IDs.
+// A "REMOVE" block can block this operation.
+func (a *StorageAPI) RemoveStorageSnapshots(args params.VolumeSnapshotIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		if !names.IsValidVolume(id) {
+			results[i].Error = common.ServerError(errors.NotValidf("volume snapshot ID %q", id))
+			continue
+		}
+		err := a.storageAccess.VolumeAccess().DestroyVolumeSnapshot(id)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
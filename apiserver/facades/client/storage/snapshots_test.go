@@ -0,0 +1,116 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) TestCreateStorageSnapshots(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+
+	results, err := s.api.CreateStorageSnapshots(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{
+		{Result: "0"},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceFilesystemCall, nil},
+		{addVolumeSnapshotCall, []interface{}{s.volumeTag}},
+	})
+}
+
+func (s *storageSnapshotSuite) TestCreateStorageSnapshotsNoBackingVolume(c *gc.C) {
+	results, err := s.api.CreateStorageSnapshots(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{{
+		Error: &params.Error{
+			Message: "snapshotting storage data/0 without a backing volume not supported",
+			Code:    params.CodeNotSupported,
+		},
+	}})
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall})
+}
+
+func (s *storageSnapshotSuite) TestCreateStorageSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "snapshot")
+	_, err := s.api.CreateStorageSnapshots(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	s.assertBlocked(c, err, "snapshot")
+}
+
+func (s *storageSnapshotSuite) TestListStorageSnapshots(c *gc.C) {
+	result, err := s.api.ListStorageSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Snapshots, jc.DeepEquals, []params.StorageSnapshotDetails{{
+		Id:         "0",
+		VolumeTag:  s.volumeTag.String(),
+		StorageTag: s.storageTag.String(),
+		Pool:       "loop",
+		SnapshotId: "snap-0",
+		Size:       1024,
+		Created:    time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+		Life:       "alive",
+		Status: params.EntityStatus{
+			Status: status.Available,
+		},
+	}})
+	s.assertCalls(c, []string{allVolumeSnapshotsCall, volumeCall})
+}
+
+func (s *storageSnapshotSuite) TestListStorageSnapshotsNotProvisioned(c *gc.C) {
+	s.volumeSnapshot.info = nil
+	result, err := s.api.ListStorageSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Snapshots, gc.HasLen, 1)
+	c.Assert(result.Snapshots[0].SnapshotId, gc.Equals, "")
+	c.Assert(result.Snapshots[0].Size, gc.Equals, uint64(0))
+}
+
+func (s *storageSnapshotSuite) TestRemoveStorageSnapshots(c *gc.C) {
+	results, err := s.api.RemoveStorageSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"0", "snap"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Message: `volume snapshot ID "snap" not valid`, Code: params.CodeNotValid}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.RemoveBlock}},
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{destroyVolumeSnapshotCall, []interface{}{"0"}},
+	})
+}
+
+func (s *storageSnapshotSuite) TestRemoveStorageSnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "remove")
+	_, err := s.api.RemoveStorageSnapshots(params.VolumeSnapshotIds{
+		Ids: []string{"0"},
+	})
+	s.assertBlocked(c, err, "remove")
+}
@@ -30,7 +30,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v8) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -41,9 +41,19 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// StorageAPIv7 implements the storage v7 API.
+type StorageAPIv7 struct {
+	StorageAPI
+}
+
+// StorageAPIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPIv7
+}
+
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -104,14 +114,36 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV7 returns a new storage v7 API facade.
+func NewStorageAPIV7(context facade.Context) (*StorageAPIv7, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv7{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPIV7(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPIv7: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -870,7 +902,7 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 	}
 
 	paramsToState := func(p params.StorageConstraints) state.StorageConstraints {
-		s := state.StorageConstraints{Pool: p.Pool}
+		s := state.StorageConstraints{Pool: p.Pool, Snapshot: p.Snapshot}
 		if p.Size != nil {
 			s.Size = *p.Size
 		}
@@ -1209,10 +1241,143 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 	return results, nil
 }
 
+// ResizeStorage requests that the volumes or filesystems of the
+// specified storage instances be grown to the given sizes.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) ResizeStorage(args params.ResizeStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		if arg.Size == 0 {
+			result[i].Error = common.ServerError(errors.NotValidf("size 0"))
+			continue
+		}
+		if err := a.checkResizeSupported(tag); err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		err = a.storageAccess.ResizeStorageInstance(tag, arg.Size)
+		result[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// checkResizeSupported returns an error satisfying errors.IsNotSupported
+// if the storage provider of the volume or filesystem assigned to the
+// specified storage instance cannot grow it. Filesystems backed by a
+// volume are grown by Juju once the volume has been grown, so only
+// the volume's provider is consulted for those.
+func (a *StorageAPI) checkResizeSupported(tag names.StorageTag) error {
+	storageInstance, err := a.storageAccess.StorageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var volumeTag names.VolumeTag
+	switch storageInstance.Kind() {
+	case state.StorageKindBlock:
+		volume, err := a.storageAccess.VolumeAccess().StorageInstanceVolume(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag = volume.VolumeTag()
+	case state.StorageKindFilesystem:
+		filesystem, err := a.storageAccess.FilesystemAccess().StorageInstanceFilesystem(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag, err = filesystem.Volume()
+		if err == state.ErrNoBackingVolume {
+			info, err := filesystem.Info()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return a.checkPoolSupportsResize(info.Pool, storage.StorageKindFilesystem)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.Errorf("invalid storage kind %v", storageInstance.Kind())
+	}
+	volume, err := a.storageAccess.VolumeAccess().Volume(volumeTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return a.checkPoolSupportsResize(info.Pool, storage.StorageKindBlock)
+}
+
+func (a *StorageAPI) checkPoolSupportsResize(pool string, kind storage.StorageKind) error {
+	providerType, _, err := storagecommon.StoragePoolConfig(pool, a.poolManager, a.registry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resizer, ok := provider.(storage.ResizeSupporter); !ok || !resizer.SupportsResize(kind) {
+		return errors.NotSupportedf("resizing %s storage with provider %q", kind, providerType)
+	}
+	return nil
+}
+
+// CancelStorageResize cancels any pending resize of the volumes or
+// filesystems of the specified storage instances, so that a resize
+// which the storage provisioner cannot complete no longer blocks
+// other operations on the model.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) CancelStorageResize(args params.Entities) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		err = a.storageAccess.CancelStorageResize(tag)
+		result[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
 // Mask out old methods from the new API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v8 api version
+func (*StorageAPIv7) CancelStorageResize(_, _ struct{})    {}
+func (*StorageAPIv7) CreateStorageSnapshots(_, _ struct{}) {}
+func (*StorageAPIv7) ListStorageSnapshots(_, _ struct{})   {}
+func (*StorageAPIv7) RemoveStorageSnapshots(_, _ struct{}) {}
+
+// Added in v7 api version
+func (*StorageAPIv6) ResizeStorage(_, _ struct{}) {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
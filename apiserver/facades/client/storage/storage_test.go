@@ -371,7 +371,11 @@ func (s *storageSuite) TestDestroyV3(c *gc.C) {
 
 func (s *storageSuite) TestDetachV5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
@@ -442,7 +446,11 @@ func (s *storageSuite) TestDetachV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -512,7 +520,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentv5(c *gc.C) {
 		)
 	}
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0"},
@@ -568,7 +580,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachNoAttachmentsStorageNotFoundv5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-foo-42"},
@@ -628,6 +644,97 @@ func (s *storageSuite) TestAttach(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) TestResizeStorage(c *gc.C) {
+	s.filesystem.info = &state.FilesystemInfo{Pool: "radiance", Size: 1024}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{IsResizable: true}
+
+	results, err := s.api.ResizeStorage(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-data-0", Size: 2048},
+		{Tag: "storage-data-0", Size: 0},
+		{Tag: "volume-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Message: `size 0 not valid`, Code: params.CodeNotValid}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceFilesystemCall, nil},
+		{resizeStorageInstanceCall, []interface{}{s.storageTag, uint64(2048)}},
+	})
+}
+
+func (s *storageSuite) TestResizeStorageBackingVolume(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{Pool: "radiance", Size: 1024}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{IsResizable: true}
+
+	results, err := s.api.ResizeStorage(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-data-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{Error: nil}})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceFilesystemCall, nil},
+		{volumeCall, nil},
+		{resizeStorageInstanceCall, []interface{}{s.storageTag, uint64(2048)}},
+	})
+}
+
+func (s *storageSuite) TestResizeStorageNotSupported(c *gc.C) {
+	s.filesystem.info = &state.FilesystemInfo{Pool: "radiance", Size: 1024}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{IsResizable: false}
+
+	results, err := s.api.ResizeStorage(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-data-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Message: `resizing filesystem storage with provider "radiance" not supported`,
+			Code:    params.CodeNotSupported,
+		},
+	}})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall)
+}
+
+func (s *storageSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "resize")
+	_, err := s.api.ResizeStorage(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-data-0", Size: 2048},
+	}})
+	s.assertBlocked(c, err, "resize")
+}
+
+func (s *storageSuite) TestCancelStorageResize(c *gc.C) {
+	results, err := s.api.CancelStorageResize(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{cancelStorageResizeCall, []interface{}{s.storageTag}},
+	})
+}
+
+func (s *storageSuite) TestCancelStorageResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "cancel")
+	_, err := s.api.CancelStorageResize(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	s.assertBlocked(c, err, "cancel")
+}
+
 func (s *storageSuite) TestImportFilesystem(c *gc.C) {
 	s.state.modelTag = coretesting.ModelTag
 	filesystemSource := filesystemImporter{&dummy.FilesystemSource{}}
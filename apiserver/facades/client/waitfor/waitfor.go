@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/waitfor"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.waitfor")
+
+// MaxTimeout is the longest a single WaitFor call will block.
+// Clients wanting to wait longer should call again.
+const MaxTimeout = 5 * time.Minute
+
+// Model describes the cached model that queries are evaluated against.
+type Model interface {
+	waitfor.Model
+	WatchChanges() cache.NotifyWatcher
+}
+
+// API implements the WaitFor facade.
+type API struct {
+	model  Model
+	clock  clock.Clock
+	cancel <-chan struct{}
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	if err := checkCanRead(ctx.Auth(), st.ControllerTag(), names.NewModelTag(st.ModelUUID())); err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := ctx.CachedModel(st.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(model, clock.WallClock, ctx.Cancel()), nil
+}
+
+// NewAPI returns a WaitFor API evaluating queries against the model.
+func NewAPI(model Model, clk clock.Clock, cancel <-chan struct{}) *API {
+	return &API{
+		model:  model,
+		clock:  clk,
+		cancel: cancel,
+	}
+}
+
+func checkCanRead(auth facade.Authorizer, controllerTag names.ControllerTag, modelTag names.ModelTag) error {
+	if !auth.AuthClient() {
+		return common.ErrPerm
+	}
+	isAdmin, err := auth.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	canRead, err := auth.HasPermission(permission.ReadAccess, modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead && !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// WaitFor blocks until the entity satisfies the query, the timeout
+// expires or the connection is closed. The query is re-evaluated
+// against the model cache each time the model changes. An entity that
+// does not exist is treated as not satisfying the query, so that
+// callers can wait for it to appear.
+func (api *API) WaitFor(args params.WaitForArgs) (params.WaitForResult, error) {
+	var result params.WaitForResult
+	kind := waitfor.Kind(args.Kind)
+	query, err := waitfor.Parse(kind, args.Query)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if kind != waitfor.KindModel && args.Name == "" {
+		return result, errors.NotValidf("empty %s name", kind)
+	}
+	timeout := args.Timeout
+	if timeout <= 0 || timeout > MaxTimeout {
+		timeout = MaxTimeout
+	}
+
+	w := api.model.WatchChanges()
+	defer func() { _ = w.Stop() }()
+	timer := api.clock.After(timeout)
+	for {
+		select {
+		case <-api.cancel:
+			return result, errors.New("wait cancelled")
+		case <-timer:
+			return result, nil
+		case _, ok := <-w.Changes():
+			if !ok {
+				return result, errors.New("model cache watcher closed")
+			}
+		}
+		values, err := waitfor.EntityValues(api.model, kind, args.Name)
+		if errors.IsNotFound(err) {
+			logger.Tracef("waiting for %s %q to exist", kind, args.Name)
+			result.Values = nil
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Values = values
+		if query.Match(values) {
+			result.Satisfied = true
+			return result, nil
+		}
+	}
+}
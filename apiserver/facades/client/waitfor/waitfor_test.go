@@ -0,0 +1,188 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/facades/client/waitfor"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/cache/cachetest"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type waitForSuite struct {
+	testing.IsolationSuite
+
+	ctrl   *cachetest.TestController
+	clock  *testclock.Clock
+	cancel chan struct{}
+	api    *waitfor.API
+}
+
+var _ = gc.Suite(&waitForSuite{})
+
+const modelUUID = "model-uuid"
+
+func (s *waitForSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.ctrl = cachetest.NewTestController(cachetest.ModelEvents, cachetest.UnitEvents)
+	s.ctrl.Init(c)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, s.ctrl.Controller) })
+
+	s.ctrl.SendChange(cache.ModelChange{
+		ModelUUID: modelUUID,
+		Name:      "test",
+		Life:      life.Alive,
+		Status:    status.StatusInfo{Status: status.Available},
+	})
+	_ = s.ctrl.NextChange(c)
+
+	model, err := s.ctrl.Model(modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock = testclock.NewClock(time.Now())
+	s.cancel = make(chan struct{})
+	s.api = waitfor.NewAPI(model, s.clock, s.cancel)
+}
+
+func (s *waitForSuite) sendUnit(c *gc.C, workload status.Status) {
+	s.ctrl.SendChange(cache.UnitChange{
+		ModelUUID:      modelUUID,
+		Name:           "mysql/0",
+		Application:    "mysql",
+		Life:           life.Alive,
+		WorkloadStatus: status.StatusInfo{Status: workload},
+		AgentStatus:    status.StatusInfo{Status: status.Idle},
+	})
+	_ = s.ctrl.NextChange(c)
+}
+
+type waitForResult struct {
+	result params.WaitForResult
+	err    error
+}
+
+func (s *waitForSuite) waitFor(args params.WaitForArgs) <-chan waitForResult {
+	done := make(chan waitForResult, 1)
+	go func() {
+		result, err := s.api.WaitFor(args)
+		done <- waitForResult{result, err}
+	}()
+	return done
+}
+
+func (s *waitForSuite) assertNotDone(c *gc.C, done <-chan waitForResult) {
+	select {
+	case r := <-done:
+		c.Fatalf("unexpected result %#v", r)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *waitForSuite) assertDone(c *gc.C, done <-chan waitForResult) waitForResult {
+	select {
+	case r := <-done:
+		return r
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+	panic("unreachable")
+}
+
+func (s *waitForSuite) TestAlreadySatisfied(c *gc.C) {
+	s.sendUnit(c, status.Active)
+	r := s.assertDone(c, s.waitFor(params.WaitForArgs{
+		Kind:    "unit",
+		Name:    "mysql/0",
+		Query:   "workload-status == active",
+		Timeout: time.Minute,
+	}))
+	c.Assert(r.err, jc.ErrorIsNil)
+	c.Assert(r.result.Satisfied, jc.IsTrue)
+	c.Assert(r.result.Values["workload-status"], jc.DeepEquals, []string{"active"})
+}
+
+func (s *waitForSuite) TestWaitsForEntityToExistAndMatch(c *gc.C) {
+	done := s.waitFor(params.WaitForArgs{
+		Kind:    "unit",
+		Name:    "mysql/0",
+		Query:   "workload-status == active",
+		Timeout: time.Minute,
+	})
+	s.assertNotDone(c, done)
+
+	s.sendUnit(c, status.Maintenance)
+	s.assertNotDone(c, done)
+
+	s.sendUnit(c, status.Active)
+	r := s.assertDone(c, done)
+	c.Assert(r.err, jc.ErrorIsNil)
+	c.Assert(r.result.Satisfied, jc.IsTrue)
+}
+
+func (s *waitForSuite) TestTimeout(c *gc.C) {
+	s.sendUnit(c, status.Maintenance)
+	done := s.waitFor(params.WaitForArgs{
+		Kind:    "model",
+		Query:   "units == 2",
+		Timeout: time.Minute,
+	})
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	r := s.assertDone(c, done)
+	c.Assert(r.err, jc.ErrorIsNil)
+	c.Assert(r.result.Satisfied, jc.IsFalse)
+	c.Assert(r.result.Values["units"], jc.DeepEquals, []string{"1"})
+}
+
+func (s *waitForSuite) TestTimeoutCapped(c *gc.C) {
+	done := s.waitFor(params.WaitForArgs{
+		Kind:    "model",
+		Query:   "units == 2",
+		Timeout: time.Hour,
+	})
+	err := s.clock.WaitAdvance(waitfor.MaxTimeout, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	r := s.assertDone(c, done)
+	c.Assert(r.err, jc.ErrorIsNil)
+	c.Assert(r.result.Satisfied, jc.IsFalse)
+}
+
+func (s *waitForSuite) TestCancel(c *gc.C) {
+	done := s.waitFor(params.WaitForArgs{
+		Kind:  "model",
+		Query: "units == 2",
+	})
+	s.assertNotDone(c, done)
+	close(s.cancel)
+	r := s.assertDone(c, done)
+	c.Assert(r.err, gc.ErrorMatches, "wait cancelled")
+}
+
+func (s *waitForSuite) TestInvalidQuery(c *gc.C) {
+	_, err := s.api.WaitFor(params.WaitForArgs{
+		Kind:  "machine",
+		Name:  "0",
+		Query: "exposed == true",
+	})
+	c.Assert(err, gc.ErrorMatches, `field "exposed" not valid for machine .*`)
+}
+
+func (s *waitForSuite) TestMissingName(c *gc.C) {
+	_, err := s.api.WaitFor(params.WaitForArgs{
+		Kind:  "application",
+		Query: "status == active",
+	})
+	c.Assert(err, gc.ErrorMatches, "empty application name not valid")
+}
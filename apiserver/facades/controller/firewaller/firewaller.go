@@ -16,6 +16,7 @@ import (
 	corefirewall "github.com/juju/juju/core/firewall"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
@@ -52,6 +53,12 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+	*common.APIAddresser
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -95,6 +102,18 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+		APIAddresser:    common.NewAPIAddresser(context.State(), context.Resources()),
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -530,3 +549,69 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetEgressRules returns the egress rules of each given application.
+func (f *FirewallerAPIV6) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Restricted = application.EgressRestricted()
+		for _, rule := range application.EgressRules() {
+			result.Results[i].Rules = append(result.Results[i].Rules, params.EgressRule{
+				PortRange:        params.FromNetworkPortRange(rule.PortRange),
+				DestinationCIDRs: rule.DestinationCIDRs,
+			})
+		}
+	}
+	return result, nil
+}
+
+// SetMachineEgressRules records the egress firewall to be enforced by
+// the agent of each given machine, for machines whose provider can't
+// enforce egress rules.
+func (f *FirewallerAPIV6) SetMachineEgressRules(args params.MachineEgressRulesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rules := make([]jujunetwork.EgressRule, len(arg.Rules))
+		for j, rule := range arg.Rules {
+			rules[j] = jujunetwork.EgressRule{
+				PortRange:        rule.PortRange.NetworkPortRange(),
+				DestinationCIDRs: rule.DestinationCIDRs,
+			}
+		}
+		err = machine.SetEgressRules(arg.Restricted, rules)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
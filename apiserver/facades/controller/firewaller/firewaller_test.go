@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/network"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -278,3 +279,75 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetEgressRules(c *gc.C) {
+	err := s.application.AllowEgress(jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	result, err := apiv6.GetEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Restricted: true, Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/24"},
+			}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestSetMachineEgressRules(c *gc.C) {
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	result, err := apiv6.SetMachineEgressRules(params.MachineEgressRulesArgs{
+		Args: []params.MachineEgressRules{{
+			MachineTag: s.machines[0].Tag().String(),
+			Restricted: true,
+			Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/24"},
+			}},
+		}, {
+			MachineTag: "machine-42",
+		}, {
+			MachineTag: s.application.Tag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.machines[0].Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machines[0].EgressRestricted(), jc.IsTrue)
+	c.Assert(s.machines[0].EgressRules(), jc.DeepEquals, []jujunetwork.EgressRule{
+		jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	})
+}
@@ -217,3 +217,18 @@ func (mr *MockPrecheckBackendMockRecorder) NeedsCleanup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsCleanup", reflect.TypeOf((*MockPrecheckBackend)(nil).NeedsCleanup))
 }
+
+// PendingStorageResizes mocks base method
+func (m *MockPrecheckBackend) PendingStorageResizes() ([]names_v3.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingStorageResizes")
+	ret0, _ := ret[0].([]names_v3.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingStorageResizes indicates an expected call of PendingStorageResizes
+func (mr *MockPrecheckBackendMockRecorder) PendingStorageResizes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStorageResizes", reflect.TypeOf((*MockPrecheckBackend)(nil).PendingStorageResizes))
+}
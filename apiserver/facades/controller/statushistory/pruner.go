@@ -4,9 +4,14 @@
 package statushistory
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/state"
 )
 
@@ -33,10 +38,47 @@ func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API,
 
 // Prune endpoint removes status history entries until
 // only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// the history is smaller than p.MaxHistoryMB. If the model
+// is configured to archive status history, the entries are
+// archived before they are removed.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthController() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	m, err := api.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	archive := state.PruneArchiveConfig{
+		MaxFileSizeMB: int(cfg.StatusHistoryArchiveFileSizeMB()),
+		MaxAge:        cfg.StatusHistoryArchiveAge(),
+	}
+	switch cfg.StatusHistoryArchive() {
+	case config.StatusHistoryArchiveFile:
+		controllerCfg, err := api.st.ControllerConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		archive.Dir = controllerCfg.StatusHistoryArchiveDir()
+		archive.MaxTotalSizeMB = controllerCfg.StatusHistoryArchiveMaxSizeMB()
+		if archive.Dir == "" {
+			return errors.Errorf("cannot archive status history to files: controller %s not set", controller.StatusHistoryArchiveDir)
+		}
+	case config.StatusHistoryArchiveSyslog:
+		syslogCfg, ok := cfg.LogFwdSyslog()
+		if !ok || syslogCfg.Host == "" {
+			return errors.Errorf("cannot archive status history to syslog: %s not set", config.LogFwdSyslogHost)
+		}
+		sender, err := syslog.OpenSender(*syslogCfg, syslog.NewSenderOpener())
+		if err != nil {
+			return errors.Annotate(err, "cannot archive status history to syslog")
+		}
+		defer sender.Close()
+		archive.Syslog = sender
+	}
+	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB, archive)
 }
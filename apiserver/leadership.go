@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
 )
 
 // leadershipChecker implements leadership.Checker by wrapping a lease.Checker.
@@ -91,6 +92,39 @@ func (m leadershipPinner) PinnedLeadership() map[string][]string {
 	return m.pinner.Pinned()
 }
 
+// leadershipTransferer implements leadership.Transferer by wrapping a
+// lease.Transferer.
+type leadershipTransferer struct {
+	transferer lease.Transferer
+
+	// st is the controller's state, used to check that all the
+	// controllers can apply transfers.
+	st *state.State
+}
+
+// TransferLeadership (leadership.Transferer) transfers the lease for the
+// input application from the input unit to the new unit.
+func (m leadershipTransferer) TransferLeadership(applicationName, unitName, newUnitName string, duration time.Duration) error {
+	// Transfers are applied by the lease FSM on every controller, and
+	// controllers that haven't been upgraded don't know how to, so
+	// refuse them until all the controllers have been.
+	upgraded, err := m.st.ControllersRunningVersion(jujuversion.Current)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !upgraded {
+		return errors.NotSupportedf("transferring leadership until all controllers are running %s", jujuversion.Current)
+	}
+	err = m.transferer.Transfer(applicationName, unitName, newUnitName, duration)
+	switch errors.Cause(err) {
+	case lease.ErrNotHeld:
+		return leadership.NewNotLeaderError(unitName, applicationName)
+	case lease.ErrPinned:
+		return leadership.ErrLeadershipPinned
+	}
+	return errors.Trace(err)
+}
+
 // leadershipReader implements leadership.Reader by wrapping a lease.Reader.
 type leadershipReader struct {
 	reader lease.Reader
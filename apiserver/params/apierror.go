@@ -185,6 +185,7 @@ const (
 	CodeIncompatibleSeries        = "incompatible series"
 	CodeCloudRegionRequired       = "cloud region required"
 	CodeIncompatibleClouds        = "incompatible clouds"
+	CodeQuotaExceeded             = "quota exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -358,3 +359,7 @@ func IsCodeForbidden(err error) bool {
 func IsCodeCloudRegionRequired(err error) bool {
 	return ErrCode(err) == CodeCloudRegionRequired
 }
+
+func IsCodeQuotaExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaExceeded
+}
@@ -446,3 +446,67 @@ type ApplicationInfoResult struct {
 type ApplicationInfoResults struct {
 	Results []ApplicationInfoResult `json:"results"`
 }
+
+// TransferLeadershipArgs holds bulk parameters for the
+// Application.TransferLeadership call.
+type TransferLeadershipArgs struct {
+	Args []TransferLeadershipArg `json:"args"`
+}
+
+// TransferLeadershipArg holds parameters for transferring leadership of
+// an application to another of its units.
+type TransferLeadershipArg struct {
+	// ApplicationTag holds the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// UnitTag holds the tag of the unit to transfer leadership to.
+	// If empty, the next healthy unit of the application is chosen.
+	UnitTag string `json:"unit-tag,omitempty"`
+}
+
+// TransferLeadershipResults contains the results of a
+// TransferLeadership API request.
+type TransferLeadershipResults struct {
+	Results []TransferLeadershipResult `json:"results"`
+}
+
+// TransferLeadershipResult contains one of the results of a
+// TransferLeadership API request.
+type TransferLeadershipResult struct {
+	// Leader holds the name of the unit that is now the leader.
+	Leader string `json:"leader,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// UnitsMaintenanceModeArgs holds bulk parameters for the
+// Application.SetUnitsMaintenanceMode call.
+type UnitsMaintenanceModeArgs struct {
+	Args []UnitMaintenanceModeArg `json:"args"`
+}
+
+// UnitMaintenanceModeArg holds parameters for putting a unit into, or
+// taking it out of, maintenance mode.
+type UnitMaintenanceModeArg struct {
+	// UnitTag holds the tag of the unit.
+	UnitTag string `json:"unit-tag"`
+
+	// MaintenanceMode is true to put the unit into maintenance mode,
+	// and false to take it out again.
+	MaintenanceMode bool `json:"maintenance-mode"`
+}
+
+// ApplicationEgressRulesArgs holds bulk parameters for the
+// Application.AllowEgress and Application.RevokeEgress calls.
+type ApplicationEgressRulesArgs struct {
+	Args []ApplicationEgressRules `json:"args"`
+}
+
+// ApplicationEgressRules holds egress rules to be allowed for,
+// or revoked from, an application.
+type ApplicationEgressRules struct {
+	// ApplicationTag holds the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Rules holds the egress rules to allow or revoke.
+	Rules []EgressRule `json:"rules"`
+}
@@ -14,6 +14,13 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+
+	// Passphrase, if set, is used to encrypt the backup archive.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// PublicKey, if set, is an armored OpenPGP public key that the
+	// backup archive is encrypted to.
+	PublicKey string `json:"public-key,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -90,10 +97,26 @@ type BackupsMetadataResult struct {
 
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
+
+	// Encryption is the method used to encrypt the backup archive,
+	// if it is encrypted.
+	Encryption string `json:"encryption,omitempty"`
+
+	// EncryptionKeyID is the fingerprint of the public key the backup
+	// archive was encrypted to, if any.
+	EncryptionKeyID string `json:"encryption-key-id,omitempty"`
 }
 
 // RestoreArgs Holds the backup file or id
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// Passphrase is used to decrypt an encrypted backup, or the
+	// private key that decrypts it.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// PrivateKey is an armored OpenPGP private key used to decrypt a
+	// backup that was encrypted to its public key.
+	PrivateKey string `json:"private-key,omitempty"`
 }
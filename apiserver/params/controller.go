@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/core/life"
+import (
+	"time"
+
+	"github.com/juju/juju/core/life"
+)
 
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
@@ -115,3 +119,80 @@ type ControllerVersionResults struct {
 	Version   string `json:"version"`
 	GitCommit string `json:"git-commit"`
 }
+
+// AuditLogQuery holds the criteria used to select records from a
+// controller's audit log. Empty fields match everything.
+type AuditLogQuery struct {
+	User           string     `json:"user,omitempty"`
+	ModelUUID      string     `json:"model-uuid,omitempty"`
+	ConversationID string     `json:"conversation-id,omitempty"`
+	Facade         string     `json:"facade,omitempty"`
+	Method         string     `json:"method,omitempty"`
+	After          *time.Time `json:"after,omitempty"`
+	Before         *time.Time `json:"before,omitempty"`
+	Limit          int        `json:"limit,omitempty"`
+}
+
+// AuditLogResult holds the audit records selected by an
+// AuditLogQuery. Each record holds exactly one of Conversation,
+// Request or Errors.
+type AuditLogResult struct {
+	Records []AuditLogRecord `json:"records"`
+}
+
+// AuditLogRecord holds a single audit log entry.
+type AuditLogRecord struct {
+	Conversation *AuditConversation   `json:"conversation,omitempty"`
+	Request      *AuditRequest        `json:"request,omitempty"`
+	Errors       *AuditResponseErrors `json:"errors,omitempty"`
+}
+
+// AuditConversation describes a series of API requests made by a
+// single client for one command.
+type AuditConversation struct {
+	Who            string `json:"who"`
+	What           string `json:"what"`
+	When           string `json:"when"`
+	ModelName      string `json:"model-name"`
+	ModelUUID      string `json:"model-uuid"`
+	ConversationID string `json:"conversation-id"`
+	ConnectionID   string `json:"connection-id"`
+}
+
+// AuditRequest describes an API request made in a conversation.
+type AuditRequest struct {
+	ConversationID string `json:"conversation-id"`
+	ConnectionID   string `json:"connection-id"`
+	RequestID      uint64 `json:"request-id"`
+	When           string `json:"when"`
+	Facade         string `json:"facade"`
+	Method         string `json:"method"`
+	Version        int    `json:"version"`
+	Args           string `json:"args,omitempty"`
+}
+
+// AuditResponseErrors holds the errors returned for an API request.
+type AuditResponseErrors struct {
+	ConversationID string        `json:"conversation-id"`
+	ConnectionID   string        `json:"connection-id"`
+	RequestID      uint64        `json:"request-id"`
+	When           string        `json:"when"`
+	Errors         []*AuditError `json:"errors"`
+}
+
+// AuditError is an error returned for an API request.
+type AuditError struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+// BackupStatusResult holds the outcome of the controller's scheduled
+// backups. The times are omitted when there is nothing to report.
+type BackupStatusResult struct {
+	Schedule     string     `json:"schedule,omitempty"`
+	NextBackup   *time.Time `json:"next-backup,omitempty"`
+	LastSuccess  *time.Time `json:"last-success,omitempty"`
+	LastBackupID string     `json:"last-backup-id,omitempty"`
+	LastFailure  *time.Time `json:"last-failure,omitempty"`
+	LastError    string     `json:"last-error,omitempty"`
+}
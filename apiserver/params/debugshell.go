@@ -0,0 +1,45 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// DebugShellConfig holds the parameters for opening a debug shell
+// stream, either by an operator debugging the hooks of a unit or
+// by an agent waiting for such an operator.
+type DebugShellConfig struct {
+	// Unit is the name of the unit whose hooks are debugged. The
+	// agent of a unit may omit it, but an application operator
+	// agent must specify which of its units it serves.
+	Unit string `schema:"unit" url:"unit,omitempty"`
+
+	// Hooks holds the names of the hooks or actions to debug.
+	// If it is empty, the first hook or action to run is debugged.
+	// It is only specified by operators.
+	Hooks []string `schema:"hooks" url:"hooks,omitempty"`
+}
+
+// DebugShellMessage is sent in either direction over a debug shell
+// stream. The controller relays messages between the operator and
+// the unit agent unaltered, apart from the first message to the
+// agent, which holds the hooks requested by the operator.
+type DebugShellMessage struct {
+	// Hooks is sent to the unit agent when an operator connects,
+	// and holds the names of the hooks or actions to debug.
+	Hooks []string `json:"hooks,omitempty"`
+
+	// Hook is sent to the operator when the unit agent starts
+	// a shell in place of the named hook or action.
+	Hook string `json:"hook,omitempty"`
+
+	// Data holds terminal input from the operator, or terminal
+	// output from the unit agent.
+	Data []byte `json:"data,omitempty"`
+
+	// Rows and Cols are sent by the operator when the size of
+	// their terminal changes.
+	Rows uint16 `json:"rows,omitempty"`
+	Cols uint16 `json:"cols,omitempty"`
+
+	// ExitCode is sent to the operator when the shell exits.
+	ExitCode *int `json:"exit-code,omitempty"`
+}
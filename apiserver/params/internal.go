@@ -262,6 +262,46 @@ type UnitStateResults struct {
 	Results []UnitStateResult `json:"results"`
 }
 
+// CharmMetric holds a value published by a charm for export to the
+// controller's Prometheus endpoint.
+type CharmMetric struct {
+	Name  string  `json:"name"`
+	Type  string  `json:"type"`
+	Help  string  `json:"help,omitempty"`
+	Value float64 `json:"value"`
+}
+
+// PublishCharmMetricsArg holds the charm metrics published by a unit.
+type PublishCharmMetricsArg struct {
+	Tag     string        `json:"tag"`
+	Metrics []CharmMetric `json:"metrics"`
+}
+
+// PublishCharmMetricsArgs holds the charm metrics published by
+// multiple units.
+type PublishCharmMetricsArgs struct {
+	Args []PublishCharmMetricsArg `json:"args"`
+}
+
+// HookTimeoutPolicy holds how long the hooks of an application may
+// run, and how long a timed out hook has to exit before it is killed.
+type HookTimeoutPolicy struct {
+	Default         time.Duration            `json:"default,omitempty"`
+	ByKind          map[string]time.Duration `json:"by-kind,omitempty"`
+	KillGracePeriod time.Duration            `json:"kill-grace-period"`
+}
+
+// HookTimeoutPolicyResult holds a hook timeout policy or an error.
+type HookTimeoutPolicyResult struct {
+	Result *HookTimeoutPolicy `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// HookTimeoutPolicyResults holds multiple hook timeout policies or errors.
+type HookTimeoutPolicyResults struct {
+	Results []HookTimeoutPolicyResult `json:"results"`
+}
+
 // SetUnitStateArgs holds multiple SetUnitStateArg objects to be persisted by the controller.
 type SetUnitStateArgs struct {
 	Args []SetUnitStateArg `json:"args"`
@@ -928,10 +968,11 @@ type ResourceUploadResult struct {
 // UnitRefreshResult is used to return the latest values for attributes
 // on a unit.
 type UnitRefreshResult struct {
-	Life       life.Value
-	Resolved   ResolvedMode
-	Error      *Error
-	ProviderID string `json:"provider-id,omitempty"`
+	Life            life.Value
+	Resolved        ResolvedMode
+	Error           *Error
+	ProviderID      string `json:"provider-id,omitempty"`
+	MaintenanceMode bool   `json:"maintenance-mode,omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -180,6 +180,39 @@ type ModelInfo struct {
 
 	// AgentVersion is the agent version for this model.
 	AgentVersion *version.Number `json:"agent-version"`
+
+	// Quotas contains the resource limits set on the model, along
+	// with the amount of each resource currently in use.
+	Quotas []ModelQuota `json:"quotas,omitempty"`
+}
+
+// ModelQuota describes a resource limit set on a model.
+type ModelQuota struct {
+	// Resource is the name of the limited resource, for
+	// example "machines" or "memory".
+	Resource string `json:"resource"`
+
+	// Limit is the maximum amount of the resource the model may use.
+	Limit uint64 `json:"limit"`
+
+	// Used is the amount of the resource the model currently uses.
+	Used uint64 `json:"used"`
+}
+
+// SetModelQuotasArgs holds the arguments for a SetModelQuotas call.
+type SetModelQuotasArgs struct {
+	Models []SetModelQuotas `json:"models"`
+}
+
+// SetModelQuotas holds the quota changes for a single model.
+type SetModelQuotas struct {
+	ModelTag string `json:"model-tag"`
+
+	// Set holds the limits to set, keyed by resource name.
+	Set map[string]uint64 `json:"set,omitempty"`
+
+	// Unset holds the names of the resources to no longer limit.
+	Unset []string `json:"unset,omitempty"`
 }
 
 // ModelSummary holds summary about a Juju model.
@@ -403,6 +403,48 @@ type EntitiesPortRanges struct {
 	Entities []EntityPortRange `json:"entities"`
 }
 
+// EgressRule represents a range of ports and destinations to which
+// outgoing traffic is allowed. It is used in API requests/responses.
+// See also network.EgressRule, from/to which this is transformed.
+type EgressRule struct {
+	PortRange        PortRange `json:"port-range"`
+	DestinationCIDRs []string  `json:"destination-cidrs,omitempty"`
+}
+
+// EgressRulesResult holds the egress rules of an entity, and whether
+// its egress is restricted to them, or an error.
+type EgressRulesResult struct {
+	Restricted bool         `json:"restricted,omitempty"`
+	Rules      []EgressRule `json:"rules,omitempty"`
+	Error      *Error       `json:"error,omitempty"`
+}
+
+// EgressRulesResults holds the results of a bulk call
+// returning egress rules.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// MachineEgressRulesArgs holds bulk parameters for the
+// Firewaller.SetMachineEgressRules call.
+type MachineEgressRulesArgs struct {
+	Args []MachineEgressRules `json:"args"`
+}
+
+// MachineEgressRules holds the egress firewall to be enforced
+// on a machine by its agent.
+type MachineEgressRules struct {
+	// MachineTag holds the tag of the machine.
+	MachineTag string `json:"machine-tag"`
+
+	// Restricted holds whether the machine's outgoing traffic
+	// is restricted to the egress rules.
+	Restricted bool `json:"restricted,omitempty"`
+
+	// Rules holds the egress rules.
+	Rules []EgressRule `json:"rules,omitempty"`
+}
+
 // Address represents the location of a machine, including metadata
 // about what kind of location the address describes.
 // See also the address types in core/network which this type can be
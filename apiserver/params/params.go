@@ -858,6 +858,59 @@ type ControllersChanges struct {
 	Converted  []string `json:"converted,omitempty"`
 }
 
+// ControllerMemberHealth holds the health of a controller machine as a
+// member of the controller's mongo replica set and raft cluster.
+type ControllerMemberHealth struct {
+	// Tag is the tag of the controller machine.
+	Tag string `json:"tag"`
+
+	// WantsVote and HasVote report the machine's voting membership
+	// as recorded in the controller.
+	WantsVote bool `json:"wants-vote"`
+	HasVote   bool `json:"has-vote"`
+
+	// MongoState is the state of the machine's replica set member,
+	// e.g. PRIMARY or SECONDARY. It is empty if the machine is not
+	// a member of the replica set.
+	MongoState   string `json:"mongo-state,omitempty"`
+	MongoHealthy bool   `json:"mongo-healthy"`
+	MongoError   string `json:"mongo-error,omitempty"`
+
+	// ReplicationLag is how far the member's oplog is behind that of
+	// the primary.
+	ReplicationLag time.Duration `json:"replication-lag,omitempty"`
+
+	// RaftRole is the machine's role in the raft cluster: leader,
+	// follower or non-voter. It is empty if the role is not known.
+	RaftRole string `json:"raft-role,omitempty"`
+
+	// APIAddress is the address used to check whether the machine's
+	// API server is reachable from the controller answering the
+	// request.
+	APIAddress   string `json:"api-address,omitempty"`
+	APIReachable bool   `json:"api-reachable"`
+	APIError     string `json:"api-error,omitempty"`
+}
+
+// ControllerMembersHealth holds the health of all controller machines.
+type ControllerMembersHealth struct {
+	Members []ControllerMemberHealth `json:"members"`
+
+	// RaftError is set if the raft cluster status could not be
+	// determined.
+	RaftError string `json:"raft-error,omitempty"`
+}
+
+// RemoveControllersArgs holds the controller machines to remove.
+type RemoveControllersArgs struct {
+	// Machines holds the tags of the machines to remove.
+	Machines []string `json:"machines"`
+
+	// Force removes the machines even if their agents cannot
+	// take part in their removal.
+	Force bool `json:"force,omitempty"`
+}
+
 // FindToolsParams defines parameters for the FindTools method.
 type FindToolsParams struct {
 	// Number will be used to match tools versions exactly if non-zero.
@@ -1289,6 +1342,45 @@ type GenerationResult struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+// CommitPreviewUnit describes the effect that committing a branch
+// would have on a single unit.
+type CommitPreviewUnit struct {
+	// UnitName is the name of the unit.
+	UnitName string `json:"unit"`
+
+	// ConfigChanges holds the charm configuration values that the unit
+	// would see change, keyed by setting name. A nil value indicates that
+	// the setting would become unset.
+	ConfigChanges map[string]interface{} `json:"config"`
+
+	// Hooks are the names of the hooks that would run on the unit.
+	Hooks []string `json:"hooks"`
+}
+
+// CommitPreviewApplication describes the effect that committing a branch
+// would have on an application with changes under the branch.
+type CommitPreviewApplication struct {
+	// ApplicationName is the name of the application.
+	ApplicationName string `json:"application"`
+
+	// InvalidConfigKeys are the settings changed under the branch
+	// that are not defined by the application's current charm.
+	InvalidConfigKeys []string `json:"invalid-config-keys,omitempty"`
+
+	// Units describes the units whose configuration would change.
+	Units []CommitPreviewUnit `json:"units,omitempty"`
+}
+
+// CommitPreviewResult transports the result of previewing a branch commit.
+type CommitPreviewResult struct {
+	// Applications holds the preview for each application
+	// with changes under the branch.
+	Applications []CommitPreviewApplication `json:"applications"`
+
+	// Error holds the value of any error that occurred processing the request.
+	Error *Error `json:"error,omitempty"`
+}
+
 // CharmProfilingInfoResult contains the result based on ProfileInfoArg values
 // to update profiles on a machine.
 type CharmProfilingInfoResult struct {
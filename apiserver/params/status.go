@@ -205,6 +205,10 @@ type UnitStatus struct {
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
 
+	// MaintenanceMode is true if the unit is in maintenance mode,
+	// in which case its agent doesn't run hooks.
+	MaintenanceMode bool `json:"maintenance-mode,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
@@ -284,6 +288,36 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// ModelStatusHistoryRequest holds the parameters used to export the
+// status history of every entity in a model.
+type ModelStatusHistoryRequest struct {
+	From    *time.Time `json:"from,omitempty"`
+	To      *time.Time `json:"to,omitempty"`
+	Kinds   []string   `json:"kinds,omitempty"`
+	Exclude []string   `json:"exclude,omitempty"`
+	After   string     `json:"after,omitempty"`
+	Size    int        `json:"size,omitempty"`
+}
+
+// ModelStatusHistoryEntry holds a status history record of an entity
+// in a model.
+type ModelStatusHistoryEntry struct {
+	Kind   string                 `json:"kind"`
+	Entity string                 `json:"entity"`
+	Status string                 `json:"status"`
+	Info   string                 `json:"info"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  *time.Time             `json:"since"`
+}
+
+// ModelStatusHistoryResult holds a page of the status history of a
+// model, oldest first. If Next is not empty, it is passed as After to
+// request the following page.
+type ModelStatusHistoryResult struct {
+	Entries []ModelStatusHistoryEntry `json:"entries"`
+	Next    string                    `json:"next,omitempty"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
@@ -335,3 +369,26 @@ type BranchStatus struct {
 	Created       int64               `json:"created"`
 	CreatedBy     string              `json:"created-by"`
 }
+
+// WaitForArgs holds the arguments for the WaitFor call.
+type WaitForArgs struct {
+	// Kind is the kind of entity to wait on: model, application,
+	// unit or machine.
+	Kind string `json:"kind"`
+	// Name identifies the entity; it is ignored for models.
+	Name string `json:"name,omitempty"`
+	// Query is the condition to wait for.
+	Query string `json:"query"`
+	// Timeout is the longest the call should block. The server
+	// may impose a shorter limit.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// WaitForResult holds the result of the WaitFor call.
+type WaitForResult struct {
+	// Satisfied is true if the query matched before the call timed out.
+	Satisfied bool `json:"satisfied"`
+	// Values holds the entity's field values when the call returned,
+	// or is empty if the entity does not exist.
+	Values map[string][]string `json:"values,omitempty"`
+}
@@ -99,6 +99,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     life.Value  `json:"life"`
+
+	// Size is the size of the storage attachment's volume or
+	// filesystem in MiB, if known.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -259,6 +263,10 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+
+	// SnapshotId is the storage provider's unique ID for the
+	// snapshot from which the volume should be created, if any.
+	SnapshotId string `json:"snapshot-id,omitempty"`
 }
 
 // RemoveVolumeParams holds the parameters for destroying or releasing a
@@ -275,6 +283,44 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// ResizeVolumeParams holds the parameters for growing a volume.
+type ResizeVolumeParams struct {
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size in MiB that the volume should be grown to.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking or destroying
+// a volume snapshot.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string `json:"id"`
+
+	// Life is the lifecycle state of the snapshot.
+	Life life.Value `json:"life"`
+
+	// VolumeTag is the tag of the volume that the snapshot is of.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// SnapshotId is the storage provider's unique ID for the
+	// snapshot, if it has been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Tags is the set of tags to set on the snapshot.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -356,6 +402,65 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// ResizeVolumeParamsResult holds parameters for growing a volume.
+type ResizeVolumeParamsResult struct {
+	Result ResizeVolumeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ResizeVolumeParamsResults holds parameters for growing multiple volumes.
+type ResizeVolumeParamsResults struct {
+	Results []ResizeVolumeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds parameters for taking or
+// destroying a volume snapshot.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds parameters for taking or
+// destroying multiple volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotIds holds the IDs of a set of volume snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot that has been taken.
+type VolumeSnapshotInfo struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string `json:"id"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// Size is the size of the volume at the time the snapshot was
+	// taken, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotInfos holds the details of a set of volume snapshots.
+type VolumeSnapshotInfos struct {
+	Snapshots []VolumeSnapshotInfo `json:"snapshots"`
+}
+
+// VolumeSnapshotStatus holds the status of a volume snapshot.
+type VolumeSnapshotStatus struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+	Info   string `json:"info"`
+}
+
+// VolumeSnapshotStatuses holds the statuses of a set of volume snapshots.
+type VolumeSnapshotStatuses struct {
+	Statuses []VolumeSnapshotStatus `json:"statuses"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -436,6 +541,22 @@ type RemoveFilesystemParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// ResizeFilesystemParams holds the parameters for growing a filesystem.
+type ResizeFilesystemParams struct {
+	// Provider is the storage provider that manages the filesystem.
+	Provider string `json:"provider"`
+
+	// FilesystemId is the storage provider's unique ID for the filesystem.
+	FilesystemId string `json:"filesystem-id"`
+
+	// VolumeTag is the tag of the volume backing the filesystem,
+	// if any.
+	VolumeTag string `json:"volume-tag,omitempty"`
+
+	// Size is the size in MiB that the filesystem should be grown to.
+	Size uint64 `json:"size"`
+}
+
 // FilesystemAttachmentParams holds the parameters for creating a filesystem
 // attachment.
 type FilesystemAttachmentParams struct {
@@ -495,6 +616,18 @@ type RemoveFilesystemParamsResults struct {
 	Results []RemoveFilesystemParamsResult `json:"results,omitempty"`
 }
 
+// ResizeFilesystemParamsResult holds parameters for growing a filesystem.
+type ResizeFilesystemParamsResult struct {
+	Result ResizeFilesystemParams `json:"result"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// ResizeFilesystemParamsResults holds parameters for growing multiple
+// filesystems.
+type ResizeFilesystemParamsResults struct {
+	Results []ResizeFilesystemParamsResult `json:"results,omitempty"`
+}
+
 // FilesystemAttachmentParamsResults holds provisioning parameters for a filesystem
 // attachment.
 type FilesystemAttachmentParamsResult struct {
@@ -843,6 +976,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count *uint64 `json:"count,omitempty"`
+
+	// Snapshot is the ID of the volume snapshot from which to
+	// restore the storage instances, if any.
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // StorageAddParams holds storage details to add to a unit dynamically.
@@ -892,6 +1029,62 @@ type RemoveStorageInstance struct {
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
+// ResizeStorage holds the parameters for growing storage instances.
+type ResizeStorage struct {
+	Storage []ResizeStorageInstance `json:"storage"`
+}
+
+// ResizeStorageInstance holds the parameters for growing a storage
+// instance.
+type ResizeStorageInstance struct {
+	// Tag is the tag of the storage instance to be resized.
+	Tag string `json:"tag"`
+
+	// Size is the size in MiB that the storage instance's volume or
+	// filesystem should be grown to.
+	Size uint64 `json:"size"`
+}
+
+// StorageSnapshotDetails holds information about a snapshot of a
+// storage instance's volume.
+type StorageSnapshotDetails struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the volume that the snapshot is of.
+	VolumeTag string `json:"volume-tag"`
+
+	// StorageTag is the tag of the storage instance that the volume
+	// is assigned to, if the volume still exists and is assigned.
+	StorageTag string `json:"storage-tag,omitempty"`
+
+	// Pool is the storage pool that the snapshot belongs to.
+	Pool string `json:"pool"`
+
+	// SnapshotId is the storage provider's unique ID for the
+	// snapshot, if it has been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Size is the size of the volume at the time the snapshot was
+	// taken, in MiB, if it has been taken.
+	Size uint64 `json:"size,omitempty"`
+
+	// Created is the time at which the snapshot was requested.
+	Created time.Time `json:"created"`
+
+	// Life is the lifecycle state of the snapshot.
+	Life life.Value `json:"life"`
+
+	// Status contains the status of the snapshot.
+	Status EntityStatus `json:"status"`
+}
+
+// StorageSnapshotDetailsList holds information about a set of
+// storage snapshots.
+type StorageSnapshotDetailsList struct {
+	Snapshots []StorageSnapshotDetails `json:"snapshots"`
+}
+
 // BulkImportStorageParams contains the parameters for importing a collection
 // of storage entities.
 type BulkImportStorageParams struct {
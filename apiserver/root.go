@@ -551,6 +551,26 @@ func (ctx *facadeContext) LeadershipPinner(modelUUID string) (leadership.Pinner,
 	return leadershipPinner{pinner}, nil
 }
 
+// LeadershipTransferer is part of the facade.Context interface.
+// Transferring leadership is only available with the Raft leases implementation.
+func (ctx *facadeContext) LeadershipTransferer(modelUUID string) (leadership.Transferer, error) {
+	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
+		return nil, errors.NotImplementedf(
+			"unable to get leadership transferer; transferring is not available with the legacy lease manager")
+	}
+	transferer, err := ctx.r.shared.leaseManager.Transferer(
+		lease.ApplicationLeadershipNamespace,
+		modelUUID,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return leadershipTransferer{
+		transferer: transferer,
+		st:         ctx.r.shared.statePool.SystemState(),
+	}, nil
+}
+
 // LeadershipReader is part of the facade.Context interface.
 // It returns a reader that can be used to return all application leaders
 // in the model.
@@ -579,6 +599,11 @@ func (ctx *facadeContext) SingularClaimer() (lease.Claimer, error) {
 	)
 }
 
+// LogDir is part of the facade.Context interface.
+func (ctx *facadeContext) LogDir() string {
+	return ctx.r.shared.logDir
+}
+
 // adminRoot dispatches API calls to those available to an anonymous connection
 // which has not logged in, which here is the admin facade.
 type adminRoot struct {
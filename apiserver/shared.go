@@ -46,6 +46,7 @@ type sharedServerContext struct {
 	leaseManager        lease.Manager
 	logger              loggo.Logger
 	cancel              <-chan struct{}
+	logDir              string
 
 	configMutex      sync.RWMutex
 	controllerConfig jujucontroller.Config
@@ -63,6 +64,7 @@ type sharedServerConfig struct {
 	leaseManager        lease.Manager
 	controllerConfig    jujucontroller.Config
 	logger              loggo.Logger
+	logDir              string
 }
 
 func (c *sharedServerConfig) validate() error {
@@ -102,6 +104,7 @@ func newSharedServerContext(config sharedServerConfig) (*sharedServerContext, er
 		presence:            config.presence,
 		leaseManager:        config.leaseManager,
 		logger:              config.logger,
+		logDir:              config.logDir,
 		controllerConfig:    config.controllerConfig,
 	}
 	ctx.features = config.controllerConfig.Features()
@@ -0,0 +1,406 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/network"
+)
+
+var allowEgressHelpDetails = `
+Allows the workloads of an application to send outgoing traffic on the
+specified ports. Ports are specified as <port>[-<port>][/<protocol>],
+where the protocol is one of tcp (the default), udp or icmp.
+
+Allowing egress restricts the application's egress: from then on its
+workloads may only send outgoing traffic that matches one of its egress
+rules, and traffic to anywhere else is dropped. An application whose
+egress has never been restricted may send outgoing traffic anywhere.
+By default the specified ports may be used to reach any destination;
+use --to to restrict the destinations to a list of CIDRs.
+
+Egress rules are not supported when the model's firewall mode is
+"none". They are enforced by the cloud where it supports them, and by
+the machine agent with iptables otherwise. They are enforced
+per machine: a machine's egress is only restricted if the egress of
+every application with units on it is restricted, in which case the
+egress rules of all those applications apply. A restricted machine
+may always reach the controller and send DNS queries. Egress rules
+only cover IPv4, so if the controller has IPv6 addresses no machine's
+egress is restricted.
+
+Examples:
+    juju allow-egress wordpress 443 123/udp
+    juju allow-egress wordpress 3306 --to 10.0.0.0/24,10.0.1.0/24
+
+See also:
+    revoke-egress
+    reset-egress
+    egress-rules`[1:]
+
+var revokeEgressHelpDetails = `
+Revokes egress rules previously added with allow-egress. If --to is
+specified, only the specified destinations are revoked for the ports;
+otherwise the rules for the ports are removed entirely.
+
+The application's egress stays restricted once all of its egress rules
+have been revoked, so that its workloads may not send outgoing traffic
+anywhere. Use reset-egress to lift the restriction.
+
+Examples:
+    juju revoke-egress wordpress 53/udp
+    juju revoke-egress wordpress 3306 --to 10.0.1.0/24
+
+See also:
+    allow-egress
+    reset-egress
+    egress-rules`[1:]
+
+var resetEgressHelpDetails = `
+Removes all egress rules of an application and lifts the restriction on
+its egress, so that its workloads may send outgoing traffic anywhere
+again.
+
+Examples:
+    juju reset-egress wordpress
+
+See also:
+    allow-egress
+    revoke-egress
+    egress-rules`[1:]
+
+var egressRulesHelpDetails = `
+Shows the egress rules of an application. An application whose egress
+isn't restricted may send outgoing traffic anywhere; one whose egress
+is restricted but has no egress rules may not send outgoing traffic
+anywhere.
+
+Examples:
+    juju egress-rules wordpress
+    juju egress-rules wordpress --format yaml
+
+See also:
+    allow-egress
+    revoke-egress
+    reset-egress`[1:]
+
+// EgressAPI defines the API methods that the egress commands use.
+type EgressAPI interface {
+	Close() error
+	BestAPIVersion() int
+	AllowEgress(application string, rules ...network.EgressRule) error
+	RevokeEgress(application string, rules ...network.EgressRule) error
+	ResetEgress(application string) error
+	EgressRules(application string) ([]network.EgressRule, bool, error)
+}
+
+func newEgressAPIFunc(c *modelcmd.ModelCommandBase) func() (EgressAPI, error) {
+	return func() (EgressAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+}
+
+// NewAllowEgressCommand returns a command which adds egress rules
+// to an application.
+func NewAllowEgressCommand() modelcmd.ModelCommand {
+	return newChangeEgressCommand(true)
+}
+
+// NewRevokeEgressCommand returns a command which removes egress rules
+// from an application.
+func NewRevokeEgressCommand() modelcmd.ModelCommand {
+	return newChangeEgressCommand(false)
+}
+
+func newChangeEgressCommand(allow bool) modelcmd.ModelCommand {
+	cmd := &changeEgressCommand{allow: allow}
+	cmd.newAPIFunc = newEgressAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// changeEgressCommand is responsible for adding egress rules to,
+// or removing them from, an application.
+type changeEgressCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc      func() (EgressAPI, error)
+	allow           bool
+	applicationName string
+	portRanges      []corenetwork.PortRange
+	destinations    string
+}
+
+// Info implements cmd.Command.
+func (c *changeEgressCommand) Info() *cmd.Info {
+	if c.allow {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "allow-egress",
+			Args:    "<application name> <ports> [<ports>...]",
+			Purpose: "Allow an application to send outgoing traffic.",
+			Doc:     allowEgressHelpDetails,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "revoke-egress",
+		Args:    "<application name> <ports> [<ports>...]",
+		Purpose: "Revoke egress rules of an application.",
+		Doc:     revokeEgressHelpDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *changeEgressCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.destinations, "to", "", "Comma separated list of destination CIDRs")
+}
+
+// Init implements cmd.Command.
+func (c *changeEgressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if len(args) == 1 {
+		return errors.New("no ports specified")
+	}
+	for _, arg := range args[1:] {
+		portRange, err := corenetwork.ParsePortRange(arg)
+		if err != nil {
+			return errors.Annotatef(err, "invalid ports %q", arg)
+		}
+		c.portRanges = append(c.portRanges, portRange)
+	}
+	for _, cidr := range c.destinationCIDRs() {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Errorf("invalid destination CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+func (c *changeEgressCommand) destinationCIDRs() []string {
+	var cidrs []string
+	for _, cidr := range strings.Split(c.destinations, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
+// Run implements cmd.Command.
+func (c *changeEgressCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 14 {
+		return errors.New("egress rules are not supported by this controller")
+	}
+	rules := make([]network.EgressRule, len(c.portRanges))
+	for i, portRange := range c.portRanges {
+		rules[i] = network.EgressRule{
+			PortRange:        portRange,
+			DestinationCIDRs: c.destinationCIDRs(),
+		}
+	}
+	if c.allow {
+		err = client.AllowEgress(c.applicationName, rules...)
+	} else {
+		err = client.RevokeEgress(c.applicationName, rules...)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// NewResetEgressCommand returns a command which removes all egress
+// rules from an application and lifts the restriction on its egress.
+func NewResetEgressCommand() modelcmd.ModelCommand {
+	cmd := &resetEgressCommand{}
+	cmd.newAPIFunc = newEgressAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// resetEgressCommand is responsible for lifting the restriction on
+// an application's egress.
+type resetEgressCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc      func() (EgressAPI, error)
+	applicationName string
+}
+
+// Info implements cmd.Command.
+func (c *resetEgressCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "reset-egress",
+		Args:    "<application name>",
+		Purpose: "Lift the restriction on the egress of an application.",
+		Doc:     resetEgressHelpDetails,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *resetEgressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *resetEgressCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 14 {
+		return errors.New("egress rules are not supported by this controller")
+	}
+	err = client.ResetEgress(c.applicationName)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// NewEgressRulesCommand returns a command which shows the egress
+// rules of an application.
+func NewEgressRulesCommand() modelcmd.ModelCommand {
+	cmd := &egressRulesCommand{}
+	cmd.newAPIFunc = newEgressAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// egressRulesCommand shows the egress rules of an application.
+type egressRulesCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc      func() (EgressAPI, error)
+	out             cmd.Output
+	applicationName string
+}
+
+// EgressRuleInfo defines the serialization behaviour of an egress rule.
+type EgressRuleInfo struct {
+	Protocol     string   `yaml:"protocol" json:"protocol"`
+	Ports        string   `yaml:"ports,omitempty" json:"ports,omitempty"`
+	Destinations []string `yaml:"destinations,omitempty" json:"destinations,omitempty"`
+}
+
+// Info implements cmd.Command.
+func (c *egressRulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "egress-rules",
+		Args:    "<application name>",
+		Purpose: "Show the egress rules of an application.",
+		Doc:     egressRulesHelpDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *egressRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatEgressRulesTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *egressRulesCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *egressRulesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 14 {
+		return errors.New("egress rules are not supported by this controller")
+	}
+	rules, restricted, err := client.EgressRules(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !restricted {
+		ctx.Infof("Application %q has unrestricted egress.", c.applicationName)
+		return nil
+	}
+	if len(rules) == 0 {
+		ctx.Infof("Application %q has restricted egress and no egress rules; its outgoing traffic is dropped.", c.applicationName)
+		return nil
+	}
+	infos := make([]EgressRuleInfo, len(rules))
+	for i, rule := range rules {
+		info := EgressRuleInfo{
+			Protocol:     rule.Protocol,
+			Destinations: rule.DestinationCIDRs,
+		}
+		if rule.Protocol != "icmp" {
+			info.Ports = fmt.Sprint(rule.FromPort)
+			if rule.ToPort != rule.FromPort {
+				info.Ports = fmt.Sprintf("%d-%d", rule.FromPort, rule.ToPort)
+			}
+		}
+		infos[i] = info
+	}
+	return c.out.Write(ctx, infos)
+}
+
+// formatEgressRulesTabular writes a tabular summary of egress rules.
+func formatEgressRulesTabular(writer io.Writer, value interface{}) error {
+	rules, ok := value.([]EgressRuleInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", rules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Protocol\tPorts\tDestinations")
+	for _, rule := range rules {
+		destinations := "any"
+		if len(rule.Destinations) > 0 {
+			destinations = strings.Join(rule.Destinations, ",")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", rule.Protocol, rule.Ports, destinations)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,197 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/network"
+)
+
+type EgressSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockEgressAPI
+}
+
+var _ = gc.Suite(&EgressSuite{})
+
+type mockEgressAPI struct {
+	*testing.Stub
+	version    int
+	restricted bool
+	rules      []network.EgressRule
+}
+
+func (s mockEgressAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockEgressAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockEgressAPI) AllowEgress(application string, rules ...network.EgressRule) error {
+	s.MethodCall(s, "AllowEgress", application, rules)
+	return s.NextErr()
+}
+
+func (s mockEgressAPI) RevokeEgress(application string, rules ...network.EgressRule) error {
+	s.MethodCall(s, "RevokeEgress", application, rules)
+	return s.NextErr()
+}
+
+func (s mockEgressAPI) ResetEgress(application string) error {
+	s.MethodCall(s, "ResetEgress", application)
+	return s.NextErr()
+}
+
+func (s mockEgressAPI) EgressRules(application string) ([]network.EgressRule, bool, error) {
+	s.MethodCall(s, "EgressRules", application)
+	return s.rules, s.restricted, s.NextErr()
+}
+
+func (s *EgressSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockEgressAPI{Stub: &testing.Stub{}, version: 14}
+}
+
+func (s *EgressSuite) runChangeEgress(c *gc.C, allow bool, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, NewChangeEgressCommandForTest(allow, s.mockAPI, store), args...)
+}
+
+func (s *EgressSuite) runResetEgress(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, NewResetEgressCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *EgressSuite) runEgressRules(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, NewEgressRulesCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *EgressSuite) TestAllowEgress(c *gc.C) {
+	_, err := s.runChangeEgress(c, true, "wordpress", "443", "53/udp", "icmp")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "AllowEgress", "wordpress", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("udp", 53, 53),
+		network.MustNewEgressRule("icmp", -1, -1),
+	})
+}
+
+func (s *EgressSuite) TestAllowEgressDestinations(c *gc.C) {
+	_, err := s.runChangeEgress(c, true, "wordpress", "3306-3307", "--to", "10.0.0.0/24, 10.0.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "AllowEgress", "wordpress", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 3306, 3307, "10.0.0.0/24", "10.0.1.0/24"),
+	})
+}
+
+func (s *EgressSuite) TestRevokeEgress(c *gc.C) {
+	_, err := s.runChangeEgress(c, false, "wordpress", "3306", "--to", "10.0.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "RevokeEgress", "wordpress", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 3306, 3306, "10.0.1.0/24"),
+	})
+}
+
+func (s *EgressSuite) TestChangeEgressBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	_, err := s.runChangeEgress(c, true, "wordpress", "443")
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *EgressSuite) TestChangeEgressInvalidArgs(c *gc.C) {
+	_, err := s.runChangeEgress(c, true)
+	c.Assert(err, gc.ErrorMatches, `no application name specified`)
+	_, err = s.runChangeEgress(c, true, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `invalid application name "wordpress/0"`)
+	_, err = s.runChangeEgress(c, true, "wordpress")
+	c.Assert(err, gc.ErrorMatches, `no ports specified`)
+	_, err = s.runChangeEgress(c, true, "wordpress", "80-79")
+	c.Assert(err, gc.ErrorMatches, `invalid ports "80-79": invalid port range 80-79/tcp`)
+	_, err = s.runChangeEgress(c, true, "wordpress", "80", "--to", "foo")
+	c.Assert(err, gc.ErrorMatches, `invalid destination CIDR "foo"`)
+}
+
+func (s *EgressSuite) TestChangeEgressOldServer(c *gc.C) {
+	s.mockAPI.version = 13
+	_, err := s.runChangeEgress(c, true, "wordpress", "443")
+	c.Assert(err, gc.ErrorMatches, "egress rules are not supported by this controller")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *EgressSuite) TestResetEgress(c *gc.C) {
+	_, err := s.runResetEgress(c, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "ResetEgress", "wordpress")
+}
+
+func (s *EgressSuite) TestResetEgressInvalidArgs(c *gc.C) {
+	_, err := s.runResetEgress(c)
+	c.Assert(err, gc.ErrorMatches, `no application name specified`)
+	_, err = s.runResetEgress(c, "wordpress/0")
+	c.Assert(err, gc.ErrorMatches, `invalid application name "wordpress/0"`)
+	_, err = s.runResetEgress(c, "wordpress", "mysql")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql"\]`)
+}
+
+func (s *EgressSuite) TestEgressRulesTabular(c *gc.C) {
+	s.mockAPI.restricted = true
+	s.mockAPI.rules = []network.EgressRule{
+		network.MustNewEgressRule("icmp", -1, -1),
+		network.MustNewEgressRule("tcp", 3306, 3307, "10.0.0.0/24", "10.0.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53),
+	}
+	ctx, err := s.runEgressRules(c, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "EgressRules", "wordpress")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Protocol  Ports      Destinations
+icmp                 any
+tcp       3306-3307  10.0.0.0/24,10.0.1.0/24
+udp       53         any
+`[1:])
+}
+
+func (s *EgressSuite) TestEgressRulesYAML(c *gc.C) {
+	s.mockAPI.restricted = true
+	s.mockAPI.rules = []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	}
+	ctx, err := s.runEgressRules(c, "wordpress", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- protocol: tcp
+  ports: "443"
+  destinations:
+  - 10.0.0.0/24
+`[1:])
+}
+
+func (s *EgressSuite) TestEgressRulesUnrestricted(c *gc.C) {
+	ctx, err := s.runEgressRules(c, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"Application \"wordpress\" has unrestricted egress.\n")
+}
+
+func (s *EgressSuite) TestEgressRulesRestrictedNone(c *gc.C) {
+	s.mockAPI.restricted = true
+	ctx, err := s.runEgressRules(c, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"Application \"wordpress\" has restricted egress and no egress rules; its outgoing traffic is dropped.\n")
+}
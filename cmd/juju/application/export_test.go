@@ -267,6 +267,56 @@ func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStor
 	return modelcmd.Wrap(cmd)
 }
 
+// NewTransferLeadershipCommandForTest returns a TransferLeadershipCommand
+// with the api provided as specified.
+func NewTransferLeadershipCommandForTest(api transferLeadershipAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &transferLeadershipCommand{newAPIFunc: func() (transferLeadershipAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewMaintenanceCommandForTest returns an enter-maintenance or
+// exit-maintenance command with the api provided as specified.
+func NewMaintenanceCommandForTest(enter bool, api SetUnitsMaintenanceModeAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &maintenanceCommand{enter: enter, newAPIFunc: func() (SetUnitsMaintenanceModeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewChangeEgressCommandForTest returns an allow-egress or
+// revoke-egress command with the api provided as specified.
+func NewChangeEgressCommandForTest(allow bool, api EgressAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &changeEgressCommand{allow: allow, newAPIFunc: func() (EgressAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResetEgressCommandForTest returns a reset-egress command with
+// the api provided as specified.
+func NewResetEgressCommandForTest(api EgressAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &resetEgressCommand{newAPIFunc: func() (EgressAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewEgressRulesCommandForTest returns an egress-rules command
+// with the api provided as specified.
+func NewEgressRulesCommandForTest(api EgressAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &egressRulesCommand{newAPIFunc: func() (EgressAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewBundleDiffCommandForTest(api base.APICallCloser, charmStore BundleResolver, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &bundleDiffCommand{
 		_apiRoot:    api,
@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var enterMaintenanceHelpDetails = `
+Puts the specified units into maintenance mode, for when they are being
+worked on. The unit agent stops running hooks for a unit in maintenance
+mode; configuration, relation and leadership changes made in the meantime
+are queued and acted on once maintenance mode ends. Actions can still be
+run on the unit.
+
+Units in maintenance mode are marked as such in the output of "juju status",
+and their workload status does not contribute to the status of their
+application.
+
+Examples:
+    juju enter-maintenance mysql/0
+    juju enter-maintenance mysql/0 mysql/1
+
+See also:
+    exit-maintenance
+    status`[1:]
+
+var exitMaintenanceHelpDetails = `
+Takes the specified units out of maintenance mode. Any changes made while
+the units were in maintenance mode are then acted on by running the
+relevant hooks.
+
+Examples:
+    juju exit-maintenance mysql/0
+
+See also:
+    enter-maintenance
+    status`[1:]
+
+// NewEnterMaintenanceCommand returns a command which puts units into
+// maintenance mode.
+func NewEnterMaintenanceCommand() modelcmd.ModelCommand {
+	return newMaintenanceCommand(true)
+}
+
+// NewExitMaintenanceCommand returns a command which takes units out of
+// maintenance mode.
+func NewExitMaintenanceCommand() modelcmd.ModelCommand {
+	return newMaintenanceCommand(false)
+}
+
+func newMaintenanceCommand(enter bool) modelcmd.ModelCommand {
+	cmd := &maintenanceCommand{enter: enter}
+	cmd.newAPIFunc = func() (SetUnitsMaintenanceModeAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// maintenanceCommand is responsible for putting units into, or taking
+// them out of, maintenance mode.
+type maintenanceCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc func() (SetUnitsMaintenanceModeAPI, error)
+	enter      bool
+	unitNames  []string
+}
+
+// Info implements cmd.Command.
+func (c *maintenanceCommand) Info() *cmd.Info {
+	if c.enter {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "enter-maintenance",
+			Args:    "<unit> [<unit>...]",
+			Purpose: "Put units into maintenance mode.",
+			Doc:     enterMaintenanceHelpDetails,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "exit-maintenance",
+		Args:    "<unit> [<unit>...]",
+		Purpose: "Take units out of maintenance mode.",
+		Doc:     exitMaintenanceHelpDetails,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *maintenanceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no units specified")
+	}
+	for _, name := range args {
+		if !names.IsValidUnit(name) {
+			return errors.Errorf("invalid unit name %q", name)
+		}
+	}
+	c.unitNames = args
+	return nil
+}
+
+// SetUnitsMaintenanceModeAPI defines the API methods that the
+// enter/exit maintenance commands use.
+type SetUnitsMaintenanceModeAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetUnitsMaintenanceMode(maintenance bool, unitNames ...string) error
+}
+
+// Run implements cmd.Command.
+func (c *maintenanceCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 13 {
+		return errors.New("maintenance mode is not supported by this controller")
+	}
+	err = client.SetUnitsMaintenanceMode(c.enter, c.unitNames...)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type MaintenanceSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockMaintenanceAPI
+}
+
+var _ = gc.Suite(&MaintenanceSuite{})
+
+type mockMaintenanceAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s mockMaintenanceAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockMaintenanceAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockMaintenanceAPI) SetUnitsMaintenanceMode(maintenance bool, unitNames ...string) error {
+	s.MethodCall(s, "SetUnitsMaintenanceMode", maintenance, unitNames)
+	return s.NextErr()
+}
+
+func (s *MaintenanceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockMaintenanceAPI{Stub: &testing.Stub{}, version: 13}
+}
+
+func (s *MaintenanceSuite) runMaintenance(c *gc.C, enter bool, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, NewMaintenanceCommandForTest(enter, s.mockAPI, store), args...)
+}
+
+func (s *MaintenanceSuite) TestEnterMaintenance(c *gc.C) {
+	_, err := s.runMaintenance(c, true, "mysql/0", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetUnitsMaintenanceMode", true, []string{"mysql/0", "mysql/1"})
+}
+
+func (s *MaintenanceSuite) TestExitMaintenance(c *gc.C) {
+	_, err := s.runMaintenance(c, false, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetUnitsMaintenanceMode", false, []string{"mysql/0"})
+}
+
+func (s *MaintenanceSuite) TestMaintenanceBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	_, err := s.runMaintenance(c, true, "mysql/0")
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *MaintenanceSuite) TestInvalidArgs(c *gc.C) {
+	_, err := s.runMaintenance(c, true)
+	c.Assert(err, gc.ErrorMatches, `no units specified`)
+	_, err = s.runMaintenance(c, true, "mysql")
+	c.Assert(err, gc.ErrorMatches, `invalid unit name "mysql"`)
+}
+
+func (s *MaintenanceSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 12
+	_, err := s.runMaintenance(c, true, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "maintenance mode is not supported by this controller")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewTransferLeadershipCommand returns a command which moves leadership
+// of an application to another of its units.
+func NewTransferLeadershipCommand() modelcmd.ModelCommand {
+	cmd := &transferLeadershipCommand{}
+	cmd.newAPIFunc = func() (transferLeadershipAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// transferLeadershipCommand is responsible for handing over leadership
+// of an application.
+type transferLeadershipCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc      func() (transferLeadershipAPI, error)
+	applicationName string
+	unitName        string
+}
+
+const transferLeadershipDoc = `
+Transfer leadership of an application from its current leader to another
+of its units, for example before taking the leader's machine down for
+maintenance.
+
+The current leader is refused the next time it tries to renew its lease, so it
+learns that it is losing leadership, but it remains leader until its current
+lease runs out; there are never two leaders at once. Leadership then passes
+straight to the new leader, so no other unit can take it in between. This can
+take up to a minute.
+
+In an HA controller, leadership can only be transferred once all of the
+controllers have been upgraded.
+
+If --to is not specified, leadership is given to the next healthy unit of the
+application.
+
+Examples:
+
+    juju transfer-leadership mysql
+    juju transfer-leadership mysql --to mysql/2
+
+See also:
+    status
+`
+
+// Info implements cmd.Command.
+func (c *transferLeadershipCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "transfer-leadership",
+		Args:    "<application>",
+		Purpose: "Transfer leadership of an application to another unit.",
+		Doc:     transferLeadershipDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *transferLeadershipCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.unitName, "to", "", "The unit to transfer leadership to")
+}
+
+// Init implements cmd.Command.
+func (c *transferLeadershipCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if c.unitName != "" {
+		if !names.IsValidUnit(c.unitName) {
+			return errors.Errorf("invalid unit name %q", c.unitName)
+		}
+		appName, err := names.UnitApplication(c.unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if appName != c.applicationName {
+			return errors.Errorf("unit %q is not a unit of application %q", c.unitName, c.applicationName)
+		}
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+type transferLeadershipAPI interface {
+	Close() error
+	BestAPIVersion() int
+	TransferLeadership(application, unitName string) (string, error)
+}
+
+// Run implements cmd.Command.
+func (c *transferLeadershipCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 12 {
+		return errors.New("transferring leadership is not supported by this controller")
+	}
+
+	leader, err := client.TransferLeadership(c.applicationName, c.unitName)
+	if err != nil {
+		return block.ProcessBlockedError(
+			errors.Annotatef(err, "could not transfer leadership of %q", c.applicationName), block.BlockChange)
+	}
+	ctx.Infof("leadership of %v is being transferred to %v", c.applicationName, leader)
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type TransferLeadershipSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockTransferLeadershipAPI
+}
+
+var _ = gc.Suite(&TransferLeadershipSuite{})
+
+type mockTransferLeadershipAPI struct {
+	*testing.Stub
+	version int
+	leader  string
+}
+
+func (s mockTransferLeadershipAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockTransferLeadershipAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockTransferLeadershipAPI) TransferLeadership(application, unitName string) (string, error) {
+	s.MethodCall(s, "TransferLeadership", application, unitName)
+	return s.leader, s.NextErr()
+}
+
+func (s *TransferLeadershipSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockTransferLeadershipAPI{Stub: &testing.Stub{}, version: 12, leader: "mysql/1"}
+}
+
+func (s *TransferLeadershipSuite) runTransferLeadership(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, NewTransferLeadershipCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *TransferLeadershipSuite) TestTransferLeadership(c *gc.C) {
+	ctx, err := s.runTransferLeadership(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.TrimSpace(cmdtesting.Stderr(ctx)), gc.Equals, "leadership of mysql is being transferred to mysql/1")
+	s.mockAPI.CheckCall(c, 0, "TransferLeadership", "mysql", "")
+}
+
+func (s *TransferLeadershipSuite) TestTransferLeadershipToUnit(c *gc.C) {
+	_, err := s.runTransferLeadership(c, "mysql", "--to", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "TransferLeadership", "mysql", "mysql/1")
+}
+
+func (s *TransferLeadershipSuite) TestTransferLeadershipError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`leadership of "mysql" is pinned and cannot be transferred`))
+	_, err := s.runTransferLeadership(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `could not transfer leadership of "mysql": leadership of "mysql" is pinned and cannot be transferred`)
+}
+
+func (s *TransferLeadershipSuite) TestTransferLeadershipBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	_, err := s.runTransferLeadership(c, "mysql")
+	c.Assert(err.Error(), jc.Contains, `could not transfer leadership of "mysql": nope`)
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *TransferLeadershipSuite) TestInvalidArgs(c *gc.C) {
+	_, err := s.runTransferLeadership(c)
+	c.Assert(err, gc.ErrorMatches, `no application specified`)
+	_, err = s.runTransferLeadership(c, "invalid:name")
+	c.Assert(err, gc.ErrorMatches, `invalid application name "invalid:name"`)
+	_, err = s.runTransferLeadership(c, "mysql", "--to", "mysql")
+	c.Assert(err, gc.ErrorMatches, `invalid unit name "mysql"`)
+	_, err = s.runTransferLeadership(c, "mysql", "--to", "redis/0")
+	c.Assert(err, gc.ErrorMatches, `unit "redis/0" is not a unit of application "mysql"`)
+	_, err = s.runTransferLeadership(c, "mysql", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *TransferLeadershipSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 11
+	_, err := s.runTransferLeadership(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "transferring leadership is not supported by this controller")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
@@ -33,6 +33,8 @@ type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
 	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	// CreateEncrypted sends an RPC request to create a new encrypted backup.
+	CreateEncrypted(notes string, keepCopy, noDownload bool, passphrase, publicKey string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -45,6 +47,10 @@ type APIClient interface {
 	Remove(ids ...string) ([]params.ErrorResult, error)
 	// Restore will restore a backup with the given id into the controller.
 	Restore(string, backups.ClientConnection) error
+	// RestoreEncrypted will restore an encrypted backup with the given id
+	// into the controller, which decrypts it with the passphrase or
+	// private key.
+	RestoreEncrypted(backupId, passphrase, privateKey string, newClient backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
 	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
 }
@@ -136,7 +142,8 @@ machine ID:            {{.MachineID}}
 created on host:       {{.Hostname}} 
 
 checksum:              {{.Checksum}} 
-checksum format:       {{.ChecksumFormat}} 
+checksum format:       {{.ChecksumFormat}} {{if .Encryption}}
+encryption:            {{.Encryption}}{{if .EncryptionKeyID}} {{.EncryptionKeyID}}{{end}} {{end}}
 size (B):              {{.Size}} 
 stored:                {{.Stored}} 
 started:               {{.Started}} 
@@ -146,22 +153,24 @@ notes:                 {{.Notes}}
 `
 
 type MetadataParams struct {
-	BackupID       string
-	FormatVersion  int64
-	Checksum       string
-	ChecksumFormat string
-	Size           int64
-	Stored         time.Time
-	Started        time.Time
-	Finished       time.Time
-	Notes          string
-	ControllerUUID string
-	HANodes        int64
-	ModelUUID      string
-	MachineID      string
-	Hostname       string
-	JujuVersion    version.Number
-	Series         string
+	BackupID        string
+	FormatVersion   int64
+	Checksum        string
+	ChecksumFormat  string
+	Size            int64
+	Stored          time.Time
+	Started         time.Time
+	Finished        time.Time
+	Notes           string
+	ControllerUUID  string
+	HANodes         int64
+	ModelUUID       string
+	MachineID       string
+	Hostname        string
+	JujuVersion     version.Number
+	Series          string
+	Encryption      string
+	EncryptionKeyID string
 }
 
 func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
@@ -182,6 +191,8 @@ func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
 		result.Hostname,
 		result.Version,
 		result.Series,
+		result.Encryption,
+		result.EncryptionKeyID,
 	}
 	t := template.Must(template.New("template").Parse(backupMetadataTemplate))
 	content := bytes.Buffer{}
@@ -211,6 +222,18 @@ var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.Bac
 		return nil, nil, errors.Trace(err)
 	}
 
+	// Encrypted archives must be decrypted before they can be read.
+	header := make([]byte, 1)
+	if _, err := io.ReadFull(archive, header); err != nil && err != io.EOF {
+		return nil, nil, errors.Trace(err)
+	}
+	if statebackups.IsEncryptedArchive(header) {
+		return nil, nil, errors.Errorf("backup archive %q is encrypted: supply a passphrase or private key to decrypt it", filename)
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	// Extract the metadata.
 	ad, err := statebackups.NewArchiveDataReader(archive)
 	if err != nil {
@@ -6,6 +6,7 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"time"
 
@@ -38,6 +39,11 @@ Use --keep-copy option to store a copy of backup remotely on the controller.
 
 Use --verbose to see extra information about backup.
 
+Use --passphrase-file or --public-key-file to have the controller encrypt
+the backup archive, with the passphrase held in the file or to the armored
+OpenPGP public key held in the file. Encrypted archives must be decrypted
+with the same passphrase, or the matching private key, to be restored.
+
 To access remote backups stored on the controller, see 'juju download-backup'.
 
 Examples:
@@ -46,6 +52,7 @@ Examples:
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
     juju create-backup --verbose
+    juju create-backup --public-key-file backups.pub
 
 See also:
     backups
@@ -68,6 +75,10 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
+	// PassphraseFile holds the passphrase used to encrypt the archive.
+	PassphraseFile string
+	// PublicKeyFile holds the public key the archive is encrypted to.
+	PublicKeyFile string
 }
 
 // Info implements Command.Info.
@@ -86,6 +97,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Encrypt the archive with the passphrase in this file")
+	f.StringVar(&c.PublicKeyFile, "public-key-file", "", "Encrypt the archive to the armored OpenPGP public key in this file")
 	c.fs = f
 }
 
@@ -122,6 +135,10 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+
+	if c.PassphraseFile != "" && c.PublicKeyFile != "" {
+		return errors.Errorf("cannot mix --passphrase-file and --public-key-file")
+	}
 	return nil
 }
 
@@ -143,6 +160,9 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		// for API v1, keepCopy is the default and only choice, so set it here
 		c.KeepCopy = true
 	}
+	if apiVersion < 3 && (c.PassphraseFile != "" || c.PublicKeyFile != "") {
+		return errors.New("encrypting backups is not supported by this controller")
+	}
 
 	if c.NoDownload {
 		ctx.Warningf(downloadWarning)
@@ -205,7 +225,17 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 }
 
 func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	var result *params.BackupsMetadataResult
+	var err error
+	if c.PassphraseFile == "" && c.PublicKeyFile == "" {
+		result, err = client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	} else {
+		var passphrase, publicKey string
+		if passphrase, publicKey, err = c.encryptionKey(); err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		result, err = client.CreateEncrypted(c.Notes, c.KeepCopy, c.NoDownload, passphrase, publicKey)
+	}
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
@@ -217,3 +247,17 @@ func (c *createCommand) create(client APIClient, apiVersion int) (*params.Backup
 
 	return result, copyFrom, err
 }
+
+// encryptionKey returns the passphrase or public key to encrypt the
+// archive with.
+func (c *createCommand) encryptionKey() (passphrase, publicKey string, err error) {
+	if c.PassphraseFile != "" {
+		passphrase, err = readPassphraseFile(c.PassphraseFile)
+		return passphrase, "", errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(c.PublicKeyFile)
+	if err != nil {
+		return "", "", errors.Annotate(err, "reading public key file")
+	}
+	return "", string(data), nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -17,6 +18,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 type createSuite struct {
@@ -289,6 +291,44 @@ func (s *createSuite) TestKeepCopyV1Fail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "--keep-copy is not supported by this controller")
 }
 
+func (s *createSuite) TestPassphraseFile(c *gc.C) {
+	s.apiVersion = 3
+	client := s.setSuccess()
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "CreateEncrypted")
+	client.CheckArgs(c, "", "true", "true", "secret", "")
+}
+
+func (s *createSuite) TestPublicKeyFile(c *gc.C) {
+	s.apiVersion = 3
+	client := s.setSuccess()
+	publicKeyFile := filepath.Join(c.MkDir(), "backups.pub")
+	err := ioutil.WriteFile(publicKeyFile, []byte(backupstesting.PublicKey), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--public-key-file", publicKeyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "CreateEncrypted")
+	client.CheckArgs(c, "", "true", "true", "", backupstesting.PublicKey)
+}
+
+func (s *createSuite) TestEncryptionV2Fail(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", "passphrase")
+	c.Assert(err, gc.ErrorMatches, "encrypting backups is not supported by this controller")
+}
+
+func (s *createSuite) TestPassphraseAndPublicKeyFile(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--passphrase-file", "passphrase", "--public-key-file", "backups.pub")
+	c.Check(err, gc.ErrorMatches, "cannot mix --passphrase-file and --public-key-file")
+}
+
 func (s *createSuite) TestFilenameAndNoDownload(c *gc.C) {
 	s.setSuccess()
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--filename", "backup.tgz")
@@ -22,6 +22,9 @@ download-backup retrieves a backup archive file.
 
 If --filename is not used, the archive is downloaded to a temporary
 location and the filename is printed to stdout.
+
+An encrypted archive is downloaded as is, unless --passphrase-file or
+--private-key-file is used to decrypt it.
 `
 
 // NewDownloadCommand returns a commant used to download backups.
@@ -36,6 +39,8 @@ type downloadCommand struct {
 	Filename string
 	// ID is the backup ID to download.
 	ID string
+
+	decryption decryptionFlags
 }
 
 // Info implements Command.Info.
@@ -52,6 +57,7 @@ func (c *downloadCommand) Info() *cmd.Info {
 func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Download target")
+	c.decryption.SetFlags(f)
 }
 
 // Init implements Command.Init.
@@ -78,12 +84,21 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	key, err := c.decryption.key()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	// Download the archive.
 	resultArchive, err := client.Download(c.ID)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer resultArchive.Close()
+	source, err := maybeDecrypt(resultArchive, key)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	// Prepare the local archive.
 	filename := c.ResolveFilename()
@@ -94,7 +109,7 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	defer archive.Close()
 
 	// Write out the archive.
-	_, err = io.Copy(archive, resultArchive)
+	_, err = io.Copy(archive, source)
 	if err != nil {
 		return errors.Annotate(err, "while copying local archive file")
 	}
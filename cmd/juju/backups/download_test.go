@@ -4,10 +4,15 @@
 package backups_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
@@ -68,6 +73,26 @@ func (s *downloadSuite) TestFilename(c *gc.C) {
 	s.checkArchive(c)
 }
 
+func (s *downloadSuite) TestDecrypt(c *gc.C) {
+	client := s.setSuccess()
+	var encrypted bytes.Buffer
+	w, err := openpgp.SymmetricallyEncrypt(&encrypted, []byte("secret"), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(s.data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	client.archive = ioutil.NopCloser(&encrypted)
+
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err = ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--passphrase-file", passphraseFile)
+	c.Check(err, jc.ErrorIsNil)
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	s.checkArchive(c)
+}
+
 func (s *downloadSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
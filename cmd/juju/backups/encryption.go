@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+// readPassphraseFile returns the passphrase held in the named file,
+// without any trailing newline.
+func readPassphraseFile(filename string) (string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", errors.Annotate(err, "reading passphrase file")
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", errors.Errorf("passphrase file %q is empty", filename)
+	}
+	return passphrase, nil
+}
+
+// decryptionFlags holds the options commands use to decrypt
+// encrypted backup archives.
+type decryptionFlags struct {
+	passphraseFile string
+	privateKeyFile string
+}
+
+// SetFlags adds the decryption options to the flag set.
+func (f *decryptionFlags) SetFlags(fs *gnuflag.FlagSet) {
+	fs.StringVar(&f.passphraseFile, "passphrase-file", "", "Decrypt the backup with the passphrase in this file")
+	fs.StringVar(&f.privateKeyFile, "private-key-file", "", "Decrypt the backup with the armored OpenPGP private key in this file")
+}
+
+// key returns the decryption key specified by the options.
+func (f *decryptionFlags) key() (statebackups.DecryptionKey, error) {
+	var key statebackups.DecryptionKey
+	if f.passphraseFile != "" {
+		passphrase, err := readPassphraseFile(f.passphraseFile)
+		if err != nil {
+			return key, errors.Trace(err)
+		}
+		key.Passphrase = passphrase
+	}
+	if f.privateKeyFile != "" {
+		data, err := ioutil.ReadFile(f.privateKeyFile)
+		if err != nil {
+			return key, errors.Annotate(err, "reading private key file")
+		}
+		key.PrivateKey = string(data)
+	}
+	return key, nil
+}
+
+// maybeDecrypt returns a reader for the decrypted contents of the
+// archive read from r, if the archive is encrypted and a key was
+// supplied. Otherwise the archive is returned as is.
+func maybeDecrypt(r io.Reader, key statebackups.DecryptionKey) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(1)
+	if err != nil && err != io.EOF {
+		return nil, errors.Trace(err)
+	}
+	if key.IsZero() || !statebackups.IsEncryptedArchive(header) {
+		return buffered, nil
+	}
+	decrypted, err := statebackups.DecryptArchive(buffered, key)
+	return decrypted, errors.Trace(err)
+}
+
+// decryptArchiveFile returns the name of a file holding the contents
+// of the named backup archive decrypted with the given key, and a
+// function that removes it once it is no longer needed. An archive
+// that isn't encrypted is used as is.
+func decryptArchiveFile(filename string, key statebackups.DecryptionKey) (_ string, cleanup func(), err error) {
+	archive, err := os.Open(filename)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	header := make([]byte, 1)
+	if _, err := io.ReadFull(archive, header); err != nil && err != io.EOF {
+		return "", nil, errors.Trace(err)
+	}
+	if !statebackups.IsEncryptedArchive(header) {
+		return filename, func() {}, nil
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	decrypted, err := statebackups.DecryptArchive(archive, key)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	plain, err := ioutil.TempFile("", "juju-backup-")
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	cleanup = func() { _ = os.Remove(plain.Name()) }
+	defer func() {
+		_ = plain.Close()
+		if err != nil {
+			cleanup()
+		}
+	}()
+	if _, err := io.Copy(plain, decrypted); err != nil {
+		return "", nil, errors.Annotate(err, "decrypting backup archive")
+	}
+	return plain.Name(), cleanup, nil
+}
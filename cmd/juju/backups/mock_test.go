@@ -122,6 +122,19 @@ func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2)
 }
 
+// CreateEncrypted mocks base method
+func (m *MockAPIClient) CreateEncrypted(arg0 string, arg1, arg2 bool, arg3, arg4 string) (*params.BackupsMetadataResult, error) {
+	ret := m.ctrl.Call(m, "CreateEncrypted", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*params.BackupsMetadataResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEncrypted indicates an expected call of CreateEncrypted
+func (mr *MockAPIClientMockRecorder) CreateEncrypted(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEncrypted", reflect.TypeOf((*MockAPIClient)(nil).CreateEncrypted), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Download mocks base method
 func (m *MockAPIClient) Download(arg0 string) (io.ReadCloser, error) {
 	ret := m.ctrl.Call(m, "Download", arg0)
@@ -190,6 +203,18 @@ func (mr *MockAPIClientMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAPIClient)(nil).Restore), arg0, arg1)
 }
 
+// RestoreEncrypted mocks base method
+func (m *MockAPIClient) RestoreEncrypted(arg0, arg1, arg2 string, arg3 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "RestoreEncrypted", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreEncrypted indicates an expected call of RestoreEncrypted
+func (mr *MockAPIClientMockRecorder) RestoreEncrypted(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreEncrypted", reflect.TypeOf((*MockAPIClient)(nil).RestoreEncrypted), arg0, arg1, arg2, arg3)
+}
+
 // RestoreReader mocks base method
 func (m *MockAPIClient) RestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 backups.ClientConnection) error {
 	ret := m.ctrl.Call(m, "RestoreReader", arg0, arg1, arg2)
@@ -203,6 +203,16 @@ func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params
 	return createResult, nil
 }
 
+func (c *fakeAPIClient) CreateEncrypted(notes string, keepCopy, noDownload bool, passphrase, publicKey string) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateEncrypted")
+	c.args = append(c.args, notes, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload), passphrase, publicKey)
+	c.notes = notes
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Info(id string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Info")
 	c.args = append(c.args, id)
@@ -263,3 +273,7 @@ func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResu
 func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
 	return nil
 }
+
+func (c *fakeAPIClient) RestoreEncrypted(string, string, string, apibackups.ClientConnection) error {
+	return nil
+}
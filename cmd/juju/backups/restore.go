@@ -40,6 +40,8 @@ type restoreCommand struct {
 
 	Filename string
 	BackupId string
+
+	decryption decryptionFlags
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -76,6 +78,11 @@ https://jaas.ai/docs/controller-backups for more information.
 
 If the provided state cannot be restored, this command will fail with
 an explanation.
+
+Encrypted backups are decrypted with the passphrase held in the file given
+with --passphrase-file, or with the armored OpenPGP private key held in the
+file given with --private-key-file. If the private key is itself protected
+by a passphrase, supply both.
 `
 
 // Info returns the content for --help.
@@ -93,6 +100,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	c.decryption.SetFlags(f)
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -172,14 +180,28 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("unable to restore backup in HA configuration.  For help see https://jaas.ai/docs/controller-backups")
 	}
 
+	key, err := c.decryption.key()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
 	target := c.BackupId
 	if c.Filename != "" {
-		// Read archive specified by the Filename
+		// Read archive specified by the Filename, decrypting it
+		// first if need be.
 		target = c.Filename
-		var err error
-		archive, meta, err = getArchive(c.Filename)
+		filename := c.Filename
+		if !key.IsZero() {
+			var cleanup func()
+			filename, cleanup, err = decryptArchiveFile(c.Filename, key)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer cleanup()
+		}
+		archive, meta, err = getArchive(filename)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -194,10 +216,13 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.Filename != "" {
+	switch {
+	case c.Filename != "":
 		err = client.RestoreReader(archive, meta, c.newClient)
-	} else {
+	case key.IsZero():
 		err = client.Restore(c.BackupId, c.newClient)
+	default:
+		err = client.RestoreEncrypted(c.BackupId, key.Passphrase, key.PrivateKey, c.newClient)
 	}
 	if err != nil {
 		return errors.Trace(err)
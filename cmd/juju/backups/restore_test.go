@@ -5,6 +5,7 @@ package backups_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 
 	"github.com/golang/mock/gomock"
@@ -201,6 +202,23 @@ func (s *restoreSuite) TestRestoreFromBackupId(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, out)
 }
 
+func (s *restoreSuite) TestRestoreFromEncryptedBackupId(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().RestoreEncrypted("an_id", "secret", "", gomock.Any()).Return(
+			nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
 	defer ctlr.Finish()
@@ -0,0 +1,362 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ControllerMembersClient defines the methods on the client api that
+// the controller member commands call.
+type ControllerMembersClient interface {
+	Close() error
+	ControllerHealth() (params.ControllerMembersHealth, error)
+	DemoteControllers(ids []string) error
+	RemoveControllers(ids []string, force bool) error
+}
+
+// controllerMembersCommandBase is the base type of the commands that
+// report on and manage the controller machines.
+type controllerMembersCommandBase struct {
+	modelcmd.ControllerCommandBase
+
+	newClientFunc func() (ControllerMembersClient, error)
+}
+
+func (c *controllerMembersCommandBase) newClient() (ControllerMembersClient, error) {
+	if c.newClientFunc != nil {
+		return c.newClientFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get API connection")
+	}
+	return highavailability.NewClient(root), nil
+}
+
+// parseControllerMachineIds returns the machine ids in args, or an
+// error if there are none or any are invalid.
+func parseControllerMachineIds(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no controller machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) || names.IsContainerMachine(id) {
+			return nil, errors.NotValidf("controller machine id %q", id)
+		}
+	}
+	return args, nil
+}
+
+func newShowControllerHealthCommand() cmd.Command {
+	return modelcmd.WrapController(&showControllerHealthCommand{})
+}
+
+// showControllerHealthCommand reports the health of each controller
+// machine.
+type showControllerHealthCommand struct {
+	controllerMembersCommandBase
+	out cmd.Output
+}
+
+const showControllerHealthDoc = `
+Shows the health of each controller machine, as seen by the controller
+answering the request:
+
+ - whether the machine has, or should have, a vote in the replica set;
+ - the state of the machine's mongo replica set member, and how far
+   its replication lags behind the primary;
+ - the machine's role in the raft cluster that manages leases;
+ - whether the machine's API server can be reached.
+
+Voting membership is normally managed automatically. If a controller
+machine has failed, demote-controller-machine and
+remove-controller-machine may be used to remove it explicitly.
+
+Examples:
+    juju show-controller-health
+    juju show-controller-health --format yaml
+
+See also:
+    enable-ha
+    demote-controller-machine
+    remove-controller-machine
+`
+
+// Info implements Command.
+func (c *showControllerHealthCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-controller-health",
+		Purpose: "Shows the health of the controller machines.",
+		Doc:     showControllerHealthDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *showControllerHealthCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerHealthTabular,
+	})
+}
+
+// Init implements Command.
+func (c *showControllerHealthCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// controllerMemberHealth is the serialisable form of the health of a
+// controller machine.
+type controllerMemberHealth struct {
+	Vote           string `yaml:"vote" json:"vote"`
+	MongoState     string `yaml:"mongo-state,omitempty" json:"mongo-state,omitempty"`
+	MongoHealthy   bool   `yaml:"mongo-healthy" json:"mongo-healthy"`
+	MongoError     string `yaml:"mongo-error,omitempty" json:"mongo-error,omitempty"`
+	ReplicationLag string `yaml:"replication-lag,omitempty" json:"replication-lag,omitempty"`
+	RaftRole       string `yaml:"raft-role,omitempty" json:"raft-role,omitempty"`
+	APIAddress     string `yaml:"api-address,omitempty" json:"api-address,omitempty"`
+	APIReachable   bool   `yaml:"api-reachable" json:"api-reachable"`
+	APIError       string `yaml:"api-error,omitempty" json:"api-error,omitempty"`
+}
+
+// voteStatus describes the voting membership of a controller machine.
+func voteStatus(wantsVote, hasVote bool) string {
+	switch {
+	case wantsVote && hasVote:
+		return "yes"
+	case wantsVote:
+		return "adding"
+	case hasVote:
+		return "removing"
+	}
+	return "no"
+}
+
+// Run implements Command.
+func (c *showControllerHealthCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := client.ControllerHealth()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.RaftError != "" {
+		ctx.Warningf("cannot determine raft roles: %s", result.RaftError)
+	}
+	members := make(map[string]controllerMemberHealth)
+	for _, m := range result.Members {
+		tag, err := names.ParseMachineTag(m.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		health := controllerMemberHealth{
+			Vote:         voteStatus(m.WantsVote, m.HasVote),
+			MongoState:   m.MongoState,
+			MongoHealthy: m.MongoHealthy,
+			MongoError:   m.MongoError,
+			RaftRole:     m.RaftRole,
+			APIAddress:   m.APIAddress,
+			APIReachable: m.APIReachable,
+			APIError:     m.APIError,
+		}
+		if m.MongoState != "" {
+			health.ReplicationLag = m.ReplicationLag.Round(time.Millisecond).String()
+		}
+		members[tag.Id()] = health
+	}
+	return c.out.Write(ctx, members)
+}
+
+// formatControllerHealthTabular writes the controller health as a table
+// ordered by machine id.
+func formatControllerHealthTabular(writer io.Writer, value interface{}) error {
+	members, ok := value.(map[string]controllerMemberHealth)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", members, value)
+	}
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Vote", "Mongo", "Lag", "Raft", "API")
+	for _, id := range ids {
+		m := members[id]
+		mongo := m.MongoState
+		switch {
+		case mongo == "":
+			mongo = "-"
+		case !m.MongoHealthy:
+			mongo += " (unhealthy)"
+		}
+		lag := m.ReplicationLag
+		if lag == "" {
+			lag = "-"
+		}
+		raft := m.RaftRole
+		if raft == "" {
+			raft = "-"
+		}
+		api := "ok"
+		if !m.APIReachable {
+			api = fmt.Sprintf("unreachable: %s", m.APIError)
+		}
+		w.Println(id, m.Vote, mongo, lag, raft, api)
+	}
+	return tw.Flush()
+}
+
+func newDemoteControllerMachineCommand() cmd.Command {
+	return modelcmd.WrapController(&demoteControllerMachineCommand{})
+}
+
+// demoteControllerMachineCommand removes the vote of controller
+// machines.
+type demoteControllerMachineCommand struct {
+	controllerMembersCommandBase
+	machineIds []string
+}
+
+const demoteControllerMachineDoc = `
+Removes the vote of the specified controller machines from the
+controller's replica set, without waiting for the peer grouper to
+decide that they have failed. The machines remain controllers.
+
+A machine is only demoted if the remaining voting members would be a
+healthy majority, so that the controller keeps a quorum.
+
+Examples:
+    juju demote-controller-machine 2
+
+See also:
+    show-controller-health
+    remove-controller-machine
+`
+
+// Info implements Command.
+func (c *demoteControllerMachineCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "demote-controller-machine",
+		Args:    "<machine> ...",
+		Purpose: "Removes the vote of controller machines.",
+		Doc:     demoteControllerMachineDoc,
+	})
+}
+
+// Init implements Command.
+func (c *demoteControllerMachineCommand) Init(args []string) (err error) {
+	c.machineIds, err = parseControllerMachineIds(args)
+	return err
+}
+
+// Run implements Command.
+func (c *demoteControllerMachineCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.DemoteControllers(c.machineIds); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
+
+func newRemoveControllerMachineCommand() cmd.Command {
+	return modelcmd.WrapController(&removeControllerMachineCommand{})
+}
+
+// removeControllerMachineCommand removes controller machines.
+type removeControllerMachineCommand struct {
+	controllerMembersCommandBase
+	machineIds []string
+	force      bool
+}
+
+const removeControllerMachineDoc = `
+Removes the specified controller machines, removing their votes from
+the controller's replica set first. Use this to remove a failed
+controller machine instead of waiting for the peer grouper to decide
+that it has failed.
+
+A machine is only removed if the remaining voting members would be a
+healthy majority, so that the controller keeps a quorum. If --force is
+specified, the machines are removed even if their agents are not
+running; this is needed when a machine has failed.
+
+Examples:
+    juju remove-controller-machine 2
+    juju remove-controller-machine 2 --force
+
+See also:
+    show-controller-health
+    demote-controller-machine
+    enable-ha
+`
+
+// Info implements Command.
+func (c *removeControllerMachineCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-controller-machine",
+		Args:    "<machine> ...",
+		Purpose: "Removes controller machines.",
+		Doc:     removeControllerMachineDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *removeControllerMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Remove the machines even if their agents are not running")
+}
+
+// Init implements Command.
+func (c *removeControllerMachineCommand) Init(args []string) (err error) {
+	c.machineIds, err = parseControllerMachineIds(args)
+	return err
+}
+
+// Run implements Command.
+func (c *removeControllerMachineCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.RemoveControllers(c.machineIds, c.force); err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ControllerMembersSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	fake *fakeControllerMembersClient
+}
+
+var _ = gc.Suite(&ControllerMembersSuite{})
+
+func (s *ControllerMembersSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeControllerMembersClient{
+		health: params.ControllerMembersHealth{
+			Members: []params.ControllerMemberHealth{{
+				Tag:          "machine-0",
+				WantsVote:    true,
+				HasVote:      true,
+				MongoState:   "PRIMARY",
+				MongoHealthy: true,
+				RaftRole:     "leader",
+				APIAddress:   "10.0.0.1:17070",
+				APIReachable: true,
+			}, {
+				Tag:            "machine-10",
+				WantsVote:      false,
+				HasVote:        true,
+				MongoState:     "SECONDARY",
+				MongoHealthy:   true,
+				ReplicationLag: 1500 * time.Millisecond,
+				RaftRole:       "follower",
+				APIAddress:     "10.0.0.10:17070",
+				APIReachable:   true,
+			}, {
+				Tag:          "machine-2",
+				WantsVote:    true,
+				HasVote:      true,
+				MongoState:   "(not reachable/healthy)",
+				MongoError:   "no route to host",
+				APIAddress:   "10.0.0.2:17070",
+				APIError:     "connection refused",
+				RaftRole:     "non-voter",
+				MongoHealthy: false,
+			}},
+		},
+	}
+}
+
+func (s *ControllerMembersSuite) base() controllerMembersCommandBase {
+	base := controllerMembersCommandBase{
+		newClientFunc: func() (ControllerMembersClient, error) { return s.fake, nil },
+	}
+	base.SetClientStore(jujuclienttesting.MinimalStore())
+	return base
+}
+
+func (s *ControllerMembersSuite) run(c *gc.C, command modelcmd.ControllerCommand, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *ControllerMembersSuite) TestShowControllerHealth(c *gc.C) {
+	ctx, err := s.run(c, &showControllerHealthCommand{controllerMembersCommandBase: s.base()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Machine  Vote      Mongo                                Lag   Raft       API\n"+
+		"0        yes       PRIMARY                              0s    leader     ok\n"+
+		"2        yes       (not reachable/healthy) (unhealthy)  0s    non-voter  unreachable: connection refused\n"+
+		"10       removing  SECONDARY                            1.5s  follower   ok\n",
+	)
+}
+
+func (s *ControllerMembersSuite) TestShowControllerHealthYAML(c *gc.C) {
+	s.fake.health.Members = s.fake.health.Members[1:2]
+	s.fake.health.RaftError = "timeout waiting for raft status"
+	ctx, err := s.run(c, &showControllerHealthCommand{controllerMembersCommandBase: s.base()}, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"10":
+  vote: removing
+  mongo-state: SECONDARY
+  mongo-healthy: true
+  replication-lag: 1.5s
+  raft-role: follower
+  api-address: 10.0.0.10:17070
+  api-reachable: true
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, "(?s).*cannot determine raft roles: timeout waiting for raft status\n")
+}
+
+func (s *ControllerMembersSuite) TestDemoteControllerMachine(c *gc.C) {
+	_, err := s.run(c, &demoteControllerMachineCommand{controllerMembersCommandBase: s.base()}, "1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.demoted, jc.DeepEquals, []string{"1", "2"})
+}
+
+func (s *ControllerMembersSuite) TestRemoveControllerMachine(c *gc.C) {
+	_, err := s.run(c, &removeControllerMachineCommand{controllerMembersCommandBase: s.base()}, "2", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.removed, jc.DeepEquals, []string{"2"})
+	c.Assert(s.fake.force, jc.IsTrue)
+}
+
+func (s *ControllerMembersSuite) TestRemoveControllerMachineBlocked(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestRemoveControllerMachineBlocked")
+	_, err := s.run(c, &removeControllerMachineCommand{controllerMembersCommandBase: s.base()}, "2")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestRemoveControllerMachineBlocked.*")
+}
+
+func (s *ControllerMembersSuite) TestMachineArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no controller machines specified",
+	}, {
+		args: []string{"foo"},
+		err:  `controller machine id "foo" not valid`,
+	}, {
+		args: []string{"0/lxd/1"},
+		err:  `controller machine id "0/lxd/1" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, &demoteControllerMachineCommand{controllerMembersCommandBase: s.base()}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+		_, err = s.run(c, &removeControllerMachineCommand{controllerMembersCommandBase: s.base()}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type fakeControllerMembersClient struct {
+	health  params.ControllerMembersHealth
+	demoted []string
+	removed []string
+	force   bool
+	err     error
+}
+
+func (f *fakeControllerMembersClient) Close() error {
+	return nil
+}
+
+func (f *fakeControllerMembersClient) ControllerHealth() (params.ControllerMembersHealth, error) {
+	return f.health, f.err
+}
+
+func (f *fakeControllerMembersClient) DemoteControllers(ids []string) error {
+	f.demoted = ids
+	return f.err
+}
+
+func (f *fakeControllerMembersClient) RemoveControllers(ids []string, force bool) error {
+	f.removed = ids
+	f.force = force
+	return f.err
+}
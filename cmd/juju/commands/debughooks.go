@@ -6,15 +6,21 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/debugshell"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -25,6 +31,7 @@ import (
 func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 	c := new(debugHooksCommand)
 	c.getActionAPI = c.newActionsAPI
+	c.getDebugShellAPI = c.newDebugShellAPI
 	c.setHostChecker(hostChecker)
 	return modelcmd.Wrap(c)
 }
@@ -32,9 +39,11 @@ func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 // debugHooksCommand is responsible for launching a ssh shell on a given unit or machine.
 type debugHooksCommand struct {
 	sshCommand
-	hooks []string
+	hooks  []string
+	viaAPI bool
 
-	getActionAPI func() (ActionsAPI, error)
+	getActionAPI     func() (ActionsAPI, error)
+	getDebugShellAPI func() (DebugShellAPI, error)
 }
 
 const debugHooksDoc = `
@@ -42,6 +51,18 @@ Interactively debug hooks or actions remotely on an application unit.
 
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+With --via-api, no SSH connection or tmux session is used. Instead,
+the unit agent pauses the next matching hook or action, and runs a
+shell in its place whose terminal is relayed through the controller.
+This works for units that cannot be reached over SSH, including units
+of Kubernetes applications. Run the hook from the shell as usual, and
+exit the shell to let the agent continue; the exit status of the shell
+is reported as the result of the hook or action.
+
+Examples:
+
+    juju debug-hooks --via-api mysql/0 config-changed
 `
 
 func (c *debugHooksCommand) Info() *cmd.Info {
@@ -54,6 +75,11 @@ func (c *debugHooksCommand) Info() *cmd.Info {
 	})
 }
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.BoolVar(&c.viaAPI, "via-api", false, "Debug in a shell relayed by the controller instead of over SSH")
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
@@ -82,6 +108,11 @@ type ActionsAPI interface {
 	ApplicationCharmActions(params.Entity) (map[string]params.ActionSpec, error)
 }
 
+// DebugShellAPI opens debug shell sessions relayed by the controller.
+type DebugShellAPI interface {
+	Open(unitName string, hooks []string) (debugshell.Stream, error)
+}
+
 func (c *debugHooksCommand) getApplicationAPI() (charmRelationsAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -98,6 +129,14 @@ func (c *debugHooksCommand) newActionsAPI() (ActionsAPI, error) {
 	return action.NewClient(root), nil
 }
 
+func (c *debugHooksCommand) newDebugShellAPI() (DebugShellAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return debugshell.NewAPI(root), nil
+}
+
 func (c *debugHooksCommand) validateHooksOrActions() error {
 	if len(c.hooks) == 0 {
 		return nil
@@ -181,6 +220,9 @@ func (c *debugHooksCommand) getValidHooks(appName string) (set.Strings, error) {
 // and connects to it via SSH to execute the debug-hooks
 // script.
 func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
+	if c.viaAPI {
+		return c.runViaAPI(ctx)
+	}
 	err := c.initRun()
 	if err != nil {
 		return err
@@ -197,3 +239,90 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 	c.Args = args
 	return c.sshCommand.Run(ctx)
 }
+
+// runViaAPI debugs the unit's hooks in a shell relayed by the
+// controller, with the local terminal standing in for tmux.
+func (c *debugHooksCommand) runViaAPI(ctx *cmd.Context) error {
+	if err := c.validateHooksOrActions(); err != nil {
+		return err
+	}
+	api, err := c.getDebugShellAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stream, err := api.Open(c.Target, c.hooks)
+	if err != nil {
+		return errors.Annotatef(err, "starting debug session for %s", c.Target)
+	}
+	defer stream.Close()
+
+	if len(c.hooks) == 0 {
+		ctx.Infof("Waiting for the next hook or action to run on %s...", c.Target)
+	} else {
+		ctx.Infof("Waiting for %s to run on %s...", strings.Join(c.hooks, ", "), c.Target)
+	}
+	for {
+		m, err := stream.Receive()
+		if err != nil {
+			return errors.Annotate(err, "waiting for hook")
+		}
+		if m.Hook != "" {
+			ctx.Infof("Debugging %s on %s; exit the shell to continue", m.Hook, c.Target)
+			break
+		}
+	}
+
+	if f, ok := ctx.Stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		fd := int(f.Fd())
+		state, err := terminal.MakeRaw(fd)
+		if err != nil {
+			return errors.Annotate(err, "setting terminal to raw mode")
+		}
+		defer terminal.Restore(fd, state)
+		if cols, rows, err := terminal.GetSize(fd); err == nil {
+			if err := stream.Send(params.DebugShellMessage{
+				Rows: uint16(rows),
+				Cols: uint16(cols),
+			}); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	go sendDebugShellInput(ctx.Stdin, stream)
+
+	for {
+		m, err := stream.Receive()
+		if err != nil {
+			return errors.Annotate(err, "debug session ended")
+		}
+		if len(m.Data) > 0 {
+			if _, err := ctx.Stdout.Write(m.Data); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if m.ExitCode != nil {
+			if *m.ExitCode != 0 {
+				return cmd.NewRcPassthroughError(*m.ExitCode)
+			}
+			return nil
+		}
+	}
+}
+
+// sendDebugShellInput sends the local input to the debug shell
+// until the input or the stream is closed.
+func sendDebugShellInput(stdin io.Reader, stream debugshell.Stream) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			data := append([]byte(nil), buf[:n]...)
+			if sendErr := stream.Send(params.DebugShellMessage{Data: data}); sendErr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -4,13 +4,19 @@
 package commands
 
 import (
+	"io"
 	"regexp"
 	"runtime"
+	"strings"
 
+	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/debugshell"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
 	jujussh "github.com/juju/juju/network/ssh"
 )
 
@@ -153,3 +159,97 @@ func (s *DebugHooksSuite) TestDebugHooksCommand(c *gc.C) {
 		}
 	}
 }
+
+func (s *DebugHooksSuite) newDebugHooksCommandViaAPI(api DebugShellAPI) cmd.Command {
+	c := new(debugHooksCommand)
+	c.getActionAPI = c.newActionsAPI
+	c.getDebugShellAPI = func() (DebugShellAPI, error) {
+		return api, nil
+	}
+	c.setHostChecker(s.hostChecker)
+	return modelcmd.Wrap(c)
+}
+
+func (s *DebugHooksSuite) TestDebugHooksViaAPI(c *gc.C) {
+	s.setupModel(c)
+	exitCode := 0
+	stream := &fakeDebugShellStream{
+		toOperator: []params.DebugShellMessage{
+			{Hook: "config-changed"},
+			{Data: []byte("hello\r\n")},
+			{ExitCode: &exitCode},
+		},
+		fromOperator: make(chan params.DebugShellMessage, 10),
+	}
+	api := &fakeDebugShellAPI{stream: stream}
+
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("./hooks/config-changed\n")
+	code := cmd.Main(s.newDebugHooksCommandViaAPI(api), ctx, []string{"--via-api", "mysql/0", "config-changed"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(api.unitName, gc.Equals, "mysql/0")
+	c.Check(api.hooks, jc.DeepEquals, []string{"config-changed"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "hello\r\n")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "Debugging config-changed on mysql/0")
+	c.Check(stream.closed, jc.IsTrue)
+}
+
+func (s *DebugHooksSuite) TestDebugHooksViaAPIExitCode(c *gc.C) {
+	s.setupModel(c)
+	exitCode := 3
+	stream := &fakeDebugShellStream{
+		toOperator: []params.DebugShellMessage{
+			{Hook: "install"},
+			{ExitCode: &exitCode},
+		},
+		fromOperator: make(chan params.DebugShellMessage, 10),
+	}
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(s.newDebugHooksCommandViaAPI(&fakeDebugShellAPI{stream: stream}), ctx, []string{"--via-api", "mysql/0"})
+	c.Assert(code, gc.Equals, 3)
+}
+
+func (s *DebugHooksSuite) TestDebugHooksViaAPIInvalidHook(c *gc.C) {
+	s.setupModel(c)
+	api := &fakeDebugShellAPI{}
+	_, err := cmdtesting.RunCommand(c, s.newDebugHooksCommandViaAPI(api), "--via-api", "mysql/0", "invalid-hook")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" contains neither hook nor action "invalid-hook".*`)
+	c.Assert(api.unitName, gc.Equals, "")
+}
+
+type fakeDebugShellAPI struct {
+	stream   *fakeDebugShellStream
+	unitName string
+	hooks    []string
+}
+
+func (f *fakeDebugShellAPI) Open(unitName string, hooks []string) (debugshell.Stream, error) {
+	f.unitName = unitName
+	f.hooks = hooks
+	return f.stream, nil
+}
+
+type fakeDebugShellStream struct {
+	toOperator   []params.DebugShellMessage
+	fromOperator chan params.DebugShellMessage
+	closed       bool
+}
+
+func (f *fakeDebugShellStream) Send(m params.DebugShellMessage) error {
+	f.fromOperator <- m
+	return nil
+}
+
+func (f *fakeDebugShellStream) Receive() (params.DebugShellMessage, error) {
+	if len(f.toOperator) == 0 {
+		return params.DebugShellMessage{}, io.EOF
+	}
+	m := f.toOperator[0]
+	f.toOperator = f.toOperator[1:]
+	return m, nil
+}
+
+func (f *fakeDebugShellStream) Close() error {
+	f.closed = true
+	return nil
+}
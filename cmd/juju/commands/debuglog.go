@@ -6,10 +6,12 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/juju/ansiterm"
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -52,13 +54,32 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by matching
+the log message against a regular expression.
+
+The '--agent' option filters by the kind of agent that logged the message:
+machine, unit or application.
+
+The '--since' and '--until' options limit the messages to those logged within
+a time window. Each takes a timestamp in RFC3339 format, a date and time in
+the form "YYYY-MM-DD HH:MM:SS" (local time, or UTC with --utc), or a duration
+such as 90m meaning that long ago. Using --since implies --replay. If the
+--until time has passed, no new messages are waited for.
+
+All filtering is done by the controller, so only matching messages are sent.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-message options are logically ORed together.
+* All --exclude-message options are logically ORed together.
+* All --agent options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-message, --exclude-message and --agent selections, and the
+  --since and --until time window, are logically ANDed to form the complete
+  filter.
 
 Examples:
 
@@ -101,6 +122,16 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all unit agent messages mentioning a failed hook in the hour around an
+incident, and then stop:
+
+    juju debug-log --agent unit --include-message 'hook .* failed' \
+        --since '2020-03-02 09:30:00' --until '2020-03-02 10:30:00'
+
+Show ERROR messages from the last 2 hours and continue to append:
+
+    juju debug-log --level ERROR --since 2h
+
 See also:
     status
     ssh`
@@ -127,7 +158,10 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	since  string
+	until  string
 	params common.DebugLogParams
+	clock  clock.Clock
 
 	utc      bool
 	location bool
@@ -150,6 +184,11 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "include-message", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-message", "Do not show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.AgentKinds), "agent", "Only show log messages from these kinds of agent, any of [machine, unit, application]")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -184,6 +223,37 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.utc {
 		c.tz = time.UTC
 	}
+	for _, expr := range append(c.params.IncludeMessage, c.params.ExcludeMessage...) {
+		if _, err := regexp.Compile(expr); err != nil {
+			return errors.Errorf("message pattern %q is not a valid regular expression: %v", expr, err)
+		}
+	}
+	for _, kind := range c.params.AgentKinds {
+		switch kind {
+		case names.MachineTagKind, names.UnitTagKind, names.ApplicationTagKind:
+		default:
+			return errors.Errorf("agent value %q is not one of %q, %q, %q",
+				kind, names.MachineTagKind, names.UnitTagKind, names.ApplicationTagKind)
+		}
+	}
+	if c.since != "" {
+		since, err := c.parseTime(c.since)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := c.parseTime(c.until)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		if until.Before(c.params.StartTime) {
+			return errors.New("--until time is before --since time")
+		}
+		c.params.EndTime = until
+	}
 	if c.date {
 		c.format = "2006-01-02 15:04:05"
 	} else {
@@ -202,6 +272,41 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// timeLayouts are the layouts accepted by --since and --until, other
+// than RFC3339 and durations.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTime parses a --since or --until value, which is either an
+// RFC3339 timestamp, a date and time in the command's time zone, or a
+// duration meaning that long ago.
+func (c *debugLogCommand) parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	loc := c.tz
+	if loc == nil {
+		loc = time.Local
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		now := clock.WallClock.Now()
+		if c.clock != nil {
+			now = c.clock.Now()
+		}
+		return now.Add(-d), nil
+	}
+	return time.Time{}, errors.Errorf(
+		"%q is not a timestamp (RFC3339 or YYYY-MM-DD HH:MM:SS) or a duration", value)
+}
+
 func (c *debugLogCommand) processEntities(isCAAS bool, entities []string) []string {
 	if entities == nil {
 		return nil
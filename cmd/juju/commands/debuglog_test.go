@@ -6,6 +6,7 @@ package commands
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -104,6 +105,48 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{
+				"--include-message", "^hook .* failed",
+				"--include-message", "error",
+				"--exclude-message", "update-status"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"^hook .* failed", "error"},
+				ExcludeMessage: []string{"update-status"},
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--include-message", "hook (start"},
+			errMatch: `message pattern "hook \(start" is not a valid regular expression: .*`,
+		}, {
+			args: []string{"--agent", "unit", "--agent", "machine"},
+			expected: common.DebugLogParams{
+				AgentKinds: []string{"unit", "machine"},
+				Backlog:    10,
+			},
+		}, {
+			args:     []string{"--agent", "user"},
+			errMatch: `agent value "user" is not one of "machine", "unit", "application"`,
+		}, {
+			args: []string{"--since", "2020-03-02T09:30:00Z", "--until", "2020-03-02T10:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2020, 3, 2, 9, 30, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 3, 2, 10, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args: []string{"--until", "2020-03-02T10:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog: 10,
+				EndTime: time.Date(2020, 3, 2, 10, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is not a timestamp \(RFC3339 or YYYY-MM-DD HH:MM:SS\) or a duration`,
+		}, {
+			args:     []string{"--since", "2020-03-02T10:30:00Z", "--until", "2020-03-02T09:30:00Z"},
+			errMatch: `--until time is before --since time`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -119,6 +162,22 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestSinceUntilLocalTimeAndDuration(c *gc.C) {
+	now := time.Date(2020, 3, 2, 12, 0, 0, 0, time.UTC)
+	tz := time.FixedZone("", 3600)
+	command := &debugLogCommand{
+		tz:    tz,
+		clock: testclock.NewClock(now),
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{
+		"--since", "90m", "--until", "2020-03-02 12:30",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.params.StartTime, gc.Equals, now.Add(-90*time.Minute))
+	c.Check(command.params.EndTime.Equal(time.Date(2020, 3, 2, 11, 30, 0, 0, time.UTC)), jc.IsTrue)
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
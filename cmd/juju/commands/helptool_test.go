@@ -65,6 +65,7 @@ Currently available charm hook tools are:
     opened-ports             lists all ports or ranges opened by the unit
     pod-spec-get             get pod spec information
     pod-spec-set             set pod spec information
+    publish-metric           publish metrics to the controller
     relation-get             get relation settings
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
@@ -113,6 +114,7 @@ var expectedCommands = []string{
 	"payload-unregister",
 	"pod-spec-get",
 	"pod-spec-set",
+	"publish-metric",
 	"relation-get",
 	"relation-ids",
 	"relation-list",
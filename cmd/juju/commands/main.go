@@ -293,6 +293,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewExportStatusHistoryCommand())
+	r.Register(status.NewWaitForCommand())
 
 	// Error resolution and debugging commands.
 	if !featureflag.Enabled(feature.JujuV3) {
@@ -368,6 +370,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
 	r.Register(model.NewModelCredentialCommand())
+	r.Register(model.NewSetQuotasCommand())
 	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewAddBranchCommand())
 		r.Register(model.NewCommitCommand())
@@ -404,6 +407,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
+	r.Register(newShowControllerHealthCommand())
+	r.Register(newDemoteControllerMachineCommand())
+	r.Register(newRemoveControllerMachineCommand())
 
 	// Manage and control applications
 	r.Register(application.NewAddUnitCommand())
@@ -415,6 +421,13 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewApplicationSetConstraintsCommand())
 	r.Register(application.NewBundleDiffCommand())
 	r.Register(application.NewShowApplicationCommand())
+	r.Register(application.NewTransferLeadershipCommand())
+	r.Register(application.NewEnterMaintenanceCommand())
+	r.Register(application.NewExitMaintenanceCommand())
+	r.Register(application.NewAllowEgressCommand())
+	r.Register(application.NewRevokeEgressCommand())
+	r.Register(application.NewResetEgressCommand())
+	r.Register(application.NewEgressRulesCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -432,6 +445,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewResizeStorageCommandWithAPI())
+	r.Register(storage.NewCreateStorageSnapshotCommandWithAPI())
+	r.Register(storage.NewListStorageSnapshotsCommandWithAPI())
+	r.Register(storage.NewRemoveStorageSnapshotCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
@@ -464,6 +481,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
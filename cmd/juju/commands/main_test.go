@@ -439,9 +439,11 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"agreements",
+	"allow-egress",
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bind",
@@ -460,6 +462,7 @@ var commandNames = []string{
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"create-wallet",
 	"credentials",
 	"debug-hook",
@@ -467,6 +470,7 @@ var commandNames = []string{
 	"debug-log",
 	"default-credential",
 	"default-region",
+	"demote-controller-machine",
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
@@ -476,12 +480,16 @@ var commandNames = []string{
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
+	"egress-rules",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"enter-maintenance",
 	"exec",
+	"exit-maintenance",
 	"export-bundle",
+	"export-status-log",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -518,6 +526,7 @@ var commandNames = []string{
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"list-wallets",
@@ -543,6 +552,7 @@ var commandNames = []string{
 	"remove-cached-images",
 	"remove-cloud",
 	"remove-consumed-application",
+	"remove-controller-machine",
 	"remove-credential",
 	"remove-k8s",
 	"remove-machine",
@@ -553,9 +563,12 @@ var commandNames = []string{
 	"remove-ssh-key",
 	"remove-storage",
 	"remove-storage-pool",
+	"remove-storage-snapshot",
 	"remove-unit",
 	"remove-user",
 	"rename-space",
+	"reset-egress",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
@@ -564,6 +577,7 @@ var commandNames = []string{
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
+	"revoke-egress",
 	"run",
 	"scale-application",
 	"scp",
@@ -574,6 +588,7 @@ var commandNames = []string{
 	"set-firewall-rule",
 	"set-meter-status",
 	"set-model-constraints",
+	"set-model-quotas",
 	"set-plan",
 	"set-series",
 	"set-wallet",
@@ -582,6 +597,7 @@ var commandNames = []string{
 	"show-backup",
 	"show-cloud",
 	"show-controller",
+	"show-controller-health",
 	"show-credential",
 	"show-credentials",
 	"show-machine",
@@ -600,11 +616,13 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"transfer-leadership",
 	"trust",
 	"unexpose",
 	"unregister",
@@ -622,6 +640,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
@@ -40,6 +40,14 @@ type ModelInfo struct {
 	SLAOwner       string                      `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion   string                      `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
 	Credential     *ModelCredential            `json:"credential,omitempty" yaml:"credential,omitempty"`
+	Quotas         map[string]ModelQuota       `json:"quotas,omitempty" yaml:"quotas,omitempty"`
+}
+
+// ModelQuota contains the limit set on a model resource and the amount
+// of it in use. Memory and storage are measured in MiB.
+type ModelQuota struct {
+	Limit uint64 `json:"limit" yaml:"limit"`
+	Used  uint64 `json:"used" yaml:"used"`
 }
 
 // ModelMachineInfo contains information about a machine in a model.
@@ -144,6 +152,15 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		modelInfo.SLA = ModelSLAFromParams(info.SLA)
 		modelInfo.SLAOwner = ModelSLAOwnerFromParams(info.SLA)
 	}
+	if len(info.Quotas) != 0 {
+		modelInfo.Quotas = make(map[string]ModelQuota)
+		for _, quota := range info.Quotas {
+			modelInfo.Quotas[quota.Resource] = ModelQuota{
+				Limit: quota.Limit,
+				Used:  quota.Used,
+			}
+		}
+	}
 
 	if info.CloudCredentialTag != "" {
 		credTag, err := names.ParseCloudCredentialTag(info.CloudCredentialTag)
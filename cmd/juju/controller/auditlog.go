@@ -0,0 +1,407 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api"
+	controllerapi "github.com/juju/juju/api/controller"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/network"
+)
+
+// NewAuditLogCommand returns a command to query the controller audit
+// log.
+func NewAuditLogCommand() cmd.Command {
+	command := &auditLogCommand{}
+	command.newAPIFunc = command.newAPI
+	return modelcmd.WrapController(command)
+}
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	AuditLog(auditlog.Filter) ([]auditlog.Record, error)
+	APIHostPorts() []network.MachineHostPorts
+}
+
+const auditLogDoc = `
+Shows the API requests recorded in the audit log of the current
+controller. The audit log needs to be enabled with the
+"auditing-enabled" controller config setting.
+
+Each controller machine only records the requests it served, so in a
+highly available controller the logs from all of the controller
+machines are fetched and merged. Controller machines that can't be
+reached are reported and skipped.
+
+Records can be selected by the user that made them, the model they
+were made against, the facade and method called, the conversation (a
+single client command) they were part of, and a time range. Times can
+be given in RFC3339 format (2006-01-02T15:04:05Z) or as a date
+(2006-01-02). When --limit is given, the most recent matching requests
+are shown.
+
+Only controller superusers can read the audit log.
+
+Examples:
+
+    juju audit-log --user bob --after 2020-03-01
+    juju audit-log --model-uuid 1f6a2b5e-4e3c-4c1c-8b2e-4d2a1d6f6f00 --method Application.Deploy
+    juju audit-log --conversation 0123456789abcdef --format yaml
+
+See also:
+    controller-config
+`
+
+// auditLogCommand queries the audit logs of the controller machines.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	user           string
+	modelUUID      string
+	method         string
+	conversationID string
+	after          string
+	before         string
+	limit          int
+
+	filter     auditlog.Filter
+	newAPIFunc func(addrs []string) (AuditLogAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Shows the API requests recorded in the controller audit log.",
+		Doc:     auditLogDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.modelUUID, "model-uuid", "", "Only show requests made against the model with this UUID")
+	f.StringVar(&c.method, "method", "", "Only show calls to this facade or method (Facade or Facade.Method)")
+	f.StringVar(&c.conversationID, "conversation", "", "Only show requests in this conversation")
+	f.StringVar(&c.after, "after", "", "Only show requests made at or after this time")
+	f.StringVar(&c.before, "before", "", "Only show requests made at or before this time")
+	f.IntVar(&c.limit, "limit", 0, "Show at most this many of the most recent requests")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative limit %d", c.limit)
+	}
+	c.filter = auditlog.Filter{
+		Who:            c.user,
+		ModelUUID:      c.modelUUID,
+		ConversationID: c.conversationID,
+		Limit:          c.limit,
+	}
+	if c.method != "" {
+		parts := strings.SplitN(c.method, ".", 2)
+		c.filter.Facade = parts[0]
+		if len(parts) == 2 {
+			c.filter.Method = parts[1]
+		}
+		if c.filter.Facade == "" {
+			return errors.NotValidf("method %q", c.method)
+		}
+	}
+	var err error
+	if c.filter.After, err = parseAuditTime(c.after); err != nil {
+		return errors.Annotate(err, "invalid --after")
+	}
+	if c.filter.Before, err = parseAuditTime(c.before); err != nil {
+		return errors.Annotate(err, "invalid --before")
+	}
+	if !c.filter.After.IsZero() && !c.filter.Before.IsZero() && c.filter.Before.Before(c.filter.After) {
+		return errors.New("--before time must not be earlier than --after time")
+	}
+	return nil
+}
+
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("time %q (expected RFC3339 or YYYY-MM-DD)", value)
+	}
+	return t, nil
+}
+
+func (c *auditLogCommand) newAPI(addrs []string) (AuditLogAPI, error) {
+	if len(addrs) > 0 {
+		// Connect to a specific controller machine rather than
+		// whichever one answers first.
+		c.SetAPIOpen(func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+			machineInfo := *info
+			machineInfo.Addrs = addrs
+			return api.Open(&machineInfo, opts)
+		})
+		defer c.SetAPIOpen(nil)
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &auditLogAPI{
+		Client: controllerapi.NewClient(root),
+		conn:   root,
+	}, nil
+}
+
+type auditLogAPI struct {
+	*controllerapi.Client
+	conn api.Connection
+}
+
+// APIHostPorts is part of AuditLogAPI.
+func (a *auditLogAPI) APIHostPorts() []network.MachineHostPorts {
+	return a.conn.APIHostPorts()
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	machines := client.APIHostPorts()
+	if len(machines) <= 1 {
+		records, err := client.AuditLog(c.filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, mergeAuditRecords(c.filter.Limit, records))
+	}
+
+	var (
+		all      [][]auditlog.Record
+		failures int
+	)
+	for _, hostPorts := range machines {
+		addrs := hostPorts.HostPorts().FilterUnusable().Strings()
+		records, err := c.machineAuditLog(addrs)
+		if err != nil {
+			failures++
+			ctx.Warningf("cannot read audit log from controller at %s: %v", strings.Join(addrs, ", "), err)
+			continue
+		}
+		all = append(all, records)
+	}
+	if failures == len(machines) {
+		return errors.New("cannot read audit log from any controller machine")
+	}
+	return c.out.Write(ctx, mergeAuditRecords(c.filter.Limit, all...))
+}
+
+func (c *auditLogCommand) machineAuditLog(addrs []string) ([]auditlog.Record, error) {
+	client, err := c.newAPIFunc(addrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+	records, err := client.AuditLog(c.filter)
+	return records, errors.Trace(err)
+}
+
+// AuditConversation is the serialisation format for a conversation
+// in the audit log, along with its requests.
+type AuditConversation struct {
+	ConversationID string         `yaml:"conversation-id" json:"conversation-id"`
+	Who            string         `yaml:"user" json:"user"`
+	What           string         `yaml:"command" json:"command"`
+	When           string         `yaml:"when" json:"when"`
+	ModelName      string         `yaml:"model,omitempty" json:"model,omitempty"`
+	ModelUUID      string         `yaml:"model-uuid,omitempty" json:"model-uuid,omitempty"`
+	Requests       []AuditRequest `yaml:"requests" json:"requests"`
+}
+
+// AuditRequest is the serialisation format for an API request in the
+// audit log.
+type AuditRequest struct {
+	RequestID uint64       `yaml:"request-id" json:"request-id"`
+	When      string       `yaml:"when" json:"when"`
+	Facade    string       `yaml:"facade" json:"facade"`
+	Method    string       `yaml:"method" json:"method"`
+	Version   int          `yaml:"version" json:"version"`
+	Args      string       `yaml:"args,omitempty" json:"args,omitempty"`
+	Errors    []AuditError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+// AuditError is the serialisation format for an error returned by an
+// API request.
+type AuditError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+// mergeAuditRecords combines the records read from one or more
+// controller machines into conversations ordered by time. If limit is
+// positive only that many of the most recent requests are kept.
+func mergeAuditRecords(limit int, recordSets ...[]auditlog.Record) []AuditConversation {
+	var order []string
+	conversations := make(map[string]*AuditConversation)
+	requests := make(map[string]map[uint64]*AuditRequest)
+	conversation := func(id string) *AuditConversation {
+		if conv, ok := conversations[id]; ok {
+			return conv
+		}
+		conv := &AuditConversation{ConversationID: id}
+		conversations[id] = conv
+		requests[id] = make(map[uint64]*AuditRequest)
+		order = append(order, id)
+		return conv
+	}
+	for _, records := range recordSets {
+		for _, record := range records {
+			switch {
+			case record.Conversation != nil:
+				r := record.Conversation
+				conv := conversation(r.ConversationID)
+				conv.Who = r.Who
+				conv.What = r.What
+				conv.When = r.When
+				conv.ModelName = r.ModelName
+				conv.ModelUUID = r.ModelUUID
+			case record.Request != nil:
+				r := record.Request
+				conversation(r.ConversationID)
+				if req, ok := requests[r.ConversationID][r.RequestID]; ok {
+					// Keep any errors already seen for a duplicate.
+					req.When, req.Facade, req.Method, req.Version, req.Args = r.When, r.Facade, r.Method, r.Version, r.Args
+					continue
+				}
+				requests[r.ConversationID][r.RequestID] = &AuditRequest{
+					RequestID: r.RequestID,
+					When:      r.When,
+					Facade:    r.Facade,
+					Method:    r.Method,
+					Version:   r.Version,
+					Args:      r.Args,
+				}
+			case record.Errors != nil:
+				r := record.Errors
+				conversation(r.ConversationID)
+				req, ok := requests[r.ConversationID][r.RequestID]
+				if !ok {
+					req = &AuditRequest{RequestID: r.RequestID}
+					requests[r.ConversationID][r.RequestID] = req
+				}
+				req.Errors = nil
+				for _, e := range r.Errors {
+					if e != nil {
+						req.Errors = append(req.Errors, AuditError{Message: e.Message, Code: e.Code})
+					}
+				}
+			}
+		}
+	}
+
+	// Work out which requests survive the limit, oldest first.
+	type requestRef struct {
+		conversationID string
+		request        *AuditRequest
+	}
+	var all []requestRef
+	for _, id := range order {
+		for _, req := range requests[id] {
+			all = append(all, requestRef{id, req})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if a, b := auditTime(all[i].request.When), auditTime(all[j].request.When); !a.Equal(b) {
+			return a.Before(b)
+		}
+		if all[i].conversationID != all[j].conversationID {
+			return all[i].conversationID < all[j].conversationID
+		}
+		return all[i].request.RequestID < all[j].request.RequestID
+	})
+	if limit > 0 && len(all) > limit {
+		all = all[len(all)-limit:]
+	}
+
+	var result []AuditConversation
+	index := make(map[string]int)
+	for _, ref := range all {
+		i, ok := index[ref.conversationID]
+		if !ok {
+			i = len(result)
+			index[ref.conversationID] = i
+			result = append(result, *conversations[ref.conversationID])
+		}
+		result[i].Requests = append(result[i].Requests, *ref.request)
+	}
+	return result
+}
+
+func auditTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	conversations, ok := value.([]AuditConversation)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", conversations, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "User", "Model", "Conversation", "Request", "Errors")
+	for _, conv := range conversations {
+		model := conv.ModelName
+		if model == "" {
+			model = conv.ModelUUID
+		}
+		for _, req := range conv.Requests {
+			var errs []string
+			for _, e := range req.Errors {
+				errs = append(errs, e.Message)
+			}
+			w.Println(
+				req.When,
+				conv.Who,
+				model,
+				conv.ConversationID,
+				fmt.Sprintf("%s.%s v%d", req.Facade, req.Method, req.Version),
+				strings.Join(errs, "; "),
+			)
+		}
+	}
+	return errors.Trace(tw.Flush())
+}
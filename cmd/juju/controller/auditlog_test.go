@@ -0,0 +1,280 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/jujuclient"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	store *jujuclient.MemStore
+	apis  map[string]*fakeAuditLogAPI
+	stub  testing.Stub
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.stub = testing.Stub{}
+	s.apis = map[string]*fakeAuditLogAPI{
+		"": {stub: &s.stub},
+	}
+}
+
+func (s *auditLogSuite) newCommand() cmd.Command {
+	return controller.NewAuditLogCommandForTest(func(addrs []string) (controller.AuditLogAPI, error) {
+		key := strings.Join(addrs, ",")
+		s.stub.AddCall("NewAPI", key)
+		if err := s.stub.NextErr(); err != nil {
+			return nil, err
+		}
+		api, ok := s.apis[key]
+		if !ok {
+			return nil, errors.NotFoundf("controller at %q", key)
+		}
+		return api, nil
+	}, s.store)
+}
+
+var (
+	bobConversation = auditlog.Conversation{
+		Who:            "bob",
+		What:           "juju deploy prometheus",
+		When:           "2020-03-02T10:00:00Z",
+		ModelName:      "admin/default",
+		ModelUUID:      "model-1",
+		ConversationID: "0123456789abcdef",
+	}
+	bobDeploy = auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		RequestID:      1,
+		When:           "2020-03-02T10:00:01Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        10,
+	}
+	bobDeployErrors = auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		RequestID:      1,
+		When:           "2020-03-02T10:00:02Z",
+		Errors:         []*auditlog.Error{{Message: "oops", Code: "unauthorized access"}},
+	}
+	aliceConversation = auditlog.Conversation{
+		Who:            "alice",
+		What:           "juju add-model foo",
+		When:           "2020-03-02T09:00:00Z",
+		ConversationID: "fedcba9876543210",
+	}
+	aliceAddModel = auditlog.Request{
+		ConversationID: "fedcba9876543210",
+		RequestID:      1,
+		When:           "2020-03-02T09:00:01Z",
+		Facade:         "ModelManager",
+		Method:         "CreateModel",
+		Version:        7,
+	}
+)
+
+func (s *auditLogSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  `negative limit -1 not valid`,
+	}, {
+		args: []string{"--method", ".Deploy"},
+		err:  `method ".Deploy" not valid`,
+	}, {
+		args: []string{"--after", "yesterday"},
+		err:  `invalid --after: time "yesterday" \(expected RFC3339 or YYYY-MM-DD\)`,
+	}, {
+		args: []string{"--after", "2020-03-02", "--before", "2020-03-01T00:00:00Z"},
+		err:  `--before time must not be earlier than --after time`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *auditLogSuite) TestFilterFromFlags(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"--user", "bob",
+		"--model-uuid", "model-1",
+		"--method", "Application.Deploy",
+		"--conversation", "0123456789abcdef",
+		"--after", "2020-03-01",
+		"--before", "2020-03-02T12:00:00Z",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCall(c, 2, "AuditLog", auditlog.Filter{
+		Who:            "bob",
+		ModelUUID:      "model-1",
+		ConversationID: "0123456789abcdef",
+		Facade:         "Application",
+		Method:         "Deploy",
+		After:          time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		Before:         time.Date(2020, 3, 2, 12, 0, 0, 0, time.UTC),
+		Limit:          5,
+	})
+}
+
+func (s *auditLogSuite) TestSingleController(c *gc.C) {
+	s.apis[""].records = []auditlog.Record{
+		{Conversation: &bobConversation},
+		{Request: &bobDeploy},
+		{Errors: &bobDeployErrors},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  User  Model          Conversation      Request                 Errors\n"+
+		"2020-03-02T10:00:01Z  bob   admin/default  0123456789abcdef  Application.Deploy v10  oops\n")
+	s.stub.CheckCallNames(c, "NewAPI", "APIHostPorts", "AuditLog", "Close")
+}
+
+func (s *auditLogSuite) TestMergesControllerMachines(c *gc.C) {
+	s.apis[""].hostPorts = []network.MachineHostPorts{
+		network.NewMachineHostPorts(17070, "10.0.0.1"),
+		network.NewMachineHostPorts(17070, "10.0.0.2"),
+	}
+	s.apis["10.0.0.1:17070"] = &fakeAuditLogAPI{
+		stub: &s.stub,
+		records: []auditlog.Record{
+			{Conversation: &bobConversation},
+			{Request: &bobDeploy},
+			{Errors: &bobDeployErrors},
+		},
+	}
+	s.apis["10.0.0.2:17070"] = &fakeAuditLogAPI{
+		stub: &s.stub,
+		records: []auditlog.Record{
+			{Conversation: &aliceConversation},
+			{Request: &aliceAddModel},
+		},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- conversation-id: fedcba9876543210
+  user: alice
+  command: juju add-model foo
+  when: "2020-03-02T09:00:00Z"
+  requests:
+  - request-id: 1
+    when: "2020-03-02T09:00:01Z"
+    facade: ModelManager
+    method: CreateModel
+    version: 7
+- conversation-id: 0123456789abcdef
+  user: bob
+  command: juju deploy prometheus
+  when: "2020-03-02T10:00:00Z"
+  model: admin/default
+  model-uuid: model-1
+  requests:
+  - request-id: 1
+    when: "2020-03-02T10:00:01Z"
+    facade: Application
+    method: Deploy
+    version: 10
+    errors:
+    - message: oops
+      code: unauthorized access
+`[1:])
+	s.stub.CheckCall(c, 2, "NewAPI", "10.0.0.1:17070")
+	s.stub.CheckCall(c, 5, "NewAPI", "10.0.0.2:17070")
+}
+
+func (s *auditLogSuite) TestLimitAppliesAcrossMachines(c *gc.C) {
+	s.apis[""].hostPorts = []network.MachineHostPorts{
+		network.NewMachineHostPorts(17070, "10.0.0.1"),
+		network.NewMachineHostPorts(17070, "10.0.0.2"),
+	}
+	s.apis["10.0.0.1:17070"] = &fakeAuditLogAPI{
+		stub:    &s.stub,
+		records: []auditlog.Record{{Conversation: &bobConversation}, {Request: &bobDeploy}},
+	}
+	s.apis["10.0.0.2:17070"] = &fakeAuditLogAPI{
+		stub:    &s.stub,
+		records: []auditlog.Record{{Conversation: &aliceConversation}, {Request: &aliceAddModel}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--limit", "1", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[{"conversation-id":"0123456789abcdef","user":"bob","command":"juju deploy prometheus","when":"2020-03-02T10:00:00Z","model":"admin/default","model-uuid":"model-1","requests":[{"request-id":1,"when":"2020-03-02T10:00:01Z","facade":"Application","method":"Deploy","version":10}]}]`+"\n")
+}
+
+func (s *auditLogSuite) TestUnreachableMachineSkipped(c *gc.C) {
+	s.apis[""].hostPorts = []network.MachineHostPorts{
+		network.NewMachineHostPorts(17070, "10.0.0.1"),
+		network.NewMachineHostPorts(17070, "10.0.0.2"),
+	}
+	s.apis["10.0.0.2:17070"] = &fakeAuditLogAPI{
+		stub:    &s.stub,
+		records: []auditlog.Record{{Conversation: &aliceConversation}, {Request: &aliceAddModel}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, `cannot read audit log from controller at 10.0.0.1:17070: controller at "10.0.0.1:17070" not found`)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "ModelManager.CreateModel v7")
+}
+
+func (s *auditLogSuite) TestAllMachinesUnreachable(c *gc.C) {
+	s.apis[""].hostPorts = []network.MachineHostPorts{
+		network.NewMachineHostPorts(17070, "10.0.0.1"),
+		network.NewMachineHostPorts(17070, "10.0.0.2"),
+	}
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "cannot read audit log from any controller machine")
+}
+
+func (s *auditLogSuite) TestAuditLogError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("permission denied"))
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	stub      *testing.Stub
+	records   []auditlog.Record
+	hostPorts []network.MachineHostPorts
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.stub.AddCall("Close")
+	return nil
+}
+
+func (f *fakeAuditLogAPI) AuditLog(filter auditlog.Filter) ([]auditlog.Record, error) {
+	f.stub.AddCall("AuditLog", filter)
+	return f.records, f.stub.NextErr()
+}
+
+func (f *fakeAuditLogAPI) APIHostPorts() []network.MachineHostPorts {
+	f.stub.AddCall("APIHostPorts")
+	return f.hostPorts
+}
@@ -189,3 +189,13 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewAuditLogCommandForTest returns an audit-log command with the
+// function used to open API connections mocked out.
+func NewAuditLogCommandForTest(newAPI func(addrs []string) (AuditLogAPI, error), store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{
+		newAPIFunc: newAPI,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -94,6 +95,7 @@ type ControllerAccessAPI interface {
 	MongoVersion() (string, error)
 	IdentityProviderURL() (string, error)
 	ControllerVersion() (controller.ControllerVersion, error)
+	BackupStatus() (controller.BackupStatus, error)
 	Close() error
 }
 
@@ -188,6 +190,13 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 				details.Errors = append(details.Errors, err.Error())
 				mongoVersion = "(error)"
 			}
+			// Fetch the scheduled backup status if the apiserver supports it
+			backupStatus, err := client.BackupStatus()
+			if err != nil && !errors.IsNotSupported(err) {
+				details.Errors = append(details.Errors, err.Error())
+			} else if err == nil {
+				details.Backups = convertBackupStatusForShow(backupStatus)
+			}
 		}
 
 		// Fetch identityURL if the apiserver supports it
@@ -291,6 +300,9 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// Backups holds the status of the controller's scheduled backups.
+	Backups *BackupDetails `yaml:"backups,omitempty" json:"backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -386,6 +398,51 @@ type AccountDetails struct {
 	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// BackupDetails holds details of the scheduled backups of a controller
+// to show.
+type BackupDetails struct {
+	// Schedule is the cron-style schedule the backups are taken on.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+
+	// NextBackup is when the next scheduled backup is due.
+	NextBackup string `yaml:"next-backup,omitempty" json:"next-backup,omitempty"`
+
+	// LastSuccess is when the last scheduled backup succeeded.
+	LastSuccess string `yaml:"last-success,omitempty" json:"last-success,omitempty"`
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string `yaml:"last-backup-id,omitempty" json:"last-backup-id,omitempty"`
+
+	// LastFailure is when the last scheduled backup failed.
+	LastFailure string `yaml:"last-failure,omitempty" json:"last-failure,omitempty"`
+
+	// LastError is the error the last failed scheduled backup hit.
+	LastError string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
+// convertBackupStatusForShow returns the backup details to show, or
+// nil if backups have never been scheduled.
+func convertBackupStatusForShow(status controller.BackupStatus) *BackupDetails {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	details := BackupDetails{
+		Schedule:     status.Schedule,
+		NextBackup:   formatTime(status.NextBackup),
+		LastSuccess:  formatTime(status.LastSuccess),
+		LastBackupID: status.LastBackupID,
+		LastFailure:  formatTime(status.LastFailure),
+		LastError:    status.LastError,
+	}
+	if details == (BackupDetails{}) {
+		return nil
+	}
+	return &details
+}
+
 func (c *showControllerCommand) convertControllerForShow(
 	controller *ShowControllerDetails,
 	controllerName string,
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -527,6 +528,32 @@ func (s *ShowControllerSuite) TestShowControllerWithIdentityProvider(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "identity-url: "+expURL)
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithBackupStatus(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	s.fakeController.backupStatus = &apicontroller.BackupStatus{}
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "backups:")
+
+	s.fakeController.backupStatus = &apicontroller.BackupStatus{
+		Schedule:     "0 2 * * *",
+		NextBackup:   time.Date(2020, 3, 19, 2, 0, 0, 0, time.UTC),
+		LastSuccess:  time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC),
+		LastBackupID: "backup-id",
+		LastFailure:  time.Date(2020, 3, 17, 2, 0, 0, 0, time.UTC),
+		LastError:    "disk full",
+	}
+	ctx, err = s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, jc.Contains, "  backups:\n    schedule: 0 2 * * *\n")
+	c.Assert(out, gc.Matches, `(?s).*next-backup: "?2020-03-19T02:00:00Z"?\n.*`)
+	c.Assert(out, gc.Matches, `(?s).*last-success: "?2020-03-18T02:00:00Z"?\n.*`)
+	c.Assert(out, jc.Contains, "last-backup-id: backup-id\n")
+	c.Assert(out, gc.Matches, `(?s).*last-failure: "?2020-03-17T02:00:00Z"?\n.*`)
+	c.Assert(out, jc.Contains, "last-error: disk full\n")
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithCAFingerprint(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -668,6 +695,7 @@ type fakeController struct {
 	bestAPIVersion    int
 	identityURL       string
 	controllerVersion apicontroller.ControllerVersion
+	backupStatus      *apicontroller.BackupStatus
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -728,6 +756,13 @@ func (c *fakeController) ControllerVersion() (apicontroller.ControllerVersion, e
 	return c.controllerVersion, nil
 }
 
+func (c *fakeController) BackupStatus() (apicontroller.BackupStatus, error) {
+	if c.backupStatus == nil {
+		return apicontroller.BackupStatus{}, errors.NotSupportedf("BackupStatus")
+	}
+	return *c.backupStatus, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
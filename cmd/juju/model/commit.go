@@ -23,8 +23,14 @@ Committing a branch writes changes to charm configuration made under the
 branch, to the model. All units who's applications were changed under the 
 branch realise those changes, as will any new units.
 
+The --dry-run option reports what committing the branch would do without
+changing the model: which units would see which configuration changes and
+the hooks that would run on them as a result, along with any changed
+settings that are not valid for the applications' current charms.
+
 Examples:
     juju commit upgrade-postgresql
+    juju commit upgrade-postgresql --dry-run
 
 See also:
     add-branch
@@ -48,6 +54,7 @@ type commitCommand struct {
 	api CommitCommandAPI
 
 	branchName string
+	dryRun     bool
 }
 
 // CommitCommandAPI defines an API interface to be used during testing.
@@ -60,6 +67,10 @@ type CommitCommandAPI interface {
 	// all branch changes across the model.
 	// The new generation ID of the model is returned.
 	CommitBranch(branchName string) (int, error)
+
+	// PreviewCommitBranch reports what committing the branch with the
+	// input name would do, without changing the model.
+	PreviewCommitBranch(branchName string) ([]model.CommitPreviewApplication, error)
 }
 
 // Info implements part of the cmd.Command interface.
@@ -76,6 +87,7 @@ func (c *commitCommand) Info() *cmd.Info {
 // SetFlags implements part of the cmd.Command interface.
 func (c *commitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Show the effect of committing the branch without committing it")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -109,6 +121,10 @@ func (c *commitCommand) Run(ctx *cmd.Context) error {
 	}
 	defer func() { _ = client.Close() }()
 
+	if c.dryRun {
+		return c.preview(ctx, client)
+	}
+
 	newGenId, err := client.CommitBranch(c.branchName)
 	if err != nil {
 		return err
@@ -133,3 +149,20 @@ func (c *commitCommand) Run(ctx *cmd.Context) error {
 	_, err = ctx.Stdout.Write([]byte(msg))
 	return err
 }
+
+// preview writes out what committing the branch would do.
+func (c *commitCommand) preview(ctx *cmd.Context, client CommitCommandAPI) error {
+	preview, err := client.PreviewCommitBranch(c.branchName)
+	if err != nil {
+		return err
+	}
+	if len(preview) == 0 {
+		ctx.Infof("Branch %q has no changes to commit", c.branchName)
+		return nil
+	}
+	if err := cmd.FormatYaml(ctx.Stdout, preview); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Dry run: branch %q was not committed", c.branchName)
+	return nil
+}
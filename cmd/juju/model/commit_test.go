@@ -82,6 +82,48 @@ func (s *commitSuite) TestRunCommandFail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "fail")
 }
 
+func (s *commitSuite) TestRunCommandDryRun(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().PreviewCommitBranch(s.branchName).Return([]coremodel.CommitPreviewApplication{{
+		ApplicationName:   "redis",
+		InvalidConfigKeys: []string{"no-such-key"},
+		Units: []coremodel.CommitPreviewUnit{{
+			UnitName:      "redis/1",
+			ConfigChanges: map[string]interface{}{"port": 8000},
+			Hooks:         []string{"config-changed"},
+		}},
+	}}, nil)
+
+	ctx, err := cmdtesting.RunCommand(c, model.NewCommitCommandForTest(api, s.store), s.branchName, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- application: redis
+  invalid-config-keys:
+  - no-such-key
+  units:
+  - unit: redis/1
+    config:
+      port: 8000
+    hooks:
+    - config-changed
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Dry run: branch \"new-branch\" was not committed\n")
+}
+
+func (s *commitSuite) TestRunCommandDryRunNoChanges(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().PreviewCommitBranch(s.branchName).Return(nil, nil)
+
+	ctx, err := cmdtesting.RunCommand(c, model.NewCommitCommandForTest(api, s.store), s.branchName, "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Branch \"new-branch\" has no changes to commit\n")
+}
+
 func (s *commitSuite) runInit(args ...string) error {
 	return cmdtesting.InitCommand(model.NewCommitCommandForTest(nil, s.store), args)
 }
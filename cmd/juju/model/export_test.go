@@ -59,6 +59,14 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetQuotasCommandForTest returns a set-model-quotas command with the
+// api provided as specified.
+func NewSetQuotasCommandForTest(api SetQuotasAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setQuotasCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/juju/juju/core/model"
 )
 
 // MockCommitCommandAPI is a mock of CommitCommandAPI interface
@@ -57,3 +58,16 @@ func (m *MockCommitCommandAPI) CommitBranch(arg0 string) (int, error) {
 func (mr *MockCommitCommandAPIMockRecorder) CommitBranch(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBranch", reflect.TypeOf((*MockCommitCommandAPI)(nil).CommitBranch), arg0)
 }
+
+// PreviewCommitBranch mocks base method
+func (m *MockCommitCommandAPI) PreviewCommitBranch(arg0 string) ([]model.CommitPreviewApplication, error) {
+	ret := m.ctrl.Call(m, "PreviewCommitBranch", arg0)
+	ret0, _ := ret[0].([]model.CommitPreviewApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewCommitBranch indicates an expected call of PreviewCommitBranch
+func (mr *MockCommitCommandAPIMockRecorder) PreviewCommitBranch(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewCommitBranch", reflect.TypeOf((*MockCommitCommandAPI)(nil).PreviewCommitBranch), arg0)
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v3"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremodel "github.com/juju/juju/core/model"
+)
+
+// NewSetQuotasCommand returns a fully constructed set-model-quotas command.
+func NewSetQuotasCommand() cmd.Command {
+	return modelcmd.Wrap(&setQuotasCommand{})
+}
+
+type setQuotasCommand struct {
+	modelcmd.ModelCommandBase
+	api SetQuotasAPI
+
+	set   map[string]uint64
+	unset []string
+}
+
+const setQuotasHelpDoc = `
+Sets limits on the resources a model may consume. Once a limit is
+reached, adding machines, units or storage that would take the model
+over it fails. Resources already in use are never removed, even when
+a limit is lowered below the current usage.
+
+The resources that may be limited are:
+
+    machines    the number of machines, not counting containers
+    units       the number of principal units; subordinates are not counted
+    cores       the total CPU cores of the model's machines
+    memory      the total memory of the model's machines
+    storage     the total size of the model's volumes, filesystems and unit storage
+
+Memory and storage take a size with an optional M, G, T or P suffix;
+without a suffix the value is in megabytes. Set a limit to "unlimited"
+or to an empty value to remove it.
+
+Only controller administrators may change model quotas. The current
+limits and usage are shown by "juju show-model".
+
+Examples:
+
+    juju set-model-quotas machines=10 units=50
+    juju set-model-quotas -m mymodel memory=64G storage=2T
+    juju set-model-quotas cores=unlimited
+
+See also:
+    show-model
+`
+
+// Info implements Command.
+func (c *setQuotasCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-model-quotas",
+		Args:    "<resource>=<limit> ...",
+		Purpose: "Sets limits on the resources a model may consume.",
+		Doc:     setQuotasHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *setQuotasCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no quotas specified")
+	}
+	c.set = make(map[string]uint64)
+	c.unset = nil
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected <resource>=<limit>, got %q", arg)
+		}
+		name, value := parts[0], parts[1]
+		if !isQuotaName(name) {
+			return errors.Errorf(
+				"resource %q not valid (expected one of %s)",
+				name, strings.Join(coremodel.QuotaNames(), ", "),
+			)
+		}
+		if seen[name] {
+			return errors.Errorf("quota %q specified more than once", name)
+		}
+		seen[name] = true
+		if value == "" || value == "unlimited" {
+			c.unset = append(c.unset, name)
+			continue
+		}
+		limit, err := parseQuotaLimit(name, value)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s quota", name)
+		}
+		c.set[name] = limit
+	}
+	return nil
+}
+
+func isQuotaName(name string) bool {
+	for _, quota := range coremodel.QuotaNames() {
+		if name == quota {
+			return true
+		}
+	}
+	return false
+}
+
+// parseQuotaLimit parses a limit for the named resource. Memory and
+// storage limits are sizes, which are returned in MiB.
+func parseQuotaLimit(name, value string) (uint64, error) {
+	switch name {
+	case coremodel.QuotaMemory, coremodel.QuotaStorage:
+		return utils.ParseSize(value)
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// SetQuotasAPI specifies the used function calls of the ModelManager.
+type SetQuotasAPI interface {
+	Close() error
+	SetModelQuotas(model names.ModelTag, set map[string]uint64, unset []string) error
+}
+
+func (c *setQuotasCommand) getAPI() (SetQuotasAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.ModelCommandBase.NewModelManagerAPIClient()
+}
+
+// Run implements Command.
+func (c *setQuotasCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	_, modelDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+	return block.ProcessBlockedError(client.SetModelQuotas(modelTag, c.set, c.unset), block.BlockChange)
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type SetQuotasCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeSetQuotasClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&SetQuotasCommandSuite{})
+
+type fakeSetQuotasClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeSetQuotasClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSetQuotasClient) SetModelQuotas(model names.ModelTag, set map[string]uint64, unset []string) error {
+	f.MethodCall(f, "SetModelQuotas", model, set, unset)
+	return f.NextErr()
+}
+
+func (s *SetQuotasCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *SetQuotasCommandSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no quotas specified",
+	}, {
+		args: []string{"machines"},
+		err:  `expected <resource>=<limit>, got "machines"`,
+	}, {
+		args: []string{"gpus=2"},
+		err:  `resource "gpus" not valid \(expected one of cores, machines, memory, storage, units\)`,
+	}, {
+		args: []string{"units=lots"},
+		err:  `invalid units quota: .*`,
+	}, {
+		args: []string{"memory=8X"},
+		err:  `invalid memory quota: .*`,
+	}, {
+		args: []string{"units=1", "units=2"},
+		err:  `quota "units" specified more than once`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, model.NewSetQuotasCommandForTest(&s.fake, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *SetQuotasCommandSuite) TestSetQuotas(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewSetQuotasCommandForTest(&s.fake, s.store),
+		"machines=10", "memory=64G", "storage=2048", "cores=unlimited", "units=")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetModelQuotas", []interface{}{
+			testing.ModelTag,
+			map[string]uint64{"machines": 10, "memory": 65536, "storage": 2048},
+			[]string{"cores", "units"},
+		}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetQuotasCommandSuite) TestSetQuotasError(c *gc.C) {
+	s.fake.SetErrors(errors.New("permission denied"))
+	_, err := cmdtesting.RunCommand(c, model.NewSetQuotasCommandForTest(&s.fake, s.store), "units=5")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
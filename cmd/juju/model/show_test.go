@@ -186,6 +186,21 @@ func (s *ShowCommandSuite) TestShowWithCredentialFormatYaml(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
 }
 
+func (s *ShowCommandSuite) TestShowWithQuotasFormatYaml(c *gc.C) {
+	s.fake.info.Quotas = []params.ModelQuota{
+		{Resource: "machines", Limit: 10, Used: 3},
+		{Resource: "memory", Limit: 65536, Used: 12288},
+	}
+	modelOutput := s.expectedOutput["mymodel"].(attrs)
+	modelOutput["quotas"] = attrs{
+		"machines": attrs{"limit": 10, "used": 3},
+		"memory":   attrs{"limit": 65536, "used": 12288},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newShowCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+}
+
 func (s *ShowCommandSuite) TestShowFormatJson(c *gc.C) {
 	ctx, err := cmdtesting.RunCommand(c, s.newShowCommand(), "--format", "json")
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,6 +4,7 @@
 package status
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/cmd/juju/storage"
@@ -14,7 +15,15 @@ func NewTestStatusHistoryCommand(api HistoryAPI) cmd.Command {
 	return &statusHistoryCommand{api: api}
 }
 
+func NewTestExportStatusHistoryCommand(api ExportHistoryAPI) cmd.Command {
+	return &exportStatusHistoryCommand{api: api}
+}
+
 func NewTestStatusCommand(statusapi statusAPI, storageapi storage.StorageListAPI, clock Clock) cmd.Command {
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, storageAPI: storageapi, clock: clock})
 }
+
+func NewTestWaitForCommand(api WaitForAPI, clock clock.Clock) cmd.Command {
+	return &waitForCommand{api: api, clock: clock}
+}
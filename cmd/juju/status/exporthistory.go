@@ -0,0 +1,383 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
+)
+
+// NewExportStatusHistoryCommand returns a command that exports the
+// history of status changes of every entity in the model.
+func NewExportStatusHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&exportStatusHistoryCommand{})
+}
+
+// ExportHistoryAPI is the API surface for the export-status-log command.
+type ExportHistoryAPI interface {
+	ModelStatusHistory(params.ModelStatusHistoryRequest) (params.ModelStatusHistoryResult, error)
+	Close() error
+}
+
+type exportStatusHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	api ExportHistoryAPI
+
+	format               string
+	filename             string
+	fromArg              string
+	toArg                string
+	kindArg              string
+	isoTime              bool
+	includeStatusUpdates bool
+
+	from  *time.Time
+	to    *time.Time
+	kinds []string
+}
+
+// exportHistoryKinds holds the kinds of status history entry that
+// may be selected with --type, and their descriptions.
+var exportHistoryKinds = map[status.HistoryKind]string{
+	status.KindModel:               "statuses of the model",
+	status.KindApplication:         "statuses of applications",
+	status.KindApplicationOperator: "statuses of the operators of applications in a k8s model",
+	status.KindRemoteApplication:   "statuses of applications in other models",
+	status.KindRelation:            "statuses of relations",
+	status.KindUnitAgent:           "statuses from the agents that manage units",
+	status.KindWorkload:            "statuses of units' workloads",
+	status.KindWorkloadVersion:     "workload versions of units",
+	status.KindMachineInstance:     "statuses that occur due to provisioning of machines",
+	status.KindMachine:             "statuses of the agents that manage machines",
+	status.KindMachineModification: "statuses of changes made to machines",
+	status.KindContainerInstance:   "statuses that occur due to provisioning of containers",
+	status.KindContainer:           "statuses of the agents that manage containers",
+	status.KindVolume:              "statuses of storage volumes",
+	status.KindFilesystem:          "statuses of storage filesystems",
+}
+
+var exportStatusHistoryDoc = fmt.Sprintf(`
+Exports the history of status changes of every machine, unit,
+application, relation and storage volume or filesystem in the model,
+merged into a single timeline ordered by the time of each change.
+
+The timeline may be restricted to the changes made within a time range
+with --from and --to, which accept either a date (YYYY-MM-DD, taken as
+midnight UTC) or an RFC3339 time. Changes made at the --to time are not
+included.
+
+The --type option selects the kinds of status to export, as a
+comma-separated list of:
+%v
+In the csv and json formats, output is written as it is received from
+the controller, so large histories may be streamed to a file with
+--output. These formats always report times as UTC in RFC3339 format.
+
+Examples:
+
+    juju export-status-log --from 2020-03-01 --to 2020-03-02
+    juju export-status-log --type workload,juju-unit --format csv -o status.csv
+
+See also:
+    show-status-log
+    status
+`, exportHistoryKindDescs())
+
+func exportHistoryKindDescs() string {
+	kinds := make([]string, 0, len(exportHistoryKinds))
+	for k := range exportHistoryKinds {
+		kinds = append(kinds, string(k))
+	}
+	sort.Strings(kinds)
+	all := ""
+	for _, k := range kinds {
+		all += fmt.Sprintf("    %v:  %v\n", k, exportHistoryKinds[status.HistoryKind(k)])
+	}
+	return all
+}
+
+// Info implements Command.
+func (c *exportStatusHistoryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-status-log",
+		Purpose: "Export past statuses of all entities in the model.",
+		Doc:     exportStatusHistoryDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *exportStatusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.format, "format", "tabular", "Specify output format (csv|json|tabular)")
+	f.StringVar(&c.filename, "o", "", "Specify an output file")
+	f.StringVar(&c.filename, "output", "", "")
+	f.StringVar(&c.fromArg, "from", "", "Export statuses set at or after this date or time")
+	f.StringVar(&c.toArg, "to", "", "Export statuses set before this date or time")
+	f.StringVar(&c.kindArg, "type", "", "Comma-separated kinds of status to export (default all)")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Include update status hook messages in the exported logs")
+}
+
+// Init implements Command.
+func (c *exportStatusHistoryCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	switch c.format {
+	case "csv", "json", "tabular":
+	default:
+		return errors.Errorf("unknown format %q", c.format)
+	}
+	var err error
+	if c.from, err = parseHistoryTime("from", c.fromArg); err != nil {
+		return errors.Trace(err)
+	}
+	if c.to, err = parseHistoryTime("to", c.toArg); err != nil {
+		return errors.Trace(err)
+	}
+	if c.from != nil && c.to != nil && !c.to.After(*c.from) {
+		return errors.New("--to must be later than --from")
+	}
+	c.kinds = nil
+	for _, kind := range strings.Split(c.kindArg, ",") {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		if _, ok := exportHistoryKinds[status.HistoryKind(kind)]; !ok {
+			return errors.Errorf("unexpected status type %q", kind)
+		}
+		c.kinds = append(c.kinds, kind)
+	}
+	return nil
+}
+
+func parseHistoryTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, errors.Errorf("--%s: expected YYYY-MM-DD or RFC3339 time, got %q", name, value)
+}
+
+func (c *exportStatusHistoryCommand) getAPI() (ExportHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run implements Command.
+func (c *exportStatusHistoryCommand) Run(ctx *cmd.Context) (err error) {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+
+	out := ctx.Stdout
+	if c.filename != "" {
+		f, err := os.Create(ctx.AbsPath(c.filename))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = errors.Trace(closeErr)
+			}
+		}()
+		out = f
+	}
+
+	args := params.ModelStatusHistoryRequest{
+		From:  c.from,
+		To:    c.to,
+		Kinds: c.kinds,
+	}
+	if !c.includeStatusUpdates {
+		args.Exclude = []string{runningHookMSG}
+	}
+	w := c.newHistoryWriter(out)
+	count := 0
+	for {
+		result, err := apiclient.ModelStatusHistory(args)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.Write(result.Entries); err != nil {
+			return errors.Trace(err)
+		}
+		count += len(result.Entries)
+		if result.Next == "" {
+			break
+		}
+		args.After = result.Next
+	}
+	if err := w.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if count == 0 {
+		ctx.Infof("No status history available.")
+	} else if c.filename != "" {
+		ctx.Infof("Exported %d status history entries to %s.", count, c.filename)
+	}
+	return nil
+}
+
+// historyWriter writes a status history timeline, one page at a time.
+type historyWriter interface {
+	Write([]params.ModelStatusHistoryEntry) error
+	Close() error
+}
+
+func (c *exportStatusHistoryCommand) newHistoryWriter(out io.Writer) historyWriter {
+	switch c.format {
+	case "csv":
+		return &csvHistoryWriter{w: csv.NewWriter(out)}
+	case "json":
+		return &jsonHistoryWriter{out: out}
+	}
+	return &tabularHistoryWriter{tw: output.TabWriter(out), isoTime: c.isoTime}
+}
+
+type tabularHistoryWriter struct {
+	tw            *ansiterm.TabWriter
+	isoTime       bool
+	headerWritten bool
+}
+
+// Write is part of the historyWriter interface.
+func (h *tabularHistoryWriter) Write(entries []params.ModelStatusHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	w := output.Wrapper{h.tw}
+	if !h.headerWritten {
+		w.Println("Time", "Type", "Entity", "Status", "Message")
+		h.headerWritten = true
+	}
+	for _, e := range entries {
+		w.Print(common.FormatTime(e.Since, h.isoTime), e.Kind, e.Entity)
+		w.PrintStatus(status.Status(e.Status))
+		w.Println(e.Info)
+	}
+	return nil
+}
+
+// Close is part of the historyWriter interface. The columns of the
+// table can only be aligned once all the entries have been written.
+func (h *tabularHistoryWriter) Close() error {
+	return h.tw.Flush()
+}
+
+type csvHistoryWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+// Write is part of the historyWriter interface.
+func (h *csvHistoryWriter) Write(entries []params.ModelStatusHistoryEntry) error {
+	if !h.headerWritten {
+		if err := h.w.Write([]string{"time", "type", "entity", "status", "message"}); err != nil {
+			return errors.Trace(err)
+		}
+		h.headerWritten = true
+	}
+	for _, e := range entries {
+		record := []string{formatHistoryTime(e.Since), e.Kind, e.Entity, e.Status, e.Info}
+		if err := h.w.Write(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	h.w.Flush()
+	return errors.Trace(h.w.Error())
+}
+
+// Close is part of the historyWriter interface.
+func (h *csvHistoryWriter) Close() error {
+	return nil
+}
+
+// formattedHistoryEntry is the json representation of a status history
+// entry.
+type formattedHistoryEntry struct {
+	Time    string                 `json:"time"`
+	Type    string                 `json:"type"`
+	Entity  string                 `json:"entity"`
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// jsonHistoryWriter writes the timeline as a json array, without
+// holding the whole timeline in memory.
+type jsonHistoryWriter struct {
+	out     io.Writer
+	written int
+}
+
+// Write is part of the historyWriter interface.
+func (h *jsonHistoryWriter) Write(entries []params.ModelStatusHistoryEntry) error {
+	for _, e := range entries {
+		data, err := json.Marshal(formattedHistoryEntry{
+			Time:    formatHistoryTime(e.Since),
+			Type:    e.Kind,
+			Entity:  e.Entity,
+			Status:  e.Status,
+			Message: e.Info,
+			Data:    e.Data,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sep := ",\n"
+		if h.written == 0 {
+			sep = "[\n"
+		}
+		if _, err := fmt.Fprintf(h.out, "%s%s", sep, data); err != nil {
+			return errors.Trace(err)
+		}
+		h.written++
+	}
+	return nil
+}
+
+// Close is part of the historyWriter interface.
+func (h *jsonHistoryWriter) Close() error {
+	end := "\n]\n"
+	if h.written == 0 {
+		end = "[]\n"
+	}
+	_, err := fmt.Fprint(h.out, end)
+	return errors.Trace(err)
+}
+
+func formatHistoryTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
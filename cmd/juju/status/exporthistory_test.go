@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type ExportStatusHistorySuite struct {
+	testing.IsolationSuite
+	api *fakeExportHistoryAPI
+}
+
+var _ = gc.Suite(&ExportStatusHistorySuite{})
+
+func (s *ExportStatusHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	t0 := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	t2 := t1.Add(time.Minute)
+	s.api = &fakeExportHistoryAPI{
+		pages: []params.ModelStatusHistoryResult{{
+			Entries: []params.ModelStatusHistoryEntry{{
+				Kind: "juju-machine", Entity: "0", Status: "started", Since: &t0,
+			}, {
+				Kind: "application", Entity: "mysql", Status: "maintenance", Info: "installing, please wait", Since: &t1,
+			}},
+			Next: "page-2",
+		}, {
+			Entries: []params.ModelStatusHistoryEntry{{
+				Kind: "workload", Entity: "mysql/0", Status: "active", Info: "ready",
+				Data: map[string]interface{}{"port": "3306"}, Since: &t2,
+			}},
+		}},
+	}
+}
+
+func (s *ExportStatusHistorySuite) newCommand() cmd.Command {
+	return statuscmd.NewTestExportStatusHistoryCommand(s.api)
+}
+
+func (s *ExportStatusHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--format", "yaml"},
+		err:  `unknown format "yaml"`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `--from: expected YYYY-MM-DD or RFC3339 time, got "yesterday"`,
+	}, {
+		args: []string{"--from", "2020-03-02", "--to", "2020-03-01"},
+		err:  `--to must be later than --from`,
+	}, {
+		args: []string{"--type", "workload,unit"},
+		err:  `unexpected status type "unit"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ExportStatusHistorySuite) TestRequests(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"--from", "2020-03-01", "--to", "2020-03-01T18:00:00Z", "--type", "workload, juju-machine")
+	c.Assert(err, jc.ErrorIsNil)
+
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 3, 1, 18, 0, 0, 0, time.UTC)
+	expected := params.ModelStatusHistoryRequest{
+		From:    &from,
+		To:      &to,
+		Kinds:   []string{"workload", "juju-machine"},
+		Exclude: []string{"running update-status hook"},
+	}
+	c.Assert(s.api.requests, gc.HasLen, 2)
+	c.Check(s.api.requests[0], jc.DeepEquals, expected)
+	expected.After = "page-2"
+	c.Check(s.api.requests[1], jc.DeepEquals, expected)
+}
+
+func (s *ExportStatusHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Type          Entity   Status       Message\n"+
+		"2020-03-01 12:00:00Z  juju-machine  0        started      \n"+
+		"2020-03-01 12:01:00Z  application   mysql    maintenance  installing, please wait\n"+
+		"2020-03-01 12:02:00Z  workload      mysql/0  active       ready\n",
+	)
+}
+
+func (s *ExportStatusHistorySuite) TestCSV(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,type,entity,status,message\n"+
+		"2020-03-01T12:00:00Z,juju-machine,0,started,\n"+
+		"2020-03-01T12:01:00Z,application,mysql,maintenance,\"installing, please wait\"\n"+
+		"2020-03-01T12:02:00Z,workload,mysql/0,active,ready\n",
+	)
+}
+
+func (s *ExportStatusHistorySuite) TestJSONToFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "status.json")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "json", "-o", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Exported 3 status history entries to "+path+".\n")
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `[
+{"time":"2020-03-01T12:00:00Z","type":"juju-machine","entity":"0","status":"started","message":""},
+{"time":"2020-03-01T12:01:00Z","type":"application","entity":"mysql","status":"maintenance","message":"installing, please wait"},
+{"time":"2020-03-01T12:02:00Z","type":"workload","entity":"mysql/0","status":"active","message":"ready","data":{"port":"3306"}}
+]
+`)
+}
+
+func (s *ExportStatusHistorySuite) TestEmpty(c *gc.C) {
+	s.api.pages = []params.ModelStatusHistoryResult{{}}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "[]\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No status history available.\n")
+}
+
+func (s *ExportStatusHistorySuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.NotSupportedf("exporting model status history by this controller")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "exporting model status history by this controller not supported")
+}
+
+type fakeExportHistoryAPI struct {
+	pages    []params.ModelStatusHistoryResult
+	requests []params.ModelStatusHistoryRequest
+	err      error
+}
+
+func (f *fakeExportHistoryAPI) ModelStatusHistory(args params.ModelStatusHistoryRequest) (params.ModelStatusHistoryResult, error) {
+	f.requests = append(f.requests, args)
+	if f.err != nil {
+		return params.ModelStatusHistoryResult{}, f.err
+	}
+	page := f.pages[0]
+	f.pages = f.pages[1:]
+	return page, nil
+}
+
+func (*fakeExportHistoryAPI) Close() error {
+	return nil
+}
@@ -231,6 +231,8 @@ type unitStatus struct {
 	ProviderId    string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
 	Branch        string                `json:"branch,omitempty" yaml:"branch,omitempty"`
+
+	MaintenanceMode bool `json:"maintenance-mode,omitempty" yaml:"maintenance-mode,omitempty"`
 }
 
 func (s *formattedStatus) applicationScale(name string) (string, bool) {
@@ -428,6 +428,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Subordinates:       make(map[string]unitStatus),
 		Leader:             info.unit.Leader,
 		Branch:             info.branchRef,
+		MaintenanceMode:    info.unit.MaintenanceMode,
 	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
@@ -199,6 +199,9 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 		if agentDoing != "" {
 			message = fmt.Sprintf("(%s) %s", agentDoing, message)
 		}
+		if u.MaintenanceMode {
+			message = fmt.Sprintf("(maintenance mode) %s", message)
+		}
 		if u.Leader {
 			name += "*"
 		}
@@ -0,0 +1,243 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/waitfor"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	corewaitfor "github.com/juju/juju/core/waitfor"
+)
+
+// waitForTimeoutExitCode is the exit code used when the query
+// is not satisfied before the timeout expires.
+const waitForTimeoutExitCode = 2
+
+const defaultWaitForTimeout = 10 * time.Minute
+
+var defaultWaitForQueries = map[corewaitfor.Kind]string{
+	corewaitfor.KindModel:       "workload-status == active && agent-status == idle",
+	corewaitfor.KindApplication: "workload-status == active && agent-status == idle",
+	corewaitfor.KindUnit:        "workload-status == active && agent-status == idle",
+	corewaitfor.KindMachine:     "status == started",
+}
+
+var waitForDoc = fmt.Sprintf(`
+Blocks until an entity in the model satisfies a query, or the timeout
+expires. The controller re-evaluates the query each time the model
+changes, so no polling is done by the client.
+
+A query is one or more clauses of the form <field> <operator> <value>
+joined by "&&" or "and". Values may be quoted. The operators == and !=
+can be used with every field; <, <=, > and >= can only be used with the
+numeric fields applications, machines, units and charm-revision.
+
+Fields that describe several units, such as the workload status of an
+application, must satisfy the clause for every unit. Such a clause is
+never satisfied while there are no units, so waiting for an application
+to be active does not end before its units have been added.
+
+The fields available for each kind of entity are:
+    model:       %s
+    application: %s
+    unit:        %s
+    machine:     %s
+
+If no query is given, models, applications and units wait for
+    %s
+and machines wait for
+    %s
+
+An entity that does not exist yet is waited for until it appears.
+
+The exit code is 0 if the query was satisfied, 2 if the timeout
+expired first, and 1 for any other error.
+
+Examples:
+    juju wait-for model
+    juju wait-for application mysql --query 'units >= 3 && workload-status == active'
+    juju wait-for unit mysql/0 --query 'charm-revision == 42' --timeout 30m
+    juju wait-for machine 0 --query 'instance-status == running'
+
+See also:
+    status
+    show-status-log
+`[1:],
+	strings.Join(corewaitfor.Fields(corewaitfor.KindModel), ", "),
+	strings.Join(corewaitfor.Fields(corewaitfor.KindApplication), ", "),
+	strings.Join(corewaitfor.Fields(corewaitfor.KindUnit), ", "),
+	strings.Join(corewaitfor.Fields(corewaitfor.KindMachine), ", "),
+	defaultWaitForQueries[corewaitfor.KindModel],
+	defaultWaitForQueries[corewaitfor.KindMachine],
+)
+
+// WaitForAPI is the API surface for the wait-for command.
+type WaitForAPI interface {
+	WaitFor(kind, name, query string, timeout time.Duration) (waitfor.Result, error)
+	Close() error
+}
+
+// NewWaitForCommand returns a command that waits for an entity in
+// the model to satisfy a query.
+func NewWaitForCommand() cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{})
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+	api   WaitForAPI
+	clock clock.Clock
+
+	kind    corewaitfor.Kind
+	name    string
+	query   string
+	timeout time.Duration
+}
+
+func (c *waitForCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "wait-for",
+		Args:    "model | application <name> | unit <name> | machine <id>",
+		Purpose: "Wait for an entity in the model to reach a given state.",
+		Doc:     waitForDoc,
+	})
+}
+
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", "", "Condition to wait for")
+	f.DurationVar(&c.timeout, "timeout", defaultWaitForTimeout, "How long to wait before giving up")
+}
+
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no entity kind specified")
+	}
+	c.kind = corewaitfor.Kind(args[0])
+	args = args[1:]
+	switch c.kind {
+	case corewaitfor.KindModel:
+		if len(args) > 0 {
+			return errors.New("model name should be specified with -m")
+		}
+	case corewaitfor.KindApplication, corewaitfor.KindUnit, corewaitfor.KindMachine:
+		if len(args) == 0 {
+			return errors.Errorf("no %s name specified", c.kind)
+		}
+		c.name, args = args[0], args[1:]
+		if err := c.validateName(); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.Errorf("entity kind %q not valid (expected model, application, unit or machine)", c.kind)
+	}
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if c.query == "" {
+		c.query = defaultWaitForQueries[c.kind]
+	}
+	if _, err := corewaitfor.Parse(c.kind, c.query); err != nil {
+		return errors.Annotate(err, "invalid query")
+	}
+	return nil
+}
+
+func (c *waitForCommand) validateName() error {
+	var valid bool
+	switch c.kind {
+	case corewaitfor.KindApplication:
+		valid = names.IsValidApplication(c.name)
+	case corewaitfor.KindUnit:
+		valid = names.IsValidUnit(c.name)
+	case corewaitfor.KindMachine:
+		valid = names.IsValidMachine(c.name)
+	}
+	if !valid {
+		return errors.NotValidf("%s name %q", c.kind, c.name)
+	}
+	return nil
+}
+
+func (c *waitForCommand) getAPI() (WaitForAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("WaitFor") < 1 {
+		_ = root.Close()
+		return nil, errors.NotSupportedf("wait-for on this controller")
+	}
+	return waitfor.NewFacade(root), nil
+}
+
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	// The controller limits how long a single call blocks,
+	// so keep asking until our own deadline passes.
+	deadline := c.clock.Now().Add(c.timeout)
+	var result waitfor.Result
+	for {
+		remaining := deadline.Sub(c.clock.Now())
+		if remaining <= 0 {
+			break
+		}
+		result, err = client.WaitFor(string(c.kind), c.name, c.query, remaining)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if result.Satisfied {
+			ctx.Infof("%s satisfied %q", c.describeEntity(), c.query)
+			return nil
+		}
+	}
+
+	fmt.Fprintf(ctx.Stderr, "timed out after %v waiting for %s to satisfy %q\n", c.timeout, c.describeEntity(), c.query)
+	if len(result.Values) == 0 && c.kind != corewaitfor.KindModel {
+		fmt.Fprintf(ctx.Stderr, "%s not found\n", c.describeEntity())
+	}
+	fields := make([]string, 0, len(result.Values))
+	for field := range result.Values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		values := append([]string(nil), result.Values[field]...)
+		sort.Strings(values)
+		fmt.Fprintf(ctx.Stderr, "  %s: %s\n", field, strings.Join(values, ", "))
+	}
+	return cmd.NewRcPassthroughError(waitForTimeoutExitCode)
+}
+
+func (c *waitForCommand) describeEntity() string {
+	if c.kind == corewaitfor.KindModel {
+		return "model"
+	}
+	return fmt.Sprintf("%s %q", c.kind, c.name)
+}
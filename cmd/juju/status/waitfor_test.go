@@ -0,0 +1,170 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/waitfor"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type WaitForSuite struct {
+	testing.IsolationSuite
+	api   *fakeWaitForAPI
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 3, 4, 12, 0, 0, 0, time.UTC))
+	s.api = &fakeWaitForAPI{clock: s.clock}
+}
+
+func (s *WaitForSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, statuscmd.NewTestWaitForCommand(s.api, s.clock), args...)
+}
+
+func (s *WaitForSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no entity kind specified",
+	}, {
+		args: []string{"charm", "mysql"},
+		err:  `entity kind "charm" not valid \(expected model, application, unit or machine\)`,
+	}, {
+		args: []string{"model", "default"},
+		err:  "model name should be specified with -m",
+	}, {
+		args: []string{"application"},
+		err:  "no application name specified",
+	}, {
+		args: []string{"unit", "mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"machine", "0", "1"},
+		err:  `unrecognized args: \["1"\]`,
+	}, {
+		args: []string{"machine", "0", "--timeout", "0s"},
+		err:  "timeout must be positive",
+	}, {
+		args: []string{"machine", "0", "--query", "exposed == true"},
+		err:  `invalid query: field "exposed" not valid for machine .*`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WaitForSuite) TestDefaultQuery(c *gc.C) {
+	s.api.results = []waitfor.Result{{Satisfied: true}}
+	ctx, err := s.run(c, "application", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		`application "mysql" satisfied "workload-status == active && agent-status == idle"`+"\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"WaitFor", []interface{}{"application", "mysql", "workload-status == active && agent-status == idle", 10 * time.Minute}},
+		{"Close", nil},
+	})
+}
+
+func (s *WaitForSuite) TestMachineDefaultQuery(c *gc.C) {
+	s.api.results = []waitfor.Result{{Satisfied: true}}
+	_, err := s.run(c, "machine", "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "WaitFor", "machine", "0/lxd/1", "status == started", 10*time.Minute)
+}
+
+func (s *WaitForSuite) TestRetriesUntilSatisfied(c *gc.C) {
+	s.api.results = []waitfor.Result{
+		{Values: map[string][]string{"units": {"1"}}},
+		{Satisfied: true},
+	}
+	s.api.block = 4 * time.Minute
+	_, err := s.run(c, "model", "--query", "units >= 2", "--timeout", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "WaitFor", "model", "", "units >= 2", 10*time.Minute)
+	s.api.CheckCall(c, 1, "WaitFor", "model", "", "units >= 2", 6*time.Minute)
+}
+
+func (s *WaitForSuite) TestTimeout(c *gc.C) {
+	s.api.results = []waitfor.Result{{
+		Values: map[string][]string{
+			"workload-status": {"maintenance", "active"},
+			"units":           {"2"},
+		},
+	}}
+	ctx, err := s.run(c, "application", "mysql", "--timeout", "5m")
+	c.Assert(err, gc.FitsTypeOf, &cmd.RcPassthroughError{})
+	c.Assert(err.(*cmd.RcPassthroughError).Code, gc.Equals, 2)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+timed out after 5m0s waiting for application "mysql" to satisfy "workload-status == active && agent-status == idle"
+  units: 2
+  workload-status: active, maintenance
+`[1:])
+	s.api.CheckCallNames(c, "WaitFor", "Close")
+}
+
+func (s *WaitForSuite) TestTimeoutNotFound(c *gc.C) {
+	s.api.results = []waitfor.Result{{}}
+	ctx, err := s.run(c, "unit", "mysql/0", "--timeout", "1m")
+	c.Assert(err, gc.FitsTypeOf, &cmd.RcPassthroughError{})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+timed out after 1m0s waiting for unit "mysql/0" to satisfy "workload-status == active && agent-status == idle"
+unit "mysql/0" not found
+`[1:])
+}
+
+func (s *WaitForSuite) TestAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "model")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeWaitForAPI struct {
+	testing.Stub
+	clock   *testclock.Clock
+	results []waitfor.Result
+	// block is how long each call takes when not satisfied;
+	// by default each call lasts until its timeout.
+	block time.Duration
+}
+
+func (f *fakeWaitForAPI) WaitFor(kind, name, query string, timeout time.Duration) (waitfor.Result, error) {
+	f.AddCall("WaitFor", kind, name, query, timeout)
+	if err := f.NextErr(); err != nil {
+		return waitfor.Result{}, err
+	}
+	result := f.results[0]
+	if len(f.results) > 1 {
+		f.results = f.results[1:]
+	}
+	if !result.Satisfied {
+		elapsed := timeout
+		if f.block > 0 && f.block < timeout {
+			elapsed = f.block
+		}
+		f.clock.Advance(elapsed)
+	}
+	return result, nil
+}
+
+func (f *fakeWaitForAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
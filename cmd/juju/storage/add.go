@@ -191,6 +191,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 				cons.Pool,
 				&cons.Size,
 				&cons.Count,
+				cons.Snapshot,
 			},
 		})
 	}
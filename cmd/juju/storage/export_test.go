@@ -85,9 +85,37 @@ func NewAttachStorageCommandForTest(new NewEntityAttacherCloserFunc, store jujuc
 	return modelcmd.Wrap(cmd)
 }
 
+func NewResizeStorageCommandForTest(new NewStorageResizerCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
 func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuclient.ClientStore) cmd.Command {
 	cmd := &detachStorageCommand{}
 	cmd.SetClientStore(store)
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewCreateStorageSnapshotCommandForTest(new NewStorageSnapshotterCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &createStorageSnapshotCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewListStorageSnapshotsCommandForTest(new NewStorageSnapshotterCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listStorageSnapshotsCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveStorageSnapshotCommandForTest(new NewStorageSnapshotterCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeStorageSnapshotCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
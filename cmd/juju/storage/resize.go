@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommandWithAPI returns a command
+// used to grow storage instances.
+func NewResizeStorageCommandWithAPI() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = func() (StorageResizerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResizeStorageCommand returns a command
+// used to grow storage instances.
+func NewResizeStorageCommand(new NewStorageResizerCloserFunc) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grow the volume or filesystem of a storage instance to the
+specified size. The size is specified as a number with an
+optional multiplier suffix (M, G, T, P, E, Z, Y); without a
+suffix, the size is in MiB.
+
+Storage can only be grown, and only if its storage provider
+supports resizing. The resize is carried out by the storage
+provisioner, and any units to which the storage is attached
+are notified with the storage-resized hook once it is complete.
+At present only the loop and lvm storage providers can resize
+volumes, and no cloud storage provider supports resizing.
+
+A pending resize that cannot be completed prevents the model
+from being migrated; it can be abandoned with --cancel.
+
+Examples:
+    juju resize-storage pgdata/0 20G
+    juju resize-storage --cancel pgdata/0
+`
+
+	resizeStorageCommandArgs = `<storage> [<size>]`
+)
+
+// resizeStorageCommand grows storage instances.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newStorageResizerCloser NewStorageResizerCloserFunc
+	storageId               string
+	size                    uint64
+	cancel                  bool
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *resizeStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.cancel, "cancel", false, "Cancel a pending resize instead of requesting one")
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if c.cancel {
+		if len(args) != 1 {
+			return errors.New("resize-storage --cancel requires a storage ID")
+		}
+		c.storageId = args[0]
+		return nil
+	}
+	if len(args) != 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.New("size must be greater than zero")
+	}
+	c.storageId = args[0]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows a storage instance to a larger size, or cancels a pending resize.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	resizer, err := c.newStorageResizerCloser()
+	if err != nil {
+		return err
+	}
+	defer resizer.Close()
+
+	if c.cancel {
+		if err := resizer.CancelResize(c.storageId); err != nil {
+			if params.IsCodeUnauthorized(err) {
+				common.PermissionsMessage(ctx.Stderr, "cancel storage resize")
+			}
+			return block.ProcessBlockedError(errors.Annotatef(err, "could not cancel resize of storage %s", c.storageId), block.BlockChange)
+		}
+		ctx.Infof("cancelled resize of %s", c.storageId)
+		return nil
+	}
+	if err := resizer.Resize(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not resize storage %s", c.storageId), block.BlockChange)
+	}
+	ctx.Infof("resizing %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// NewStorageResizerCloserFunc is the type of a function that returns a
+// StorageResizerCloser.
+type NewStorageResizerCloserFunc func() (StorageResizerCloser, error)
+
+// StorageResizerCloser extends StorageResizer with a Closer method.
+type StorageResizerCloser interface {
+	StorageResizer
+	Close() error
+}
+
+// StorageResizer defines an interface for growing the storage
+// instance with the specified ID to the specified size in MiB, and
+// for cancelling a pending resize of it.
+type StorageResizer interface {
+	Resize(string, uint64) error
+	CancelResize(string) error
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ResizeStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) TestResize(c *gc.C) {
+	var fake fakeStorageResizer
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "20G")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageResizerCloser", "Resize", "Close")
+	fake.CheckCall(c, 1, "Resize", "pgdata/0", uint64(20*1024))
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing pgdata/0 to 20480MiB\n")
+}
+
+func (s *ResizeStorageSuite) TestResizeError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, errors.New("foo"))
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "2048")
+	c.Assert(err, gc.ErrorMatches, "could not resize storage pgdata/0: foo")
+}
+
+func (s *ResizeStorageSuite) TestResizeBlocked(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, &params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "2048")
+	c.Assert(err.Error(), jc.Contains, `could not resize storage pgdata/0: nope`)
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *ResizeStorageSuite) TestCancelResize(c *gc.C) {
+	var fake fakeStorageResizer
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--cancel", "pgdata/0")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageResizerCloser", "CancelResize", "Close")
+	fake.CheckCall(c, 1, "CancelResize", "pgdata/0")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "cancelled resize of pgdata/0\n")
+}
+
+func (s *ResizeStorageSuite) TestCancelResizeError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, errors.New("foo"))
+	cmd := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "--cancel", "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "could not cancel resize of storage pgdata/0: foo")
+}
+
+func (s *ResizeStorageSuite) TestResizeInitErrors(c *gc.C) {
+	s.testResizeInitError(c, []string{}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"pgdata/0"}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"pgdata/0", "20G", "30G"}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"pgdata/0", "big"}, "cannot parse size: .*")
+	s.testResizeInitError(c, []string{"pgdata/0", "0"}, "size must be greater than zero")
+	s.testResizeInitError(c, []string{"--cancel"}, "resize-storage --cancel requires a storage ID")
+	s.testResizeInitError(c, []string{"--cancel", "pgdata/0", "20G"}, "resize-storage --cancel requires a storage ID")
+}
+
+func (s *ResizeStorageSuite) testResizeInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewResizeStorageCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageResizer struct {
+	testing.Stub
+}
+
+func (f *fakeStorageResizer) new() (storage.StorageResizerCloser, error) {
+	f.MethodCall(f, "NewStorageResizerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageResizer) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageResizer) Resize(storageId string, size uint64) error {
+	f.MethodCall(f, "Resize", storageId, size)
+	return f.NextErr()
+}
+
+func (f *fakeStorageResizer) CancelResize(storageId string) error {
+	f.MethodCall(f, "CancelResize", storageId)
+	return f.NextErr()
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewCreateStorageSnapshotCommandWithAPI returns a command
+// used to take snapshots of storage instances.
+func NewCreateStorageSnapshotCommandWithAPI() cmd.Command {
+	cmd := &createStorageSnapshotCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	createStorageSnapshotCommandDoc = `
+Takes a point-in-time snapshot of the volume of each of the
+specified storage instances. Filesystem storage can only be
+snapshotted if the filesystem is backed by a volume.
+
+Snapshots are taken by the storage provisioner, and only if the
+storage provider supports them; currently only the loop provider
+does. The ID of each snapshot is printed once it has been
+requested; use "juju storage-snapshots" to see when it has been
+taken.
+
+A snapshot can be used to create new block storage when
+deploying an application or adding storage to a unit, by
+specifying "snapshot:<id>" in the storage constraints. Filesystem
+storage cannot be created from a snapshot.
+
+Snapshots of volumes that are bound to a machine are removed
+along with the machine. A model with snapshots can only be
+destroyed if its storage is destroyed or released with it.
+
+Examples:
+    juju create-storage-snapshot pgdata/0
+
+    # Create new storage from the snapshot with ID 3.
+    juju add-storage postgresql/1 pgdata=snapshot:3
+
+See also:
+    storage-snapshots
+    remove-storage-snapshot
+`
+
+	createStorageSnapshotCommandArgs = `<storage> [<storage> ...]`
+)
+
+// createStorageSnapshotCommand takes snapshots of storage instances.
+type createStorageSnapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	storageIds                  []string
+}
+
+// Init implements Command.Init.
+func (c *createStorageSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one storage ID")
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *createStorageSnapshotCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "Creates snapshots of storage instances.",
+		Doc:     createStorageSnapshotCommandDoc,
+		Args:    createStorageSnapshotCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *createStorageSnapshotCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return err
+	}
+	defer snapshotter.Close()
+
+	results, err := snapshotter.CreateSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "create storage snapshots")
+		}
+		return block.ProcessBlockedError(errors.Annotate(err, "could not create storage snapshots"), block.BlockChange)
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("snapshot %s of %s requested", result.Result, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewStorageSnapshotterCloserFunc is the type of a function that returns
+// a StorageSnapshotterCloser.
+type NewStorageSnapshotterCloserFunc func() (StorageSnapshotterCloser, error)
+
+// StorageSnapshotterCloser extends StorageSnapshotter with a Closer method.
+type StorageSnapshotterCloser interface {
+	StorageSnapshotter
+	Close() error
+}
+
+// StorageSnapshotter defines an interface for creating, listing and
+// removing snapshots of storage instances.
+type StorageSnapshotter interface {
+	CreateSnapshots(storageIds []string) ([]params.StringResult, error)
+	ListSnapshots() ([]params.StorageSnapshotDetails, error)
+	RemoveSnapshots(ids []string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type CreateStorageSnapshotSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CreateStorageSnapshotSuite{})
+
+func (s *CreateStorageSnapshotSuite) TestCreate(c *gc.C) {
+	fake := fakeStorageSnapshotter{
+		createResults: []params.StringResult{{Result: "0"}, {Result: "1"}},
+	}
+	cmd := storage.NewCreateStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "pgdata/0", "pgdata/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "CreateSnapshots", "Close")
+	fake.CheckCall(c, 1, "CreateSnapshots", []string{"pgdata/0", "pgdata/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+snapshot 0 of pgdata/0 requested
+snapshot 1 of pgdata/1 requested
+`[1:])
+}
+
+func (s *CreateStorageSnapshotSuite) TestCreateResultError(c *gc.C) {
+	fake := fakeStorageSnapshotter{
+		createResults: []params.StringResult{
+			{Result: "0"},
+			{Error: &params.Error{Message: "not supported"}},
+		},
+	}
+	command := storage.NewCreateStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "pgdata/0", "data/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+snapshot 0 of pgdata/0 requested
+failed to snapshot data/0: not supported
+`[1:])
+}
+
+func (s *CreateStorageSnapshotSuite) TestCreateError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, errors.New("foo"))
+	cmd := storage.NewCreateStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "could not create storage snapshots: foo")
+}
+
+func (s *CreateStorageSnapshotSuite) TestCreateBlocked(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	cmd := storage.NewCreateStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "pgdata/0")
+	c.Assert(err.Error(), jc.Contains, `could not create storage snapshots: nope`)
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *CreateStorageSnapshotSuite) TestCreateInitErrors(c *gc.C) {
+	cmd := storage.NewCreateStorageSnapshotCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one storage ID")
+}
+
+type fakeStorageSnapshotter struct {
+	testing.Stub
+	createResults []params.StringResult
+	listResults   []params.StorageSnapshotDetails
+	removeResults []params.ErrorResult
+}
+
+func (f *fakeStorageSnapshotter) new() (storage.StorageSnapshotterCloser, error) {
+	f.MethodCall(f, "NewStorageSnapshotterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) CreateSnapshots(storageIds []string) ([]params.StringResult, error) {
+	f.MethodCall(f, "CreateSnapshots", storageIds)
+	return f.createResults, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	f.MethodCall(f, "ListSnapshots")
+	return f.listResults, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) RemoveSnapshots(ids []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "RemoveSnapshots", ids)
+	return f.removeResults, f.NextErr()
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewListStorageSnapshotsCommandWithAPI returns a command
+// used to list storage snapshots.
+func NewListStorageSnapshotsCommandWithAPI() cmd.Command {
+	cmd := &listStorageSnapshotsCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const listStorageSnapshotsCommandDoc = `
+Lists the storage snapshots in the model. A snapshot's provider ID
+and size are shown once the snapshot has been taken.
+
+Examples:
+    juju storage-snapshots
+    juju storage-snapshots --format yaml
+
+See also:
+    create-storage-snapshot
+    remove-storage-snapshot
+`
+
+// SnapshotInfo defines the serialization behaviour for storage snapshots.
+type SnapshotInfo struct {
+	// Volume is the ID of the volume that the snapshot was taken of.
+	Volume string `yaml:"volume" json:"volume"`
+
+	// Storage is the ID of the storage instance that the volume is
+	// assigned to, if the volume still exists.
+	Storage string `yaml:"storage,omitempty" json:"storage,omitempty"`
+
+	// Pool is the name of the storage pool that the snapshot belongs to.
+	Pool string `yaml:"pool" json:"pool"`
+
+	// ProviderSnapshotId is the provider-supplied unique snapshot ID.
+	ProviderSnapshotId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+
+	// Created is the time at which the snapshot was requested.
+	Created string `yaml:"created" json:"created"`
+
+	// Life is the lifecycle state of the snapshot.
+	Life string `yaml:"life,omitempty" json:"life,omitempty"`
+
+	// Status is the status of the snapshot.
+	Status EntityStatus `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// listStorageSnapshotsCommand lists storage snapshots.
+type listStorageSnapshotsCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	out                         cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listStorageSnapshotsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists storage snapshots.",
+		Doc:     listStorageSnapshotsCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listStorageSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listStorageSnapshotsCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return err
+	}
+	defer snapshotter.Close()
+
+	result, err := snapshotter.ListSnapshots()
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "list storage snapshots")
+		}
+		return err
+	}
+	if len(result) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	output, err := formatSnapshotInfo(result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, output)
+}
+
+func formatSnapshotInfo(all []params.StorageSnapshotDetails) (map[string]SnapshotInfo, error) {
+	output := make(map[string]SnapshotInfo)
+	for _, details := range all {
+		volumeId, err := idFromTag(details.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := SnapshotInfo{
+			Volume:             volumeId,
+			Pool:               details.Pool,
+			ProviderSnapshotId: details.SnapshotId,
+			Size:               details.Size,
+			Created:            common.FormatTime(&details.Created, false),
+			Life:               string(details.Life),
+			Status: EntityStatus{
+				details.Status.Status,
+				details.Status.Info,
+				common.FormatTime(details.Status.Since, false),
+			},
+		}
+		if details.StorageTag != "" {
+			storageId, err := idFromTag(details.StorageTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.Storage = storageId
+		}
+		output[details.Id] = info
+	}
+	return output, nil
+}
+
+// formatSnapshotListTabular writes a tabular summary of storage snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Snapshot", "Storage", "Volume", "Provider Id", "Size", "Created", "State", "Message")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	naturalsort.Sort(ids)
+	for _, id := range ids {
+		info := snapshots[id]
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(
+			id, info.Storage, info.Volume, info.ProviderSnapshotId,
+			size, info.Created,
+			string(info.Status.Current), info.Status.Message,
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ListStorageSnapshotsSuite struct {
+	testing.IsolationSuite
+	created time.Time
+	fake    fakeStorageSnapshotter
+}
+
+var _ = gc.Suite(&ListStorageSnapshotsSuite{})
+
+func (s *ListStorageSnapshotsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.created = time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.fake = fakeStorageSnapshotter{
+		listResults: []params.StorageSnapshotDetails{{
+			Id:        "10",
+			VolumeTag: "volume-1",
+			Pool:      "ebs",
+			Created:   s.created,
+			Life:      "alive",
+			Status:    params.EntityStatus{Status: status.Pending, Since: &s.created},
+		}, {
+			Id:         "2",
+			VolumeTag:  "volume-0",
+			StorageTag: "storage-pgdata-0",
+			Pool:       "ebs",
+			SnapshotId: "snap-2",
+			Size:       1024,
+			Created:    s.created,
+			Life:       "alive",
+			Status:     params.EntityStatus{Status: status.Available, Since: &s.created},
+		}},
+	}
+}
+
+func (s *ListStorageSnapshotsSuite) TestListTabular(c *gc.C) {
+	cmd := storage.NewListStorageSnapshotsCommandForTest(s.fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "ListSnapshots", "Close")
+
+	// The width of the Created column depends on the local timezone.
+	created := common.FormatTime(&s.created, false)
+	header := fmt.Sprintf("%-*s", len(created), "Created")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Snapshot  Storage   Volume  Provider Id  Size    "+header+"  State      Message\n"+
+		"2         pgdata/0  0       snap-2       1.0GiB  "+created+"  available  \n"+
+		"10                  1                            "+created+"  pending    \n",
+	)
+}
+
+func (s *ListStorageSnapshotsSuite) TestListYAML(c *gc.C) {
+	cmd := storage.NewListStorageSnapshotsCommandForTest(s.fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var result map[string]storage.SnapshotInfo
+	err = goyaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	created := common.FormatTime(&s.created, false)
+	c.Assert(result, jc.DeepEquals, map[string]storage.SnapshotInfo{
+		"10": {
+			Volume:  "1",
+			Pool:    "ebs",
+			Created: created,
+			Life:    "alive",
+			Status:  storage.EntityStatus{Current: status.Pending, Since: created},
+		},
+		"2": {
+			Volume:             "0",
+			Storage:            "pgdata/0",
+			Pool:               "ebs",
+			ProviderSnapshotId: "snap-2",
+			Size:               1024,
+			Created:            created,
+			Life:               "alive",
+			Status:             storage.EntityStatus{Current: status.Available, Since: created},
+		},
+	})
+}
+
+func (s *ListStorageSnapshotsSuite) TestListEmpty(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	cmd := storage.NewListStorageSnapshotsCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *ListStorageSnapshotsSuite) TestListError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, errors.New("foo"))
+	cmd := storage.NewListStorageSnapshotsCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "foo")
+}
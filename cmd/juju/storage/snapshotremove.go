@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRemoveStorageSnapshotCommandWithAPI returns a command
+// used to remove storage snapshots.
+func NewRemoveStorageSnapshotCommandWithAPI() cmd.Command {
+	cmd := &removeStorageSnapshotCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	removeStorageSnapshotCommandDoc = `
+Removes storage snapshots from the model, destroying them in the
+storage provider. Specify one or more snapshot IDs, as output by
+"juju storage-snapshots".
+
+Storage that has already been created from a snapshot is not
+affected by the snapshot's removal. A snapshot cannot be removed
+while an application's storage constraints refer to it, or while
+storage is still waiting to be created from it.
+
+Examples:
+    juju remove-storage-snapshot 3
+
+See also:
+    create-storage-snapshot
+    storage-snapshots
+`
+
+	removeStorageSnapshotCommandArgs = `<snapshot> [<snapshot> ...]`
+)
+
+// removeStorageSnapshotCommand removes storage snapshots.
+type removeStorageSnapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	ids                         []string
+}
+
+// Init implements Command.Init.
+func (c *removeStorageSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires at least one snapshot ID")
+	}
+	c.ids = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *removeStorageSnapshotCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Purpose: "Removes storage snapshots.",
+		Doc:     removeStorageSnapshotCommandDoc,
+		Args:    removeStorageSnapshotCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *removeStorageSnapshotCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return err
+	}
+	defer snapshotter.Close()
+
+	results, err := snapshotter.RemoveSnapshots(c.ids)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "remove storage snapshots")
+		}
+		return block.ProcessBlockedError(errors.Annotate(err, "could not remove storage snapshots"), block.BlockRemove)
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to remove snapshot %s: %s", c.ids[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removing snapshot %s", c.ids[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
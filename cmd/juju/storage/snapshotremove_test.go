@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type RemoveStorageSnapshotSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RemoveStorageSnapshotSuite{})
+
+func (s *RemoveStorageSnapshotSuite) TestRemove(c *gc.C) {
+	fake := fakeStorageSnapshotter{
+		removeResults: []params.ErrorResult{{}, {}},
+	}
+	cmd := storage.NewRemoveStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "0", "0/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "RemoveSnapshots", "Close")
+	fake.CheckCall(c, 1, "RemoveSnapshots", []string{"0", "0/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing snapshot 0
+removing snapshot 0/1
+`[1:])
+}
+
+func (s *RemoveStorageSnapshotSuite) TestRemoveResultError(c *gc.C) {
+	fake := fakeStorageSnapshotter{
+		removeResults: []params.ErrorResult{
+			{Error: &params.Error{Message: `volume snapshot "1" not found`}},
+		},
+	}
+	command := storage.NewRemoveStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "failed to remove snapshot 1: volume snapshot \"1\" not found\n")
+}
+
+func (s *RemoveStorageSnapshotSuite) TestRemoveBlocked(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	cmd := storage.NewRemoveStorageSnapshotCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, "0")
+	c.Assert(err.Error(), jc.Contains, `could not remove storage snapshots: nope`)
+	c.Assert(err.Error(), jc.Contains, "All operations that remove machines, applications, units or\nrelations have been disabled for the current model.")
+}
+
+func (s *RemoveStorageSnapshotSuite) TestRemoveInitErrors(c *gc.C) {
+	cmd := storage.NewRemoveStorageSnapshotCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires at least one snapshot ID")
+}
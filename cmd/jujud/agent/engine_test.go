@@ -142,6 +142,7 @@ var (
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
 		"disk-manager",
+		"egress-firewaller",
 		"fan-configurer",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
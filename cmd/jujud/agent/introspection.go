@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"io"
 	"runtime"
 	"sync"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/introspection"
+	"github.com/juju/juju/worker/raft"
 )
 
 // DefaultIntrospectionSocketName returns the socket name to use for the
@@ -37,6 +39,8 @@ type introspectionConfig struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	PresenceRecorder   presence.Recorder
+	LeaseReporter      introspection.LeaseReporter
+	RaftReporter       introspection.RaftReporter
 	NewSocketName      func(names.Tag) string
 	WorkerFunc         func(config introspection.Config) (worker.Worker, error)
 }
@@ -62,6 +66,8 @@ func startIntrospection(cfg introspectionConfig) error {
 		MachineLock:        cfg.MachineLock,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 		Presence:           cfg.PresenceRecorder,
+		Leases:             cfg.LeaseReporter,
+		Raft:               cfg.RaftReporter,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -115,3 +121,45 @@ func (h *statePoolIntrospectionReporter) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// raftIntrospectionReporter wraps a (possibly nil) raft.Worker,
+// reporting on the raft node it manages or returning an error if it
+// is nil.
+type raftIntrospectionReporter struct {
+	mu     sync.Mutex
+	worker *raft.Worker
+}
+
+func (h *raftIntrospectionReporter) set(worker *raft.Worker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.worker = worker
+}
+
+func (h *raftIntrospectionReporter) get() (*raft.Worker, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.worker == nil {
+		return nil, errors.New("agent is not running raft")
+	}
+	return h.worker, nil
+}
+
+// RaftStats is part of the introspection.RaftReporter interface.
+func (h *raftIntrospectionReporter) RaftStats() (map[string]string, error) {
+	worker, err := h.get()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker.Stats()
+}
+
+// WriteRaftSnapshot is part of the introspection.RaftReporter interface.
+func (h *raftIntrospectionReporter) WriteRaftSnapshot(w io.Writer) error {
+	worker, err := h.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = worker.WriteSnapshot(w)
+	return errors.Trace(err)
+}
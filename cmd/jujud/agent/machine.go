@@ -42,6 +42,7 @@ import (
 	apideployer "github.com/juju/juju/api/deployer"
 	apimachiner "github.com/juju/juju/api/machiner"
 	apiprovisioner "github.com/juju/juju/api/provisioner"
+	jujuapiserver "github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
@@ -60,6 +61,7 @@ import (
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
@@ -93,8 +95,9 @@ var (
 	jujuRun           = paths.MustSucceed(paths.JujuRun(series.MustHostSeries()))
 	jujuDumpLogs      = paths.MustSucceed(paths.JujuDumpLogs(series.MustHostSeries()))
 	jujuIntrospect    = paths.MustSucceed(paths.JujuIntrospect(series.MustHostSeries()))
+	jujuHookTrace     = paths.MustSucceed(paths.JujuHookTrace(series.MustHostSeries()))
 	jujuUpdateSeries  = paths.MustSucceed(paths.JujuUpdateSeries(series.MustHostSeries()))
-	jujudSymlinks     = []string{jujuRun, jujuDumpLogs, jujuIntrospect, jujuUpdateSeries}
+	jujudSymlinks     = []string{jujuRun, jujuDumpLogs, jujuIntrospect, jujuHookTrace, jujuUpdateSeries}
 	caasJujudSymlinks = []string{jujuRun, jujuDumpLogs, jujuIntrospect}
 
 	// The following are defined as variables to allow the tests to
@@ -327,6 +330,7 @@ func NewMachineAgent(
 		prometheusRegistry:          prometheusRegistry,
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
+		charmMetricsCollector:       jujuapiserver.NewCharmMetricsCollector(),
 		preUpgradeSteps:             preUpgradeSteps,
 		isCaasAgent:                 isCaasAgent,
 	}
@@ -355,6 +359,12 @@ func (a *MachineAgent) registerPrometheusCollectors() error {
 	if err := a.prometheusRegistry.Register(a.mongoDialCollector); err != nil {
 		return errors.Annotate(err, "registering mongo dial collector")
 	}
+	// The charm metrics collector is unchecked, so it can't be
+	// unregistered; it is registered here rather than by the
+	// apiserver worker, which may be restarted.
+	if err := a.prometheusRegistry.Register(a.charmMetricsCollector); err != nil {
+		return errors.Annotate(err, "registering charm metrics collector")
+	}
 	return nil
 }
 
@@ -389,6 +399,7 @@ type MachineAgent struct {
 	prometheusRegistry         *prometheus.Registry
 	mongoTxnCollector          *mongometrics.TxnCollector
 	mongoDialCollector         *mongometrics.DialCollector
+	charmMetricsCollector      *jujuapiserver.CharmMetricsCollector
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
 
 	// Only API servers have hubs. This is temporary until the apiserver and
@@ -560,12 +571,20 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 		// which is set to the current StatePool managed by the state
 		// tracker in controller agents.
 		var statePoolReporter statePoolIntrospectionReporter
+		// raftReporter is an introspection.RaftReporter, which is set
+		// to the raft worker running in controller agents.
+		var raftReporter raftIntrospectionReporter
+		// leaseFSM is the raft lease store, which is created here so
+		// that the introspection worker may report on it.
+		leaseFSM := raftlease.NewFSM()
 		registerIntrospectionHandlers := func(handle func(path string, h http.Handler)) {
 			introspection.RegisterHTTPHandlers(introspection.ReportSources{
 				DependencyEngine:   engine,
 				StatePool:          &statePoolReporter,
 				PubSub:             pubsubReporter,
 				PrometheusGatherer: a.prometheusRegistry,
+				Leases:             leaseFSM,
+				Raft:               &raftReporter,
 			}, handle)
 		}
 
@@ -588,6 +607,7 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			Clock:                   clock.WallClock,
 			ValidateMigration:       a.validateMigration,
 			PrometheusRegisterer:    a.prometheusRegistry,
+			CharmMetricsCollector:   a.charmMetricsCollector,
 			CentralHub:              a.centralHub,
 			PubSubReporter:          pubsubReporter,
 			PresenceRecorder:        presenceRecorder,
@@ -601,6 +621,8 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			TransactionPruneInterval:          time.Hour,
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.set,
+			LeaseFSM:                          leaseFSM,
+			SetRaftWorker:                     raftReporter.set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
 			MuxShutdownWait:                   1 * time.Minute,
@@ -627,6 +649,8 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			NewSocketName:      a.newIntrospectionSocketName,
 			PrometheusGatherer: a.prometheusRegistry,
 			PresenceRecorder:   presenceRecorder,
+			LeaseReporter:      leaseFSM,
+			RaftReporter:       &raftReporter,
 			WorkerFunc:         introspection.NewWorker,
 		}); err != nil {
 			// If the introspection worker failed to start, we just log error
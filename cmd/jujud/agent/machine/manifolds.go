@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/crosscontroller"
 	apideployer "github.com/juju/juju/api/deployer"
+	jujuapiserver "github.com/juju/juju/apiserver"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	containerbroker "github.com/juju/juju/container/broker"
@@ -44,6 +45,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
@@ -53,6 +55,7 @@ import (
 	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
+	"github.com/juju/juju/worker/egressfirewaller"
 	"github.com/juju/juju/worker/externalcontrollerupdater"
 	"github.com/juju/juju/worker/fanconfigurer"
 	"github.com/juju/juju/worker/featureflag"
@@ -200,6 +203,10 @@ type ManifoldsConfig struct {
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
 
+	// CharmMetricsCollector is the collector of the metrics published
+	// by charms, which the apiserver worker serves metrics through.
+	CharmMetricsCollector *jujuapiserver.CharmMetricsCollector
+
 	// CentralHub is the primary hub that exists in the apiserver.
 	CentralHub *pubsub.StructuredHub
 
@@ -238,6 +245,17 @@ type ManifoldsConfig struct {
 	// worker running outside of the dependency engine.
 	SetStatePool func(*state.StatePool)
 
+	// LeaseFSM is the raft lease store shared by the raft and lease
+	// manager workers. It is passed in so that it may also be reported
+	// on by the introspection worker. If it is nil, a new FSM is
+	// created.
+	LeaseFSM *raftlease.FSM
+
+	// SetRaftWorker is used by the raft worker for informing the agent
+	// of the raft node that it manages, so we can pass it to the
+	// introspection worker running outside of the dependency engine.
+	SetRaftWorker func(*raft.Worker)
+
 	// RegisterIntrospectionHTTPHandlers is a function that calls the
 	// supplied function to register introspection HTTP handlers. The
 	// function will be passed a path and a handler; the function may
@@ -308,7 +326,10 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 	agentTag := agentConfig.Tag()
 	controllerTag := agentConfig.Controller()
 
-	leaseFSM := raftlease.NewFSM()
+	leaseFSM := config.LeaseFSM
+	if leaseFSM == nil {
+		leaseFSM = raftlease.NewFSM()
+	}
 
 	manifolds := dependency.Manifolds{
 		// The agent manifold references the enclosing agent, and is the
@@ -708,6 +729,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			RaftTransportName: raftTransportName,
 
 			PrometheusRegisterer:              config.PrometheusRegisterer,
+			CharmMetricsCollector:             config.CharmMetricsCollector,
 			RegisterIntrospectionHTTPHandlers: config.RegisterIntrospectionHTTPHandlers,
 			Hub:                               config.CentralHub,
 			Presence:                          config.PresenceRecorder,
@@ -764,6 +786,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Logger:               loggo.GetLogger("juju.worker.raft"),
 			PrometheusRegisterer: config.PrometheusRegisterer,
 			NewWorker:            raft.NewWorker,
+			SetWorker:            config.SetRaftWorker,
 		})),
 
 		raftFlagName: raftflag.Manifold(raftflag.ManifoldConfig{
@@ -874,6 +897,16 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Clock:         config.Clock,
 		})),
 
+		// The egress firewaller worker enforces the egress rules of
+		// the machine with iptables, when its provider can't.
+		egressFirewallerName: ifNotMigrating(egressfirewaller.Manifold(egressfirewaller.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     egressfirewaller.NewFacade,
+			NewWorker:     egressfirewaller.NewWorker,
+			RunCommand:    egressfirewaller.RunCommand,
+		})),
+
 		certificateUpdaterName: ifFullyUpgraded(certupdater.Manifold(certupdater.ManifoldConfig{
 			AgentName:                agentName,
 			StateName:                stateName,
@@ -996,6 +1029,18 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewBrokerFunc: config.NewBrokerFunc,
 			NewTracker:    lxdbroker.NewWorkerTracker,
 		})),
+		// The backup scheduler takes backups of the controller on the
+		// schedule set in controller config. Backups aren't supported
+		// on kubernetes controllers, so it only runs here.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName:            agentName,
+				ClockName:            clockName,
+				StateName:            stateName,
+				PrometheusRegisterer: config.PrometheusRegisterer,
+				NewWorker:            backupscheduler.NewWorkerShim,
+			},
+		))),
 		instanceMutaterName: ifNotMigrating(instancemutater.MachineManifold(instancemutater.MachineManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
@@ -1143,6 +1188,7 @@ const (
 	machineActionName             = "machine-action-runner"
 	hostKeyReporterName           = "host-key-reporter"
 	fanConfigurerName             = "fan-configurer"
+	egressFirewallerName          = "egress-firewaller"
 	externalControllerUpdaterName = "external-controller-updater"
 	globalClockUpdaterName        = "global-clock-updater"
 	leaseClockUpdaterName         = "lease-clock-updater"
@@ -1150,6 +1196,7 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelCacheInitializedFlagName = "model-cache-initialized-flag"
@@ -65,6 +65,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"backup-scheduler",
 			"broker-tracker",
 			"central-hub",
 			"certificate-updater",
@@ -72,6 +73,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"clock",
 			"controller-port",
 			"disk-manager",
+			"egress-firewaller",
 			"external-controller-updater",
 			"fan-configurer",
 			"global-clock-updater",
@@ -306,6 +308,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"upgrade-database-runner",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"transaction-pruner",
 	)
@@ -469,6 +472,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-updater": {
@@ -509,6 +529,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"egress-firewaller": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"broker-tracker": {
 		"agent",
 		"api-caller",
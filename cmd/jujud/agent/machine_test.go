@@ -793,7 +793,7 @@ func (s *MachineLegacyLeasesSuite) TestMachineAgentUninstall(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// juju-* symlinks should have been removed on termination.
-	for _, link := range []string{jujuRun, jujuDumpLogs, jujuIntrospect} {
+	for _, link := range []string{jujuRun, jujuDumpLogs, jujuIntrospect, jujuHookTrace} {
 		_, err = os.Stat(utils.EnsureBaseDir(a.rootDir, link))
 		c.Assert(err, jc.Satisfies, os.IsNotExist)
 	}
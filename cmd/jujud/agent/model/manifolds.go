@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
@@ -294,7 +295,16 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
+				Kind:   logfwd.SinkSyslog,
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:   "juju-log-forward-json",
+				Kind:   logfwd.SinkJSON,
+				OpenFn: sinks.OpenJSON,
+			}, {
+				Name:   "juju-log-forward-loki",
+				Kind:   logfwd.SinkLoki,
+				OpenFn: sinks.OpenLoki,
 			}},
 			Logger: config.LoggingContext.GetLogger("juju.worker.logforwarder"),
 		})),
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
+	"github.com/juju/juju/worker/debugshell"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/leadership"
@@ -42,6 +43,7 @@ import (
 	"github.com/juju/juju/worker/proxyupdater"
 	"github.com/juju/juju/worker/retrystrategy"
 	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/upgrader"
 	"github.com/juju/juju/worker/upgradesteps"
 )
@@ -125,6 +127,10 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		return err
 	}
 
+	// debugSessions holds the debug sessions started by the debug
+	// shell worker until the uniter runs them.
+	debugSessions := debug.NewRemoteSessions()
+
 	return dependency.Manifolds{
 
 		// The agent manifold references the enclosing agent, and is the
@@ -313,6 +319,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:             agentName,
 			APICallerName:         apiCallerName,
 			MachineLock:           config.MachineLock,
+			DebugSessions:         debugSessions,
 			Clock:                 config.Clock,
 			LeadershipTrackerName: leadershipTrackerName,
 			CharmDirName:          charmDirName,
@@ -320,6 +327,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
 		})),
 
+		// The debug shell worker waits for operators to debug the
+		// unit's hooks over the API, and has the uniter run a shell
+		// in place of the next matching hook.
+		debugShellName: ifNotMigrating(debugshell.Manifold(debugshell.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Sessions:      debugSessions,
+			NewFacade:     debugshell.NewFacade,
+			NewWorker:     debugshell.NewWorker,
+		})),
+
 		// TODO (mattyw) should be added to machine agent.
 		metricSpoolName: ifNotMigrating(spool.Manifold(spool.ManifoldConfig{
 			AgentName: agentName,
@@ -394,6 +412,7 @@ const (
 	leadershipTrackerName = "leadership-tracker"
 	hookRetryStrategyName = "hook-retry-strategy"
 	uniterName            = "uniter"
+	debugShellName        = "debug-shell"
 
 	metricSpoolName   = "metric-spool"
 	meterStatusName   = "meter-status"
@@ -50,6 +50,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"leadership-tracker",
 		"hook-retry-strategy",
 		"uniter",
+		"debug-shell",
 		"metric-spool",
 		"meter-status",
 		"metric-collect",
@@ -147,6 +148,17 @@ var expectedUnitManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
+	"debug-shell": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"hook-retry-strategy": {
 		"agent",
 		"api-caller",
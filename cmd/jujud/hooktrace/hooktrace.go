@@ -0,0 +1,220 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	jujucmd "github.com/juju/juju/cmd"
+	cmdutil "github.com/juju/juju/cmd/jujud/util"
+	corenames "github.com/juju/juju/juju/names"
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
+)
+
+// NewCommand returns a new Command instance which implements the
+// "juju-hook-trace" command.
+func NewCommand() cmd.Command {
+	return &hookTraceCommand{}
+}
+
+type hookTraceCommand struct {
+	cmd.CommandBase
+	dataDir  string
+	charmDir string
+	replay   bool
+	unitTag  names.UnitTag
+	traceID  string
+}
+
+const hookTraceCommandDoc = `
+Inspect and replay the hook executions traced by a unit agent
+running on this machine. Hooks are only traced if the unit agent's
+configuration sets HOOK_TRACE_LIMIT to the number of traces to keep.
+
+With a single unit argument, the IDs of the unit's traces are
+listed, oldest first. e.g.
+
+    juju-hook-trace mysql/0
+
+Given a trace ID, the trace is shown. e.g.
+
+    juju-hook-trace mysql/0 20200102-030405.000000-install
+
+With --replay, the traced hook is run again with the environment it
+originally ran with. Hook tool commands are answered with the results
+recorded in the trace rather than acting on the model, but everything
+else the hook does, such as installing packages, writing files or
+restarting services, really happens again on this machine. Do not
+replay hooks on machines running production workloads. Differences
+between the replayed and the traced execution are reported. e.g.
+
+    juju-hook-trace --replay mysql/0 20200102-030405.000000-install
+
+By default the hook is run from the unit's deployed charm; use
+--charm-dir to replay it against a modified copy of the charm.
+
+Traces do not hold the values of environment variables that may be
+credentials, nor the output of hook tools that read configuration,
+relation, leadership or credential data. These are shown, and
+replayed, as "<redacted>".
+`
+
+// Info implements cmd.Command.
+func (c *hookTraceCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    corenames.JujuHookTrace,
+		Args:    "<unit> [<trace-id>]",
+		Purpose: "inspect and replay traced hook executions",
+		Doc:     hookTraceCommandDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *hookTraceCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.dataDir, "data-dir", cmdutil.DataDir, "Juju base data directory")
+	f.StringVar(&c.charmDir, "charm-dir", "", "charm directory to replay the hook from (defaults to the unit's charm)")
+	f.BoolVar(&c.replay, "replay", false, "replay the traced hook")
+}
+
+// Init implements cmd.Command.
+func (c *hookTraceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	c.unitTag, args = names.NewUnitTag(args[0]), args[1:]
+	if len(args) > 0 {
+		c.traceID, args = args[0], args[1:]
+	}
+	if c.traceID == "" && (c.replay || c.charmDir != "") {
+		return errors.New("a trace ID must be specified to replay a hook")
+	}
+	if c.charmDir != "" && !c.replay {
+		return errors.New("--charm-dir may only be specified with --replay")
+	}
+	return c.CommandBase.Init(args)
+}
+
+// Run implements cmd.Command.
+func (c *hookTraceCommand) Run(ctx *cmd.Context) error {
+	paths := uniter.NewPaths(c.dataDir, c.unitTag, nil)
+	store := hooktrace.NewStore(paths.State.HookTracesDir, 0, nil)
+	if c.traceID == "" {
+		ids, err := store.List()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(ids) == 0 {
+			ctx.Infof("No hook traces for %s", c.unitTag.Id())
+		}
+		for _, id := range ids {
+			fmt.Fprintln(ctx.Stdout, id)
+		}
+		return nil
+	}
+
+	trace, err := store.Load(c.traceID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.replay {
+		writeTrace(ctx.Stdout, trace)
+		return nil
+	}
+
+	charmDir := c.charmDir
+	if charmDir == "" {
+		charmDir = paths.State.CharmDir
+	}
+	socketDir, err := ioutil.TempDir("", "juju-hook-trace")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(socketDir)
+
+	result, err := hooktrace.Replay(hooktrace.ReplayParams{
+		Trace:    trace,
+		CharmDir: charmDir,
+		Socket:   sockets.Socket{Network: "unix", Address: filepath.Join(socketDir, "jujuc.socket")},
+		Stdout:   ctx.Stdout,
+		Stderr:   ctx.Stderr,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !result.Diverged(trace) {
+		ctx.Infof("Replay of %s matched the trace", trace.ID)
+		return nil
+	}
+	if result.ExitCode != trace.ExitCode {
+		ctx.Infof("Exit code %d differs from traced exit code %d", result.ExitCode, trace.ExitCode)
+	}
+	for _, call := range result.Unexpected {
+		ctx.Infof("Unexpected hook tool call: %s", call)
+	}
+	for _, call := range result.Unused {
+		ctx.Infof("Traced hook tool call not made: %s", call)
+	}
+	return cmd.NewRcPassthroughError(1)
+}
+
+// writeTrace writes a readable description of the trace to w.
+func writeTrace(w io.Writer, trace *hooktrace.Trace) {
+	fmt.Fprintf(w, "id: %s\n", trace.ID)
+	fmt.Fprintf(w, "unit: %s\n", trace.Unit)
+	fmt.Fprintf(w, "hook: %s\n", trace.Hook)
+	if trace.Relation != "" {
+		fmt.Fprintf(w, "relation: %s\n", trace.Relation)
+	}
+	if trace.RemoteUnit != "" {
+		fmt.Fprintf(w, "remote-unit: %s\n", trace.RemoteUnit)
+	}
+	fmt.Fprintf(w, "started: %s\n", trace.Started.Format("2006-01-02 15:04:05.000000Z07:00"))
+	fmt.Fprintf(w, "duration: %s\n", trace.Duration)
+	fmt.Fprintf(w, "exit-code: %d\n", trace.ExitCode)
+	if trace.Error != "" {
+		fmt.Fprintf(w, "error: %s\n", trace.Error)
+	}
+	fmt.Fprintln(w, "env:")
+	for _, kv := range trace.Env {
+		fmt.Fprintf(w, "  %s\n", kv)
+	}
+	fmt.Fprintln(w, "tool-calls:")
+	for _, call := range trace.ToolCalls {
+		fmt.Fprintf(w, "- command: %s\n", call)
+		if call.Error != "" {
+			fmt.Fprintf(w, "  error: %s\n", call.Error)
+			continue
+		}
+		fmt.Fprintf(w, "  code: %d\n", call.Code)
+		writeOutput(w, "stdin", call.Stdin)
+		writeOutput(w, "stdout", call.Stdout)
+		writeOutput(w, "stderr", call.Stderr)
+	}
+}
+
+func writeOutput(w io.Writer, name string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "  %s: |\n", name)
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/cmd/jujud/hooktrace"
+	"github.com/juju/juju/worker/uniter"
+	tracestore "github.com/juju/juju/worker/uniter/runner/hooktrace"
+)
+
+type hookTraceSuite struct {
+	testing.IsolationSuite
+
+	dataDir string
+	paths   uniter.Paths
+}
+
+var _ = gc.Suite(&hookTraceSuite{})
+
+const traceID = "20200102-030405.000000-install"
+
+func (s *hookTraceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+	s.paths = uniter.NewPaths(s.dataDir, names.NewUnitTag("mysql/0"), nil)
+	store := tracestore.NewStore(s.paths.State.HookTracesDir, 5, nil)
+	err := store.Save(&tracestore.Trace{
+		ID:       traceID,
+		Unit:     "mysql/0",
+		Hook:     "install",
+		Started:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Duration: 2 * time.Second,
+		Env:      []string{"JUJU_UNIT_NAME=mysql/0"},
+		ToolCalls: []tracestore.ToolCall{{
+			Command: "config-get",
+			Args:    []string{"port"},
+			Stdout:  []byte("8080\n"),
+		}},
+		ExitCode: 1,
+		Error:    "exit status 1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *hookTraceSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	args = append([]string{"--data-dir", s.dataDir}, args...)
+	return cmdtesting.RunCommand(c, hooktrace.NewCommand(), args...)
+}
+
+func (s *hookTraceSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no unit specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"--replay", "mysql/0"},
+		err:  "a trace ID must be specified to replay a hook",
+	}, {
+		args: []string{"--charm-dir", "/charm", "mysql/0", traceID},
+		err:  "--charm-dir may only be specified with --replay",
+	}, {
+		args: []string{"mysql/0", traceID, "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		err := cmdtesting.InitCommand(hooktrace.NewCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *hookTraceSuite) TestList(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, traceID+"\n")
+}
+
+func (s *hookTraceSuite) TestListNoTraces(c *gc.C) {
+	ctx, err := s.run(c, "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No hook traces for mysql/1\n")
+}
+
+func (s *hookTraceSuite) TestShow(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", traceID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+id: 20200102-030405.000000-install
+unit: mysql/0
+hook: install
+started: 2020-01-02 03:04:05.000000Z
+duration: 2s
+exit-code: 1
+error: exit status 1
+env:
+  JUJU_UNIT_NAME=mysql/0
+tool-calls:
+- command: config-get port
+  code: 0
+  stdout: |
+    <redacted>
+`[1:])
+}
+
+func (s *hookTraceSuite) TestShowNotFound(c *gc.C) {
+	_, err := s.run(c, "mysql/0", "20200102-030405.000000-start")
+	c.Assert(err, gc.ErrorMatches, `hook trace "20200102-030405.000000-start" not found`)
+}
+
+func (s *hookTraceSuite) writeHook(c *gc.C, charmDir, script string) {
+	err := os.MkdirAll(filepath.Join(charmDir, "hooks"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(charmDir, "hooks", "install"), []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *hookTraceSuite) TestReplayMatches(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replaying hooks uses a shell script")
+	}
+	s.writeHook(c, s.paths.State.CharmDir, "#!/bin/sh\necho $JUJU_UNIT_NAME\nexit 1\n")
+	ctx, err := s.run(c, "--replay", "mysql/0", traceID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "mysql/0\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Replay of "+traceID+" matched the trace\n")
+}
+
+func (s *hookTraceSuite) TestReplayDiverged(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replaying hooks uses a shell script")
+	}
+	charmDir := c.MkDir()
+	s.writeHook(c, charmDir, "#!/bin/sh\nexit 0\n")
+	ctx, err := s.run(c, "--replay", "--charm-dir", charmDir, "mysql/0", traceID)
+	c.Assert(err, jc.Satisfies, cmd.IsRcPassthroughError)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Exit code 0 differs from traced exit code 1
+Traced hook tool call not made: config-get port
+`[1:])
+}
@@ -28,6 +28,7 @@ import (
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
 	"github.com/juju/juju/cmd/jujud/agent/caasoperator"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
+	"github.com/juju/juju/cmd/jujud/hooktrace"
 	"github.com/juju/juju/cmd/jujud/introspect"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	components "github.com/juju/juju/component/all"
@@ -303,6 +304,8 @@ func Main(args []string) int {
 		code = cmd.Main(dumplogs.NewCommand(), ctx, args[1:])
 	case jujunames.JujuIntrospect:
 		code = cmd.Main(&introspect.IntrospectCommand{}, ctx, args[1:])
+	case jujunames.JujuHookTrace:
+		code = cmd.Main(hooktrace.NewCommand(), ctx, args[1:])
 	default:
 		code, err = hookToolMain(commandName, ctx, args)
 	}
@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"net/url"
+	"path/filepath"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// BackupStorageController keeps backup archives in the
+	// controller's own database.
+	BackupStorageController = "controller"
+
+	// BackupStorageLocal keeps backup archives in a directory on the
+	// controller machines.
+	BackupStorageLocal = "local"
+
+	// BackupStorageS3 keeps backup archives in an S3-compatible object
+	// store.
+	BackupStorageS3 = "s3"
+)
+
+// BackupStorageConfig holds the settings for where the controller keeps
+// backup archives.
+type BackupStorageConfig struct {
+	// Type is one of BackupStorageController, BackupStorageLocal or
+	// BackupStorageS3.
+	Type string
+
+	// Path is the directory archives are kept in by local storage.
+	Path string
+
+	// S3Endpoint is the URL of the object store used by s3 storage.
+	S3Endpoint string
+
+	// S3Region is the region of the object store.
+	S3Region string
+
+	// S3Bucket is the bucket archives are kept in.
+	S3Bucket string
+
+	// S3AccessKey and S3SecretKey are the credentials used to
+	// authenticate with the object store.
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// Validate checks that the settings needed by the selected storage
+// type are present.
+func (cfg BackupStorageConfig) Validate() error {
+	switch cfg.Type {
+	case BackupStorageController:
+	case BackupStorageLocal:
+		if cfg.Path == "" {
+			return errors.NotValidf("empty %s", BackupStoragePath)
+		}
+		if !filepath.IsAbs(cfg.Path) {
+			return errors.NotValidf("relative %s %q", BackupStoragePath, cfg.Path)
+		}
+	case BackupStorageS3:
+		if cfg.S3Endpoint == "" {
+			return errors.NotValidf("empty %s", BackupS3Endpoint)
+		}
+		u, err := url.Parse(cfg.S3Endpoint)
+		if err != nil {
+			return errors.Annotatef(err, "parsing %s", BackupS3Endpoint)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.NotValidf("%s %q", BackupS3Endpoint, cfg.S3Endpoint)
+		}
+		if cfg.S3Region == "" {
+			return errors.NotValidf("empty %s", BackupS3Region)
+		}
+		if cfg.S3Bucket == "" {
+			return errors.NotValidf("empty %s", BackupS3Bucket)
+		}
+		if cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+			return errors.NotValidf("empty %s or %s", BackupS3AccessKey, BackupS3SecretKey)
+		}
+	default:
+		return errors.NotValidf("%s %q", BackupStorage, cfg.Type)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -21,7 +22,10 @@ import (
 	"gopkg.in/macaroon-bakery.v2/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/core/schedule"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 const (
@@ -83,6 +87,42 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSinks is the list of sinks that audit records are
+	// written to: any of "file", "syslog" and "webhook".
+	AuditLogSinks = "audit-log-sinks"
+
+	// AuditLogSyslogHost is the host:port of the syslog server that
+	// receives audit records when the syslog sink is enabled.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogSyslogCACert is the certificate of the CA that signed
+	// the audit syslog server certificate, in PEM format.
+	AuditLogSyslogCACert = "audit-log-syslog-ca-cert"
+
+	// AuditLogSyslogClientCert is the client certificate used to
+	// connect to the audit syslog server, in PEM format.
+	AuditLogSyslogClientCert = "audit-log-syslog-client-cert"
+
+	// AuditLogSyslogClientKey is the client key used to connect to
+	// the audit syslog server, in PEM format.
+	AuditLogSyslogClientKey = "audit-log-syslog-client-key"
+
+	// AuditLogWebhookURL is the HTTP(S) endpoint that batches of
+	// audit records are posted to when the webhook sink is enabled.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogWebhookBatchSize is the maximum number of audit records
+	// posted to the webhook in one request.
+	AuditLogWebhookBatchSize = "audit-log-webhook-batch-size"
+
+	// AuditLogWebhookFlushInterval is the longest time an audit
+	// record is held waiting for a webhook batch to fill, eg "5s".
+	AuditLogWebhookFlushInterval = "audit-log-webhook-flush-interval"
+
+	// AuditLogWebhookMaxAttempts is the number of times posting a
+	// batch of audit records is tried before the batch is dropped.
+	AuditLogWebhookMaxAttempts = "audit-log-webhook-max-attempts"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -153,6 +193,17 @@ const (
 	// logs for the models, eg "20M". Size is per model.
 	ModelLogsSize = "model-logs-size"
 
+	// StatusHistoryArchiveDir is the directory on each controller in
+	// which pruned status history is archived, for models configured
+	// to archive it to files. If it is not set, status history can't
+	// be archived to files.
+	StatusHistoryArchiveDir = "status-history-archive-dir"
+
+	// StatusHistoryArchiveMaxSize is the total size that the status
+	// history archive files on a controller can grow to, across all
+	// models, before the oldest files are removed, eg "10G".
+	StatusHistoryArchiveMaxSize = "status-history-archive-max-size"
+
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
@@ -167,6 +218,51 @@ const (
 	// default value of 1M BatchSize and 100 passes will be used instead.
 	MaxPruneTxnPasses = "max-prune-txn-passes"
 
+	// BackupSchedule is a cron-like schedule, eg "0 2 * * *", on which the
+	// controller takes backups of itself. An empty schedule, the default,
+	// disables scheduled backups.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetentionDaily is the number of days for which the most recent
+	// scheduled backup of each day is kept.
+	BackupRetentionDaily = "backup-retention-daily"
+
+	// BackupRetentionWeekly is the number of weeks for which the most
+	// recent scheduled backup of each week is kept.
+	BackupRetentionWeekly = "backup-retention-weekly"
+
+	// BackupStorage is where backup archives are kept: "controller"
+	// (the default) keeps them in the controller's own database, "local"
+	// in a directory on the controller machines, such as an NFS mount,
+	// and "s3" in an S3-compatible object store.
+	BackupStorage = "backup-storage"
+
+	// BackupStoragePath is the directory backup archives are kept in
+	// when backup-storage is "local". In HA it must be shared by all
+	// the controller machines, as a backup may be made by, or asked
+	// for from, any of them, and the scheduled backups move with the
+	// primary controller.
+	BackupStoragePath = "backup-storage-path"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store
+	// backup archives are kept in when backup-storage is "s3".
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the backup object store.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the bucket of the backup object store that
+	// backup archives are kept in.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used to authenticate with the
+	// backup object store.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with the
+	// backup object store.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
 	// PruneTxnQueryCount is the number of transactions to read in a single query.
 	// Minimum of 10, a value of 0 will indicate to use the default value (1000)
 	PruneTxnQueryCount = "prune-txn-query-count"
@@ -206,6 +302,18 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogWebhookBatchSize is the default maximum number
+	// of audit records posted to the webhook in one request.
+	DefaultAuditLogWebhookBatchSize = 100
+
+	// DefaultAuditLogWebhookFlushInterval is the default longest time
+	// an audit record waits for a webhook batch to fill.
+	DefaultAuditLogWebhookFlushInterval = "5s"
+
+	// DefaultAuditLogWebhookMaxAttempts is the default number of
+	// attempts made to post a batch of audit records.
+	DefaultAuditLogWebhookMaxAttempts = 5
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -243,10 +351,29 @@ const (
 	// DefaultModelLogfileMaxBackups is the number of old model log files to keep (compressed).
 	DefaultModelLogfileMaxBackups = 2
 
+	// DefaultBackupRetentionDaily is the default number of days for which
+	// a daily scheduled backup is kept.
+	DefaultBackupRetentionDaily = 7
+
+	// DefaultBackupRetentionWeekly is the default number of weeks for
+	// which a weekly scheduled backup is kept.
+	DefaultBackupRetentionWeekly = 4
+
+	// DefaultBackupStorage is the default place backup archives are kept.
+	DefaultBackupStorage = BackupStorageController
+
+	// DefaultBackupS3Region is the default region of the backup object
+	// store.
+	DefaultBackupS3Region = "us-east-1"
+
 	// DefaultModelLogsSizeMB is the size in MB of the capped logs collection
 	// for each model.
 	DefaultModelLogsSizeMB = 20
 
+	// DefaultStatusHistoryArchiveMaxSizeMB is the default total size in
+	// MB of the status history archive files on a controller.
+	DefaultStatusHistoryArchiveMaxSizeMB = 10 * 1024
+
 	// DefaultPruneTxnQueryCount is the number of transactions to read in a single query.
 	DefaultPruneTxnQueryCount = 1000
 
@@ -311,6 +438,8 @@ var (
 		ModelLogsSize,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		StatusHistoryArchiveDir,
+		StatusHistoryArchiveMaxSize,
 		JujuHASpace,
 		JujuManagementSpace,
 		AuditingEnabled,
@@ -318,6 +447,25 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSinks,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookFlushInterval,
+		AuditLogWebhookMaxAttempts,
+		BackupSchedule,
+		BackupRetentionDaily,
+		BackupRetentionWeekly,
+		BackupStorage,
+		BackupStoragePath,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -334,6 +482,25 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogSinks,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogWebhookURL,
+		AuditLogWebhookBatchSize,
+		AuditLogWebhookFlushInterval,
+		AuditLogWebhookMaxAttempts,
+		BackupSchedule,
+		BackupRetentionDaily,
+		BackupRetentionWeekly,
+		BackupStorage,
+		BackupStoragePath,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -347,6 +514,8 @@ var (
 		MongoMemoryProfile,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		StatusHistoryArchiveDir,
+		StatusHistoryArchiveMaxSize,
 		JujuHASpace,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
@@ -362,6 +531,10 @@ var (
 		ReadOnlyMethodsWildcard,
 	}
 
+	// DefaultAuditLogSinks is the default list of audit log sinks:
+	// just the audit.log file on each controller.
+	DefaultAuditLogSinks = []string{auditlog.FileSink}
+
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
 )
 
@@ -567,6 +740,43 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSinks returns the names of the sinks that audit records
+// should be written to.
+func (c Config) AuditLogSinks() set.Strings {
+	if value, ok := c[AuditLogSinks]; ok {
+		value := value.([]interface{})
+		items := set.NewStrings()
+		for _, item := range value {
+			items.Add(item.(string))
+		}
+		return items
+	}
+	return set.NewStrings(DefaultAuditLogSinks...)
+}
+
+// AuditLogSyslog returns the connection details for the audit syslog
+// sink. The config is only enabled if the syslog sink is selected.
+func (c Config) AuditLogSyslog() syslog.RawConfig {
+	return syslog.RawConfig{
+		Enabled:    c.AuditLogSinks().Contains(auditlog.SyslogSink),
+		Host:       c.asString(AuditLogSyslogHost),
+		CACert:     c.asString(AuditLogSyslogCACert),
+		ClientCert: c.asString(AuditLogSyslogClientCert),
+		ClientKey:  c.asString(AuditLogSyslogClientKey),
+	}
+}
+
+// AuditLogWebhook returns the settings for the audit webhook sink.
+func (c Config) AuditLogWebhook() auditlog.WebhookConfig {
+	interval, _ := time.ParseDuration(DefaultAuditLogWebhookFlushInterval)
+	return auditlog.WebhookConfig{
+		URL:           c.asString(AuditLogWebhookURL),
+		BatchSize:     c.intOrDefault(AuditLogWebhookBatchSize, DefaultAuditLogWebhookBatchSize),
+		FlushInterval: c.durationOrDefault(AuditLogWebhookFlushInterval, interval),
+		MaxAttempts:   c.intOrDefault(AuditLogWebhookMaxAttempts, DefaultAuditLogWebhookMaxAttempts),
+	}
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -685,6 +895,19 @@ func (c Config) ModelLogsSizeMB() int {
 	return c.sizeMBOrDefault(ModelLogsSize, DefaultModelLogsSizeMB)
 }
 
+// StatusHistoryArchiveDir returns the directory on each controller in
+// which pruned status history is archived to files. If it is empty,
+// status history can't be archived to files.
+func (c Config) StatusHistoryArchiveDir() string {
+	return c.asString(StatusHistoryArchiveDir)
+}
+
+// StatusHistoryArchiveMaxSizeMB is the total size in MiB of the status
+// history archive files on each controller.
+func (c Config) StatusHistoryArchiveMaxSizeMB() int {
+	return c.sizeMBOrDefault(StatusHistoryArchiveMaxSize, DefaultStatusHistoryArchiveMaxSizeMB)
+}
+
 // MaxDebugLogDuration is the maximum time a debug-log session is allowed
 // to run before it is terminated by the server.
 func (c Config) MaxDebugLogDuration() time.Duration {
@@ -695,6 +918,46 @@ func (c Config) MaxDebugLogDuration() time.Duration {
 	return duration
 }
 
+// BackupSchedule returns the cron-like schedule on which the controller
+// takes backups of itself, or "" if scheduled backups are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupRetentionDaily returns the number of days for which the most
+// recent scheduled backup of each day is kept.
+func (c Config) BackupRetentionDaily() int {
+	return c.intOrDefault(BackupRetentionDaily, DefaultBackupRetentionDaily)
+}
+
+// BackupRetentionWeekly returns the number of weeks for which the most
+// recent scheduled backup of each week is kept.
+func (c Config) BackupRetentionWeekly() int {
+	return c.intOrDefault(BackupRetentionWeekly, DefaultBackupRetentionWeekly)
+}
+
+// BackupStorage returns the settings for where the controller keeps
+// backup archives.
+func (c Config) BackupStorage() BackupStorageConfig {
+	region := c.asString(BackupS3Region)
+	if region == "" {
+		region = DefaultBackupS3Region
+	}
+	storageType := c.asString(BackupStorage)
+	if storageType == "" {
+		storageType = DefaultBackupStorage
+	}
+	return BackupStorageConfig{
+		Type:        storageType,
+		Path:        c.asString(BackupStoragePath),
+		S3Endpoint:  c.asString(BackupS3Endpoint),
+		S3Region:    region,
+		S3Bucket:    c.asString(BackupS3Bucket),
+		S3AccessKey: c.asString(BackupS3AccessKey),
+		S3SecretKey: c.asString(BackupS3SecretKey),
+	}
+}
+
 // MaxTxnLogSizeMB is the maximum size in MiB of the txn log collection.
 func (c Config) MaxTxnLogSizeMB() int {
 	return c.sizeMBOrDefault(MaxTxnLogSize, DefaultMaxTxnLogCollectionMB)
@@ -857,6 +1120,32 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[StatusHistoryArchiveDir].(string); ok && v != "" {
+		if !filepath.IsAbs(v) {
+			return errors.NotValidf("%s %q (must be an absolute path)", StatusHistoryArchiveDir, v)
+		}
+	}
+
+	if v, ok := c[StatusHistoryArchiveMaxSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in configuration", StatusHistoryArchiveMaxSize)
+		}
+	}
+
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := schedule.Parse(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in configuration", BackupSchedule)
+		}
+	}
+	for _, key := range []string{BackupRetentionDaily, BackupRetentionWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.NotValidf("negative %s", key)
+		}
+	}
+	if err := c.BackupStorage().Validate(); err != nil {
+		return errors.Annotate(err, "invalid backup storage config")
+	}
+
 	if v, ok := c[PruneTxnSleepTime].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotatef(err, `%s must be a valid duration (eg "10ms")`, PruneTxnSleepTime)
@@ -917,6 +1206,30 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogSinks].([]interface{}); ok {
+		for i, name := range v {
+			name := name.(string)
+			if !auditlog.ValidSinks.Contains(name) {
+				return errors.Errorf(
+					`invalid audit log sinks: expected any of %q, got %q at position %d`,
+					auditlog.ValidSinks.SortedValues(),
+					name,
+					i+1,
+				)
+			}
+		}
+	}
+
+	if v, ok := c[AuditLogWebhookFlushInterval].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, `%s must be a valid duration (eg "5s")`, AuditLogWebhookFlushInterval)
+		}
+	}
+
+	if err := c.validateAuditLogSinks(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalid and --reset is used.
 		// However that doesn't exist yet.
@@ -940,6 +1253,24 @@ func Validate(c Config) error {
 	return nil
 }
 
+func (c Config) validateAuditLogSinks() error {
+	if _, ok := c[AuditingEnabled]; ok && !c.AuditingEnabled() {
+		return nil
+	}
+	sinks := c.AuditLogSinks()
+	if sinks.Contains(auditlog.SyslogSink) {
+		if err := c.AuditLogSyslog().Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit log syslog sink config")
+		}
+	}
+	if sinks.Contains(auditlog.WebhookSink) {
+		if err := c.AuditLogWebhook().Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit log webhook sink config")
+		}
+	}
+	return nil
+}
+
 func (c Config) validateSpaceConfig(key, topic string) error {
 	val := c[key]
 	if val == nil {
@@ -996,77 +1327,119 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AgentRateLimitMax:       schema.ForceInt(),
-	AgentRateLimitRate:      schema.TimeDuration(),
-	AuditingEnabled:         schema.Bool(),
-	AuditLogCaptureArgs:     schema.Bool(),
-	AuditLogMaxSize:         schema.String(),
-	AuditLogMaxBackups:      schema.ForceInt(),
-	AuditLogExcludeMethods:  schema.List(schema.String()),
-	APIPort:                 schema.ForceInt(),
-	APIPortOpenDelay:        schema.String(),
-	ControllerAPIPort:       schema.ForceInt(),
-	ControllerName:          schema.String(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxDebugLogDuration:     schema.TimeDuration(),
-	MaxTxnLogSize:           schema.String(),
-	MaxPruneTxnBatchSize:    schema.ForceInt(),
-	MaxPruneTxnPasses:       schema.ForceInt(),
-	ModelLogfileMaxBackups:  schema.ForceInt(),
-	ModelLogfileMaxSize:     schema.String(),
-	ModelLogsSize:           schema.String(),
-	PruneTxnQueryCount:      schema.ForceInt(),
-	PruneTxnSleepTime:       schema.String(),
-	JujuHASpace:             schema.String(),
-	JujuManagementSpace:     schema.String(),
-	CAASOperatorImagePath:   schema.String(),
-	CAASImageRepo:           schema.String(),
-	Features:                schema.List(schema.String()),
-	CharmStoreURL:           schema.String(),
-	MeteringURL:             schema.String(),
+	AgentRateLimitMax:            schema.ForceInt(),
+	AgentRateLimitRate:           schema.TimeDuration(),
+	AuditingEnabled:              schema.Bool(),
+	AuditLogCaptureArgs:          schema.Bool(),
+	AuditLogMaxSize:              schema.String(),
+	AuditLogMaxBackups:           schema.ForceInt(),
+	AuditLogExcludeMethods:       schema.List(schema.String()),
+	AuditLogSinks:                schema.List(schema.String()),
+	AuditLogSyslogHost:           schema.String(),
+	AuditLogSyslogCACert:         schema.String(),
+	AuditLogSyslogClientCert:     schema.String(),
+	AuditLogSyslogClientKey:      schema.String(),
+	AuditLogWebhookURL:           schema.String(),
+	AuditLogWebhookBatchSize:     schema.ForceInt(),
+	AuditLogWebhookFlushInterval: schema.String(),
+	AuditLogWebhookMaxAttempts:   schema.ForceInt(),
+	BackupSchedule:               schema.String(),
+	BackupRetentionDaily:         schema.ForceInt(),
+	BackupRetentionWeekly:        schema.ForceInt(),
+	BackupStorage:                schema.String(),
+	BackupStoragePath:            schema.String(),
+	BackupS3Endpoint:             schema.String(),
+	BackupS3Region:               schema.String(),
+	BackupS3Bucket:               schema.String(),
+	BackupS3AccessKey:            schema.String(),
+	BackupS3SecretKey:            schema.String(),
+	APIPort:                      schema.ForceInt(),
+	APIPortOpenDelay:             schema.String(),
+	ControllerAPIPort:            schema.ForceInt(),
+	ControllerName:               schema.String(),
+	StatePort:                    schema.ForceInt(),
+	IdentityURL:                  schema.String(),
+	IdentityPublicKey:            schema.String(),
+	SetNUMAControlPolicyKey:      schema.Bool(),
+	AutocertURLKey:               schema.String(),
+	AutocertDNSNameKey:           schema.String(),
+	AllowModelAccessKey:          schema.Bool(),
+	MongoMemoryProfile:           schema.String(),
+	MaxDebugLogDuration:          schema.TimeDuration(),
+	MaxTxnLogSize:                schema.String(),
+	MaxPruneTxnBatchSize:         schema.ForceInt(),
+	MaxPruneTxnPasses:            schema.ForceInt(),
+	ModelLogfileMaxBackups:       schema.ForceInt(),
+	ModelLogfileMaxSize:          schema.String(),
+	ModelLogsSize:                schema.String(),
+	StatusHistoryArchiveDir:      schema.String(),
+	StatusHistoryArchiveMaxSize:  schema.String(),
+	PruneTxnQueryCount:           schema.ForceInt(),
+	PruneTxnSleepTime:            schema.String(),
+	JujuHASpace:                  schema.String(),
+	JujuManagementSpace:          schema.String(),
+	CAASOperatorImagePath:        schema.String(),
+	CAASImageRepo:                schema.String(),
+	Features:                     schema.List(schema.String()),
+	CharmStoreURL:                schema.String(),
+	MeteringURL:                  schema.String(),
 }, schema.Defaults{
-	AgentRateLimitMax:       schema.Omit,
-	AgentRateLimitRate:      schema.Omit,
-	APIPort:                 DefaultAPIPort,
-	APIPortOpenDelay:        DefaultAPIPortOpenDelay,
-	ControllerAPIPort:       schema.Omit,
-	ControllerName:          schema.Omit,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	AuditLogCaptureArgs:     DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      DefaultMongoMemoryProfile,
-	MaxDebugLogDuration:     DefaultMaxDebugLogDuration,
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	MaxPruneTxnBatchSize:    DefaultMaxPruneTxnBatchSize,
-	MaxPruneTxnPasses:       DefaultMaxPruneTxnPasses,
-	ModelLogfileMaxBackups:  DefaultModelLogfileMaxBackups,
-	ModelLogfileMaxSize:     fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
-	ModelLogsSize:           fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
-	PruneTxnQueryCount:      DefaultPruneTxnQueryCount,
-	PruneTxnSleepTime:       DefaultPruneTxnSleepTime,
-	JujuHASpace:             schema.Omit,
-	JujuManagementSpace:     schema.Omit,
-	CAASOperatorImagePath:   schema.Omit,
-	CAASImageRepo:           schema.Omit,
-	Features:                schema.Omit,
-	CharmStoreURL:           csclient.ServerURL,
-	MeteringURL:             romulus.DefaultAPIRoot,
+	AgentRateLimitMax:            schema.Omit,
+	AgentRateLimitRate:           schema.Omit,
+	APIPort:                      DefaultAPIPort,
+	APIPortOpenDelay:             DefaultAPIPortOpenDelay,
+	ControllerAPIPort:            schema.Omit,
+	ControllerName:               schema.Omit,
+	AuditingEnabled:              DefaultAuditingEnabled,
+	AuditLogCaptureArgs:          DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:              fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:           DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:       DefaultAuditLogExcludeMethods,
+	AuditLogSinks:                DefaultAuditLogSinks,
+	AuditLogSyslogHost:           schema.Omit,
+	AuditLogSyslogCACert:         schema.Omit,
+	AuditLogSyslogClientCert:     schema.Omit,
+	AuditLogSyslogClientKey:      schema.Omit,
+	AuditLogWebhookURL:           schema.Omit,
+	AuditLogWebhookBatchSize:     DefaultAuditLogWebhookBatchSize,
+	AuditLogWebhookFlushInterval: DefaultAuditLogWebhookFlushInterval,
+	AuditLogWebhookMaxAttempts:   DefaultAuditLogWebhookMaxAttempts,
+	BackupSchedule:               schema.Omit,
+	BackupRetentionDaily:         DefaultBackupRetentionDaily,
+	BackupRetentionWeekly:        DefaultBackupRetentionWeekly,
+	BackupStorage:                DefaultBackupStorage,
+	BackupStoragePath:            schema.Omit,
+	BackupS3Endpoint:             schema.Omit,
+	BackupS3Region:               DefaultBackupS3Region,
+	BackupS3Bucket:               schema.Omit,
+	BackupS3AccessKey:            schema.Omit,
+	BackupS3SecretKey:            schema.Omit,
+	StatePort:                    DefaultStatePort,
+	IdentityURL:                  schema.Omit,
+	IdentityPublicKey:            schema.Omit,
+	SetNUMAControlPolicyKey:      DefaultNUMAControlPolicy,
+	AutocertURLKey:               schema.Omit,
+	AutocertDNSNameKey:           schema.Omit,
+	AllowModelAccessKey:          schema.Omit,
+	MongoMemoryProfile:           DefaultMongoMemoryProfile,
+	MaxDebugLogDuration:          DefaultMaxDebugLogDuration,
+	MaxTxnLogSize:                fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxPruneTxnBatchSize:         DefaultMaxPruneTxnBatchSize,
+	MaxPruneTxnPasses:            DefaultMaxPruneTxnPasses,
+	ModelLogfileMaxBackups:       DefaultModelLogfileMaxBackups,
+	ModelLogfileMaxSize:          fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
+	ModelLogsSize:                fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
+	StatusHistoryArchiveDir:      schema.Omit,
+	StatusHistoryArchiveMaxSize:  fmt.Sprintf("%vM", DefaultStatusHistoryArchiveMaxSizeMB),
+	PruneTxnQueryCount:           DefaultPruneTxnQueryCount,
+	PruneTxnSleepTime:            DefaultPruneTxnSleepTime,
+	JujuHASpace:                  schema.Omit,
+	JujuManagementSpace:          schema.Omit,
+	CAASOperatorImagePath:        schema.Omit,
+	CAASImageRepo:                schema.Omit,
+	Features:                     schema.Omit,
+	CharmStoreURL:                csclient.ServerURL,
+	MeteringURL:                  romulus.DefaultAPIRoot,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1101,6 +1474,84 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.FieldType("list of strings"),
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogSinks: {
+		Type:        environschema.FieldType("list of strings"),
+		Description: `The list of sinks that audit records are written to ("file", "syslog" or "webhook")`,
+	},
+	AuditLogSyslogHost: {
+		Type:        environschema.Tstring,
+		Description: `The hostname:port of the syslog server receiving audit records`,
+	},
+	AuditLogSyslogCACert: {
+		Type:        environschema.Tstring,
+		Description: `The certificate of the CA that signed the audit syslog server certificate, in PEM format`,
+	},
+	AuditLogSyslogClientCert: {
+		Type:        environschema.Tstring,
+		Description: `The audit syslog client certificate in PEM format`,
+	},
+	AuditLogSyslogClientKey: {
+		Type:        environschema.Tstring,
+		Description: `The audit syslog client key in PEM format; it is not shown by controller-config`,
+		Secret:      true,
+	},
+	AuditLogWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: `The URL that batches of audit records are posted to`,
+	},
+	AuditLogWebhookBatchSize: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of audit records posted to the webhook in one request`,
+	},
+	AuditLogWebhookFlushInterval: {
+		Type:        environschema.Tstring,
+		Description: `The longest time an audit record waits for a webhook batch to fill`,
+	},
+	AuditLogWebhookMaxAttempts: {
+		Type:        environschema.Tint,
+		Description: `The number of attempts made to post a batch of audit records before dropping it`,
+	},
+	BackupSchedule: {
+		Type:        environschema.Tstring,
+		Description: `The cron-like schedule on which the controller takes backups of itself; empty disables scheduled backups`,
+	},
+	BackupRetentionDaily: {
+		Type:        environschema.Tint,
+		Description: `The number of days for which the most recent scheduled backup of each day is kept`,
+	},
+	BackupRetentionWeekly: {
+		Type:        environschema.Tint,
+		Description: `The number of weeks for which the most recent scheduled backup of each week is kept`,
+	},
+	BackupStorage: {
+		Type:        environschema.Tstring,
+		Description: `Where backup archives are kept: controller, local or s3`,
+	},
+	BackupStoragePath: {
+		Type:        environschema.Tstring,
+		Description: `The directory backup archives are kept in when backup-storage is local; with more than one controller machine it must be a mount, such as NFS, shared by all of them`,
+	},
+	BackupS3Endpoint: {
+		Type:        environschema.Tstring,
+		Description: `The URL of the S3-compatible object store backup archives are kept in when backup-storage is s3`,
+	},
+	BackupS3Region: {
+		Type:        environschema.Tstring,
+		Description: `The region of the backup object store`,
+	},
+	BackupS3Bucket: {
+		Type:        environschema.Tstring,
+		Description: `The bucket backup archives are kept in`,
+	},
+	BackupS3AccessKey: {
+		Type:        environschema.Tstring,
+		Description: `The access key used to authenticate with the backup object store`,
+	},
+	BackupS3SecretKey: {
+		Type:        environschema.Tstring,
+		Description: `The secret key used to authenticate with the backup object store; it is not shown by controller-config`,
+		Secret:      true,
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -1182,6 +1633,16 @@ they don't have any access rights to the controller itself`,
 		Type:        environschema.Tstring,
 		Description: `The size of the capped collections used to hold the logs for the models`,
 	},
+	StatusHistoryArchiveDir: {
+		Type: environschema.Tstring,
+		Description: `The absolute path of the directory on each controller in which pruned status history is archived, ` +
+			`for models with status-history-archive set to "file". Files are written on the controller that prunes ` +
+			`the model's status history, so in an HA controller a model's archive is split across the controllers`,
+	},
+	StatusHistoryArchiveMaxSize: {
+		Type:        environschema.Tstring,
+		Description: `The total size of the status history archive files on each controller, across all models, before the oldest files are removed`,
+	},
 	PruneTxnQueryCount: {
 		Type:        environschema.Tint,
 		Description: `The number of transactions to read in a single query`,
@@ -18,6 +18,8 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -196,6 +198,36 @@ var newConfigTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log sink",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"file", "carrier-pigeon"},
+	},
+	expectError: `invalid audit log sinks: expected any of \["file" "syslog" "webhook"\], got "carrier-pigeon" at position 2`,
+}, {
+	about: "audit log syslog sink without host",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"syslog"},
+	},
+	expectError: `invalid audit log syslog sink config: Host "" not valid`,
+}, {
+	about: "audit log webhook sink without URL",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"file", "webhook"},
+	},
+	expectError: `invalid audit log webhook sink config: webhook URL "" \(expected http or https\) not valid`,
+}, {
+	about: "invalid audit log webhook flush interval",
+	config: controller.Config{
+		controller.AuditLogWebhookFlushInterval: "soon",
+	},
+	expectError: `audit-log-webhook-flush-interval must be a valid duration \(eg "5s"\): .*`,
+}, {
+	about: "audit log sinks not validated when auditing disabled",
+	config: controller.Config{
+		controller.AuditingEnabled: false,
+		controller.AuditLogSinks:   []interface{}{"syslog"},
+	},
 }, {
 	about: "invalid model log max size",
 	config: controller.Config{
@@ -208,6 +240,18 @@ var newConfigTests = []struct {
 		controller.ModelLogsSize: "0",
 	},
 	expectError: "model logs size less than 1 MB not valid",
+}, {
+	about: "relative status history archive dir",
+	config: controller.Config{
+		controller.StatusHistoryArchiveDir: "archive",
+	},
+	expectError: `status-history-archive-dir "archive" \(must be an absolute path\) not valid`,
+}, {
+	about: "invalid status history archive max size",
+	config: controller.Config{
+		controller.StatusHistoryArchiveMaxSize: "lots",
+	},
+	expectError: `invalid status-history-archive-max-size in configuration: .*`,
 }, {
 	about: "invalid CAAS docker image repo",
 	config: controller.Config{
@@ -295,6 +339,67 @@ var newConfigTests = []struct {
 		controller.ModelLogfileMaxBackups: -1,
 	},
 	expectError: `negative model-logfile-max-backups not valid`,
+}, {
+	about: "backup-schedule not valid",
+	config: controller.Config{
+		controller.BackupSchedule: "0 25 * * *",
+	},
+	expectError: `invalid backup-schedule in configuration: schedule "0 25 \* \* \*": hour 25 \(expected 0-23\) not valid`,
+}, {
+	about: "backup-retention-daily not valid",
+	config: controller.Config{
+		controller.BackupRetentionDaily: -1,
+	},
+	expectError: `negative backup-retention-daily not valid`,
+}, {
+	about: "backup-retention-weekly not valid",
+	config: controller.Config{
+		controller.BackupRetentionWeekly: -1,
+	},
+	expectError: `negative backup-retention-weekly not valid`,
+}, {
+	about: "backup-storage not valid",
+	config: controller.Config{
+		controller.BackupStorage: "tape",
+	},
+	expectError: `invalid backup storage config: backup-storage "tape" not valid`,
+}, {
+	about: "backup-storage-path missing",
+	config: controller.Config{
+		controller.BackupStorage: "local",
+	},
+	expectError: `invalid backup storage config: empty backup-storage-path not valid`,
+}, {
+	about: "backup-storage-path relative",
+	config: controller.Config{
+		controller.BackupStorage:     "local",
+		controller.BackupStoragePath: "backups",
+	},
+	expectError: `invalid backup storage config: relative backup-storage-path "backups" not valid`,
+}, {
+	about: "backup-s3-endpoint not valid",
+	config: controller.Config{
+		controller.BackupStorage:    "s3",
+		controller.BackupS3Endpoint: "minio:9000",
+		controller.BackupS3Bucket:   "backups",
+	},
+	expectError: `invalid backup storage config: backup-s3-endpoint "minio:9000" not valid`,
+}, {
+	about: "backup-s3-bucket missing",
+	config: controller.Config{
+		controller.BackupStorage:    "s3",
+		controller.BackupS3Endpoint: "http://minio:9000",
+	},
+	expectError: `invalid backup storage config: empty backup-s3-bucket not valid`,
+}, {
+	about: "backup s3 credentials missing",
+	config: controller.Config{
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "http://minio:9000",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+	},
+	expectError: `invalid backup storage config: empty backup-s3-access-key or backup-s3-secret-key not valid`,
 }, {
 	about: "model-logfile-max-size not valid",
 	config: controller.Config{
@@ -385,6 +490,27 @@ func (s *ConfigSuite) TestLogConfigValues(c *gc.C) {
 	c.Assert(cfg.ModelLogsSizeMB(), gc.Equals, 35)
 }
 
+func (s *ConfigSuite) TestStatusHistoryArchiveConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.StatusHistoryArchiveDir(), gc.Equals, "")
+	c.Assert(cfg.StatusHistoryArchiveMaxSizeMB(), gc.Equals, 10240)
+}
+
+func (s *ConfigSuite) TestStatusHistoryArchiveConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"status-history-archive-dir":      "/var/lib/juju/status-archive",
+			"status-history-archive-max-size": "2G",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.StatusHistoryArchiveDir(), gc.Equals, "/var/lib/juju/status-archive")
+	c.Assert(cfg.StatusHistoryArchiveMaxSizeMB(), gc.Equals, 2048)
+}
+
 func (s *ConfigSuite) TestTxnLogConfigDefault(c *gc.C) {
 	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -501,6 +627,51 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 	))
 }
 
+func (s *ConfigSuite) TestAuditLogSinkDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), gc.DeepEquals, set.NewStrings("file"))
+	c.Assert(cfg.AuditLogSyslog().Enabled, jc.IsFalse)
+	c.Assert(cfg.AuditLogWebhook(), gc.Equals, auditlog.WebhookConfig{
+		BatchSize:     100,
+		FlushInterval: 5 * time.Second,
+		MaxAttempts:   5,
+	})
+}
+
+func (s *ConfigSuite) TestAuditLogSinkValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-sinks":                  []string{"file", "syslog", "webhook"},
+			"audit-log-syslog-host":            "syslog.example.com:6514",
+			"audit-log-syslog-ca-cert":         testing.CACert,
+			"audit-log-syslog-client-cert":     testing.ServerCert,
+			"audit-log-syslog-client-key":      testing.ServerKey,
+			"audit-log-webhook-url":            "https://audit.example.com/ingest",
+			"audit-log-webhook-batch-size":     20,
+			"audit-log-webhook-flush-interval": "30s",
+			"audit-log-webhook-max-attempts":   2.0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), gc.DeepEquals, set.NewStrings("file", "syslog", "webhook"))
+	c.Assert(cfg.AuditLogSyslog(), gc.Equals, syslog.RawConfig{
+		Enabled:    true,
+		Host:       "syslog.example.com:6514",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+	c.Assert(cfg.AuditLogWebhook(), gc.Equals, auditlog.WebhookConfig{
+		URL:           "https://audit.example.com/ingest",
+		BatchSize:     20,
+		FlushInterval: 30 * time.Second,
+		MaxAttempts:   2,
+	})
+}
+
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
 	_, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -690,6 +861,77 @@ func (s *ConfigSuite) TestDefaults(c *gc.C) {
 	c.Assert(cfg.ModelLogfileMaxSizeMB(), gc.Equals, controller.DefaultModelLogfileMaxSize)
 }
 
+func (s *ConfigSuite) TestBackupScheduleDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupRetentionDaily(), gc.Equals, controller.DefaultBackupRetentionDaily)
+	c.Assert(cfg.BackupRetentionWeekly(), gc.Equals, controller.DefaultBackupRetentionWeekly)
+}
+
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":         "30 2 * * *",
+			"backup-retention-daily":  3,
+			"backup-retention-weekly": 0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "30 2 * * *")
+	c.Assert(cfg.BackupRetentionDaily(), gc.Equals, 3)
+	c.Assert(cfg.BackupRetentionWeekly(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestBackupStorageDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), jc.DeepEquals, controller.BackupStorageConfig{
+		Type:     controller.BackupStorageController,
+		S3Region: controller.DefaultBackupS3Region,
+	})
+}
+
+func (s *ConfigSuite) TestBackupStorageS3(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":       "s3",
+			"backup-s3-endpoint":   "https://s3.example.com",
+			"backup-s3-region":     "eu-west-1",
+			"backup-s3-bucket":     "backups",
+			"backup-s3-access-key": "access",
+			"backup-s3-secret-key": "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), jc.DeepEquals, controller.BackupStorageConfig{
+		Type:        controller.BackupStorageS3,
+		S3Endpoint:  "https://s3.example.com",
+		S3Region:    "eu-west-1",
+		S3Bucket:    "backups",
+		S3AccessKey: "access",
+		S3SecretKey: "secret",
+	})
+}
+
+func (s *ConfigSuite) TestSecretAttributes(c *gc.C) {
+	c.Check(controller.ConfigSchema[controller.BackupS3SecretKey].Secret, jc.IsTrue)
+	c.Check(controller.ConfigSchema[controller.AuditLogSyslogClientKey].Secret, jc.IsTrue)
+	c.Check(controller.ConfigSchema[controller.AuditLogSyslogClientCert].Secret, jc.IsFalse)
+}
+
 func (s *ConfigSuite) TestModelLogfile(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
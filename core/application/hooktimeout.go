@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6/hooks"
+)
+
+const (
+	// HookTimeoutConfigOptionName is the application config option
+	// holding how long any hook of the application may run before
+	// it is stopped. An empty value means hooks may run forever.
+	HookTimeoutConfigOptionName = "hook-timeout"
+
+	// HookKindTimeoutsConfigOptionName is the application config
+	// option holding the timeouts for particular kinds of hook,
+	// overriding hook-timeout, as a comma-separated list of
+	// <kind>=<duration> pairs; e.g. "install=1h,update-status=2m".
+	// Relation hooks are named by kind, e.g. "relation-changed".
+	HookKindTimeoutsConfigOptionName = "hook-kind-timeouts"
+
+	// HookKillGracePeriodConfigOptionName is the application config
+	// option holding how long a hook that has timed out is given to
+	// exit after it is sent SIGTERM, before it is sent SIGKILL. A zero
+	// duration means timed out hooks are killed immediately.
+	HookKillGracePeriodConfigOptionName = "hook-kill-grace-period"
+
+	// DefaultHookKillGracePeriod is the default value of the
+	// hook-kill-grace-period option.
+	DefaultHookKillGracePeriod = 30 * time.Second
+)
+
+// HookTimeoutPolicy describes how long the hooks of an application
+// may run, and how they are stopped when they run for too long.
+type HookTimeoutPolicy struct {
+	// Default is the timeout for hooks with no timeout of their
+	// kind. Zero means no timeout.
+	Default time.Duration
+
+	// ByKind holds the timeouts of particular kinds of hook.
+	// Zero means no timeout.
+	ByKind map[hooks.Kind]time.Duration
+
+	// KillGracePeriod is how long a timed out hook has to exit
+	// after it is asked to terminate, before it is killed.
+	KillGracePeriod time.Duration
+}
+
+// Timeout returns the timeout for hooks of the given kind, or zero
+// if they may run forever.
+func (p HookTimeoutPolicy) Timeout(kind hooks.Kind) time.Duration {
+	if timeout, ok := p.ByKind[kind]; ok {
+		return timeout
+	}
+	return p.Default
+}
+
+// ParseHookTimeoutPolicy returns the hook timeout policy described
+// by the application config attributes. Options missing from the
+// attributes take their default values.
+func ParseHookTimeoutPolicy(attrs ConfigAttributes) (HookTimeoutPolicy, error) {
+	policy := HookTimeoutPolicy{
+		KillGracePeriod: DefaultHookKillGracePeriod,
+	}
+	var err error
+	if value := attrs.GetString(HookTimeoutConfigOptionName, ""); value != "" {
+		if policy.Default, err = parseHookDuration(HookTimeoutConfigOptionName, value); err != nil {
+			return HookTimeoutPolicy{}, errors.Trace(err)
+		}
+	}
+	if value := attrs.GetString(HookKindTimeoutsConfigOptionName, ""); value != "" {
+		if policy.ByKind, err = parseHookKindTimeouts(value); err != nil {
+			return HookTimeoutPolicy{}, errors.Trace(err)
+		}
+	}
+	if value := attrs.GetString(HookKillGracePeriodConfigOptionName, ""); value != "" {
+		if policy.KillGracePeriod, err = parseHookDuration(HookKillGracePeriodConfigOptionName, value); err != nil {
+			return HookTimeoutPolicy{}, errors.Trace(err)
+		}
+	}
+	return policy, nil
+}
+
+func parseHookKindTimeouts(value string) (map[hooks.Kind]time.Duration, error) {
+	validKinds := make(map[hooks.Kind]bool)
+	for _, kind := range hooks.UnitHooks() {
+		validKinds[kind] = true
+	}
+	for _, kind := range hooks.RelationHooks() {
+		validKinds[kind] = true
+	}
+	result := make(map[hooks.Kind]time.Duration)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		fields := strings.SplitN(pair, "=", 2)
+		if len(fields) != 2 {
+			return nil, errors.NotValidf("%s entry %q (expected <kind>=<duration>)", HookKindTimeoutsConfigOptionName, pair)
+		}
+		kind := hooks.Kind(strings.TrimSpace(fields[0]))
+		if !validKinds[kind] {
+			return nil, errors.NotValidf("%s hook kind %q", HookKindTimeoutsConfigOptionName, kind)
+		}
+		timeout, err := parseHookDuration(HookKindTimeoutsConfigOptionName, strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[kind] = timeout
+	}
+	return result, nil
+}
+
+func parseHookDuration(option, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.NotValidf("%s duration %q", option, value)
+	}
+	if d < 0 {
+		return 0, errors.NotValidf("negative %s duration %q", option, value)
+	}
+	return d, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookTimeoutSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&HookTimeoutSuite{})
+
+func (s *HookTimeoutSuite) TestDefaults(c *gc.C) {
+	policy, err := application.ParseHookTimeoutPolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookTimeoutPolicy{
+		KillGracePeriod: application.DefaultHookKillGracePeriod,
+	})
+	c.Assert(policy.Timeout(hooks.Install), gc.Equals, time.Duration(0))
+}
+
+func (s *HookTimeoutSuite) TestParse(c *gc.C) {
+	policy, err := application.ParseHookTimeoutPolicy(application.ConfigAttributes{
+		"hook-timeout":           "10m",
+		"hook-kind-timeouts":     "install=1h, relation-changed=2m,update-status=0s",
+		"hook-kill-grace-period": "5s",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookTimeoutPolicy{
+		Default: 10 * time.Minute,
+		ByKind: map[hooks.Kind]time.Duration{
+			hooks.Install:         time.Hour,
+			hooks.RelationChanged: 2 * time.Minute,
+			hooks.UpdateStatus:    0,
+		},
+		KillGracePeriod: 5 * time.Second,
+	})
+	c.Check(policy.Timeout(hooks.Install), gc.Equals, time.Hour)
+	c.Check(policy.Timeout(hooks.RelationChanged), gc.Equals, 2*time.Minute)
+	c.Check(policy.Timeout(hooks.UpdateStatus), gc.Equals, time.Duration(0))
+	c.Check(policy.Timeout(hooks.ConfigChanged), gc.Equals, 10*time.Minute)
+}
+
+func (s *HookTimeoutSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{"hook-timeout": "soon"},
+		err:   `hook-timeout duration "soon" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-timeout": "-1m"},
+		err:   `negative hook-timeout duration "-1m" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-kind-timeouts": "install"},
+		err:   `hook-kind-timeouts entry "install" \(expected <kind>=<duration>\) not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-kind-timeouts": "db-relation-changed=1m"},
+		err:   `hook-kind-timeouts hook kind "db-relation-changed" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-kind-timeouts": "install=1"},
+		err:   `hook-kind-timeouts duration "1" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-kill-grace-period": "x"},
+		err:   `hook-kill-grace-period duration "x" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		_, err := application.ParseHookTimeoutPolicy(test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -6,8 +6,27 @@ package auditlog
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+const (
+	// FileSink is the name of the sink that writes records to the
+	// audit.log file in the controller's log directory.
+	FileSink = "file"
+
+	// SyslogSink is the name of the sink that sends records to a
+	// remote syslog host.
+	SyslogSink = "syslog"
+
+	// WebhookSink is the name of the sink that posts batches of
+	// records to an HTTP endpoint.
+	WebhookSink = "webhook"
 )
 
+// ValidSinks holds the names of all of the audit log sinks.
+var ValidSinks = set.NewStrings(FileSink, SyslogSink, WebhookSink)
+
 // Config holds parameters to control audit logging.
 type Config struct {
 	// Enabled determines whether API requests should be audited at
@@ -29,7 +48,18 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
-	// Target is the AuditLog entries should be written to.
+	// Sinks holds the names of the sinks (FileSink, SyslogSink,
+	// WebhookSink) that records should be written to.
+	Sinks set.Strings
+
+	// Syslog holds the connection details for the syslog sink.
+	Syslog syslog.RawConfig
+
+	// Webhook holds the settings for the webhook sink.
+	Webhook WebhookConfig
+
+	// Target is the AuditLog entries should be written to. When more
+	// than one sink is configured this feeds all of them.
 	Target AuditLog
 }
 
@@ -38,5 +68,20 @@ func (cfg Config) Validate() error {
 	if cfg.Enabled && cfg.Target == nil {
 		return errors.NewNotValid(nil, "logging enabled but no target provided")
 	}
+	for _, name := range cfg.Sinks.SortedValues() {
+		if !ValidSinks.Contains(name) {
+			return errors.NotValidf("audit log sink %q", name)
+		}
+	}
+	if cfg.Sinks.Contains(SyslogSink) {
+		if err := cfg.Syslog.Validate(); err != nil {
+			return errors.Annotate(err, "validating syslog sink")
+		}
+	}
+	if cfg.Sinks.Contains(WebhookSink) {
+		if err := cfg.Webhook.Validate(); err != nil {
+			return errors.Annotate(err, "validating webhook sink")
+		}
+	}
 	return nil
 }
@@ -0,0 +1,63 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sync"
+)
+
+// DropReporter is implemented by audit log sinks that deliver records
+// on a best-effort basis: records they can't deliver are dropped
+// rather than failing the API requests being audited.
+type DropReporter interface {
+	// DroppedRecords returns the number of records the sink has
+	// dropped since it was opened.
+	DroppedRecords() uint64
+}
+
+// dropCounter counts the records dropped by a sink. Rather than
+// logging every dropped record, it logs when a sink starts dropping
+// records and again when delivery resumes.
+type dropCounter struct {
+	sink string
+
+	mu      sync.Mutex
+	total   uint64
+	pending uint64
+}
+
+func newDropCounter(sink string) *dropCounter {
+	return &dropCounter{sink: sink}
+}
+
+// drop records that n records couldn't be delivered because of err.
+func (d *dropCounter) drop(n int, err error) {
+	if n <= 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending == 0 {
+		logger.Errorf("dropping records for audit %s sink: %v", d.sink, err)
+	}
+	d.pending += uint64(n)
+	d.total += uint64(n)
+}
+
+// delivered records that a record was delivered successfully.
+func (d *dropCounter) delivered() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending > 0 {
+		logger.Warningf("audit %s sink resumed delivery after dropping %d records", d.sink, d.pending)
+		d.pending = 0
+	}
+}
+
+// DroppedRecords is part of DropReporter.
+func (d *dropCounter) DroppedRecords() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.total
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// NewMultiLog returns an AuditLog that writes every record to each of
+// the logs passed in. A failure to write to one log doesn't prevent
+// the record being written to the others; all of the errors are
+// reported together. The remote sinks deliver records on a best-effort
+// basis and never report errors from adding records, so only the file
+// sink can fail an audited request.
+func NewMultiLog(logs ...AuditLog) AuditLog {
+	return &multiLog{logs: logs}
+}
+
+type multiLog struct {
+	logs []AuditLog
+}
+
+// AddConversation implements AuditLog.
+func (m *multiLog) AddConversation(c Conversation) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (m *multiLog) AddRequest(r Request) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddRequest(r)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (m *multiLog) AddResponse(r ResponseErrors) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddResponse(r)
+	})
+}
+
+// Close implements AuditLog.
+func (m *multiLog) Close() error {
+	return m.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+func (m *multiLog) each(f func(AuditLog) error) error {
+	var messages []string
+	for _, log := range m.logs {
+		if err := f(log); err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	switch len(messages) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(messages[0])
+	default:
+		return errors.Errorf("%d audit log sinks failed: %s", len(messages), strings.Join(messages, "; "))
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type MultiLogSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MultiLogSuite{})
+
+func (s *MultiLogSuite) TestWritesToAllLogs(c *gc.C) {
+	var log1, log2 fakeLog
+	log := auditlog.NewMultiLog(&log1, &log2)
+
+	conversation := auditlog.Conversation{Who: "a-ha", ConversationID: "0123456789abcdef"}
+	err := log.AddConversation(conversation)
+	c.Assert(err, jc.ErrorIsNil)
+	request := auditlog.Request{ConversationID: "0123456789abcdef", Facade: "Take", Method: "OnMe"}
+	err = log.AddRequest(request)
+	c.Assert(err, jc.ErrorIsNil)
+	response := auditlog.ResponseErrors{ConversationID: "0123456789abcdef"}
+	err = log.AddResponse(response)
+	c.Assert(err, jc.ErrorIsNil)
+	err = log.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, l := range []*fakeLog{&log1, &log2} {
+		l.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse", "Close")
+		l.stub.CheckCall(c, 0, "AddConversation", conversation)
+		l.stub.CheckCall(c, 1, "AddRequest", request)
+		l.stub.CheckCall(c, 2, "AddResponse", response)
+	}
+}
+
+func (s *MultiLogSuite) TestErrorDoesNotStopOtherLogs(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(errors.New("disk full"))
+	log := auditlog.NewMultiLog(&log1, &log2)
+
+	err := log.AddConversation(auditlog.Conversation{Who: "a-ha"})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+
+	log1.stub.CheckCallNames(c, "AddConversation")
+	log2.stub.CheckCallNames(c, "AddConversation")
+}
+
+func (s *MultiLogSuite) TestMultipleErrors(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(errors.New("disk full"))
+	log2.stub.SetErrors(errors.New("connection refused"))
+	log := auditlog.NewMultiLog(&log1, &log2)
+
+	err := log.AddRequest(auditlog.Request{Facade: "Hunting", Method: "HighAndLow"})
+	c.Assert(err, gc.ErrorMatches, "2 audit log sinks failed: disk full; connection refused")
+}
@@ -0,0 +1,248 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxRecordSize is the longest line accepted when reading an audit
+// log file; requests with captured args can be large.
+const maxRecordSize = 16 * 1024 * 1024
+
+// Filter holds the criteria used to select records from an audit log.
+// Empty fields match everything.
+type Filter struct {
+	// Who, ModelUUID and ConversationID select conversations.
+	Who            string
+	ModelUUID      string
+	ConversationID string
+
+	// Facade and Method select the requests within a conversation.
+	Facade string
+	Method string
+
+	// After and Before limit the time range of the requests
+	// returned.
+	After  time.Time
+	Before time.Time
+
+	// Limit is the maximum number of requests returned (along with
+	// their conversations and errors). If there are more matches the
+	// most recent are kept.
+	Limit int
+}
+
+func (f Filter) matchesConversation(c *Conversation) bool {
+	return (f.Who == "" || f.Who == c.Who) &&
+		(f.ModelUUID == "" || f.ModelUUID == c.ModelUUID) &&
+		(f.ConversationID == "" || f.ConversationID == c.ConversationID)
+}
+
+func (f Filter) matchesRequest(r *Request) bool {
+	if f.Facade != "" && f.Facade != r.Facade {
+		return false
+	}
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	if f.After.IsZero() && f.Before.IsZero() {
+		return true
+	}
+	when, err := time.Parse(time.RFC3339, r.When)
+	if err != nil {
+		return false
+	}
+	if !f.After.IsZero() && when.Before(f.After) {
+		return false
+	}
+	if !f.Before.IsZero() && when.After(f.Before) {
+		return false
+	}
+	return true
+}
+
+// ReadLogFiles reads the audit.log file in logDir, along with any
+// rotated (and possibly compressed) backups of it, and returns the
+// records selected by the filter. Each conversation precedes its
+// selected requests, and each request is followed by any errors
+// recorded for it.
+func ReadLogFiles(logDir string, filter Filter) ([]Record, error) {
+	paths, err := logFilePaths(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sel := newSelector(filter)
+	for _, path := range paths {
+		if err := readLogFile(path, sel); err != nil {
+			return nil, errors.Annotatef(err, "reading %s", path)
+		}
+	}
+	return sel.records(), nil
+}
+
+// ReadRecords reads audit records in the JSON lines format written by
+// NewLogFile from r, returning the ones selected by the filter in the
+// same way as ReadLogFiles.
+func ReadRecords(r io.Reader, filter Filter) ([]Record, error) {
+	sel := newSelector(filter)
+	if err := sel.read(r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sel.records(), nil
+}
+
+// logFilePaths returns the audit log files in logDir, oldest first.
+// Lumberjack names backups with their rotation time, so they sort
+// chronologically by name.
+func logFilePaths(logDir string) ([]string, error) {
+	var backups []string
+	for _, pattern := range []string{"audit-*.log", "audit-*.log.gz"} {
+		matches, err := filepath.Glob(filepath.Join(logDir, pattern))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		backups = append(backups, matches...)
+	}
+	sort.Strings(backups)
+	current := filepath.Join(logDir, "audit.log")
+	if _, err := os.Stat(current); err == nil {
+		backups = append(backups, current)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+	return backups, nil
+}
+
+func readLogFile(path string, sel *selector) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if filepath.Ext(path) == ".gz" {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return errors.Trace(sel.read(r))
+}
+
+type requestKey struct {
+	conversationID string
+	requestID      uint64
+}
+
+// selector accumulates the records matching a filter as the audit
+// log is read. When the filter has a limit, only that many requests
+// (and their errors) are held at a time, however long the log is.
+type selector struct {
+	filter        Filter
+	conversations map[string]*Conversation
+
+	// requests holds the selected requests in the order they were
+	// read. When the filter has a limit, it is used as a ring buffer
+	// once full, with oldest being the index of the oldest request.
+	requests []*Request
+	oldest   int
+
+	// responses holds the errors recorded for each request in
+	// requests; requests without errors have a nil entry.
+	responses map[requestKey][]*ResponseErrors
+}
+
+func newSelector(filter Filter) *selector {
+	return &selector{
+		filter:        filter,
+		conversations: make(map[string]*Conversation),
+		responses:     make(map[requestKey][]*ResponseErrors),
+	}
+}
+
+func (s *selector) read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxRecordSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			// A torn write (eg. when the controller died mid-line)
+			// shouldn't prevent reading the rest of the log.
+			logger.Warningf("skipping unreadable audit record: %v", err)
+			continue
+		}
+		s.add(record)
+	}
+	return errors.Trace(scanner.Err())
+}
+
+func (s *selector) add(record Record) {
+	switch {
+	case record.Conversation != nil:
+		if s.filter.matchesConversation(record.Conversation) {
+			s.conversations[record.Conversation.ConversationID] = record.Conversation
+		}
+	case record.Request != nil:
+		if _, ok := s.conversations[record.Request.ConversationID]; !ok {
+			return
+		}
+		if s.filter.matchesRequest(record.Request) {
+			s.addRequest(record.Request)
+		}
+	case record.Errors != nil:
+		key := requestKey{record.Errors.ConversationID, record.Errors.RequestID}
+		if responses, ok := s.responses[key]; ok {
+			s.responses[key] = append(responses, record.Errors)
+		}
+	}
+}
+
+// addRequest adds a selected request, replacing the oldest one if
+// the filter's limit has been reached.
+func (s *selector) addRequest(request *Request) {
+	s.responses[requestKey{request.ConversationID, request.RequestID}] = nil
+	if s.filter.Limit <= 0 || len(s.requests) < s.filter.Limit {
+		s.requests = append(s.requests, request)
+		return
+	}
+	evicted := s.requests[s.oldest]
+	delete(s.responses, requestKey{evicted.ConversationID, evicted.RequestID})
+	s.requests[s.oldest] = request
+	s.oldest = (s.oldest + 1) % len(s.requests)
+}
+
+func (s *selector) records() []Record {
+	requests := make([]*Request, 0, len(s.requests))
+	requests = append(requests, s.requests[s.oldest:]...)
+	requests = append(requests, s.requests[:s.oldest]...)
+	var result []Record
+	seen := make(map[string]bool)
+	for _, request := range requests {
+		if !seen[request.ConversationID] {
+			seen[request.ConversationID] = true
+			result = append(result, Record{Conversation: s.conversations[request.ConversationID]})
+		}
+		result = append(result, Record{Request: request})
+		for _, response := range s.responses[requestKey{request.ConversationID, request.RequestID}] {
+			result = append(result, Record{Errors: response})
+		}
+	}
+	return result
+}
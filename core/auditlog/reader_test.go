@@ -0,0 +1,249 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type ReaderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReaderSuite{})
+
+var (
+	deployConversation = auditlog.Conversation{
+		Who:            "deerhoof",
+		What:           "juju deploy prometheus",
+		When:           "2017-12-12T11:34:50Z",
+		ModelName:      "admin/default",
+		ModelUUID:      "model-1",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	}
+	deployRequest = auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2017-12-12T11:34:56Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        4,
+	}
+	deployErrors = auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2017-12-12T11:35:11Z",
+		Errors: []*auditlog.Error{
+			{Message: "oops", Code: "unauthorized access"},
+		},
+	}
+	exposeRequest = auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      26,
+		When:           "2017-12-12T11:36:00Z",
+		Facade:         "Application",
+		Method:         "Expose",
+		Version:        4,
+	}
+	destroyConversation = auditlog.Conversation{
+		Who:            "gojira",
+		What:           "juju destroy-model other",
+		When:           "2017-12-13T09:00:00Z",
+		ModelName:      "gojira/other",
+		ModelUUID:      "model-2",
+		ConversationID: "fedcba9876543210",
+		ConnectionID:   "AC2",
+	}
+	destroyRequest = auditlog.Request{
+		ConversationID: "fedcba9876543210",
+		ConnectionID:   "AC2",
+		RequestID:      3,
+		When:           "2017-12-13T09:00:01Z",
+		Facade:         "ModelManager",
+		Method:         "DestroyModels",
+		Version:        5,
+	}
+)
+
+func allRecords() []auditlog.Record {
+	return []auditlog.Record{
+		{Conversation: &deployConversation},
+		{Request: &deployRequest},
+		{Conversation: &destroyConversation},
+		{Errors: &deployErrors},
+		{Request: &exposeRequest},
+		{Request: &destroyRequest},
+	}
+}
+
+func encodeRecords(c *gc.C, records []auditlog.Record) []byte {
+	var buf bytes.Buffer
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func (s *ReaderSuite) read(c *gc.C, filter auditlog.Filter) []auditlog.Record {
+	records, err := auditlog.ReadRecords(bytes.NewReader(encodeRecords(c, allRecords())), filter)
+	c.Assert(err, jc.ErrorIsNil)
+	return records
+}
+
+func (s *ReaderSuite) TestNoFilter(c *gc.C) {
+	c.Assert(s.read(c, auditlog.Filter{}), jc.DeepEquals, []auditlog.Record{
+		{Conversation: &deployConversation},
+		{Request: &deployRequest},
+		{Errors: &deployErrors},
+		{Request: &exposeRequest},
+		{Conversation: &destroyConversation},
+		{Request: &destroyRequest},
+	})
+}
+
+func (s *ReaderSuite) TestFilterByUser(c *gc.C) {
+	c.Assert(s.read(c, auditlog.Filter{Who: "gojira"}), jc.DeepEquals, []auditlog.Record{
+		{Conversation: &destroyConversation},
+		{Request: &destroyRequest},
+	})
+}
+
+func (s *ReaderSuite) TestFilterByModelUUID(c *gc.C) {
+	c.Assert(s.read(c, auditlog.Filter{ModelUUID: "model-1"}), jc.DeepEquals, []auditlog.Record{
+		{Conversation: &deployConversation},
+		{Request: &deployRequest},
+		{Errors: &deployErrors},
+		{Request: &exposeRequest},
+	})
+}
+
+func (s *ReaderSuite) TestFilterByConversationID(c *gc.C) {
+	c.Assert(s.read(c, auditlog.Filter{ConversationID: "fedcba9876543210"}), jc.DeepEquals, []auditlog.Record{
+		{Conversation: &destroyConversation},
+		{Request: &destroyRequest},
+	})
+}
+
+func (s *ReaderSuite) TestFilterByFacadeAndMethod(c *gc.C) {
+	c.Assert(s.read(c, auditlog.Filter{Facade: "Application", Method: "Expose"}), jc.DeepEquals, []auditlog.Record{
+		{Conversation: &deployConversation},
+		{Request: &exposeRequest},
+	})
+}
+
+func (s *ReaderSuite) TestFilterByTime(c *gc.C) {
+	filter := auditlog.Filter{
+		After:  time.Date(2017, 12, 12, 11, 35, 0, 0, time.UTC),
+		Before: time.Date(2017, 12, 13, 0, 0, 0, 0, time.UTC),
+	}
+	c.Assert(s.read(c, filter), jc.DeepEquals, []auditlog.Record{
+		{Conversation: &deployConversation},
+		{Request: &exposeRequest},
+	})
+}
+
+func (s *ReaderSuite) TestLimitKeepsMostRecent(c *gc.C) {
+	c.Assert(s.read(c, auditlog.Filter{Limit: 2}), jc.DeepEquals, []auditlog.Record{
+		{Conversation: &deployConversation},
+		{Request: &exposeRequest},
+		{Conversation: &destroyConversation},
+		{Request: &destroyRequest},
+	})
+}
+
+func (s *ReaderSuite) TestLimitWrapsAround(c *gc.C) {
+	var records []auditlog.Record
+	var expected []auditlog.Record
+	records = append(records, auditlog.Record{Conversation: &deployConversation})
+	expected = append(expected, auditlog.Record{Conversation: &deployConversation})
+	for i := 0; i < 10; i++ {
+		request := deployRequest
+		request.RequestID = uint64(i)
+		responseErrors := deployErrors
+		responseErrors.RequestID = uint64(i)
+		records = append(records,
+			auditlog.Record{Request: &request},
+			auditlog.Record{Errors: &responseErrors},
+		)
+		if i >= 7 {
+			expected = append(expected,
+				auditlog.Record{Request: &request},
+				auditlog.Record{Errors: &responseErrors},
+			)
+		}
+	}
+	data := encodeRecords(c, records)
+	result, err := auditlog.ReadRecords(bytes.NewReader(data), auditlog.Filter{Limit: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *ReaderSuite) TestSkipsUnreadableLines(c *gc.C) {
+	data := encodeRecords(c, []auditlog.Record{{Conversation: &destroyConversation}})
+	data = append(data, []byte("{\"request\": {\"conv\n\n")...)
+	data = append(data, encodeRecords(c, []auditlog.Record{{Request: &destroyRequest}})...)
+	records, err := auditlog.ReadRecords(bytes.NewReader(data), auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []auditlog.Record{
+		{Conversation: &destroyConversation},
+		{Request: &destroyRequest},
+	})
+}
+
+func (s *ReaderSuite) TestReadLogFilesIncludesBackups(c *gc.C) {
+	dir := c.MkDir()
+	all := allRecords()
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(encodeRecords(c, all[:2]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "audit-2017-12-12T11-35-00.000.log.gz"), compressed.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "audit-2017-12-13T00-00-00.000.log"), encodeRecords(c, all[2:4]), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "audit.log"), encodeRecords(c, all[4:]), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	records, err := auditlog.ReadLogFiles(dir, auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, s.read(c, auditlog.Filter{}))
+}
+
+func (s *ReaderSuite) TestReadLogFilesMissing(c *gc.C) {
+	records, err := auditlog.ReadLogFiles(c.MkDir(), auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 0)
+}
+
+func (s *ReaderSuite) TestReadLogFilesBadArchive(c *gc.C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "audit-2017-12-12T11-35-00.000.log.gz")
+	err := ioutil.WriteFile(path, []byte("not gzip"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = auditlog.ReadLogFiles(dir, auditlog.Filter{})
+	c.Assert(err, gc.NotNil)
+	c.Assert(strings.HasPrefix(err.Error(), "reading "+path), jc.IsTrue)
+}
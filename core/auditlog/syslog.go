@@ -0,0 +1,278 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/rfc5424"
+	"github.com/juju/rfc/rfc5424/sdelements"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// canonicalPEN is the IANA-registered Private Enterprise Number
+// assigned to Canonical, used to qualify the audit structured data
+// element (see logfwd.Software).
+const canonicalPEN = 28978
+
+// syslogAppName is the RFC 5424 APP-NAME used for audit records.
+const syslogAppName = "juju-audit"
+
+const (
+	// syslogQueueSize is the number of messages held while the
+	// syslog host is unavailable, after which records are dropped.
+	syslogQueueSize = 1000
+
+	// syslogRetryDelay is the initial delay before reconnecting to
+	// the syslog host; it doubles after each failed attempt, up to
+	// syslogMaxRetryDelay.
+	syslogRetryDelay    = time.Second
+	syslogMaxRetryDelay = time.Minute
+)
+
+// NewSyslogSink returns an audit entry sink which sends each record
+// as an RFC 5424 message to the remote syslog host described by cfg.
+// The hostname is reported as the origin of the messages.
+//
+// Messages are queued and sent in the background, so an unavailable
+// syslog host neither holds up nor fails API requests: the sink
+// reconnects with backoff, and records that don't fit in the queue
+// in the meantime are logged and dropped.
+func NewSyslogSink(cfg syslog.RawConfig, hostname string, clock clock.Clock) (AuditLog, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating audit syslog config")
+	}
+	open := func() (syslog.Sender, error) {
+		return syslog.OpenSender(cfg, syslog.NewSenderOpener())
+	}
+	return NewSyslogSinkForOpener(open, hostname, clock), nil
+}
+
+// SenderOpenFunc connects to a syslog host.
+type SenderOpenFunc func() (syslog.Sender, error)
+
+// NewSyslogSinkForOpener returns an audit entry sink that sends
+// records using syslog senders returned by open.
+func NewSyslogSinkForOpener(open SenderOpenFunc, hostname string, clock clock.Clock) AuditLog {
+	s := &syslogSink{
+		dropCounter: newDropCounter(SyslogSink),
+		open:        open,
+		hostname:    hostname,
+		clock:       clock,
+		messages:    make(chan rfc5424.Message, syslogQueueSize),
+		done:        make(chan struct{}),
+		finished:    make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+type syslogSink struct {
+	*dropCounter
+
+	open     SenderOpenFunc
+	hostname string
+	clock    clock.Clock
+
+	// mu guards closed; adds hold it for reading while they queue
+	// a message, so once Close has the write lock every queued
+	// message is in the channel.
+	mu       sync.RWMutex
+	closed   bool
+	messages chan rfc5424.Message
+	done     chan struct{}
+	finished chan struct{}
+}
+
+// AddConversation implements AuditLog.
+func (s *syslogSink) AddConversation(c Conversation) error {
+	s.add(
+		Record{Conversation: &c},
+		c.When, c.ConversationID, c.ModelUUID,
+		rfc5424.SeverityInformational,
+	)
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (s *syslogSink) AddRequest(r Request) error {
+	s.add(
+		Record{Request: &r},
+		r.When, r.ConversationID, "",
+		rfc5424.SeverityInformational,
+	)
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (s *syslogSink) AddResponse(r ResponseErrors) error {
+	s.add(
+		Record{Errors: &r},
+		r.When, r.ConversationID, "",
+		rfc5424.SeverityWarning,
+	)
+	return nil
+}
+
+// Close implements AuditLog. It makes one attempt to send any queued
+// messages before returning.
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	s.mu.Unlock()
+	<-s.finished
+	return nil
+}
+
+func (s *syslogSink) add(r Record, when, conversationID, modelUUID string, severity rfc5424.Severity) {
+	msg, err := s.messageFromRecord(r, when, conversationID, modelUUID, severity)
+	if err != nil {
+		s.drop(1, err)
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		s.drop(1, errors.New("sink closed"))
+		return
+	}
+	select {
+	case s.messages <- msg:
+	default:
+		s.drop(1, errors.New("queue full"))
+	}
+}
+
+func (s *syslogSink) loop() {
+	defer close(s.finished)
+	var sender syslog.Sender
+	defer func() {
+		if sender != nil {
+			if err := sender.Close(); err != nil {
+				logger.Debugf("closing audit syslog connection: %v", err)
+			}
+		}
+	}()
+	delay := syslogRetryDelay
+	for {
+		var msg rfc5424.Message
+		select {
+		case <-s.done:
+			s.flush(&sender)
+			return
+		case msg = <-s.messages:
+		}
+		for {
+			err := s.send(&sender, msg)
+			if err == nil {
+				delay = syslogRetryDelay
+				break
+			}
+			logger.Debugf("sending audit record to syslog (retrying in %v): %v", delay, err)
+			select {
+			case <-s.done:
+				s.drop(1, err)
+				s.flush(&sender)
+				return
+			case <-s.clock.After(delay):
+			}
+			delay *= 2
+			if delay > syslogMaxRetryDelay {
+				delay = syslogMaxRetryDelay
+			}
+		}
+	}
+}
+
+// send sends msg, connecting to the syslog host first if necessary.
+// If sending fails the connection is closed, so that the next attempt
+// reconnects.
+func (s *syslogSink) send(sender *syslog.Sender, msg rfc5424.Message) error {
+	if *sender == nil {
+		conn, err := s.open()
+		if err != nil {
+			return errors.Annotate(err, "connecting to syslog host")
+		}
+		*sender = conn
+	}
+	if err := (*sender).Send(msg); err != nil {
+		_ = (*sender).Close()
+		*sender = nil
+		return errors.Trace(err)
+	}
+	s.delivered()
+	return nil
+}
+
+// flush makes one attempt to send each of the queued messages,
+// dropping the rest as soon as one fails.
+func (s *syslogSink) flush(sender *syslog.Sender) {
+	for {
+		select {
+		case msg := <-s.messages:
+			if err := s.send(sender, msg); err != nil {
+				s.drop(1+len(s.messages), err)
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (s *syslogSink) messageFromRecord(r Record, when, conversationID, modelUUID string, severity rfc5424.Severity) (rfc5424.Message, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return rfc5424.Message{}, errors.Trace(err)
+	}
+	timestamp, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		return rfc5424.Message{}, errors.Annotatef(err, "parsing audit record time %q", when)
+	}
+	data := []rfc5424.StructuredDataParam{{
+		Name:  "conversation-id",
+		Value: rfc5424.StructuredDataParamValue(conversationID),
+	}}
+	if modelUUID != "" {
+		data = append(data, rfc5424.StructuredDataParam{
+			Name:  "model-uuid",
+			Value: rfc5424.StructuredDataParamValue(modelUUID),
+		})
+	}
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: severity,
+				Facility: rfc5424.FacilityUser,
+			},
+			Timestamp: rfc5424.Timestamp{timestamp},
+			Hostname: rfc5424.Hostname{
+				FQDN: s.hostname,
+			},
+			AppName: syslogAppName,
+		},
+		StructuredData: rfc5424.StructuredData{
+			&sdelements.Private{
+				Name: "audit",
+				PEN:  canonicalPEN,
+				Data: data,
+			},
+		},
+		Msg: string(body),
+	}
+	if err := msg.Validate(); err != nil {
+		return msg, errors.Trace(err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/rfc5424"
+	"github.com/juju/rfc/rfc5424/sdelements"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SyslogSinkSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	opener *stubOpener
+}
+
+var _ = gc.Suite(&SyslogSinkSuite{})
+
+func (s *SyslogSinkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.opener = newStubOpener()
+}
+
+func (s *SyslogSinkSuite) newSink(c *gc.C) auditlog.AuditLog {
+	sink := auditlog.NewSyslogSinkForOpener(s.opener.open, "controller-0.example.com", s.clock)
+	s.AddCleanup(func(*gc.C) { sink.Close() })
+	return sink
+}
+
+func (s *SyslogSinkSuite) TestAddConversation(c *gc.C) {
+	sink := s.newSink(c)
+	err := sink.AddConversation(auditlog.Conversation{
+		Who:            "deerhoof",
+		What:           "gojira",
+		When:           "2017-11-27T13:21:24Z",
+		ModelName:      "admin/default",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.opener.nextMessage(c), jc.DeepEquals, rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityInformational,
+				Facility: rfc5424.FacilityUser,
+			},
+			Timestamp: rfc5424.Timestamp{time.Date(2017, 11, 27, 13, 21, 24, 0, time.UTC)},
+			Hostname: rfc5424.Hostname{
+				FQDN: "controller-0.example.com",
+			},
+			AppName: "juju-audit",
+		},
+		StructuredData: rfc5424.StructuredData{
+			&sdelements.Private{
+				Name: "audit",
+				PEN:  28978,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "conversation-id",
+					Value: "0123456789abcdef",
+				}, {
+					Name:  "model-uuid",
+					Value: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+				}},
+			},
+		},
+		Msg: `{"conversation":{"who":"deerhoof","what":"gojira","when":"2017-11-27T13:21:24Z","model-name":"admin/default","model-uuid":"deadbeef-2f18-4fd2-967d-db9663db7bea","conversation-id":"0123456789abcdef","connection-id":"AC1"}}`,
+	})
+}
+
+func (s *SyslogSinkSuite) TestAddResponseIsWarning(c *gc.C) {
+	sink := s.newSink(c)
+	err := sink.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AC1",
+		RequestID:      25,
+		When:           "2017-12-12T11:35:11Z",
+		Errors: []*auditlog.Error{
+			{Message: "oops", Code: "unauthorized access"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.opener.nextMessage(c)
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityWarning)
+	c.Check(msg.Msg, gc.Equals, `{"errors":{"conversation-id":"0123456789abcdef","connection-id":"AC1","request-id":25,"when":"2017-12-12T11:35:11Z","errors":[{"message":"oops","code":"unauthorized access"}]}}`)
+}
+
+func (s *SyslogSinkSuite) TestBadTimestampIsDropped(c *gc.C) {
+	sink := s.newSink(c)
+	err := sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		When:           "last tuesday",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.(auditlog.DropReporter).DroppedRecords(), gc.Equals, uint64(1))
+	s.opener.stub.CheckNoCalls(c)
+}
+
+func (s *SyslogSinkSuite) TestReconnectsWithBackoff(c *gc.C) {
+	s.opener.stub.SetErrors(
+		errors.New("connection refused"),
+		errors.New("connection refused"),
+	)
+	sink := s.newSink(c)
+	err := sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		When:           "2017-12-12T11:35:11Z",
+	})
+	// The syslog host being unavailable doesn't fail the request.
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(2*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.opener.nextMessage(c)
+	c.Assert(msg.Msg, jc.Contains, `"conversation-id":"0123456789abcdef"`)
+	s.opener.stub.CheckCallNames(c, "Open", "Open", "Open", "Send")
+	c.Assert(sink.(auditlog.DropReporter).DroppedRecords(), gc.Equals, uint64(0))
+}
+
+func (s *SyslogSinkSuite) TestReconnectsAfterSendFailure(c *gc.C) {
+	s.opener.stub.SetErrors(nil, errors.New("broken pipe"))
+	sink := s.newSink(c)
+	err := sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		When:           "2017-12-12T11:35:11Z",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.opener.nextMessage(c)
+	s.opener.stub.CheckCallNames(c, "Open", "Send", "Close", "Open", "Send")
+}
+
+func (s *SyslogSinkSuite) TestCloseSendsQueuedMessages(c *gc.C) {
+	sink := auditlog.NewSyslogSinkForOpener(s.opener.open, "controller-0.example.com", s.clock)
+	err := sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		When:           "2017-12-12T11:35:11Z",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.opener.nextMessage(c)
+	s.opener.stub.CheckCallNames(c, "Open", "Send", "Close")
+
+	// Records added after closing are dropped rather than failing.
+	err = sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		When:           "2017-12-12T11:35:11Z",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.(auditlog.DropReporter).DroppedRecords(), gc.Equals, uint64(1))
+}
+
+// stubOpener opens stub syslog senders, recording the calls made to
+// them all in one stub so the order of connections and sends can be
+// checked.
+type stubOpener struct {
+	mu       sync.Mutex
+	stub     testing.Stub
+	messages chan rfc5424.Message
+}
+
+func newStubOpener() *stubOpener {
+	return &stubOpener{messages: make(chan rfc5424.Message, 10)}
+}
+
+func (o *stubOpener) open() (syslog.Sender, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stub.AddCall("Open")
+	if err := o.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &stubSender{opener: o}, nil
+}
+
+func (o *stubOpener) nextMessage(c *gc.C) rfc5424.Message {
+	select {
+	case msg := <-o.messages:
+		return msg
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for syslog message")
+	}
+	return rfc5424.Message{}
+}
+
+type stubSender struct {
+	opener *stubOpener
+}
+
+func (s *stubSender) Send(msg rfc5424.Message) error {
+	s.opener.mu.Lock()
+	defer s.opener.mu.Unlock()
+	s.opener.stub.AddCall("Send", msg)
+	if err := s.opener.stub.NextErr(); err != nil {
+		return err
+	}
+	s.opener.messages <- msg
+	return nil
+}
+
+func (s *stubSender) Close() error {
+	s.opener.mu.Lock()
+	defer s.opener.mu.Unlock()
+	s.opener.stub.AddCall("Close")
+	return s.opener.stub.NextErr()
+}
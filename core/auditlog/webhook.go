@@ -0,0 +1,256 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+)
+
+const (
+	// webhookRetryDelay is the initial delay between attempts to
+	// post a batch of records; it doubles after each failed attempt.
+	webhookRetryDelay = time.Second
+
+	// webhookQueueBatches is the number of batches of records held
+	// while the endpoint is unavailable, after which records are
+	// dropped.
+	webhookQueueBatches = 10
+)
+
+// WebhookConfig holds the settings for an audit entry sink that posts
+// batches of records to an HTTP endpoint.
+type WebhookConfig struct {
+	// URL is the endpoint records are posted to.
+	URL string
+
+	// BatchSize is the maximum number of records sent in one
+	// request.
+	BatchSize int
+
+	// FlushInterval is the longest time a record will be held
+	// waiting for a batch to fill up.
+	FlushInterval time.Duration
+
+	// MaxAttempts is the number of times posting a batch will be
+	// tried before the records are dropped.
+	MaxAttempts int
+}
+
+// Validate checks the webhook sink configuration.
+func (cfg WebhookConfig) Validate() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "webhook URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("webhook URL %q (expected http or https)", cfg.URL)
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.NotValidf("non-positive webhook batch size %d", cfg.BatchSize)
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive webhook flush interval %v", cfg.FlushInterval)
+	}
+	if cfg.MaxAttempts <= 0 {
+		return errors.NotValidf("non-positive webhook max attempts %d", cfg.MaxAttempts)
+	}
+	return nil
+}
+
+// HTTPClient is the part of *http.Client used by the webhook sink.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// NewWebhookSink returns an audit entry sink which posts records to
+// an HTTP endpoint as newline-delimited JSON (the same format as the
+// audit.log file). Records are collected into batches in the
+// background, so a slow or unavailable endpoint doesn't hold up API
+// requests; batches that still can't be delivered after
+// cfg.MaxAttempts, and records that don't fit in the queue while
+// the endpoint is unavailable, are logged and dropped. Close sends
+// any pending records before returning.
+func NewWebhookSink(cfg WebhookConfig, client HTTPClient, clock clock.Clock) (AuditLog, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &webhookSink{
+		dropCounter: newDropCounter(WebhookSink),
+		config:      cfg,
+		client:      client,
+		clock:       clock,
+		records:     make(chan []byte, cfg.BatchSize*webhookQueueBatches),
+		done:        make(chan struct{}),
+		finished:    make(chan struct{}),
+	}
+	go w.loop()
+	return w, nil
+}
+
+type webhookSink struct {
+	*dropCounter
+
+	config WebhookConfig
+	client HTTPClient
+	clock  clock.Clock
+
+	// mu guards closed; adds hold it for reading while they queue
+	// a record, so once Close has the write lock every queued record
+	// is in the channel.
+	mu       sync.RWMutex
+	closed   bool
+	records  chan []byte
+	done     chan struct{}
+	finished chan struct{}
+}
+
+// AddConversation implements AuditLog.
+func (w *webhookSink) AddConversation(c Conversation) error {
+	w.addRecord(Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (w *webhookSink) AddRequest(r Request) error {
+	w.addRecord(Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (w *webhookSink) AddResponse(r ResponseErrors) error {
+	w.addRecord(Record{Errors: &r})
+	return nil
+}
+
+// Close implements AuditLog.
+func (w *webhookSink) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	w.mu.Unlock()
+	<-w.finished
+	return nil
+}
+
+func (w *webhookSink) addRecord(r Record) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		w.drop(1, err)
+		return
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		w.drop(1, errors.New("sink closed"))
+		return
+	}
+	select {
+	case w.records <- data:
+	default:
+		w.drop(1, errors.New("queue full"))
+	}
+}
+
+func (w *webhookSink) loop() {
+	defer close(w.finished)
+	var (
+		batch [][]byte
+		timer <-chan time.Time
+	)
+	flush := func() {
+		if len(batch) > 0 {
+			w.post(batch)
+		}
+		batch = nil
+		timer = nil
+	}
+	for {
+		select {
+		case <-w.done:
+			for {
+				select {
+				case record := <-w.records:
+					batch = append(batch, record)
+					if len(batch) >= w.config.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case record := <-w.records:
+			batch = append(batch, record)
+			if len(batch) >= w.config.BatchSize {
+				flush()
+			} else if timer == nil {
+				timer = w.clock.After(w.config.FlushInterval)
+			}
+		case <-timer:
+			flush()
+		}
+	}
+}
+
+func (w *webhookSink) post(batch [][]byte) {
+	var body []byte
+	for _, record := range batch {
+		body = append(body, record...)
+		body = append(body, '\n')
+	}
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			return w.send(body)
+		},
+		NotifyFunc: func(lastError error, attempt int) {
+			logger.Debugf("posting %d audit records (attempt %d): %v", len(batch), attempt, lastError)
+		},
+		Attempts:    w.config.MaxAttempts,
+		Delay:       webhookRetryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       w.clock,
+		// Once the sink is closing, each remaining batch gets a
+		// single attempt so that Close doesn't wait on retries.
+		Stop: w.done,
+	})
+	if err != nil {
+		w.drop(len(batch), errors.Annotatef(retry.LastError(err), "posting to %s", w.config.URL))
+		return
+	}
+	w.delivered()
+}
+
+func (w *webhookSink) send(body []byte) error {
+	req, err := http.NewRequest("POST", w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected response status %q", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WebhookSinkSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	client *fakeHTTPClient
+	config auditlog.WebhookConfig
+}
+
+var _ = gc.Suite(&WebhookSinkSuite{})
+
+func (s *WebhookSinkSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.client = newFakeHTTPClient()
+	s.config = auditlog.WebhookConfig{
+		URL:           "https://audit.example.com/ingest",
+		BatchSize:     2,
+		FlushInterval: 5 * time.Second,
+		MaxAttempts:   3,
+	}
+}
+
+func (s *WebhookSinkSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		modify func(*auditlog.WebhookConfig)
+		err    string
+	}{{
+		modify: func(cfg *auditlog.WebhookConfig) { cfg.URL = "ftp://audit.example.com" },
+		err:    `webhook URL "ftp://audit.example.com" \(expected http or https\) not valid`,
+	}, {
+		modify: func(cfg *auditlog.WebhookConfig) { cfg.BatchSize = 0 },
+		err:    `non-positive webhook batch size 0 not valid`,
+	}, {
+		modify: func(cfg *auditlog.WebhookConfig) { cfg.FlushInterval = 0 },
+		err:    `non-positive webhook flush interval 0s not valid`,
+	}, {
+		modify: func(cfg *auditlog.WebhookConfig) { cfg.MaxAttempts = -1 },
+		err:    `non-positive webhook max attempts -1 not valid`,
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.config
+		test.modify(&cfg)
+		c.Check(cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WebhookSinkSuite) TestPostsFullBatch(c *gc.C) {
+	sink, err := auditlog.NewWebhookSink(s.config, s.client, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddConversation(auditlog.Conversation{Who: "deerhoof", ConversationID: "0123456789abcdef"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddRequest(auditlog.Request{ConversationID: "0123456789abcdef", RequestID: 25, Facade: "Application", Method: "Deploy"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	body := s.client.nextBody(c)
+	c.Assert(body, gc.Equals, `
+{"conversation":{"who":"deerhoof","what":"","when":"","model-name":"","model-uuid":"","conversation-id":"0123456789abcdef","connection-id":""}}
+{"request":{"conversation-id":"0123456789abcdef","connection-id":"","request-id":25,"when":"","facade":"Application","method":"Deploy","version":0}}
+`[1:])
+}
+
+func (s *WebhookSinkSuite) TestFlushesAfterInterval(c *gc.C) {
+	sink, err := auditlog.NewWebhookSink(s.config, s.client, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	body := s.client.nextBody(c)
+	c.Assert(body, jc.Contains, `"who":"deerhoof"`)
+}
+
+func (s *WebhookSinkSuite) TestRetriesFailedPost(c *gc.C) {
+	s.client.setStatuses(http.StatusServiceUnavailable, http.StatusOK)
+	sink, err := auditlog.NewWebhookSink(s.config, s.client, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddConversation(auditlog.Conversation{Who: "gojira"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	first := s.client.nextBody(c)
+	// The flush timer started by the first record is still pending
+	// alongside the retry delay.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	second := s.client.nextBody(c)
+	c.Assert(second, gc.Equals, first)
+}
+
+func (s *WebhookSinkSuite) TestCloseFlushesPending(c *gc.C) {
+	sink, err := auditlog.NewWebhookSink(s.config, s.client, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sink.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	body := s.client.nextBody(c)
+	c.Assert(body, jc.Contains, `"who":"deerhoof"`)
+
+	// Records added after closing are dropped rather than failing.
+	err = sink.AddConversation(auditlog.Conversation{Who: "gojira"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.(auditlog.DropReporter).DroppedRecords(), gc.Equals, uint64(1))
+}
+
+func (s *WebhookSinkSuite) TestDropsRecordsWhenQueueFull(c *gc.C) {
+	s.client.setStatuses(http.StatusServiceUnavailable)
+	sink, err := auditlog.NewWebhookSink(s.config, s.client, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	err = sink.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddConversation(auditlog.Conversation{Who: "gojira"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The sink is now waiting to retry the first batch, so the queue
+	// fills up; adding to a full queue mustn't block or fail.
+	s.client.nextBody(c)
+	for i := 0; i < 2*10+1; i++ {
+		err = sink.AddRequest(auditlog.Request{RequestID: uint64(i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(sink.(auditlog.DropReporter).DroppedRecords(), gc.Equals, uint64(1))
+}
+
+type fakeHTTPClient struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   chan string
+}
+
+func newFakeHTTPClient() *fakeHTTPClient {
+	return &fakeHTTPClient{bodies: make(chan string, 10)}
+}
+
+func (f *fakeHTTPClient) setStatuses(statuses ...int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses = statuses
+}
+
+func (f *fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != "POST" {
+		return nil, errors.Errorf("unexpected method %q", req.Method)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f.bodies <- string(body)
+
+	f.mu.Lock()
+	status := http.StatusOK
+	if len(f.statuses) > 0 {
+		status, f.statuses = f.statuses[0], f.statuses[1:]
+	}
+	f.mu.Unlock()
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Body:       ioutil.NopCloser(&bytes.Buffer{}),
+	}, nil
+}
+
+func (f *fakeHTTPClient) nextBody(c *gc.C) string {
+	select {
+	case body := <-f.bodies:
+		return body
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for webhook post")
+	}
+	return ""
+}
@@ -7,6 +7,9 @@ import (
 	"sync"
 
 	"github.com/juju/pubsub"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 )
 
 const (
@@ -51,6 +54,26 @@ func (a *Application) CharmURL() string {
 	return a.details.CharmURL
 }
 
+// Name returns the name of this application.
+func (a *Application) Name() string {
+	return a.details.Name
+}
+
+// Life returns the current life of the application.
+func (a *Application) Life() life.Value {
+	return a.details.Life
+}
+
+// Status returns the status of the application.
+func (a *Application) Status() status.StatusInfo {
+	return a.details.Status
+}
+
+// Exposed returns whether the application is exposed.
+func (a *Application) Exposed() bool {
+	return a.details.Exposed
+}
+
 // Config returns a copy of the current application config.
 func (a *Application) Config() map[string]interface{} {
 	a.metrics.ApplicationConfigReads.Inc()
@@ -11,6 +11,8 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 )
 
 const (
@@ -70,6 +72,21 @@ func (m *Machine) Config() map[string]interface{} {
 	return m.details.Config
 }
 
+// Life returns the current life of the machine.
+func (m *Machine) Life() life.Value {
+	return m.details.Life
+}
+
+// AgentStatus returns the status of the machine agent.
+func (m *Machine) AgentStatus() status.StatusInfo {
+	return m.details.AgentStatus
+}
+
+// InstanceStatus returns the status of the machine's instance.
+func (m *Machine) InstanceStatus() status.StatusInfo {
+	return m.details.InstanceStatus
+}
+
 // Units returns all the units that have been assigned to the machine
 // including subordinates.
 func (m *Machine) Units() ([]Unit, error) {
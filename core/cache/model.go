@@ -13,6 +13,7 @@ import (
 	"github.com/juju/pubsub"
 	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
 )
@@ -29,6 +30,9 @@ const (
 	modelUnitRemove = "model-unit-remove"
 	// A branch has been removed from the model.
 	modelBranchRemove = "model-branch-remove"
+	// The model, or an application, unit or machine in it, has been
+	// added, changed or removed.
+	modelEntityChange = "model-entity-change"
 )
 
 type initializer interface {
@@ -320,6 +324,50 @@ func (m *Model) WatchMachines() (*PredicateStringsWatcher, error) {
 	return w, nil
 }
 
+// Life returns the current life of the model.
+func (m *Model) Life() life.Value {
+	defer m.doLocked()()
+	return m.details.Life
+}
+
+// Status returns the current status of the model.
+func (m *Model) Status() status.StatusInfo {
+	defer m.doLocked()()
+	return copyStatusInfo(m.details.Status)
+}
+
+// Applications returns all applications in the model.
+func (m *Model) Applications() map[string]Application {
+	m.mu.Lock()
+
+	apps := make(map[string]Application, len(m.applications))
+	for name, a := range m.applications {
+		apps[name] = a.copy()
+	}
+
+	m.mu.Unlock()
+	return apps
+}
+
+// WatchChanges returns a watcher that notifies when the model, or any
+// application, unit or machine in it, is added, changed or removed.
+// The first notification is sent straight away, so that callers can
+// check the current state of the model before waiting for changes.
+func (m *Model) WatchChanges() NotifyWatcher {
+	w := newNotifyWatcherBase()
+	deregister := m.registerWorker(w)
+	unsub := m.hub.Subscribe(modelEntityChange, func(string, interface{}) {
+		w.notify()
+	})
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+	return w
+}
+
 // updateApplication adds or updates the application in the model.
 func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 	m.mu.Lock()
@@ -330,6 +378,7 @@ func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 		m.applications[ch.Name] = app
 	}
 	app.setDetails(ch)
+	m.hub.Publish(modelEntityChange, nil)
 	m.updateSummary()
 	m.mu.Unlock()
 }
@@ -345,6 +394,7 @@ func (m *Model) removeApplication(ch RemoveApplication) error {
 		}
 		delete(m.applications, ch.Name)
 	}
+	m.hub.Publish(modelEntityChange, nil)
 	m.updateSummary()
 	return nil
 }
@@ -388,6 +438,7 @@ func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 	}
 	unit.setDetails(ch)
 
+	m.hub.Publish(modelEntityChange, nil)
 	m.updateSummary()
 	m.mu.Unlock()
 }
@@ -404,6 +455,7 @@ func (m *Model) removeUnit(ch RemoveUnit) error {
 		}
 		delete(m.units, ch.Name)
 	}
+	m.hub.Publish(modelEntityChange, nil)
 	m.updateSummary()
 	return nil
 }
@@ -473,6 +525,7 @@ func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 	}
 	machine.setDetails(ch)
 
+	m.hub.Publish(modelEntityChange, nil)
 	m.updateSummary()
 	m.mu.Unlock()
 }
@@ -489,6 +542,7 @@ func (m *Model) removeMachine(ch RemoveMachine) error {
 		}
 		delete(m.machines, ch.Id)
 	}
+	m.hub.Publish(modelEntityChange, nil)
 	m.updateSummary()
 	return nil
 }
@@ -546,6 +600,7 @@ func (m *Model) setDetails(details ModelChange) {
 		m.hub.Publish(modelConfigChange, hashCache)
 	}
 
+	m.hub.Publish(modelEntityChange, nil)
 	m.updateSummary()
 	m.mu.Unlock()
 }
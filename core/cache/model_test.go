@@ -361,6 +361,59 @@ func (s *ModelSuite) TestWaitForUnitCancelClosesChannel(c *gc.C) {
 	}
 }
 
+func (s *ModelSuite) TestWatchChangesNotifiesOnEntityChanges(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchChanges()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	m.UpdateApplication(appChange, s.Manager)
+	wc.AssertOneChange()
+
+	m.UpdateUnit(unitChange, s.Manager)
+	wc.AssertOneChange()
+
+	m.UpdateMachine(machineChange, s.Manager)
+	wc.AssertOneChange()
+
+	change := modelChange
+	change.Status = status.StatusInfo{Status: status.Busy}
+	m.SetDetails(change)
+	wc.AssertOneChange()
+	c.Check(m.Status().Status, gc.Equals, status.Busy)
+
+	err := m.RemoveUnit(cache.RemoveUnit{
+		ModelUUID: unitChange.ModelUUID,
+		Name:      unitChange.Name,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *ModelSuite) TestWatchChangesStops(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchChanges()
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+	wc.AssertStops()
+}
+
+func (s *ModelSuite) TestApplicationsReturnsCopies(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateApplication(appChange, s.Manager)
+
+	apps := m.Applications()
+	c.Assert(apps, gc.HasLen, 1)
+	app := apps[appChange.Name]
+	c.Check(app.Name(), gc.Equals, appChange.Name)
+	c.Check(app.Life(), gc.Equals, appChange.Life)
+	c.Check(app.Exposed(), gc.Equals, appChange.Exposed)
+	c.Check(app.Status(), jc.DeepEquals, appChange.Status)
+}
+
 var modelChange = cache.ModelChange{
 	ModelUUID:    "model-uuid",
 	Name:         "test-model",
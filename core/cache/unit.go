@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/core/status"
 )
 
 // Unit represents a unit in a cached model.
@@ -72,6 +73,16 @@ func (u *Unit) CharmURL() string {
 	return u.details.CharmURL
 }
 
+// WorkloadStatus returns the workload status of the unit.
+func (u *Unit) WorkloadStatus() status.StatusInfo {
+	return u.details.WorkloadStatus
+}
+
+// AgentStatus returns the status of the unit agent.
+func (u *Unit) AgentStatus() status.StatusInfo {
+	return u.details.AgentStatus
+}
+
 // Ports returns the exposed ports for the unit.
 func (u *Unit) Ports() []network.Port {
 	return u.details.Ports
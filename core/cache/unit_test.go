@@ -35,6 +35,16 @@ func (s *UnitSuite) TestWatchCharmConfigNewWatcher(c *gc.C) {
 	workertest.CleanKill(c, w)
 }
 
+func (s *UnitSuite) TestStatusAccessors(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	u, err := m.Unit(unitChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(u.WorkloadStatus(), jc.DeepEquals, unitChange.WorkloadStatus)
+	c.Check(u.AgentStatus(), jc.DeepEquals, unitChange.AgentStatus)
+}
+
 func (s *UnitSuite) TestConfigSettingsNoBranch(c *gc.C) {
 	m := s.NewModel(modelChange)
 	m.UpdateCharm(charmChange, s.Manager)
@@ -30,6 +30,10 @@ var ErrClaimDenied = errors.New("leadership claim denied")
 // if the client cancels the request by closing the cancel channel.
 var ErrBlockCancelled = errors.New("waiting for leadership cancelled by client")
 
+// ErrLeadershipPinned is returned from TransferLeadership if leadership
+// of the application is pinned.
+var ErrLeadershipPinned = errors.New("leadership pinned")
+
 // NewNotLeaderError returns an error indicating that this unit is not
 // the leader of that application.
 func NewNotLeaderError(unit, application string) error {
@@ -89,6 +93,21 @@ type Pinner interface {
 	PinnedLeadership() map[string][]string
 }
 
+// Transferer describes the capability to hand leadership of an
+// application over from one unit to another before it expires.
+type Transferer interface {
+
+	// TransferLeadership arranges for leadership of the named
+	// application to pass from the named unit to the new unit, for the
+	// supplied duration. The unit stays leader until its current claim
+	// runs out but can't renew it, so it finds out it is losing
+	// leadership before the new unit takes over, and no other unit can
+	// claim it in between. It returns an error satisfying
+	// IsNotLeaderError if the unit is not the leader, and
+	// ErrLeadershipPinned if leadership of the application is pinned.
+	TransferLeadership(applicationId, unitId, newUnitId string, duration time.Duration) error
+}
+
 // Token represents a unit's leadership of its application.
 type Token interface {
 
@@ -25,6 +25,10 @@ var ErrClaimDenied = errors.New("lease claim denied")
 // ErrNotHeld indicates that some holder does not hold some lease.
 var ErrNotHeld = errors.New("lease not held")
 
+// ErrPinned indicates that a lease cannot be transferred because it is
+// pinned.
+var ErrPinned = errors.New("lease pinned")
+
 // ErrWaitCancelled is returned by Claimer.WaitUntilExpired if the
 // cancel channel is closed.
 var ErrWaitCancelled = errors.New("waiting for lease cancelled by client")
@@ -67,6 +71,20 @@ type Pinner interface {
 	Pinned() map[string][]string
 }
 
+// Transferer describes methods used to hand leases over to another
+// holder before they expire.
+type Transferer interface {
+
+	// Transfer arranges for the named lease to pass from the named
+	// holder to the new holder, for the supplied duration. The holder
+	// keeps the lease until its current claim runs out but can no
+	// longer extend it; the lease then goes straight to the new
+	// holder, so that no other holder can claim it in between. It
+	// returns ErrNotHeld if the holder does not hold the lease, and
+	// ErrPinned if the lease is pinned.
+	Transfer(leaseName, holderName, newHolderName string, duration time.Duration) error
+}
+
 // Checker exposes facts about lease ownership.
 type Checker interface {
 
@@ -113,4 +131,5 @@ type Manager interface {
 	Claimer(namespace string, modelUUID string) (Claimer, error)
 	Pinner(namespace string, modelUUID string) (Pinner, error)
 	Reader(namespace string, modelUUID string) (Reader, error)
+	Transferer(namespace string, modelUUID string) (Transferer, error)
 }
@@ -32,6 +32,14 @@ type Store interface {
 	// have passed. If it returns ErrInvalid, check Leases() for updated state.
 	ExpireLease(lease Key) error
 
+	// TransferLease arranges for the supplied lease to pass from the
+	// supplied holder to the holder in the request when it expires,
+	// instead of becoming free. The holder can't extend the lease in
+	// the meantime. It will fail if the lease is not held by the
+	// holder, or if it is pinned. If it returns ErrInvalid, check
+	// Leases() for updated state.
+	TransferLease(lease Key, holder string, request Request, stop <-chan struct{}) error
+
 	// Leases returns a recent snapshot of lease state. Expiry times are
 	// expressed according to the Clock the store was configured with.
 	// Supplying any lease keys will filter the return for those requested.
@@ -97,6 +97,35 @@ type GenerationCommit struct {
 	Applications []GenerationApplication `yaml:"applications,omitempty"`
 }
 
+// CommitPreviewUnit describes the effect that committing
+// a branch would have on a single unit.
+type CommitPreviewUnit struct {
+	// UnitName is the name of the unit.
+	UnitName string `yaml:"unit"`
+
+	// ConfigChanges are the charm configuration values that the unit
+	// would see change. A nil value indicates that the setting would
+	// become unset.
+	ConfigChanges map[string]interface{} `yaml:"config"`
+
+	// Hooks are the names of the hooks that would run on the unit.
+	Hooks []string `yaml:"hooks"`
+}
+
+// CommitPreviewApplication describes the effect that committing
+// a branch would have on an application changed under it.
+type CommitPreviewApplication struct {
+	// ApplicationsName is the name of the application.
+	ApplicationName string `yaml:"application"`
+
+	// InvalidConfigKeys are the settings changed under the branch
+	// that are not defined by the application's current charm.
+	InvalidConfigKeys []string `yaml:"invalid-config-keys,omitempty"`
+
+	// Units are the units whose configuration would change.
+	Units []CommitPreviewUnit `yaml:"units,omitempty"`
+}
+
 // GenerationSummaries is a type alias for a representation
 // of changes-by-generation.
 type GenerationSummaries = map[string]Generation
@@ -0,0 +1,195 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+)
+
+// Names of the resources that can be limited by a model quota.
+const (
+	// QuotaMachines limits the number of top level machines, which are
+	// the ones that consume cloud instances.
+	QuotaMachines = "machines"
+
+	// QuotaUnits limits the number of principal units. Subordinate
+	// units are not limited, as they are added by relations.
+	QuotaUnits = "units"
+
+	// QuotaCores limits the total CPU cores of the top level machines.
+	QuotaCores = "cores"
+
+	// QuotaMemory limits the total memory, in MiB, of the top level
+	// machines.
+	QuotaMemory = "memory"
+
+	// QuotaStorage limits the total size, in MiB, of the model's
+	// volumes and filesystems, including any they are being grown to,
+	// and of the storage requested by units that hasn't been assigned
+	// any yet.
+	QuotaStorage = "storage"
+)
+
+// QuotaNames returns the names of all the resources that can be
+// limited, in alphabetical order.
+func QuotaNames() []string {
+	return []string{QuotaCores, QuotaMachines, QuotaMemory, QuotaStorage, QuotaUnits}
+}
+
+// Quotas holds the limits on the resources a model may consume.
+// A nil limit means the resource is not limited.
+type Quotas struct {
+	Machines *uint64
+	Units    *uint64
+	Cores    *uint64
+	Memory   *uint64
+	Storage  *uint64
+}
+
+// Usage holds an amount of each resource that can be limited by a
+// quota, in the same units as the quota.
+type Usage struct {
+	Machines uint64
+	Units    uint64
+	Cores    uint64
+	Memory   uint64
+	Storage  uint64
+}
+
+// Add returns the sum of the two usages.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		Machines: u.Machines + other.Machines,
+		Units:    u.Units + other.Units,
+		Cores:    u.Cores + other.Cores,
+		Memory:   u.Memory + other.Memory,
+		Storage:  u.Storage + other.Storage,
+	}
+}
+
+// Get returns the amount of the named resource.
+func (u Usage) Get(name string) uint64 {
+	switch name {
+	case QuotaMachines:
+		return u.Machines
+	case QuotaUnits:
+		return u.Units
+	case QuotaCores:
+		return u.Cores
+	case QuotaMemory:
+		return u.Memory
+	case QuotaStorage:
+		return u.Storage
+	}
+	return 0
+}
+
+func (q *Quotas) limit(name string) (**uint64, error) {
+	switch name {
+	case QuotaMachines:
+		return &q.Machines, nil
+	case QuotaUnits:
+		return &q.Units, nil
+	case QuotaCores:
+		return &q.Cores, nil
+	case QuotaMemory:
+		return &q.Memory, nil
+	case QuotaStorage:
+		return &q.Storage, nil
+	}
+	return nil, errors.NotValidf("quota %q", name)
+}
+
+// Limits returns the quotas that are set, keyed by resource name.
+func (q Quotas) Limits() map[string]uint64 {
+	result := make(map[string]uint64)
+	for _, name := range QuotaNames() {
+		if limit, _ := q.limit(name); *limit != nil {
+			result[name] = **limit
+		}
+	}
+	return result
+}
+
+// IsEmpty returns true if no quota is set.
+func (q Quotas) IsEmpty() bool {
+	return len(q.Limits()) == 0
+}
+
+// Update returns a copy of the quotas with the given limits set and
+// the named limits removed. An error is returned if any name is not
+// that of a resource that can be limited.
+func (q Quotas) Update(set map[string]uint64, unset []string) (Quotas, error) {
+	result := Quotas{}
+	for name, value := range q.Limits() {
+		value := value
+		limit, _ := result.limit(name)
+		*limit = &value
+	}
+	for name, value := range set {
+		value := value
+		limit, err := result.limit(name)
+		if err != nil {
+			return Quotas{}, errors.Trace(err)
+		}
+		*limit = &value
+	}
+	for _, name := range unset {
+		limit, err := result.limit(name)
+		if err != nil {
+			return Quotas{}, errors.Trace(err)
+		}
+		*limit = nil
+	}
+	return result, nil
+}
+
+// Check returns a QuotaExceededError if adding the requested
+// resources to those currently used would exceed any quota.
+// Resources already over quota, for example because the quota was
+// lowered, only cause an error if more of them are requested.
+func (q Quotas) Check(current, requested Usage) error {
+	limits := q.Limits()
+	for _, name := range QuotaNames() {
+		limit, ok := limits[name]
+		if !ok || requested.Get(name) == 0 {
+			continue
+		}
+		if current.Get(name)+requested.Get(name) > limit {
+			return &QuotaExceededError{
+				Resource:  name,
+				Limit:     limit,
+				Current:   current.Get(name),
+				Requested: requested.Get(name),
+			}
+		}
+	}
+	return nil
+}
+
+// QuotaExceededError is returned when an operation would take a model
+// over one of its quotas.
+type QuotaExceededError struct {
+	Resource  string
+	Limit     uint64
+	Current   uint64
+	Requested uint64
+}
+
+// Error is part of the error interface.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"model %s quota exceeded: %d in use, %d requested, limit is %d",
+		e.Resource, e.Current, e.Requested, e.Limit,
+	)
+}
+
+// IsQuotaExceeded returns true if the cause of the error is a
+// QuotaExceededError.
+func IsQuotaExceeded(err error) bool {
+	_, ok := errors.Cause(err).(*QuotaExceededError)
+	return ok
+}
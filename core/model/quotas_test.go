@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+)
+
+type QuotasSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuotasSuite{})
+
+func limit(v uint64) *uint64 {
+	return &v
+}
+
+func (*QuotasSuite) TestLimits(c *gc.C) {
+	q := model.Quotas{Machines: limit(10), Memory: limit(0)}
+	c.Assert(q.Limits(), jc.DeepEquals, map[string]uint64{
+		"machines": 10,
+		"memory":   0,
+	})
+	c.Assert(q.IsEmpty(), jc.IsFalse)
+	c.Assert(model.Quotas{}.IsEmpty(), jc.IsTrue)
+}
+
+func (*QuotasSuite) TestUpdate(c *gc.C) {
+	q := model.Quotas{Machines: limit(10), Units: limit(20)}
+	updated, err := q.Update(map[string]uint64{"cores": 64, "units": 30}, []string{"machines"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated, jc.DeepEquals, model.Quotas{Units: limit(30), Cores: limit(64)})
+	// The original is unchanged.
+	c.Assert(q, jc.DeepEquals, model.Quotas{Machines: limit(10), Units: limit(20)})
+}
+
+func (*QuotasSuite) TestUpdateInvalidName(c *gc.C) {
+	_, err := model.Quotas{}.Update(map[string]uint64{"gpus": 1}, nil)
+	c.Assert(err, gc.ErrorMatches, `quota "gpus" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = model.Quotas{}.Update(nil, []string{"gpus"})
+	c.Assert(err, gc.ErrorMatches, `quota "gpus" not valid`)
+}
+
+func (*QuotasSuite) TestCheck(c *gc.C) {
+	q := model.Quotas{Machines: limit(3), Memory: limit(8192)}
+	current := model.Usage{Machines: 2, Units: 100, Memory: 4096}
+
+	err := q.Check(current, model.Usage{Machines: 1, Units: 5, Memory: 4096})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = q.Check(current, model.Usage{Machines: 2})
+	c.Assert(err, gc.ErrorMatches, "model machines quota exceeded: 2 in use, 2 requested, limit is 3")
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+	c.Assert(err, jc.DeepEquals, &model.QuotaExceededError{
+		Resource:  "machines",
+		Limit:     3,
+		Current:   2,
+		Requested: 2,
+	})
+
+	err = q.Check(current, model.Usage{Machines: 1, Memory: 4097})
+	c.Assert(err, gc.ErrorMatches, "model memory quota exceeded: .*")
+}
+
+func (*QuotasSuite) TestCheckAlreadyOverQuota(c *gc.C) {
+	q := model.Quotas{Machines: limit(1), Units: limit(1)}
+	current := model.Usage{Machines: 3, Units: 0}
+	c.Assert(q.Check(current, model.Usage{Units: 1}), jc.ErrorIsNil)
+	c.Assert(q.Check(current, model.Usage{Machines: 1}), jc.Satisfies, model.IsQuotaExceeded)
+}
+
+func (*QuotasSuite) TestIsQuotaExceeded(c *gc.C) {
+	err := errors.Annotate(&model.QuotaExceededError{Resource: "units"}, "adding units")
+	c.Assert(model.IsQuotaExceeded(err), jc.IsTrue)
+	c.Assert(model.IsQuotaExceeded(errors.New("boom")), jc.IsFalse)
+}
+
+func (*QuotasSuite) TestUsageAdd(c *gc.C) {
+	sum := model.Usage{Machines: 1, Units: 2, Cores: 3, Memory: 4, Storage: 5}.Add(
+		model.Usage{Machines: 10, Units: 20, Cores: 30, Memory: 40, Storage: 50})
+	c.Assert(sum, jc.DeepEquals, model.Usage{Machines: 11, Units: 22, Cores: 33, Memory: 44, Storage: 55})
+}
@@ -25,6 +25,7 @@ const (
 	uniterStateDir
 	jujuDumpLogs
 	jujuIntrospect
+	jujuHookTrace
 	jujuUpdateSeries
 	instanceCloudInitDir
 	cloudInitCfgDir
@@ -48,6 +49,7 @@ var nixVals = map[osVarType]string{
 	jujuRun:              "/usr/bin/juju-run",
 	jujuDumpLogs:         "/usr/bin/juju-dumplogs",
 	jujuIntrospect:       "/usr/bin/juju-introspect",
+	jujuHookTrace:        "/usr/bin/juju-hook-trace",
 	jujuUpdateSeries:     "/usr/bin/juju-updateseries",
 	certDir:              "/etc/juju/certs.d",
 	metricsSpoolDir:      "/var/lib/juju/metricspool",
@@ -66,6 +68,7 @@ var winVals = map[osVarType]string{
 	jujuRun:          "C:/Juju/bin/juju-run.exe",
 	jujuDumpLogs:     "C:/Juju/bin/juju-dumplogs.exe",
 	jujuIntrospect:   "C:/Juju/bin/juju-introspect.exe",
+	jujuHookTrace:    "C:/Juju/bin/juju-hook-trace.exe",
 	jujuUpdateSeries: "C:/Juju/bin/juju-updateseries.exe",
 	certDir:          "C:/Juju/certs",
 	metricsSpoolDir:  "C:/Juju/lib/juju/metricspool",
@@ -154,6 +157,12 @@ func JujuIntrospect(series string) (string, error) {
 	return osVal(series, jujuIntrospect)
 }
 
+// JujuHookTrace returns the absolute path to the juju-hook-trace
+// binary for a particular series.
+func JujuHookTrace(series string) (string, error) {
+	return osVal(series, jujuHookTrace)
+}
+
 // MachineCloudInitDir returns the absolute path to the instance
 // cloudinit directory for a particular series.
 func MachineCloudInitDir(series string) (string, error) {
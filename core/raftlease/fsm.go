@@ -45,6 +45,12 @@ const (
 	// OperationUnpin unpins a lease, restoring normal
 	// lease expiry behaviour.
 	OperationUnpin = "unpin"
+
+	// OperationTransfer arranges for a lease held by a particular
+	// holder to pass to a new holder. The current holder can no
+	// longer extend the lease, and it changes hands once the current
+	// holder's claim runs out.
+	OperationTransfer = "transfer"
 )
 
 // FSMResponse defines what will be available on the return value from
@@ -138,6 +144,11 @@ func (f *FSM) extend(key lease.Key, holder string, duration time.Duration) *resp
 	if entry.holder != holder {
 		return invalidResponse()
 	}
+	if entry.nextHolder != "" {
+		// The lease is being transferred; the holder keeps it until
+		// its current claim runs out but can't stretch that out.
+		return invalidResponse()
+	}
 	expiry := f.globalTime.Add(duration)
 	if !expiry.After(entry.start.Add(entry.duration)) {
 		// No extension needed - the lease already expires after the
@@ -151,6 +162,24 @@ func (f *FSM) extend(key lease.Key, holder string, duration time.Duration) *resp
 	return &response{}
 }
 
+func (f *FSM) transfer(key lease.Key, holder, newHolder string, duration time.Duration) *response {
+	entries, groupFound := f.getGroup(key)
+	if !groupFound {
+		return invalidResponse()
+	}
+	entry, found := entries[key]
+	if !found || entry.holder != holder || f.isPinned(key) {
+		return invalidResponse()
+	}
+	// The current holder was promised the lease until its expiry, so
+	// it keeps it until then; it can't extend it any more, and when
+	// it runs out the lease goes straight to the new holder rather
+	// than becoming free for anyone else to claim.
+	entry.nextHolder = newHolder
+	entry.nextDuration = duration
+	return &response{}
+}
+
 func (f *FSM) pin(key lease.Key, entity string) *response {
 	if f.pinned[key] == nil {
 		f.pinned[key] = set.NewStrings()
@@ -171,26 +200,45 @@ func (f *FSM) setTime(oldTime, newTime time.Time) *response {
 		return &response{err: globalclock.ErrConcurrentUpdate}
 	}
 	f.globalTime = newTime
-	return &response{expired: f.removeExpired(newTime)}
+	expired, transferred := f.removeExpired(newTime)
+	return &response{expired: expired, transferred: transferred}
 }
 
-// expired returns a collection of keys for leases that have expired.
+// removeExpired removes leases that have expired, returning their
+// keys. Expired leases that are being transferred pass to their new
+// holder instead, and are returned in the map of new holders by key.
 // Any pinned leases are not included in the return.
-func (f *FSM) removeExpired(newTime time.Time) []lease.Key {
-	var expired []lease.Key
+func (f *FSM) removeExpired(newTime time.Time) ([]lease.Key, map[lease.Key]string) {
+	var (
+		expired     []lease.Key
+		transferred map[lease.Key]string
+	)
 	for gKey, entries := range f.groups {
 		for key, entry := range entries {
 			expiry := entry.start.Add(entry.duration)
-			if expiry.Before(newTime) && !f.isPinned(key) {
-				delete(entries, key)
-				expired = append(expired, key)
+			if !expiry.Before(newTime) || f.isPinned(key) {
+				continue
 			}
+			if entry.nextHolder != "" {
+				if transferred == nil {
+					transferred = make(map[lease.Key]string)
+				}
+				transferred[key] = entry.nextHolder
+				entry.holder = entry.nextHolder
+				entry.start = newTime
+				entry.duration = entry.nextDuration
+				entry.nextHolder = ""
+				entry.nextDuration = 0
+				continue
+			}
+			delete(entries, key)
+			expired = append(expired, key)
 		}
 		if len(entries) == 0 {
 			delete(f.groups, gKey)
 		}
 	}
-	return expired
+	return expired, transferred
 }
 
 // GlobalTime returns the FSM's internal time.
@@ -306,16 +354,24 @@ type entry struct {
 	// duration is the duration for which the lease is valid,
 	// from the start time.
 	duration time.Duration
+
+	// nextHolder, if set, identifies the holder the lease is being
+	// transferred to when it expires.
+	nextHolder string
+
+	// nextDuration is the duration of the lease for nextHolder.
+	nextDuration time.Duration
 }
 
 var _ FSMResponse = (*response)(nil)
 
 // response stores what happened as a result of applying a command.
 type response struct {
-	err     error
-	claimer string
-	claimed lease.Key
-	expired []lease.Key
+	err         error
+	claimer     string
+	claimed     lease.Key
+	expired     []lease.Key
+	transferred map[lease.Key]string
 }
 
 // Error is part of FSMResponse.
@@ -325,14 +381,18 @@ func (r *response) Error() error {
 
 // Notify is part of FSMResponse.
 func (r *response) Notify(target NotifyTarget) {
-	// This response is either for a claim (in which case claimer will
-	// be set) or a set-time (so it will have zero or more expiries).
+	// This response is either for a claim (in which case claimer
+	// will be set) or a set-time (so it will have zero or more
+	// expiries and transfers).
 	if r.claimer != "" {
 		target.Claimed(r.claimed, r.claimer)
 	}
 	for _, expiredKey := range r.expired {
 		target.Expired(expiredKey)
 	}
+	for key, holder := range r.transferred {
+		target.Claimed(key, holder)
+	}
 }
 
 func invalidResponse() *response {
@@ -358,6 +418,8 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		return f.claim(command.LeaseKey(), command.Holder, command.Duration)
 	case OperationExtend:
 		return f.extend(command.LeaseKey(), command.Holder, command.Duration)
+	case OperationTransfer:
+		return f.transfer(command.LeaseKey(), command.Holder, command.NewHolder, command.Duration)
 	case OperationPin:
 		return f.pin(command.LeaseKey(), command.PinEntity)
 	case OperationUnpin:
@@ -381,9 +443,11 @@ func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
 				ModelUUID: key.ModelUUID,
 				Lease:     key.Lease,
 			}] = SnapshotEntry{
-				Holder:   entry.holder,
-				Start:    entry.start,
-				Duration: entry.duration,
+				Holder:       entry.holder,
+				Start:        entry.start,
+				Duration:     entry.duration,
+				NextHolder:   entry.nextHolder,
+				NextDuration: entry.nextDuration,
 			}
 		}
 	}
@@ -443,9 +507,11 @@ func (f *FSM) Restore(reader io.ReadCloser) error {
 			ModelUUID: key.ModelUUID,
 			Lease:     key.Lease,
 		}] = &entry{
-			holder:   ssEntry.Holder,
-			start:    ssEntry.Start,
-			duration: ssEntry.Duration,
+			holder:       ssEntry.Holder,
+			start:        ssEntry.Start,
+			duration:     ssEntry.Duration,
+			nextHolder:   ssEntry.NextHolder,
+			nextDuration: ssEntry.NextDuration,
 		}
 	}
 
@@ -505,9 +571,11 @@ type SnapshotKey struct {
 
 // SnapshotEntry defines the format of a lease entry in a snapshot.
 type SnapshotEntry struct {
-	Holder   string        `yaml:"holder"`
-	Start    time.Time     `yaml:"start"`
-	Duration time.Duration `yaml:"duration"`
+	Holder       string        `yaml:"holder"`
+	Start        time.Time     `yaml:"start"`
+	Duration     time.Duration `yaml:"duration"`
+	NextHolder   string        `yaml:"next-holder,omitempty"`
+	NextDuration time.Duration `yaml:"next-duration,omitempty"`
 }
 
 // Command captures the details of an operation to be run on the FSM.
@@ -516,7 +584,8 @@ type Command struct {
 	// to handle multiple formats.
 	Version int `yaml:"version"`
 
-	// Operation is one of claim, extend, expire or setTime.
+	// Operation is one of claim, extend, transfer, pin, unpin or
+	// setTime.
 	Operation string `yaml:"operation"`
 
 	// Namespace is the kind of lease.
@@ -528,10 +597,14 @@ type Command struct {
 	// Lease is the name of the lease the command affects.
 	Lease string `yaml:"lease,omitempty"`
 
-	// Holder is the name of the party claiming or extending the
-	// lease.
+	// Holder is the name of the party claiming, extending or
+	// transferring the lease.
 	Holder string `yaml:"holder,omitempty"`
 
+	// NewHolder is the name of the party to which the lease is
+	// transferred.
+	NewHolder string `yaml:"new-holder,omitempty"`
+
 	// Duration is how long the lease should last.
 	Duration time.Duration `yaml:"duration,omitempty"`
 
@@ -570,6 +643,25 @@ func (c *Command) Validate() error {
 		if c.PinEntity != "" {
 			return errors.NotValidf("%s with pin entity", c.Operation)
 		}
+	case OperationTransfer:
+		if err := c.validateLeaseKey(); err != nil {
+			return err
+		}
+		if err := c.validateNoTime(); err != nil {
+			return err
+		}
+		if c.Holder == "" {
+			return errors.NotValidf("%s with empty holder", c.Operation)
+		}
+		if c.NewHolder == "" {
+			return errors.NotValidf("%s with empty new holder", c.Operation)
+		}
+		if c.Duration == 0 {
+			return errors.NotValidf("%s with zero duration", c.Operation)
+		}
+		if c.PinEntity != "" {
+			return errors.NotValidf("%s with pin entity", c.Operation)
+		}
 	case OperationPin, OperationUnpin:
 		if err := c.validateLeaseKey(); err != nil {
 			return err
@@ -144,6 +144,137 @@ func (s *fsmSuite) TestExtend(c *gc.C) {
 	assertNoNotifications(c, resp)
 }
 
+func (s *fsmSuite) TestTransfer(c *gc.C) {
+	key := lease.Key{Namespace: "ns", ModelUUID: "model", Lease: "lease"}
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		NewHolder: "you",
+		Duration:  time.Minute,
+	}
+	// Can't transfer a lease that isn't held.
+	resp := s.apply(c, command)
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+	assertNoNotifications(c, resp)
+
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}).Error(), jc.ErrorIsNil)
+
+	// Someone else can't transfer it.
+	command.Holder = "them"
+	resp = s.apply(c, command)
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+	assertNoNotifications(c, resp)
+
+	// The holder can, but keeps the lease until its claim runs out.
+	command.Holder = "me"
+	resp = s.apply(c, command)
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertNoNotifications(c, resp)
+	c.Assert(s.fsm.Leases(timeDelegate(zero)), gc.DeepEquals, map[lease.Key]lease.Info{
+		key: {
+			Holder: "me",
+			Expiry: offset(time.Second),
+		},
+	})
+
+	// Meanwhile the holder can't extend it.
+	resp = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationExtend,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Minute,
+	})
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+
+	// Once it expires, the new holder gets it with the new duration.
+	resp = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   zero,
+		NewTime:   zero.Add(2 * time.Second),
+	})
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertClaimed(c, resp, key, "you")
+	c.Assert(s.fsm.Leases(timeDelegate(zero)), gc.DeepEquals, map[lease.Key]lease.Info{
+		key: {
+			Holder: "you",
+			Expiry: offset(time.Minute),
+		},
+	})
+
+	// At no point was the lease free to be claimed by anyone else.
+	resp = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "them",
+		Duration:  time.Minute,
+	})
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+
+	// And the new holder can extend it as usual.
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationExtend,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "you",
+		Duration:  2 * time.Minute,
+	}).Error(), jc.ErrorIsNil)
+}
+
+func (s *fsmSuite) TestTransferPinned(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Minute,
+	}).Error(), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: names.NewMachineTag("0").String(),
+	}).Error(), jc.ErrorIsNil)
+
+	resp := s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		NewHolder: "you",
+		Duration:  time.Minute,
+	})
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+	assertNoNotifications(c, resp)
+	c.Assert(s.fsm.Leases(timeDelegate(zero))[lease.Key{"ns", "model", "lease"}].Holder, gc.Equals, "me")
+}
+
 func (s *fsmSuite) TestSetTime(c *gc.C) {
 	// Time always starts at 0.
 	resp := s.apply(c, raftlease.Command{
@@ -661,6 +792,16 @@ func (s *fsmSuite) TestSnapshot(c *gc.C) {
 		Holder:    "you",
 		Duration:  4 * time.Second,
 	}).Error(), jc.ErrorIsNil)
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns2",
+		ModelUUID: "model2",
+		Lease:     "lease",
+		Holder:    "you",
+		NewHolder: "them",
+		Duration:  time.Minute,
+	}).Error(), jc.ErrorIsNil)
 
 	machineTag := names.NewMachineTag("0")
 	c.Assert(s.apply(c, raftlease.Command{
@@ -683,9 +824,11 @@ func (s *fsmSuite) TestSnapshot(c *gc.C) {
 				Duration: 3 * time.Second,
 			},
 			{"ns2", "model2", "lease"}: {
-				Holder:   "you",
-				Start:    zero.Add(2 * time.Second),
-				Duration: 4 * time.Second,
+				Holder:       "you",
+				Start:        zero.Add(2 * time.Second),
+				Duration:     4 * time.Second,
+				NextHolder:   "them",
+				NextDuration: time.Minute,
 			},
 		},
 		GlobalTime: zero.Add(2 * time.Second),
@@ -822,6 +965,28 @@ func (s *fsmSuite) TestCommandValidationSetTime(c *gc.C) {
 	c.Assert(command.Validate(), gc.ErrorMatches, "setTime with zero new time not valid")
 }
 
+func (s *fsmSuite) TestCommandValidationTransfer(c *gc.C) {
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "you",
+		NewHolder: "me",
+		Duration:  time.Second,
+	}
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Duration = 0
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer with zero duration not valid")
+	command.Duration = time.Second
+	command.NewHolder = ""
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer with empty new holder not valid")
+	command.NewHolder = "me"
+	command.Holder = ""
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer with empty holder not valid")
+}
+
 func (s *fsmSuite) TestCommandValidationPin(c *gc.C) {
 	command := raftlease.Command{
 		Version:   1,
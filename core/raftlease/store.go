@@ -140,6 +140,20 @@ func (s *Store) ExpireLease(key lease.Key) error {
 	return lease.ErrInvalid
 }
 
+// TransferLease is part of lease.Store.
+func (s *Store) TransferLease(key lease.Key, holder string, req lease.Request, stop <-chan struct{}) error {
+	return errors.Trace(s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationTransfer,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		Holder:    holder,
+		NewHolder: req.Holder,
+		Duration:  req.Duration,
+	}, stop))
+}
+
 // Leases is part of lease.Store.
 func (s *Store) Leases(keys ...lease.Key) map[lease.Key]lease.Info {
 	leaseMap := s.fsm.Leases(s.config.Clock.Now, keys...)
@@ -392,6 +392,37 @@ func (s *storeSuite) TestLeaseGroup(c *gc.C) {
 	c.Assert(out, gc.Equals, "{la cry mosa} held by mozart")
 }
 
+func (s *storeSuite) TestTransfer(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.TransferLease(
+				lease.Key{"warframe", "rhino", "prime"},
+				"lotus",
+				lease.Request{"vor", time.Minute},
+				nil,
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationTransfer,
+			Namespace: "warframe",
+			ModelUUID: "rhino",
+			Lease:     "prime",
+			Holder:    "lotus",
+			NewHolder: "vor",
+			Duration:  time.Minute,
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
 func (s *storeSuite) TestPin(c *gc.C) {
 	machine := names.NewMachineTag("0").String()
 	s.handleHubRequest(c,
@@ -0,0 +1,206 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package schedule parses cron-like schedule specifications and
+// computes when they next fire.
+package schedule
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron-like schedule. The zero value is not
+// valid; use Parse to create one.
+type Schedule struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day of month and day
+	// of week fields were "*". As with cron, when both are
+	// restricted a day matches if either field matches.
+	domStar, dowStar bool
+}
+
+type field struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "day of week", min: 0, max: 6, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var shorthands = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// Parse parses a schedule in the five field cron format
+// "minute hour day-of-month month day-of-week". Each field may be
+// "*", a value, a range "a-b", a list "a,b,c" or any of those with
+// a step "/n". Months and days of the week may also be given by
+// their three letter English names. The shorthands @hourly, @daily,
+// @weekly, @monthly and @yearly are also accepted.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	expanded := spec
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if expanded, ok = shorthands[strings.ToLower(spec)]; !ok {
+			return nil, errors.NotValidf("schedule %q", spec)
+		}
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	s := &Schedule{
+		spec:    spec,
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	var err error
+	for i, f := range []struct {
+		bits  *uint64
+		field field
+	}{
+		{&s.minute, minuteField},
+		{&s.hour, hourField},
+		{&s.dom, domField},
+		{&s.month, monthField},
+		{&s.dow, dowField},
+	} {
+		if *f.bits, err = f.field.parse(fields[i]); err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+	}
+	return s, nil
+}
+
+// String returns the specification the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+func (f field) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, uint(1)
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, errors.NotValidf("%s step %q", f.name, part[i+1:])
+			}
+			rangePart, step = part[:i], uint(n)
+		}
+		var lo, hi uint
+		switch {
+		case rangePart == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if lo > hi {
+				return 0, errors.NotValidf("%s range %q", f.name, rangePart)
+			}
+		default:
+			v, err := f.value(rangePart)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				// As with cron, "n/step" means from n to the maximum.
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (f field) value(s string) (uint, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, errors.NotValidf("%s %q", f.name, s)
+	}
+	v := uint(n)
+	if f.name == dowField.name && v == 7 {
+		// Sunday may be given as 0 or 7.
+		v = 0
+	}
+	if v < f.min || v > f.max {
+		return 0, errors.NotValidf("%s %d (expected %d-%d)", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that the schedule fires, in
+// t's location. Seconds and smaller units are always zero. The zero
+// time is returned if the schedule never fires, as for "0 0 31 2 *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// A schedule that fires at all fires within a few years
+	// (29 February recurs at least every 8 years).
+	limit := t.AddDate(8, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
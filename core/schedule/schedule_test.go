@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schedule_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/schedule"
+)
+
+type ScheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var nextTests = []struct {
+	spec string
+	from string
+	next string
+}{
+	{"* * * * *", "2020-03-04 10:15", "2020-03-04 10:16"},
+	{"30 2 * * *", "2020-03-04 10:15", "2020-03-05 02:30"},
+	{"30 2 * * *", "2020-03-04 01:15", "2020-03-04 02:30"},
+	{"*/15 * * * *", "2020-03-04 10:15", "2020-03-04 10:30"},
+	{"5/20 * * * *", "2020-03-04 10:46", "2020-03-04 11:05"},
+	{"0 9-17/4 * * *", "2020-03-04 13:00", "2020-03-04 17:00"},
+	{"0 0 * * sun", "2020-03-04 10:15", "2020-03-08 00:00"},
+	{"0 0 * * 7", "2020-03-04 10:15", "2020-03-08 00:00"},
+	{"0 0 1,15 * *", "2020-03-04 10:15", "2020-03-15 00:00"},
+	{"0 0 1 jan *", "2020-03-04 10:15", "2021-01-01 00:00"},
+	{"0 0 29 2 *", "2021-03-04 10:15", "2024-02-29 00:00"},
+	// Either the day of month or the day of week may match when
+	// both are restricted.
+	{"0 0 13 * fri", "2020-03-04 10:15", "2020-03-06 00:00"},
+	{"@hourly", "2020-03-04 10:15", "2020-03-04 11:00"},
+	{"@daily", "2020-12-31 10:15", "2021-01-01 00:00"},
+	{"@weekly", "2020-03-04 10:15", "2020-03-08 00:00"},
+	{"@monthly", "2020-03-04 10:15", "2020-04-01 00:00"},
+}
+
+func (*ScheduleSuite) TestNext(c *gc.C) {
+	for i, test := range nextTests {
+		c.Logf("test %d: %q from %s", i, test.spec, test.from)
+		s, err := schedule.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.Next(at(test.from)), gc.Equals, at(test.next))
+		c.Check(s.String(), gc.Equals, test.spec)
+	}
+}
+
+func (*ScheduleSuite) TestNextIgnoresSeconds(c *gc.C) {
+	s, err := schedule.Parse("* * * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	from := at("2020-03-04 10:15").Add(59 * time.Second)
+	c.Assert(s.Next(from), gc.Equals, at("2020-03-04 10:16"))
+}
+
+func (*ScheduleSuite) TestNextNeverFires(c *gc.C) {
+	s, err := schedule.Parse("0 0 31 2 *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Next(at("2020-03-04 10:15")).IsZero(), jc.IsTrue)
+}
+
+var parseErrorTests = []struct {
+	spec string
+	err  string
+}{
+	{"", `schedule "": expected 5 fields, got 0 not valid`},
+	{"* * * *", `schedule "\* \* \* \*": expected 5 fields, got 4 not valid`},
+	{"@often", `schedule "@often" not valid`},
+	{"60 * * * *", `schedule "60 \* \* \* \*": minute 60 \(expected 0-59\) not valid`},
+	{"* 24 * * *", `schedule .*: hour 24 \(expected 0-23\) not valid`},
+	{"* * 0 * *", `schedule .*: day of month 0 \(expected 1-31\) not valid`},
+	{"* * * 13 *", `schedule .*: month 13 \(expected 1-12\) not valid`},
+	{"* * * * mon-sun", `schedule .*: day of week range "mon-sun" not valid`},
+	{"*/0 * * * *", `schedule .*: minute step "0" not valid`},
+	{"a * * * *", `schedule .*: minute "a" not valid`},
+}
+
+func (*ScheduleSuite) TestParseErrors(c *gc.C) {
+	for i, test := range parseErrorTests {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := schedule.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
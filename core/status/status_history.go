@@ -96,6 +96,34 @@ const (
 	KindContainer HistoryKind = "juju-container"
 )
 
+// The following kinds of entry are only reported when exporting the
+// status history of a whole model, and cannot be requested by
+// 'show-status-log'.
+const (
+	// KindModel represents an entry for the model itself.
+	KindModel HistoryKind = "model"
+	// KindApplication represents an entry for an application.
+	KindApplication HistoryKind = "application"
+	// KindApplicationOperator represents an entry for the operator
+	// of an application in a CAAS model.
+	KindApplicationOperator HistoryKind = "operator"
+	// KindRemoteApplication represents an entry for an application
+	// in another model.
+	KindRemoteApplication HistoryKind = "remote-application"
+	// KindRelation represents an entry for a relation.
+	KindRelation HistoryKind = "relation"
+	// KindWorkloadVersion represents an entry for the workload
+	// version of a unit.
+	KindWorkloadVersion HistoryKind = "workload-version"
+	// KindMachineModification represents an entry for changes made
+	// to a machine, such as applying LXD profiles.
+	KindMachineModification HistoryKind = "machine-modification"
+	// KindVolume represents an entry for a storage volume.
+	KindVolume HistoryKind = "volume"
+	// KindFilesystem represents an entry for a storage filesystem.
+	KindFilesystem HistoryKind = "filesystem"
+)
+
 // String returns a string representation of the HistoryKind.
 func (k HistoryKind) String() string {
 	return string(k)
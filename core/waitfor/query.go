@@ -0,0 +1,337 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package waitfor provides the query language used to wait for
+// entities in the model cache to reach a desired state.
+package waitfor
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+// Kind identifies the type of entity a query is evaluated against.
+type Kind string
+
+const (
+	KindModel       Kind = "model"
+	KindApplication Kind = "application"
+	KindUnit        Kind = "unit"
+	KindMachine     Kind = "machine"
+)
+
+// Validate returns an error if the kind is not one that can be waited on.
+func (k Kind) Validate() error {
+	if _, ok := kindFields[k]; !ok {
+		return errors.NotValidf("entity kind %q", string(k))
+	}
+	return nil
+}
+
+// Field names that can be used in a query.
+const (
+	FieldStatus         = "status"
+	FieldLife           = "life"
+	FieldWorkloadStatus = "workload-status"
+	FieldAgentStatus    = "agent-status"
+	FieldInstanceStatus = "instance-status"
+	FieldExposed        = "exposed"
+	FieldApplications   = "applications"
+	FieldMachines       = "machines"
+	FieldUnits          = "units"
+	FieldCharmRevision  = "charm-revision"
+	FieldMachine        = "machine"
+)
+
+// numericFields are compared as integers and are the only
+// fields that support the ordering operators.
+var numericFields = map[string]bool{
+	FieldApplications:  true,
+	FieldMachines:      true,
+	FieldUnits:         true,
+	FieldCharmRevision: true,
+}
+
+// kindFields holds the fields that can be queried for each kind of entity.
+var kindFields = map[Kind][]string{
+	KindModel: {
+		FieldStatus, FieldLife, FieldApplications, FieldMachines, FieldUnits,
+		FieldWorkloadStatus, FieldAgentStatus,
+	},
+	KindApplication: {
+		FieldStatus, FieldLife, FieldExposed, FieldUnits, FieldCharmRevision,
+		FieldWorkloadStatus, FieldAgentStatus,
+	},
+	KindUnit: {
+		FieldWorkloadStatus, FieldAgentStatus, FieldLife, FieldCharmRevision,
+		FieldMachine,
+	},
+	KindMachine: {
+		FieldStatus, FieldInstanceStatus, FieldLife, FieldUnits,
+	},
+}
+
+// Fields returns the names of the fields that can be queried for
+// the kind, in alphabetical order.
+func Fields(kind Kind) []string {
+	fields := append([]string(nil), kindFields[kind]...)
+	sort.Strings(fields)
+	return fields
+}
+
+// Operator is a comparison operator used in a query clause.
+type Operator string
+
+const (
+	Equal          Operator = "=="
+	NotEqual       Operator = "!="
+	Less           Operator = "<"
+	LessOrEqual    Operator = "<="
+	Greater        Operator = ">"
+	GreaterOrEqual Operator = ">="
+)
+
+// Clause is a single comparison of a field against a value.
+type Clause struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+// String returns the clause as it would be written in a query.
+func (c Clause) String() string {
+	return c.Field + " " + string(c.Operator) + " " + strconv.Quote(c.Value)
+}
+
+// Query is a conjunction of clauses evaluated against one kind of entity.
+type Query struct {
+	Kind    Kind
+	Clauses []Clause
+}
+
+// Values holds the current values of the fields of an entity. A field
+// may hold more than one value when it is derived from several
+// entities, such as the workload status of each of an application's
+// units.
+type Values map[string][]string
+
+// Match reports whether the values satisfy every clause in the query.
+// A clause over a field with several values must hold for each of
+// them. A clause over a field with no values, such as the workload
+// status of an application without units, does not hold: waiting
+// for the units to be active should not end before there are any.
+func (q Query) Match(values Values) bool {
+	for _, clause := range q.Clauses {
+		fieldValues := values[clause.Field]
+		if len(fieldValues) == 0 {
+			return false
+		}
+		for _, value := range fieldValues {
+			if !clause.match(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c Clause) match(value string) bool {
+	if !numericFields[c.Field] {
+		switch c.Operator {
+		case Equal:
+			return value == c.Value
+		case NotEqual:
+			return value != c.Value
+		}
+		return false
+	}
+	// Numeric values have been checked at parse time, but the
+	// actual value may not be known, such as a charm revision
+	// from a URL without one.
+	want, _ := strconv.Atoi(c.Value)
+	got, err := strconv.Atoi(value)
+	if err != nil {
+		return c.Operator == NotEqual
+	}
+	switch c.Operator {
+	case Equal:
+		return got == want
+	case NotEqual:
+		return got != want
+	case Less:
+		return got < want
+	case LessOrEqual:
+		return got <= want
+	case Greater:
+		return got > want
+	case GreaterOrEqual:
+		return got >= want
+	}
+	return false
+}
+
+// Parse parses a query expression for the given kind of entity. An
+// expression is one or more clauses of the form
+//
+//	<field> <operator> <value>
+//
+// joined by "&&" or "and". Values containing spaces or operator
+// characters may be quoted with single or double quotes.
+func Parse(kind Kind, expr string) (Query, error) {
+	if err := kind.Validate(); err != nil {
+		return Query{}, errors.Trace(err)
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return Query{}, errors.Trace(err)
+	}
+	if len(tokens) == 0 {
+		return Query{}, errors.NotValidf("empty query")
+	}
+
+	query := Query{Kind: kind}
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return Query{}, errors.Errorf("incomplete clause %q", joinTokens(tokens))
+		}
+		clause, err := parseClause(kind, tokens[0], tokens[1], tokens[2])
+		if err != nil {
+			return Query{}, errors.Trace(err)
+		}
+		query.Clauses = append(query.Clauses, clause)
+		tokens = tokens[3:]
+		if len(tokens) == 0 {
+			break
+		}
+		if !tokens[0].isAnd() {
+			return Query{}, errors.Errorf("expected \"&&\" after %q, got %q", clause.String(), tokens[0].text)
+		}
+		tokens = tokens[1:]
+		if len(tokens) == 0 {
+			return Query{}, errors.Errorf("expected clause after \"&&\"")
+		}
+	}
+	return query, nil
+}
+
+func parseClause(kind Kind, field, op, value token) (Clause, error) {
+	if field.kind != tokenWord {
+		return Clause{}, errors.Errorf("expected field name, got %q", field.text)
+	}
+	if !isKindField(kind, field.text) {
+		return Clause{}, errors.Errorf(
+			"field %q not valid for %s (expected one of %s)",
+			field.text, kind, strings.Join(Fields(kind), ", "))
+	}
+	if op.kind != tokenOperator || op.isAnd() {
+		return Clause{}, errors.Errorf("expected operator after %q, got %q", field.text, op.text)
+	}
+	if value.kind == tokenOperator {
+		return Clause{}, errors.Errorf("expected value after %q, got %q", field.text+" "+op.text, value.text)
+	}
+	operator := Operator(op.text)
+	if numericFields[field.text] {
+		if _, err := strconv.Atoi(value.text); err != nil {
+			return Clause{}, errors.Errorf("field %q expects an integer value, got %q", field.text, value.text)
+		}
+	} else if operator != Equal && operator != NotEqual {
+		return Clause{}, errors.Errorf("operator %q not valid for field %q (expected == or !=)", op.text, field.text)
+	}
+	return Clause{
+		Field:    field.text,
+		Operator: operator,
+		Value:    value.text,
+	}, nil
+}
+
+func isKindField(kind Kind, field string) bool {
+	for _, f := range kindFields[kind] {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuoted
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) isAnd() bool {
+	switch t.kind {
+	case tokenOperator:
+		return t.text == "&&"
+	case tokenWord:
+		return strings.ToLower(t.text) == "and"
+	}
+	return false
+}
+
+func joinTokens(tokens []token) string {
+	texts := make([]string, len(tokens))
+	for i, t := range tokens {
+		texts[i] = t.text
+	}
+	return strings.Join(texts, " ")
+}
+
+func isOperatorChar(r rune) bool {
+	return strings.ContainsRune("=!<>&", r)
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.Errorf("unterminated quoted value %q", string(runes[i:]))
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, text: string(runes[i+1 : end])})
+			i = end + 1
+		case isOperatorChar(r):
+			end := i
+			for end < len(runes) && isOperatorChar(runes[end]) {
+				end++
+			}
+			op := string(runes[i:end])
+			switch op {
+			case "==", "!=", "<", "<=", ">", ">=", "&&":
+			default:
+				return nil, errors.Errorf("unknown operator %q", op)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op})
+			i = end
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !isOperatorChar(runes[end]) &&
+				runes[end] != '"' && runes[end] != '\'' {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
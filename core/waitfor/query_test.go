@@ -0,0 +1,167 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/waitfor"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+func (s *QuerySuite) TestParse(c *gc.C) {
+	query, err := waitfor.Parse(waitfor.KindApplication,
+		`workload-status == active && agent-status==idle and units >= 3 && status != "waiting"`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(query, jc.DeepEquals, waitfor.Query{
+		Kind: waitfor.KindApplication,
+		Clauses: []waitfor.Clause{
+			{Field: "workload-status", Operator: waitfor.Equal, Value: "active"},
+			{Field: "agent-status", Operator: waitfor.Equal, Value: "idle"},
+			{Field: "units", Operator: waitfor.GreaterOrEqual, Value: "3"},
+			{Field: "status", Operator: waitfor.NotEqual, Value: "waiting"},
+		},
+	})
+}
+
+func (s *QuerySuite) TestParseQuotedValue(c *gc.C) {
+	query, err := waitfor.Parse(waitfor.KindUnit, `machine == '0/lxd/1'`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(query.Clauses, jc.DeepEquals, []waitfor.Clause{
+		{Field: "machine", Operator: waitfor.Equal, Value: "0/lxd/1"},
+	})
+}
+
+func (s *QuerySuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		kind waitfor.Kind
+		expr string
+		err  string
+	}{{
+		kind: "charm",
+		expr: "status == active",
+		err:  `entity kind "charm" not valid`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "  ",
+		err:  "empty query not valid",
+	}, {
+		kind: waitfor.KindModel,
+		expr: "status ==",
+		err:  `incomplete clause "status =="`,
+	}, {
+		kind: waitfor.KindMachine,
+		expr: "exposed == true",
+		err:  `field "exposed" not valid for machine \(expected one of instance-status, life, status, units\)`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "status => active",
+		err:  `unknown operator "=>"`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "status active available",
+		err:  `expected operator after "status", got "active"`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "status == == active",
+		err:  `expected value after "status ==", got "=="`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "status >= active",
+		err:  `operator ">=" not valid for field "status" \(expected == or !=\)`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "units > many",
+		err:  `field "units" expects an integer value, got "many"`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "units > 1 status == active",
+		err:  `expected "&&" after "units > \\"1\\"", got "status"`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: "units > 1 &&",
+		err:  `expected clause after "&&"`,
+	}, {
+		kind: waitfor.KindModel,
+		expr: `status == "active`,
+		err:  `unterminated quoted value "\\"active"`,
+	}} {
+		c.Logf("test %d: %q", i, test.expr)
+		_, err := waitfor.Parse(test.kind, test.expr)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	_, err := waitfor.Parse("charm", "status == active")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *QuerySuite) TestMatch(c *gc.C) {
+	query, err := waitfor.Parse(waitfor.KindApplication,
+		"workload-status == active && units >= 2 && charm-revision != 7")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		values  waitfor.Values
+		matched bool
+	}{{
+		values: waitfor.Values{
+			"workload-status": {"active", "active"},
+			"units":           {"2"},
+			"charm-revision":  {"8"},
+		},
+		matched: true,
+	}, {
+		values: waitfor.Values{
+			"workload-status": {"active", "maintenance"},
+			"units":           {"2"},
+			"charm-revision":  {"8"},
+		},
+		matched: false,
+	}, {
+		values: waitfor.Values{
+			"workload-status": {"active"},
+			"units":           {"1"},
+			"charm-revision":  {"8"},
+		},
+		matched: false,
+	}, {
+		values: waitfor.Values{
+			"workload-status": {"active", "active"},
+			"units":           {"3"},
+			"charm-revision":  {"7"},
+		},
+		matched: false,
+	}, {
+		// With no units there are no workload statuses to
+		// satisfy the clause.
+		values: waitfor.Values{
+			"units":          {"2"},
+			"charm-revision": {"-1"},
+		},
+		matched: false,
+	}} {
+		c.Logf("test %d", i)
+		c.Check(query.Match(test.values), gc.Equals, test.matched)
+	}
+}
+
+func (s *QuerySuite) TestMatchNoValues(c *gc.C) {
+	query, err := waitfor.Parse(waitfor.KindModel, "workload-status != blocked")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(query.Match(waitfor.Values{"units": {"0"}}), jc.IsFalse)
+	c.Check(query.Match(waitfor.Values{"units": {"1"}, "workload-status": {"active"}}), jc.IsTrue)
+}
+
+func (s *QuerySuite) TestFields(c *gc.C) {
+	c.Assert(waitfor.Fields(waitfor.KindUnit), jc.DeepEquals, []string{
+		"agent-status", "charm-revision", "life", "machine", "workload-status",
+	})
+}
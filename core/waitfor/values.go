@@ -0,0 +1,133 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+)
+
+// Model describes the parts of a cached model needed to evaluate
+// queries. It is satisfied by *cache.Model.
+type Model interface {
+	Life() life.Value
+	Status() status.StatusInfo
+	Applications() map[string]cache.Application
+	Units() map[string]cache.Unit
+	Machines() map[string]cache.Machine
+}
+
+// EntityValues returns the current values of the queryable fields of
+// the named entity in the model. The name is ignored for models.
+// A NotFound error is returned if the entity does not exist.
+func EntityValues(model Model, kind Kind, name string) (Values, error) {
+	switch kind {
+	case KindModel:
+		return modelValues(model), nil
+	case KindApplication:
+		return applicationValues(model, name)
+	case KindUnit:
+		return unitValues(model, name)
+	case KindMachine:
+		return machineValues(model, name)
+	}
+	return nil, errors.NotValidf("entity kind %q", string(kind))
+}
+
+func modelValues(model Model) Values {
+	units := model.Units()
+	values := Values{
+		FieldStatus:       {string(model.Status().Status)},
+		FieldLife:         {string(model.Life())},
+		FieldApplications: {strconv.Itoa(len(model.Applications()))},
+		FieldMachines:     {strconv.Itoa(len(model.Machines()))},
+		FieldUnits:        {strconv.Itoa(len(units))},
+	}
+	addUnitStatuses(values, units, func(cache.Unit) bool { return true })
+	return values
+}
+
+func applicationValues(model Model, name string) (Values, error) {
+	app, ok := model.Applications()[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	units := model.Units()
+	values := Values{
+		FieldStatus:        {string(app.Status().Status)},
+		FieldLife:          {string(app.Life())},
+		FieldExposed:       {strconv.FormatBool(app.Exposed())},
+		FieldCharmRevision: {charmRevision(app.CharmURL())},
+	}
+	count := addUnitStatuses(values, units, func(u cache.Unit) bool {
+		return u.Application() == name
+	})
+	values[FieldUnits] = []string{strconv.Itoa(count)}
+	return values, nil
+}
+
+func unitValues(model Model, name string) (Values, error) {
+	unit, ok := model.Units()[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return Values{
+		FieldWorkloadStatus: {string(unit.WorkloadStatus().Status)},
+		FieldAgentStatus:    {string(unit.AgentStatus().Status)},
+		FieldLife:           {string(unit.Life())},
+		FieldCharmRevision:  {charmRevision(unit.CharmURL())},
+		FieldMachine:        {unit.MachineId()},
+	}, nil
+}
+
+func machineValues(model Model, id string) (Values, error) {
+	machine, ok := model.Machines()[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	count := 0
+	for _, unit := range model.Units() {
+		if unit.MachineId() == id {
+			count++
+		}
+	}
+	return Values{
+		FieldStatus:         {string(machine.AgentStatus().Status)},
+		FieldInstanceStatus: {string(machine.InstanceStatus().Status)},
+		FieldLife:           {string(machine.Life())},
+		FieldUnits:          {strconv.Itoa(count)},
+	}, nil
+}
+
+// addUnitStatuses adds the workload and agent status of each unit
+// selected by the predicate to the values, returning the number of
+// units selected.
+func addUnitStatuses(values Values, units map[string]cache.Unit, include func(cache.Unit) bool) int {
+	count := 0
+	for _, unit := range units {
+		if !include(unit) {
+			continue
+		}
+		count++
+		values[FieldWorkloadStatus] = append(values[FieldWorkloadStatus], string(unit.WorkloadStatus().Status))
+		values[FieldAgentStatus] = append(values[FieldAgentStatus], string(unit.AgentStatus().Status))
+	}
+	return count
+}
+
+// charmRevision returns the revision from the charm URL, or "-1"
+// if the URL has no revision or cannot be parsed.
+func charmRevision(url string) string {
+	curl, err := charm.ParseURL(url)
+	if err != nil {
+		return "-1"
+	}
+	return strconv.Itoa(curl.Revision)
+}
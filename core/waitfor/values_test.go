@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/cache/cachetest"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/waitfor"
+)
+
+type ValuesSuite struct {
+	testing.IsolationSuite
+
+	model *cache.Model
+}
+
+var _ = gc.Suite(&ValuesSuite{})
+
+const modelUUID = "model-uuid"
+
+func (s *ValuesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	ctrl := cachetest.NewTestController(
+		cachetest.ModelEvents,
+		cachetest.ApplicationEvents,
+		cachetest.UnitEvents,
+		cachetest.MachineEvents,
+	)
+	ctrl.Init(c)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, ctrl.Controller) })
+
+	for _, change := range []interface{}{
+		cache.ModelChange{
+			ModelUUID: modelUUID,
+			Name:      "test",
+			Life:      life.Alive,
+			Status:    status.StatusInfo{Status: status.Available},
+		},
+		cache.ApplicationChange{
+			ModelUUID: modelUUID,
+			Name:      "mysql",
+			CharmURL:  "cs:mysql-42",
+			Life:      life.Alive,
+			Exposed:   true,
+			Status:    status.StatusInfo{Status: status.Active},
+		},
+		cache.MachineChange{
+			ModelUUID:      modelUUID,
+			Id:             "0",
+			Life:           life.Alive,
+			AgentStatus:    status.StatusInfo{Status: status.Started},
+			InstanceStatus: status.StatusInfo{Status: status.Running},
+		},
+		cache.UnitChange{
+			ModelUUID:      modelUUID,
+			Name:           "mysql/0",
+			Application:    "mysql",
+			CharmURL:       "cs:mysql-42",
+			MachineId:      "0",
+			Life:           life.Alive,
+			WorkloadStatus: status.StatusInfo{Status: status.Active},
+			AgentStatus:    status.StatusInfo{Status: status.Idle},
+		},
+		cache.UnitChange{
+			ModelUUID:      modelUUID,
+			Name:           "mysql/1",
+			Application:    "mysql",
+			CharmURL:       "cs:mysql",
+			MachineId:      "1",
+			Life:           life.Alive,
+			WorkloadStatus: status.StatusInfo{Status: status.Maintenance},
+			AgentStatus:    status.StatusInfo{Status: status.Executing},
+		},
+	} {
+		ctrl.SendChange(change)
+		_ = ctrl.NextChange(c)
+	}
+
+	var err error
+	s.model, err = ctrl.Model(modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ValuesSuite) TestModelValues(c *gc.C) {
+	values, err := waitfor.EntityValues(s.model, waitfor.KindModel, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["workload-status"], jc.SameContents, []string{"active", "maintenance"})
+	c.Assert(values["agent-status"], jc.SameContents, []string{"idle", "executing"})
+	delete(values, "workload-status")
+	delete(values, "agent-status")
+	c.Assert(values, jc.DeepEquals, waitfor.Values{
+		"status":       {"available"},
+		"life":         {"alive"},
+		"applications": {"1"},
+		"machines":     {"1"},
+		"units":        {"2"},
+	})
+}
+
+func (s *ValuesSuite) TestApplicationValues(c *gc.C) {
+	values, err := waitfor.EntityValues(s.model, waitfor.KindApplication, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["workload-status"], jc.SameContents, []string{"active", "maintenance"})
+	delete(values, "workload-status")
+	delete(values, "agent-status")
+	c.Assert(values, jc.DeepEquals, waitfor.Values{
+		"status":         {"active"},
+		"life":           {"alive"},
+		"exposed":        {"true"},
+		"charm-revision": {"42"},
+		"units":          {"2"},
+	})
+}
+
+func (s *ValuesSuite) TestUnitValues(c *gc.C) {
+	values, err := waitfor.EntityValues(s.model, waitfor.KindUnit, "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, waitfor.Values{
+		"workload-status": {"maintenance"},
+		"agent-status":    {"executing"},
+		"life":            {"alive"},
+		"charm-revision":  {"-1"},
+		"machine":         {"1"},
+	})
+}
+
+func (s *ValuesSuite) TestMachineValues(c *gc.C) {
+	values, err := waitfor.EntityValues(s.model, waitfor.KindMachine, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, waitfor.Values{
+		"status":          {"started"},
+		"instance-status": {"running"},
+		"life":            {"alive"},
+		"units":           {"1"},
+	})
+}
+
+func (s *ValuesSuite) TestNotFound(c *gc.C) {
+	_, err := waitfor.EntityValues(s.model, waitfor.KindApplication, "wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = waitfor.EntityValues(s.model, waitfor.KindUnit, "mysql/2")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = waitfor.EntityValues(s.model, waitfor.KindMachine, "1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
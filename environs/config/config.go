@@ -25,6 +25,8 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/jsonlines"
+	"github.com/juju/juju/logfwd/loki"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -189,6 +191,33 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdJSONHost sets the hostname:port of the server receiving
+	// newline-delimited JSON log records.
+	LogFwdJSONHost = "jsonlog-host"
+
+	// LogFwdJSONCACert sets the certificate of the CA that signed the
+	// JSON log server certificate. If it is not set, records are sent
+	// over plain TCP.
+	LogFwdJSONCACert = "jsonlog-ca-cert"
+
+	// LogFwdJSONClientCert sets the client certificate for JSON log
+	// forwarding.
+	LogFwdJSONClientCert = "jsonlog-client-cert"
+
+	// LogFwdJSONClientKey sets the client key for JSON log forwarding.
+	LogFwdJSONClientKey = "jsonlog-client-key"
+
+	// LogFwdLokiURL sets the URL of the Loki push endpoint.
+	LogFwdLokiURL = "loki-url"
+
+	// LogFwdLokiCACert sets the certificate of the CA that signed the
+	// Loki server certificate.
+	LogFwdLokiCACert = "loki-ca-cert"
+
+	// LogFwdLokiTenantID sets the tenant ID sent to a multi-tenant
+	// Loki endpoint.
+	LogFwdLokiTenantID = "loki-tenant-id"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -209,6 +238,21 @@ const (
 	// collection can grow to before it is pruned, eg "5M"
 	MaxStatusHistorySize = "max-status-history-size"
 
+	// StatusHistoryArchive is where status history entries are
+	// archived before they are pruned: "file" archives them to files
+	// in the controller's status-history-archive-dir, and "syslog"
+	// sends them to the model's syslog log forwarding host. If it is
+	// not set, pruned entries are discarded.
+	StatusHistoryArchive = "status-history-archive"
+
+	// StatusHistoryArchiveFileSize is the size a status history
+	// archive file can grow to before a new file is started, eg "100M"
+	StatusHistoryArchiveFileSize = "status-history-archive-file-size"
+
+	// StatusHistoryArchiveAge is how long status history archive files
+	// are kept after they were last written, eg "8760h"
+	StatusHistoryArchiveAge = "status-history-archive-age"
+
 	// MaxActionResultsAge is the maximum age of actions to keep when pruning, eg
 	// "72h"
 	MaxActionResultsAge = "max-action-results-age"
@@ -408,6 +452,23 @@ const (
 	// DefaultStatusHistorySize is the default value for MaxStatusHistorySize.
 	DefaultStatusHistorySize = "5G"
 
+	// StatusHistoryArchiveFile is the StatusHistoryArchive value that
+	// archives status history to files on the controller.
+	StatusHistoryArchiveFile = "file"
+
+	// StatusHistoryArchiveSyslog is the StatusHistoryArchive value
+	// that sends status history to the model's syslog log forwarding
+	// host.
+	StatusHistoryArchiveSyslog = "syslog"
+
+	// DefaultStatusHistoryArchiveFileSize is the default value for
+	// StatusHistoryArchiveFileSize.
+	DefaultStatusHistoryArchiveFileSize = "100M"
+
+	// DefaultStatusHistoryArchiveAge is the default value for
+	// StatusHistoryArchiveAge; archive files are kept indefinitely.
+	DefaultStatusHistoryArchiveAge = "0s"
+
 	// DefaultUpdateStatusHookInterval is the default value for UpdateStatusHookInterval
 	DefaultUpdateStatusHookInterval = "5m"
 
@@ -608,6 +669,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdJSON(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid JSON log forwarding config")
+		}
+	}
+
+	if lfCfg, ok := cfg.LogFwdLoki(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid Loki log forwarding config")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -629,6 +702,29 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[StatusHistoryArchive].(string); ok {
+		switch v {
+		case "", StatusHistoryArchiveFile, StatusHistoryArchiveSyslog:
+		default:
+			return errors.Errorf("status history archive %q not valid (expected %q or %q)",
+				v, StatusHistoryArchiveFile, StatusHistoryArchiveSyslog)
+		}
+	}
+
+	if v, ok := cfg.defined[StatusHistoryArchiveFileSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid status history archive file size in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[StatusHistoryArchiveAge].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid status history archive age in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("status history archive age %v cannot be negative", d)
+		}
+	}
+
 	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max action age in model configuration")
@@ -1075,7 +1171,9 @@ func (c *Config) SnapStoreProxyURL() string {
 	return c.asString(SnapStoreProxyURLKey)
 }
 
-// LogFwdSyslog returns the syslog forwarding config.
+// LogFwdSyslog returns the syslog forwarding config. When
+// log forwarding is enabled, syslog forwarding is enabled if
+// syslog-host is set or no other log forwarding sink is configured.
 func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	partial := false
 	var lfCfg syslog.RawConfig
@@ -1088,6 +1186,8 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	if s, ok := c.defined[LogFwdSyslogHost]; ok && s != "" {
 		partial = true
 		lfCfg.Host = s.(string)
+	} else if c.asString(LogFwdJSONHost) != "" || c.asString(LogFwdLokiURL) != "" {
+		lfCfg.Enabled = false
 	}
 
 	if s, ok := c.defined[LogFwdSyslogCACert]; ok && s != "" {
@@ -1111,6 +1211,73 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdJSON returns the config for forwarding logs as newline-delimited
+// JSON. Forwarding is enabled only if log forwarding is enabled and
+// jsonlog-host is set.
+func (c *Config) LogFwdJSON() (*jsonlines.RawConfig, bool) {
+	partial := false
+	var lfCfg jsonlines.RawConfig
+
+	if s, ok := c.defined[LogFwdJSONHost]; ok && s != "" {
+		partial = true
+		lfCfg.Host = s.(string)
+		lfCfg.Enabled = c.logForwardEnabled()
+	}
+
+	if s, ok := c.defined[LogFwdJSONCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdJSONClientCert]; ok && s != "" {
+		partial = true
+		lfCfg.ClientCert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdJSONClientKey]; ok && s != "" {
+		partial = true
+		lfCfg.ClientKey = s.(string)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdLoki returns the config for pushing logs to Loki. Forwarding
+// is enabled only if log forwarding is enabled and loki-url is set.
+func (c *Config) LogFwdLoki() (*loki.RawConfig, bool) {
+	partial := false
+	var lfCfg loki.RawConfig
+
+	if s, ok := c.defined[LogFwdLokiURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+		lfCfg.Enabled = c.logForwardEnabled()
+	}
+
+	if s, ok := c.defined[LogFwdLokiCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdLokiTenantID]; ok && s != "" {
+		partial = true
+		lfCfg.TenantID = s.(string)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+func (c *Config) logForwardEnabled() bool {
+	enabled, _ := c.defined[LogForwardEnabled].(bool)
+	return enabled
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1339,6 +1506,38 @@ func (c *Config) MaxStatusHistorySizeMB() uint {
 	return uint(val)
 }
 
+// StatusHistoryArchive is where status history entries are archived
+// before being pruned: StatusHistoryArchiveFile or
+// StatusHistoryArchiveSyslog. If it is empty, entries are not archived.
+func (c *Config) StatusHistoryArchive() string {
+	return c.asString(StatusHistoryArchive)
+}
+
+// StatusHistoryArchiveFileSizeMB is the size in MiB which a status
+// history archive file can grow to before a new file is started.
+func (c *Config) StatusHistoryArchiveFileSizeMB() uint {
+	v := c.asString(StatusHistoryArchiveFileSize)
+	if v == "" {
+		v = DefaultStatusHistoryArchiveFileSize
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(v)
+	return uint(val)
+}
+
+// StatusHistoryArchiveAge is how long status history archive files
+// are kept after they were last written. Zero means they are kept
+// indefinitely.
+func (c *Config) StatusHistoryArchiveAge() time.Duration {
+	v := c.asString(StatusHistoryArchiveAge)
+	if v == "" {
+		v = DefaultStatusHistoryArchiveAge
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(v)
+	return val
+}
+
 func (c *Config) MaxActionResultsAge() time.Duration {
 	// Value has already been validated.
 	val, _ := time.ParseDuration(c.mustString(MaxActionResultsAge))
@@ -1477,6 +1676,13 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdJSONHost:         schema.Omit,
+	LogFwdJSONCACert:       schema.Omit,
+	LogFwdJSONClientCert:   schema.Omit,
+	LogFwdJSONClientKey:    schema.Omit,
+	LogFwdLokiURL:          schema.Omit,
+	LogFwdLokiCACert:       schema.Omit,
+	LogFwdLokiTenantID:     schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1527,6 +1733,9 @@ var alwaysOptional = schema.Defaults{
 	ContainerNetworkingMethod:     schema.Omit,
 	MaxStatusHistoryAge:           schema.Omit,
 	MaxStatusHistorySize:          schema.Omit,
+	StatusHistoryArchive:          schema.Omit,
+	StatusHistoryArchiveFileSize:  schema.Omit,
+	StatusHistoryArchiveAge:       schema.Omit,
 	MaxActionResultsAge:           schema.Omit,
 	MaxActionResultsSize:          schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
@@ -1901,7 +2110,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1925,6 +2134,41 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdJSONHost: {
+		Description: `The hostname:port of a server receiving newline-delimited JSON log records (e.g. Fluentd or Logstash).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONCACert: {
+		Description: `The certificate of the CA that signed the JSON log server certificate, in PEM format. If not set, records are sent over plain TCP.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONClientCert: {
+		Description: `The JSON log forwarding client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONClientKey: {
+		Description: `The JSON log forwarding client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiURL: {
+		Description: `The URL of a Loki push endpoint (e.g. https://loki.example.com/loki/api/v1/push).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiCACert: {
+		Description: `The certificate of the CA that signed the Loki server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdLokiTenantID: {
+		Description: `The tenant ID sent to a multi-tenant Loki endpoint.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1990,6 +2234,21 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	StatusHistoryArchive: {
+		Description: `Where status history entries are archived before they are pruned: "file" writes compressed files to the controller's status-history-archive-dir, and "syslog" sends them to the model's syslog-host. If not set, pruned entries are discarded`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	StatusHistoryArchiveFileSize: {
+		Description: "The size a status history archive file can grow to before a new file is started, in human-readable memory format (default 100M)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	StatusHistoryArchiveAge: {
+		Description: "How long status history archive files are kept after they were last written, in human-readable time format (default 0s, keep indefinitely)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	MaxActionResultsAge: {
 		Description: "The maximum age for action entries before they are pruned, in human-readable time format",
 		Type:        environschema.Tstring,
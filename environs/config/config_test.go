@@ -21,6 +21,8 @@ import (
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/jsonlines"
+	"github.com/juju/juju/logfwd/loki"
 	"github.com/juju/juju/testing"
 )
 
@@ -550,6 +552,70 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid JSON log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                "my-type",
+			"name":                "my-name",
+			"logforward-enabled":  true,
+			"jsonlog-host":        "fluentd.example.com:24224",
+			"jsonlog-ca-cert":     testing.CACert,
+			"jsonlog-client-cert": testing.ServerCert,
+			"jsonlog-client-key":  testing.ServerKey,
+		}),
+	}, {
+		about:       "Invalid JSON log forwarding host",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":               "my-type",
+			"name":               "my-name",
+			"logforward-enabled": true,
+			"jsonlog-host":       "fluentd.example.com",
+		}),
+		err: `invalid JSON log forwarding config: Host "fluentd.example.com" \(expected host:port\) not valid`,
+	}, {
+		about:       "JSON log forwarding client cert without CA cert",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                "my-type",
+			"name":                "my-name",
+			"jsonlog-host":        "fluentd.example.com:24224",
+			"jsonlog-client-cert": testing.ServerCert,
+			"jsonlog-client-key":  testing.ServerKey,
+		}),
+		err: `invalid JSON log forwarding config: validating TLS config: client certificate requires a CA certificate`,
+	}, {
+		about:       "Valid Loki log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":               "my-type",
+			"name":               "my-name",
+			"logforward-enabled": true,
+			"loki-url":           "https://loki.example.com/loki/api/v1/push",
+			"loki-ca-cert":       testing.CACert,
+			"loki-tenant-id":     "juju",
+		}),
+	}, {
+		about:       "Invalid Loki log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":               "my-type",
+			"name":               "my-name",
+			"logforward-enabled": true,
+			"loki-url":           "loki.example.com:3100",
+		}),
+		err: `invalid Loki log forwarding config: URL "loki.example.com:3100" \(expected http or https\) not valid`,
+	}, {
+		about:       "Invalid Loki log forwarding CA cert",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":         "my-type",
+			"name":         "my-name",
+			"loki-url":     "https://loki.example.com/loki/api/v1/push",
+			"loki-ca-cert": "abc",
+		}),
+		err: `invalid Loki log forwarding config: validating TLS config: parsing CA certificate: no certificates found`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -654,7 +720,13 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		c.Assert(hasLogCfg, jc.IsTrue)
-		c.Assert(lfCfg.Enabled, gc.Equals, v)
+		_, hasJSON := test.attrs["jsonlog-host"]
+		_, hasLoki := test.attrs["loki-url"]
+		if hasJSON || hasLoki {
+			c.Assert(lfCfg.Enabled, jc.IsFalse)
+		} else {
+			c.Assert(lfCfg.Enabled, gc.Equals, v)
+		}
 	}
 	if v, ok := test.attrs["syslog-ca-cert"].(string); v != "" {
 		c.Assert(hasLogCfg, jc.IsTrue)
@@ -1311,6 +1383,44 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestStatusHistoryArchiveConfigDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.StatusHistoryArchive(), gc.Equals, "")
+	c.Assert(cfg.StatusHistoryArchiveFileSizeMB(), gc.Equals, uint(100))
+	c.Assert(cfg.StatusHistoryArchiveAge(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestStatusHistoryArchiveConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"status-history-archive":           "file",
+		"status-history-archive-file-size": "1G",
+		"status-history-archive-age":       "8760h",
+	})
+	c.Assert(cfg.StatusHistoryArchive(), gc.Equals, "file")
+	c.Assert(cfg.StatusHistoryArchiveFileSizeMB(), gc.Equals, uint(1024))
+	c.Assert(cfg.StatusHistoryArchiveAge(), gc.Equals, 8760*time.Hour)
+}
+
+func (s *ConfigSuite) TestStatusHistoryArchiveConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"status-history-archive": "/var/lib/juju/archive"},
+		err:   `status history archive "/var/lib/juju/archive" not valid \(expected "file" or "syslog"\)`,
+	}, {
+		attrs: testing.Attrs{"status-history-archive-file-size": "lots"},
+		err:   `invalid status history archive file size in model configuration: .*`,
+	}, {
+		attrs: testing.Attrs{"status-history-archive-age": "-1h"},
+		err:   `status history archive age -1h0m0s cannot be negative`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(test.attrs))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigDefault(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 5*time.Minute)
@@ -1330,6 +1440,46 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestLogFwdSinks(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"jsonlog-host":       "fluentd.example.com:24224",
+		"loki-url":           "http://loki.example.com:3100/loki/api/v1/push",
+		"loki-tenant-id":     "juju",
+	})
+
+	syslogCfg, ok := cfg.LogFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(syslogCfg.Enabled, jc.IsFalse)
+
+	jsonCfg, ok := cfg.LogFwdJSON()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(*jsonCfg, jc.DeepEquals, jsonlines.RawConfig{
+		Enabled: true,
+		Host:    "fluentd.example.com:24224",
+	})
+
+	lokiCfg, ok := cfg.LogFwdLoki()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(*lokiCfg, jc.DeepEquals, loki.RawConfig{
+		Enabled:  true,
+		URL:      "http://loki.example.com:3100/loki/api/v1/push",
+		TenantID: "juju",
+	})
+}
+
+func (s *ConfigSuite) TestLogFwdSinksDisabled(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"jsonlog-host": "fluentd.example.com:24224",
+	})
+	jsonCfg, ok := cfg.LogFwdJSON()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(jsonCfg.Enabled, jc.IsFalse)
+
+	_, ok = cfg.LogFwdLoki()
+	c.Check(ok, jc.IsFalse)
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
@@ -41,3 +41,36 @@ type InstanceFirewaller interface {
 	// address rules for that port range.
 	IngressRules(ctx context.ProviderCallContext, machineId string) ([]network.IngressRule, error)
 }
+
+// InstanceEgressFirewaller provides instance-level egress firewall
+// functionality. An instance's egress is unrestricted until it is
+// restricted with SetEgressRestricted; from then on, only outgoing
+// traffic matching one of the instance's egress rules is allowed.
+//
+// Implementations return an error satisfying errors.IsNotSupported if
+// they can't enforce egress rules for a particular instance.
+type InstanceEgressFirewaller interface {
+	// OpenEgressRules allows outgoing traffic matching the given
+	// rules from the instance, which should have been started with
+	// the given machine id.
+	OpenEgressRules(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error
+
+	// CloseEgressRules stops allowing outgoing traffic matching the
+	// given rules from the instance, which should have been started
+	// with the given machine id.
+	CloseEgressRules(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error
+
+	// SetEgressRestricted restricts the outgoing traffic of the
+	// instance, which should have been started with the given machine
+	// id, to its egress rules, or lifts the restriction.
+	SetEgressRestricted(ctx context.ProviderCallContext, machineId string, restricted bool) error
+
+	// EgressRules returns the set of egress rules for the instance,
+	// which should have been applied to the given machine id, and
+	// whether the instance's egress is restricted to them. The
+	// rules are returned as sorted by network.SortEgressRules().
+	// It is expected that there be only one egress rule result for a
+	// given port range - the rule's DestinationCIDRs will contain all
+	// applicable destination address rules for that port range.
+	EgressRules(ctx context.ProviderCallContext, machineId string) ([]network.EgressRule, bool, error)
+}
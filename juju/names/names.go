@@ -12,5 +12,6 @@ const (
 	JujuRun          = "juju-run"
 	JujuDumpLogs     = "juju-dumplogs"
 	JujuIntrospect   = "juju-introspect"
+	JujuHookTrace    = "juju-hook-trace"
 	JujuUpdateSeries = "juju-updateseries"
 )
@@ -11,5 +11,6 @@ const (
 	JujuRun          = "juju-run.exe"
 	JujuDumpLogs     = "juju-dumplogs.exe"
 	JujuIntrospect   = "juju-introspect.exe"
+	JujuHookTrace    = "juju-hook-trace.exe"
 	JujuUpdateSeries = "juju-updateseries.exe"
 )
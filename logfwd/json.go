@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"fmt"
+	"time"
+)
+
+// JSONRecord is the form in which a Record is serialised by the
+// JSON-based forwarding targets. The field names match the syslog
+// structured data where there is an equivalent.
+type JSONRecord struct {
+	ID             int64  `json:"id"`
+	Timestamp      string `json:"timestamp"`
+	Level          string `json:"level"`
+	Message        string `json:"message"`
+	Module         string `json:"module,omitempty"`
+	Source         string `json:"source,omitempty"`
+	ControllerUUID string `json:"controller-uuid"`
+	ModelUUID      string `json:"model-uuid"`
+	Hostname       string `json:"hostname,omitempty"`
+	OriginType     string `json:"origin-type"`
+	OriginName     string `json:"origin-name"`
+	Software       string `json:"software,omitempty"`
+	Version        string `json:"version,omitempty"`
+}
+
+// NewJSONRecord returns the JSON form of the record.
+func NewJSONRecord(rec Record) JSONRecord {
+	result := JSONRecord{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC().Format(time.RFC3339Nano),
+		Level:          rec.Level.String(),
+		Message:        rec.Message,
+		Module:         rec.Location.Module,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Software:       rec.Origin.Software.Name,
+	}
+	if rec.Location.Filename != "" {
+		result.Source = rec.Location.Filename
+		if rec.Location.Line > 0 {
+			result.Source = fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)
+		}
+	}
+	if !rec.Origin.Software.isZero() {
+		result.Version = rec.Origin.Software.Version.String()
+	}
+	return result
+}
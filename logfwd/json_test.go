@@ -0,0 +1,57 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type JSONRecordSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&JSONRecordSuite{})
+
+func (s *JSONRecordSuite) TestNewJSONRecord(c *gc.C) {
+	rec := validRecord
+	rec.ID = 42
+	rec.Timestamp = time.Date(2020, 3, 2, 10, 0, 1, 500, time.FixedZone("", 3600))
+
+	c.Check(logfwd.NewJSONRecord(rec), jc.DeepEquals, logfwd.JSONRecord{
+		ID:             42,
+		Timestamp:      "2020-03-02T09:00:01.0000005Z",
+		Level:          "ERROR",
+		Message:        "uh-oh",
+		Module:         "spam",
+		Source:         "eggs.go:42",
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:       "spam.x.y.z.com",
+		OriginType:     "user",
+		OriginName:     "a-user",
+		Software:       "juju",
+		Version:        "2.0.1",
+	})
+}
+
+func (s *JSONRecordSuite) TestNewJSONRecordMinimal(c *gc.C) {
+	rec := validRecord
+	rec.Location = logfwd.SourceLocation{}
+	rec.Origin.Hostname = ""
+	rec.Origin.Software = logfwd.Software{}
+
+	result := logfwd.NewJSONRecord(rec)
+
+	c.Check(result.Module, gc.Equals, "")
+	c.Check(result.Source, gc.Equals, "")
+	c.Check(result.Hostname, gc.Equals, "")
+	c.Check(result.Software, gc.Equals, "")
+	c.Check(result.Version, gc.Equals, "")
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonlines
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// dialTimeout is how long to wait for the connection to the listener
+// to be established.
+const dialTimeout = 30 * time.Second
+
+// Client sends log records to a remote listener as newline-delimited
+// JSON, one logfwd.JSONRecord per line.
+type Client struct {
+	conn io.WriteCloser
+}
+
+// Open connects to the remote listener described by the config and
+// wraps that connection in a new client.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	if tlsCfg != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.Host, tlsCfg)
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.Host)
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "opening client connection")
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient returns a client that writes records to the connection
+// passed in.
+func NewClient(conn io.WriteCloser) *Client {
+	return &Client{conn: conn}
+}
+
+// Send sends the records to the remote listener. All of the records
+// are written at once, so a batch is never interleaved with another.
+func (client *Client) Send(records []logfwd.Record) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, rec := range records {
+		// Encode terminates each record with a newline.
+		if err := encoder.Encode(logfwd.NewJSONRecord(rec)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	_, err := client.conn.Write(buf.Bytes())
+	return errors.Trace(err)
+}
+
+// Close closes the client's connection.
+func (client *Client) Close() error {
+	return errors.Trace(client.conn.Close())
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonlines_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/jsonlines"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+var record = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Type:           logfwd.OriginTypeMachine,
+		Name:           "99",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:                    "jujud-machine-agent",
+			Version:                 version.MustParse("2.8.0"),
+		},
+	},
+	Timestamp: time.Date(2020, 3, 2, 10, 0, 1, 0, time.UTC),
+	Level:     loggo.INFO,
+	Location: logfwd.SourceLocation{
+		Module:   "juju.worker.uniter",
+		Filename: "uniter.go",
+		Line:     42,
+	},
+	Message: "hello",
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	lines := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	client, err := jsonlines.Open(jsonlines.RawConfig{
+		Enabled: true,
+		Host:    listener.Addr().String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	second := record
+	second.ID = 11
+	second.Message = "world"
+	err = client.Send([]logfwd.Record{record, second})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.Close(), jc.ErrorIsNil)
+
+	for _, expected := range []logfwd.Record{record, second} {
+		select {
+		case line := <-lines:
+			var got logfwd.JSONRecord
+			c.Assert(json.Unmarshal([]byte(line), &got), jc.ErrorIsNil)
+			c.Check(got, jc.DeepEquals, logfwd.NewJSONRecord(expected))
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for record %d", expected.ID)
+		}
+	}
+}
+
+func (s *ClientSuite) TestSendFormat(c *gc.C) {
+	conn := &fakeConn{}
+	client := jsonlines.NewClient(conn)
+
+	err := client.Send([]logfwd.Record{record})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(conn.written), gc.Equals, `{"id":10,"timestamp":"2020-03-02T10:00:01Z","level":"INFO","message":"hello",`+
+		`"module":"juju.worker.uniter","source":"uniter.go:42",`+
+		`"controller-uuid":"feebdaed-2f18-4fd2-967d-db9663db7bea","model-uuid":"deadbeef-2f18-4fd2-967d-db9663db7bea",`+
+		`"hostname":"machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea","origin-type":"machine","origin-name":"99",`+
+		`"software":"jujud-machine-agent","version":"2.8.0"}`+"\n")
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := jsonlines.Open(jsonlines.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `Host "" \(expected host:port\) not valid`)
+}
+
+type fakeConn struct {
+	written []byte
+	closed  bool
+}
+
+func (f *fakeConn) Write(data []byte) (int, error) {
+	f.written = append(f.written, data...)
+	return len(data), nil
+}
+
+func (f *fakeConn) Close() error {
+	f.closed = true
+	return nil
+}
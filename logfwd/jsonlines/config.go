@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonlines
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// RawConfig holds the raw configuration data for a connection to a
+// JSON log forwarding target.
+type RawConfig struct {
+	// Enabled is true if records should be forwarded to the target.
+	Enabled bool
+
+	// Host is the host-port of the listener, in the form
+	// [domain-or-ip-addr]:port.
+	Host string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) used to
+	// validate the server certificate. If it is empty, records are
+	// sent over plain TCP.
+	CACert string
+
+	// ClientCert is the optional TLS certificate (x.509, PEM-encoded)
+	// to present when connecting.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) for
+	// ClientCert.
+	ClientKey string
+}
+
+// IsEnabled implements logfwd.SinkConfig.
+func (cfg RawConfig) IsEnabled() bool {
+	return cfg.Enabled
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateHost(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateHost() error {
+	if cfg.Host == "" && !cfg.Enabled {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(cfg.Host)
+	if err != nil || host == "" || port == "" {
+		return errors.NotValidf("Host %q (expected host:port)", cfg.Host)
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration for connecting to the
+// listener, or nil if the connection should not use TLS.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		if cfg.ClientCert != "" || cfg.ClientKey != "" {
+			return nil, errors.New("client certificate requires a CA certificate")
+		}
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	tlsCfg := &tls.Config{
+		RootCAs: rootCAs,
+	}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonlines_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/jsonlines"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestValidateTLS(c *gc.C) {
+	cfg := jsonlines.RawConfig{
+		Enabled:    true,
+		Host:       "fluentd.example.com:24224",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+	c.Check(cfg.IsEnabled(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestValidatePlainTCP(c *gc.C) {
+	cfg := jsonlines.RawConfig{
+		Enabled: true,
+		Host:    "10.0.0.1:5170",
+	}
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestValidateZero(c *gc.C) {
+	var cfg jsonlines.RawConfig
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+	c.Check(cfg.IsEnabled(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestValidateErrors(c *gc.C) {
+	for i, test := range []struct {
+		cfg jsonlines.RawConfig
+		err string
+	}{{
+		cfg: jsonlines.RawConfig{Enabled: true},
+		err: `Host "" \(expected host:port\) not valid`,
+	}, {
+		cfg: jsonlines.RawConfig{Host: "fluentd.example.com"},
+		err: `Host "fluentd.example.com" \(expected host:port\) not valid`,
+	}, {
+		cfg: jsonlines.RawConfig{Host: ":5170"},
+		err: `Host ":5170" \(expected host:port\) not valid`,
+	}, {
+		cfg: jsonlines.RawConfig{Host: "a.b.c:5170", CACert: "abc"},
+		err: `validating TLS config: parsing CA certificate: no certificates found`,
+	}, {
+		cfg: jsonlines.RawConfig{Host: "a.b.c:5170", ClientCert: coretesting.ServerCert, ClientKey: coretesting.ServerKey},
+		err: `validating TLS config: client certificate requires a CA certificate`,
+	}, {
+		cfg: jsonlines.RawConfig{Host: "a.b.c:5170", CACert: coretesting.CACert, ClientCert: coretesting.ServerCert},
+		err: `validating TLS config: parsing client key pair: .*`,
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
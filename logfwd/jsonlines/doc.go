@@ -0,0 +1,8 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The jsonlines package holds the tools needed to perform log
+// forwarding from Juju to a remote host accepting newline-delimited
+// JSON over TCP or TLS, such as the Fluentd in_tcp or Logstash tcp
+// inputs.
+package jsonlines
@@ -0,0 +1,160 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// requestTimeout bounds each push to the endpoint.
+const requestTimeout = 30 * time.Second
+
+// HTTPClient is the part of *http.Client used to push records.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client pushes log records to a Loki endpoint. Each record becomes
+// an entry whose line is the logfwd.JSONRecord form of the record,
+// in a stream labelled with where the record came from.
+type Client struct {
+	config RawConfig
+	client HTTPClient
+}
+
+// Open returns a client for the endpoint described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	return NewClient(cfg, &http.Client{
+		Transport: utils.NewHttpTLSTransport(tlsCfg),
+		Timeout:   requestTimeout,
+	}), nil
+}
+
+// NewClient returns a client that pushes records using the HTTP
+// client passed in.
+func NewClient(cfg RawConfig, client HTTPClient) *Client {
+	return &Client{
+		config: cfg,
+		client: client,
+	}
+}
+
+// pushRequest is the body of a request to the Loki push API.
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+type stream struct {
+	Labels map[string]string `json:"stream"`
+	// Values holds [timestamp, line] pairs, where the timestamp is
+	// nanoseconds since the epoch, as a string.
+	Values [][2]string `json:"values"`
+}
+
+// Send pushes the records to the endpoint in a single request.
+func (client *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var (
+		streams []stream
+		index   = make(map[string]int)
+	)
+	for _, rec := range records {
+		labels := recordLabels(rec)
+		key := labelsKey(labels)
+		i, ok := index[key]
+		if !ok {
+			i = len(streams)
+			index[key] = i
+			streams = append(streams, stream{Labels: labels})
+		}
+		line, err := json.Marshal(logfwd.NewJSONRecord(rec))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		streams[i].Values = append(streams[i].Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			string(line),
+		})
+	}
+	body, err := json.Marshal(pushRequest{Streams: streams})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(client.push(body))
+}
+
+func (client *Client) push(body []byte) error {
+	req, err := http.NewRequest("POST", client.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client.config.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", client.config.TenantID)
+	}
+	resp, err := client.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("pushing log records: %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// Close implements logforwarder.SendCloser.
+func (client *Client) Close() error {
+	return nil
+}
+
+// recordLabels returns the Loki stream labels for the record. Labels
+// should have low cardinality, so only where the record came from
+// and its level are used; everything else is in the line.
+func recordLabels(rec logfwd.Record) map[string]string {
+	return map[string]string{
+		"job":             "juju",
+		"controller_uuid": rec.Origin.ControllerUUID,
+		"model_uuid":      rec.Origin.ModelUUID,
+		"origin_type":     rec.Origin.Type.String(),
+		"origin_name":     rec.Origin.Name,
+		"level":           strings.ToLower(rec.Level.String()),
+	}
+}
+
+func labelsKey(labels map[string]string) string {
+	// The label names are fixed, so the values alone identify the
+	// stream.
+	return strings.Join([]string{
+		labels["controller_uuid"],
+		labels["model_uuid"],
+		labels["origin_type"],
+		labels["origin_name"],
+		labels["level"],
+	}, "\x00")
+}
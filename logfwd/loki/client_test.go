@@ -0,0 +1,164 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/loki"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	doer *stubDoer
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.doer = &stubDoer{stub: s.stub, status: http.StatusNoContent}
+}
+
+func (s *ClientSuite) newRecord(id int64, machine string, level loggo.Level) logfwd.Record {
+	origin := logfwd.OriginForMachineAgent(
+		names.NewMachineTag(machine),
+		"9f484882-2f18-4fd2-967d-db9663db7bea",
+		"deadbeef-2f18-4fd2-967d-db9663db7bea",
+		version.MustParse("2.8.0"),
+	)
+	return logfwd.Record{
+		Origin:    origin,
+		ID:        id,
+		Timestamp: time.Date(2020, 2, 3, 4, 5, 6, 7, time.UTC),
+		Level:     level,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.logforwarder",
+			Filename: "logforwarder.go",
+			Line:     42,
+		},
+		Message: "hello",
+	}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client := loki.NewClient(loki.RawConfig{
+		URL:      "https://loki.example.com/loki/api/v1/push",
+		TenantID: "juju",
+	}, s.doer)
+	records := []logfwd.Record{
+		s.newRecord(10, "0", loggo.INFO),
+		s.newRecord(11, "1", loggo.INFO),
+		s.newRecord(12, "0", loggo.INFO),
+		s.newRecord(13, "0", loggo.ERROR),
+	}
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Do")
+
+	req := s.doer.req
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.String(), gc.Equals, "https://loki.example.com/loki/api/v1/push")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Check(req.Header.Get("X-Scope-OrgID"), gc.Equals, "juju")
+
+	var body struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	err = json.Unmarshal(s.doer.body, &body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(body.Streams, gc.HasLen, 3)
+	c.Check(body.Streams[0].Stream, jc.DeepEquals, map[string]string{
+		"job":             "juju",
+		"controller_uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin_type":     "machine",
+		"origin_name":     "0",
+		"level":           "info",
+	})
+	c.Check(body.Streams[0].Values, gc.HasLen, 2)
+	c.Check(body.Streams[1].Stream["origin_name"], gc.Equals, "1")
+	c.Check(body.Streams[2].Stream["level"], gc.Equals, "error")
+
+	value := body.Streams[0].Values[0]
+	c.Check(value[0], gc.Equals, "1580702706000000007")
+	var line logfwd.JSONRecord
+	err = json.Unmarshal([]byte(value[1]), &line)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(line, jc.DeepEquals, logfwd.NewJSONRecord(records[0]))
+}
+
+func (s *ClientSuite) TestSendNoTenant(c *gc.C) {
+	client := loki.NewClient(loki.RawConfig{URL: "http://loki.example.com/"}, s.doer)
+	err := client.Send([]logfwd.Record{s.newRecord(10, "0", loggo.INFO)})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.doer.req.Header["X-Scope-Orgid"]
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *ClientSuite) TestSendNothing(c *gc.C) {
+	client := loki.NewClient(loki.RawConfig{URL: "http://loki.example.com/"}, s.doer)
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestSendBadStatus(c *gc.C) {
+	s.doer.status = http.StatusBadRequest
+	s.doer.respBody = "entry out of order\n"
+	client := loki.NewClient(loki.RawConfig{URL: "http://loki.example.com/"}, s.doer)
+	err := client.Send([]logfwd.Record{s.newRecord(10, "0", loggo.INFO)})
+	c.Assert(err, gc.ErrorMatches, "pushing log records: 400 Bad Request: entry out of order")
+}
+
+func (s *ClientSuite) TestOpenInvalid(c *gc.C) {
+	_, err := loki.Open(loki.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, "empty URL not valid")
+}
+
+type stubDoer struct {
+	stub     *testing.Stub
+	status   int
+	respBody string
+
+	req  *http.Request
+	body []byte
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	d.stub.AddCall("Do", req)
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.req = req
+	d.body = body
+	return &http.Response{
+		StatusCode: d.status,
+		Status:     http.StatusText(d.status),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(d.respBody)),
+	}, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// RawConfig holds the raw configuration data for pushing records to
+// a Loki endpoint.
+type RawConfig struct {
+	// Enabled is true if records should be forwarded to the target.
+	Enabled bool
+
+	// URL is the push endpoint, usually ending in
+	// /loki/api/v1/push.
+	URL string
+
+	// CACert is the optional TLS CA certificate (x.509, PEM-encoded)
+	// used to validate the server certificate of an https URL. If it
+	// is empty the system roots are used.
+	CACert string
+
+	// TenantID is sent as the X-Scope-OrgID header when the endpoint
+	// is multi-tenant.
+	TenantID string
+}
+
+// IsEnabled implements logfwd.SinkConfig.
+func (cfg RawConfig) IsEnabled() bool {
+	return cfg.Enabled
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+	} else {
+		u, err := url.Parse(cfg.URL)
+		if err != nil {
+			return errors.NewNotValid(err, "URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("URL %q (expected http or https)", cfg.URL)
+		}
+		if u.Host == "" {
+			return errors.NotValidf("URL %q (missing host)", cfg.URL)
+		}
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration for the endpoint, or nil if
+// the defaults should be used.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/loki"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestValidate(c *gc.C) {
+	cfg := loki.RawConfig{
+		Enabled:  true,
+		URL:      "https://loki.example.com/loki/api/v1/push",
+		CACert:   coretesting.CACert,
+		TenantID: "juju",
+	}
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+	c.Check(cfg.IsEnabled(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestValidateZero(c *gc.C) {
+	var cfg loki.RawConfig
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+	c.Check(cfg.IsEnabled(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestValidateErrors(c *gc.C) {
+	for i, test := range []struct {
+		cfg loki.RawConfig
+		err string
+	}{{
+		cfg: loki.RawConfig{Enabled: true},
+		err: `empty URL not valid`,
+	}, {
+		cfg: loki.RawConfig{URL: "loki.example.com:3100"},
+		err: `URL "loki.example.com:3100" \(expected http or https\) not valid`,
+	}, {
+		cfg: loki.RawConfig{URL: "ftp://loki.example.com/"},
+		err: `URL "ftp://loki.example.com/" \(expected http or https\) not valid`,
+	}, {
+		cfg: loki.RawConfig{URL: "http:///loki/api/v1/push"},
+		err: `URL "http:///loki/api/v1/push" \(missing host\) not valid`,
+	}, {
+		cfg: loki.RawConfig{URL: "https://loki.example.com/", CACert: "nope"},
+		err: `validating TLS config: parsing CA certificate: .*`,
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The loki package holds the tools needed to perform log forwarding
+// from Juju to an HTTP endpoint implementing the Loki push API.
+package loki
@@ -0,0 +1,29 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+// The kinds of log forwarding target, each provided by a sub-package.
+const (
+	// SinkSyslog forwards records to a syslog host as RFC 5424
+	// messages.
+	SinkSyslog = "syslog"
+
+	// SinkJSON forwards records to a TCP (or TLS) listener as
+	// newline-delimited JSON.
+	SinkJSON = "json"
+
+	// SinkLoki pushes records to a Loki-compatible HTTP endpoint.
+	SinkLoki = "loki"
+)
+
+// SinkConfig is implemented by the configuration of each kind of log
+// forwarding target.
+type SinkConfig interface {
+	// IsEnabled reports whether records should be forwarded to the
+	// target.
+	IsEnabled() bool
+
+	// Validate ensures that the config is currently valid.
+	Validate() error
+}
@@ -60,11 +60,7 @@ func Open(cfg RawConfig) (*Client, error) {
 // OpenForSender connects to a remote syslog host and wraps that
 // connection in a new client.
 func OpenForSender(cfg RawConfig, opener SenderOpener) (*Client, error) {
-	if err := cfg.Validate(); err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	sender, err := open(cfg, opener)
+	sender, err := OpenSender(cfg, opener)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -75,7 +71,20 @@ func OpenForSender(cfg RawConfig, opener SenderOpener) (*Client, error) {
 	return client, nil
 }
 
-func open(cfg RawConfig, opener SenderOpener) (Sender, error) {
+// NewSenderOpener returns the SenderOpener used by Open, which dials
+// the syslog host over TLS.
+func NewSenderOpener() SenderOpener {
+	return &senderOpener{}
+}
+
+// OpenSender connects to a remote syslog host and returns the raw
+// sender, for callers that render their own RFC 5424 messages rather
+// than sending logfwd records.
+func OpenSender(cfg RawConfig, opener SenderOpener) (Sender, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	tlsCfg, err := cfg.tlsConfig()
 	if err != nil {
 		return nil, errors.Annotate(err, "constructing TLS config")
@@ -39,6 +39,11 @@ type RawConfig struct {
 	ClientKey string
 }
 
+// IsEnabled implements logfwd.SinkConfig.
+func (cfg RawConfig) IsEnabled() bool {
+	return cfg.Enabled
+}
+
 // Validate ensures that the config is currently valid.
 func (cfg RawConfig) Validate() error {
 	if err := cfg.validateHost(); err != nil {
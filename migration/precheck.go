@@ -35,6 +35,7 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	PendingStorageResizes() ([]names.Tag, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -75,6 +76,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	EgressRestricted() bool
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -88,6 +90,7 @@ type PrecheckUnit interface {
 	Status() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
 	ShouldBeAssigned() bool
+	MaintenanceMode() bool
 }
 
 // PrecheckRelation describes the state interface for relations needed
@@ -138,6 +141,13 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
+	// Resizes requested in the source model are not migrated.
+	if resizes, err := backend.PendingStorageResizes(); err != nil {
+		return errors.Annotate(err, "checking storage resizes")
+	} else if len(resizes) > 0 {
+		return errors.Errorf("%s has a pending resize", names.ReadableString(resizes[0]))
+	}
+
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
@@ -347,6 +357,11 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		if app.EgressRestricted() {
+			// TODO - egress rules can't be migrated until the
+			// description package has a representation for them.
+			return nil, errors.Errorf("application %s has restricted egress, which can't be migrated", app.Name())
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
@@ -372,6 +387,10 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
 		}
 
+		if unit.MaintenanceMode() {
+			return errors.Errorf("unit %s is in maintenance mode", unit.Name())
+		}
+
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
 			return errors.Trace(err)
 		}
@@ -6,6 +6,7 @@ package migration
 import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
@@ -105,6 +106,34 @@ func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, er
 	return resources, errors.Trace(err)
 }
 
+// PendingStorageResizes implements PrecheckBackend.
+func (s *precheckShim) PendingStorageResizes() ([]names.Tag, error) {
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	filesystems, err := sb.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tags []names.Tag
+	for _, v := range volumes {
+		if _, ok := v.RequestedSize(); ok {
+			tags = append(tags, v.VolumeTag())
+		}
+	}
+	for _, f := range filesystems {
+		if _, ok := f.RequestedSize(); ok {
+			tags = append(tags, f.FilesystemTag())
+		}
+	}
+	return tags, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	return PrecheckShim(s.controllerState, s.controllerState)
@@ -190,6 +190,19 @@ func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is below its minimum units threshold")
 }
 
+func (s *SourcePrecheckSuite) TestEgressRestrictedApplication(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:             "foo",
+				egressRestricted: true,
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has restricted egress, which can't be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestUnitVersionsDontMatch(c *gc.C) {
 	backend := &fakeBackend{
 		model: fakeModel{modelType: state.ModelTypeIAAS},
@@ -270,6 +283,35 @@ func (s *SourcePrecheckSuite) TestUnitNotIdle(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "unit foo/0 not idle or executing (failed)")
 }
 
+func (s *SourcePrecheckSuite) TestPendingStorageResize(c *gc.C) {
+	backend := newHappyBackend()
+	backend.storageResizes = []names.Tag{names.NewFilesystemTag("0/1")}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "filesystem 0/1 has a pending resize")
+}
+
+func (s *SourcePrecheckSuite) TestPendingStorageResizesError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.storageResizesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking storage resizes: boom")
+}
+
+func (s *SourcePrecheckSuite) TestUnitInMaintenanceMode(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", maintenance: true},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "unit foo/0 is in maintenance mode")
+}
+
 func (s *SourcePrecheckSuite) TestUnitLostLegacy(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -784,6 +826,9 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	storageResizes    []names.Tag
+	storageResizesErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -831,6 +876,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) PendingStorageResizes() ([]names.Tag, error) {
+	return b.storageResizes, b.storageResizesErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -960,11 +1009,12 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 }
 
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name             string
+	life             state.Life
+	charmURL         string
+	units            []migration.PrecheckUnit
+	minunits         int
+	egressRestricted bool
 }
 
 func (a *fakeApp) Name() string {
@@ -991,6 +1041,10 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) EgressRestricted() bool {
+	return a.egressRestricted
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -999,6 +1053,7 @@ type fakeUnit struct {
 	charmURL    string
 	agentStatus status.Status
 	lost        bool
+	maintenance bool
 }
 
 func (u *fakeUnit) Name() string {
@@ -1028,6 +1083,10 @@ func (u *fakeUnit) ShouldBeAssigned() bool {
 	return true
 }
 
+func (u *fakeUnit) MaintenanceMode() bool {
+	return u.maintenance
+}
+
 func (u *fakeUnit) CharmURL() (*charm.URL, bool) {
 	url := u.charmURL
 	if url == "" {
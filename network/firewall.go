@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/network"
 )
@@ -109,3 +110,153 @@ func (p IngressRuleSlice) Less(i, j int) bool {
 func SortIngressRules(IngressRules []IngressRule) {
 	sort.Sort(IngressRuleSlice(IngressRules))
 }
+
+// EgressRule represents a range of ports and destinations
+// to which outgoing packets are allowed.
+type EgressRule struct {
+	// PortRange is the range of ports for which outgoing
+	// packets are allowed.
+	network.PortRange
+
+	// DestinationCIDRs is a list of IP address blocks expressed in CIDR
+	// format to which this rule applies.
+	DestinationCIDRs []string
+}
+
+// NewEgressRule returns an EgressRule for the specified port
+// range. If no explicit destination ranges are specified, outgoing
+// traffic may be sent anywhere on those ports.
+func NewEgressRule(protocol string, from, to int, destinationCIDRs ...string) (EgressRule, error) {
+	rule := EgressRule{
+		PortRange: network.PortRange{
+			Protocol: protocol,
+			FromPort: from,
+			ToPort:   to,
+		},
+	}
+	for _, cidr := range destinationCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return EgressRule{}, errors.Trace(err)
+		}
+	}
+	if len(destinationCIDRs) > 0 {
+		rule.DestinationCIDRs = destinationCIDRs
+	}
+	return rule, nil
+}
+
+// MustNewEgressRule returns an EgressRule for the specified port
+// range. If no explicit destination ranges are specified, outgoing
+// traffic may be sent anywhere on those ports.
+// The method will panic if there is an error.
+func MustNewEgressRule(protocol string, from, to int, destinationCIDRs ...string) EgressRule {
+	rule, err := NewEgressRule(protocol, from, to, destinationCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// String is the string representation of EgressRule.
+func (r EgressRule) String() string {
+	destination := ""
+	to := strings.Join(r.DestinationCIDRs, ",")
+	if to != "" && to != "0.0.0.0/0" {
+		destination = " to " + to
+	}
+	if r.FromPort == r.ToPort {
+		return fmt.Sprintf("%d/%s%s", r.FromPort, strings.ToLower(r.Protocol), destination)
+	}
+	return fmt.Sprintf("%d-%d/%s%s", r.FromPort, r.ToPort, strings.ToLower(r.Protocol), destination)
+}
+
+// GoString is used to print values passed as an operand to a %#v format.
+func (r EgressRule) GoString() string {
+	return r.String()
+}
+
+type EgressRuleSlice []EgressRule
+
+func (p EgressRuleSlice) Len() int      { return len(p) }
+func (p EgressRuleSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p EgressRuleSlice) Less(i, j int) bool {
+	p1 := p[i]
+	p2 := p[j]
+	if p1.Protocol != p2.Protocol {
+		return p1.Protocol < p2.Protocol
+	}
+	if p1.FromPort != p2.FromPort {
+		return p1.FromPort < p2.FromPort
+	}
+	if p1.ToPort != p2.ToPort {
+		return p1.ToPort < p2.ToPort
+	}
+	d1 := strings.Join(p1.DestinationCIDRs, ",")
+	d2 := strings.Join(p2.DestinationCIDRs, ",")
+	return d1 < d2
+}
+
+// SortEgressRules sorts the given rules, first by protocol, then by ports.
+func SortEgressRules(egressRules []EgressRule) {
+	sort.Sort(EgressRuleSlice(egressRules))
+}
+
+// DiffEgressRules returns the egress rules to open and to close to go
+// from the current rules to the wanted rules. Rules without destination
+// CIDRs are taken to allow any destination.
+func DiffEgressRules(currentRules, wantedRules []EgressRule) (toOpen, toClose []EgressRule) {
+	portCidrs := func(rules []EgressRule) map[network.PortRange]set.Strings {
+		result := make(map[network.PortRange]set.Strings)
+		for _, rule := range rules {
+			cidrs, ok := result[rule.PortRange]
+			if !ok {
+				cidrs = set.NewStrings()
+				result[rule.PortRange] = cidrs
+			}
+			ruleCidrs := rule.DestinationCIDRs
+			if len(ruleCidrs) == 0 {
+				ruleCidrs = []string{"0.0.0.0/0"}
+			}
+			for _, cidr := range ruleCidrs {
+				cidrs.Add(cidr)
+			}
+		}
+		return result
+	}
+
+	currentPortCidrs := portCidrs(currentRules)
+	wantedPortCidrs := portCidrs(wantedRules)
+	for portRange, wantedCidrs := range wantedPortCidrs {
+		existingCidrs, ok := currentPortCidrs[portRange]
+
+		// If the wanted port range doesn't exist at all, the entire rule is to be opened.
+		if !ok {
+			rule := EgressRule{PortRange: portRange, DestinationCIDRs: wantedCidrs.SortedValues()}
+			toOpen = append(toOpen, rule)
+			continue
+		}
+
+		// Figure out the difference between CIDRs to get the rules to open/close.
+		toOpenCidrs := wantedCidrs.Difference(existingCidrs)
+		if toOpenCidrs.Size() > 0 {
+			rule := EgressRule{PortRange: portRange, DestinationCIDRs: toOpenCidrs.SortedValues()}
+			toOpen = append(toOpen, rule)
+		}
+		toCloseCidrs := existingCidrs.Difference(wantedCidrs)
+		if toCloseCidrs.Size() > 0 {
+			rule := EgressRule{PortRange: portRange, DestinationCIDRs: toCloseCidrs.SortedValues()}
+			toClose = append(toClose, rule)
+		}
+	}
+
+	for portRange, currentCidrs := range currentPortCidrs {
+		// If a current port range doesn't exist at all in the wanted set, the entire rule is to be closed.
+		if _, ok := wantedPortCidrs[portRange]; !ok {
+			rule := EgressRule{PortRange: portRange, DestinationCIDRs: currentCidrs.SortedValues()}
+			toClose = append(toClose, rule)
+		}
+	}
+	SortEgressRules(toOpen)
+	SortEgressRules(toClose)
+	return toOpen, toClose
+}
@@ -72,3 +72,64 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0")
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("udp", 5000, 5010, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "5000-5010/udp to 10.0.0.0/8,192.168.1.0/24")
+	c.Assert(rule.GoString(), gc.Equals, "5000-5010/udp to 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8")
+	rule2 := network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")
+	rule3 := network.MustNewEgressRule("tcp", 80, 80, "192.168.1.0/24")
+	rule4 := network.MustNewEgressRule("tcp", 80, 80, "10.0.0.0/8")
+
+	rules := []network.EgressRule{rule1, rule2, rule3, rule4}
+	network.SortEgressRules(rules)
+	c.Assert(rules, gc.DeepEquals, []network.EgressRule{rule4, rule3, rule2, rule1})
+}
+
+func (*FirewallSuite) TestNewEgressRule(c *gc.C) {
+	rule, err := network.NewEgressRule("tcp", 80, 100, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.Protocol, gc.Equals, "tcp")
+	c.Assert(rule.FromPort, gc.Equals, 80)
+	c.Assert(rule.ToPort, gc.Equals, 100)
+	c.Assert(rule.DestinationCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	rule, err = network.NewEgressRule("tcp", 80, 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.DestinationCIDRs, gc.IsNil)
+}
+
+func (*FirewallSuite) TestNewEgressRuleBadCIDR(c *gc.C) {
+	_, err := network.NewEgressRule("tcp", 80, 100, "10.0/8")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 10.0/8")
+}
+
+func (*FirewallSuite) TestDiffEgressRules(c *gc.C) {
+	current := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24", "192.168.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53),
+	}
+	wanted := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.1.0/24"),
+	}
+	toOpen, toClose := network.DiffEgressRules(current, wanted)
+	c.Assert(toOpen, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.1.0/24"),
+	})
+	c.Assert(toClose, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
 
@@ -23,6 +24,14 @@ const (
 	// rules directly related to ingress rules.
 	iptablesIngressComment = "juju ingress"
 
+	// iptablesEgressComment is the comment attached to iptables
+	// rules directly related to egress rules.
+	iptablesEgressComment = "juju egress"
+
+	// iptablesEgressDropComment is the comment attached to the
+	// iptables rule that restricts egress to the egress rules.
+	iptablesEgressDropComment = "juju egress drop"
+
 	// iptablesInternalCommand is the comment attached to iptables
 	// rules that are not directly related to ingress rules.
 	iptablesInternalComment = "juju internal"
@@ -145,6 +154,103 @@ func (c IngressRuleCommand) render(commandFlag string) string {
 	return strings.Join(args, " ")
 }
 
+// EgressRuleCommand represents an iptables ACCEPT target command
+// for egress rules.
+type EgressRuleCommand struct {
+	Rule          network.EgressRule
+	SourceAddress string
+	Delete        bool
+}
+
+// Render renders the command to a string which can be executed via
+// bash in order to install the iptables rule.
+func (c EgressRuleCommand) Render() string {
+	checkCommand := c.render("-C")
+	if c.Delete {
+		deleteCommand := c.render("-D")
+		return fmt.Sprintf("(%s) && (%s)", checkCommand, deleteCommand)
+	}
+	insertCommand := c.render("-I")
+	return fmt.Sprintf("(%s) || (%s)", checkCommand, insertCommand)
+}
+
+func (c EgressRuleCommand) render(commandFlag string) string {
+	args := []string{
+		"sudo", "iptables",
+		commandFlag, "OUTPUT",
+		"-j ACCEPT",
+		"-p", c.Rule.Protocol,
+	}
+	if c.SourceAddress != "" {
+		args = append(args, "-s", c.SourceAddress)
+	}
+	if c.Rule.Protocol == "icmp" {
+		args = append(args, "--icmp-type 8")
+	} else {
+		if c.Rule.ToPort-c.Rule.FromPort > 0 {
+			args = append(args,
+				"-m multiport --dports",
+				fmt.Sprintf("%d:%d", c.Rule.FromPort, c.Rule.ToPort),
+			)
+		} else {
+			args = append(args, "--dport", fmt.Sprint(c.Rule.FromPort))
+		}
+	}
+	if len(c.Rule.DestinationCIDRs) > 0 {
+		args = append(args, "-d", strings.Join(c.Rule.DestinationCIDRs, ","))
+	}
+	// Comment always comes last.
+	args = append(args,
+		"-m comment --comment", fmt.Sprintf("'%s'", iptablesEgressComment),
+	)
+	return strings.Join(args, " ")
+}
+
+// DropEgressCommand represents an iptables DROP target command for
+// new outgoing connections that are not allowed by an egress rule.
+// The DROP rule is what restricts egress: without it, egress rules
+// have no effect.
+//
+// Unlike the egress rules themselves, which are inserted at the
+// head of the OUTPUT chain, the DROP rule is appended to the end
+// of it so that it only applies to traffic no egress rule accepts.
+// Without a source address, the DROP rule applies to all outgoing
+// traffic except loopback traffic.
+type DropEgressCommand struct {
+	SourceAddress string
+	Delete        bool
+}
+
+// Render renders the command to a string which can be executed via
+// bash in order to install the iptables rule.
+func (c DropEgressCommand) Render() string {
+	checkCommand := c.render("-C")
+	if c.Delete {
+		deleteCommand := c.render("-D")
+		return fmt.Sprintf("(%s) && (%s)", checkCommand, deleteCommand)
+	}
+	appendCommand := c.render("-A")
+	return fmt.Sprintf("(%s) || (%s)", checkCommand, appendCommand)
+}
+
+func (c DropEgressCommand) render(commandFlag string) string {
+	args := []string{
+		"sudo", "iptables",
+		commandFlag, "OUTPUT",
+		"-m state --state NEW",
+		"-j DROP",
+	}
+	if c.SourceAddress != "" {
+		args = append(args, "-s", c.SourceAddress)
+	} else {
+		args = append(args, "! -o lo")
+	}
+	args = append(args,
+		"-m comment --comment", fmt.Sprintf("'%s'", iptablesEgressDropComment),
+	)
+	return strings.Join(args, " ")
+}
+
 // ParseIngressRules parses the output of "iptables -L INPUT -n",
 // extracting previously added ingress rules, as rendered by
 // IngressRuleCommand.
@@ -169,9 +275,101 @@ func ParseIngressRules(r io.Reader) ([]network.IngressRule, error) {
 	return rules, nil
 }
 
+// ParseEgressRules parses the output of "iptables -L OUTPUT -n",
+// extracting previously added egress rules, as rendered by
+// EgressRuleCommand, and whether egress is restricted to them by
+// the DROP rule rendered by DropEgressCommand. iptables lists a
+// rule with multiple destinations as one rule per destination;
+// these are merged back into a single egress rule per port range.
+func ParseEgressRules(r io.Reader) ([]network.EgressRule, bool, error) {
+	var (
+		rules      []network.EgressRule
+		restricted bool
+	)
+	byPortRange := make(map[corenetwork.PortRange]int)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if isEgressDropRule(strings.TrimSpace(line)) {
+			restricted = true
+			continue
+		}
+		rule, ok, err := parseAcceptRule(strings.TrimSpace(line), iptablesEgressComment)
+		if err != nil {
+			logger.Warningf("failed to parse iptables line %q: %v", line, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		var destinations []string
+		if rule.destination != "0.0.0.0/0" {
+			destinations = []string{rule.destination}
+		}
+		egressRule, err := network.NewEgressRule(
+			rule.protocol, rule.fromPort, rule.toPort, destinations...,
+		)
+		if err != nil {
+			logger.Warningf("failed to parse iptables line %q: %v", line, err)
+			continue
+		}
+		if i, ok := byPortRange[egressRule.PortRange]; ok {
+			// A rule without destinations allows traffic
+			// anywhere, so there's nothing to merge.
+			if len(rules[i].DestinationCIDRs) > 0 && len(destinations) > 0 {
+				rules[i].DestinationCIDRs = append(rules[i].DestinationCIDRs, destinations...)
+			} else {
+				rules[i].DestinationCIDRs = nil
+			}
+			continue
+		}
+		byPortRange[egressRule.PortRange] = len(rules)
+		rules = append(rules, egressRule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, false, errors.Annotate(err, "reading iptables output")
+	}
+	return rules, restricted, nil
+}
+
+// isEgressDropRule reports whether a single iptables output line
+// represents the DROP rule rendered by DropEgressCommand:
+//
+//    DROP       all  --  192.168.0.1          0.0.0.0/0    state NEW /* juju egress drop */
+//
+func isEgressDropRule(line string) bool {
+	return strings.HasPrefix(line, "DROP") &&
+		strings.HasSuffix(line, fmt.Sprintf("/* %s */", iptablesEgressDropComment))
+}
+
 // parseIngressRule parses a single iptables output line, extracting
 // an ingress rule if the line represents one, or returning false
 // otherwise.
+func parseIngressRule(line string) (network.IngressRule, bool, error) {
+	rule, ok, err := parseAcceptRule(line, iptablesIngressComment)
+	if err != nil || !ok {
+		return network.IngressRule{}, false, errors.Trace(err)
+	}
+	ingressRule, err := network.NewIngressRule(rule.protocol, rule.fromPort, rule.toPort, rule.source)
+	if err != nil {
+		return network.IngressRule{}, false, errors.Trace(err)
+	}
+	return ingressRule, true, nil
+}
+
+// acceptRule holds the fields of an iptables ACCEPT rule
+// that are relevant to ingress and egress rules.
+type acceptRule struct {
+	protocol    string
+	fromPort    int
+	toPort      int
+	source      string
+	destination string
+}
+
+// parseAcceptRule parses a single iptables output line, extracting
+// an ACCEPT rule with the given comment if the line represents one,
+// or returning false otherwise.
 //
 // The iptables rules we care about have the following format, and we
 // will skip all other rules:
@@ -182,26 +380,30 @@ func ParseIngressRules(r io.Reader) ([]network.IngressRule, error) {
 //    ACCEPT     tcp  --  0.0.0.0/0            192.168.0.2  tcp dpt:12345 /* juju ingress */
 //    ACCEPT     icmp --  0.0.0.0/0            10.0.0.1     icmptype 8 /* juju ingress */
 //
-func parseIngressRule(line string) (network.IngressRule, bool, error) {
-	fail := func(err error) (network.IngressRule, bool, error) {
-		return network.IngressRule{}, false, err
+//    Chain OUTPUT (policy ACCEPT)
+//    target     prot opt source               destination
+//    ACCEPT     tcp  --  192.168.0.1          10.0.0.0/24  tcp dpt:443 /* juju egress */
+//
+func parseAcceptRule(line, wantComment string) (acceptRule, bool, error) {
+	fail := func(err error) (acceptRule, bool, error) {
+		return acceptRule{}, false, err
 	}
 	if !strings.HasPrefix(line, "ACCEPT") {
-		return network.IngressRule{}, false, nil
+		return acceptRule{}, false, nil
 	}
 
-	// We only care about rules with the comment "juju ingress".
+	// We only care about rules with the specified comment.
 	if !strings.HasSuffix(line, "*/") {
-		return network.IngressRule{}, false, nil
+		return acceptRule{}, false, nil
 	}
 	commentStart := strings.LastIndex(line, "/*")
 	if commentStart == -1 {
-		return network.IngressRule{}, false, nil
+		return acceptRule{}, false, nil
 	}
 	line, comment := line[:commentStart], line[commentStart+2:]
 	comment = comment[:len(comment)-2]
-	if strings.TrimSpace(comment) != iptablesIngressComment {
-		return network.IngressRule{}, false, nil
+	if strings.TrimSpace(comment) != wantComment {
+		return acceptRule{}, false, nil
 	}
 
 	const (
@@ -221,10 +423,13 @@ func parseIngressRule(line string) (network.IngressRule, bool, error) {
 		line = remainder
 	}
 
-	source := fields[fieldSource]
-	proto := strings.ToLower(fields[fieldProtocol])
+	rule := acceptRule{
+		protocol:    strings.ToLower(fields[fieldProtocol]),
+		source:      fields[fieldSource],
+		destination: fields[fieldDestination],
+	}
+	proto := rule.protocol
 
-	var fromPort, toPort int
 	if strings.HasPrefix(line, "multiport dports") {
 		_, line, _ = popField(line) // pop "multiport"
 		_, line, _ = popField(line) // pop "dports"
@@ -233,12 +438,12 @@ func parseIngressRule(line string) (network.IngressRule, bool, error) {
 			return fail(errors.New("could not extract port range"))
 		}
 		var err error
-		fromPort, toPort, err = parsePortRange(portRange)
+		rule.fromPort, rule.toPort, err = parsePortRange(portRange)
 		if err != nil {
 			return fail(errors.Trace(err))
 		}
 	} else if proto == "icmp" {
-		fromPort, toPort = -1, -1
+		rule.fromPort, rule.toPort = -1, -1
 	} else {
 		field, line, ok := popField(line)
 		if !ok {
@@ -257,13 +462,8 @@ func parseIngressRule(line string) (network.IngressRule, bool, error) {
 		if err != nil {
 			return fail(errors.Trace(err))
 		}
-		fromPort = port
-		toPort = port
-	}
-
-	rule, err := network.NewIngressRule(proto, fromPort, toPort, source)
-	if err != nil {
-		return fail(errors.Trace(err))
+		rule.fromPort = port
+		rule.toPort = port
 	}
 	return rule, true, nil
 }
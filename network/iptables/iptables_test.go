@@ -192,6 +192,96 @@ ACCEPT     icmp --  0.0.0.0/0            0.0.0.0/0    icmptype 8 /* juju ingress
 	)
 }
 
+func (*IptablesSuite) TestEgressRuleCommand(c *gc.C) {
+	// TCP, single port, any destination.
+	assertRender(c,
+		iptables.EgressRuleCommand{
+			Rule:          network.MustNewEgressRule("tcp", 443, 443),
+			SourceAddress: "1.2.3.4",
+		},
+		"(sudo iptables -C OUTPUT -j ACCEPT -p tcp -s 1.2.3.4 --dport 443 -m comment --comment 'juju egress') || "+
+			"(sudo iptables -I OUTPUT -j ACCEPT -p tcp -s 1.2.3.4 --dport 443 -m comment --comment 'juju egress')",
+	)
+
+	// UDP, port range, restricted destinations, delete.
+	assertRender(c,
+		iptables.EgressRuleCommand{
+			Rule:          network.MustNewEgressRule("udp", 5000, 5010, "10.0.0.0/24", "192.168.1.0/24"),
+			SourceAddress: "1.2.3.4",
+			Delete:        true,
+		},
+		"(sudo iptables -C OUTPUT -j ACCEPT -p udp -s 1.2.3.4 -m multiport --dports 5000:5010 -d 10.0.0.0/24,192.168.1.0/24 -m comment --comment 'juju egress') && "+
+			"(sudo iptables -D OUTPUT -j ACCEPT -p udp -s 1.2.3.4 -m multiport --dports 5000:5010 -d 10.0.0.0/24,192.168.1.0/24 -m comment --comment 'juju egress')",
+	)
+
+	// ICMP.
+	assertRender(c,
+		iptables.EgressRuleCommand{
+			Rule: network.MustNewEgressRule("icmp", -1, -1),
+		},
+		"(sudo iptables -C OUTPUT -j ACCEPT -p icmp --icmp-type 8 -m comment --comment 'juju egress') || "+
+			"(sudo iptables -I OUTPUT -j ACCEPT -p icmp --icmp-type 8 -m comment --comment 'juju egress')",
+	)
+}
+
+func (*IptablesSuite) TestDropEgressCommand(c *gc.C) {
+	assertRender(c,
+		iptables.DropEgressCommand{SourceAddress: "1.2.3.4"},
+		"(sudo iptables -C OUTPUT -m state --state NEW -j DROP -s 1.2.3.4 -m comment --comment 'juju egress drop') || "+
+			"(sudo iptables -A OUTPUT -m state --state NEW -j DROP -s 1.2.3.4 -m comment --comment 'juju egress drop')",
+	)
+	assertRender(c,
+		iptables.DropEgressCommand{SourceAddress: "1.2.3.4", Delete: true},
+		"(sudo iptables -C OUTPUT -m state --state NEW -j DROP -s 1.2.3.4 -m comment --comment 'juju egress drop') && "+
+			"(sudo iptables -D OUTPUT -m state --state NEW -j DROP -s 1.2.3.4 -m comment --comment 'juju egress drop')",
+	)
+	assertRender(c,
+		iptables.DropEgressCommand{},
+		"(sudo iptables -C OUTPUT -m state --state NEW -j DROP ! -o lo -m comment --comment 'juju egress drop') || "+
+			"(sudo iptables -A OUTPUT -m state --state NEW -j DROP ! -o lo -m comment --comment 'juju egress drop')",
+	)
+}
+
+func (*IptablesSuite) TestParseEgressRulesEmpty(c *gc.C) {
+	assertParseEgressRules(c, ``, []network.EgressRule{}, false)
+}
+
+func (*IptablesSuite) TestParseEgressRulesRestrictedEmpty(c *gc.C) {
+	assertParseEgressRules(c, `
+Chain OUTPUT (policy ACCEPT)
+target     prot opt source               destination         
+DROP       all  --  1.2.3.4              0.0.0.0/0            state NEW /* juju internal */
+DROP       all  --  0.0.0.0/0            0.0.0.0/0            state NEW /* juju egress drop */
+`[1:], []network.EgressRule{}, true)
+}
+
+func (*IptablesSuite) TestParseEgressRules(c *gc.C) {
+	assertParseEgressRules(c, `
+Chain OUTPUT (policy ACCEPT)
+target     prot opt source               destination         
+ACCEPT     tcp  --  1.2.3.4              0.0.0.0/0            tcp dpt:443 /* juju egress */
+ACCEPT     udp  --  1.2.3.4              10.0.0.0/24          multiport dports 5000:5010 /* juju egress */
+ACCEPT     udp  --  1.2.3.4              192.168.1.0/24       multiport dports 5000:5010 /* juju egress */
+ACCEPT     tcp  --  0.0.0.0/0            0.0.0.0/0            tcp dpt:53 /* juju ingress */
+ACCEPT     icmp --  1.2.3.4              0.0.0.0/0            icmptype 8 /* juju egress */
+DROP       all  --  1.2.3.4              0.0.0.0/0            state NEW /* juju egress drop */
+`[1:],
+		[]network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443),
+			network.MustNewEgressRule("udp", 5000, 5010, "10.0.0.0/24", "192.168.1.0/24"),
+			network.MustNewEgressRule("icmp", -1, -1),
+		},
+		true,
+	)
+}
+
+func assertParseEgressRules(c *gc.C, in string, expect []network.EgressRule, expectRestricted bool) {
+	rules, restricted, err := iptables.ParseEgressRules(strings.NewReader(in))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expect)
+	c.Assert(restricted, gc.Equals, expectRestricted)
+}
+
 func assertParseIngressRules(c *gc.C, in string, expect []network.IngressRule) {
 	rules, err := iptables.ParseIngressRules(strings.NewReader(in))
 	c.Assert(err, jc.ErrorIsNil)
@@ -35,6 +35,15 @@ type InstanceConfigurator interface {
 
 	// List all ingress rules.
 	FindIngressRules() ([]network.IngressRule, error)
+
+	// Allow or disallow outgoing traffic.
+	ChangeEgressRules(ipAddress string, insert bool, rules []network.EgressRule) error
+
+	// Restrict outgoing traffic to the egress rules, or lift the restriction.
+	SetEgressRestricted(ipAddress string, restricted bool) error
+
+	// List all egress rules, and whether outgoing traffic is restricted to them.
+	FindEgressRules() ([]network.EgressRule, bool, error)
 }
 
 type sshInstanceConfigurator struct {
@@ -152,3 +161,50 @@ func (c *sshInstanceConfigurator) FindIngressRules() ([]network.IngressRule, err
 	logger.Tracef("find open ports output: %s", output)
 	return iptables.ParseIngressRules(strings.NewReader(output))
 }
+
+// ChangeEgressRules implements InstanceConfigurator interface.
+func (c *sshInstanceConfigurator) ChangeEgressRules(ipAddress string, insert bool, rules []network.EgressRule) error {
+	var cmds []string
+	for _, rule := range rules {
+		cmds = append(cmds, iptables.EgressRuleCommand{
+			Rule:          rule,
+			SourceAddress: ipAddress,
+			Delete:        !insert,
+		}.Render())
+	}
+
+	output, err := c.runCommand(strings.Join(cmds, "\n"))
+	if err != nil {
+		return errors.Annotatef(err, "configuring egress for address %q: %s", ipAddress, output)
+	}
+	logger.Tracef("change egress output: %s", output)
+	return nil
+}
+
+// SetEgressRestricted implements InstanceConfigurator interface.
+func (c *sshInstanceConfigurator) SetEgressRestricted(ipAddress string, restricted bool) error {
+	cmd := iptables.DropEgressCommand{
+		SourceAddress: ipAddress,
+		Delete:        !restricted,
+	}.Render()
+	if !restricted {
+		// The DROP rule may not be there to delete.
+		cmd += " || true"
+	}
+	output, err := c.runCommand(cmd)
+	if err != nil {
+		return errors.Annotatef(err, "restricting egress for address %q: %s", ipAddress, output)
+	}
+	logger.Tracef("restrict egress output: %s", output)
+	return nil
+}
+
+// FindEgressRules implements InstanceConfigurator interface.
+func (c *sshInstanceConfigurator) FindEgressRules() ([]network.EgressRule, bool, error) {
+	output, err := c.runCommand("sudo iptables -L OUTPUT -n")
+	if err != nil {
+		return nil, false, errors.Errorf("failed to list egress rules: %s", output)
+	}
+	logger.Tracef("find egress rules output: %s", output)
+	return iptables.ParseEgressRules(strings.NewReader(output))
+}
@@ -34,6 +34,18 @@ func (m *MockInstanceConfigurator) EXPECT() *MockInstanceConfiguratorMockRecorde
 	return m.recorder
 }
 
+// ChangeEgressRules mocks base method
+func (m *MockInstanceConfigurator) ChangeEgressRules(arg0 string, arg1 bool, arg2 []network.EgressRule) error {
+	ret := m.ctrl.Call(m, "ChangeEgressRules", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEgressRules indicates an expected call of ChangeEgressRules
+func (mr *MockInstanceConfiguratorMockRecorder) ChangeEgressRules(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEgressRules", reflect.TypeOf((*MockInstanceConfigurator)(nil).ChangeEgressRules), arg0, arg1, arg2)
+}
+
 // ChangeIngressRules mocks base method
 func (m *MockInstanceConfigurator) ChangeIngressRules(arg0 string, arg1 bool, arg2 []network.IngressRule) error {
 	ret := m.ctrl.Call(m, "ChangeIngressRules", arg0, arg1, arg2)
@@ -70,6 +82,20 @@ func (mr *MockInstanceConfiguratorMockRecorder) DropAllPorts(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropAllPorts", reflect.TypeOf((*MockInstanceConfigurator)(nil).DropAllPorts), arg0, arg1)
 }
 
+// FindEgressRules mocks base method
+func (m *MockInstanceConfigurator) FindEgressRules() ([]network.EgressRule, bool, error) {
+	ret := m.ctrl.Call(m, "FindEgressRules")
+	ret0, _ := ret[0].([]network.EgressRule)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindEgressRules indicates an expected call of FindEgressRules
+func (mr *MockInstanceConfiguratorMockRecorder) FindEgressRules() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEgressRules", reflect.TypeOf((*MockInstanceConfigurator)(nil).FindEgressRules))
+}
+
 // FindIngressRules mocks base method
 func (m *MockInstanceConfigurator) FindIngressRules() ([]network.IngressRule, error) {
 	ret := m.ctrl.Call(m, "FindIngressRules")
@@ -82,3 +108,15 @@ func (m *MockInstanceConfigurator) FindIngressRules() ([]network.IngressRule, er
 func (mr *MockInstanceConfiguratorMockRecorder) FindIngressRules() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIngressRules", reflect.TypeOf((*MockInstanceConfigurator)(nil).FindIngressRules))
 }
+
+// SetEgressRestricted mocks base method
+func (m *MockInstanceConfigurator) SetEgressRestricted(arg0 string, arg1 bool) error {
+	ret := m.ctrl.Call(m, "SetEgressRestricted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEgressRestricted indicates an expected call of SetEgressRestricted
+func (mr *MockInstanceConfiguratorMockRecorder) SetEgressRestricted(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEgressRestricted", reflect.TypeOf((*MockInstanceConfigurator)(nil).SetEgressRestricted), arg0, arg1)
+}
@@ -32,6 +32,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	"github.com/juju/loggo"
@@ -1740,14 +1741,16 @@ func (*environ) Provider() environs.EnvironProvider {
 }
 
 type dummyInstance struct {
-	state        *environState
-	rules        network.IngressRuleSlice
-	id           instance.Id
-	status       string
-	machineId    string
-	series       string
-	firewallMode string
-	controller   bool
+	state            *environState
+	rules            network.IngressRuleSlice
+	egressRules      network.EgressRuleSlice
+	egressRestricted bool
+	id               instance.Id
+	status           string
+	machineId        string
+	series           string
+	firewallMode     string
+	controller       bool
 
 	mu        sync.Mutex
 	addresses []corenetwork.ProviderAddress
@@ -1919,6 +1922,109 @@ func (inst *dummyInstance) IngressRules(ctx context.ProviderCallContext, machine
 	return
 }
 
+func (inst *dummyInstance) OpenEgressRules(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	defer delay()
+	logger.Infof("openEgressRules %s, %#v", machineId, rules)
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening egress rules on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("OpenEgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("OpenEgressRules"); err != nil {
+		return err
+	}
+	for _, r := range rules {
+		found := false
+		for i, rule := range inst.egressRules {
+			if r.PortRange == rule.PortRange {
+				cidrs := set.NewStrings(rule.DestinationCIDRs...).Union(set.NewStrings(r.DestinationCIDRs...))
+				inst.egressRules[i].DestinationCIDRs = cidrs.SortedValues()
+				found = true
+				break
+			}
+		}
+		if !found {
+			inst.egressRules = append(inst.egressRules, r)
+		}
+	}
+	return nil
+}
+
+func (inst *dummyInstance) CloseEgressRules(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing egress rules on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("CloseEgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("CloseEgressRules"); err != nil {
+		return err
+	}
+	for _, r := range rules {
+		for i, rule := range inst.egressRules {
+			if r.PortRange != rule.PortRange {
+				continue
+			}
+			cidrs := set.NewStrings(rule.DestinationCIDRs...).Difference(set.NewStrings(r.DestinationCIDRs...))
+			if cidrs.IsEmpty() {
+				inst.egressRules = inst.egressRules[:i+copy(inst.egressRules[i:], inst.egressRules[i+1:])]
+			} else {
+				inst.egressRules[i].DestinationCIDRs = cidrs.SortedValues()
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (inst *dummyInstance) SetEgressRestricted(ctx context.ProviderCallContext, machineId string, restricted bool) error {
+	defer delay()
+	logger.Infof("setEgressRestricted %s, %v", machineId, restricted)
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for restricting egress on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("SetEgressRestricted with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("SetEgressRestricted"); err != nil {
+		return err
+	}
+	inst.egressRestricted = restricted
+	return nil
+}
+
+func (inst *dummyInstance) EgressRules(ctx context.ProviderCallContext, machineId string) (rules []network.EgressRule, restricted bool, err error) {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return nil, false, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("EgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("EgressRules"); err != nil {
+		return nil, false, err
+	}
+	for _, r := range inst.egressRules {
+		rules = append(rules, r)
+	}
+	network.SortEgressRules(rules)
+	return rules, inst.egressRestricted, nil
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
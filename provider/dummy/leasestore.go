@@ -35,6 +35,13 @@ type entry struct {
 	// duration is the duration for which the lease is valid,
 	// from the start time.
 	duration time.Duration
+
+	// nextHolder, if set, identifies the holder the lease is being
+	// transferred to when it expires.
+	nextHolder string
+
+	// nextDuration is the duration of the lease for nextHolder.
+	nextDuration time.Duration
 }
 
 func newLeaseStore(clock clock.Clock, target raftlease.NotifyTarget, trapdoor raftlease.TrapdoorFunc) *leaseStore {
@@ -73,7 +80,7 @@ func (s *leaseStore) ExtendLease(key lease.Key, req lease.Request, _ <-chan stru
 	if !found {
 		return lease.ErrInvalid
 	}
-	if entry.holder != req.Holder {
+	if entry.holder != req.Holder || entry.nextHolder != "" {
 		return lease.ErrInvalid
 	}
 	now := s.clock.Now()
@@ -99,14 +106,37 @@ func (s *leaseStore) ExpireLease(key lease.Key) error {
 		return lease.ErrInvalid
 	}
 	expiry := entry.start.Add(entry.duration)
-	if !s.clock.Now().After(expiry) {
+	now := s.clock.Now()
+	if !now.After(expiry) {
 		return lease.ErrInvalid
 	}
+	if entry.nextHolder != "" {
+		entry.holder = entry.nextHolder
+		entry.start = now
+		entry.duration = entry.nextDuration
+		entry.nextHolder = ""
+		entry.nextDuration = 0
+		s.target.Claimed(key, entry.holder)
+		return nil
+	}
 	delete(s.entries, key)
 	s.target.Expired(key)
 	return nil
 }
 
+// TransferLease is part of lease.Store.
+func (s *leaseStore) TransferLease(key lease.Key, holder string, req lease.Request, _ <-chan struct{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, found := s.entries[key]
+	if !found || entry.holder != holder {
+		return lease.ErrInvalid
+	}
+	entry.nextHolder = req.Holder
+	entry.nextDuration = req.Duration
+	return nil
+}
+
 // Leases is part of lease.Store.
 func (s *leaseStore) Leases(keys ...lease.Key) map[lease.Key]lease.Info {
 	s.mu.Lock()
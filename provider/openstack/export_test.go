@@ -133,6 +133,9 @@ func ResolveNetwork(e environs.Environ, networkName string, external bool) (stri
 
 var PortsToRuleInfo = rulesToRuleInfo
 var SecGroupMatchesIngressRule = secGroupMatchesIngressRule
+var EgressRulesToRuleInfo = egressRulesToRuleInfo
+var SecGroupMatchesEgressRule = secGroupMatchesEgressRule
+var EgressRulesInGroup = egressRulesInGroup
 
 var MakeServiceURL = &makeServiceURL
 
@@ -6,6 +6,7 @@ package openstack
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -901,6 +902,336 @@ func (c *neutronFirewaller) ingressRulesInGroup(ctx context.ProviderCallContext,
 	return rules, nil
 }
 
+// instanceEgressFirewaller is implemented by firewallers that can
+// restrict the outgoing traffic of instances.
+type instanceEgressFirewaller interface {
+	// OpenInstanceEgressRules allows outgoing traffic matching the
+	// given rules from the specified instance.
+	OpenInstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineId string, rules []network.EgressRule) error
+
+	// CloseInstanceEgressRules stops allowing outgoing traffic
+	// matching the given rules from the specified instance.
+	CloseInstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineId string, rules []network.EgressRule) error
+
+	// SetInstanceEgressRestricted restricts the outgoing traffic of
+	// the specified instance to its egress rules, or lifts the
+	// restriction.
+	SetInstanceEgressRestricted(ctx context.ProviderCallContext, inst instances.Instance, machineId string, restricted bool) error
+
+	// InstanceEgressRules returns the egress rules applied to the
+	// specified instance, and whether its outgoing traffic is
+	// restricted to them.
+	InstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineId string) ([]network.EgressRule, bool, error)
+}
+
+// allowAllEgressRules holds the rules neutron creates with any new
+// security group, which allow all outgoing traffic.
+var allowAllEgressRules = []neutron.RuleInfoV2{
+	{Direction: "egress", EthernetType: "IPv4"},
+	{Direction: "egress", EthernetType: "IPv6"},
+}
+
+// isAllowAllEgressRule reports whether the security group rule allows
+// all outgoing traffic.
+func isAllowAllEgressRule(rule neutron.SecurityGroupRuleV2) bool {
+	return rule.Direction == "egress" && rule.IPProtocol == nil && rule.RemoteIPPrefix == ""
+}
+
+// checkInstanceEgress returns a NotSupported error if the egress of
+// the instance can't be restricted with security groups.
+func (c *neutronFirewaller) checkInstanceEgress(inst instances.Instance) error {
+	if mode := c.environ.Config().FirewallMode(); mode != config.FwInstance {
+		return errors.NotSupportedf("egress rules in %q firewall mode", mode)
+	}
+	// Security groups are allow-lists, so the allow-all egress rules
+	// of the default group would defeat any restriction.
+	if c.environ.ecfg().useDefaultSecurityGroup() {
+		return errors.NotSupportedf("egress rules with the default security group")
+	}
+	// For bug 1680787: no security groups exist if the network used
+	// to boot the instance has PortSecurityEnabled set to false.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return errors.NotSupportedf("egress rules without port security")
+	}
+	return nil
+}
+
+// OpenInstanceEgressRules implements instanceEgressFirewaller.
+func (c *neutronFirewaller) OpenInstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineId string, rules []network.EgressRule) error {
+	if err := c.checkInstanceEgress(inst); err != nil {
+		return errors.Trace(err)
+	}
+	group, err := c.matchingGroup(ctx, c.machineGroupRegexp(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, rule := range egressRulesToRuleInfo(group.Id, rules) {
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Annotatef(err, "cannot open egress rules for machine %s", machineId)
+		}
+	}
+	logger.Infof("opened egress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// CloseInstanceEgressRules implements instanceEgressFirewaller.
+func (c *neutronFirewaller) CloseInstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineId string, rules []network.EgressRule) error {
+	if err := c.checkInstanceEgress(inst); err != nil {
+		return errors.Trace(err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(ctx, c.machineGroupRegexp(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, p := range group.Rules {
+		for _, rule := range rules {
+			if !secGroupMatchesEgressRule(p, rule) {
+				continue
+			}
+			if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+				handleCredentialError(err, ctx)
+				return errors.Annotatef(err, "cannot close egress rules for machine %s", machineId)
+			}
+			break
+		}
+	}
+	logger.Infof("closed egress rules in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// SetInstanceEgressRestricted implements instanceEgressFirewaller.
+//
+// Neutron adds rules allowing all outgoing traffic to any new security
+// group, and an instance may send any traffic that one of its groups
+// allows. Restricting an instance therefore removes these rules from
+// its machine group, and from the model group it shares with the other
+// machines of the model; the latter are moved to the machine groups of
+// the other machines first, so they stay unrestricted.
+func (c *neutronFirewaller) SetInstanceEgressRestricted(ctx context.ProviderCallContext, inst instances.Instance, machineId string, restricted bool) error {
+	if err := c.checkInstanceEgress(inst); err != nil {
+		return errors.Trace(err)
+	}
+	group, err := c.matchingGroup(ctx, c.machineGroupRegexp(machineId))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !restricted {
+		if err := c.allowAllEgress(ctx, group); err != nil {
+			return errors.Annotatef(err, "cannot lift egress restriction of machine %s", machineId)
+		}
+		logger.Infof("lifted egress restriction of security group %s-%s", c.environ.Config().UUID(), machineId)
+		return nil
+	}
+	if err := c.moveModelAllowAllEgress(ctx); err != nil {
+		return errors.Annotatef(err, "cannot restrict egress of machine %s", machineId)
+	}
+	if err := c.denyAllEgress(ctx, group); err != nil {
+		return errors.Annotatef(err, "cannot restrict egress of machine %s", machineId)
+	}
+	logger.Infof("restricted egress of security group %s-%s", c.environ.Config().UUID(), machineId)
+	return nil
+}
+
+// InstanceEgressRules implements instanceEgressFirewaller.
+func (c *neutronFirewaller) InstanceEgressRules(ctx context.ProviderCallContext, inst instances.Instance, machineId string) ([]network.EgressRule, bool, error) {
+	if err := c.checkInstanceEgress(inst); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	group, err := c.matchingGroup(ctx, c.machineGroupRegexp(machineId))
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return nil, false, errors.Trace(err)
+	}
+	modelGroup, err := c.matchingGroup(ctx, c.modelGroupRegexp())
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return nil, false, errors.Trace(err)
+	}
+	restricted := true
+	for _, rule := range append(group.Rules, modelGroup.Rules...) {
+		if isAllowAllEgressRule(rule) {
+			restricted = false
+			break
+		}
+	}
+	rules, err := egressRulesInGroup(group)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return rules, restricted, nil
+}
+
+// modelGroupRegexp matches the security group shared by all the
+// machines of the model.
+func (c *neutronFirewaller) modelGroupRegexp() string {
+	return fmt.Sprintf("^%s$", c.jujuGroupRegexp())
+}
+
+// moveModelAllowAllEgress moves the allow-all egress rules of the
+// model group, if any, to the machine groups of the model.
+func (c *neutronFirewaller) moveModelAllowAllEgress(ctx context.ProviderCallContext) error {
+	modelGroup, err := c.matchingGroup(ctx, c.modelGroupRegexp())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var allowAll bool
+	for _, rule := range modelGroup.Rules {
+		if isAllowAllEgressRule(rule) {
+			allowAll = true
+			break
+		}
+	}
+	if !allowAll {
+		return nil
+	}
+	re, err := regexp.Compile(fmt.Sprintf("^%s-[0-9]+$", c.jujuGroupRegexp()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	allGroups, err := c.environ.neutron().ListSecurityGroupsV2()
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Trace(err)
+	}
+	for _, group := range allGroups {
+		if !re.MatchString(group.Name) {
+			continue
+		}
+		if err := c.allowAllEgress(ctx, group); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(c.denyAllEgress(ctx, modelGroup))
+}
+
+// allowAllEgress adds the allow-all egress rules to the group, unless
+// it has them already.
+func (c *neutronFirewaller) allowAllEgress(ctx context.ProviderCallContext, group neutron.SecurityGroupV2) error {
+	have := newRuleInfoSetFromRules(group.Rules)
+	neutronClient := c.environ.neutron()
+	for _, rule := range allowAllEgressRules {
+		if _, ok := have[rule]; ok {
+			continue
+		}
+		rule.ParentGroupId = group.Id
+		if _, err := neutronClient.CreateSecurityGroupRuleV2(rule); err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// denyAllEgress removes the allow-all egress rules from the group.
+func (c *neutronFirewaller) denyAllEgress(ctx context.ProviderCallContext, group neutron.SecurityGroupV2) error {
+	neutronClient := c.environ.neutron()
+	for _, rule := range group.Rules {
+		if !isAllowAllEgressRule(rule) {
+			continue
+		}
+		if err := neutronClient.DeleteSecurityGroupRuleV2(rule.Id); err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// egressRulesToRuleInfo returns the security group rules allowing the
+// outgoing traffic matched by the egress rules.
+func egressRulesToRuleInfo(groupId string, rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:     "egress",
+			ParentGroupId: groupId,
+			PortRangeMin:  r.FromPort,
+			PortRangeMax:  r.ToPort,
+			IPProtocol:    r.Protocol,
+		}
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, dr := range destinationCIDRs {
+			ruleInfo.RemoteIPPrefix = dr
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
+// secGroupMatchesEgressRule checks if the security group rule allows
+// some of the outgoing traffic matched by the egress rule.
+func secGroupMatchesEgressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.EgressRule) bool {
+	if secGroupRule.Direction != "egress" || secGroupRule.IPProtocol == nil ||
+		secGroupRule.PortRangeMax == nil || secGroupRule.PortRangeMin == nil {
+		return false
+	}
+	portsMatch := *secGroupRule.IPProtocol == rule.Protocol &&
+		*secGroupRule.PortRangeMin == rule.FromPort &&
+		*secGroupRule.PortRangeMax == rule.ToPort
+	if !portsMatch {
+		return false
+	}
+	if len(rule.DestinationCIDRs) == 0 {
+		return secGroupRule.RemoteIPPrefix == "" || secGroupRule.RemoteIPPrefix == "0.0.0.0/0"
+	}
+	for _, r := range rule.DestinationCIDRs {
+		if r == secGroupRule.RemoteIPPrefix {
+			return true
+		}
+	}
+	return false
+}
+
+// egressRulesInGroup returns the egress rules of the group, skipping
+// the allow-all egress rules.
+func egressRulesInGroup(group neutron.SecurityGroupV2) ([]network.EgressRule, error) {
+	// Keep track of all the RemoteIPPrefixes for each port range.
+	portDestinationCIDRs := make(map[corenetwork.PortRange][]string)
+	for _, p := range group.Rules {
+		if p.Direction != "egress" || p.IPProtocol == nil {
+			continue
+		}
+		portRange := corenetwork.PortRange{
+			Protocol: *p.IPProtocol,
+		}
+		if p.PortRangeMin != nil {
+			portRange.FromPort = *p.PortRangeMin
+		}
+		if p.PortRangeMax != nil {
+			portRange.ToPort = *p.PortRangeMax
+		}
+		remotePrefix := p.RemoteIPPrefix
+		if remotePrefix == "" {
+			remotePrefix = "0.0.0.0/0"
+		}
+		portDestinationCIDRs[portRange] = append(portDestinationCIDRs[portRange], remotePrefix)
+	}
+	var rules []network.EgressRule
+	for portRange, destinationCIDRs := range portDestinationCIDRs {
+		sort.Strings(destinationCIDRs)
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			destinationCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
@@ -532,6 +532,46 @@ func (inst *openstackInstance) IngressRules(ctx context.ProviderCallContext, mac
 	return inst.e.firewaller.InstanceIngressRules(ctx, inst, machineId)
 }
 
+func (inst *openstackInstance) egressFirewaller() (instanceEgressFirewaller, error) {
+	fw, ok := inst.e.firewaller.(instanceEgressFirewaller)
+	if !ok {
+		return nil, errors.NotSupportedf("egress rules")
+	}
+	return fw, nil
+}
+
+func (inst *openstackInstance) OpenEgressRules(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	fw, err := inst.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.OpenInstanceEgressRules(ctx, inst, machineId, rules)
+}
+
+func (inst *openstackInstance) CloseEgressRules(ctx context.ProviderCallContext, machineId string, rules []network.EgressRule) error {
+	fw, err := inst.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.CloseInstanceEgressRules(ctx, inst, machineId, rules)
+}
+
+func (inst *openstackInstance) SetEgressRestricted(ctx context.ProviderCallContext, machineId string, restricted bool) error {
+	fw, err := inst.egressFirewaller()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return fw.SetInstanceEgressRestricted(ctx, inst, machineId, restricted)
+}
+
+func (inst *openstackInstance) EgressRules(ctx context.ProviderCallContext, machineId string) ([]network.EgressRule, bool, error) {
+	fw, err := inst.egressFirewaller()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return fw.InstanceEgressRules(ctx, inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -380,6 +380,122 @@ func (*localTests) TestSecGroupMatchesIngressRule(c *gc.C) {
 	}
 }
 
+func (*localTests) TestEgressRulesToRuleInfo(c *gc.C) {
+	rules := EgressRulesToRuleInfo("groupid", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/24", "10.0.1.0/24"),
+	})
+	c.Assert(rules, jc.DeepEquals, []neutron.RuleInfoV2{{
+		Direction:      "egress",
+		IPProtocol:     "tcp",
+		PortRangeMin:   443,
+		PortRangeMax:   443,
+		RemoteIPPrefix: "0.0.0.0/0",
+		ParentGroupId:  "groupid",
+	}, {
+		Direction:      "egress",
+		IPProtocol:     "udp",
+		PortRangeMin:   53,
+		PortRangeMax:   53,
+		RemoteIPPrefix: "10.0.0.0/24",
+		ParentGroupId:  "groupid",
+	}, {
+		Direction:      "egress",
+		IPProtocol:     "udp",
+		PortRangeMin:   53,
+		PortRangeMax:   53,
+		RemoteIPPrefix: "10.0.1.0/24",
+		ParentGroupId:  "groupid",
+	}})
+}
+
+func (*localTests) TestSecGroupMatchesEgressRule(c *gc.C) {
+	proto_tcp := "tcp"
+	port_443 := 443
+
+	testCases := []struct {
+		about        string
+		rule         network.EgressRule
+		secGroupRule neutron.SecurityGroupRuleV2
+		expected     bool
+	}{{
+		about: "default RemoteIPPrefix",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_443,
+			PortRangeMax:   &port_443,
+			RemoteIPPrefix: "0.0.0.0/0",
+		},
+		expected: true,
+	}, {
+		about: "matching RemoteIPPrefix",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443, "10.0.0.0/24"),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_443,
+			PortRangeMax:   &port_443,
+			RemoteIPPrefix: "10.0.0.0/24",
+		},
+		expected: true,
+	}, {
+		about: "ingress rule",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction:    "ingress",
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_443,
+			PortRangeMax: &port_443,
+		},
+		expected: false,
+	}, {
+		about: "allow-all egress rule",
+		rule:  network.MustNewEgressRule(proto_tcp, 443, 443),
+		secGroupRule: neutron.SecurityGroupRuleV2{
+			Direction: "egress",
+		},
+		expected: false,
+	}}
+	for i, t := range testCases {
+		c.Logf("test %d: %s", i, t.about)
+		c.Check(SecGroupMatchesEgressRule(t.secGroupRule, t.rule), gc.Equals, t.expected)
+	}
+}
+
+func (*localTests) TestEgressRulesInGroup(c *gc.C) {
+	proto_tcp := "tcp"
+	port_443 := 443
+	rules, err := EgressRulesInGroup(neutron.SecurityGroupV2{
+		Rules: []neutron.SecurityGroupRuleV2{{
+			Direction:    "egress",
+			EthernetType: "IPv4",
+		}, {
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_443,
+			PortRangeMax:   &port_443,
+			RemoteIPPrefix: "10.0.1.0/24",
+		}, {
+			Direction:      "egress",
+			IPProtocol:     &proto_tcp,
+			PortRangeMin:   &port_443,
+			PortRangeMax:   &port_443,
+			RemoteIPPrefix: "10.0.0.0/24",
+		}, {
+			Direction:    "ingress",
+			IPProtocol:   &proto_tcp,
+			PortRangeMin: &port_443,
+			PortRangeMax: &port_443,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule(proto_tcp, 443, 443, "10.0.0.0/24", "10.0.1.0/24"),
+	})
+}
+
 func (s *localTests) TestDetectRegionsNoRegionName(c *gc.C) {
 	_, err := s.detectRegions(c)
 	c.Assert(err, gc.ErrorMatches, "OS_REGION_NAME environment variable not set")
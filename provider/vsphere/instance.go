@@ -112,6 +112,82 @@ func (inst *environInstance) changeIngressRules(ctx context.ProviderCallContext,
 	return nil
 }
 
+// OpenEgressRules allows outgoing traffic matching the given rules
+// from the instance, which should have been started with the given
+// machine id.
+func (inst *environInstance) OpenEgressRules(ctx context.ProviderCallContext, machineID string, rules []network.EgressRule) error {
+	return inst.changeEgress(ctx, func(client common.InstanceConfigurator, addr string) error {
+		return client.ChangeEgressRules(addr, true, rules)
+	})
+}
+
+// CloseEgressRules stops allowing outgoing traffic matching the given
+// rules from the instance, which should have been started with the
+// given machine id.
+func (inst *environInstance) CloseEgressRules(ctx context.ProviderCallContext, machineID string, rules []network.EgressRule) error {
+	return inst.changeEgress(ctx, func(client common.InstanceConfigurator, addr string) error {
+		return client.ChangeEgressRules(addr, false, rules)
+	})
+}
+
+// SetEgressRestricted restricts the outgoing traffic of the instance,
+// which should have been started with the given machine id, to its
+// egress rules, or lifts the restriction.
+func (inst *environInstance) SetEgressRestricted(ctx context.ProviderCallContext, machineID string, restricted bool) error {
+	return inst.changeEgress(ctx, func(client common.InstanceConfigurator, addr string) error {
+		return client.SetEgressRestricted(addr, restricted)
+	})
+}
+
+// EgressRules returns the set of egress rules applied to the instance,
+// which should have been started with the given machine id, and
+// whether its egress is restricted to them.
+func (inst *environInstance) EgressRules(ctx context.ProviderCallContext, machineID string) ([]network.EgressRule, bool, error) {
+	if err := inst.checkEgressSupported(); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	_, client, err := inst.getInstanceConfigurator(ctx)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return client.FindEgressRules()
+}
+
+// checkEgressSupported returns a NotSupported error if the egress of
+// the instance can't be restricted. As with ingress rules, we don't
+// firewall the internal network, which the agents use to reach the
+// controller, so egress can only be restricted on the external network.
+func (inst *environInstance) checkEgressSupported() error {
+	if inst.env.ecfg.externalNetwork() == "" {
+		return errors.NotSupportedf("egress rules without an external network")
+	}
+	return nil
+}
+
+func (inst *environInstance) changeEgress(
+	ctx context.ProviderCallContext,
+	change func(common.InstanceConfigurator, string) error,
+) error {
+	if err := inst.checkEgressSupported(); err != nil {
+		return errors.Trace(err)
+	}
+	addresses, client, err := inst.getInstanceConfigurator(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, addr := range addresses {
+		if addr.Type == corenetwork.IPv6Address || addr.Scope != corenetwork.ScopePublic {
+			// TODO(axw) support firewalling IPv6
+			continue
+		}
+		if err := change(client, addr.Value); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func (inst *environInstance) getInstanceConfigurator(
 	ctx context.ProviderCallContext,
 ) ([]corenetwork.ProviderAddress, common.InstanceConfigurator, error) {
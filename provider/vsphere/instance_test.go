@@ -4,6 +4,7 @@
 package vsphere_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/vmware/govmomi/vim25/mo"
 	gc "gopkg.in/check.v1"
@@ -137,3 +138,25 @@ func (s *InstanceSuite) TestOpenPortNoExternalNetwork(c *gc.C) {
 	}})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *InstanceSuite) TestOpenEgressRulesNoExternalNetwork(c *gc.C) {
+	s.client.virtualMachines = []*mo.VirtualMachine{
+		buildVM("inst-0").vm(),
+	}
+	envInstances, err := s.env.Instances(s.callCtx, []instance.Id{"inst-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(envInstances, gc.HasLen, 1)
+	inst0 := envInstances[0]
+	firewaller, ok := inst0.(instances.InstanceEgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	// machineID is ignored in per-instance firewallers
+	rules := []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24")}
+	err = firewaller.OpenEgressRules(s.callCtx, "", rules)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = firewaller.CloseEgressRules(s.callCtx, "", rules)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = firewaller.SetEgressRestricted(s.callCtx, "", true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, _, err = firewaller.EgressRules(s.callCtx, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
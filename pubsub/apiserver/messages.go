@@ -98,3 +98,44 @@ const RestartTopic = "apiserver.restart"
 // Restart message only contains the local-only indicator as the restart
 // is only ever for the same agent.
 type Restart common.LocalOnly
+
+// DebugShellClaimTopic is published by an API server when an operator
+// asks it for a debug shell for a unit whose agent is not waiting on
+// it. The API server on which the agent is waiting, if any, claims the
+// agent's stream and relays the session over DebugShellDataTopic.
+// data: `DebugShellClaim`
+const DebugShellClaimTopic = "debugshell.claim"
+
+// DebugShellClaim identifies the unit whose agent an operator wants a
+// debug shell session with, and the hooks to debug.
+type DebugShellClaim struct {
+	Origin    string   `yaml:"origin"`
+	Session   string   `yaml:"session"`
+	ModelUUID string   `yaml:"model-uuid"`
+	Unit      string   `yaml:"unit"`
+	Hooks     []string `yaml:"hooks,omitempty"`
+}
+
+// DebugShellDataTopic carries the messages of a debug shell session
+// between the API server the operator is connected to and the one the
+// unit agent is connected to.
+// data: `DebugShellData`
+const DebugShellDataTopic = "debugshell.data"
+
+// DebugShellData holds a message of a debug shell session relayed
+// between API servers.
+type DebugShellData struct {
+	Origin  string `yaml:"origin"`
+	Session string `yaml:"session"`
+
+	// Message holds a JSON encoded params.DebugShellMessage.
+	Message string `yaml:"message,omitempty"`
+
+	// Claimed is sent by the API server the unit agent is connected
+	// to once it has claimed the agent's stream for the session.
+	Claimed bool `yaml:"claimed,omitempty"`
+
+	// Closed is sent by either API server when its end of the
+	// session ends.
+	Closed bool `yaml:"closed,omitempty"`
+}
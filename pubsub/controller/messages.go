@@ -22,3 +22,45 @@ type ConfigChangedMessage struct {
 	// different machines, and the forwarding of those messages cross each other.
 	// Adding a version could allow subscribers to ignore lower versioned messages.
 }
+
+// RaftStatusRequestTopic is the topic that requests for the status of
+// the raft cluster are published on. The raft clusterer, which runs on
+// the raft leader, responds by publishing the status on the
+// RaftStatusTopic.
+// data: `RaftStatusRequest`
+const RaftStatusRequestTopic = "controller.raft-status-request"
+
+// RaftStatusTopic is the topic that the status of the raft cluster is
+// published on in response to a request.
+// data: `RaftStatus`
+const RaftStatusTopic = "controller.raft-status"
+
+// RaftStatusRequest is published to ask for the status of the raft
+// cluster. The response will hold the same RequestID.
+type RaftStatusRequest struct {
+	RequestID string `yaml:"request-id"`
+}
+
+// RaftStatus holds the status of the raft cluster as seen by the
+// raft leader.
+type RaftStatus struct {
+	RequestID string `yaml:"request-id"`
+
+	// Leader is the ID of the raft leader.
+	Leader string `yaml:"leader"`
+
+	// Servers holds the servers in the raft cluster configuration.
+	Servers []RaftServer `yaml:"servers"`
+}
+
+// RaftServer holds the details of a server in the raft cluster.
+type RaftServer struct {
+	// ID is the server's raft ID, which is the controller machine ID.
+	ID string `yaml:"id"`
+
+	// Address is the server's raft address.
+	Address string `yaml:"address"`
+
+	// Voter is true if the server takes part in raft elections.
+	Voter bool `yaml:"voter"`
+}
@@ -818,7 +818,7 @@ func PruneOperations(st *State, maxHistoryTime time.Duration, maxHistoryMB int)
 		{{"operation", ""}},
 		{{"operation", bson.D{{"$exists", false}}}},
 	}}}
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", hasNoOperation, GoTime)
+	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", hasNoOperation, GoTime, PruneArchiveConfig{})
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -841,6 +841,6 @@ func PruneOperations(st *State, maxHistoryTime time.Duration, maxHistoryMB int)
 	}
 	sizeFactor := float64(actionsCount) / float64(operationsCount)
 
-	err = pruneCollectionAndChildren(st, maxHistoryTime, maxHistoryMB, operationsC, "completed", actionsC, "operation", nil, sizeFactor, GoTime)
+	err = pruneCollectionAndChildren(st, maxHistoryTime, maxHistoryMB, operationsC, "completed", actionsC, "operation", nil, sizeFactor, GoTime, PruneArchiveConfig{})
 	return errors.Trace(err)
 }
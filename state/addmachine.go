@@ -106,12 +106,36 @@ type HostFilesystemParams struct {
 // AddMachineInsideNewMachine creates a new machine within a container
 // of the given type inside another new machine. The two given templates
 // specify the form of the child and parent respectively.
-func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemplate, containerType instance.ContainerType) (*Machine, error) {
-	mdoc, ops, err := st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
-	if err != nil {
-		return nil, errors.Annotate(err, "cannot add a new machine")
+func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemplate, containerType instance.ContainerType) (_ *Machine, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add a new machine")
+	var mdoc *machineDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := checkModelActive(st); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		var ops []txn.Op
+		var err error
+		mdoc, ops, err = st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		usage, err := st.newMachinesQuotaUsage(parentTemplate)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		quotaOps, err := st.quotaOps(usage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...)
+		return append(ops, quotaOps...), nil
 	}
-	return st.addMachine(mdoc, ops)
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newMachine(st, mdoc), nil
 }
 
 // AddMachineInsideMachine adds a machine inside a container of the
@@ -152,31 +176,45 @@ func (st *State) AddOneMachine(template MachineTemplate) (*Machine, error) {
 func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add a new machine")
 	var ms []*Machine
-	var ops []txn.Op
-	var controllerIds []string
-	for _, template := range templates {
-		mdoc, addOps, err := st.addMachineOps(template)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := checkModelActive(st); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ms = nil
+		var ops []txn.Op
+		var controllerIds []string
+		for _, template := range templates {
+			mdoc, addOps, err := st.addMachineOps(template)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if isController(mdoc) {
+				controllerIds = append(controllerIds, mdoc.Id)
+			}
+			ms = append(ms, newMachine(st, mdoc))
+			ops = append(ops, addOps...)
+		}
+		ssOps, err := st.maintainControllersOps(controllerIds, true)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if isController(mdoc) {
-			controllerIds = append(controllerIds, mdoc.Id)
+		ops = append(ops, ssOps...)
+		ops = append(ops, assertModelActiveOp(st.ModelUUID()))
+
+		// The quotas are checked for all the machines together.
+		usage, err := st.newMachinesQuotaUsage(templates...)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
-		ms = append(ms, newMachine(st, mdoc))
-		ops = append(ops, addOps...)
-	}
-	ssOps, err := st.maintainControllersOps(controllerIds, true)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	ops = append(ops, ssOps...)
-	ops = append(ops, assertModelActiveOp(st.ModelUUID()))
-	if err := st.db().RunTransaction(ops); err != nil {
-		if errors.Cause(err) == txn.ErrAborted {
-			if err := checkModelActive(st); err != nil {
-				return nil, errors.Trace(err)
-			}
+		quotaOps, err := st.quotaOps(usage)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		return append(ops, quotaOps...), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return ms, nil
@@ -291,6 +291,10 @@ func allCollections() CollectionSchema {
 		unitStatesC: {},
 		minUnitsC:   {},
 
+		// This collection holds the metrics published by the charm of
+		// each unit, for export to the controller's Prometheus endpoint.
+		charmMetricsC: {},
+
 		// This collection holds documents that indicate units which are queued
 		// to be assigned to machines. It is used exclusively by the
 		// AssignUnitWorker.
@@ -384,6 +388,11 @@ func allCollections() CollectionSchema {
 		},
 		volumeAttachmentsC:    {},
 		volumeAttachmentPlanC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "volumeid"},
+			}},
+		},
 
 		// -----
 
@@ -633,6 +642,7 @@ const (
 	txnsC                      = "txns"
 	unitsC                     = "units"
 	unitStatesC                = "unitstates"
+	charmMetricsC              = "charmmetrics"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
@@ -640,6 +650,7 @@ const (
 	volumeAttachmentsC         = "volumeattachments"
 	volumeAttachmentPlanC      = "volumeattachmentplan"
 	volumesC                   = "volumes"
+	volumeSnapshotsC           = "volumesnapshots"
 
 	// "resources" (see resource/persistence/mongo.go)
 
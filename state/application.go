@@ -64,6 +64,14 @@ type applicationDoc struct {
 	TxnRevno             int64        `bson:"txn-revno"`
 	MetricCredentials    []byte       `bson:"metric-credentials"`
 
+	// EgressRestricted records whether the outgoing traffic of the
+	// application's workloads is restricted to EgressRules. It stays
+	// set when the last egress rule is revoked, until egress is reset.
+	EgressRestricted bool `bson:"egress-restricted,omitempty"`
+	// EgressRules holds the outgoing traffic allowed for the
+	// application's workloads when egress is restricted.
+	EgressRules []egressRuleDoc `bson:"egress-rules,omitempty"`
+
 	// CAAS related attributes.
 	DesiredScale int    `bson:"scale"`
 	PasswordHash string `bson:"passwordhash"`
@@ -1130,7 +1138,11 @@ func (a *Application) newCharmStorageOps(
 	if err != nil {
 		return fail(err)
 	}
-	return checkStorageOps, upgradeStorageOps, []txn.Op{storageConstraintsOp}, nil
+	storageConstraintsOps := append(
+		[]txn.Op{storageConstraintsOp},
+		volumeSnapshotsAliveOps(newStorageConstraints)...,
+	)
+	return checkStorageOps, upgradeStorageOps, storageConstraintsOps, nil
 }
 
 func (a *Application) upgradeStorageOps(
@@ -2065,19 +2077,32 @@ type AddUnitParams struct {
 // AddUnit adds a new principal unit to the application.
 func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
-	name, ops, err := a.addUnitOps("", args, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
-		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+	var name string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+				return nil, err
+			} else if !alive {
+				return nil, applicationNotAliveErr
+			}
+		}
+		var ops []txn.Op
+		var err error
+		name, ops, err = a.addUnitOps("", args, nil)
+		if err != nil {
 			return nil, err
-		} else if !alive {
-			return nil, applicationNotAliveErr
 		}
-		return nil, errors.New("inconsistent state")
-	} else if err != nil {
+		storageCons, err := a.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		quotaOps, err := a.st.quotaOps(unitsQuotaUsage(1, storageCons))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, quotaOps...), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
 		return nil, err
 	}
 	return a.st.Unit(name)
@@ -2117,6 +2142,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeUnitStateOp(a.st, u.globalKey()),
+		removeCharmMetricsOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalCloudContainerKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
@@ -2664,6 +2690,11 @@ func (a *Application) Status() (status.StatusInfo, error) {
 		logger.Tracef("application %q has %d units", a.Name(), len(units))
 		var unitStatuses []status.StatusInfo
 		for _, unit := range units {
+			// Units in maintenance mode don't contribute to the
+			// application status.
+			if unit.MaintenanceMode() {
+				continue
+			}
 			unitStatus, err := unit.Status()
 			if err != nil {
 				// Sometimes as units are being removed, we may hit a not found error here.
@@ -2885,6 +2916,7 @@ func addApplicationOps(mb modelBackend, app *Application, args addApplicationOps
 		createStatusOp(mb, globalKey, args.statusDoc),
 		addModelApplicationRefOp(mb, app.Name()),
 	}
+	ops = append(ops, volumeSnapshotsAliveOps(args.storage)...)
 	m, err := app.st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	jujunetwork "github.com/juju/juju/network"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -2220,6 +2221,113 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestApplicationEgressRules(c *gc.C) {
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+	c.Assert(s.mysql.EgressRestricted(), jc.IsFalse)
+
+	err := s.mysql.AllowEgress(
+		jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+		jujunetwork.MustNewEgressRule("udp", 53, 53),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.AllowEgress(jujunetwork.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16", "10.0.0.0/24"))
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []jujunetwork.EgressRule{
+		jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24", "192.168.0.0/16"),
+		jujunetwork.MustNewEgressRule("udp", 53, 53),
+	}
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+	c.Assert(s.mysql.EgressRestricted(), jc.IsTrue)
+
+	// Check that the rules were persisted.
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+	c.Assert(s.mysql.EgressRestricted(), jc.IsTrue)
+
+	// Restricting destinations of a rule that allows any destination
+	// leaves the rule as it is.
+	err = s.mysql.AllowEgress(jujunetwork.MustNewEgressRule("udp", 53, 53, "10.0.0.1/32"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+
+	// Revoking a destination leaves the others in place.
+	err = s.mysql.RevokeEgress(jujunetwork.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, []jujunetwork.EgressRule{
+		jujunetwork.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+		jujunetwork.MustNewEgressRule("udp", 53, 53),
+	})
+
+	// Revoking the last destination, or revoking without
+	// destinations, removes the rule entirely.
+	err = s.mysql.RevokeEgress(
+		jujunetwork.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+		jujunetwork.MustNewEgressRule("udp", 53, 53),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+
+	// Revoking the last rule leaves egress restricted, so that
+	// no outgoing traffic is allowed.
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+	c.Assert(s.mysql.EgressRestricted(), jc.IsTrue)
+
+	// Revoking rules that don't exist does not fail.
+	err = s.mysql.RevokeEgress(jujunetwork.MustNewEgressRule("tcp", 80, 80))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestApplicationResetEgress(c *gc.C) {
+	err := s.mysql.AllowEgress(jujunetwork.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.ResetEgress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+	c.Assert(s.mysql.EgressRestricted(), jc.IsFalse)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.HasLen, 0)
+	c.Assert(s.mysql.EgressRestricted(), jc.IsFalse)
+
+	// Resetting again is a no-op.
+	err = s.mysql.ResetEgress()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestApplicationEgressRulesInvalid(c *gc.C) {
+	err := s.mysql.AllowEgress(jujunetwork.EgressRule{
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 79},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot allow egress for application "mysql": invalid port range 80-79/tcp`)
+	err = s.mysql.AllowEgress(jujunetwork.EgressRule{
+		PortRange:        network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 80},
+		DestinationCIDRs: []string{"foo"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot allow egress for application "mysql": destination "foo" not valid`)
+}
+
+func (s *ApplicationSuite) TestApplicationEgressRulesNotAlive(c *gc.C) {
+	u, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, s.mysql, state.Dying)
+	err = s.mysql.AllowEgress(jujunetwork.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+
+	err = u.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.RevokeEgress(jujunetwork.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	c.Assert(s.mysql.UnitCount(), gc.Equals, 0)
@@ -0,0 +1,288 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/network"
+)
+
+// egressRuleDoc represents a range of ports and destinations to
+// which an application's workloads may send outgoing traffic.
+type egressRuleDoc struct {
+	Protocol         string   `bson:"protocol"`
+	FromPort         int      `bson:"from-port"`
+	ToPort           int      `bson:"to-port"`
+	DestinationCIDRs []string `bson:"destination-cidrs,omitempty"`
+}
+
+func newEgressRuleDoc(rule network.EgressRule) egressRuleDoc {
+	return egressRuleDoc{
+		Protocol:         rule.Protocol,
+		FromPort:         rule.FromPort,
+		ToPort:           rule.ToPort,
+		DestinationCIDRs: rule.DestinationCIDRs,
+	}
+}
+
+func (doc egressRuleDoc) portRange() corenetwork.PortRange {
+	return corenetwork.PortRange{
+		Protocol: doc.Protocol,
+		FromPort: doc.FromPort,
+		ToPort:   doc.ToPort,
+	}
+}
+
+// EgressRestricted reports whether the outgoing traffic of the
+// application's workloads is restricted to its egress rules. Once
+// restricted, an application without egress rules may not send
+// outgoing traffic anywhere; only ResetEgress lifts the restriction.
+func (a *Application) EgressRestricted() bool {
+	return a.doc.EgressRestricted
+}
+
+// EgressRules returns the egress rules of the application. Unless the
+// application's egress is restricted, the application's workloads may
+// send outgoing traffic anywhere; otherwise only outgoing traffic
+// matching one of the rules is allowed.
+func (a *Application) EgressRules() []network.EgressRule {
+	return egressRulesFromDocs(a.doc.EgressRules)
+}
+
+// AllowEgress adds the given egress rules to the application, and
+// restricts the application's egress if it isn't already. A rule for a
+// port range that already has a rule is merged into the existing rule;
+// a rule without destinations allows outgoing traffic on those ports to
+// go anywhere.
+func (a *Application) AllowEgress(rules ...network.EgressRule) error {
+	if err := validateEgressRules(rules); err != nil {
+		return errors.Annotatef(err, "cannot allow egress for application %q", a)
+	}
+	return a.updateEgressRules(func(docs []egressRuleDoc, _ bool) ([]egressRuleDoc, bool) {
+		return allowEgress(docs, rules), true
+	})
+}
+
+// RevokeEgress removes the given egress rules from the application.
+// If a rule has destinations, only those destinations are removed from
+// the application's rule for the port range; otherwise the application's
+// rule for the port range is removed entirely. Revoking the last egress
+// rule leaves the application's egress restricted.
+func (a *Application) RevokeEgress(rules ...network.EgressRule) error {
+	if err := validateEgressRules(rules); err != nil {
+		return errors.Annotatef(err, "cannot revoke egress for application %q", a)
+	}
+	return a.updateEgressRules(func(docs []egressRuleDoc, restricted bool) ([]egressRuleDoc, bool) {
+		return revokeEgress(docs, rules), restricted
+	})
+}
+
+// ResetEgress removes all egress rules from the application and lifts
+// the restriction on its egress, so that the application's workloads
+// may send outgoing traffic anywhere again.
+func (a *Application) ResetEgress() error {
+	return a.updateEgressRules(func([]egressRuleDoc, bool) ([]egressRuleDoc, bool) {
+		return nil, false
+	})
+}
+
+func (a *Application) updateEgressRules(update func([]egressRuleDoc, bool) ([]egressRuleDoc, bool)) error {
+	var (
+		docs       []egressRuleDoc
+		restricted bool
+	)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		docs, restricted = update(a.doc.EgressRules, a.doc.EgressRestricted)
+		if restricted == a.doc.EgressRestricted && egressRuleDocsEqual(docs, a.doc.EgressRules) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", a.doc.TxnRevno}},
+			Update: egressUpdate(restricted, docs),
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update egress rules for application %q", a)
+	}
+	a.doc.EgressRules = docs
+	a.doc.EgressRestricted = restricted
+	return nil
+}
+
+// egressUpdate returns the update to a document with the
+// "egress-restricted" and "egress-rules" fields that sets them
+// to the given values.
+func egressUpdate(restricted bool, docs []egressRuleDoc) bson.D {
+	var setFields, unsetFields bson.D
+	if restricted {
+		setFields = append(setFields, bson.DocElem{Name: "egress-restricted", Value: true})
+	} else {
+		unsetFields = append(unsetFields, bson.DocElem{Name: "egress-restricted", Value: nil})
+	}
+	if len(docs) > 0 {
+		setFields = append(setFields, bson.DocElem{Name: "egress-rules", Value: docs})
+	} else {
+		unsetFields = append(unsetFields, bson.DocElem{Name: "egress-rules", Value: nil})
+	}
+	var update bson.D
+	if len(setFields) > 0 {
+		update = append(update, bson.DocElem{Name: "$set", Value: setFields})
+	}
+	if len(unsetFields) > 0 {
+		update = append(update, bson.DocElem{Name: "$unset", Value: unsetFields})
+	}
+	return update
+}
+
+func egressRulesFromDocs(docs []egressRuleDoc) []network.EgressRule {
+	if len(docs) == 0 {
+		return nil
+	}
+	rules := make([]network.EgressRule, len(docs))
+	for i, doc := range docs {
+		rules[i] = network.EgressRule{
+			PortRange:        doc.portRange(),
+			DestinationCIDRs: doc.DestinationCIDRs,
+		}
+	}
+	network.SortEgressRules(rules)
+	return rules
+}
+
+func validateEgressRules(rules []network.EgressRule) error {
+	for _, rule := range rules {
+		if err := rule.PortRange.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		for _, cidr := range rule.DestinationCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("destination %q", cidr)
+			}
+		}
+	}
+	return nil
+}
+
+// allowEgress returns the result of merging the given rules into the
+// given egress rule documents, without modifying the originals.
+func allowEgress(docs []egressRuleDoc, rules []network.EgressRule) []egressRuleDoc {
+	result := copyEgressRuleDocs(docs)
+	for _, rule := range rules {
+		i := findEgressRuleDoc(result, rule.PortRange)
+		if i == -1 {
+			result = append(result, newEgressRuleDoc(rule))
+			continue
+		}
+		if len(result[i].DestinationCIDRs) == 0 {
+			// Already allowed anywhere.
+			continue
+		}
+		if len(rule.DestinationCIDRs) == 0 {
+			result[i].DestinationCIDRs = nil
+			continue
+		}
+		existing := set.NewStrings(result[i].DestinationCIDRs...)
+		for _, cidr := range rule.DestinationCIDRs {
+			if !existing.Contains(cidr) {
+				existing.Add(cidr)
+				result[i].DestinationCIDRs = append(result[i].DestinationCIDRs, cidr)
+			}
+		}
+	}
+	return result
+}
+
+// revokeEgress returns the result of removing the given rules from the
+// given egress rule documents, without modifying the originals.
+func revokeEgress(docs []egressRuleDoc, rules []network.EgressRule) []egressRuleDoc {
+	result := copyEgressRuleDocs(docs)
+	for _, rule := range rules {
+		i := findEgressRuleDoc(result, rule.PortRange)
+		if i == -1 {
+			continue
+		}
+		if len(rule.DestinationCIDRs) == 0 {
+			result = append(result[:i], result[i+1:]...)
+			continue
+		}
+		if len(result[i].DestinationCIDRs) == 0 {
+			// The rule allows any destination, and we can't
+			// express "anywhere except", so leave it be.
+			continue
+		}
+		revoked := set.NewStrings(rule.DestinationCIDRs...)
+		var remaining []string
+		for _, cidr := range result[i].DestinationCIDRs {
+			if !revoked.Contains(cidr) {
+				remaining = append(remaining, cidr)
+			}
+		}
+		if len(remaining) == 0 {
+			// Removing the last destination must remove the
+			// whole rule, or else it would allow any destination.
+			result = append(result[:i], result[i+1:]...)
+			continue
+		}
+		result[i].DestinationCIDRs = remaining
+	}
+	return result
+}
+
+func findEgressRuleDoc(docs []egressRuleDoc, portRange corenetwork.PortRange) int {
+	for i, doc := range docs {
+		if doc.portRange() == portRange {
+			return i
+		}
+	}
+	return -1
+}
+
+func copyEgressRuleDocs(docs []egressRuleDoc) []egressRuleDoc {
+	result := make([]egressRuleDoc, len(docs))
+	for i, doc := range docs {
+		result[i] = doc
+		if doc.DestinationCIDRs != nil {
+			result[i].DestinationCIDRs = append([]string(nil), doc.DestinationCIDRs...)
+		}
+	}
+	return result
+}
+
+func egressRuleDocsEqual(a, b []egressRuleDoc) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].portRange() != b[i].portRange() {
+			return false
+		}
+		if len(a[i].DestinationCIDRs) != len(b[i].DestinationCIDRs) {
+			return false
+		}
+		for j, cidr := range a[i].DestinationCIDRs {
+			if b[i].DestinationCIDRs[j] != cidr {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -88,7 +88,8 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
 	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
+	// The archive is encrypted if the encryption key is not zero.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, key EncryptionKey) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -122,10 +123,19 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, key EncryptionKey) (string, error) {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
+	encrypter, err := newArchiveEncrypter(key)
+	if err != nil {
+		return "", errors.Annotate(err, "while preparing archive encryption")
+	}
+	if encrypter != nil {
+		meta.Encryption = encrypter.method
+		meta.EncryptionKeyID = encrypter.keyID
+	}
+
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
 	// are either adding the metadata file to the archive after the fact
@@ -147,7 +157,14 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{
+		backupDir:      paths.BackupDir,
+		filesToBackUp:  filesToBackUp,
+		db:             dumper,
+		metadataReader: metadataFile,
+		noDownload:     noDownload,
+		encrypter:      encrypter,
+	}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
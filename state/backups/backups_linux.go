@@ -6,6 +6,7 @@
 package backups
 
 import (
+	"io"
 	"net"
 	"strconv"
 
@@ -76,7 +77,15 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	defer backupReader.Close()
 
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	var archive io.Reader = backupReader
+	if meta.Encryption != "" {
+		archive, err = DecryptArchive(backupReader, args.Decryption)
+		if err != nil {
+			return nil, errors.Annotatef(err, "could not decrypt backup %q", backupId)
+		}
+	}
+
+	workspace, err := NewArchiveWorkspaceReader(archive)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
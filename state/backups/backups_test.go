@@ -62,7 +62,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 
-	_, err := s.api.Create(meta, &paths, &dbInfo, true, true)
+	_, err := s.api.Create(meta, &paths, &dbInfo, true, true, backups.EncryptionKey{})
 	c.Check(err, gc.ErrorMatches, expected)
 }
 
@@ -118,7 +118,7 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	resultFilename, err := s.api.Create(meta, &paths, &dbInfo, keepCopy, noDownload)
+	resultFilename, err := s.api.Create(meta, &paths, &dbInfo, keepCopy, noDownload, backups.EncryptionKey{})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(resultFilename, gc.Equals, path.Join(backupDir, backups.TempFilename))
 
@@ -151,6 +151,7 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	c.Check(meta.Origin.Machine, gc.Equals, "<machine ID>")
 	c.Check(meta.Origin.Hostname, gc.Equals, "<hostname>")
 	c.Check(meta.Notes, gc.Equals, "some notes")
+	c.Check(meta.Encryption, gc.Equals, "")
 
 	// Check the file storage.
 	if keepCopy {
@@ -165,6 +166,35 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	}
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<encrypted tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>", "")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+
+	paths := backups.Paths{BackupDir: c.MkDir()}
+	meta := backupstesting.NewMetadataStarted()
+	key := backups.EncryptionKey{PublicKey: backupstesting.PublicKey}
+	_, err := s.api.Create(meta, &paths, &backups.DBInfo{}, false, true, key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.Encryption, gc.Equals, backups.EncryptionPublicKey)
+	c.Check(meta.EncryptionKeyID, gc.Equals, backupstesting.PublicKeyID)
+}
+
+func (s *backupsSuite) TestCreateFailBadEncryptionKey(c *gc.C) {
+	paths := backups.Paths{BackupDir: c.MkDir()}
+	meta := backupstesting.NewMetadataStarted()
+	key := backups.EncryptionKey{Passphrase: "secret", PublicKey: backupstesting.PublicKey}
+	_, err := s.api.Create(meta, &paths, &backups.DBInfo{}, false, true, key)
+	c.Check(err, gc.ErrorMatches, "while preparing archive encryption: specifying both a passphrase and a public key not valid")
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
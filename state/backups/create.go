@@ -33,6 +33,7 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	encrypter      *archiveEncrypter
 }
 
 type createResult struct {
@@ -58,6 +59,8 @@ func create(args *createArgs) (_ *createResult, err error) {
 			}
 		}
 	}()
+	builder.encrypter = args.encrypter
+
 	// Inject the metadata file.
 	if args.metadataReader == nil {
 		return nil, errors.New("missing metadataReader")
@@ -105,6 +108,8 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// encrypter, if set, encrypts the archive file.
+	encrypter *archiveEncrypter
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
@@ -316,9 +321,24 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// than to the uncompressed contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
+	// Likewise, an encrypted archive's checksum is that of the
+	// encrypted file.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.encrypter == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		encrypted, err := b.encrypter.encrypt(hasher)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
+		if err := b.buildArchive(encrypted); err != nil {
+			return errors.Trace(err)
+		}
+		if err := encrypted.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -4,6 +4,8 @@
 package backups_test
 
 import (
+	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"runtime"
@@ -58,6 +60,42 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	backupDir := c.MkDir()
+	_, testFiles, expected := s.createTestFiles(c)
+
+	dumper := &TestDBDumper{}
+	args := backups.NewTestCreateArgs(backupDir, testFiles, dumper, metadataFile, true)
+	err = backups.SetCreateArgsEncryption(args, backups.EncryptionKey{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum, _ := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+
+	// The size and checksum are those of the encrypted archive.
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	decrypted, err := backups.DecryptArchive(file, backups.DecryptionKey{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	plain, err := ioutil.TempFile(c.MkDir(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	defer plain.Close()
+	_, err = io.Copy(plain, decrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	resetFile(c, plain)
+	s.checkArchive(c, plain, expected)
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var backupDir string
 	var testFiles []string
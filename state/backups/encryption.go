@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	// The OpenPGP package only uses hash functions that are linked in.
+	_ "crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+const (
+	// EncryptionPassphrase is the encryption method recorded for
+	// archives that are symmetrically encrypted with a passphrase.
+	EncryptionPassphrase = "passphrase"
+
+	// EncryptionPublicKey is the encryption method recorded for
+	// archives that are encrypted to an OpenPGP public key.
+	EncryptionPublicKey = "public-key"
+)
+
+// encryptionConfig is the OpenPGP configuration used to encrypt
+// backup archives.
+var encryptionConfig = &packet.Config{
+	DefaultCipher: packet.CipherAES256,
+}
+
+// EncryptionKey holds what is needed to encrypt a backup archive.
+// At most one of its fields may be set; if neither is, the archive
+// is not encrypted.
+type EncryptionKey struct {
+	// Passphrase is used to symmetrically encrypt the archive.
+	Passphrase string
+
+	// PublicKey is an ASCII-armored OpenPGP public key. The archive
+	// is encrypted so that only the holder of the corresponding
+	// private key can read it.
+	PublicKey string
+}
+
+// IsZero reports whether no encryption was requested.
+func (k EncryptionKey) IsZero() bool {
+	return k.Passphrase == "" && k.PublicKey == ""
+}
+
+// Validate checks that the key can be used to encrypt an archive.
+func (k EncryptionKey) Validate() error {
+	_, err := newArchiveEncrypter(k)
+	return errors.Trace(err)
+}
+
+// archiveEncrypter encrypts backup archives with an EncryptionKey.
+type archiveEncrypter struct {
+	// method is the encryption method recorded in the metadata.
+	method string
+	// keyID is the fingerprint of the public key, if there is one.
+	keyID      string
+	passphrase []byte
+	recipient  *openpgp.Entity
+}
+
+// newArchiveEncrypter returns an archiveEncrypter for the given key,
+// or nil if the key doesn't request encryption.
+func newArchiveEncrypter(key EncryptionKey) (*archiveEncrypter, error) {
+	switch {
+	case key.IsZero():
+		return nil, nil
+	case key.Passphrase != "" && key.PublicKey != "":
+		return nil, errors.NotValidf("specifying both a passphrase and a public key")
+	case key.Passphrase != "":
+		return &archiveEncrypter{
+			method:     EncryptionPassphrase,
+			passphrase: []byte(key.Passphrase),
+		}, nil
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.PublicKey))
+	if err != nil {
+		return nil, errors.NewNotValid(err, "reading public key")
+	}
+	if len(entities) != 1 {
+		return nil, errors.NotValidf("public key block with %d keys", len(entities))
+	}
+	e := &archiveEncrypter{
+		method:    EncryptionPublicKey,
+		keyID:     fmt.Sprintf("%X", entities[0].PrimaryKey.Fingerprint),
+		recipient: entities[0],
+	}
+	// Make sure the key can be encrypted to before we go to the
+	// trouble of building an archive.
+	w, err := e.encrypt(ioutil.Discard)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "public key")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return e, nil
+}
+
+// encrypt returns a writer that encrypts what is written to it
+// before passing it on to w. The returned writer must be closed to
+// flush the encrypted data.
+func (e *archiveEncrypter) encrypt(w io.Writer) (io.WriteCloser, error) {
+	hints := &openpgp.FileHints{IsBinary: true}
+	if e.recipient != nil {
+		plaintext, err := openpgp.Encrypt(w, []*openpgp.Entity{e.recipient}, nil, hints, encryptionConfig)
+		return plaintext, errors.Trace(err)
+	}
+	plaintext, err := openpgp.SymmetricallyEncrypt(w, e.passphrase, hints, encryptionConfig)
+	return plaintext, errors.Trace(err)
+}
+
+// DecryptionKey holds what is needed to decrypt an encrypted backup
+// archive.
+type DecryptionKey struct {
+	// Passphrase is the passphrase the archive was encrypted with,
+	// or the passphrase protecting PrivateKey.
+	Passphrase string
+
+	// PrivateKey is an ASCII-armored OpenPGP private key matching
+	// the public key the archive was encrypted to.
+	PrivateKey string
+}
+
+// IsZero reports whether no decryption key was supplied.
+func (k DecryptionKey) IsZero() bool {
+	return k.Passphrase == "" && k.PrivateKey == ""
+}
+
+// IsEncryptedArchive reports whether the archive starting with the
+// given bytes is encrypted. Plain archives are gzipped tarballs,
+// whereas encrypted ones start with an OpenPGP packet tag, which
+// always has its high bit set.
+func IsEncryptedArchive(header []byte) bool {
+	return len(header) > 0 && header[0]&0x80 != 0
+}
+
+// DecryptArchive returns a reader for the decrypted contents of the
+// encrypted backup archive read from r. The integrity of the archive
+// is only verified once the returned reader has been read to the end.
+func DecryptArchive(r io.Reader, key DecryptionKey) (io.Reader, error) {
+	if key.IsZero() {
+		return nil, errors.New("backup archive is encrypted: a passphrase or private key is required")
+	}
+	var keyring openpgp.EntityList
+	if key.PrivateKey != "" {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.PrivateKey))
+		if err != nil {
+			return nil, errors.NewNotValid(err, "reading private key")
+		}
+		keyring = entities
+	}
+
+	// ReadMessage calls prompt until the archive can be decrypted,
+	// so we must give up once the supplied key has been tried.
+	tried := false
+	prompt := func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		if tried || key.Passphrase == "" {
+			return nil, errors.New("backup archive cannot be decrypted with the supplied key")
+		}
+		tried = true
+		if symmetric {
+			return []byte(key.Passphrase), nil
+		}
+		for _, k := range keys {
+			if err := k.PrivateKey.Decrypt([]byte(key.Passphrase)); err != nil {
+				return nil, errors.Annotate(err, "decrypting private key")
+			}
+		}
+		return nil, nil
+	}
+	md, err := openpgp.ReadMessage(r, keyring, prompt, encryptionConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "decrypting backup archive")
+	}
+	return md.UnverifiedBody, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type encryptionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+const plainArchive = "\x1f\x8b<compressed tarball>"
+
+func (s *encryptionSuite) encrypt(c *gc.C, key backups.EncryptionKey) *bytes.Buffer {
+	var buf bytes.Buffer
+	w, err := backups.EncryptArchive(&buf, key)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(plainArchive))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	c.Assert(backups.IsEncryptedArchive(buf.Bytes()), jc.IsTrue)
+	return &buf
+}
+
+func (s *encryptionSuite) checkDecrypt(c *gc.C, encrypted *bytes.Buffer, key backups.DecryptionKey) {
+	r, err := backups.DecryptArchive(encrypted, key)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, plainArchive)
+}
+
+func (s *encryptionSuite) TestPassphrase(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptionKey{Passphrase: "secret"})
+	c.Check(bytes.Contains(encrypted.Bytes(), []byte("tarball")), jc.IsFalse)
+	s.checkDecrypt(c, encrypted, backups.DecryptionKey{Passphrase: "secret"})
+}
+
+func (s *encryptionSuite) TestWrongPassphrase(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptionKey{Passphrase: "secret"})
+	_, err := backups.DecryptArchive(encrypted, backups.DecryptionKey{Passphrase: "guess"})
+	c.Check(err, gc.ErrorMatches, "decrypting backup archive: backup archive cannot be decrypted with the supplied key")
+}
+
+func (s *encryptionSuite) TestPublicKey(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptionKey{PublicKey: backupstesting.PublicKey})
+	s.checkDecrypt(c, encrypted, backups.DecryptionKey{PrivateKey: backupstesting.PrivateKey})
+}
+
+func (s *encryptionSuite) TestPublicKeyWithPassphrase(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptionKey{PublicKey: backupstesting.PublicKey})
+	_, err := backups.DecryptArchive(encrypted, backups.DecryptionKey{Passphrase: "secret"})
+	c.Check(err, gc.ErrorMatches, "decrypting backup archive: .*incorrect key")
+}
+
+func (s *encryptionSuite) TestMissingDecryptionKey(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptionKey{Passphrase: "secret"})
+	_, err := backups.DecryptArchive(encrypted, backups.DecryptionKey{})
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted: a passphrase or private key is required")
+}
+
+func (s *encryptionSuite) TestValidate(c *gc.C) {
+	c.Check(backups.EncryptionKey{}.Validate(), jc.ErrorIsNil)
+	c.Check(backups.EncryptionKey{Passphrase: "secret"}.Validate(), jc.ErrorIsNil)
+	c.Check(backups.EncryptionKey{PublicKey: backupstesting.PublicKey}.Validate(), jc.ErrorIsNil)
+
+	err := backups.EncryptionKey{Passphrase: "secret", PublicKey: backupstesting.PublicKey}.Validate()
+	c.Check(err, gc.ErrorMatches, "specifying both a passphrase and a public key not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	err = backups.EncryptionKey{PublicKey: "not a key"}.Validate()
+	c.Check(err, gc.ErrorMatches, "reading public key: .*")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *encryptionSuite) TestIsEncryptedArchive(c *gc.C) {
+	c.Check(backups.IsEncryptedArchive([]byte(plainArchive)), jc.IsFalse)
+	c.Check(backups.IsEncryptedArchive(nil), jc.IsFalse)
+}
@@ -105,6 +105,24 @@ func NewTestCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, me
 	return &args
 }
 
+// SetCreateArgsEncryption sets the key used by create() to encrypt
+// the archive.
+func SetCreateArgsEncryption(args *createArgs, key EncryptionKey) error {
+	encrypter, err := newArchiveEncrypter(key)
+	args.encrypter = encrypter
+	return err
+}
+
+// EncryptArchive returns a writer that encrypts what is written to it
+// with the given key.
+func EncryptArchive(w io.Writer, key EncryptionKey) (io.WriteCloser, error) {
+	encrypter, err := newArchiveEncrypter(key)
+	if err != nil {
+		return nil, err
+	}
+	return encrypter.encrypt(w)
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.backupDir, args.filesToBackUp, args.db
@@ -169,3 +187,5 @@ func NewTestArchiveStorer(failure string) func(filestorage.FileStorage, *Metadat
 
 // Export for patching in tests
 var RestorePath = &getMongorestorePath
+
+var SignS3Request = signS3Request
@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// localTarget is a Target that keeps objects as files in a directory.
+// In HA the directory must be a network mount shared by the controller
+// machines: otherwise a backup is only listed by, and can only be
+// downloaded from, the controller that happened to make it.
+type localTarget struct {
+	dir string
+}
+
+// NewLocalTarget returns a Target that keeps objects as files in the
+// given directory, which is created when first written to.
+func NewLocalTarget(dir string) Target {
+	return &localTarget{dir: dir}
+}
+
+func (t *localTarget) path(name string) (string, error) {
+	if err := validateObjectName(name); err != nil {
+		return "", errors.Trace(err)
+	}
+	return filepath.Join(t.dir, name), nil
+}
+
+// Put is part of the Target interface. The content is written to a
+// temporary file which is renamed into place once complete, so that a
+// partially written object is never seen.
+func (t *localTarget) Put(name string, content io.Reader, size int64) (err error) {
+	path, err := t.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := ioutil.TempFile(t.dir, "."+name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+	n, err := io.Copy(f, content)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if size >= 0 && n != size {
+		return errors.Errorf("wrote %d bytes of %q, expected %d", n, name, size)
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(f.Name(), path))
+}
+
+// Get is part of the Target interface.
+func (t *localTarget) Get(name string) (io.ReadCloser, error) {
+	path, err := t.path(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup object %q", name)
+	}
+	return f, errors.Trace(err)
+}
+
+// List is part of the Target interface.
+func (t *localTarget) List() ([]string, error) {
+	infos, err := ioutil.ReadDir(t.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		// Skip the temporary files of writes in progress.
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
+
+// Remove is part of the Target interface.
+func (t *localTarget) Remove(name string) error {
+	path, err := t.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup object %q", name)
+	}
+	return errors.Trace(err)
+}
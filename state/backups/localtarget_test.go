@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type localTargetSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&localTargetSuite{})
+
+func (s *localTargetSuite) TestPutGetRemove(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "backups")
+	target := backups.NewLocalTarget(dir)
+
+	err := target.Put("backup.tar.gz", bytes.NewBufferString("content"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := os.Stat(filepath.Join(dir, "backup.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	r, err := target.Get("backup.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "content")
+
+	err = target.Remove("backup.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = target.Get("backup.tar.gz")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = target.Remove("backup.tar.gz")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *localTargetSuite) TestPutShortContent(c *gc.C) {
+	dir := c.MkDir()
+	target := backups.NewLocalTarget(dir)
+	err := target.Put("backup.tar.gz", bytes.NewBufferString("short"), 10)
+	c.Assert(err, gc.ErrorMatches, `wrote 5 bytes of "backup.tar.gz", expected 10`)
+
+	// Nothing is left behind.
+	infos, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(infos, gc.HasLen, 0)
+}
+
+func (s *localTargetSuite) TestListMissingDir(c *gc.C) {
+	target := backups.NewLocalTarget(filepath.Join(c.MkDir(), "missing"))
+	names, err := target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+}
+
+func (s *localTargetSuite) TestInvalidName(c *gc.C) {
+	target := backups.NewLocalTarget(c.MkDir())
+	err := target.Put("../escape", bytes.NewBufferString(""), 0)
+	c.Check(err, gc.ErrorMatches, `backup object name "../escape" not valid`)
+	_, err = target.Get(".hidden")
+	c.Check(err, gc.ErrorMatches, `backup object name ".hidden" not valid`)
+}
@@ -83,6 +83,19 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was taken by the controller's
+	// backup schedule rather than requested by a user. Only scheduled
+	// backups are removed by the retention policy.
+	Scheduled bool
+
+	// Encryption is the method used to encrypt the archive, if it is
+	// encrypted. See EncryptionPassphrase and EncryptionPublicKey.
+	Encryption string
+
+	// EncryptionKeyID is the fingerprint of the public key the archive
+	// was encrypted to, if any.
+	EncryptionKeyID string
+
 	// FormatVersion stores format version of these metadata.
 	FormatVersion int64
 
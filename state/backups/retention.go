@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"sort"
+	"time"
+)
+
+// RetentionPolicy determines which scheduled backups are kept. Backups
+// requested by users are never subject to the policy.
+type RetentionPolicy struct {
+	// Daily is the number of days, counting today, for which the most
+	// recent scheduled backup of each day is kept.
+	Daily int
+
+	// Weekly is the number of weeks, counting this week, for which the
+	// most recent scheduled backup of each week is kept. Weeks start
+	// on Monday.
+	Weekly int
+}
+
+// Expired returns the scheduled backups in metadata that the policy
+// does not keep as of now, oldest first. Days and weeks are in UTC.
+// The most recent scheduled backup is always kept, whatever the
+// policy, so that a schedule always leaves at least one backup behind.
+func (p RetentionPolicy) Expired(metadata []*Metadata, now time.Time) []*Metadata {
+	var scheduled []*Metadata
+	for _, meta := range metadata {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	// Newest first, so the first backup seen in each period is the
+	// one kept for it.
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+
+	today := startOfDay(now)
+	thisWeek := startOfWeek(now)
+	keptDays := make(map[time.Time]bool)
+	keptWeeks := make(map[time.Time]bool)
+	var expired []*Metadata
+	for i, meta := range scheduled {
+		day := startOfDay(meta.Started)
+		week := startOfWeek(meta.Started)
+		keep := i == 0
+		if !keptDays[day] && periodsBetween(day, today, 24*time.Hour) < p.Daily {
+			keptDays[day] = true
+			keep = true
+		}
+		if !keptWeeks[week] && periodsBetween(week, thisWeek, 7*24*time.Hour) < p.Weekly {
+			keptWeeks[week] = true
+			keep = true
+		}
+		if !keep {
+			expired = append(expired, meta)
+		}
+	}
+	for i, j := 0, len(expired)-1; i < j; i, j = i+1, j-1 {
+		expired[i], expired[j] = expired[j], expired[i]
+	}
+	return expired
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	// Weekday counts from Sunday; weeks start on Monday.
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// periodsBetween returns the number of whole periods from start to end,
+// which are both the start of a period. Backups from the future, which
+// can only be due to clock skew, are treated as current.
+func periodsBetween(start, end time.Time, period time.Duration) int {
+	if start.After(end) {
+		return 0
+	}
+	return int(end.Sub(start) / period)
+}
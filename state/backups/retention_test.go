@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time" // Only used for time types and funcs, not Now().
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type retentionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+func backupAt(id, started string, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	t, err := time.Parse("2006-01-02 15:04", started)
+	if err != nil {
+		panic(err)
+	}
+	meta.Started = t
+	meta.Scheduled = scheduled
+	return meta
+}
+
+func ids(metadata []*backups.Metadata) []string {
+	result := make([]string, len(metadata))
+	for i, meta := range metadata {
+		result[i] = meta.ID()
+	}
+	return result
+}
+
+// now is a Wednesday.
+var now = time.Date(2020, 3, 18, 12, 0, 0, 0, time.UTC)
+
+func (s *retentionSuite) TestExpiredDaily(c *gc.C) {
+	metadata := []*backups.Metadata{
+		backupAt("today-2", "2020-03-18 02:00", true),
+		backupAt("today-1", "2020-03-18 01:00", true),
+		backupAt("yesterday", "2020-03-17 02:00", true),
+		backupAt("two-days-ago", "2020-03-16 02:00", true),
+		backupAt("three-days-ago", "2020-03-15 02:00", true),
+	}
+	policy := backups.RetentionPolicy{Daily: 3}
+	c.Assert(ids(policy.Expired(metadata, now)), jc.DeepEquals, []string{
+		"three-days-ago", "today-1",
+	})
+}
+
+func (s *retentionSuite) TestExpiredWeekly(c *gc.C) {
+	metadata := []*backups.Metadata{
+		backupAt("this-week", "2020-03-16 02:00", true),
+		backupAt("last-sunday", "2020-03-15 02:00", true),
+		backupAt("last-monday", "2020-03-09 02:00", true),
+		backupAt("two-weeks-ago", "2020-03-04 02:00", true),
+		backupAt("three-weeks-ago", "2020-02-26 02:00", true),
+	}
+	policy := backups.RetentionPolicy{Weekly: 3}
+	c.Assert(ids(policy.Expired(metadata, now)), jc.DeepEquals, []string{
+		"three-weeks-ago", "last-monday",
+	})
+}
+
+func (s *retentionSuite) TestExpiredDailyAndWeekly(c *gc.C) {
+	metadata := []*backups.Metadata{
+		backupAt("today", "2020-03-18 02:00", true),
+		backupAt("yesterday", "2020-03-17 02:00", true),
+		backupAt("monday", "2020-03-16 02:00", true),
+		backupAt("last-sunday", "2020-03-15 02:00", true),
+		backupAt("last-saturday", "2020-03-14 02:00", true),
+	}
+	policy := backups.RetentionPolicy{Daily: 2, Weekly: 2}
+	// Today and yesterday are kept daily, and last Sunday is the most
+	// recent backup of last week.
+	c.Assert(ids(policy.Expired(metadata, now)), jc.DeepEquals, []string{
+		"last-saturday", "monday",
+	})
+}
+
+func (s *retentionSuite) TestExpiredIgnoresUnscheduled(c *gc.C) {
+	metadata := []*backups.Metadata{
+		backupAt("scheduled", "2020-03-18 02:00", true),
+		backupAt("manual", "2020-01-01 02:00", false),
+	}
+	policy := backups.RetentionPolicy{Daily: 1}
+	c.Assert(policy.Expired(metadata, now), gc.HasLen, 0)
+}
+
+func (s *retentionSuite) TestExpiredKeepsMostRecent(c *gc.C) {
+	metadata := []*backups.Metadata{
+		backupAt("older", "2020-01-01 02:00", true),
+		backupAt("newest", "2020-01-02 02:00", true),
+	}
+	c.Assert(ids(backups.RetentionPolicy{}.Expired(metadata, now)), jc.DeepEquals, []string{
+		"older",
+	})
+}
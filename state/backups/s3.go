@@ -0,0 +1,405 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// S3Config holds the details of an S3-compatible object store, such as
+// AWS S3 or MinIO, used as a backup Target.
+type S3Config struct {
+	// Endpoint is the URL of the object store, eg
+	// "https://s3.eu-west-1.amazonaws.com". Buckets are addressed
+	// by path under the endpoint.
+	Endpoint string
+
+	// Region is the region requests are signed for.
+	Region string
+
+	// Bucket is the bucket that objects are kept in.
+	Bucket string
+
+	// AccessKey and SecretKey are the credentials requests are
+	// signed with.
+	AccessKey string
+	SecretKey string
+
+	// HTTPClient is used to make requests. If nil, a client
+	// that gives up on an unresponsive object store is used.
+	HTTPClient *http.Client
+}
+
+// Validate checks that the configuration is usable.
+func (config S3Config) Validate() error {
+	if config.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if config.Region == "" {
+		return errors.NotValidf("empty Region")
+	}
+	if config.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return errors.NotValidf("missing credentials")
+	}
+	return nil
+}
+
+// s3Target is a Target that keeps objects in a bucket of an S3
+// compatible object store.
+type s3Target struct {
+	config   S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// s3Now returns the time requests are signed with.
+var s3Now = time.Now
+
+const (
+	// s3ConnectTimeout limits how long connecting to the object
+	// store may take.
+	s3ConnectTimeout = 30 * time.Second
+
+	// s3ResponseTimeout limits how long the object store may take
+	// to respond once a request has been sent.
+	s3ResponseTimeout = time.Minute
+
+	// s3RequestTimeout limits how long a whole request may take,
+	// including transferring the archive. It is generous, as
+	// archives can be large, but stops a stalled transfer from
+	// holding up backups forever.
+	s3RequestTimeout = time.Hour
+)
+
+// newS3HTTPClient returns the client used to make requests when none
+// is configured.
+func newS3HTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   s3ConnectTimeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   s3ConnectTimeout,
+			ResponseHeaderTimeout: s3ResponseTimeout,
+			ExpectContinueTimeout: time.Second,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		Timeout: s3RequestTimeout,
+	}
+}
+
+// NewS3Target returns a Target that keeps objects in a bucket of an S3
+// compatible object store.
+func NewS3Target(config S3Config) (Target, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing endpoint")
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = newS3HTTPClient()
+	}
+	return &s3Target{
+		config:   config,
+		endpoint: endpoint,
+		client:   client,
+	}, nil
+}
+
+func (t *s3Target) url(name string, query url.Values) *url.URL {
+	u := *t.endpoint
+	u.Path = path.Join("/", u.Path, t.config.Bucket, name)
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+// do signs and sends a request, returning an error for any response
+// other than a success.
+func (t *s3Target) do(method string, u *url.URL, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if body != nil {
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+		req.ContentLength = size
+	}
+	signS3Request(req, t.config.AccessKey, t.config.SecretKey, t.config.Region, s3Now().UTC())
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, s3ResponseError(resp)
+	}
+	return resp, nil
+}
+
+// Put is part of the Target interface.
+func (t *s3Target) Put(name string, content io.Reader, size int64) error {
+	if err := validateObjectName(name); err != nil {
+		return errors.Trace(err)
+	}
+	if size < 0 {
+		// The object store needs to know the size up front.
+		f, err := spool(content)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+		info, err := f.Stat()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		content, size = f, info.Size()
+	}
+	resp, err := t.do("PUT", t.url(name, nil), content, size)
+	if err != nil {
+		return errors.Annotatef(err, "storing %q", name)
+	}
+	return errors.Trace(resp.Body.Close())
+}
+
+// spool copies content to a temporary file, and returns the file ready
+// to be read from the start.
+func spool(content io.Reader) (*os.File, error) {
+	f, err := ioutil.TempFile("", "juju-backup-upload")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, errors.Trace(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// Get is part of the Target interface.
+func (t *s3Target) Get(name string) (io.ReadCloser, error) {
+	if err := validateObjectName(name); err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := t.do("GET", t.url(name, nil), nil, 0)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup object %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting %q", name)
+	}
+	return resp.Body, nil
+}
+
+// s3ListResult is the response to a ListObjectsV2 request.
+type s3ListResult struct {
+	Contents []struct {
+		Key string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// List is part of the Target interface.
+func (t *s3Target) List() ([]string, error) {
+	var names []string
+	query := url.Values{"list-type": {"2"}}
+	for {
+		resp, err := t.do("GET", t.url("", query), nil, 0)
+		if err != nil {
+			return nil, errors.Annotate(err, "listing objects")
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, errors.Annotate(err, "decoding object list")
+		}
+		for _, object := range result.Contents {
+			names = append(names, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// Remove is part of the Target interface. Object stores don't report
+// removing a missing object as an error, so neither does Remove.
+func (t *s3Target) Remove(name string) error {
+	if err := validateObjectName(name); err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := t.do("DELETE", t.url(name, nil), nil, 0)
+	if err != nil {
+		return errors.Annotatef(err, "removing %q", name)
+	}
+	return errors.Trace(resp.Body.Close())
+}
+
+// s3ErrorResponse is the body of an unsuccessful response.
+type s3ErrorResponse struct {
+	Code    string
+	Message string
+}
+
+func s3ResponseError(resp *http.Response) error {
+	var body s3ErrorResponse
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = xml.Unmarshal(data, &body)
+	if resp.StatusCode == http.StatusNotFound && body.Code != "NoSuchBucket" {
+		return errors.NotFoundf("object")
+	}
+	if body.Code != "" {
+		return errors.Errorf("object store error %s: %s", body.Code, body.Message)
+	}
+	return errors.Errorf("object store returned %s", resp.Status)
+}
+
+const (
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+)
+
+// signS3Request signs the request with AWS signature version 4. All
+// the headers set on the request when it's signed are signed, along
+// with the host. Unless a payload hash has already been set, the
+// payload itself is left unsigned so that it can be streamed.
+func signS3Request(req *http.Request, accessKey, secretKey, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	delete(headers, "authorization")
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, accessKey, scope, signedHeaders, signature,
+	))
+}
+
+// s3CanonicalQuery returns the query parameters sorted and encoded as
+// signature version 4 requires.
+func s3CanonicalQuery(query url.Values) string {
+	var params []string
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, s3Escape(name)+"="+s3Escape(value))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// s3Escape percent-encodes everything but the unreserved characters.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hexSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
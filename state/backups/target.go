@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"regexp"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+// Target is a store outside the controller that backup archives and
+// their metadata can be kept in, so that they outlive the controller
+// they protect.
+type Target interface {
+	// Put stores the content under the given name, replacing any
+	// object already there. A size of -1 means the size isn't known.
+	Put(name string, content io.Reader, size int64) error
+
+	// Get returns the content stored under the given name. If there
+	// is none, an error satisfying errors.IsNotFound is returned.
+	Get(name string) (io.ReadCloser, error)
+
+	// List returns the names of all stored objects.
+	List() ([]string, error)
+
+	// Remove deletes the content stored under the given name. If
+	// there is none, an error satisfying errors.IsNotFound may be
+	// returned.
+	Remove(name string) error
+}
+
+// validObjectName matches the names of objects that may be kept in a
+// Target. Backup IDs, and so the names derived from them, only ever
+// contain these characters.
+var validObjectName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func validateObjectName(name string) error {
+	if !validObjectName.MatchString(name) {
+		return errors.NotValidf("backup object name %q", name)
+	}
+	return nil
+}
+
+// NewTarget returns the Target described by the backup storage config.
+// Controller storage isn't a Target; use NewStorage for that instead.
+func NewTarget(cfg controller.BackupStorageConfig) (Target, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch cfg.Type {
+	case controller.BackupStorageLocal:
+		return NewLocalTarget(cfg.Path), nil
+	case controller.BackupStorageS3:
+		return NewS3Target(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	}
+	return nil, errors.NotSupportedf("backup storage %q as a target", cfg.Type)
+}
+
+// OpenStorage returns the FileStorage that the controller's backups are
+// kept in, as selected by its backup-storage config.
+func OpenStorage(st DB) (filestorage.FileStorage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageCfg := cfg.BackupStorage()
+	if storageCfg.Type == controller.BackupStorageController {
+		return NewStorage(st), nil
+	}
+	target, err := NewTarget(storageCfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening backup storage")
+	}
+	return NewTargetStorage(target), nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+)
+
+const (
+	// targetArchiveSuffix and targetMetadataSuffix are appended to a
+	// backup's ID to name the objects holding its archive and its
+	// metadata in a Target.
+	targetArchiveSuffix  = ".tar.gz"
+	targetMetadataSuffix = ".json"
+)
+
+// targetMetadataDoc is how backup metadata is kept in a Target. It is
+// the archive's metadata file format, plus the fields that are
+// otherwise only recorded in the controller's database.
+type targetMetadataDoc struct {
+	flatMetadata
+	Scheduled       bool   `json:",omitempty"`
+	Encryption      string `json:",omitempty"`
+	EncryptionKeyID string `json:",omitempty"`
+}
+
+// targetStorage is a filestorage.FileStorage that keeps each backup as
+// an archive object and a metadata object in a Target. The metadata is
+// written last, so a backup is only listed once its archive is stored.
+type targetStorage struct {
+	target Target
+}
+
+// NewTargetStorage returns a new FileStorage that keeps backup archives
+// and their metadata in the given Target.
+func NewTargetStorage(target Target) filestorage.FileStorage {
+	return &targetStorage{target: target}
+}
+
+func (s *targetStorage) readMetadata(id string) (*Metadata, error) {
+	if err := validateObjectName(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	r, err := s.target.Get(id + targetMetadataSuffix)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup metadata %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "reading backup metadata %q", id)
+	}
+	defer r.Close()
+
+	var doc targetMetadataDoc
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, errors.Annotatef(err, "decoding backup metadata %q", id)
+	}
+	meta, err := doc.inflate()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Scheduled = doc.Scheduled
+	meta.Encryption = doc.Encryption
+	meta.EncryptionKeyID = doc.EncryptionKeyID
+	return meta, nil
+}
+
+func (s *targetStorage) writeMetadata(id string, meta *Metadata, stored time.Time) error {
+	doc := targetMetadataDoc{
+		flatMetadata:    meta.flat(),
+		Scheduled:       meta.Scheduled,
+		Encryption:      meta.Encryption,
+		EncryptionKeyID: meta.EncryptionKeyID,
+	}
+	doc.ID = id
+	doc.Stored = stored.UTC()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.target.Put(id+targetMetadataSuffix, bytes.NewReader(data), int64(len(data)))
+	return errors.Annotatef(err, "writing backup metadata %q", id)
+}
+
+// Metadata implements filestorage.FileStorage.
+func (s *targetStorage) Metadata(id string) (filestorage.Metadata, error) {
+	meta, err := s.readMetadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// Get implements filestorage.FileStorage.
+func (s *targetStorage) Get(id string) (filestorage.Metadata, io.ReadCloser, error) {
+	meta, err := s.readMetadata(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := s.target.Get(id + targetArchiveSuffix)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "reading backup archive %q", id)
+	}
+	return meta, archive, nil
+}
+
+// List implements filestorage.FileStorage. The backups are returned in
+// ID order, which is the order in which they were started.
+func (s *targetStorage) List() ([]filestorage.Metadata, error) {
+	names, err := s.target.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing backups")
+	}
+	var ids []string
+	for _, name := range names {
+		if id := strings.TrimSuffix(name, targetMetadataSuffix); id != name {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	result := make([]filestorage.Metadata, 0, len(ids))
+	for _, id := range ids {
+		meta, err := s.readMetadata(id)
+		if errors.IsNotFound(err) {
+			// Removed while we were listing.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, meta)
+	}
+	return result, nil
+}
+
+// Add implements filestorage.FileStorage. Any ID already set on the
+// metadata is ignored; the backup's ID is derived from when and where
+// it was taken, as it is in the controller's database.
+func (s *targetStorage) Add(meta filestorage.Metadata, archive io.Reader) (string, error) {
+	backupMeta, ok := meta.(*Metadata)
+	if !ok {
+		return "", errors.Errorf("metadata must be of type *backups.Metadata, got %T", meta)
+	}
+	doc := newStorageMetaDoc(backupMeta)
+	id := newStorageID(&doc)
+
+	_, err := s.readMetadata(id)
+	if err == nil {
+		return "", errors.AlreadyExistsf("backup metadata %q", id)
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	if archive != nil {
+		if err := s.putArchive(id, archive, backupMeta.Size()); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	if err := s.writeMetadata(id, backupMeta, time.Now()); err != nil {
+		return "", errors.Trace(err)
+	}
+	return id, nil
+}
+
+func (s *targetStorage) putArchive(id string, archive io.Reader, size int64) error {
+	if size <= 0 {
+		size = -1
+	}
+	err := s.target.Put(id+targetArchiveSuffix, archive, size)
+	return errors.Annotatef(err, "writing backup archive %q", id)
+}
+
+// SetFile implements filestorage.FileStorage.
+func (s *targetStorage) SetFile(id string, file io.Reader) error {
+	meta, err := s.readMetadata(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.putArchive(id, file, meta.Size()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.writeMetadata(id, meta, time.Now()))
+}
+
+// Remove implements filestorage.FileStorage.
+func (s *targetStorage) Remove(id string) error {
+	if _, err := s.readMetadata(id); err != nil {
+		return errors.Trace(err)
+	}
+	// Remove the metadata first so that a partially removed backup
+	// is never listed.
+	if err := s.target.Remove(id + targetMetadataSuffix); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "removing backup metadata %q", id)
+	}
+	if err := s.target.Remove(id + targetArchiveSuffix); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "removing backup archive %q", id)
+	}
+	return nil
+}
+
+// Close implements filestorage.FileStorage.
+func (s *targetStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type targetStorageSuite struct {
+	testing.IsolationSuite
+	dir    string
+	target backups.Target
+}
+
+var _ = gc.Suite(&targetStorageSuite{})
+
+func (s *targetStorageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.target = backups.NewLocalTarget(s.dir)
+}
+
+func (s *targetStorageSuite) metadata(c *gc.C, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Started = started
+	meta.Origin.Model = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	meta.Notes = "some notes"
+	err := meta.MarkComplete(10, "some hash")
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *targetStorageSuite) TestAddGet(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	meta := s.metadata(c, time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC))
+	meta.Scheduled = true
+
+	id, err := stor.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "20200318-020000.deadbeef-0bad-400d-8000-4b1d0d06f00d")
+
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, jc.SameContents, []string{id + ".json", id + ".tar.gz"})
+
+	stored, archive, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "0123456789")
+
+	storedMeta := stored.(*backups.Metadata)
+	c.Check(storedMeta.ID(), gc.Equals, id)
+	c.Check(storedMeta.Started.Equal(meta.Started), jc.IsTrue)
+	c.Check(storedMeta.Notes, gc.Equals, "some notes")
+	c.Check(storedMeta.Scheduled, jc.IsTrue)
+	c.Check(storedMeta.Size(), gc.Equals, int64(10))
+	c.Check(storedMeta.Checksum(), gc.Equals, "some hash")
+	c.Check(storedMeta.Origin, jc.DeepEquals, meta.Origin)
+	c.Check(storedMeta.Stored(), gc.NotNil)
+}
+
+func (s *targetStorageSuite) TestAddAlreadyExists(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	meta := s.metadata(c, time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC))
+	_, err := stor.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stor.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *targetStorageSuite) TestList(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	for _, day := range []int{19, 17, 18} {
+		meta := s.metadata(c, time.Date(2020, 3, day, 2, 0, 0, 0, time.UTC))
+		_, err := stor.Add(meta, bytes.NewBufferString("0123456789"))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	// An archive without metadata, as left by an interrupted upload,
+	// isn't listed.
+	err := s.target.Put("20200320-020000.deadbeef.tar.gz", bytes.NewBufferString("x"), 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, meta := range list {
+		ids = append(ids, meta.ID())
+	}
+	c.Check(ids, jc.DeepEquals, []string{
+		"20200317-020000.deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"20200318-020000.deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"20200319-020000.deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+}
+
+func (s *targetStorageSuite) TestRemove(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	meta := s.metadata(c, time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC))
+	id, err := stor.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	names, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+
+	err = stor.Remove(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetStorageSuite) TestMetadataNotFound(c *gc.C) {
+	stor := backups.NewTargetStorage(s.target)
+	_, err := stor.Metadata("20200318-020000.missing")
+	c.Check(err, gc.ErrorMatches, `backup metadata "20200318-020000.missing" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetStorageSuite) TestBackupsAddAndList(c *gc.C) {
+	b := backups.NewBackups(backups.NewTargetStorage(s.target))
+	meta := s.metadata(c, time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC))
+	id, err := b.Add(bytes.NewBufferString("0123456789"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.ID(), gc.Equals, id)
+	c.Check(meta.Stored(), gc.NotNil)
+
+	list, err := b.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Check(list[0].ID(), gc.Equals, id)
+}
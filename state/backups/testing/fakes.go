@@ -53,6 +53,10 @@ type FakeBackups struct {
 	KeepCopy bool
 	// NoDownload holds the noDownload bool that was passed in.
 	NoDownload bool
+	// EncryptionKeyArg holds the encryption key that was passed in.
+	EncryptionKeyArg backups.EncryptionKey
+	// DecryptionKeyArg holds the decryption key that was passed in.
+	DecryptionKeyArg backups.DecryptionKey
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -64,6 +68,7 @@ func (b *FakeBackups) Create(
 	paths *backups.Paths,
 	dbInfo *backups.DBInfo,
 	keepCopy, noDownload bool,
+	key backups.EncryptionKey,
 ) (string, error) {
 	b.Calls = append(b.Calls, "Create")
 
@@ -72,6 +77,7 @@ func (b *FakeBackups) Create(
 	b.MetaArg = meta
 	b.KeepCopy = keepCopy
 	b.NoDownload = noDownload
+	b.EncryptionKeyArg = key
 
 	if b.Meta != nil {
 		*meta = *b.Meta
@@ -117,6 +123,7 @@ func (b *FakeBackups) Restore(bkpId string, args backups.RestoreArgs) (names.Tag
 	b.Calls = append(b.Calls, "Restore")
 	b.PrivateAddr = args.PrivateAddress
 	b.InstanceId = args.NewInstId
+	b.DecryptionKeyArg = args.Decryption
 	return nil, errors.Trace(b.Error)
 }
 
@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// scheduledBackupsKey is the id of the document in the controllers
+// collection that records the outcome of scheduled backups.
+const scheduledBackupsKey = "scheduledBackups"
+
+// ScheduledBackupStatus records the outcome of the most recent
+// scheduled backups of the controller.
+type ScheduledBackupStatus struct {
+	// LastSuccess is when the most recent successful scheduled
+	// backup finished, or the zero time if none has succeeded.
+	LastSuccess time.Time
+
+	// LastBackupID is the ID of the most recent successful
+	// scheduled backup.
+	LastBackupID string
+
+	// LastFailure is when the most recent failed scheduled backup
+	// was attempted, or the zero time if none has failed.
+	LastFailure time.Time
+
+	// LastError is the error that caused the most recent failure.
+	LastError string
+}
+
+type scheduledBackupsDoc struct {
+	LastSuccess  int64  `bson:"last-success,omitempty"`
+	LastBackupID string `bson:"last-backup-id,omitempty"`
+	LastFailure  int64  `bson:"last-failure,omitempty"`
+	LastError    string `bson:"last-error,omitempty"`
+}
+
+// ScheduledBackupStatus returns the outcome of the most recent
+// scheduled backups. The zero value is returned if no scheduled
+// backup has been attempted.
+func (st *State) ScheduledBackupStatus() (ScheduledBackupStatus, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc scheduledBackupsDoc
+	err := controllers.FindId(scheduledBackupsKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ScheduledBackupStatus{}, nil
+	} else if err != nil {
+		return ScheduledBackupStatus{}, errors.Annotate(err, "cannot get scheduled backup status")
+	}
+	return ScheduledBackupStatus{
+		LastSuccess:  unixNanoToTime0(doc.LastSuccess).UTC(),
+		LastBackupID: doc.LastBackupID,
+		LastFailure:  unixNanoToTime0(doc.LastFailure).UTC(),
+		LastError:    doc.LastError,
+	}, nil
+}
+
+// RecordScheduledBackupSuccess records that the scheduled backup with
+// the given ID finished successfully at the given time.
+func (st *State) RecordScheduledBackupSuccess(id string, when time.Time) error {
+	err := st.updateScheduledBackups(bson.D{
+		{"last-success", when.UnixNano()},
+		{"last-backup-id", id},
+	})
+	return errors.Annotate(err, "cannot record scheduled backup success")
+}
+
+// RecordScheduledBackupFailure records that the scheduled backup
+// attempted at the given time failed with the given error.
+func (st *State) RecordScheduledBackupFailure(cause error, when time.Time) error {
+	err := st.updateScheduledBackups(bson.D{
+		{"last-failure", when.UnixNano()},
+		{"last-error", cause.Error()},
+	})
+	return errors.Annotate(err, "cannot record scheduled backup failure")
+}
+
+func (st *State) updateScheduledBackups(fields bson.D) error {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		count, err := controllers.FindId(scheduledBackupsKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     scheduledBackupsKey,
+				Assert: txn.DocMissing,
+				Insert: fields,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     scheduledBackupsKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", fields}},
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ScheduledBackupStatusSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ScheduledBackupStatusSuite{})
+
+func (s *ScheduledBackupStatusSuite) TestStatusInitiallyEmpty(c *gc.C) {
+	status, err := s.State.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.ScheduledBackupStatus{})
+}
+
+func (s *ScheduledBackupStatusSuite) TestRecordSuccessAndFailure(c *gc.C) {
+	success := time.Date(2020, 3, 18, 2, 0, 0, 0, time.UTC)
+	err := s.State.RecordScheduledBackupSuccess("backup-1", success)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.State.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.ScheduledBackupStatus{
+		LastSuccess:  success,
+		LastBackupID: "backup-1",
+	})
+
+	failure := success.Add(24 * time.Hour)
+	err = s.State.RecordScheduledBackupFailure(errors.New("disk full"), failure)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = s.State.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.ScheduledBackupStatus{
+		LastSuccess:  success,
+		LastBackupID: "backup-1",
+		LastFailure:  failure,
+		LastError:    "disk full",
+	})
+}
@@ -0,0 +1,244 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CharmMetricType describes how the values of a charm metric are
+// aggregated by the controller.
+type CharmMetricType string
+
+const (
+	// CharmMetricGauge metrics take the latest value published.
+	CharmMetricGauge CharmMetricType = "gauge"
+
+	// CharmMetricCounter metrics add up the values published, which
+	// must not be negative.
+	CharmMetricCounter CharmMetricType = "counter"
+)
+
+var validCharmMetricName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// MaxCharmMetricsPerUnit is the number of differently named metrics a
+// unit may publish, which bounds the series the controller exposes.
+const MaxCharmMetricsPerUnit = 100
+
+// CharmMetric is a value published by a charm, which the controller
+// exposes on its Prometheus endpoint.
+type CharmMetric struct {
+	Name  string
+	Type  CharmMetricType
+	Help  string
+	Value float64
+}
+
+// Validate returns an error if the metric cannot be published.
+func (m CharmMetric) Validate() error {
+	if !validCharmMetricName.MatchString(m.Name) {
+		return errors.NotValidf("charm metric name %q", m.Name)
+	}
+	switch m.Type {
+	case CharmMetricGauge:
+	case CharmMetricCounter:
+		if m.Value < 0 {
+			return errors.NotValidf("negative value %v for counter %q", m.Value, m.Name)
+		}
+	default:
+		return errors.NotValidf("charm metric type %q", m.Type)
+	}
+	return nil
+}
+
+// UnitCharmMetrics holds the aggregated metrics published by a unit.
+type UnitCharmMetrics struct {
+	Unit        string
+	Application string
+	Metrics     []CharmMetric
+	Updated     time.Time
+}
+
+// charmMetricsDoc records the aggregated metrics published by a unit.
+type charmMetricsDoc struct {
+	DocID       string                    `bson:"_id"`
+	Unit        string                    `bson:"unit"`
+	Application string                    `bson:"application"`
+	Metrics     map[string]charmMetricDoc `bson:"metrics"`
+	Updated     int64                     `bson:"updated"`
+
+	TxnRevno int64 `bson:"txn-revno"`
+}
+
+type charmMetricDoc struct {
+	Type  CharmMetricType `bson:"type"`
+	Help  string          `bson:"help,omitempty"`
+	Value float64         `bson:"value"`
+}
+
+func (doc charmMetricsDoc) unitCharmMetrics() UnitCharmMetrics {
+	result := UnitCharmMetrics{
+		Unit:        doc.Unit,
+		Application: doc.Application,
+		Metrics:     make([]CharmMetric, 0, len(doc.Metrics)),
+		Updated:     time.Unix(0, doc.Updated).UTC(),
+	}
+	for name, m := range doc.Metrics {
+		result.Metrics = append(result.Metrics, CharmMetric{
+			Name:  name,
+			Type:  m.Type,
+			Help:  m.Help,
+			Value: m.Value,
+		})
+	}
+	sort.Slice(result.Metrics, func(i, j int) bool {
+		return result.Metrics[i].Name < result.Metrics[j].Name
+	})
+	return result
+}
+
+// removeCharmMetricsOp returns the operation needed to remove the charm
+// metrics document associated with the given globalKey.
+func removeCharmMetricsOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      charmMetricsC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+// PublishCharmMetrics aggregates the supplied metrics into those
+// previously published by the unit. Gauges replace any earlier value,
+// while counters are added to it; a metric that changes type starts
+// again from the value supplied. An error is returned if the unit
+// would have more than MaxCharmMetricsPerUnit metrics.
+func (u *Unit) PublishCharmMetrics(metrics []CharmMetric) error {
+	for _, m := range metrics {
+		if err := m.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+	unitGlobalKey := u.globalKey()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() != Alive {
+			return nil, errors.NotFoundf("unit %s", u.Name())
+		}
+		unitAliveOp := txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+		}
+
+		coll, closer := u.st.db().GetCollection(charmMetricsC)
+		defer closer()
+
+		var doc charmMetricsDoc
+		err := coll.FindId(unitGlobalKey).One(&doc)
+		if err != nil && err != mgo.ErrNotFound {
+			return nil, errors.Trace(err)
+		}
+		exists := err == nil
+
+		aggregated := make(map[string]charmMetricDoc, len(doc.Metrics)+len(metrics))
+		for name, m := range doc.Metrics {
+			aggregated[name] = m
+		}
+		for _, m := range metrics {
+			value := m.Value
+			if prev, ok := aggregated[m.Name]; ok && m.Type == CharmMetricCounter && prev.Type == CharmMetricCounter {
+				value += prev.Value
+			}
+			aggregated[m.Name] = charmMetricDoc{
+				Type:  m.Type,
+				Help:  m.Help,
+				Value: value,
+			}
+		}
+		if len(aggregated) > MaxCharmMetricsPerUnit {
+			return nil, errors.Errorf(
+				"unit would have %d charm metrics, the limit is %d",
+				len(aggregated), MaxCharmMetricsPerUnit,
+			)
+		}
+		updated := u.st.clock().Now().UnixNano()
+
+		if !exists {
+			return []txn.Op{unitAliveOp, {
+				C:      charmMetricsC,
+				Id:     unitGlobalKey,
+				Assert: txn.DocMissing,
+				Insert: charmMetricsDoc{
+					DocID:       unitGlobalKey,
+					Unit:        u.Name(),
+					Application: u.ApplicationName(),
+					Metrics:     aggregated,
+					Updated:     updated,
+				},
+			}}, nil
+		}
+		return []txn.Op{unitAliveOp, {
+			C:      charmMetricsC,
+			Id:     unitGlobalKey,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"metrics", aggregated},
+				{"updated", updated},
+			}}},
+		}}, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(onAbort(err, ErrDead), "cannot publish charm metrics for unit %q", u)
+	}
+	return nil
+}
+
+// CharmMetrics returns the aggregated metrics published by the unit.
+func (u *Unit) CharmMetrics() (UnitCharmMetrics, error) {
+	coll, closer := u.st.db().GetCollection(charmMetricsC)
+	defer closer()
+
+	var doc charmMetricsDoc
+	if err := coll.FindId(u.globalKey()).One(&doc); err == mgo.ErrNotFound {
+		return UnitCharmMetrics{
+			Unit:        u.Name(),
+			Application: u.ApplicationName(),
+		}, nil
+	} else if err != nil {
+		return UnitCharmMetrics{}, errors.Trace(err)
+	}
+	return doc.unitCharmMetrics(), nil
+}
+
+// AllCharmMetrics returns the aggregated metrics published by each unit
+// in the model, ordered by unit name.
+func (st *State) AllCharmMetrics() ([]UnitCharmMetrics, error) {
+	coll, closer := st.db().GetCollection(charmMetricsC)
+	defer closer()
+
+	var docs []charmMetricsDoc
+	if err := coll.Find(nil).Sort("unit").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]UnitCharmMetrics, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.unitCharmMetrics()
+	}
+	return result, nil
+}
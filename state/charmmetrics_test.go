@@ -0,0 +1,147 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmMetricsSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&CharmMetricsSuite{})
+
+func (s *CharmMetricsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.unit, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmMetricsSuite) TestNoMetrics(c *gc.C) {
+	metrics, err := s.unit.CharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metrics, jc.DeepEquals, state.UnitCharmMetrics{
+		Unit:        "wordpress/0",
+		Application: "wordpress",
+	})
+
+	all, err := s.State.AllCharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
+
+func (s *CharmMetricsSuite) TestPublishAggregates(c *gc.C) {
+	err := s.unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Help: "Jobs waiting", Value: 5},
+		{Name: "requests", Type: state.CharmMetricCounter, Value: 10},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Help: "Jobs waiting", Value: 2},
+		{Name: "requests", Type: state.CharmMetricCounter, Value: 3},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	metrics, err := s.unit.CharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metrics.Metrics, jc.DeepEquals, []state.CharmMetric{
+		{Name: "queue_depth", Type: state.CharmMetricGauge, Help: "Jobs waiting", Value: 2},
+		{Name: "requests", Type: state.CharmMetricCounter, Value: 13},
+	})
+	c.Assert(metrics.Updated.Equal(s.Clock.Now()), jc.IsTrue)
+
+	all, err := s.State.AllCharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, []state.UnitCharmMetrics{metrics})
+}
+
+func (s *CharmMetricsSuite) TestPublishChangedTypeResets(c *gc.C) {
+	err := s.unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricCounter, Value: 10},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricGauge, Value: 4},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	metrics, err := s.unit.CharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metrics.Metrics, jc.DeepEquals, []state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricGauge, Value: 4},
+	})
+}
+
+func (s *CharmMetricsSuite) TestPublishInvalid(c *gc.C) {
+	for i, test := range []struct {
+		metric state.CharmMetric
+		err    string
+	}{{
+		metric: state.CharmMetric{Name: "queue-depth", Type: state.CharmMetricGauge},
+		err:    `charm metric name "queue-depth" not valid`,
+	}, {
+		metric: state.CharmMetric{Name: "requests", Type: state.CharmMetricCounter, Value: -1},
+		err:    `negative value -1 for counter "requests" not valid`,
+	}, {
+		metric: state.CharmMetric{Name: "requests", Type: "histogram"},
+		err:    `charm metric type "histogram" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.unit.PublishCharmMetrics([]state.CharmMetric{test.metric})
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *CharmMetricsSuite) TestPublishTooManyMetrics(c *gc.C) {
+	metrics := make([]state.CharmMetric, state.MaxCharmMetricsPerUnit)
+	for i := range metrics {
+		metrics[i] = state.CharmMetric{Name: fmt.Sprintf("metric_%d", i), Type: state.CharmMetricGauge}
+	}
+	err := s.unit.PublishCharmMetrics(metrics)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Metrics already published may still be updated.
+	err = s.unit.PublishCharmMetrics(metrics[:1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "one_too_many", Type: state.CharmMetricGauge},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot publish charm metrics for unit "wordpress/0": unit would have 101 charm metrics, the limit is 100`)
+	published, err := s.unit.CharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(published.Metrics, gc.HasLen, state.MaxCharmMetricsPerUnit)
+}
+
+func (s *CharmMetricsSuite) TestRemoveUnitDeletesMetrics(c *gc.C) {
+	err := s.unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricCounter, Value: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllCharmMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+
+	err = s.unit.PublishCharmMetrics([]state.CharmMetric{
+		{Name: "requests", Type: state.CharmMetricCounter, Value: 1},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
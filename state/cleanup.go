@@ -435,8 +435,14 @@ func (st *State) cleanupStorageForDyingModel(cleanupArgs []bson.Raw) (err error)
 	}
 
 	destroyStorage := sb.DestroyStorageInstance
+	destroyVolumeSnapshot := func(id string) error {
+		// The model's applications are being destroyed, so any
+		// references to the snapshot will soon be gone too.
+		return sb.destroyVolumeSnapshot(id, false)
+	}
 	if args.DestroyStorage == nil || !*args.DestroyStorage {
 		destroyStorage = sb.ReleaseStorageInstance
+		destroyVolumeSnapshot = sb.releaseVolumeSnapshot
 	}
 
 	storage, err := sb.AllStorageInstances()
@@ -453,6 +459,19 @@ func (st *State) cleanupStorageForDyingModel(cleanupArgs []bson.Raw) (err error)
 			return errors.Trace(err)
 		}
 	}
+
+	snapshots, err := sb.AllVolumeSnapshots()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range snapshots {
+		err := destroyVolumeSnapshot(s.Id())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
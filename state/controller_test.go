@@ -34,8 +34,23 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AllowModelAccessKey,
 		controller.APIPortOpenDelay,
 		controller.AuditLogExcludeMethods,
+		controller.AuditLogSinks,
+		controller.AuditLogSyslogHost,
+		controller.AuditLogSyslogCACert,
+		controller.AuditLogSyslogClientCert,
+		controller.AuditLogSyslogClientKey,
+		controller.AuditLogWebhookURL,
+		controller.AuditLogWebhookBatchSize,
+		controller.AuditLogWebhookFlushInterval,
+		controller.AuditLogWebhookMaxAttempts,
 		controller.AutocertURLKey,
 		controller.AutocertDNSNameKey,
+		controller.BackupSchedule,
+		controller.BackupStoragePath,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Bucket,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
 		controller.CAASImageRepo,
 		controller.CAASOperatorImagePath,
 		controller.CharmStoreURL,
@@ -674,6 +674,61 @@ func setControllerWantsVoteOp(st *State, id string, wantsVote bool) txn.Op {
 	}
 }
 
+// DemoteController marks the controller with the given id as no longer
+// wanting to vote, so that the peergrouper removes its vote from the
+// replica set. The controller remains a controller. It is an error to
+// demote the last controller that wants to vote.
+func (st *State) DemoteController(id string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		node, err := st.ControllerNode(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !node.WantsVote() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		controllerIds, err := st.ControllerIds()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      controllerNodesC,
+			Id:     st.docID(id),
+			Assert: bson.D{{"wants-vote", true}},
+			Update: bson.D{{"$set", bson.D{{"wants-vote", false}}}},
+		}}
+		for _, otherId := range controllerIds {
+			if otherId == id {
+				continue
+			}
+			other, err := st.ControllerNode(otherId)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !other.WantsVote() {
+				continue
+			}
+			// Ensure the other controller still wants to vote
+			// when this one is demoted.
+			ops = append(ops, txn.Op{
+				C:      controllerNodesC,
+				Id:     st.docID(otherId),
+				Assert: bson.D{{"wants-vote", true}},
+			})
+		}
+		if len(ops) == 1 {
+			return nil, errors.Errorf("controller %s cannot be demoted as it is the only voting controller", id)
+		}
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 type controllerReference interface {
 	Id() string
 	Refresh() error
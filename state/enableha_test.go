@@ -58,6 +58,42 @@ func (s *EnableHASuite) TestHasVote(c *gc.C) {
 	c.Assert(node.HasVote(), jc.IsFalse)
 }
 
+func (s *EnableHASuite) TestDemoteController(c *gc.C) {
+	m0, err := s.State.AddMachine("bionic", state.JobHostUnits, state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "bionic", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 2)
+
+	err = s.State.DemoteController(changes.Added[0])
+	c.Assert(err, jc.ErrorIsNil)
+	node, err := s.State.ControllerNode(changes.Added[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.WantsVote(), jc.IsFalse)
+
+	// Demoting again is a no-op.
+	err = s.State.DemoteController(changes.Added[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller remains a controller.
+	controllerIds, err := s.State.ControllerIds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerIds, jc.SameContents, []string{m0.Id(), changes.Added[0], changes.Added[1]})
+
+	err = s.State.DemoteController(changes.Added[1])
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DemoteController(m0.Id())
+	c.Assert(err, gc.ErrorMatches, `controller 0 cannot be demoted as it is the only voting controller`)
+	node, err = s.State.ControllerNode(m0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.WantsVote(), jc.IsTrue)
+}
+
+func (s *EnableHASuite) TestDemoteControllerNotFound(c *gc.C) {
+	err := s.State.DemoteController("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *EnableHASuite) TestEnableHAFailsWithBadCount(c *gc.C) {
 	for _, n := range []int{-1, 2, 6} {
 		changes, err := s.State.EnableHA(n, constraints.Value{}, "", nil)
@@ -67,6 +67,11 @@ type Filesystem interface {
 	// Releasing reports whether or not the filesystem is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size in MiB that the filesystem has
+	// been requested to grow to, and true; or false if the filesystem
+	// has no pending resize.
+	RequestedSize() (uint64, bool)
 }
 
 // FilesystemAttachment describes an attachment of a filesystem to a machine.
@@ -116,6 +121,11 @@ type filesystemDoc struct {
 	Info            *FilesystemInfo   `bson:"info,omitempty"`
 	Params          *FilesystemParams `bson:"params,omitempty"`
 
+	// RequestedSize is the size in MiB that the provisioned filesystem
+	// has been requested to grow to. It is removed once the filesystem
+	// info records a size at least this large.
+	RequestedSize uint64 `bson:"requested-size,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the filesystem as being non-detachable, and to determine
@@ -252,6 +262,11 @@ func (f *filesystem) Releasing() bool {
 	return f.doc.Releasing
 }
 
+// RequestedSize is required to implement Filesystem.
+func (f *filesystem) RequestedSize() (uint64, bool) {
+	return f.doc.RequestedSize, f.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (f *filesystem) Status() (status.StatusInfo, error) {
 	return getStatus(f.mb.db(), filesystemGlobalKey(f.FilesystemTag().Id()), "filesystem")
@@ -1265,7 +1280,13 @@ func (sb *storageBackend) SetFilesystemInfo(tag names.FilesystemTag, info Filesy
 				return nil, err
 			}
 		}
-		ops := setFilesystemInfoOps(tag, info, unsetParams)
+		// If the filesystem has grown to its requested size,
+		// the resize is complete.
+		var resizedFrom uint64
+		if requestedSize, ok := fs.RequestedSize(); ok && info.Size >= requestedSize {
+			resizedFrom = requestedSize
+		}
+		ops := setFilesystemInfoOps(tag, info, unsetParams, resizedFrom)
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
@@ -1287,15 +1308,27 @@ func validateFilesystemInfoChange(newInfo, oldInfo FilesystemInfo) error {
 	return nil
 }
 
-func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetParams bool) []txn.Op {
+// setFilesystemInfoOps returns the operations for setting the filesystem's
+// info. If resizedFrom is non-zero, the filesystem's requested size is
+// removed, asserting that it is still resizedFrom.
+func setFilesystemInfoOps(tag names.FilesystemTag, info FilesystemInfo, unsetParams bool, resizedFrom uint64) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if resizedFrom > 0 {
+		// Don't lose a concurrent request to grow the filesystem further.
+		asserts = append(asserts, bson.DocElem{"requested-size", resizedFrom})
+		unset = append(unset, bson.DocElem{"requested-size", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      filesystemsC,
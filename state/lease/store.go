@@ -223,6 +223,11 @@ func (store *store) ExpireLease(key lease.Key) error {
 	return nil
 }
 
+// TransferLease is part of the Store interface.
+func (store *store) TransferLease(key lease.Key, holder string, request lease.Request, _ <-chan struct{}) error {
+	return errors.NotImplementedf("transferring legacy leases")
+}
+
 // PinLease is part of the Store interface.
 func (store *store) PinLease(key lease.Key, entity string, _ <-chan struct{}) error {
 	return errors.NotImplementedf("pinning for legacy leases")
@@ -432,16 +432,20 @@ type LogRecord struct {
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
-	MinLevel      loggo.Level
-	InitialLines  int
-	NoTail        bool
-	IncludeEntity []string
-	ExcludeEntity []string
-	IncludeModule []string
-	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+	StartID        int64
+	StartTime      time.Time
+	EndTime        time.Time
+	MinLevel       loggo.Level
+	InitialLines   int
+	NoTail         bool
+	IncludeEntity  []string
+	ExcludeEntity  []string
+	IncludeModule  []string
+	ExcludeModule  []string
+	IncludeMessage []string        // regular expressions
+	ExcludeMessage []string        // regular expressions
+	AgentKinds     []string        // e.g. "machine", "unit"
+	Oplog          *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -477,12 +481,22 @@ type LogTailerState interface {
 // NewLogTailer returns a LogTailer which filters according to the
 // parameters given.
 func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	includeMessage, err := compileMessagePattern(params.IncludeMessage)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid message filter")
+	}
+	excludeMessage, err := compileMessagePattern(params.ExcludeMessage)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid message filter")
+	}
 	session := st.MongoSession().Copy()
 	t := &logTailer{
 		modelUUID:       st.ModelUUID(),
 		session:         session,
 		logsColl:        session.DB(logsDB).C(logCollectionName(st.ModelUUID())).With(session),
 		params:          params,
+		includeMessage:  includeMessage,
+		excludeMessage:  excludeMessage,
 		logCh:           make(chan *LogRecord),
 		recentIds:       newRecentIdTracker(maxRecentLogIds),
 		maxInitialLines: maxInitialLines,
@@ -507,6 +521,12 @@ type logTailer struct {
 	lastTime        time.Time
 	recentIds       *recentIdTracker
 	maxInitialLines int
+
+	// includeMessage and excludeMessage filter logs by message.
+	// They're applied here rather than in the query, so the
+	// expressions are run by the same engine that validated them.
+	includeMessage *regexp.Regexp
+	excludeMessage *regexp.Regexp
 }
 
 // Logs implements the LogTailer interface.
@@ -561,23 +581,30 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 			t.params.InitialLines, maxInitialLines)
 	}
 	query.Sort("-t", "-_id")
-	query.Limit(t.params.InitialLines)
+	if t.includeMessage == nil && t.excludeMessage == nil {
+		query.Limit(t.params.InitialLines)
+	} else {
+		// We don't know how many logs must be read to find
+		// enough with matching messages, so the limit is
+		// applied below, after filtering.
+		query.Batch(t.params.InitialLines)
+	}
 	iter := query.Iter()
 	defer iter.Close()
 	queue := make([]logDoc, t.params.InitialLines)
 	cur := t.params.InitialLines
 	var doc logDoc
-	for iter.Next(&doc) {
+	for cur > 0 && iter.Next(&doc) {
 		select {
 		case <-t.tomb.Dying():
 			return errors.Trace(tomb.ErrDying)
 		default:
 		}
+		if !t.messageMatches(doc.Message) {
+			continue
+		}
 		cur--
 		queue[cur] = doc
-		if cur == 0 {
-			break
-		}
 	}
 	if err := iter.Close(); err != nil {
 		return errors.Trace(err)
@@ -645,6 +672,9 @@ func (t *logTailer) processCollection() error {
 			}
 			deserialisationFailures = 0
 		}
+		if !t.messageMatches(rec.Message) {
+			continue
+		}
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
@@ -666,6 +696,17 @@ func (t *logTailer) tailOplog() error {
 
 	newParams := t.params
 	newParams.StartID = t.lastID // (t.lastID + 1) once Id is a sequential int.
+	// Logs written after the end time are not filtered out of the
+	// oplog, so that tailing stops as soon as one is seen.
+	newParams.EndTime = time.Time{}
+	// Tailing also stops once the end time has passed, in case
+	// nothing more is logged.
+	var endTime <-chan time.Time
+	if !t.params.EndTime.IsZero() {
+		timer := time.NewTimer(t.params.EndTime.Sub(time.Now()))
+		defer timer.Stop()
+		endTime = timer.C
+	}
 	oplogSel := append(t.paramsToSelector(newParams, "o."),
 		bson.DocElem{"ns", logsDB + "." + logCollectionName(t.modelUUID)},
 	)
@@ -691,6 +732,8 @@ func (t *logTailer) tailOplog() error {
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
+		case <-endTime:
+			return nil
 		case oplogDoc, ok := <-oplogTailer.Out():
 			if !ok {
 				return errors.Annotate(oplogTailer.Err(), "oplog tailer died")
@@ -727,6 +770,14 @@ func (t *logTailer) tailOplog() error {
 				}
 				deserialisationFailures = 0
 			}
+			if !t.params.EndTime.IsZero() && rec.Time.After(t.params.EndTime) {
+				// Logs are written in time order, so no more
+				// will fall within the time range.
+				return nil
+			}
+			if !t.messageMatches(rec.Message) {
+				continue
+			}
 			select {
 			case <-t.tomb.Dying():
 				return tomb.ErrDying
@@ -738,8 +789,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
-	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+	if !params.StartTime.IsZero() || !params.EndTime.IsZero() {
+		timeSel := bson.M{}
+		if !params.StartTime.IsZero() {
+			timeSel["$gte"] = params.StartTime.UnixNano()
+		}
+		if !params.EndTime.IsZero() {
+			timeSel["$lte"] = params.EndTime.UnixNano()
+		}
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -760,6 +818,10 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.AgentKinds) > 0 {
+		sel = append(sel,
+			bson.DocElem{"n", bson.RegEx{Pattern: makeAgentKindPattern(params.AgentKinds)}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -786,6 +848,42 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+func makeAgentKindPattern(kinds []string) string {
+	var patterns []string
+	for _, kind := range kinds {
+		patterns = append(patterns, regexp.QuoteMeta(kind))
+	}
+	return `^(` + strings.Join(patterns, "|") + `)-`
+}
+
+// compileMessagePattern compiles a regular expression that matches
+// any of the given expressions, or returns nil if there are none.
+func compileMessagePattern(expressions []string) (*regexp.Regexp, error) {
+	if len(expressions) == 0 {
+		return nil, nil
+	}
+	var patterns []string
+	for _, expr := range expressions {
+		if _, err := regexp.Compile(expr); err != nil {
+			return nil, errors.Trace(err)
+		}
+		patterns = append(patterns, `(?:`+expr+`)`)
+	}
+	return regexp.Compile(strings.Join(patterns, "|"))
+}
+
+// messageMatches reports whether a log with the given message
+// passes the tailer's message filters.
+func (t *logTailer) messageMatches(message string) bool {
+	if t.includeMessage != nil && !t.includeMessage.MatchString(message) {
+		return false
+	}
+	if t.excludeMessage != nil && t.excludeMessage.MatchString(message) {
+		return false
+	}
+	return true
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
@@ -627,6 +627,165 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestTimeRangeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5,
+		logTemplate{Message: "too early"},
+	)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT, threshT.Add(5*time.Second), 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(6*time.Second), threshT.Add(10*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   threshT.Add(5 * time.Second),
+		NoTail:    true,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestTailingStopsAfterEndTime(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: threshT.Add(5 * time.Second),
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT, threshT.Add(5*time.Second), 5, want)
+	s.assertTailer(c, tailer, 5, want)
+
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(6*time.Second), threshT.Add(10*time.Second), 5,
+		logTemplate{Message: "too late"},
+	)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
+
+func (s *LogTailerSuite) TestTailingStopsAtEndTime(c *gc.C) {
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: time.Now().Add(coretesting.ShortWait),
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Nothing is logged, but tailing stops once the end time
+	// has passed.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeMessage(c *gc.C) {
+	started := logTemplate{Message: "hook \"start\" started"}
+	failed := logTemplate{Message: "hook \"install\" failed: exit status 1"}
+	ignored := logTemplate{Message: "hook \"update-status\" failed: exit status 1"}
+	other := logTemplate{Message: "connected to controller"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, started)
+		s.writeLogs(c, s.otherUUID, 1, failed)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, ignored)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{`^hook ".*" failed`, "^connected"},
+		ExcludeMessage: []string{"update-status"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, failed)
+		s.assertTailer(c, tailer, 1, other)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestInitialLinesWithMessageFilter(c *gc.C) {
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, s.otherUUID, 3, expected)
+	s.writeLogs(c, s.otherUUID, 5, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		InitialLines:   2,
+		NoTail:         true,
+		IncludeMessage: []string{"^want$"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// The last 2 matching lines are sent, even though they
+	// are not among the last 2 lines logged, and no more.
+	s.assertTailer(c, tailer, 2, expected)
+	select {
+	case log, ok := <-tailer.Logs():
+		if ok {
+			c.Fatalf("unexpected log: %#v", log)
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestInvalidMessageFilter(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		ExcludeMessage: []string{"(?<=look)behind"},
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid message filter: .*")
+}
+
+func (s *LogTailerSuite) TestAgentKinds(c *gc.C) {
+	machine0 := logTemplate{Entity: "machine-0"}
+	unitFoo0 := logTemplate{Entity: "unit-foo-0"}
+	unitBar1 := logTemplate{Entity: "unit-bar-1"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, machine0)
+		s.writeLogs(c, s.otherUUID, 1, unitFoo0)
+		s.writeLogs(c, s.otherUUID, 1, machine0)
+		s.writeLogs(c, s.otherUUID, 1, unitBar1)
+	}
+	params := state.LogTailerParams{
+		AgentKinds:    []string{"unit"},
+		ExcludeEntity: []string{"unit-bar-*"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, unitFoo0)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -143,6 +143,13 @@ type machineDoc struct {
 
 	// AgentStartedAt records the time when the machine agent started.
 	AgentStartedAt time.Time `bson:"agent-started-at,omitempty"`
+
+	// EgressRestricted and EgressRules record the egress firewall
+	// the machine agent must enforce on the machine itself, for
+	// machines whose provider can't enforce it. They are set by the
+	// firewaller from the egress rules of the machine's applications.
+	EgressRestricted bool            `bson:"egress-restricted,omitempty"`
+	EgressRules      []egressRuleDoc `bson:"egress-rules,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -1022,6 +1029,10 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	volumeSnapshotOps, err := sb.removeMachineVolumeSnapshotsOps(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	ops = append(ops, removeControllerNodeOp(m.st, m.Id()))
 	ops = append(ops, linkLayerDevicesOps...)
@@ -1030,6 +1041,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 	ops = append(ops, removeContainerRefOps(m.st, m.Id())...)
 	ops = append(ops, filesystemOps...)
 	ops = append(ops, volumeOps...)
+	ops = append(ops, volumeSnapshotOps...)
 	return ops, nil
 }
 
@@ -1316,6 +1328,19 @@ func (m *Machine) SetProvisioned(
 	if characteristics == nil {
 		characteristics = &instance.HardwareCharacteristics{}
 	}
+
+	// The instance may have more cores or memory than its constraints
+	// asked for, so the model's quotas are checked against the actual
+	// hardware before it is recorded. The provisioner stops the instance
+	// if it cannot be recorded.
+	usage, err := m.provisionedQuotaUsage(characteristics)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.st.CheckModelQuotas(usage); err != nil {
+		return errors.Trace(err)
+	}
+
 	instData := &instanceData{
 		DocID:          m.doc.DocID,
 		MachineId:      m.doc.Id,
@@ -2367,6 +2367,44 @@ func (s *MachineSuite) TestSupportsNoContainersOverwritesExisting(c *gc.C) {
 	assertSupportedContainers(c, machine, []instance.ContainerType{})
 }
 
+func (s *MachineSuite) TestSetEgressRules(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.EgressRestricted(), jc.IsFalse)
+	c.Assert(machine.EgressRules(), gc.HasLen, 0)
+
+	rules := []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24")}
+	err = machine.SetEgressRules(true, rules)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.EgressRestricted(), jc.IsTrue)
+	c.Assert(machine.EgressRules(), jc.DeepEquals, rules)
+
+	// Setting the same egress rules again is a no-op.
+	func() {
+		defer state.SetFailIfTransaction(c, s.State).Check()
+		err = machine.SetEgressRules(true, rules)
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	err = machine.SetEgressRules(false, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.EgressRestricted(), jc.IsFalse)
+	c.Assert(machine.EgressRules(), gc.HasLen, 0)
+}
+
+func (s *MachineSuite) TestSetEgressRulesDead(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetEgressRules(true, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for machine 0: not found or dead`)
+}
+
 func (s *MachineSuite) TestSetSupportedContainersSingle(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
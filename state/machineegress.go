@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/network"
+)
+
+// EgressRestricted reports whether the machine agent must restrict the
+// outgoing traffic of the machine to its egress rules.
+func (m *Machine) EgressRestricted() bool {
+	return m.doc.EgressRestricted
+}
+
+// EgressRules returns the egress rules the machine agent must enforce
+// on the machine, when its egress is restricted.
+func (m *Machine) EgressRules() []network.EgressRule {
+	return egressRulesFromDocs(m.doc.EgressRules)
+}
+
+// SetEgressRules records the egress firewall the machine agent must
+// enforce on the machine itself. This is used for machines whose
+// provider can't enforce the egress rules of their applications.
+func (m *Machine) SetEgressRules(restricted bool, rules []network.EgressRule) error {
+	if err := validateEgressRules(rules); err != nil {
+		return errors.Annotatef(err, "cannot set egress rules for machine %v", m)
+	}
+	docs := make([]egressRuleDoc, len(rules))
+	for i, rule := range rules {
+		docs[i] = newEgressRuleDoc(rule)
+	}
+	if restricted == m.doc.EgressRestricted && egressRuleDocsEqual(docs, m.doc.EgressRules) {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: egressUpdate(restricted, docs),
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		err = onAbort(err, ErrDead)
+		return errors.Annotatef(err, "cannot set egress rules for machine %v", m)
+	}
+	m.doc.EgressRestricted = restricted
+	m.doc.EgressRules = docs
+	return nil
+}
@@ -2028,7 +2028,7 @@ func (i *importer) addStorageInstance(storage description.Storage) error {
 
 func (i *importer) storageInstanceConstraints(storage description.Storage) storageInstanceConstraints {
 	if cons, ok := storage.Constraints(); ok {
-		return storageInstanceConstraints(cons)
+		return storageInstanceConstraints{Pool: cons.Pool, Size: cons.Size}
 	}
 	// Older versions of Juju did not record storage constraints on the
 	// storage instance, so we must do what we do during upgrade steps:
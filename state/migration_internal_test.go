@@ -214,6 +214,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// running within a unit. This is a new feature that is not
 		// backwards compatible with older controllers.
 		unitStatesC,
+
+		// Charm metrics are republished by the charms in the
+		// migrated model as their hooks run.
+		charmMetricsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -227,6 +231,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		leaseHoldersC,
 		// TODO(wallyworld) - migrate operations
 		operationsC,
+		// Volume snapshots are provider resources that may not be
+		// reachable from the target controller's cloud.
+		volumeSnapshotsC,
 	)
 
 	modelCollections := set.NewStrings()
@@ -271,6 +278,9 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"SLA",
 		"MeterStatus",
 		"EnvironVersion",
+		// Quotas are set by the admins of each controller, so
+		// they are not carried across to the target.
+		"Quotas",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -331,6 +341,10 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"StopMongoUntilVersion",
 		// Ignored; it gets populated on demand when the agent restarts
 		"AgentStartedAt",
+		// Ignored; the firewaller sets them again from the egress
+		// rules of the machine's applications.
+		"EgressRestricted",
+		"EgressRules",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -399,6 +413,12 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// TODO - egress rules are not yet migrated, as the
+		// description package has no representation for them.
+		// The migration prechecks refuse models with applications
+		// whose egress is restricted.
+		"EgressRestricted",
+		"EgressRules",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -428,6 +448,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Application",
 		// Resolved is not migrated as we check that all is good before we start.
 		"Resolved",
+		// MaintenanceMode is not migrated; the migration prechecks
+		// refuse to migrate units in maintenance mode.
+		"MaintenanceMode",
 		// Series and CharmURL also come from the application.
 		"Series",
 		"CharmURL",
@@ -748,8 +771,9 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"HostId",    // recreated from pool properties
-		"Releasing", // only when dying; can't migrate dying storage
+		"HostId",        // recreated from pool properties
+		"Releasing",     // only when dying; can't migrate dying storage
+		"RequestedSize", // prechecks refuse to migrate pending resizes
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -763,7 +787,11 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "WWN", "Size", "Pool", "VolumeId", "Persistent"))
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool",
+		// Volume snapshots are not migrated, so neither
+		// are pending restores from them.
+		"Snapshot",
+	))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -791,8 +819,9 @@ func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"HostId",    // recreated from pool properties
-		"Releasing", // only when dying; can't migrate dying storage
+		"HostId",        // recreated from pool properties
+		"Releasing",     // only when dying; can't migrate dying storage
+		"RequestedSize", // prechecks refuse to migrate pending resizes
 	)
 	migrated := set.NewStrings(
 		"FilesystemId",
@@ -118,6 +118,9 @@ type modelDoc struct {
 	// this model. It only has any meaning when the model is dying or
 	// dead.
 	ForceDestroyed bool `bson:"force-destroyed,omitempty"`
+
+	// Quotas holds the limits on the resources the model may consume.
+	Quotas modelQuotasDoc `bson:"quotas,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -1083,10 +1086,11 @@ func IsHasPersistentStorageError(err error) bool {
 }
 
 type modelNotEmptyError struct {
-	machines     int
-	applications int
-	volumes      int
-	filesystems  int
+	machines        int
+	applications    int
+	volumes         int
+	filesystems     int
+	volumeSnapshots int
 }
 
 // Error is part of the error interface.
@@ -1112,6 +1116,9 @@ func (e modelNotEmptyError) Error() string {
 	if n := e.filesystems; n > 0 {
 		contains = append(contains, plural(n, "filesystem"))
 	}
+	if n := e.volumeSnapshots; n > 0 {
+		contains = append(contains, plural(n, "volume snapshot"))
+	}
 	return msg + strings.Join(contains, ", ")
 }
 
@@ -1159,6 +1166,9 @@ func (m *Model) destroyOps(
 	nextLife := Dying
 
 	prereqOps, err := checkModelEntityRefsEmpty(modelEntityRefs)
+	if err == nil {
+		err = checkModelNoVolumeSnapshots(m.st)
+	}
 	if err != nil {
 		if ensureEmpty {
 			return nil, errors.Trace(err)
@@ -1173,6 +1183,11 @@ func (m *Model) destroyOps(
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
+			// Volume snapshots outlive the volumes they were taken
+			// of, so they are persistent storage in their own right.
+			if err := checkModelNoVolumeSnapshots(m.st); err != nil {
+				return nil, hasPersistentStorageError{}
+			}
 			prereqOps = storageOps
 		} else if !*args.DestroyStorage {
 			// The model is non-empty, and the user has specified that
@@ -1413,6 +1428,20 @@ func checkModelEntityRefsEmpty(doc *modelEntityRefsDoc) ([]txn.Op, error) {
 	}}, nil
 }
 
+// checkModelNoVolumeSnapshots checks that there are no volume snapshots
+// in the model. If there are, then an error of type modelNotEmptyError
+// is returned.
+func checkModelNoVolumeSnapshots(mb modelBackend) error {
+	n, err := countVolumeSnapshots(mb)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n > 0 {
+		return modelNotEmptyError{volumeSnapshots: n}
+	}
+	return nil
+}
+
 // checkModelEntityRefsNoPersistentStorage checks that there is no
 // persistent storage in the model. If there is, then an error of
 // type hasPersistentStorageError is returned. If there is not,
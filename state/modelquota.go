@@ -0,0 +1,358 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+)
+
+// modelQuotasDoc holds the resource limits set on a model.
+// A missing field means that resource is not limited.
+type modelQuotasDoc struct {
+	Machines *uint64 `bson:"machines,omitempty"`
+	Units    *uint64 `bson:"units,omitempty"`
+	Cores    *uint64 `bson:"cores,omitempty"`
+	Memory   *uint64 `bson:"memory,omitempty"`
+	Storage  *uint64 `bson:"storage,omitempty"`
+}
+
+// Quotas returns the resource limits set on the model.
+func (m *Model) Quotas() model.Quotas {
+	doc := m.doc.Quotas
+	return model.Quotas{
+		Machines: doc.Machines,
+		Units:    doc.Units,
+		Cores:    doc.Cores,
+		Memory:   doc.Memory,
+		Storage:  doc.Storage,
+	}
+}
+
+// SetQuotas replaces the resource limits set on the model. Quotas
+// are only checked when resources are added, so lowering a quota
+// below the current usage does not remove anything.
+func (m *Model) SetQuotas(quotas model.Quotas) error {
+	doc := modelQuotasDoc{
+		Machines: quotas.Machines,
+		Units:    quotas.Units,
+		Cores:    quotas.Cores,
+		Memory:   quotas.Memory,
+		Storage:  quotas.Storage,
+	}
+	var update bson.D
+	if quotas.IsEmpty() {
+		update = bson.D{{"$unset", bson.D{{"quotas", 1}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"quotas", doc}}}}
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "setting model quotas")
+	}
+	return m.Refresh()
+}
+
+// quotaUsageKey identifies the refcount document that counts the
+// additions of resources limited by the model's quotas. Each addition
+// made while the model has quotas increments it, asserting that it
+// hasn't changed since the model's usage was computed, so concurrent
+// additions can't together exceed a quota.
+const quotaUsageKey = "quotausage"
+
+// QuotaUsage returns the amount of each resource limited by quotas
+// that the model currently consumes. Dead entities are not counted,
+// and nor are subordinate units, which are added by relations rather
+// than by users. The cores and memory of a machine are taken from its
+// hardware characteristics once provisioned, and from its constraints
+// before. Storage that hasn't been assigned a volume or filesystem yet
+// is counted by the size it was requested with, and storage that is
+// being grown by the size it is being grown to.
+func (m *Model) QuotaUsage() (model.Usage, error) {
+	return m.st.quotaUsage()
+}
+
+func (st *State) quotaUsage() (model.Usage, error) {
+	var usage model.Usage
+	notDead := bson.D{{"life", bson.D{{"$ne", Dead}}}}
+
+	machines, closer := st.db().GetCollection(machinesC)
+	defer closer()
+	var machineDocs []struct {
+		Id string `bson:"machineid"`
+	}
+	if err := machines.Find(notDead).Select(bson.D{{"machineid", 1}}).All(&machineDocs); err != nil {
+		return usage, errors.Annotate(err, "reading machines")
+	}
+
+	instances, closer := st.db().GetCollection(instanceDataC)
+	defer closer()
+	var instanceDocs []instanceData
+	if err := instances.Find(nil).All(&instanceDocs); err != nil {
+		return usage, errors.Annotate(err, "reading instance data")
+	}
+	hardware := make(map[string]instanceData)
+	for _, doc := range instanceDocs {
+		hardware[doc.MachineId] = doc
+	}
+
+	for _, doc := range machineDocs {
+		if strings.Contains(doc.Id, "/") {
+			// Containers do not consume cloud resources of their own.
+			continue
+		}
+		usage.Machines++
+		if hw, ok := hardware[doc.Id]; ok {
+			if hw.CpuCores != nil {
+				usage.Cores += *hw.CpuCores
+			}
+			if hw.Mem != nil {
+				usage.Memory += *hw.Mem
+			}
+			continue
+		}
+		cons, err := readConstraints(st, machineGlobalKey(doc.Id))
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return usage, errors.Annotatef(err, "reading constraints for machine %q", doc.Id)
+		}
+		if cons.CpuCores != nil {
+			usage.Cores += *cons.CpuCores
+		}
+		if cons.Mem != nil {
+			usage.Memory += *cons.Mem
+		}
+	}
+
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+	principals := append(bson.D{{"principal", ""}}, notDead...)
+	unitCount, err := units.Find(principals).Count()
+	if err != nil {
+		return usage, errors.Annotate(err, "counting units")
+	}
+	usage.Units = uint64(unitCount)
+
+	// Storage instances are counted through their volume or
+	// filesystem once they have one.
+	assigned := set.NewStrings()
+
+	volumes, closer := st.db().GetCollection(volumesC)
+	defer closer()
+	var volumeDocs []volumeDoc
+	if err := volumes.Find(notDead).All(&volumeDocs); err != nil {
+		return usage, errors.Annotate(err, "reading volumes")
+	}
+	for _, doc := range volumeDocs {
+		if doc.StorageId != "" {
+			assigned.Add(doc.StorageId)
+		}
+		if doc.Info != nil {
+			usage.Storage += storageQuotaSize(doc.Info.Size, doc.RequestedSize)
+		} else if doc.Params != nil {
+			usage.Storage += doc.Params.Size
+		}
+	}
+
+	filesystems, closer := st.db().GetCollection(filesystemsC)
+	defer closer()
+	var filesystemDocs []filesystemDoc
+	if err := filesystems.Find(notDead).All(&filesystemDocs); err != nil {
+		return usage, errors.Annotate(err, "reading filesystems")
+	}
+	for _, doc := range filesystemDocs {
+		if doc.StorageId != "" {
+			assigned.Add(doc.StorageId)
+		}
+		if doc.VolumeId != "" {
+			// The backing volume has already been counted.
+			continue
+		}
+		if doc.Info != nil {
+			usage.Storage += storageQuotaSize(doc.Info.Size, doc.RequestedSize)
+		} else if doc.Params != nil {
+			usage.Storage += doc.Params.Size
+		}
+	}
+
+	storageInstances, closer := st.db().GetCollection(storageInstancesC)
+	defer closer()
+	var storageDocs []storageInstanceDoc
+	if err := storageInstances.Find(notDead).All(&storageDocs); err != nil {
+		return usage, errors.Annotate(err, "reading storage instances")
+	}
+	for _, doc := range storageDocs {
+		if !assigned.Contains(doc.Id) {
+			usage.Storage += doc.Constraints.Size
+		}
+	}
+	return usage, nil
+}
+
+// CheckModelQuotas returns an error satisfying model.IsQuotaExceeded
+// if adding the requested resources to those the model already uses
+// would exceed any of the model's quotas. The quotas are checked
+// again, atomically, when the resources are added.
+func (st *State) CheckModelQuotas(requested model.Usage) error {
+	_, err := st.quotaOps(requested)
+	return errors.Trace(err)
+}
+
+// quotaOps returns an error satisfying model.IsQuotaExceeded if adding
+// the requested resources would exceed any of the model's quotas, and
+// otherwise the operations that must be run with those adding them.
+// No operations are returned if the model has no quotas.
+func (st *State) quotaOps(requested model.Usage) ([]txn.Op, error) {
+	if requested == (model.Usage{}) {
+		return nil, nil
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	quotas := m.Quotas()
+	if quotas.IsEmpty() {
+		return nil, nil
+	}
+
+	// The counter must be read before the usage is computed, so
+	// that anything added in between aborts the transaction.
+	refcounts, closer := st.db().GetCollection(refcountsC)
+	defer closer()
+	op, _, err := nsRefcounts.CurrentOp(refcounts, quotaUsageKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model quota usage counter")
+	}
+	if op.Assert == txn.DocMissing {
+		op.Insert = bson.D{{"refcount", 1}}
+	} else {
+		op.Update = bson.D{{"$inc", bson.D{{"refcount", 1}}}}
+	}
+
+	usage, err := st.quotaUsage()
+	if err != nil {
+		return nil, errors.Annotate(err, "computing model resource usage")
+	}
+	if err := quotas.Check(usage, requested); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{op}, nil
+}
+
+// storageQuotaSize returns the size counted against the storage quota
+// for a provisioned volume or filesystem, which is the size it has
+// been asked to grow to while a resize is pending.
+func storageQuotaSize(size, requestedSize uint64) uint64 {
+	if requestedSize > size {
+		return requestedSize
+	}
+	return size
+}
+
+// storageQuotaOps returns the operations needed to check that adding
+// the specified amount of storage, in MiB, does not exceed the model's
+// storage quota. See quotaOps.
+func (st *State) storageQuotaOps(size uint64) ([]txn.Op, error) {
+	return st.quotaOps(model.Usage{Storage: size})
+}
+
+// unitsQuotaUsage returns the resources that will be used by the given
+// number of new principal units with the supplied storage constraints.
+func unitsQuotaUsage(numUnits int, storageCons map[string]StorageConstraints) model.Usage {
+	if numUnits <= 0 {
+		return model.Usage{}
+	}
+	usage := model.Usage{Units: uint64(numUnits)}
+	for _, cons := range storageCons {
+		usage.Storage += uint64(numUnits) * cons.Count * cons.Size
+	}
+	return usage
+}
+
+// newMachinesQuotaUsage returns the resources that will be used by
+// new top level machines created from the templates. Machines for
+// existing instances are not counted, and nor is the storage of units,
+// which was counted when the units were added.
+func (st *State) newMachinesQuotaUsage(templates ...MachineTemplate) (model.Usage, error) {
+	var usage model.Usage
+	for _, template := range templates {
+		if template.InstanceId != "" {
+			continue
+		}
+		cons := template.Constraints
+		if template.Placement == "" {
+			var err error
+			cons, err = st.resolveMachineConstraints(cons)
+			if err != nil {
+				return model.Usage{}, errors.Trace(err)
+			}
+		}
+		usage.Machines++
+		if cons.CpuCores != nil {
+			usage.Cores += *cons.CpuCores
+		}
+		if cons.Mem != nil {
+			usage.Memory += *cons.Mem
+		}
+		for _, v := range template.Volumes {
+			if v.Volume.storage == (names.StorageTag{}) {
+				usage.Storage += v.Volume.Size
+			}
+		}
+		for _, f := range template.Filesystems {
+			if f.Filesystem.storage == (names.StorageTag{}) {
+				usage.Storage += f.Filesystem.Size
+			}
+		}
+	}
+	return usage, nil
+}
+
+// provisionedQuotaUsage returns the cores and memory that the machine
+// will be counted as using once provisioned with the specified
+// hardware, beyond what is counted for it from its constraints until
+// then. The provider may start an instance larger than the machine's
+// constraints require, or the constraints may not specify cores or
+// memory at all, so the quotas must be checked again with the actual
+// hardware.
+func (m *Machine) provisionedQuotaUsage(hc *instance.HardwareCharacteristics) (model.Usage, error) {
+	if m.IsContainer() {
+		// Containers do not consume cloud resources of their own.
+		return model.Usage{}, nil
+	}
+	cons, err := readConstraints(m.st, m.globalKey())
+	if err != nil && !errors.IsNotFound(err) {
+		return model.Usage{}, errors.Annotate(err, "reading machine constraints")
+	}
+	return model.Usage{
+		Cores:  hardwareQuotaIncrease(hc.CpuCores, cons.CpuCores),
+		Memory: hardwareQuotaIncrease(hc.Mem, cons.Mem),
+	}, nil
+}
+
+func hardwareQuotaIncrease(actual, counted *uint64) uint64 {
+	switch {
+	case actual == nil:
+		return 0
+	case counted == nil:
+		return *actual
+	case *actual > *counted:
+		return *actual - *counted
+	}
+	return 0
+}
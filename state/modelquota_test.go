@@ -0,0 +1,232 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+)
+
+type ModelQuotaSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelQuotaSuite{})
+
+func quota(v uint64) *uint64 {
+	return &v
+}
+
+func (s *ModelQuotaSuite) addMachine(c *gc.C, cons string) *state.Machine {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse(cons),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *ModelQuotaSuite) TestQuotasDefaultEmpty(c *gc.C) {
+	c.Assert(s.Model.Quotas(), jc.DeepEquals, model.Quotas{})
+}
+
+func (s *ModelQuotaSuite) TestSetQuotas(c *gc.C) {
+	quotas := model.Quotas{Machines: quota(5), Memory: quota(8192)}
+	err := s.Model.SetQuotas(quotas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Model.Quotas(), jc.DeepEquals, quotas)
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Quotas(), jc.DeepEquals, quotas)
+
+	err = s.Model.SetQuotas(model.Quotas{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Refresh(), jc.ErrorIsNil)
+	c.Assert(m.Quotas(), jc.DeepEquals, model.Quotas{})
+}
+
+func (s *ModelQuotaSuite) TestQuotaUsage(c *gc.C) {
+	s.addMachine(c, "cores=2 mem=4G")
+	provisioned := s.addMachine(c, "cores=1")
+	cores, mem := uint64(8), uint64(16384)
+	err := provisioned.SetProvisioned("inst-1", "", "nonce", &instance.HardwareCharacteristics{
+		CpuCores: &cores,
+		Mem:      &mem,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, provisioned.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, nil)
+
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	// The unit's machine is the third top level machine;
+	// the container is not counted.
+	c.Assert(usage.Machines, gc.Equals, uint64(3))
+	c.Assert(usage.Units, gc.Equals, uint64(1))
+	c.Assert(usage.Cores >= 10, jc.IsTrue)
+	c.Assert(usage.Memory >= 4096+16384, jc.IsTrue)
+}
+
+func (s *ModelQuotaSuite) TestAddMachineChecksQuotas(c *gc.C) {
+	err := s.Model.SetQuotas(model.Quotas{Machines: quota(1), Cores: quota(4)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("cores=8"),
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model cores quota exceeded: 0 in use, 8 requested, limit is 4")
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+
+	s.addMachine(c, "cores=2")
+	_, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model machines quota exceeded: 1 in use, 1 requested, limit is 1")
+
+	// Containers on existing machines are not limited.
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, "0", instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// But containers in a new machine are.
+	_, err = s.State.AddMachineInsideNewMachine(
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+		instance.LXD,
+	)
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAddUnitChecksQuotas(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.SetQuotas(model.Quotas{Units: quota(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "mysql": model units quota exceeded: 1 in use, 1 requested, limit is 1`)
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+}
+
+func (s *ModelQuotaSuite) TestAddMachinesChecksQuotasTogether(c *gc.C) {
+	err := s.Model.SetQuotas(model.Quotas{Machines: quota(1)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	_, err = s.State.AddMachines(template, template)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model machines quota exceeded: 0 in use, 2 requested, limit is 1")
+}
+
+func (s *ModelQuotaSuite) TestAddMachineQuotaRace(c *gc.C) {
+	err := s.Model.SetQuotas(model.Quotas{Machines: quota(1)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		s.addMachine(c, "")
+	}).Check()
+	_, err = s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: model machines quota exceeded: 1 in use, 1 requested, limit is 1")
+
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Machines, gc.Equals, uint64(1))
+}
+
+func (s *ModelQuotaSuite) TestQuotaUsageSkipsSubordinates(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	principal, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("logging", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(principal)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Unit("logging/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Units, gc.Equals, uint64(1))
+}
+
+func (s *ModelQuotaSuite) TestAddUnitChecksStorageQuota(c *gc.C) {
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", s.AddTestingCharm(c, "storage-block"), map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	})
+	err := s.Model.SetQuotas(model.Quotas{Storage: quota(1500)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Storage, gc.Equals, uint64(1024))
+
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "storage-block": model storage quota exceeded: 1024 in use, 1024 requested, limit is 1500`)
+}
+
+func (s *ModelQuotaSuite) TestSetProvisionedChecksHardware(c *gc.C) {
+	m := s.addMachine(c, "cores=2")
+	err := s.Model.SetQuotas(model.Quotas{Cores: quota(4)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The two cores asked for are already counted, so only
+	// the extra cores the instance came with are checked.
+	cores := uint64(8)
+	err = m.SetProvisioned("inst-0", "", "nonce", &instance.HardwareCharacteristics{CpuCores: &cores})
+	c.Assert(err, gc.ErrorMatches, `cannot set instance data for machine "0": model cores quota exceeded: 2 in use, 6 requested, limit is 4`)
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+
+	cores = 4
+	err = m.SetProvisioned("inst-0", "", "nonce", &instance.HardwareCharacteristics{CpuCores: &cores})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelQuotaSuite) TestAddStorageChecksStorageQuota(c *gc.C) {
+	app := s.AddTestingApplicationWithStorage(c, "storage-block2", s.AddTestingCharm(c, "storage-block2"), map[string]state.StorageConstraints{
+		"multi1to10": makeStorageCons("loop", 1024, 1),
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetQuotas(model.Quotas{Storage: quota(usage.Storage + 1500)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sb.AddStorageForUnit(u.UnitTag(), "multi1to10", makeStorageCons("loop", 1024, 1))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sb.AddStorageForUnit(u.UnitTag(), "multi1to10", makeStorageCons("loop", 1024, 1))
+	c.Assert(err, gc.ErrorMatches, `adding "multi1to10" storage to storage-block2/0: model storage quota exceeded: .*`)
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+}
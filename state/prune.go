@@ -17,13 +17,14 @@ import (
 // pruneCollection removes collection entries until
 // only entries newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
-// deletion.
+// deletion. If archiving is enabled, the removed entries are
+// first written to the archive.
 func pruneCollection(
 	mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB int,
 	collectionName string, ageField string, filter bson.D,
-	timeUnit TimeUnit,
+	timeUnit TimeUnit, archive PruneArchiveConfig,
 ) error {
-	return pruneCollectionAndChildren(mb, maxHistoryTime, maxHistoryMB, collectionName, ageField, "", "", filter, 1, timeUnit)
+	return pruneCollectionAndChildren(mb, maxHistoryTime, maxHistoryMB, collectionName, ageField, "", "", filter, 1, timeUnit, archive)
 }
 
 // pruneCollectionAndChildren removes collection entries until
@@ -33,6 +34,7 @@ func pruneCollection(
 func pruneCollectionAndChildren(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB int,
 	collectionName, ageField, childCollectionName, parentRefField string,
 	filter bson.D, sizeFactor float64, timeUnit TimeUnit,
+	archive PruneArchiveConfig,
 ) error {
 	// NOTE(axw) we require a raw collection to obtain the size of the
 	// collection. Take care to include model-uuid in queries where
@@ -61,10 +63,26 @@ func pruneCollectionAndChildren(mb modelBackend, maxHistoryTime time.Duration, m
 	if err := p.validate(); err != nil {
 		return errors.Trace(err)
 	}
+	if archive.Enabled() {
+		if childColl != nil {
+			return errors.NotSupportedf("archiving entries with children")
+		}
+		archiver, err := newArchiver(archive, collectionName, mb.modelUUID(), mb.clock())
+		if err != nil {
+			return errors.Annotate(err, "cannot archive pruned entries")
+		}
+		p.archiver = archiver
+	}
 	if err := p.pruneByAge(); err != nil {
 		return errors.Trace(err)
 	}
-	return errors.Trace(p.pruneBySize())
+	if err := p.pruneBySize(); err != nil {
+		return errors.Trace(err)
+	}
+	if p.archiver == nil {
+		return nil
+	}
+	return errors.Annotate(p.archiver.cleanup(), "cleaning up archive")
 }
 
 const historyPruneBatchSize = 1000
@@ -97,6 +115,10 @@ type collectionPruner struct {
 
 	ageField string
 	timeUnit TimeUnit
+
+	// If specified, entries are written to the archiver
+	// before they are deleted.
+	archiver documentArchiver
 }
 
 func (p *collectionPruner) validate() error {
@@ -115,6 +137,15 @@ func (p *collectionPruner) validate() error {
 	return nil
 }
 
+// selector returns the fields of the entries to read for deletion;
+// whole entries are read when they are to be archived.
+func (p *collectionPruner) selector() bson.M {
+	if p.archiver != nil {
+		return nil
+	}
+	return bson.M{"_id": 1}
+}
+
 func (p *collectionPruner) pruneByAge() error {
 	if p.maxAge == 0 {
 		return nil
@@ -137,7 +168,7 @@ func (p *collectionPruner) pruneByAge() error {
 		{p.ageField, bson.M{"$gt": notSet, "$lt": age}},
 	}
 	query = append(query, p.filter...)
-	iter := p.coll.Find(query).Select(bson.M{"_id": 1}).Iter()
+	iter := p.coll.Find(query).Select(p.selector()).Iter()
 	defer iter.Close()
 
 	modelName, err := p.st.modelName()
@@ -145,7 +176,7 @@ func (p *collectionPruner) pruneByAge() error {
 		return errors.Trace(err)
 	}
 	logTemplate := fmt.Sprintf("%s age pruning (%s): %%d rows deleted", p.coll.Name, modelName)
-	deleted, err := deleteInBatches(p.coll, p.childColl, p.parentRefField, iter, logTemplate, loggo.INFO, noEarlyFinish)
+	deleted, err := deleteInBatches(p.coll, p.childColl, p.parentRefField, iter, p.archiver, logTemplate, loggo.INFO, noEarlyFinish)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -212,11 +243,11 @@ func (p *collectionPruner) pruneBySize() error {
 		return nil
 	}
 
-	iter := p.coll.Find(p.filter).Sort(p.ageField).Limit(toDelete).Select(bson.M{"_id": 1}).Iter()
+	iter := p.coll.Find(p.filter).Sort(p.ageField).Limit(toDelete).Select(p.selector()).Iter()
 	defer iter.Close()
 
 	template := fmt.Sprintf("%s size pruning: deleted %%d of %d (estimated)", p.coll.Name, toDelete)
-	deleted, err := deleteInBatches(p.coll, p.childColl, p.parentRefField, iter, template, loggo.INFO, func() (bool, error) {
+	deleted, err := deleteInBatches(p.coll, p.childColl, p.parentRefField, iter, p.archiver, template, loggo.INFO, func() (bool, error) {
 		// Check that we still need to delete more
 		collMB, err := getCollectionMB(p.coll)
 		if err != nil {
@@ -242,11 +273,13 @@ func deleteInBatches(
 	childColl *mgo.Collection,
 	childField string,
 	iter mongo.Iterator,
+	archiver documentArchiver,
 	logTemplate string,
 	logLevel loggo.Level,
 	shouldStop doneCheck,
 ) (int, error) {
 	var doc bson.M
+	var archived []bson.M
 	chunk := coll.Bulk()
 	chunkSize := 0
 
@@ -259,6 +292,10 @@ func deleteInBatches(
 	deleted := 0
 	for iter.Next(&doc) {
 		parentId := doc["_id"]
+		if archiver != nil {
+			archived = append(archived, doc)
+			doc = nil
+		}
 		chunk.Remove(bson.D{{"_id", parentId}})
 		chunkSize++
 		if childChunk != nil {
@@ -270,6 +307,14 @@ func deleteInBatches(
 			}
 		}
 		if chunkSize == historyPruneBatchSize {
+			// Entries are only removed once they are archived.
+			if archiver != nil {
+				if err := archiver.archive(archived); err != nil {
+					return 0, errors.Annotate(err, "archiving batch")
+				}
+				archived = archived[:0]
+			}
+
 			_, err := chunk.Run()
 			// NotFound indicates that records were already deleted.
 			if err != nil && err != mgo.ErrNotFound {
@@ -310,6 +355,11 @@ func deleteInBatches(
 	}
 
 	if chunkSize > 0 {
+		if archiver != nil {
+			if err := archiver.archive(archived); err != nil {
+				return 0, errors.Annotate(err, "archiving remainder")
+			}
+		}
 		_, err := chunk.Run()
 		if err != nil && err != mgo.ErrNotFound {
 			return 0, errors.Annotate(err, "removing remainder")
@@ -0,0 +1,356 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/rfc5424"
+	"github.com/juju/rfc/rfc5424/sdelements"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// archiveFileSuffix is the suffix of the names of the files holding
+// archived documents.
+const archiveFileSuffix = ".json.gz"
+
+// canonicalPEN is the IANA-registered Private Enterprise Number
+// assigned to Canonical, used in the structured data of archived
+// documents sent to syslog.
+const canonicalPEN = 28978
+
+// PruneArchiveSender sends messages to a remote log sink. It is
+// satisfied by the logfwd/syslog Sender.
+type PruneArchiveSender interface {
+	// Send sends the RFC 5424 message to the log sink.
+	Send(rfc5424.Message) error
+}
+
+// PruneArchiveConfig holds the configuration for archiving the
+// documents removed by pruning. Documents are either written to files
+// in Dir or sent to Syslog; if neither is set they are not archived.
+type PruneArchiveConfig struct {
+	// Dir is the directory in which archive files are written.
+	Dir string
+
+	// MaxFileSizeMB is the size in MiB that an archive file may grow
+	// to before a new file is started. If it is zero, archive files
+	// are never rotated.
+	MaxFileSizeMB int
+
+	// MaxAge is how long archive files are kept after they were last
+	// written. If it is zero, archive files are never removed.
+	MaxAge time.Duration
+
+	// MaxTotalSizeMB is the total size in MiB that the archive files
+	// for the collection in Dir may grow to, across all models, before
+	// the oldest files are removed. If it is zero, the total size is
+	// not bounded.
+	MaxTotalSizeMB int
+
+	// Syslog is the log sink to which documents are sent, instead of
+	// being written to files.
+	Syslog PruneArchiveSender
+}
+
+// Enabled reports whether pruned documents should be archived.
+func (c PruneArchiveConfig) Enabled() bool {
+	return c.Dir != "" || c.Syslog != nil
+}
+
+// Validate returns an error if the config is not valid.
+func (c PruneArchiveConfig) Validate() error {
+	if c.Dir != "" && c.Syslog != nil {
+		return errors.NotValidf("archiving to both a directory and syslog")
+	}
+	if c.Dir != "" && !filepath.IsAbs(c.Dir) {
+		return errors.NotValidf("relative archive directory %q", c.Dir)
+	}
+	if c.MaxFileSizeMB < 0 {
+		return errors.NotValidf("negative archive file size")
+	}
+	if c.MaxAge < 0 {
+		return errors.NotValidf("negative archive age")
+	}
+	if c.MaxTotalSizeMB < 0 {
+		return errors.NotValidf("negative archive total size")
+	}
+	return nil
+}
+
+// documentArchiver archives the documents removed by pruning.
+type documentArchiver interface {
+	// archive archives the given documents. They are only removed
+	// once it has returned successfully.
+	archive(docs []bson.M) error
+
+	// cleanup removes archived documents that should no longer be
+	// kept.
+	cleanup() error
+}
+
+// newArchiver returns an archiver for the documents pruned from the
+// model's collection.
+func newArchiver(config PruneArchiveConfig, collection, modelUUID string, clock clock.Clock) (documentArchiver, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if config.Syslog != nil {
+		return newSyslogArchiver(config.Syslog, collection, modelUUID, clock)
+	}
+	return newPruneArchiver(config, collection, modelUUID, clock)
+}
+
+// pruneArchiver writes documents to gzip-compressed files of
+// newline-delimited JSON. Each model's documents from a collection are
+// written to a series of files named
+//
+//	<collection>-<model-uuid>-<timestamp>.json.gz
+//
+// Each call to archive appends a complete gzip member to the newest
+// file, so that documents are safely on disk before they are removed,
+// and the files may be read with standard tools such as zcat.
+type pruneArchiver struct {
+	config     PruneArchiveConfig
+	collection string
+	prefix     string
+	clock      clock.Clock
+}
+
+func newPruneArchiver(config PruneArchiveConfig, collection, modelUUID string, clock clock.Clock) (*pruneArchiver, error) {
+	if err := os.MkdirAll(config.Dir, 0700); err != nil {
+		return nil, errors.Annotate(err, "creating archive directory")
+	}
+	return &pruneArchiver{
+		config:     config,
+		collection: collection,
+		prefix:     filepath.Join(config.Dir, fmt.Sprintf("%s-%s-", collection, modelUUID)),
+		clock:      clock,
+	}, nil
+}
+
+// archive is part of documentArchiver. It writes the given documents
+// to the newest archive file, starting a new one if it has reached the
+// maximum size.
+func (a *pruneArchiver) archive(docs []bson.M) (err error) {
+	if len(docs) == 0 {
+		return nil
+	}
+	path, err := a.currentFile()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Annotate(err, "opening archive file")
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = errors.Annotate(closeErr, "closing archive file")
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	for _, doc := range docs {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return errors.Annotate(err, "marshalling document")
+		}
+		if _, err := gz.Write(append(data, '\n')); err != nil {
+			return errors.Annotate(err, "writing archive file")
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Annotate(err, "writing archive file")
+	}
+	return errors.Annotate(f.Sync(), "syncing archive file")
+}
+
+// currentFile returns the path of the file to which documents should
+// be appended.
+func (a *pruneArchiver) currentFile() (string, error) {
+	paths, err := a.files()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(paths) > 0 {
+		latest := paths[len(paths)-1]
+		info, err := os.Stat(latest)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		maxSize := int64(a.config.MaxFileSizeMB) * 1024 * 1024
+		if maxSize == 0 || info.Size() < maxSize {
+			return latest, nil
+		}
+	}
+	timestamp := a.clock.Now().UTC().Format("20060102T150405.000000000Z")
+	return a.prefix + timestamp + archiveFileSuffix, nil
+}
+
+// cleanup is part of documentArchiver. It removes the model's expired
+// archive files, then the oldest archive files for the collection
+// until they fit in the maximum total size.
+func (a *pruneArchiver) cleanup() error {
+	if err := a.removeExpired(); err != nil {
+		return errors.Annotate(err, "removing expired archive files")
+	}
+	return errors.Annotate(a.removeOversized(), "removing oversized archive files")
+}
+
+// removeExpired removes the archive files that were last written
+// longer ago than the configured maximum age.
+func (a *pruneArchiver) removeExpired() error {
+	if a.config.MaxAge == 0 {
+		return nil
+	}
+	paths, err := a.files()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cutoff := a.clock.Now().Add(-a.config.MaxAge)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		logger.Infof("removing expired archive file %q", path)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Annotate(err, "removing archive file")
+		}
+	}
+	return nil
+}
+
+// removeOversized removes the least recently written archive files
+// for the collection, from all models, while their total size exceeds
+// the configured maximum. The most recently written file is kept.
+func (a *pruneArchiver) removeOversized() error {
+	if a.config.MaxTotalSizeMB == 0 {
+		return nil
+	}
+	paths, err := filepath.Glob(filepath.Join(a.config.Dir, a.collection+"-*"+archiveFileSuffix))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var infos []os.FileInfo
+	var total int64
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		infos = append(infos, info)
+		total += info.Size()
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+	maxSize := int64(a.config.MaxTotalSizeMB) * 1024 * 1024
+	for len(infos) > 1 && total > maxSize {
+		path := filepath.Join(a.config.Dir, infos[0].Name())
+		logger.Infof("removing archive file %q to bound archive size", path)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Annotate(err, "removing archive file")
+		}
+		total -= infos[0].Size()
+		infos = infos[1:]
+	}
+	return nil
+}
+
+// files returns the paths of the archiver's files, oldest first.
+func (a *pruneArchiver) files() ([]string, error) {
+	paths, err := filepath.Glob(a.prefix + "*" + archiveFileSuffix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// syslogArchiver sends documents to a syslog host, one RFC 5424
+// message per document, with the document as JSON in the message body.
+type syslogArchiver struct {
+	sender    PruneArchiveSender
+	appName   string
+	hostname  string
+	modelUUID string
+	clock     clock.Clock
+}
+
+func newSyslogArchiver(sender PruneArchiveSender, collection, modelUUID string, clock clock.Clock) (*syslogArchiver, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting hostname")
+	}
+	return &syslogArchiver{
+		sender:    sender,
+		appName:   "juju-" + collection,
+		hostname:  hostname,
+		modelUUID: modelUUID,
+		clock:     clock,
+	}, nil
+}
+
+// archive is part of documentArchiver. It sends each of the documents
+// to the syslog host.
+func (a *syslogArchiver) archive(docs []bson.M) error {
+	now := a.clock.Now().UTC()
+	for _, doc := range docs {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return errors.Annotate(err, "marshalling document")
+		}
+		msg := rfc5424.Message{
+			Header: rfc5424.Header{
+				Priority: rfc5424.Priority{
+					Severity: rfc5424.SeverityInformational,
+					Facility: rfc5424.FacilityUser,
+				},
+				Timestamp: rfc5424.Timestamp{now},
+				Hostname: rfc5424.Hostname{
+					FQDN: a.hostname,
+				},
+				AppName: rfc5424.AppName(a.appName),
+			},
+			StructuredData: rfc5424.StructuredData{
+				&sdelements.Private{
+					Name: "model",
+					PEN:  canonicalPEN,
+					Data: []rfc5424.StructuredDataParam{{
+						Name:  "model-uuid",
+						Value: rfc5424.StructuredDataParamValue(a.modelUUID),
+					}},
+				},
+			},
+			Msg: string(data),
+		}
+		if err := a.sender.Send(msg); err != nil {
+			return errors.Annotate(err, "sending to syslog")
+		}
+	}
+	return nil
+}
+
+// cleanup is part of documentArchiver. Retention is managed by the
+// syslog host, so it does nothing.
+func (a *syslogArchiver) cleanup() error {
+	return nil
+}
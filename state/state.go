@@ -1436,6 +1436,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			}
 			ops = append(ops, assignUnitOps(unitName, placement)...)
 		}
+		quotaOps, err := st.quotaOps(unitsQuotaUsage(args.NumUnits, args.Storage))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, quotaOps...)
 		return ops, nil
 	}
 	// At the last moment before inserting the application, prime status history.
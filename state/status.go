@@ -619,7 +619,7 @@ func eraseStatusHistory(mb modelBackend, globalKey string) error {
 
 	logFormat := "deleted %d status history documents for " + fmt.Sprintf("%q", globalKey)
 	deleted, err := deleteInBatches(
-		history.Writeable().Underlying(), nil, "", iter,
+		history.Writeable().Underlying(), nil, "", iter, nil,
 		logFormat, loggo.DEBUG,
 		noEarlyFinish,
 	)
@@ -703,7 +703,11 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
-func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", nil, NanoSeconds)
+// PruneStatusHistory removes status history entries until only entries
+// newer than maxHistoryTime remain and the collection is smaller than
+// maxHistoryMB. If archiving is enabled, the entries are written to
+// the archive before they are removed.
+func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int, archive PruneArchiveConfig) error {
+	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", nil, NanoSeconds, archive)
 	return errors.Trace(err)
 }
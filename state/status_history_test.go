@@ -4,10 +4,19 @@
 package state_test
 
 import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"time"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/rfc/rfc5424"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
@@ -53,7 +62,7 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryBySize(c *gc.C) {
 	c.Assert(history, gc.HasLen, initialHistory+1)
 
 	// Prune down to 1MB.
-	err = state.PruneStatusHistory(s.State, 0, 1)
+	err = state.PruneStatusHistory(s.State, 0, 1, state.PruneArchiveConfig{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = unit.StatusHistory(filter)
@@ -80,7 +89,7 @@ func (s *StatusHistorySuite) TestPruneStatusBySizeOnlyForController(c *gc.C) {
 	c.Logf("%d\n", len(history))
 	c.Assert(history, gc.HasLen, 20001)
 
-	err = state.PruneStatusHistory(st, 0, 1)
+	err = state.PruneStatusHistory(st, 0, 1, state.PruneArchiveConfig{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
@@ -135,7 +144,7 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 		checkPrimedUnitStatus(c, statusInfo, 9-i, 24*time.Hour)
 	}
 
-	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
+	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024, state.PruneArchiveConfig{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = units[0].StatusHistory(status.StatusHistoryFilter{Size: 50})
@@ -183,6 +192,187 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryArchives(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit, 10, 0)
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+
+	dir := filepath.Join(c.MkDir(), "archive")
+	archive := state.PruneArchiveConfig{Dir: dir}
+	err := state.PruneStatusHistory(s.State, 10*time.Hour, 1024, archive)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 11)
+
+	paths := s.archiveFiles(c, dir)
+	c.Assert(paths, gc.HasLen, 1)
+	docs := readArchive(c, paths[0])
+	c.Assert(docs, gc.HasLen, 10)
+	for _, doc := range docs {
+		c.Check(doc["model-uuid"], gc.Equals, s.State.ModelUUID())
+		c.Check(doc["globalkey"], gc.Equals, "u#"+unit.Name()+"#charm")
+		c.Check(doc["status"], gc.Equals, "active")
+	}
+
+	// Entries pruned later are appended to the same file.
+	primeUnitStatusHistory(c, unit, 5, 24*time.Hour)
+	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024, archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.archiveFiles(c, dir), jc.DeepEquals, paths)
+	c.Assert(readArchive(c, paths[0]), gc.HasLen, 15)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryArchiveRotation(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+
+	// Write a full archive file and one that has expired.
+	dir := c.MkDir()
+	prefix := filepath.Join(dir, "statuseshistory-"+s.State.ModelUUID()+"-")
+	expired := prefix + "20200101T000000.000000000Z.json.gz"
+	full := prefix + "20200102T000000.000000000Z.json.gz"
+	err := ioutil.WriteFile(expired, nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(full, make([]byte, 1024*1024), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	old := s.Clock.Now().Add(-48 * time.Hour)
+	err = os.Chtimes(expired, old, old)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024, state.PruneArchiveConfig{
+		Dir:           dir,
+		MaxFileSizeMB: 1,
+		MaxAge:        24 * time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	paths := s.archiveFiles(c, dir)
+	c.Assert(paths, gc.HasLen, 2)
+	c.Check(paths[0], gc.Equals, full)
+	c.Check(readArchive(c, paths[1]), gc.HasLen, 10)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryArchiveMaxTotalSize(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+
+	// Another model's archive file counts towards the total size,
+	// and is removed first because it is the oldest.
+	dir := c.MkDir()
+	other := filepath.Join(dir, "statuseshistory-deadbeef-20200101T000000.000000000Z.json.gz")
+	err := ioutil.WriteFile(other, make([]byte, 1024*1024), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	old := s.Clock.Now().Add(-48 * time.Hour)
+	err = os.Chtimes(other, old, old)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024, state.PruneArchiveConfig{
+		Dir:            dir,
+		MaxTotalSizeMB: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = os.Stat(other)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+	paths := s.archiveFiles(c, dir)
+	c.Assert(paths, gc.HasLen, 1)
+	c.Check(readArchive(c, paths[0]), gc.HasLen, 10)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryArchiveSyslog(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+
+	var sender fakeArchiveSender
+	err := state.PruneStatusHistory(s.State, 10*time.Hour, 1024, state.PruneArchiveConfig{
+		Syslog: &sender,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(sender.messages, gc.HasLen, 10)
+	for _, msg := range sender.messages {
+		c.Check(string(msg.AppName), gc.Equals, "juju-statuseshistory")
+		var doc map[string]interface{}
+		err := json.Unmarshal([]byte(msg.Msg), &doc)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(doc["model-uuid"], gc.Equals, s.State.ModelUUID())
+		c.Check(doc["globalkey"], gc.Equals, "u#"+unit.Name()+"#charm")
+	}
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryArchiveSyslogError(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+
+	sender := fakeArchiveSender{err: errors.New("boom")}
+	err := state.PruneStatusHistory(s.State, 10*time.Hour, 1024, state.PruneArchiveConfig{
+		Syslog: &sender,
+	})
+	c.Assert(err, gc.ErrorMatches, ".*sending to syslog: boom")
+
+	// Entries that could not be archived are not removed.
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 11)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryArchiveNotValid(c *gc.C) {
+	err := state.PruneStatusHistory(s.State, 10*time.Hour, 1024, state.PruneArchiveConfig{Dir: "archive"})
+	c.Assert(err, gc.ErrorMatches, `cannot archive pruned entries: relative archive directory "archive" not valid`)
+
+	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024, state.PruneArchiveConfig{
+		Dir:    c.MkDir(),
+		Syslog: &fakeArchiveSender{},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot archive pruned entries: archiving to both a directory and syslog not valid`)
+}
+
+type fakeArchiveSender struct {
+	messages []rfc5424.Message
+	err      error
+}
+
+func (s *fakeArchiveSender) Send(msg rfc5424.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *StatusHistorySuite) archiveFiles(c *gc.C, dir string) []string {
+	paths, err := filepath.Glob(filepath.Join(dir, "statuseshistory-"+s.State.ModelUUID()+"-*.json.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	return paths
+}
+
+func readArchive(c *gc.C, path string) []map[string]interface{} {
+	f, err := os.Open(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	c.Assert(err, jc.ErrorIsNil)
+	var docs []map[string]interface{}
+	decoder := json.NewDecoder(r)
+	for {
+		var doc map[string]interface{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
 func (s *StatusHistorySuite) TestStatusHistoryFilterRunningUpdateStatusHook(c *gc.C) {
 
 	application := s.Factory.MakeApplication(c, nil)
@@ -327,3 +517,96 @@ func (s *StatusHistorySuite) TestSameValueNotRepeated(c *gc.C) {
 	c.Assert(history[0].Message, gc.Equals, "current status")
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 }
+
+func (s *StatusHistorySuite) TestAllStatusHistory(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	machineID, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	start := s.Clock.Now().Add(time.Minute)
+	setStatus := func(setter status.StatusSetter, value status.Status, message string, offset time.Duration) {
+		when := start.Add(offset)
+		err := setter.SetStatus(status.StatusInfo{Status: value, Message: message, Since: &when})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	setStatus(unit, status.Active, "ready", 2*time.Second)
+	setStatus(machine, status.Started, "", 0)
+	setStatus(application, status.Maintenance, "installing", time.Second)
+
+	type entry struct {
+		kind    status.HistoryKind
+		entity  string
+		message string
+	}
+	entries := func(history state.ModelStatusHistory) []entry {
+		var result []entry
+		for _, e := range history.Entries {
+			result = append(result, entry{e.Kind, e.Entity, e.Message})
+		}
+		return result
+	}
+
+	history, err := s.Model.AllStatusHistory(state.ModelStatusHistoryFilter{From: start})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history.Next, gc.Equals, "")
+	c.Assert(entries(history), jc.DeepEquals, []entry{
+		{status.KindMachine, machineID, ""},
+		{status.KindApplication, application.Name(), "installing"},
+		{status.KindWorkload, unit.Name(), "ready"},
+	})
+
+	history, err = s.Model.AllStatusHistory(state.ModelStatusHistoryFilter{
+		From: start,
+		To:   start.Add(2 * time.Second),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries(history), jc.DeepEquals, []entry{
+		{status.KindMachine, machineID, ""},
+		{status.KindApplication, application.Name(), "installing"},
+	})
+
+	history, err = s.Model.AllStatusHistory(state.ModelStatusHistoryFilter{
+		From:  start,
+		Kinds: set.NewStrings(string(status.KindWorkload)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries(history), jc.DeepEquals, []entry{
+		{status.KindWorkload, unit.Name(), "ready"},
+	})
+}
+
+func (s *StatusHistorySuite) TestAllStatusHistoryPages(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	start := s.Clock.Now().Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		when := start.Add(time.Duration(i) * time.Second)
+		err := application.SetStatus(status.StatusInfo{
+			Status:  status.Active,
+			Message: fmt.Sprintf("status %d", i),
+			Since:   &when,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	filter := state.ModelStatusHistoryFilter{From: start, Size: 2}
+	history, err := s.Model.AllStatusHistory(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history.Entries, gc.HasLen, 2)
+	c.Assert(history.Entries[0].Message, gc.Equals, "status 0")
+	c.Assert(history.Entries[1].Message, gc.Equals, "status 1")
+	c.Assert(history.Next, gc.Not(gc.Equals), "")
+
+	filter.After = history.Next
+	history, err = s.Model.AllStatusHistory(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history.Entries, gc.HasLen, 1)
+	c.Assert(history.Entries[0].Message, gc.Equals, "status 2")
+	c.Assert(history.Next, gc.Equals, "")
+
+	filter.After = "bad"
+	_, err = s.Model.AllStatusHistory(filter)
+	c.Assert(err, gc.ErrorMatches, `status history cursor "bad" not valid`)
+}
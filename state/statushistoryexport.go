@@ -0,0 +1,235 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/mongo/utils"
+)
+
+// ModelStatusHistoryFilter holds the arguments used to select the
+// status history of the entities in a model.
+type ModelStatusHistoryFilter struct {
+	// From, if not zero, excludes entries recorded before this time.
+	From time.Time
+
+	// To, if not zero, excludes entries recorded at or after this time.
+	To time.Time
+
+	// Kinds, if not empty, restricts the entries to those of the
+	// given kinds.
+	Kinds set.Strings
+
+	// Exclude holds the status messages of entries to leave out.
+	Exclude set.Strings
+
+	// After, if not empty, is the Next value returned with the
+	// previous page of entries.
+	After string
+
+	// Size, if greater than zero, limits the number of status history
+	// records read. When the limit is reached, Next is set on the
+	// result so that the following page may be requested.
+	Size int
+}
+
+// ModelStatusHistoryEntry is a status history record of an entity in
+// the model.
+type ModelStatusHistoryEntry struct {
+	status.StatusInfo
+
+	// Kind identifies the kind of entity, and which of its statuses
+	// the entry records.
+	Kind status.HistoryKind
+
+	// Entity identifies the entity within entities of its kind;
+	// e.g. the unit name, or the machine or volume id.
+	Entity string
+}
+
+// ModelStatusHistory holds a page of the status history of a model.
+type ModelStatusHistory struct {
+	// Entries holds the entries of the page, oldest first.
+	Entries []ModelStatusHistoryEntry
+
+	// Next, if not empty, is used to request the following page.
+	Next string
+}
+
+// AllStatusHistory returns the status history of all the entities in the
+// model selected by the filter, merged into a single timeline ordered
+// by the time each entry was recorded.
+func (m *Model) AllStatusHistory(filter ModelStatusHistoryFilter) (ModelStatusHistory, error) {
+	query := bson.D{}
+	updated := bson.D{}
+	if !filter.From.IsZero() {
+		updated = append(updated, bson.DocElem{"$gte", filter.From.UnixNano()})
+	}
+	if !filter.To.IsZero() {
+		updated = append(updated, bson.DocElem{"$lt", filter.To.UnixNano()})
+	}
+	if len(updated) > 0 {
+		query = append(query, bson.DocElem{"updated", updated})
+	}
+	if filter.Exclude.Size() > 0 {
+		query = append(query, bson.DocElem{"statusinfo", bson.D{{"$nin", filter.Exclude.Values()}}})
+	}
+	if filter.After != "" {
+		afterUpdated, afterID, err := parseStatusHistoryCursor(filter.After)
+		if err != nil {
+			return ModelStatusHistory{}, errors.Trace(err)
+		}
+		query = append(query, bson.DocElem{"$or", []bson.D{
+			{{"updated", bson.D{{"$gt", afterUpdated}}}},
+			{{"updated", afterUpdated}, {"_id", bson.D{{"$gt", afterID}}}},
+		}})
+	}
+
+	history, closer := m.st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	q := history.Find(query).Sort("updated", "_id")
+	if filter.Size > 0 {
+		q = q.Limit(filter.Size)
+	}
+	var docs []struct {
+		ID                  bson.ObjectId `bson:"_id"`
+		historicalStatusDoc `bson:",inline"`
+	}
+	if err := q.All(&docs); err != nil {
+		return ModelStatusHistory{}, errors.Annotate(err, "cannot get status history")
+	}
+
+	var result ModelStatusHistory
+	relations := make(map[string]string)
+	for _, doc := range docs {
+		kind, entity, ok := statusHistoryEntity(doc.GlobalKey)
+		if !ok {
+			logger.Debugf("skipping status history for unknown entity %q", doc.GlobalKey)
+			continue
+		}
+		if filter.Kinds.Size() > 0 && !filter.Kinds.Contains(string(kind)) {
+			continue
+		}
+		switch kind {
+		case status.KindModel:
+			entity = m.Name()
+		case status.KindRelation:
+			entity = m.st.relationKeyForHistory(entity, relations)
+		}
+		result.Entries = append(result.Entries, ModelStatusHistoryEntry{
+			StatusInfo: status.StatusInfo{
+				Status:  doc.Status,
+				Message: doc.StatusInfo,
+				Data:    utils.UnescapeKeys(doc.StatusData),
+				Since:   unixNanoToTime(doc.Updated),
+			},
+			Kind:   kind,
+			Entity: entity,
+		})
+	}
+	if filter.Size > 0 && len(docs) == filter.Size {
+		last := docs[len(docs)-1]
+		result.Next = fmt.Sprintf("%d:%s", last.Updated, last.ID.Hex())
+	}
+	return result, nil
+}
+
+// relationKeyForHistory returns the key of the relation with the given
+// id, or the id itself if the relation no longer exists.
+func (st *State) relationKeyForHistory(id string, cache map[string]string) string {
+	if key, ok := cache[id]; ok {
+		return key
+	}
+	key := id
+	if n, err := strconv.Atoi(id); err == nil {
+		if rel, err := st.Relation(n); err == nil {
+			key = rel.String()
+		}
+	}
+	cache[id] = key
+	return key
+}
+
+func parseStatusHistoryCursor(cursor string) (int64, bson.ObjectId, error) {
+	parts := strings.SplitN(cursor, ":", 2)
+	if len(parts) == 2 && bson.IsObjectIdHex(parts[1]) {
+		if updated, err := strconv.ParseInt(parts[0], 10, 64); err == nil {
+			return updated, bson.ObjectIdHex(parts[1]), nil
+		}
+	}
+	return 0, "", errors.NotValidf("status history cursor %q", cursor)
+}
+
+// statusHistoryEntity returns the kind and identity of the entity whose
+// status history is recorded under the given global key.
+func statusHistoryEntity(globalKey string) (status.HistoryKind, string, bool) {
+	if globalKey == modelGlobalKey {
+		return status.KindModel, "", true
+	}
+	parts := strings.Split(globalKey, "#")
+	if len(parts) < 2 || parts[1] == "" {
+		return "", "", false
+	}
+	id, suffix := parts[1], strings.Join(parts[2:], "#")
+	switch parts[0] {
+	case "a":
+		switch suffix {
+		case "":
+			return status.KindApplication, id, true
+		case "operator":
+			return status.KindApplicationOperator, id, true
+		}
+	case "c":
+		if suffix == "" {
+			return status.KindRemoteApplication, id, true
+		}
+	case "r":
+		if suffix == "" {
+			return status.KindRelation, id, true
+		}
+	case "m":
+		container := names.IsContainerMachine(id)
+		switch {
+		case suffix == "" && container:
+			return status.KindContainer, id, true
+		case suffix == "":
+			return status.KindMachine, id, true
+		case suffix == "instance" && container:
+			return status.KindContainerInstance, id, true
+		case suffix == "instance":
+			return status.KindMachineInstance, id, true
+		case suffix == "modification":
+			return status.KindMachineModification, id, true
+		}
+	case "u":
+		switch suffix {
+		case "":
+			return status.KindUnitAgent, id, true
+		case "charm":
+			return status.KindWorkload, id, true
+		case "charm#sat#workload-version":
+			return status.KindWorkloadVersion, id, true
+		}
+	case "v":
+		if suffix == "" {
+			return status.KindVolume, id, true
+		}
+	case "f":
+		if suffix == "" {
+			return status.KindFilesystem, id, true
+		}
+	}
+	return "", "", false
+}
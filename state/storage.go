@@ -105,6 +105,7 @@ func NewStorageBackend(st *State) (*storageBackend, error) {
 		allApplications: st.AllApplications,
 		unit:            st.Unit,
 		machine:         st.Machine,
+		storageQuotaOps: st.storageQuotaOps,
 	}, nil
 }
 
@@ -115,6 +116,7 @@ type storageBackend struct {
 	allApplications func() ([]*Application, error)
 	unit            func(string) (*Unit, error)
 	machine         func(string) (*Machine, error)
+	storageQuotaOps func(uint64) ([]txn.Op, error)
 
 	modelType ModelType
 	registry  storage.ProviderRegistry
@@ -218,8 +220,9 @@ type storageInstanceDoc struct {
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool     string `bson:"pool"`
+	Size     uint64 `bson:"size"`
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 type storageAttachment struct {
@@ -806,6 +809,14 @@ func createStorageOps(
 
 	storageTags = make(map[string][]names.StorageTag)
 	ops = make([]txn.Op, 0, len(templates)*3)
+
+	// Storage restored from a snapshot requires the snapshot
+	// to remain alive until the storage has been created.
+	templateCons := make(map[string]StorageConstraints, len(templates))
+	for _, t := range templates {
+		templateCons[t.storageName] = t.cons
+	}
+	ops = append(ops, volumeSnapshotsAliveOps(templateCons)...)
 	for _, t := range templates {
 		owner := entityTag.String()
 		var kind StorageKind
@@ -832,8 +843,9 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:     cons.Pool,
+					Size:     cons.Size,
+					Snapshot: cons.Snapshot,
 				},
 			}
 			var hostStorageOps []txn.Op
@@ -1668,6 +1680,10 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot from
+	// which to restore the storage instances.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1771,6 +1787,9 @@ func validateStorageConstraintsAgainstCharm(
 		if err := validateStoragePool(sb, cons.Pool, kind, nil); err != nil {
 			return err
 		}
+		if _, err := sb.volumeSnapshotStorageConstraints(charmStorage, name, cons); err != nil {
+			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+		}
 	}
 	return nil
 }
@@ -1931,7 +1950,11 @@ func addDefaultStorageConstraints(sb *storageBackend, allCons map[string]Storage
 				)
 			}
 		}
-		cons, err := storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, cons)
+		cons, err := sb.volumeSnapshotStorageConstraints(charmStorage, name, cons)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cons, err = storageConstraintsWithDefaults(sb.modelType, conf, charmStorage, name, cons)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -2082,6 +2105,13 @@ func (sb *storageBackend) addStorageForUnitOps(
 	}
 	ops := u.assertCharmOps(ch)
 
+	// The pool and minimum size of storage restored
+	// from a snapshot are determined by the snapshot.
+	cons, err = sb.volumeSnapshotStorageConstraints(charmStorageMeta, storageName, cons)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	if cons.Pool == "" || cons.Size == 0 {
 		// Either pool or size, or both, were not specified. Take the
 		// values from the unit's recorded storage constraints.
@@ -2130,6 +2160,12 @@ func (sb *storageBackend) addStorageForUnitOps(
 		return nil, nil, errors.Trace(err)
 	}
 	ops = append(ops, addUnitStorageOps...)
+
+	quotaOps, err := sb.storageQuotaOps(cons.Count * cons.Size)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ops = append(ops, quotaOps...)
 	return tags, ops, nil
 }
 
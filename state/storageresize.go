@@ -0,0 +1,239 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ResizeStorageInstance requests that the volume or filesystem assigned
+// to the storage instance with the specified tag be grown to the given
+// size in MiB. For a filesystem backed by a volume, both the volume and
+// the filesystem are grown.
+//
+// The resize is carried out by the storage provisioner responsible for
+// the volume or filesystem, which records the new size with
+// SetVolumeInfo or SetFilesystemInfo. Until then, the requested size
+// is reported by the volume's or filesystem's RequestedSize method.
+//
+// The storage instance must be alive and provisioned, and the size
+// must be larger than the current size.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		ops := []txn.Op{{
+			C:      storageInstancesC,
+			Id:     s.doc.Id,
+			Assert: isAliveDoc,
+		}}
+		var volumeTag names.VolumeTag
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := sb.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeTag = v.VolumeTag()
+		case StorageKindFilesystem:
+			f, err := sb.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			filesystemOps, err := resizeFilesystemOps(f, size)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, filesystemOps...)
+			volumeTag, err = f.Volume()
+			if err == ErrNoBackingVolume {
+				// The filesystem is counted against the
+				// storage quota in its own right.
+				return sb.appendResizeQuotaOps(ops, f.doc.Info.Size, f.doc.RequestedSize, size)
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		default:
+			return nil, errors.Errorf("invalid storage kind %v", s.Kind())
+		}
+		v, err := getVolumeByTag(sb.mb, volumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Kind() == StorageKindFilesystem {
+			if info, err := v.Info(); err == nil && info.Size >= size {
+				// The backing volume is already large enough
+				// for the filesystem to grow into.
+				return ops, nil
+			}
+		}
+		volumeOps, err := resizeVolumeOps(v, size)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, volumeOps...)
+		return sb.appendResizeQuotaOps(ops, v.doc.Info.Size, v.doc.RequestedSize, size)
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// appendResizeQuotaOps appends to ops the operations needed to check
+// that growing a volume or filesystem of the specified current and
+// requested sizes to the new size does not exceed the model's storage
+// quota. Only the growth beyond any pending resize is counted, as the
+// pending resize is already counted in the model's usage.
+func (sb *storageBackend) appendResizeQuotaOps(ops []txn.Op, currentSize, requestedSize, size uint64) ([]txn.Op, error) {
+	counted := storageQuotaSize(currentSize, requestedSize)
+	if size <= counted {
+		return ops, nil
+	}
+	quotaOps, err := sb.storageQuotaOps(size - counted)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, quotaOps...), nil
+}
+
+// CancelStorageResize cancels any pending resize of the volume or
+// filesystem assigned to the storage instance with the specified tag,
+// including the backing volume of a filesystem. This is used when a
+// requested resize cannot be completed by the storage provisioner.
+//
+// An error satisfying errors.IsNotFound is returned if there is no
+// pending resize to cancel.
+func (sb *storageBackend) CancelStorageResize(tag names.StorageTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot cancel resize of storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		var volumeTag names.VolumeTag
+		switch s.Kind() {
+		case StorageKindBlock:
+			v, err := sb.storageInstanceVolume(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			volumeTag = v.VolumeTag()
+		case StorageKindFilesystem:
+			f, err := sb.storageInstanceFilesystem(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, cancelFilesystemResizeOps(f)...)
+			volumeTag, err = f.Volume()
+			if err != nil && err != ErrNoBackingVolume {
+				return nil, errors.Trace(err)
+			}
+		default:
+			return nil, errors.Errorf("invalid storage kind %v", s.Kind())
+		}
+		if volumeTag != (names.VolumeTag{}) {
+			v, err := getVolumeByTag(sb.mb, volumeTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, cancelVolumeResizeOps(v)...)
+		}
+		if len(ops) == 0 {
+			return nil, errors.NotFoundf("pending resize")
+		}
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+func cancelVolumeResizeOps(v *volume) []txn.Op {
+	requestedSize, ok := v.RequestedSize()
+	if !ok {
+		return nil
+	}
+	return []txn.Op{{
+		C:      volumesC,
+		Id:     v.VolumeTag().Id(),
+		Assert: bson.D{{"requested-size", requestedSize}},
+		Update: bson.D{{"$unset", bson.D{{"requested-size", nil}}}},
+	}}
+}
+
+func cancelFilesystemResizeOps(f *filesystem) []txn.Op {
+	requestedSize, ok := f.RequestedSize()
+	if !ok {
+		return nil
+	}
+	return []txn.Op{{
+		C:      filesystemsC,
+		Id:     f.FilesystemTag().Id(),
+		Assert: bson.D{{"requested-size", requestedSize}},
+		Update: bson.D{{"$unset", bson.D{{"requested-size", nil}}}},
+	}}
+}
+
+func resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.Errorf("volume %s is not alive", v.VolumeTag().Id())
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, errors.NotValidf(
+			"size %dMiB not larger than current size %dMiB of volume %s",
+			size, info.Size, v.VolumeTag().Id(),
+		)
+	}
+	if requestedSize, _ := v.RequestedSize(); requestedSize == size {
+		return nil, jujutxn.ErrNoOperations
+	}
+	return []txn.Op{{
+		C:  volumesC,
+		Id: v.VolumeTag().Id(),
+		Assert: bson.D{
+			{"life", Alive},
+			{"info.size", info.Size},
+		},
+		Update: bson.D{{"$set", bson.D{{"requested-size", size}}}},
+	}}, nil
+}
+
+func resizeFilesystemOps(f *filesystem, size uint64) ([]txn.Op, error) {
+	if f.Life() != Alive {
+		return nil, errors.Errorf("filesystem %s is not alive", f.FilesystemTag().Id())
+	}
+	info, err := f.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size <= info.Size {
+		return nil, errors.NotValidf(
+			"size %dMiB not larger than current size %dMiB of filesystem %s",
+			size, info.Size, f.FilesystemTag().Id(),
+		)
+	}
+	if requestedSize, _ := f.RequestedSize(); requestedSize == size {
+		return nil, jujutxn.ErrNoOperations
+	}
+	return []txn.Op{{
+		C:  filesystemsC,
+		Id: f.FilesystemTag().Id(),
+		Assert: bson.D{
+			{"life", Alive},
+			{"info.size", info.Size},
+		},
+		Update: bson.D{{"$set", bson.D{{"requested-size", size}}}},
+	}}, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type StorageResizeSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StorageResizeSuite{})
+
+func (s *StorageResizeSuite) setupBlockStorage(c *gc.C) (names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size:     1024,
+		VolumeId: "vol-ume",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, volumeTag
+}
+
+func (s *StorageResizeSuite) setupVolumeBackedFilesystem(c *gc.C) (names.StorageTag, names.FilesystemTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "modelscoped-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machine := unitMachine(c, s.st, u)
+	err = machine.SetProvisioned("inst-id", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystemTag := s.storageInstanceFilesystem(c, storageTag).FilesystemTag()
+	volumeTag := s.filesystemVolume(c, filesystemTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size:     1024,
+		VolumeId: "vol-ume",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		Size:         1024,
+		FilesystemId: "fs-id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return storageTag, filesystemTag, volumeTag
+}
+
+func (s *StorageResizeSuite) assertVolumeRequestedSize(c *gc.C, tag names.VolumeTag, expect uint64) {
+	size, ok := s.volume(c, tag).RequestedSize()
+	c.Assert(ok, gc.Equals, expect > 0)
+	c.Assert(size, gc.Equals, expect)
+}
+
+func (s *StorageResizeSuite) assertFilesystemRequestedSize(c *gc.C, tag names.FilesystemTag, expect uint64) {
+	size, ok := s.filesystem(c, tag).RequestedSize()
+	c.Assert(ok, gc.Equals, expect > 0)
+	c.Assert(size, gc.Equals, expect)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageInstanceVolume(c *gc.C) {
+	storageTag, volumeTag := s.setupBlockStorage(c)
+	s.assertVolumeRequestedSize(c, volumeTag, 0)
+
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeRequestedSize(c, volumeTag, 2048)
+
+	// Requesting the same size again is a no-op.
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeRequestedSize(c, volumeTag, 2048)
+}
+
+func (s *StorageResizeSuite) TestSetVolumeInfoCompletesResize(c *gc.C) {
+	storageTag, volumeTag := s.setupBlockStorage(c)
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A partial resize leaves the request in place.
+	info := state.VolumeInfo{Size: 1536, VolumeId: "vol-ume", Pool: "loop-pool"}
+	err = s.storageBackend.SetVolumeInfo(volumeTag, info)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeRequestedSize(c, volumeTag, 2048)
+
+	info.Size = 2048
+	err = s.storageBackend.SetVolumeInfo(volumeTag, info)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeRequestedSize(c, volumeTag, 0)
+	s.assertVolumeInfo(c, volumeTag, info)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageInstanceNotLarger(c *gc.C) {
+	storageTag, volumeTag := s.setupBlockStorage(c)
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot resize storage "data/0": size 1024MiB not larger than current size 1024MiB of volume 0/0 not valid`,
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	s.assertVolumeRequestedSize(c, volumeTag, 0)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageInstanceNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageInstanceVolumeBackedFilesystem(c *gc.C) {
+	storageTag, filesystemTag, volumeTag := s.setupVolumeBackedFilesystem(c)
+
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemRequestedSize(c, filesystemTag, 2048)
+	s.assertVolumeRequestedSize(c, volumeTag, 2048)
+
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size:     2048,
+		VolumeId: "vol-ume",
+		Pool:     "modelscoped-block",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeRequestedSize(c, volumeTag, 0)
+	s.assertFilesystemRequestedSize(c, filesystemTag, 2048)
+
+	err = s.storageBackend.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{
+		Size:         2048,
+		FilesystemId: "fs-id",
+		Pool:         "modelscoped-block",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemRequestedSize(c, filesystemTag, 0)
+}
+
+func (s *StorageResizeSuite) TestResizeStorageInstanceChecksQuota(c *gc.C) {
+	storageTag, volumeTag := s.setupBlockStorage(c)
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetQuotas(model.Quotas{Storage: quota(usage.Storage + 512)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.Satisfies, model.IsQuotaExceeded)
+	s.assertVolumeRequestedSize(c, volumeTag, 0)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 1536)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeRequestedSize(c, volumeTag, 1536)
+
+	// The pending resize is counted against the quota.
+	usage2, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage2.Storage, gc.Equals, usage.Storage+512)
+}
+
+func (s *StorageResizeSuite) TestCancelStorageResizeVolume(c *gc.C) {
+	storageTag, volumeTag := s.setupBlockStorage(c)
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.CancelStorageResize(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeRequestedSize(c, volumeTag, 0)
+
+	err = s.storageBackend.CancelStorageResize(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot cancel resize of storage "data/0": pending resize not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StorageResizeSuite) TestCancelStorageResizeVolumeBackedFilesystem(c *gc.C) {
+	storageTag, filesystemTag, volumeTag := s.setupVolumeBackedFilesystem(c)
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.CancelStorageResize(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertFilesystemRequestedSize(c, filesystemTag, 0)
+	s.assertVolumeRequestedSize(c, volumeTag, 0)
+}
+
+func (s *StorageResizeSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	storageTag, volumeTag := s.setupBlockStorage(c)
+
+	w := s.storageBackend.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(volumeTag.Id()) // initial
+	wc.AssertNoChange()
+
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(volumeTag.Id())
+	wc.AssertNoChange()
+}
+
+func (s *StorageResizeSuite) TestWatchVolume(c *gc.C) {
+	storageTag, volumeTag := s.setupBlockStorage(c)
+
+	w := s.storageBackend.WatchVolume(volumeTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange() // initial
+	wc.AssertNoChange()
+
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size:     2048,
+		VolumeId: "vol-ume",
+		Pool:     "loop-pool",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	wc.AssertNoChange()
+}
@@ -47,5 +47,8 @@ func (st *State) ProcessDyingModel() (err error) {
 	if _, err := checkModelEntityRefsEmpty(modelEntityRefsDoc); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkModelNoVolumeSnapshots(st); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
@@ -96,6 +96,7 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+	MaintenanceMode        bool `bson:"maintenance-mode,omitempty"`
 }
 
 // Unit represents the state of an application unit.
@@ -2263,13 +2264,17 @@ func (u *Unit) assignToNewMachineOps(
 	template.Dirty = true
 
 	var (
-		mdoc *machineDoc
-		ops  []txn.Op
-		err  error
+		mdoc  *machineDoc
+		ops   []txn.Op
+		usage model.Usage
+		err   error
 	)
 	switch {
 	case parentId == "" && containerType == "":
 		mdoc, ops, err = u.st.addMachineOps(template)
+		if err == nil {
+			usage, err = u.st.newMachinesQuotaUsage(template)
+		}
 	case parentId == "":
 		if containerType == "" {
 			return nil, nil, errors.New("assignToNewMachine called without container type (should never happen)")
@@ -2279,12 +2284,20 @@ func (u *Unit) assignToNewMachineOps(
 		parentParams := template
 		parentParams.Jobs = []MachineJob{JobHostUnits}
 		mdoc, ops, err = u.st.addMachineInsideNewMachineOps(template, parentParams, containerType)
+		if err == nil {
+			usage, err = u.st.newMachinesQuotaUsage(parentParams)
+		}
 	default:
 		mdoc, ops, err = u.st.addMachineInsideMachineOps(template, parentId, containerType)
 	}
 	if err != nil {
 		return nil, nil, err
 	}
+	quotaOps, err := u.st.quotaOps(usage)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ops = append(ops, quotaOps...)
 
 	// Ensure the host machine is really clean.
 	if parentId != "" {
@@ -2678,9 +2691,10 @@ func storageParamsForStorageInstance(
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		} else if errors.IsNotFound(err) {
 			volumeParams := VolumeParams{
-				storage: storage.StorageTag(),
-				Pool:    storage.doc.Constraints.Pool,
-				Size:    storage.doc.Constraints.Size,
+				storage:  storage.StorageTag(),
+				Pool:     storage.doc.Constraints.Pool,
+				Size:     storage.doc.Constraints.Size,
+				Snapshot: storage.doc.Constraints.Snapshot,
 			}
 			volumes = append(volumes, HostVolumeParams{
 				volumeParams, volumeAttachmentParams,
@@ -3204,6 +3218,36 @@ func (u *Unit) ClearResolved() error {
 	return nil
 }
 
+// MaintenanceMode returns whether the unit is in maintenance mode.
+// The unit agent doesn't run hooks for a unit in maintenance mode;
+// they are run once maintenance mode ends.
+func (u *Unit) MaintenanceMode() bool {
+	return u.doc.MaintenanceMode
+}
+
+// SetMaintenanceMode puts the unit into, or takes it out of,
+// maintenance mode.
+func (u *Unit) SetMaintenanceMode(maintenance bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set maintenance mode for unit %q", u)
+	var update bson.D
+	if maintenance {
+		update = bson.D{{"$set", bson.D{{"maintenance-mode", true}}}}
+	} else {
+		update = bson.D{{"$unset", bson.D{{"maintenance-mode", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+		Update: update,
+	}}
+	if err := u.st.db().RunTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	u.doc.MaintenanceMode = maintenance
+	return nil
+}
+
 // StorageConstraints returns the unit's storage constraints.
 func (u *Unit) StorageConstraints() (map[string]StorageConstraints, error) {
 	if u.doc.CharmURL == nil {
@@ -1441,6 +1441,30 @@ func (s *UnitSuite) TestGetSetClearResolved(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot set resolved mode for unit "wordpress/0": invalid error resolution mode: "foo"`)
 }
 
+func (s *UnitSuite) TestSetMaintenanceMode(c *gc.C) {
+	c.Assert(s.unit.MaintenanceMode(), jc.IsFalse)
+
+	err := s.unit.SetMaintenanceMode(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MaintenanceMode(), jc.IsTrue)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MaintenanceMode(), jc.IsTrue)
+
+	err = s.unit.SetMaintenanceMode(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MaintenanceMode(), jc.IsFalse)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.MaintenanceMode(), jc.IsFalse)
+}
+
+func (s *UnitSuite) TestSetMaintenanceModeDeadUnit(c *gc.C) {
+	c.Assert(s.unit.EnsureDead(), jc.ErrorIsNil)
+	err := s.unit.SetMaintenanceMode(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set maintenance mode for unit "wordpress/0": not found or dead`)
+}
+
 func (s *UnitSuite) TestOpenedPortsOnInvalidSubnet(c *gc.C) {
 	s.testOpenedPorts(c, "bad CIDR", `invalid subnet ID "bad CIDR"`)
 }
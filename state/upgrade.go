@@ -518,6 +518,45 @@ func (st *State) IsUpgrading() (bool, error) {
 	}
 }
 
+// ControllersRunningVersion reports whether no upgrade is in progress
+// and the agents of all the controller machines are running at least
+// the given version. Features that change the data replicated between
+// controllers must not be used until they are.
+func (st *State) ControllersRunningVersion(v version.Number) (bool, error) {
+	upgrading, err := st.IsUpgrading()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if upgrading {
+		return false, nil
+	}
+	controllerIds, err := st.ControllerIds()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, id := range controllerIds {
+		m, err := st.Machine(id)
+		if errors.IsNotFound(err) {
+			// CAAS controllers aren't machines, and have
+			// no peers to disagree with.
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		tools, err := m.AgentTools()
+		if errors.IsNotFound(err) {
+			// The agent hasn't started yet.
+			return false, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if tools.Version.Number.Compare(v) < 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // AbortCurrentUpgrade archives any current UpgradeInfo and sets its
 // status to UpgradeAborted. Nothing happens if there's no current
 // UpgradeInfo.
@@ -429,6 +429,45 @@ func (s *UpgradeSuite) TestAllProvisionedControllersReady(c *gc.C) {
 	assertReady(true)
 }
 
+func (s *UpgradeSuite) TestControllersRunningVersion(c *gc.C) {
+	serverIdB, _ := s.addControllers(c)
+	v123 := vers("1.2.3")
+
+	assertRunning := func(v version.Number, expect bool) {
+		ok, err := s.State.ControllersRunningVersion(v)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ok, gc.Equals, expect)
+	}
+	setVersion := func(machineId, v string) {
+		m, err := s.State.Machine(machineId)
+		c.Assert(err, jc.ErrorIsNil)
+		err = m.SetAgentVersion(version.MustParseBinary(v))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// None of the agents have started.
+	assertRunning(v123, false)
+
+	// Until every controller has been upgraded, features of the
+	// new version can't be used.
+	controllerIds, err := s.State.ControllerIds()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, id := range controllerIds {
+		setVersion(id, "1.2.3-quantal-amd64")
+	}
+	setVersion(serverIdB, "1.1.1-quantal-amd64")
+	assertRunning(v123, false)
+	assertRunning(vers("1.1.1"), true)
+
+	setVersion(serverIdB, "1.2.3-quantal-amd64")
+	assertRunning(v123, true)
+
+	// Nor while an upgrade is in progress.
+	_, err = s.State.EnsureUpgradeInfo(s.serverIdA, v123, vers("1.2.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	assertRunning(v123, false)
+}
+
 func (s *UpgradeSuite) TestSetStatusSetsModelStatus(c *gc.C) {
 	v123 := vers("1.2.3")
 	v234 := vers("2.3.4")
@@ -52,6 +52,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size in MiB that the volume has been
+	// requested to grow to, and true; or false if the volume has no
+	// pending resize.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -127,6 +132,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// RequestedSize is the size in MiB that the provisioned volume
+	// has been requested to grow to. It is removed once the volume
+	// info records a size at least this large.
+	RequestedSize uint64 `bson:"requested-size,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -176,6 +186,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot
+	// from which to create the volume.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -278,6 +292,11 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return getStatus(v.mb.db(), volumeGlobalKey(v.VolumeTag().Id()), "volume")
@@ -1099,6 +1118,14 @@ func (sb *storageBackend) validateVolumeParams(params VolumeParams, machineId st
 	if params.Size == 0 {
 		return "", errors.New("invalid size 0")
 	}
+	if params.Snapshot != "" {
+		if scope := volumeSnapshotScope(params.Snapshot); scope != "" && scope != machineId {
+			return "", errors.Errorf(
+				"cannot restore volume snapshot %q scoped to machine %q on machine %q",
+				params.Snapshot, scope, machineId,
+			)
+		}
+	}
 	return machineId, nil
 }
 
@@ -1442,7 +1469,13 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 				return nil, err
 			}
 		}
-		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		// If the volume has grown to its requested size,
+		// the resize is complete.
+		var resizedFrom uint64
+		if requestedSize, ok := v.RequestedSize(); ok && info.Size >= requestedSize {
+			resizedFrom = requestedSize
+		}
+		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams, resizedFrom)...)
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
@@ -1464,15 +1497,27 @@ func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	return nil
 }
 
-func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []txn.Op {
+// setVolumeInfoOps returns the operations for setting the volume's info.
+// If resizedFrom is non-zero, the volume's requested size is removed,
+// asserting that it is still resizedFrom.
+func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool, resizedFrom uint64) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if resizedFrom > 0 {
+		// Don't lose a concurrent request to grow the volume further.
+		asserts = append(asserts, bson.DocElem{"requested-size", resizedFrom})
+		unset = append(unset, bson.DocElem{"requested-size", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      volumesC,
@@ -0,0 +1,536 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// VolumeSnapshot describes a point-in-time copy of a volume in the
+// model, from which new volumes may be created.
+type VolumeSnapshot interface {
+	Lifer
+	status.StatusGetter
+	status.StatusSetter
+
+	// Id returns the ID of the snapshot. Snapshots of machine-scoped
+	// volumes are scoped to the same machine.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot was
+	// taken of. The volume may no longer exist.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the volume that
+	// the snapshot was taken of. Volumes restored from the snapshot
+	// are created in the same pool.
+	Pool() string
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot
+// that has been taken.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is the provider-allocated unique ID of the snapshot.
+	SnapshotId string `bson:"snapshotid"`
+
+	// Size is the size of the volume at the time the snapshot was
+	// taken, in MiB. Volumes restored from the snapshot must be at
+	// least this large.
+	Size uint64 `bson:"size"`
+}
+
+type volumeSnapshot struct {
+	mb  modelBackend
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot in
+// the model.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Id        string              `bson:"snapshotid"`
+	ModelUUID string              `bson:"model-uuid"`
+	Life      Life                `bson:"life"`
+	VolumeId  string              `bson:"volumeid"`
+	Pool      string              `bson:"pool"`
+	Created   time.Time           `bson:"created"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.VolumeId)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Id)
+	}
+	return *s.doc.Info, nil
+}
+
+// Status is required to implement StatusGetter.
+func (s *volumeSnapshot) Status() (status.StatusInfo, error) {
+	return getStatus(s.mb.db(), volumeSnapshotGlobalKey(s.doc.Id), "volume snapshot")
+}
+
+// SetStatus is required to implement StatusSetter.
+func (s *volumeSnapshot) SetStatus(snapshotStatus status.StatusInfo) error {
+	switch snapshotStatus.Status {
+	case status.Pending, status.Available, status.Destroying:
+	case status.Error:
+		if snapshotStatus.Message == "" {
+			return errors.Errorf("cannot set status %q without info", snapshotStatus.Status)
+		}
+	default:
+		return errors.Errorf("cannot set invalid status %q", snapshotStatus.Status)
+	}
+	return setStatus(s.mb.db(), setStatusParams{
+		badge:     "volume snapshot",
+		globalKey: volumeSnapshotGlobalKey(s.doc.Id),
+		status:    snapshotStatus.Status,
+		message:   snapshotStatus.Message,
+		rawData:   snapshotStatus.Data,
+		updated:   timeOrNow(snapshotStatus.Since, s.mb.clock()),
+	})
+}
+
+func volumeSnapshotGlobalKey(id string) string {
+	return "vs#" + id
+}
+
+// newVolumeSnapshotId returns a unique volume snapshot ID. Snapshots
+// of a machine-scoped volume are given the same machine scope, so that
+// they are managed by the same storage provisioner as the volume.
+func newVolumeSnapshotId(mb modelBackend, volumeId string) (string, error) {
+	seq, err := sequence(mb, "volumesnapshot")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	if i := strings.LastIndex(volumeId, "/"); i >= 0 {
+		id = volumeId[:i] + "/" + id
+	}
+	return id, nil
+}
+
+// volumeSnapshotScope returns the machine scope of the volume snapshot
+// with the given ID, or the empty string if it is model-scoped.
+func volumeSnapshotScope(id string) string {
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		return id[:i]
+	}
+	return ""
+}
+
+// AddVolumeSnapshot requests a snapshot of the volume with the
+// specified tag, and returns the ID of the new snapshot. The volume
+// must be alive and provisioned. The snapshot is taken by the storage
+// provisioner responsible for the volume, which records the provider's
+// snapshot details with SetVolumeSnapshotInfo.
+func (sb *storageBackend) AddVolumeSnapshot(tag names.VolumeTag) (_ string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot volume %s", tag.Id())
+	var id string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		id, err = newVolumeSnapshotId(sb.mb, tag.Id())
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot generate volume snapshot ID")
+		}
+		now := sb.mb.clock().Now()
+		return []txn.Op{
+			{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+			},
+			createStatusOp(sb.mb, volumeSnapshotGlobalKey(id), statusDoc{
+				Status:  status.Pending,
+				Updated: now.UnixNano(),
+			}),
+			{
+				C:      volumeSnapshotsC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &volumeSnapshotDoc{
+					Id:       id,
+					Life:     Alive,
+					VolumeId: tag.Id(),
+					Pool:     info.Pool,
+					Created:  now,
+				},
+			},
+		}, nil
+	}
+	if err := sb.mb.db().Run(buildTxn); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// countVolumeSnapshots returns the number of volume snapshots in the
+// model.
+func countVolumeSnapshots(mb modelBackend) (int, error) {
+	coll, cleanup := mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	n, err := coll.Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count volume snapshots")
+	}
+	return n, nil
+}
+
+// VolumeSnapshot returns the volume snapshot with the specified ID.
+func (sb *storageBackend) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := sb.volumeSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (sb *storageBackend) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{sb.mb, doc}, nil
+}
+
+// AllVolumeSnapshots returns all volume snapshots in the model.
+func (sb *storageBackend) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{sb.mb, doc}
+	}
+	return snapshots, nil
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot with the
+// specified ID is Dying. The storage provisioner responsible for the
+// snapshot destroys it in the provider, and then removes it from state.
+//
+// A snapshot cannot be destroyed while storage constraints refer to
+// it, or while storage is still waiting to be restored from it.
+func (sb *storageBackend) DestroyVolumeSnapshot(id string) (err error) {
+	return sb.destroyVolumeSnapshot(id, true)
+}
+
+func (sb *storageBackend) destroyVolumeSnapshot(id string, checkInUse bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "destroying volume snapshot %s", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if errors.IsNotFound(err) && attempt > 0 {
+			// On the first attempt, we expect it to exist.
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if checkInUse {
+			if err := sb.checkVolumeSnapshotNotInUse(id); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		statusOps, err := statusSetOps(sb.mb.db(), statusDoc{
+			Status:  status.Destroying,
+			Updated: sb.mb.clock().Now().UnixNano(),
+		}, volumeSnapshotGlobalKey(id))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		}}
+		return append(ops, statusOps...), nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// SetVolumeSnapshotInfo sets the VolumeSnapshotInfo for the specified
+// volume snapshot, once it has been taken.
+func (sb *storageBackend) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo == info {
+				return nil, jujutxn.ErrNoOperations
+			}
+			if oldInfo.SnapshotId != info.SnapshotId {
+				return nil, errors.Errorf(
+					"cannot change snapshot ID from %q to %q",
+					oldInfo.SnapshotId, info.SnapshotId,
+				)
+			}
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot from state. The
+// snapshot must have been destroyed with DestroyVolumeSnapshot.
+func (sb *storageBackend) RemoveVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "removing volume snapshot %s", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is not dying")
+		}
+		ops := removeVolumeSnapshotOps(sb.mb, id)
+		ops[0].Assert = bson.D{{"life", bson.D{{"$ne", Alive}}}}
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// releaseVolumeSnapshot removes the volume snapshot from state without
+// destroying it in the provider.
+func (sb *storageBackend) releaseVolumeSnapshot(id string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "releasing volume snapshot %s", id)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := sb.volumeSnapshot(id); errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return removeVolumeSnapshotOps(sb.mb, id), nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+func removeVolumeSnapshotOps(mb modelBackend, id string) []txn.Op {
+	return []txn.Op{
+		{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Remove: true,
+		},
+		removeStatusOp(mb, volumeSnapshotGlobalKey(id)),
+	}
+}
+
+// removeMachineVolumeSnapshotsOps returns txn.Ops to remove the volume
+// snapshots scoped to the specified machine. Such snapshots are stored
+// by the machine, and so are gone along with it.
+func (sb *storageBackend) removeMachineVolumeSnapshotsOps(m *Machine) ([]txn.Op, error) {
+	coll, cleanup := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	query := bson.D{{"snapshotid", bson.RegEx{
+		Pattern: "^" + regexp.QuoteMeta(m.Id()) + "/[0-9]+$",
+	}}}
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get machine volume snapshots")
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		ops = append(ops, removeVolumeSnapshotOps(sb.mb, doc.Id)...)
+	}
+	return ops, nil
+}
+
+// checkVolumeSnapshotNotInUse returns an error if the volume snapshot
+// with the specified ID is referred to by application storage
+// constraints, or if storage is still waiting to be restored from it.
+func (sb *storageBackend) checkVolumeSnapshotNotInUse(id string) error {
+	// TODO: as with storage pools, the data model should count the
+	// references to a snapshot so these checks can be txn asserts.
+	coll, closer := sb.mb.db().GetCollection(storageConstraintsC)
+	defer closer()
+
+	var consDocs []storageConstraintsDoc
+	if err := coll.Find(nil).All(&consDocs); err != nil {
+		return errors.Annotate(err, "cannot get storage constraints")
+	}
+	for _, doc := range consDocs {
+		for _, cons := range doc.Constraints {
+			if cons.Snapshot != id {
+				continue
+			}
+			// The key is "asc#<application>#<charm-url>".
+			key := strings.Split(sb.mb.localID(doc.DocID), "#")
+			if len(key) < 2 {
+				return errors.New("volume snapshot is used by application storage constraints")
+			}
+			return errors.Errorf(
+				"volume snapshot is used by the storage constraints of application %q", key[1],
+			)
+		}
+	}
+
+	storageInstances, err := sb.storageInstances(bson.D{{"constraints.snapshot", id}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, s := range storageInstances {
+		v, err := sb.storageInstanceVolume(s.StorageTag())
+		if err == nil {
+			_, err = v.Info()
+		}
+		if errors.IsNotFound(err) || errors.IsNotProvisioned(err) {
+			return errors.Errorf(
+				"volume snapshot is used by storage %s, which has not been restored yet",
+				s.StorageTag().Id(),
+			)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotsAliveOps returns txn.Ops to assert that the volume
+// snapshots referred to by the given storage constraints are alive.
+func volumeSnapshotsAliveOps(cons map[string]StorageConstraints) []txn.Op {
+	snapshots := set.NewStrings()
+	for _, cons := range cons {
+		if cons.Snapshot != "" {
+			snapshots.Add(cons.Snapshot)
+		}
+	}
+	ops := make([]txn.Op, 0, snapshots.Size())
+	for _, id := range snapshots.SortedValues() {
+		ops = append(ops, txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: isAliveDoc,
+		})
+	}
+	return ops
+}
+
+// volumeSnapshotStorageConstraints validates storage constraints that
+// specify a snapshot to restore from, and returns them with the pool
+// and size filled in from the snapshot where they are not specified.
+func (sb *storageBackend) volumeSnapshotStorageConstraints(
+	charmStorage charm.Storage, name string, cons StorageConstraints,
+) (StorageConstraints, error) {
+	if cons.Snapshot == "" {
+		return cons, nil
+	}
+	if charmStorage.Type != charm.StorageBlock {
+		return cons, errors.NotSupportedf(
+			"restoring %s storage %q from a snapshot", charmStorage.Type, name,
+		)
+	}
+	s, err := sb.volumeSnapshot(cons.Snapshot)
+	if err != nil {
+		return cons, errors.Trace(err)
+	}
+	if s.Life() != Alive {
+		return cons, errors.Errorf("volume snapshot %q is not alive", cons.Snapshot)
+	}
+	info, err := s.Info()
+	if err != nil {
+		return cons, errors.Trace(err)
+	}
+	if cons.Pool == "" {
+		cons.Pool = s.Pool()
+	} else if cons.Pool != s.Pool() {
+		return cons, errors.NotValidf(
+			"pool %q for storage %q restored from snapshot %q in pool %q",
+			cons.Pool, name, cons.Snapshot, s.Pool(),
+		)
+	}
+	if cons.Size == 0 {
+		cons.Size = info.Size
+	} else if cons.Size < info.Size {
+		return cons, errors.NotValidf(
+			"size %dMiB for storage %q smaller than snapshot %q size %dMiB",
+			cons.Size, name, cons.Snapshot, info.Size,
+		)
+	}
+	return cons, nil
+}
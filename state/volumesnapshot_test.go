@@ -0,0 +1,308 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+func (s *VolumeSnapshotSuite) setupVolume(c *gc.C, pool string) (*state.Unit, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size:     1024,
+		VolumeId: "vol-ume",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, volumeTag
+}
+
+func (s *VolumeSnapshotSuite) addProvisionedSnapshot(c *gc.C, volumeTag names.VolumeTag) string {
+	id, err := s.storageBackend.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-123",
+		Size:       1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+func (s *VolumeSnapshotSuite) volumeSnapshot(c *gc.C, id string) state.VolumeSnapshot {
+	snapshot, err := s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	return snapshot
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.setupVolume(c, "modelscoped-block")
+
+	id, err := s.storageBackend.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "0")
+
+	snapshot := s.volumeSnapshot(c, id)
+	c.Assert(snapshot.Id(), gc.Equals, id)
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "modelscoped-block")
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Pending)
+
+	all, err := s.storageBackend.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, id)
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotMachineScoped(c *gc.C) {
+	_, volumeTag := s.setupVolume(c, "loop-pool")
+	c.Assert(volumeTag.Id(), gc.Equals, "0/0")
+
+	id, err := s.storageBackend.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "0/0")
+}
+
+func (s *VolumeSnapshotSuite) TestAddVolumeSnapshotNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "modelscoped-block")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	_, err = s.storageBackend.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot volume 0: volume "0" not provisioned`)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	_, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id := s.addProvisionedSnapshot(c, volumeTag)
+
+	info, err := s.volumeSnapshot(c, id).Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-123",
+		Size:       1024,
+	})
+
+	err = s.storageBackend.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-456",
+		Size:       1024,
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot set info for volume snapshot "0": cannot change snapshot ID from "snap-123" to "snap-456"`,
+	)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id := s.addProvisionedSnapshot(c, volumeTag)
+
+	err := s.storageBackend.RemoveVolumeSnapshot(id)
+	c.Assert(err, gc.ErrorMatches, "removing volume snapshot 0: volume snapshot is not dying")
+
+	err = s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot := s.volumeSnapshot(c, id)
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+	statusInfo, err := snapshot.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Destroying)
+
+	err = s.storageBackend.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing it again is a no-op.
+	err = s.storageBackend.RemoveVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchModelVolumeSnapshots(c *gc.C) {
+	_, volumeTag := s.setupVolume(c, "modelscoped-block")
+
+	w := s.storageBackend.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	id, err := s.storageBackend.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+
+	err = s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshot(c *gc.C) {
+	u, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id := s.addProvisionedSnapshot(c, volumeTag)
+
+	tags, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: id,
+		Count:    1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.HasLen, 1)
+
+	params, ok := s.storageInstanceVolume(c, tags[0]).Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params, jc.DeepEquals, state.VolumeParams{
+		Pool:     "modelscoped-block",
+		Size:     1024,
+		Snapshot: id,
+	})
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotTooSmall(c *gc.C) {
+	u, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id := s.addProvisionedSnapshot(c, volumeTag)
+
+	_, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: id,
+		Size:     512,
+		Count:    1,
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`adding "allecto" storage to storage-block/0: size 512MiB for storage "allecto" smaller than snapshot "0" size 1024MiB not valid`,
+	)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotNotProvisioned(c *gc.C) {
+	u, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id, err := s.storageBackend.AddVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: id,
+		Count:    1,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyVolumeSnapshotRestorePending(c *gc.C) {
+	u, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id := s.addProvisionedSnapshot(c, volumeTag)
+
+	tags, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: id,
+		Count:    1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, gc.ErrorMatches,
+		`destroying volume snapshot 0: volume snapshot is used by storage allecto/\d+, which has not been restored yet`,
+	)
+
+	restoredTag := s.storageInstanceVolume(c, tags[0]).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(restoredTag, state.VolumeInfo{
+		Size:     1024,
+		VolumeId: "vol-restored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyVolumeSnapshotUsedByStorageConstraints(c *gc.C) {
+	_, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id := s.addProvisionedSnapshot(c, volumeTag)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	s.AddTestingApplicationWithStorage(c, "restored", ch, map[string]state.StorageConstraints{
+		"data": {Snapshot: id, Count: 1},
+	})
+
+	err := s.storageBackend.DestroyVolumeSnapshot(id)
+	c.Assert(err, gc.ErrorMatches,
+		`destroying volume snapshot 0: volume snapshot is used by the storage constraints of application "restored"`,
+	)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromDyingSnapshot(c *gc.C) {
+	u, volumeTag := s.setupVolume(c, "modelscoped-block")
+	id := s.addProvisionedSnapshot(c, volumeTag)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.storageBackend.DestroyVolumeSnapshot(id)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: id,
+		Count:    1,
+	})
+	c.Assert(err, gc.ErrorMatches, `.*volume snapshot "0" is not alive`)
+}
+
+func (s *VolumeSnapshotSuite) addMachineWithSnapshot(c *gc.C) (*state.Machine, string) {
+	machine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.HostVolumeParams{{
+			Volume: state.VolumeParams{Pool: "loop-pool", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := names.NewVolumeTag("0/0")
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size:     1024,
+		VolumeId: "vol-ume",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return machine, s.addProvisionedSnapshot(c, volumeTag)
+}
+
+func (s *VolumeSnapshotSuite) TestRemoveMachineRemovesVolumeSnapshots(c *gc.C) {
+	machine, id := s.addMachineWithSnapshot(c)
+	c.Assert(id, gc.Equals, "0/0")
+
+	c.Assert(machine.Destroy(), jc.ErrorIsNil)
+	c.Assert(machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(machine.Remove(), jc.ErrorIsNil)
+
+	_, err := s.storageBackend.VolumeSnapshot(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyModelWithVolumeSnapshots(c *gc.C) {
+	_, id := s.addMachineWithSnapshot(c)
+
+	// The machine's loop volume is not persistent, but its snapshot is.
+	err := s.Model.Destroy(state.DestroyModelParams{})
+	c.Assert(err, jc.Satisfies, state.IsHasPersistentStorageError)
+
+	destroyStorage := true
+	err = s.Model.Destroy(state.DestroyModelParams{DestroyStorage: &destroyStorage})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.volumeSnapshot(c, id).Life(), gc.Equals, state.Dying)
+}
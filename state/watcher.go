@@ -227,14 +227,39 @@ func (sb *storageBackend) watchModelHostStorage(collection string) StringsWatche
 	mb := sb.mb
 	pattern := fmt.Sprintf("^%s$", mb.docID(machineOrUnitSnippet))
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	filter := func(id interface{}) bool {
-		k, err := mb.strictLocalID(id.(string))
+	return newLifecycleWatcher(mb, collection, members, sb.modelHostStorageFilter(), nil)
+}
+
+// modelHostStorageFilter returns a filter that matches the ids of
+// model-scoped volumes or filesystems.
+func (sb *storageBackend) modelHostStorageFilter() func(interface{}) bool {
+	return func(id interface{}) bool {
+		k, err := sb.mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return !strings.Contains(k, "/")
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to any model-scoped volume, so that the storage provisioner
+// can check whether it has been requested to resize the volume.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: sb.modelHostStorageFilter(),
+	})
+}
+
+// WatchModelFilesystemResizes returns a StringsWatcher that notifies of
+// changes to any model-scoped filesystem, so that the storage provisioner
+// can check whether it has been requested to resize the filesystem.
+func (sb *storageBackend) WatchModelFilesystemResizes() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    filesystemsC,
+		filter: sb.modelHostStorageFilter(),
+	})
 }
 
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
@@ -263,16 +288,56 @@ func (sb *storageBackend) watchHostStorage(host names.Tag, collection string) St
 	// The host parameter passed into this method is the application name, any of whose units we are interested in.
 	pattern := fmt.Sprintf("^%s(/%s)?/%s$", mb.docID(host.Id()), names.NumberSnippet, names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	return newLifecycleWatcher(mb, collection, members, sb.hostStorageFilter(host), nil)
+}
+
+// hostStorageFilter returns a filter that matches the ids of volumes
+// or filesystems scoped to the specified host.
+func (sb *storageBackend) hostStorageFilter(host names.Tag) func(interface{}) bool {
 	prefix := fmt.Sprintf("%s(/%s)?/.*", host.Id(), names.NumberSnippet)
 	matchExp := regexp.MustCompile(prefix)
-	filter := func(id interface{}) bool {
-		k, err := mb.strictLocalID(id.(string))
+	return func(id interface{}) bool {
+		k, err := sb.mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return matchExp.MatchString(k)
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
+}
+
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all model-scoped volume snapshots.
+func (sb *storageBackend) WatchModelVolumeSnapshots() StringsWatcher {
+	return sb.watchModelHostStorage(volumeSnapshotsC)
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all volume snapshots scoped to the
+// specified machine.
+func (sb *storageBackend) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return sb.watchHostStorage(m, volumeSnapshotsC)
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to any volume scoped to the specified machine, so that the
+// storage provisioner can check whether it has been requested to resize
+// the volume.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: sb.hostStorageFilter(m),
+	})
+}
+
+// WatchMachineFilesystemResizes returns a StringsWatcher that notifies of
+// changes to any filesystem scoped to the specified machine, so that the
+// storage provisioner can check whether it has been requested to resize
+// the filesystem.
+func (sb *storageBackend) WatchMachineFilesystemResizes(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    filesystemsC,
+		filter: sb.hostStorageFilter(m),
+	})
 }
 
 // WatchMachineAttachmentsPlans returns a StringsWatcher that notifies machine agents
@@ -2015,6 +2080,18 @@ func (sb *storageBackend) WatchFilesystemAttachment(host names.Tag, f names.File
 	return newEntityWatcher(sb.mb, filesystemAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume,
+// such as the recording of a new size after it is resized.
+func (sb *storageBackend) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, volumesC, sb.mb.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a
+// filesystem, such as the recording of a new size after it is resized.
+func (sb *storageBackend) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(f.Id()))
+}
+
 // WatchCharmConfig returns a watcher for observing changes to the
 // application's charm configuration settings. The returned watcher will be
 // valid only while the application's charm URL is not changed.
@@ -27,6 +27,10 @@ type Constraints struct {
 
 	// Count is the number of instances of the storage to create.
 	Count uint64
+
+	// Snapshot is the ID of a volume snapshot from which to create
+	// the storage instances, or "" if they should be created empty.
+	Snapshot string
 }
 
 var (
@@ -35,11 +39,13 @@ var (
 	sizeRE  = regexp.MustCompile("^-?[0-9]+(?:\\.[0-9]+)?[MGTPEZY](?:i?B)?$")
 )
 
+const snapshotPrefix = "snapshot:"
+
 // ParseConstraints parses the specified string and creates a
 // Constraints structure.
 //
 // The acceptable format for storage constraints is a comma separated
-// sequence of: POOL, COUNT, and SIZE, where
+// sequence of: POOL, COUNT, SIZE, and SNAPSHOT, where
 //
 //    POOL identifies the storage pool. POOL can be a string
 //    starting with a letter, followed by zero or more digits
@@ -53,6 +59,9 @@ var (
 //    create. SIZE is a floating point number and multiplier from
 //    the set (M, G, T, P, E, Z, Y), which are all treated as
 //    powers of 1024.
+//
+//    SNAPSHOT identifies a volume snapshot from which to create
+//    the storage instances, in the form "snapshot:ID".
 func ParseConstraints(s string) (Constraints, error) {
 	var cons Constraints
 	fields := strings.Split(s, ",")
@@ -60,6 +69,17 @@ func ParseConstraints(s string) (Constraints, error) {
 		if field == "" {
 			continue
 		}
+		if strings.HasPrefix(field, snapshotPrefix) {
+			snapshot := strings.TrimPrefix(field, snapshotPrefix)
+			if snapshot == "" {
+				return cons, errors.NotValidf("empty snapshot ID")
+			}
+			if cons.Snapshot != "" {
+				return cons, errors.NotValidf("snapshot is already set to %q, new value %q", cons.Snapshot, snapshot)
+			}
+			cons.Snapshot = snapshot
+			continue
+		}
 		if IsValidPoolName(field) {
 			if cons.Pool != "" {
 				return cons, errors.NotValidf("pool name is already set to %q, new value %q", cons.Pool, field)
@@ -84,7 +104,7 @@ func ParseConstraints(s string) (Constraints, error) {
 		}
 		return cons, errors.NotValidf("unrecognized storage constraint %q", field)
 	}
-	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" {
+	if cons.Count == 0 && cons.Size == 0 && cons.Pool == "" && cons.Snapshot == "" {
 		return Constraints{}, errors.New("storage constraints require at least one field to be specified")
 	}
 	if cons.Count == 0 {
@@ -67,6 +67,21 @@ func (s *ConstraintsSuite) TestParseConstraintsCountRange(c *gc.C) {
 	s.testParseError(c, ",", `storage constraints require at least one field to be specified`)
 }
 
+func (s *ConstraintsSuite) TestParseConstraintsSnapshot(c *gc.C) {
+	s.testParse(c, "snapshot:0/3", storage.Constraints{
+		Snapshot: "0/3",
+		Count:    1,
+	})
+	s.testParse(c, "p,snapshot:3,2G", storage.Constraints{
+		Pool:     "p",
+		Snapshot: "3",
+		Count:    1,
+		Size:     2048,
+	})
+	s.testParseError(c, "snapshot:", `empty snapshot ID not valid`)
+	s.testParseError(c, "snapshot:1,snapshot:2", `snapshot is already set to "1", new value "2" not valid`)
+}
+
 func (s *ConstraintsSuite) TestParseConstraintsSizeRange(c *gc.C) {
 	s.testParseError(c, "p,-100M", `cannot parse size: expected a non-negative number, got "-100M"`)
 }
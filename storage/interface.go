@@ -216,6 +216,77 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// ResizeSupporter is an optional interface that a Provider may
+// implement to report whether storage of the specified kind created
+// by its sources can be grown. Storage created by providers that do
+// not implement ResizeSupporter cannot be resized.
+//
+// The controller consults ResizeSupporter before accepting a resize
+// request, so a provider implementing it must return sources that
+// implement VolumeResizer or FilesystemResizer for the kinds that it
+// reports as resizable.
+type ResizeSupporter interface {
+	// SupportsResize reports whether or not storage of the specified
+	// kind can be resized.
+	SupportsResize(kind StorageKind) bool
+}
+
+// VolumeResizer provides an interface for growing volumes that have
+// already been provisioned. It is optional for a VolumeSource to
+// implement VolumeResizer; volumes created by sources that do not
+// implement it cannot be resized.
+//
+// TODO: only the loop and lvm volume sources implement VolumeResizer;
+// the cloud providers' volume sources should do so too.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified provider
+	// volume IDs to at least the requested sizes. The volumes may be
+	// attached to machines; implementations must not require the
+	// volumes to be detached first.
+	//
+	// ResizeVolumes returns the resulting volume information, which
+	// should report the new size of the volume.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems
+// that have already been provisioned. It is optional for a
+// FilesystemSource to implement FilesystemResizer; filesystems
+// created by sources that do not implement it cannot be resized.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// provider filesystem IDs to at least the requested sizes. The
+	// filesystems may be mounted; implementations must not require
+	// the filesystems to be detached first.
+	//
+	// ResizeFilesystems returns the resulting filesystem information,
+	// which should report the new size of the filesystem.
+	ResizeFilesystems(ctx context.ProviderCallContext, params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes. It is optional for a VolumeSource to implement
+// VolumeSnapshotter; volumes created by sources that do not implement
+// it cannot be snapshotted.
+//
+// A VolumeSource that implements VolumeSnapshotter must also support
+// creating volumes from its snapshots, as specified by the SnapshotId
+// field of VolumeParams. Snapshots are only restored to block storage.
+//
+// TODO: only the loop and dummy providers implement VolumeSnapshotter;
+// the cloud providers' volume sources should do so too.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes snapshots of the volumes with the
+	// specified provider volume IDs, returning information about
+	// each snapshot taken.
+	CreateVolumeSnapshots(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// DestroyVolumeSnapshots destroys the snapshots with the specified
+	// provider snapshot IDs. It is not an error to destroy a snapshot
+	// that does not exist.
+	DestroyVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -239,6 +310,12 @@ type VolumeParams struct {
 	// storage provider supports tags.
 	ResourceTags map[string]string
 
+	// SnapshotId is the provider-supplied ID of the snapshot from which
+	// the volume should be created, or "" if the volume should be
+	// created empty. It is only set for providers whose volume sources
+	// implement VolumeSnapshotter.
+	SnapshotId string
+
 	// Attachment identifies the machine that the volume should be attached
 	// to initially, or nil if the volume should not be attached to any
 	// machine. Some providers, such as MAAS, do not support dynamic
@@ -339,6 +416,50 @@ type FilesystemAttachmentParams struct {
 	Path string
 }
 
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju to the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the minimum size of the volume in MiB after resizing.
+	Size uint64
+}
+
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju to the filesystem.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem, if any.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem.
+	FilesystemId string
+
+	// Size is the minimum size of the filesystem in MiB after resizing.
+	Size uint64
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju to the snapshot.
+	Id string
+
+	// Volume is the unique tag assigned by Juju to the volume.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
 // for one volume. Volume and VolumeAttachment should only be used if Error is
 // nil.
@@ -375,3 +496,26 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. VolumeInfo should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// FilesystemInfo should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	FilesystemInfo *FilesystemInfo
+	Error          error
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one snapshot.
+// VolumeSnapshotInfo should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshotInfo *VolumeSnapshotInfo
+	Error              error
+}
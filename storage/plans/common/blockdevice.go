@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/storage"
+)
+
+// CommandRunner runs the command made up of params, returning its
+// output and exit code.
+type CommandRunner func(params []string) (*exec.ExecResponse, error)
+
+// RunCommand is a CommandRunner that runs the command on the local
+// machine.
+func RunCommand(params []string) (*exec.ExecResponse, error) {
+	return exec.RunCommands(exec.RunParams{
+		Commands: strings.Join(params, " "),
+	})
+}
+
+// CheckedRun runs the command made up of params, and returns an error
+// if it could not be run or it exited with a non-zero exit code.
+func CheckedRun(run CommandRunner, params []string) (*exec.ExecResponse, error) {
+	result, err := run(params)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.Code != 0 {
+		return result, errors.Errorf(
+			"%s exited with code %d: %s",
+			params[0], result.Code, strings.TrimSpace(string(result.Stderr)),
+		)
+	}
+	return result, nil
+}
+
+// BlockDeviceInfo returns details of the named block device, as
+// reported by udev.
+func BlockDeviceInfo(run CommandRunner, name string) (storage.BlockDevice, error) {
+	cmd := []string{
+		"udevadm", "info",
+		"-q", "property",
+		"--path", fmt.Sprintf("/block/%s", name),
+	}
+	result, err := CheckedRun(run, cmd)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Annotate(err, "error running udevadm")
+	}
+	blockDevice := storage.BlockDevice{
+		DeviceName: name,
+	}
+	var busId, serialId string
+	s := bufio.NewScanner(bytes.NewReader(result.Stdout))
+	for s.Scan() {
+		fields := strings.SplitN(s.Text(), "=", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		key, value := fields[0], fields[1]
+		switch key {
+		case "ID_WWN":
+			blockDevice.WWN = value
+		case "DEVLINKS":
+			blockDevice.DeviceLinks = strings.Fields(value)
+		case "ID_BUS":
+			busId = value
+		case "ID_SERIAL":
+			serialId = value
+		}
+	}
+	if busId != "" && serialId != "" {
+		blockDevice.HardwareId = fmt.Sprintf("%s-%s", busId, serialId)
+	}
+	return blockDevice, nil
+}
@@ -4,8 +4,6 @@
 package iscsi
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -18,7 +16,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/retry"
-	"github.com/juju/utils/exec"
 
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/plans/common"
@@ -75,64 +72,7 @@ type iscsiConnectionInfo struct {
 	chapUser   string
 }
 
-var runCommand = func(params []string) (*exec.ExecResponse, error) {
-	cmd := strings.Join(params, " ")
-	execParams := exec.RunParams{
-		Commands: cmd,
-	}
-	resp, err := exec.RunCommands(execParams)
-
-	return resp, err
-}
-
-func getHardwareInfo(name string) (storage.BlockDevice, error) {
-	cmd := []string{
-		"udevadm", "info",
-		"-q", "property",
-		"--path", fmt.Sprintf("/block/%s", name),
-	}
-
-	result, err := runCommand(cmd)
-	if err != nil {
-		return storage.BlockDevice{}, errors.Annotatef(err, "error running udevadm")
-	}
-	blockDevice := storage.BlockDevice{
-		DeviceName: name,
-	}
-	var busId, serialId string
-	s := bufio.NewScanner(bytes.NewReader(result.Stdout))
-	for s.Scan() {
-		line := s.Text()
-		if line == "" {
-			continue
-		}
-		if strings.Contains(line, "") {
-			continue
-		}
-		fields := strings.SplitN(line, "=", 2)
-		if len(fields) != 2 {
-			logger.Tracef("failed to parse line %s", line)
-			continue
-		}
-
-		key := fields[0]
-		value := fields[1]
-		switch key {
-		case "ID_WWN":
-			blockDevice.WWN = value
-		case "DEVLINKS":
-			blockDevice.DeviceLinks = strings.Split(value, " ")
-		case "ID_BUS":
-			busId = value
-		case "ID_SERIAL":
-			serialId = value
-		}
-	}
-	if busId != "" && serialId != "" {
-		blockDevice.HardwareId = fmt.Sprintf("%s-%s", busId, serialId)
-	}
-	return blockDevice, nil
-}
+var runCommand common.CommandRunner = common.RunCommand
 
 func newiSCSIInfo(info map[string]string) (*iscsiConnectionInfo, error) {
 	var iqn, address, user, secret, port string
@@ -379,7 +319,7 @@ func (i *iscsiConnectionInfo) attach() (storage.BlockDevice, error) {
 	if err != nil {
 		return storage.BlockDevice{}, errors.Trace(err)
 	}
-	return getHardwareInfo(devName)
+	return common.BlockDeviceInfo(runCommand, devName)
 }
 
 func (i *iscsiConnectionInfo) detach() error {
@@ -0,0 +1,19 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nvme
+
+import (
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/storage/plans/common"
+)
+
+var (
+	RunCommand         = &runCommand
+	SysfsNVMeSubsystem = &sysfsNVMeSubsystem
+)
+
+func NewNVMeTCPPlanForTest(clock clock.Clock) common.Plan {
+	return &nvmePlan{clock: clock}
+}
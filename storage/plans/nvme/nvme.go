@@ -0,0 +1,322 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nvme
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/retry"
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/common"
+)
+
+var logger = loggo.GetLogger("juju.storage.plans.nvme")
+
+const (
+	// defaultPort is the IANA assigned port for NVMe/TCP I/O
+	// controllers, used if the plan does not specify one.
+	defaultPort = 4420
+
+	// NVME_ERR_ALREADY is the exit code nvme-cli returns if the host
+	// is already connected to the subsystem.
+	NVME_ERR_ALREADY = 114
+)
+
+var (
+	sysfsNVMeSubsystem = "/sys/class/nvme-subsystem"
+
+	runCommand common.CommandRunner = common.RunCommand
+
+	// namespaceRegexp matches the block device names of NVMe
+	// namespaces, eg nvme0n1. Controller paths to a namespace when
+	// native multipathing is enabled (eg nvme0c0n1) are not matched.
+	namespaceRegexp  = regexp.MustCompile(`^nvme[0-9]+n[0-9]+$`)
+	controllerRegexp = regexp.MustCompile(`^nvme[0-9]+$`)
+)
+
+type nvmePlan struct {
+	clock clock.Clock
+}
+
+// NewNVMeTCPPlan returns a plan that attaches NVMe namespaces exported
+// over NVMe/TCP. The plan's device attributes are:
+//   - nqn: the NQN of the subsystem (required)
+//   - address: the address of the subsystem's I/O controller (required)
+//   - port: the port of the I/O controller, 4420 by default
+//   - nsid: the ID of the volume's namespace, which may only be left
+//     out if the subsystem exports a single namespace to the machine
+//   - host-nqn: the NQN the machine connects with
+func NewNVMeTCPPlan() common.Plan {
+	return &nvmePlan{clock: clock.WallClock}
+}
+
+// AttachVolume connects to the NVMe subsystem described by volumeInfo,
+// if the machine is not already connected to it, and returns the block
+// device of its namespace.
+func (p *nvmePlan) AttachVolume(volumeInfo map[string]string) (storage.BlockDevice, error) {
+	info, err := newNVMeInfo(volumeInfo)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	return info.attach(p.clock)
+}
+
+// DetachVolume disconnects from the NVMe subsystem described by
+// volumeInfo, unless the subsystem still exports other namespaces to
+// the machine, which belong to other volume attachments. It is not an
+// error if the machine is not connected.
+func (p *nvmePlan) DetachVolume(volumeInfo map[string]string) error {
+	info, err := newNVMeInfo(volumeInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return info.detach()
+}
+
+type nvmeConnectionInfo struct {
+	nqn     string
+	address string
+	port    int
+	nsid    int
+	hostNQN string
+}
+
+func newNVMeInfo(info map[string]string) (*nvmeConnectionInfo, error) {
+	var nqn, address string
+	var ok bool
+	if nqn, ok = info["nqn"]; !ok {
+		return nil, errors.Errorf("missing required field: nqn")
+	}
+	if address, ok = info["address"]; !ok {
+		return nil, errors.Errorf("missing required field: address")
+	}
+	port := defaultPort
+	if value, ok := info["port"]; ok {
+		var err error
+		if port, err = strconv.Atoi(value); err != nil {
+			return nil, errors.Errorf("invalid port: %v", value)
+		}
+	}
+	var nsid int
+	if value, ok := info["nsid"]; ok {
+		var err error
+		if nsid, err = strconv.Atoi(value); err != nil || nsid <= 0 {
+			return nil, errors.Errorf("invalid nsid: %v", value)
+		}
+	}
+	return &nvmeConnectionInfo{
+		nqn:     nqn,
+		address: address,
+		port:    port,
+		nsid:    nsid,
+		hostNQN: info["host-nqn"],
+	}, nil
+}
+
+// subsystem returns the sysfs folder of the NVMe subsystem with the
+// plan's NQN, which only exists while the machine is connected to it.
+func (i *nvmeConnectionInfo) subsystem() (string, error) {
+	items, err := ioutil.ReadDir(sysfsNVMeSubsystem)
+	if os.IsNotExist(err) {
+		return "", errors.NotFoundf("subsystem %s", i.nqn)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, item := range items {
+		subsysPath := filepath.Join(sysfsNVMeSubsystem, item.Name())
+		nqn, err := ioutil.ReadFile(filepath.Join(subsysPath, "subsysnqn"))
+		if err != nil {
+			logger.Tracef("cannot read NQN of %s: %v", subsysPath, err)
+			continue
+		}
+		if strings.TrimSpace(string(nqn)) == i.nqn {
+			return subsysPath, nil
+		}
+	}
+	return "", errors.NotFoundf("subsystem %s", i.nqn)
+}
+
+// namespaces returns the block device names of the namespaces the
+// subsystem exports to the machine, keyed by namespace ID. With native
+// multipathing a namespace is a child of the subsystem; without it, it
+// is a child of each controller with a path to it, and the first of
+// its block devices is returned.
+func (i *nvmeConnectionInfo) namespaces() (map[int]string, error) {
+	subsysPath, err := i.subsystem()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dirs := []string{subsysPath}
+	items, err := ioutil.ReadDir(subsysPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, item := range items {
+		if controllerRegexp.MatchString(item.Name()) {
+			dirs = append(dirs, filepath.Join(subsysPath, item.Name()))
+		}
+	}
+	var devNames []string
+	nsids := make(map[string]int)
+	for _, dir := range dirs {
+		items, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, item := range items {
+			if !namespaceRegexp.MatchString(item.Name()) {
+				continue
+			}
+			data, err := ioutil.ReadFile(filepath.Join(dir, item.Name(), "nsid"))
+			if err != nil {
+				logger.Tracef("cannot read namespace ID of %s: %v", item.Name(), err)
+				continue
+			}
+			nsid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+			if err != nil {
+				logger.Tracef("invalid namespace ID of %s: %q", item.Name(), data)
+				continue
+			}
+			devNames = append(devNames, item.Name())
+			nsids[item.Name()] = nsid
+		}
+	}
+	sort.Strings(devNames)
+	namespaces := make(map[int]string)
+	for _, devName := range devNames {
+		if _, ok := namespaces[nsids[devName]]; !ok {
+			namespaces[nsids[devName]] = devName
+		}
+	}
+	return namespaces, nil
+}
+
+// deviceName returns the name of the block device of the plan's
+// namespace.
+func (i *nvmeConnectionInfo) deviceName() (string, error) {
+	namespaces, err := i.namespaces()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if i.nsid != 0 {
+		if devName, ok := namespaces[i.nsid]; ok {
+			return devName, nil
+		}
+		return "", errors.NotFoundf("namespace %d of subsystem %s", i.nsid, i.nqn)
+	}
+	switch len(namespaces) {
+	case 0:
+		return "", errors.NotFoundf("namespace of subsystem %s", i.nqn)
+	case 1:
+		for _, devName := range namespaces {
+			return devName, nil
+		}
+	}
+	return "", errors.Errorf(
+		"subsystem %s exports %d namespaces, nsid must be specified",
+		i.nqn, len(namespaces),
+	)
+}
+
+func (i *nvmeConnectionInfo) connect() error {
+	connectCmd := []string{
+		"nvme", "connect",
+		"-t", "tcp",
+		"-n", i.nqn,
+		"-a", i.address,
+		"-s", strconv.Itoa(i.port),
+	}
+	if i.hostNQN != "" {
+		connectCmd = append(connectCmd, "-q", i.hostNQN)
+	}
+	result, err := common.CheckedRun(runCommand, connectCmd)
+	if err != nil && !alreadyConnected(result) {
+		return errors.Annotatef(err, "connecting to subsystem %s", i.nqn)
+	}
+	return nil
+}
+
+func alreadyConnected(result *exec.ExecResponse) bool {
+	return result != nil && result.Code == NVME_ERR_ALREADY
+}
+
+func (i *nvmeConnectionInfo) disconnect() error {
+	disconnectCmd := []string{
+		"nvme", "disconnect",
+		"-n", i.nqn,
+	}
+	if _, err := common.CheckedRun(runCommand, disconnectCmd); err != nil {
+		return errors.Annotatef(err, "disconnecting from subsystem %s", i.nqn)
+	}
+	return nil
+}
+
+func (i *nvmeConnectionInfo) attach(clock clock.Clock) (storage.BlockDevice, error) {
+	devName, err := i.deviceName()
+	if errors.IsNotFound(err) {
+		// Connections don't survive a reboot, so this is the usual
+		// path when the machine agent restarts as well as when the
+		// volume is first attached.
+		if err := i.connect(); err != nil {
+			return storage.BlockDevice{}, errors.Trace(err)
+		}
+		// Wait for the device to show up.
+		err = retry.Call(retry.CallArgs{
+			Func: func() error {
+				devName, err = i.deviceName()
+				return err
+			},
+			IsFatalError: func(err error) bool {
+				return !errors.IsNotFound(err)
+			},
+			Attempts: 20,
+			Delay:    time.Second,
+			Clock:    clock,
+		})
+		err = retry.LastError(err)
+	}
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	return common.BlockDeviceInfo(runCommand, devName)
+}
+
+func (i *nvmeConnectionInfo) detach() error {
+	namespaces, err := i.namespaces()
+	if errors.IsNotFound(err) {
+		logger.Debugf("not connected to subsystem %s", i.nqn)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	// A subsystem may export the namespaces of several volumes, and
+	// disconnecting from it would remove them all. The provider stops
+	// exporting a namespace once its volume is detached, so another
+	// namespace means another volume is still attached to the machine.
+	// Without an nsid, a single namespace is taken to be the plan's.
+	others := len(namespaces)
+	if _, ok := namespaces[i.nsid]; ok || i.nsid == 0 && others > 0 {
+		others--
+	}
+	if others > 0 {
+		logger.Debugf(
+			"not disconnecting from subsystem %s, which exports %d other namespaces",
+			i.nqn, others,
+		)
+		return nil
+	}
+	return errors.Trace(i.disconnect())
+}
@@ -0,0 +1,294 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package nvme_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/common"
+	"github.com/juju/juju/storage/plans/nvme"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const testNQN = "nqn.2014-08.org.example:volume-0"
+
+const udevadmOutput = `
+DEVNAME=/dev/nvme0n1
+DEVTYPE=disk
+ID_SERIAL=Linux_5a4b
+ID_WWN=uuid.5a4b1c2d
+DEVLINKS=/dev/disk/by-id/nvme-Linux_5a4b /dev/disk/by-id/nvme-uuid.5a4b1c2d
+`
+
+type nvmeSuite struct {
+	testing.IsolationSuite
+
+	sysfs    string
+	clock    *testclock.Clock
+	plan     common.Plan
+	commands []string
+	// onConnect is called when "nvme connect" is run, and returns
+	// its exit code.
+	onConnect func() int
+}
+
+var _ = gc.Suite(&nvmeSuite{})
+
+func (s *nvmeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.sysfs = c.MkDir()
+	s.commands = nil
+	s.onConnect = func() int {
+		s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0n1")
+		return 0
+	}
+	s.PatchValue(nvme.SysfsNVMeSubsystem, s.sysfs)
+	s.PatchValue(nvme.RunCommand, s.runCommand)
+	s.clock = testclock.NewClock(time.Time{})
+	s.plan = nvme.NewNVMeTCPPlanForTest(s.clock)
+}
+
+func (s *nvmeSuite) runCommand(params []string) (*exec.ExecResponse, error) {
+	s.commands = append(s.commands, strings.Join(params, " "))
+	switch strings.Join(params[:2], " ") {
+	case "nvme connect":
+		return &exec.ExecResponse{Code: s.onConnect()}, nil
+	case "udevadm info":
+		return &exec.ExecResponse{Stdout: []byte(udevadmOutput)}, nil
+	}
+	return &exec.ExecResponse{}, nil
+}
+
+var nsidRegexp = regexp.MustCompile(`^nvme[0-9]+(c[0-9]+)?n([0-9]+)$`)
+
+// addSubsystem creates the sysfs folder of a connected subsystem,
+// with its namespaces at the given paths relative to the folder.
+// The ID of each namespace is taken from its name.
+func (s *nvmeSuite) addSubsystem(c *gc.C, name, nqn string, namespaces ...string) {
+	subsysPath := filepath.Join(s.sysfs, name)
+	err := os.MkdirAll(subsysPath, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(subsysPath, "subsysnqn"), []byte(nqn+"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, ns := range namespaces {
+		nsPath := filepath.Join(subsysPath, ns)
+		err := os.MkdirAll(nsPath, 0755)
+		c.Assert(err, jc.ErrorIsNil)
+		if m := nsidRegexp.FindStringSubmatch(filepath.Base(ns)); m != nil {
+			err := ioutil.WriteFile(filepath.Join(nsPath, "nsid"), []byte(m[2]+"\n"), 0644)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+}
+
+func (s *nvmeSuite) volumeInfo() map[string]string {
+	return map[string]string{
+		"nqn":     testNQN,
+		"address": "10.0.0.5",
+	}
+}
+
+var expectedBlockDevice = storage.BlockDevice{
+	DeviceName: "nvme0n1",
+	DeviceLinks: []string{
+		"/dev/disk/by-id/nvme-Linux_5a4b",
+		"/dev/disk/by-id/nvme-uuid.5a4b1c2d",
+	},
+	WWN: "uuid.5a4b1c2d",
+}
+
+func (s *nvmeSuite) TestAttachVolume(c *gc.C) {
+	dev, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev, jc.DeepEquals, expectedBlockDevice)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"nvme connect -t tcp -n " + testNQN + " -a 10.0.0.5 -s 4420",
+		"udevadm info -q property --path /block/nvme0n1",
+	})
+}
+
+func (s *nvmeSuite) TestAttachVolumePortAndHostNQN(c *gc.C) {
+	info := s.volumeInfo()
+	info["port"] = "8009"
+	info["host-nqn"] = "nqn.2014-08.org.example:host-0"
+	_, err := s.plan.AttachVolume(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands[0], gc.Equals,
+		"nvme connect -t tcp -n "+testNQN+" -a 10.0.0.5 -s 8009 -q nqn.2014-08.org.example:host-0")
+}
+
+func (s *nvmeSuite) TestAttachVolumeAlreadyConnected(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys0", "nqn.2014-08.org.example:other", "nvme0n1")
+	s.addSubsystem(c, "nvme-subsys1", testNQN, "nvme1n1")
+
+	dev, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.DeviceName, gc.Equals, "nvme1n1")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"udevadm info -q property --path /block/nvme1n1",
+	})
+}
+
+func (s *nvmeSuite) TestAttachVolumeNoMultipath(c *gc.C) {
+	// Without native multipathing the namespace is a child of the
+	// controller, and the controller's path to it isn't a block device.
+	s.onConnect = func() int {
+		s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0", "nvme0/nvme0n1", "nvme0/nvme0c0n1")
+		return 0
+	}
+	dev, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.DeviceName, gc.Equals, "nvme0n1")
+}
+
+func (s *nvmeSuite) TestAttachVolumeNSID(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0n1", "nvme0n2")
+	info := s.volumeInfo()
+	info["nsid"] = "2"
+	dev, err := s.plan.AttachVolume(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.DeviceName, gc.Equals, "nvme0n2")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"udevadm info -q property --path /block/nvme0n2",
+	})
+}
+
+func (s *nvmeSuite) TestAttachVolumeNSIDNotExported(c *gc.C) {
+	// The namespace isn't exported to the machine yet, so the machine
+	// waits for it after connecting, in case it's on the way.
+	s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0n1")
+	info := s.volumeInfo()
+	info["nsid"] = "2"
+	done := make(chan error)
+	go func() {
+		_, err := s.plan.AttachVolume(info)
+		done <- err
+	}()
+	for i := 0; i < 19; i++ {
+		err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `namespace 2 of subsystem `+testNQN+` not found`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for attach to fail")
+	}
+}
+
+func (s *nvmeSuite) TestAttachVolumeNSIDRequired(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0n1", "nvme0n2")
+	_, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, gc.ErrorMatches, `subsystem `+testNQN+` exports 2 namespaces, nsid must be specified`)
+}
+
+func (s *nvmeSuite) TestAttachVolumeConnectRace(c *gc.C) {
+	// Another attempt connected to the subsystem between looking for
+	// the device and connecting.
+	s.onConnect = func() int {
+		s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0n1")
+		return nvme.NVME_ERR_ALREADY
+	}
+	dev, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.DeviceName, gc.Equals, "nvme0n1")
+}
+
+func (s *nvmeSuite) TestAttachVolumeConnectFails(c *gc.C) {
+	s.onConnect = func() int { return 1 }
+	_, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, gc.ErrorMatches, `connecting to subsystem `+testNQN+`: nvme exited with code 1: `)
+}
+
+func (s *nvmeSuite) TestAttachVolumeWaitsForDevice(c *gc.C) {
+	s.onConnect = func() int {
+		s.addSubsystem(c, "nvme-subsys0", testNQN)
+		return 0
+	}
+	done := make(chan error)
+	go func() {
+		_, err := s.plan.AttachVolume(s.volumeInfo())
+		done <- err
+	}()
+	for i := 0; i < 19; i++ {
+		err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `namespace of subsystem `+testNQN+` not found`)
+		c.Assert(errors.IsNotFound(err), jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for attach to fail")
+	}
+}
+
+func (s *nvmeSuite) TestAttachVolumeMissingFields(c *gc.C) {
+	_, err := s.plan.AttachVolume(map[string]string{"address": "10.0.0.5"})
+	c.Assert(err, gc.ErrorMatches, "missing required field: nqn")
+	_, err = s.plan.AttachVolume(map[string]string{"nqn": testNQN})
+	c.Assert(err, gc.ErrorMatches, "missing required field: address")
+	info := s.volumeInfo()
+	info["port"] = "nvme"
+	_, err = s.plan.AttachVolume(info)
+	c.Assert(err, gc.ErrorMatches, "invalid port: nvme")
+	info = s.volumeInfo()
+	info["nsid"] = "0"
+	_, err = s.plan.AttachVolume(info)
+	c.Assert(err, gc.ErrorMatches, "invalid nsid: 0")
+	c.Assert(s.commands, gc.HasLen, 0)
+}
+
+func (s *nvmeSuite) TestDetachVolume(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0n1")
+	err := s.plan.DetachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"nvme disconnect -n " + testNQN,
+	})
+}
+
+func (s *nvmeSuite) TestDetachVolumeNotConnected(c *gc.C) {
+	err := s.plan.DetachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, gc.HasLen, 0)
+}
+
+func (s *nvmeSuite) TestDetachVolumeLastNamespace(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0", "nvme0/nvme0n2")
+	info := s.volumeInfo()
+	info["nsid"] = "2"
+	err := s.plan.DetachVolume(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"nvme disconnect -n " + testNQN,
+	})
+}
+
+func (s *nvmeSuite) TestDetachVolumeOtherNamespaces(c *gc.C) {
+	s.addSubsystem(c, "nvme-subsys0", testNQN, "nvme0n1", "nvme0n2")
+	info := s.volumeInfo()
+	info["nsid"] = "2"
+	err := s.plan.DetachVolume(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, gc.HasLen, 0)
+
+	// Without an nsid, the plan can't tell which namespace is its own.
+	err = s.plan.DetachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, gc.HasLen, 0)
+}
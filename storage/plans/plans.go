@@ -10,11 +10,15 @@ import (
 	"github.com/juju/juju/storage/plans/common"
 	"github.com/juju/juju/storage/plans/iscsi"
 	"github.com/juju/juju/storage/plans/local"
+	"github.com/juju/juju/storage/plans/nvme"
+	"github.com/juju/juju/storage/plans/rbd"
 )
 
 var registry = map[storage.DeviceType]common.Plan{
-	storage.DeviceTypeLocal: local.NewLocalPlan(),
-	storage.DeviceTypeISCSI: iscsi.NewiSCSIPlan(),
+	storage.DeviceTypeLocal:   local.NewLocalPlan(),
+	storage.DeviceTypeISCSI:   iscsi.NewiSCSIPlan(),
+	storage.DeviceTypeNVMeTCP: nvme.NewNVMeTCPPlan(),
+	storage.DeviceTypeRBD:     rbd.NewRBDPlan(),
 }
 
 func PlanByType(name storage.DeviceType) (common.Plan, error) {
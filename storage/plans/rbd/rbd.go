@@ -0,0 +1,190 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rbd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/common"
+)
+
+var logger = loggo.GetLogger("juju.storage.plans.rbd")
+
+var runCommand common.CommandRunner = common.RunCommand
+
+type rbdPlan struct{}
+
+// NewRBDPlan returns a plan that maps Ceph RBD images with the kernel
+// rbd driver.
+func NewRBDPlan() common.Plan {
+	return &rbdPlan{}
+}
+
+// AttachVolume maps the RBD image described by volumeInfo, if it is
+// not already mapped, and returns its block device.
+func (p *rbdPlan) AttachVolume(volumeInfo map[string]string) (storage.BlockDevice, error) {
+	info, err := newRBDInfo(volumeInfo)
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	return info.attach()
+}
+
+// DetachVolume unmaps the RBD image described by volumeInfo. It is not
+// an error if the image is not mapped.
+func (p *rbdPlan) DetachVolume(volumeInfo map[string]string) error {
+	info, err := newRBDInfo(volumeInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return info.detach()
+}
+
+type rbdImageInfo struct {
+	pool      string
+	namespace string
+	image     string
+	user      string
+	keyring   string
+	monitors  string
+}
+
+func newRBDInfo(info map[string]string) (*rbdImageInfo, error) {
+	var pool, image string
+	var ok bool
+	if pool, ok = info["pool"]; !ok {
+		return nil, errors.Errorf("missing required field: pool")
+	}
+	if image, ok = info["image"]; !ok {
+		return nil, errors.Errorf("missing required field: image")
+	}
+	return &rbdImageInfo{
+		pool:      pool,
+		namespace: info["namespace"],
+		image:     image,
+		user:      info["user"],
+		keyring:   info["keyring"],
+		monitors:  info["monitors"],
+	}, nil
+}
+
+// imageSpec returns the image in the form the rbd command expects.
+func (i *rbdImageInfo) imageSpec() string {
+	if i.namespace != "" {
+		return strings.Join([]string{i.pool, i.namespace, i.image}, "/")
+	}
+	return i.pool + "/" + i.image
+}
+
+// mappedImage is an entry in the output of "rbd showmapped".
+type mappedImage struct {
+	Pool      string `json:"pool"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Snap      string `json:"snap"`
+	Device    string `json:"device"`
+}
+
+// deviceName returns the name of the block device the image is mapped
+// to. Mapped snapshots of the image are ignored.
+func (i *rbdImageInfo) deviceName() (string, error) {
+	result, err := common.CheckedRun(runCommand, []string{"rbd", "showmapped", "--format", "json"})
+	if err != nil {
+		return "", errors.Annotate(err, "listing mapped images")
+	}
+	mapped, err := parseShowMapped(result.Stdout)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, m := range mapped {
+		if m.Pool != i.pool || m.Namespace != i.namespace || m.Name != i.image {
+			continue
+		}
+		if m.Snap != "" && m.Snap != "-" {
+			continue
+		}
+		return filepath.Base(m.Device), nil
+	}
+	return "", errors.NotFoundf("device for image %s", i.imageSpec())
+}
+
+// parseShowMapped parses the JSON output of "rbd showmapped". Ceph
+// releases before Nautilus output an object keyed by device id rather
+// than a list.
+func parseShowMapped(out []byte) ([]mappedImage, error) {
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+	var mapped []mappedImage
+	if err := json.Unmarshal(out, &mapped); err == nil {
+		return mapped, nil
+	}
+	var byID map[string]mappedImage
+	if err := json.Unmarshal(out, &byID); err != nil {
+		return nil, errors.Annotate(err, "cannot parse mapped images")
+	}
+	for _, m := range byID {
+		mapped = append(mapped, m)
+	}
+	return mapped, nil
+}
+
+func (i *rbdImageInfo) mapImage() (string, error) {
+	mapCmd := []string{"rbd", "map", i.imageSpec()}
+	if i.user != "" {
+		mapCmd = append(mapCmd, "--id", i.user)
+	}
+	if i.keyring != "" {
+		mapCmd = append(mapCmd, "--keyring", i.keyring)
+	}
+	if i.monitors != "" {
+		mapCmd = append(mapCmd, "-m", i.monitors)
+	}
+	result, err := common.CheckedRun(runCommand, mapCmd)
+	if err != nil {
+		return "", errors.Annotatef(err, "mapping image %s", i.imageSpec())
+	}
+	// rbd map prints the device the image was mapped to.
+	device := strings.TrimSpace(string(result.Stdout))
+	if device == "" {
+		return i.deviceName()
+	}
+	return filepath.Base(device), nil
+}
+
+func (i *rbdImageInfo) attach() (storage.BlockDevice, error) {
+	devName, err := i.deviceName()
+	if errors.IsNotFound(err) {
+		// Mappings don't survive a reboot, so this is the usual path
+		// when the machine agent restarts as well as when the volume
+		// is first attached.
+		devName, err = i.mapImage()
+	}
+	if err != nil {
+		return storage.BlockDevice{}, errors.Trace(err)
+	}
+	return common.BlockDeviceInfo(runCommand, devName)
+}
+
+func (i *rbdImageInfo) detach() error {
+	devName, err := i.deviceName()
+	if errors.IsNotFound(err) {
+		logger.Debugf("image %s is not mapped", i.imageSpec())
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	unmapCmd := []string{"rbd", "unmap", "/dev/" + devName}
+	if _, err := common.CheckedRun(runCommand, unmapCmd); err != nil {
+		return errors.Annotatef(err, "unmapping image %s", i.imageSpec())
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rbd_test
+
+import (
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage/plans/common"
+	"github.com/juju/juju/storage/plans/rbd"
+)
+
+const udevadmOutput = `
+DEVNAME=/dev/rbd0
+DEVTYPE=disk
+DEVLINKS=/dev/rbd/juju/volume-0
+`
+
+type rbdSuite struct {
+	testing.IsolationSuite
+
+	plan     common.Plan
+	commands []string
+	mapped   string
+	// mapResult is the result of running "rbd map".
+	mapResult *exec.ExecResponse
+}
+
+var _ = gc.Suite(&rbdSuite{})
+
+func (s *rbdSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.commands = nil
+	s.mapped = "[]"
+	s.mapResult = &exec.ExecResponse{Stdout: []byte("/dev/rbd0\n")}
+	s.PatchValue(rbd.RunCommand, s.runCommand)
+	s.plan = rbd.NewRBDPlan()
+}
+
+func (s *rbdSuite) runCommand(params []string) (*exec.ExecResponse, error) {
+	s.commands = append(s.commands, strings.Join(params, " "))
+	switch strings.Join(params[:2], " ") {
+	case "rbd showmapped":
+		return &exec.ExecResponse{Stdout: []byte(s.mapped)}, nil
+	case "rbd map":
+		return s.mapResult, nil
+	case "udevadm info":
+		return &exec.ExecResponse{Stdout: []byte(udevadmOutput)}, nil
+	}
+	return &exec.ExecResponse{}, nil
+}
+
+func (s *rbdSuite) volumeInfo() map[string]string {
+	return map[string]string{
+		"pool":  "juju",
+		"image": "volume-0",
+	}
+}
+
+func (s *rbdSuite) TestAttachVolume(c *gc.C) {
+	info := s.volumeInfo()
+	info["user"] = "juju"
+	info["keyring"] = "/etc/ceph/ceph.client.juju.keyring"
+	info["monitors"] = "10.0.0.1,10.0.0.2"
+	dev, err := s.plan.AttachVolume(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.DeviceName, gc.Equals, "rbd0")
+	c.Assert(dev.DeviceLinks, jc.DeepEquals, []string{"/dev/rbd/juju/volume-0"})
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"rbd showmapped --format json",
+		"rbd map juju/volume-0 --id juju --keyring /etc/ceph/ceph.client.juju.keyring -m 10.0.0.1,10.0.0.2",
+		"udevadm info -q property --path /block/rbd0",
+	})
+}
+
+func (s *rbdSuite) TestAttachVolumeNamespace(c *gc.C) {
+	info := s.volumeInfo()
+	info["namespace"] = "model-0"
+	_, err := s.plan.AttachVolume(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands[1], gc.Equals, "rbd map juju/model-0/volume-0")
+}
+
+func (s *rbdSuite) TestAttachVolumeAlreadyMapped(c *gc.C) {
+	s.mapped = `[
+{"id":"0","pool":"juju","namespace":"","name":"volume-0","snap":"snap-0","device":"/dev/rbd0"},
+{"id":"1","pool":"other","namespace":"","name":"volume-0","snap":"-","device":"/dev/rbd1"},
+{"id":"2","pool":"juju","namespace":"","name":"volume-0","snap":"-","device":"/dev/rbd2"}
+]`
+	dev, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.DeviceName, gc.Equals, "rbd2")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"rbd showmapped --format json",
+		"udevadm info -q property --path /block/rbd2",
+	})
+}
+
+func (s *rbdSuite) TestAttachVolumeAlreadyMappedLegacyFormat(c *gc.C) {
+	s.mapped = `{"3":{"pool":"juju","name":"volume-0","snap":"-","device":"/dev/rbd3"}}`
+	dev, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dev.DeviceName, gc.Equals, "rbd3")
+}
+
+func (s *rbdSuite) TestAttachVolumeMapFails(c *gc.C) {
+	s.mapResult = &exec.ExecResponse{Code: 2, Stderr: []byte("rbd: map failed: (2) No such file or directory\n")}
+	_, err := s.plan.AttachVolume(s.volumeInfo())
+	c.Assert(err, gc.ErrorMatches,
+		`mapping image juju/volume-0: rbd exited with code 2: rbd: map failed: \(2\) No such file or directory`)
+}
+
+func (s *rbdSuite) TestAttachVolumeMissingFields(c *gc.C) {
+	_, err := s.plan.AttachVolume(map[string]string{"image": "volume-0"})
+	c.Assert(err, gc.ErrorMatches, "missing required field: pool")
+	_, err = s.plan.AttachVolume(map[string]string{"pool": "juju"})
+	c.Assert(err, gc.ErrorMatches, "missing required field: image")
+	c.Assert(s.commands, gc.HasLen, 0)
+}
+
+func (s *rbdSuite) TestDetachVolume(c *gc.C) {
+	s.mapped = `[{"id":"0","pool":"juju","namespace":"","name":"volume-0","snap":"-","device":"/dev/rbd0"}]`
+	err := s.plan.DetachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"rbd showmapped --format json",
+		"rbd unmap /dev/rbd0",
+	})
+}
+
+func (s *rbdSuite) TestDetachVolumeNotMapped(c *gc.C) {
+	err := s.plan.DetachVolume(s.volumeInfo())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"rbd showmapped --format json",
+	})
+}
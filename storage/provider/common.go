@@ -14,6 +14,7 @@ var (
 
 	commonStorageProviders = map[storage.ProviderType]storage.Provider{
 		LoopProviderType:   &loopProvider{logAndExec},
+		LVMProviderType:    &lvmProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
 	}
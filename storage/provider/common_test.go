@@ -34,6 +34,7 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
 		provider.LoopProviderType,
+		provider.LVMProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
@@ -23,6 +23,7 @@ type FilesystemSource struct {
 	ValidateFilesystemParamsFunc func(storage.FilesystemParams) error
 	AttachFilesystemsFunc        func(context.ProviderCallContext, []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error)
 	DetachFilesystemsFunc        func(context.ProviderCallContext, []storage.FilesystemAttachmentParams) ([]error, error)
+	ResizeFilesystemsFunc        func(context.ProviderCallContext, []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error)
 }
 
 // CreateFilesystems is defined on storage.FilesystemSource.
@@ -78,3 +79,12 @@ func (s *FilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, pa
 	}
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
+
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *FilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, params []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	s.MethodCall(s, "ResizeFilesystems", ctx, params)
+	if s.ResizeFilesystemsFunc != nil {
+		return s.ResizeFilesystemsFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("ResizeFilesystems")
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/juju/juju/storage"
 )
 
-var _ storage.Provider = (*StorageProvider)(nil)
+var (
+	_ storage.Provider        = (*StorageProvider)(nil)
+	_ storage.ResizeSupporter = (*StorageProvider)(nil)
+)
 
 // StorageProvider is an implementation of storage.Provider, suitable for testing.
 // Each method's default behaviour may be overridden by setting the corresponding
@@ -29,6 +32,10 @@ type StorageProvider struct {
 	// supports releasing storage.
 	IsReleasable bool
 
+	// IsResizable defines whether or not the provider reports that it
+	// supports resizing storage.
+	IsResizable bool
+
 	// DefaultPools_ will be returned by DefaultPools.
 	DefaultPools_ []*storage.Config
 
@@ -85,6 +92,12 @@ func (p *StorageProvider) Supports(kind storage.StorageKind) bool {
 	return true
 }
 
+// SupportsResize is defined on storage.ResizeSupporter.
+func (p *StorageProvider) SupportsResize(kind storage.StorageKind) bool {
+	p.MethodCall(p, "SupportsResize", kind)
+	return p.IsResizable
+}
+
 // Scope is defined on storage.Provider.
 func (p *StorageProvider) Scope() storage.Scope {
 	p.MethodCall(p, "Scope")
@@ -17,14 +17,17 @@ import (
 type VolumeSource struct {
 	testing.Stub
 
-	CreateVolumesFunc        func(context.ProviderCallContext, []storage.VolumeParams) ([]storage.CreateVolumesResult, error)
-	ListVolumesFunc          func(context.ProviderCallContext) ([]string, error)
-	DescribeVolumesFunc      func(context.ProviderCallContext, []string) ([]storage.DescribeVolumesResult, error)
-	DestroyVolumesFunc       func(context.ProviderCallContext, []string) ([]error, error)
-	ReleaseVolumesFunc       func(context.ProviderCallContext, []string) ([]error, error)
-	ValidateVolumeParamsFunc func(storage.VolumeParams) error
-	AttachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
-	DetachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]error, error)
+	CreateVolumesFunc          func(context.ProviderCallContext, []storage.VolumeParams) ([]storage.CreateVolumesResult, error)
+	ListVolumesFunc            func(context.ProviderCallContext) ([]string, error)
+	DescribeVolumesFunc        func(context.ProviderCallContext, []string) ([]storage.DescribeVolumesResult, error)
+	DestroyVolumesFunc         func(context.ProviderCallContext, []string) ([]error, error)
+	ReleaseVolumesFunc         func(context.ProviderCallContext, []string) ([]error, error)
+	ValidateVolumeParamsFunc   func(storage.VolumeParams) error
+	AttachVolumesFunc          func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
+	DetachVolumesFunc          func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]error, error)
+	ResizeVolumesFunc          func(context.ProviderCallContext, []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	CreateVolumeSnapshotsFunc  func(context.ProviderCallContext, []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	DestroyVolumeSnapshotsFunc func(context.ProviderCallContext, []string) ([]error, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -98,3 +101,30 @@ func (s *VolumeSource) DetachVolumes(ctx context.ProviderCallContext, params []s
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", ctx, params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("ResizeVolumes")
+}
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", ctx, params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumeSnapshots")
+}
+
+// DestroyVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) DestroyVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DestroyVolumeSnapshots", ctx, snapshotIds)
+	if s.DestroyVolumeSnapshotsFunc != nil {
+		return s.DestroyVolumeSnapshotsFunc(ctx, snapshotIds)
+	}
+	return nil, errors.NotImplementedf("DestroyVolumeSnapshots")
+}
@@ -75,6 +75,11 @@ func (*loopProvider) Supports(k storage.StorageKind) bool {
 	return k == storage.StorageKindBlock
 }
 
+// SupportsResize is defined on the storage.ResizeSupporter interface.
+func (*loopProvider) SupportsResize(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
 // Scope is defined on the Provider interface.
 func (*loopProvider) Scope() storage.Scope {
 	return storage.ScopeMachine
@@ -104,6 +109,8 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeResizer = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -124,6 +131,17 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		snapshotFilePath, err := lvs.snapshotFilePath(params.SnapshotId)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "could not restore snapshot")
+		}
+	}
+	// If the volume was restored from a snapshot, this extends the
+	// block file to the requested size.
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -248,6 +266,125 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		if err := lvs.resizeVolume(arg); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.Tag.Id())
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: arg.VolumeId,
+			Size:     arg.Size,
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) error {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	// fallocate extends the file if the requested length is greater
+	// than its current size, and leaves the existing data alone.
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return errors.Annotate(err, "could not extend block file")
+	}
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		// The loop device's size is fixed when it is attached,
+		// so have it reread the size of the backing file.
+		if _, err := lvs.run("losetup", "-c", path.Join("/dev", deviceName)); err != nil {
+			return errors.Annotatef(err, "updating capacity of loop device %q", deviceName)
+		}
+	}
+	return nil
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		info, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeSnapshotInfo = info
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshotInfo, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	fi, err := os.Stat(loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting size of loop backing file")
+	}
+	snapshotId := "snapshot-" + strings.Replace(arg.Id, "/", "-", -1)
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The copy is not synchronised with writes to the loop device,
+	// so the snapshot is only consistent if the charm has quiesced
+	// its writes to the volume.
+	if err := copyBlockFile(lvs.run, loopFilePath, snapshotFilePath); err != nil {
+		return nil, errors.Annotate(err, "could not copy block file")
+	}
+	return &storage.VolumeSnapshotInfo{
+		SnapshotId: snapshotId,
+		Size:       uint64(fi.Size()) / (1024 * 1024),
+	}, nil
+}
+
+// DestroyVolumeSnapshots is defined on the VolumeSnapshotter interface.
+func (lvs *loopVolumeSource) DestroyVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := lvs.destroyVolumeSnapshot(snapshotId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying snapshot %q", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) destroyVolumeSnapshot(snapshotId string) error {
+	snapshotFilePath, err := lvs.snapshotFilePath(snapshotId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(snapshotFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "removing snapshot file")
+	}
+	return nil
+}
+
+// snapshotFilePath returns the path of the file holding the snapshot
+// with the specified ID, which is validated to prevent it referring to
+// a file outside of the snapshots directory.
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) (string, error) {
+	if !strings.HasPrefix(snapshotId, "snapshot-") || filepath.Base(snapshotId) != snapshotId {
+		return "", errors.Errorf("invalid loop snapshot ID %q", snapshotId)
+	}
+	return filepath.Join(lvs.storageDir, "snapshots", snapshotId), nil
+}
+
+// copyBlockFile copies the block file at the source path to the
+// destination path, preserving its sparseness.
+func copyBlockFile(run runCommandFunc, source, dest string) error {
+	if _, err := run("cp", "--sparse=always", source, dest); err != nil {
+		return errors.Annotatef(err, "copying %q to %q", source, dest)
+	}
+	return nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -277,3 +277,124 @@ func (s *loopSuite) TestDetachVolumesDetachFails(c *gc.C) {
 	_, err = os.Stat(fileName)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	c.Assert(source, gc.Implements, new(storage.VolumeResizer))
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("fallocate", "-l", "4096MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4096,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "volume-0",
+		Size:     4096,
+	})
+}
+
+func (s *loopSuite) TestResizeVolumesExtendFails(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	cmd := s.commands.expect("fallocate", "-l", "4096MiB", fileName)
+	cmd.respond("", errors.New("No space left on device"))
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     4096,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`resizing volume 0: could not extend block file: allocating loop backing file ".*": No space left on device`)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	c.Assert(source, gc.Implements, new(storage.VolumeSnapshotter))
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	err := ioutil.WriteFile(fileName, make([]byte, 2*1024*1024), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotFileName := filepath.Join(s.storageDir, "snapshots", "snapshot-0-3")
+	s.commands.expect("cp", "--sparse=always", fileName, snapshotFileName)
+
+	results, err := source.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "0/3",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshotInfo, jc.DeepEquals, &storage.VolumeSnapshotInfo{
+		SnapshotId: "snapshot-0-3",
+		Size:       2,
+	})
+	c.Assert(dirFuncs.Dirs.Contains(filepath.Join(s.storageDir, "snapshots")), jc.IsTrue)
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshotsNoVolume(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	results, err := source.(storage.VolumeSnapshotter).CreateVolumeSnapshots(s.callCtx, []storage.VolumeSnapshotParams{{
+		Id:       "3",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating snapshot of volume 0: getting size of loop backing file: .*`)
+}
+
+func (s *loopSuite) TestDestroyVolumeSnapshots(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	err := os.MkdirAll(filepath.Join(s.storageDir, "snapshots"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(s.storageDir, "snapshots", "snapshot-3")
+	err = ioutil.WriteFile(fileName, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.(storage.VolumeSnapshotter).DestroyVolumeSnapshots(s.callCtx, []string{
+		"snapshot-3", "snapshot-4", "../volume-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches, `destroying snapshot "../volume-0": invalid loop snapshot ID "../volume-0"`)
+
+	_, err = os.Stat(fileName)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-1")
+	snapshotFileName := filepath.Join(s.storageDir, "snapshots", "snapshot-3")
+	s.commands.expect("cp", "--sparse=always", snapshotFileName, fileName)
+	s.commands.expect("fallocate", "-l", "4MiB", fileName)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("1"),
+		Size:       4,
+		SnapshotId: "snapshot-3",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("1"),
+		storage.VolumeInfo{
+			VolumeId: "volume-1",
+			Size:     4,
+		},
+	})
+}
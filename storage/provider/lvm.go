@@ -0,0 +1,370 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// LVMProviderType is the provider type for LVM logical volumes.
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the name of the storage pool attribute naming
+	// the volume group that logical volumes are created in.
+	LVMVolumeGroup = "volume-group"
+
+	// lvmVolumePrefix is prefixed to the names of logical volumes
+	// created by Juju, so they can be told apart from any others in
+	// the volume group.
+	lvmVolumePrefix = "juju-"
+)
+
+// lvmNameRegexp matches valid LVM volume group names.
+var lvmNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9+_.][a-zA-Z0-9+_.-]*$`)
+
+// lvmProvider creates volume sources which carve logical volumes out
+// of an existing LVM volume group.
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*lvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	volumeGroup, ok := cfg.ValueString(LVMVolumeGroup)
+	if !ok || volumeGroup == "" {
+		return errors.Errorf("%s not specified", LVMVolumeGroup)
+	}
+	if !lvmNameRegexp.MatchString(volumeGroup) {
+		return errors.NotValidf("%s %q", LVMVolumeGroup, volumeGroup)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	if err := p.ValidateConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// volumeGroup is validated by ValidateConfig.
+	volumeGroup, _ := sourceConfig.ValueString(LVMVolumeGroup)
+	return &lvmVolumeSource{p.run, volumeGroup}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *lvmProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	// Filesystems are created on logical volumes by the managed
+	// filesystem source.
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// SupportsResize is defined on the storage.ResizeSupporter interface.
+func (*lvmProvider) SupportsResize(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*lvmProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*lvmProvider) DefaultPools() []*storage.Config {
+	// There is no volume group we can assume exists.
+	return nil
+}
+
+// lvmVolumeSource creates, activates and removes logical volumes in
+// a single volume group.
+type lvmVolumeSource struct {
+	run         runCommandFunc
+	volumeGroup string
+}
+
+var _ storage.VolumeSource = (*lvmVolumeSource)(nil)
+var _ storage.VolumeResizer = (*lvmVolumeSource)(nil)
+
+// logicalVolumeName returns the name of the logical volume for the
+// volume with the specified tag.
+func logicalVolumeName(tag names.VolumeTag) string {
+	return lvmVolumePrefix + tag.String()
+}
+
+// logicalVolumePath returns the volume group qualified name of the
+// logical volume, as accepted by the LVM commands.
+func (s *lvmVolumeSource) logicalVolumePath(volumeId string) string {
+	return s.volumeGroup + "/" + volumeId
+}
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	volumeId := logicalVolumeName(params.Tag)
+	size, err := s.logicalVolumeSize(volumeId)
+	if errors.IsNotFound(err) {
+		// --wipesignatures stops a filesystem left behind by a
+		// previous logical volume in the same extents from being
+		// mistaken for an existing filesystem on this one.
+		_, err = s.run(
+			"lvcreate",
+			"--yes",
+			"--wipesignatures", "y",
+			"--size", fmt.Sprintf("%dm", params.Size),
+			"--name", volumeId,
+			s.volumeGroup,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(err, "creating logical volume %q", volumeId)
+		}
+		// The size is rounded up to a whole number of extents.
+		size, err = s.logicalVolumeSize(volumeId)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     size,
+		},
+	}, nil
+}
+
+// logicalVolumeSize returns the size of the logical volume, in MiB.
+// If the logical volume does not exist, an error satisfying
+// errors.IsNotFound is returned.
+func (s *lvmVolumeSource) logicalVolumeSize(volumeId string) (uint64, error) {
+	stdout, err := s.run(
+		"lvs",
+		"--noheadings",
+		"--nosuffix",
+		"--units", "m",
+		"--options", "lv_size",
+		s.logicalVolumePath(volumeId),
+	)
+	if isLogicalVolumeNotFound(err) {
+		return 0, errors.NotFoundf("logical volume %q", volumeId)
+	} else if err != nil {
+		return 0, errors.Annotatef(err, "getting size of logical volume %q", volumeId)
+	}
+	// The output may be preceded by warnings, so take the last field.
+	fields := strings.Fields(stdout)
+	if len(fields) == 0 {
+		return 0, errors.Errorf("unexpected output %q", stdout)
+	}
+	size, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+	if err != nil {
+		return 0, errors.Errorf("unexpected output %q", stdout)
+	}
+	return uint64(math.Ceil(size)), nil
+}
+
+// isLogicalVolumeNotFound reports whether the error returned by an
+// LVM command is due to the logical volume not existing.
+func isLogicalVolumeNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Failed to find logical volume")
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	stdout, err := s.run(
+		"lvs",
+		"--noheadings",
+		"--options", "lv_name",
+		s.volumeGroup,
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing logical volumes in %q", s.volumeGroup)
+	}
+	var volumeIds []string
+	for _, name := range strings.Fields(stdout) {
+		if strings.HasPrefix(name, lvmVolumePrefix) {
+			volumeIds = append(volumeIds, name)
+		}
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		size, err := s.logicalVolumeSize(volumeId)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     size,
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) destroyVolume(volumeId string) error {
+	if !strings.HasPrefix(volumeId, lvmVolumePrefix) {
+		return errors.Errorf("invalid logical volume ID %q", volumeId)
+	}
+	_, err := s.run("lvremove", "--force", s.logicalVolumePath(volumeId))
+	if isLogicalVolumeNotFound(err) {
+		logger.Debugf("logical volume %q already removed", volumeId)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "removing logical volume")
+	}
+	return nil
+}
+
+// ReleaseVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	return make([]error, len(volumeIds)), nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the free space in the volume group until CreateVolumes.
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) AttachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if arg.ReadOnly {
+		return nil, errors.NotSupportedf("read-only logical volumes")
+	}
+	lvPath := s.logicalVolumePath(arg.VolumeId)
+	// Activating an active logical volume is a no-op.
+	if _, err := s.run("lvchange", "--activate", "y", lvPath); err != nil {
+		return nil, errors.Annotatef(err, "activating logical volume %q", arg.VolumeId)
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			// udev maintains a /dev/<vg>/<lv> link to the device
+			// mapper device of each active logical volume.
+			DeviceLink: "/dev/" + lvPath,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DetachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		_, err := s.run("lvchange", "--activate", "n", s.logicalVolumePath(arg.VolumeId))
+		if err != nil && !isLogicalVolumeNotFound(err) {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (s *lvmVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.Tag.Id())
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: arg.VolumeId,
+			Size:     size,
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (uint64, error) {
+	size, err := s.logicalVolumeSize(arg.VolumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if size >= arg.Size {
+		// lvextend fails if the logical volume is already at least
+		// the requested size, which it will be if we were interrupted
+		// after extending it previously.
+		return size, nil
+	}
+	_, err = s.run(
+		"lvextend",
+		"--size", fmt.Sprintf("%dm", arg.Size),
+		s.logicalVolumePath(arg.VolumeId),
+	)
+	if err != nil {
+		return 0, errors.Annotatef(err, "extending logical volume %q", arg.VolumeId)
+	}
+	// The size is rounded up to a whole number of extents.
+	size, err = s.logicalVolumeSize(arg.VolumeId)
+	return size, errors.Trace(err)
+}
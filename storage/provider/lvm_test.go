@@ -0,0 +1,301 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *lvmSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.commands = &mockRunCommand{c: c}
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) lvmProvider() storage.Provider {
+	return provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) lvmVolumeSource(c *gc.C) storage.VolumeSource {
+	cfg, err := storage.NewConfig("lvm-pool", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "vg0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := s.lvmProvider().VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+var errLVNotFound = errors.New(`Failed to find logical volume "vg0/juju-volume-0"`)
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	p := s.lvmProvider()
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, "volume-group not specified")
+
+	cfg, err = storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "-vg0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `volume-group "-vg0" not valid`)
+
+	cfg, err = storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "vg0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestVolumeSourceInvalidConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.lvmProvider().VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "volume-group not specified")
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	p := s.lvmProvider()
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	c.Assert(s.lvmProvider().Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *lvmSuite) TestDynamic(c *gc.C) {
+	c.Assert(s.lvmProvider().Dynamic(), jc.IsTrue)
+}
+
+func (s *lvmSuite) TestCreateVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("", errLVNotFound)
+	s.commands.expect("lvcreate", "--yes", "--wipesignatures", "y", "--size", "3m", "--name", "juju-volume-0", "vg0")
+	cmd = s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  4.00\n", nil)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 3,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "juju-volume-0",
+			Size:     4,
+		},
+	})
+}
+
+func (s *lvmSuite) TestCreateVolumesAlreadyExists(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  WARNING: some warning\n  4.00\n", nil)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 3,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.Size, gc.Equals, uint64(4))
+}
+
+func (s *lvmSuite) TestCreateVolumesError(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("", errLVNotFound)
+	cmd = s.commands.expect("lvcreate", "--yes", "--wipesignatures", "y", "--size", "3m", "--name", "juju-volume-0", "vg0")
+	cmd.respond("", errors.New("Volume group \"vg0\" has insufficient free space"))
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 3,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`creating volume: creating logical volume "juju-volume-0": Volume group "vg0" has insufficient free space`)
+}
+
+func (s *lvmSuite) TestListVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--options", "lv_name", "vg0")
+	cmd.respond("  juju-volume-0\n  root\n  juju-volume-1\n", nil)
+
+	volumeIds, err := source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"juju-volume-0", "juju-volume-1"})
+}
+
+func (s *lvmSuite) TestDescribeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  1024.00\n", nil)
+	cmd = s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-1")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/juju-volume-1"`))
+
+	results, err := source.DescribeVolumes(s.callCtx, []string{"juju-volume-0", "juju-volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId: "juju-volume-0",
+		Size:     1024,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvremove", "--force", "vg0/juju-volume-0")
+	cmd := s.commands.expect("lvremove", "--force", "vg0/juju-volume-1")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/juju-volume-1"`))
+	cmd = s.commands.expect("lvremove", "--force", "vg0/juju-volume-2")
+	cmd.respond("", errors.New(`Logical volume vg0/juju-volume-2 contains a filesystem in use.`))
+
+	errs, err := source.DestroyVolumes(s.callCtx, []string{
+		"juju-volume-0", "juju-volume-1", "juju-volume-2", "root",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 4)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], jc.ErrorIsNil)
+	c.Assert(errs[2], gc.ErrorMatches,
+		`destroying "juju-volume-2": removing logical volume: Logical volume vg0/juju-volume-2 contains a filesystem in use.`)
+	c.Assert(errs[3], gc.ErrorMatches, `destroying "root": invalid logical volume ID "root"`)
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvchange", "--activate", "y", "vg0/juju-volume-0")
+
+	results, err := source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		names.NewVolumeTag("0"),
+		names.NewMachineTag("0"),
+		storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/vg0/juju-volume-0",
+		},
+	})
+}
+
+func (s *lvmSuite) TestAttachVolumesReadOnly(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	results, err := source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "attaching volume 0: read-only logical volumes not supported")
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvchange", "--activate", "n", "vg0/juju-volume-0")
+	cmd := s.commands.expect("lvchange", "--activate", "n", "vg0/juju-volume-1")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/juju-volume-1"`))
+
+	errs, err := source.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-volume-0",
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "juju-volume-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+}
+
+func (s *lvmSuite) TestResizeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	c.Assert(source, gc.Implements, new(storage.VolumeResizer))
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  1024.00\n", nil)
+	s.commands.expect("lvextend", "--size", "2047m", "vg0/juju-volume-0")
+	cmd = s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  2048.00\n", nil)
+	cmd = s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-1")
+	cmd.respond("  2048.00\n", nil)
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "juju-volume-0",
+		Size:     2047,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "juju-volume-1",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		VolumeInfo: &storage.VolumeInfo{VolumeId: "juju-volume-0", Size: 2048},
+	}, {
+		VolumeInfo: &storage.VolumeInfo{VolumeId: "juju-volume-1", Size: 2048},
+	}})
+}
+
+func (s *lvmSuite) TestResizeVolumesError(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "--options", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  1024.00\n", nil)
+	cmd = s.commands.expect("lvextend", "--size", "2048m", "vg0/juju-volume-0")
+	cmd.respond("", errors.New(`Insufficient free space: 256 extents needed, but only 10 available`))
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes(s.callCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "juju-volume-0",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`resizing volume 0: extending logical volume "juju-volume-0": Insufficient free space: .*`)
+}
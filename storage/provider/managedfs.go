@@ -36,6 +36,8 @@ type managedFilesystemSource struct {
 	filesystems        map[names.FilesystemTag]storage.Filesystem
 }
 
+var _ storage.FilesystemResizer = (*managedFilesystemSource)(nil)
+
 // NewManagedFilesystemSource returns a storage.FilesystemSource that manages
 // filesystems on block devices on the host machine.
 //
@@ -178,6 +180,49 @@ func (s *managedFilesystemSource) DetachFilesystems(ctx context.ProviderCallCont
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		info, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemInfo = info
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (*storage.FilesystemInfo, error) {
+	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if blockDevice.Size < arg.Size {
+		// The filesystem can only grow to fill the block device,
+		// and the block device's size is updated asynchronously
+		// after its volume is resized.
+		return nil, errors.Errorf(
+			"backing-volume %s is not yet resized", arg.Volume.Id(),
+		)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return nil, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.FilesystemInfo{
+		arg.FilesystemId,
+		blockDevice.Size,
+	}, nil
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -207,6 +252,33 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
+// growPartition grows the partition (1) created by createPartition
+// to fill the disk with the specified device path.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	// growpart informs the kernel of the new partition size even
+	// when the partition is in use, which sgdisk does not.
+	if _, err := run("growpart", devicePath, "1"); err != nil {
+		if strings.Contains(err.Error(), "NOCHANGE") {
+			// The partition already fills the disk.
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the device with the
+// specified path to fill the device. The filesystem may be mounted.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to grow filesystem on %q", devicePath)
+	if _, err := run("resize2fs", devicePath); err != nil {
+		return errors.Annotate(err, "resize2fs failed")
+	}
+	logger.Infof("grew filesystem on %q", devicePath)
+	return nil
+}
+
 func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -234,3 +235,62 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, s.callCtx, false, s.fakeEtcDir, "")
 }
+
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	c.Assert(source, gc.Implements, new(storage.FilesystemResizer))
+	// sda's partition is grown before the filesystem on it.
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("", errors.New("NOCHANGE: partition 1 could only be grown by 0"))
+	s.commands.expect("resize2fs", "/dev/sda1")
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}, {
+		Tag:          names.NewFilesystemTag("0/1"),
+		Volume:       names.NewVolumeTag("1"),
+		FilesystemId: "filesystem-0-1",
+		Size:         5,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{
+		FilesystemInfo: &storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Size:         4,
+		},
+	}, {
+		FilesystemInfo: &storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-1",
+			Size:         6,
+		},
+	}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsVolumeNotResized(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       2,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems(s.callCtx, []storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet resized")
+}
@@ -8,8 +8,10 @@ import "gopkg.in/juju/names.v3"
 type DeviceType string
 
 var (
-	DeviceTypeLocal DeviceType = "local"
-	DeviceTypeISCSI DeviceType = "iscsi"
+	DeviceTypeLocal   DeviceType = "local"
+	DeviceTypeISCSI   DeviceType = "iscsi"
+	DeviceTypeNVMeTCP DeviceType = "nvme-tcp"
+	DeviceTypeRBD     DeviceType = "rbd"
 )
 
 // Volume identifies and describes a volume (disk, logical volume, etc.)
@@ -43,6 +45,17 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshotInfo describes a point-in-time snapshot of a volume.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the volume at the time the snapshot was
+	// taken, in MiB. Volumes created from the snapshot must be at
+	// least this large.
+	Size uint64
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
@@ -76,6 +89,10 @@ type VolumeAttachmentPlanInfo struct {
 	// * local - a block device that is directly attached to this instance
 	// * iscsi - an iSCSI disk. This type of disk will require the machine agent
 	// to execute additional steps before the device is available
+	// * nvme-tcp - an NVMe namespace exported over NVMe/TCP. The machine agent
+	// connects to the subsystem before the device is available
+	// * rbd - a Ceph RBD image, which the machine agent maps with the kernel
+	// rbd driver
 	DeviceType DeviceType
 	// DeviceAttributes is a map that contains DeviceType specific initialization
 	// values. For example, in the case of iscsi, it may contain server address:port,
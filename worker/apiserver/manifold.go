@@ -45,6 +45,7 @@ type ManifoldConfig struct {
 	RaftTransportName      string
 
 	PrometheusRegisterer              prometheus.Registerer
+	CharmMetricsCollector             *apiserver.CharmMetricsCollector
 	RegisterIntrospectionHTTPHandlers func(func(path string, _ http.Handler))
 	Hub                               *pubsub.StructuredHub
 	Presence                          presence.Recorder
@@ -94,6 +95,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.PrometheusRegisterer == nil {
 		return errors.NotValidf("nil PrometheusRegisterer")
 	}
+	if config.CharmMetricsCollector == nil {
+		return errors.NotValidf("nil CharmMetricsCollector")
+	}
 	if config.RegisterIntrospectionHTTPHandlers == nil {
 		return errors.NotValidf("nil RegisterIntrospectionHTTPHandlers")
 	}
@@ -216,6 +220,13 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	// Serve the metrics published by charms from the collector that
+	// is registered for the lifetime of the agent.
+	charmMetricsWorker := apiserver.NewCharmMetricsWorker(
+		statePool, clock, apiserver.DefaultCharmMetricsRefreshInterval,
+	)
+	config.CharmMetricsCollector.SetSource(charmMetricsWorker)
+
 	w, err := config.NewWorker(Config{
 		AgentConfig:                       agent.CurrentConfig(),
 		Clock:                             clock,
@@ -235,16 +246,25 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		MetricsCollector:                  metricsCollector,
 	})
 	if err != nil {
+		config.CharmMetricsCollector.SetSource(nil)
+		_ = worker.Stop(charmMetricsWorker)
 		stTracker.Done()
 		return nil, errors.Trace(err)
 	}
 	mux.AddClient()
 	return common.NewCleanupWorker(w, func() {
 		mux.ClientDone()
+		// The charm metrics worker reads from the state pool, so
+		// it must be detached and stopped before the pool is
+		// released.
+		config.CharmMetricsCollector.SetSource(nil)
+		_ = worker.Stop(charmMetricsWorker)
 		stTracker.Done()
 
 		// clean up the metrics for the worker, so the next time a worker is
 		// created we can safely register the metrics again.
-		config.PrometheusRegisterer.Unregister(metricsCollector)
+		if !config.PrometheusRegisterer.Unregister(metricsCollector) {
+			logger.Warningf("apiserver metrics collector was not registered")
+		}
 	}), nil
 }
@@ -96,6 +96,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		LeaseManagerName:                  "lease-manager",
 		RaftTransportName:                 "raft-transport",
 		PrometheusRegisterer:              &s.prometheusRegisterer,
+		CharmMetricsCollector:             coreapiserver.NewCharmMetricsCollector(),
 		RegisterIntrospectionHTTPHandlers: func(func(string, http.Handler)) {},
 		Hub:                               &s.hub,
 		Presence:                          presence.New(s.clock),
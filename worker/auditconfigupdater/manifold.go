@@ -4,6 +4,11 @@
 package auditconfigupdater
 
 import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -14,6 +19,11 @@ import (
 	workerstate "github.com/juju/juju/worker/state"
 )
 
+// webhookTimeout limits how long a request posting audit records to
+// the webhook may take, so an endpoint that accepts the connection but
+// never responds doesn't hold up the sink, or closing it, forever.
+const webhookTimeout = 30 * time.Second
+
 // ManifoldConfig holds the information needed to run an
 // auditconfigupdater in a dependency.Engine.
 type ManifoldConfig struct {
@@ -77,15 +87,18 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 
 	st := statePool.SystemState()
 
-	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+	logFactory := func(cfg auditlog.Config) (auditlog.AuditLog, error) {
+		return newAuditLog(cfg, logDir)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	if auditConfig.Enabled {
-		auditConfig.Target = logFactory(auditConfig)
+		auditConfig.Target, err = logFactory(auditConfig)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	w, err := config.NewWorker(st, auditConfig, logFactory)
@@ -126,6 +139,49 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Sinks:          cfg.AuditLogSinks(),
+		Syslog:         cfg.AuditLogSyslog(),
+		Webhook:        cfg.AuditLogWebhook(),
 	}
 	return result, nil
 }
+
+// newAuditLog returns an audit log that feeds all of the sinks
+// selected in the config. The remote sinks connect in the background,
+// so an error here means a sink is misconfigured.
+func newAuditLog(cfg auditlog.Config, logDir string) (auditlog.AuditLog, error) {
+	var sinks []auditlog.AuditLog
+	closeSinks := func() {
+		for _, sink := range sinks {
+			_ = sink.Close()
+		}
+	}
+	if cfg.Sinks.Contains(auditlog.FileSink) {
+		sinks = append(sinks, auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups))
+	}
+	if cfg.Sinks.Contains(auditlog.SyslogSink) {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.Warningf("unable to determine hostname for audit syslog sink: %v", err)
+		}
+		sink, err := auditlog.NewSyslogSink(cfg.Syslog, hostname, clock.WallClock)
+		if err != nil {
+			closeSinks()
+			return nil, errors.Annotate(err, "opening audit syslog sink")
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.Sinks.Contains(auditlog.WebhookSink) {
+		client := &http.Client{Timeout: webhookTimeout}
+		sink, err := auditlog.NewWebhookSink(cfg.Webhook, client, clock.WallClock)
+		if err != nil {
+			closeSinks()
+			return nil, errors.Annotate(err, "opening audit webhook sink")
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 1 {
+		return sinks[0], nil
+	}
+	return auditlog.NewMultiLog(sinks...), nil
+}
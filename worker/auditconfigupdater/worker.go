@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
 
@@ -15,6 +16,8 @@ import (
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
 // ConfigSource lets us get notifications of changes to controller
 // configuration, and then get the changed config. (Primary
 // implementation is State.)
@@ -25,10 +28,16 @@ type ConfigSource interface {
 
 // AuditLogFactory is a function that will return an audit log given
 // config.
-type AuditLogFactory func(auditlog.Config) auditlog.AuditLog
+type AuditLogFactory func(auditlog.Config) (auditlog.AuditLog, error)
 
 // New returns a worker that will keep an up-to-date audit log config.
+// The target in the config it reports stays the same when the sinks
+// are reconfigured, so that connections already being recorded write
+// to the new sinks.
 func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactory) (worker.Worker, error) {
+	if initial.Target != nil {
+		initial.Target = &swappableLog{target: initial.Target}
+	}
 	u := &updater{
 		source:     source,
 		current:    initial,
@@ -95,10 +104,33 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Sinks:          cfg.AuditLogSinks(),
+		Syslog:         cfg.AuditLogSyslog(),
+		Webhook:        cfg.AuditLogWebhook(),
 	}
-	if result.Enabled && u.current.Target == nil {
-		result.Target = u.logFactory(result)
-	} else {
+	switch {
+	case result.Enabled && u.current.Target == nil:
+		target, err := u.logFactory(result)
+		if err != nil {
+			return auditlog.Config{}, errors.Trace(err)
+		}
+		result.Target = &swappableLog{target: target}
+	case result.Enabled && !sinksEqual(result, u.current):
+		// The sinks have been reconfigured, so switch the existing
+		// target over to the new sinks; connections that are
+		// already being recorded hold the same target.
+		target, err := u.logFactory(result)
+		if err != nil {
+			logger.Errorf("cannot reconfigure audit log sinks, keeping the previous sinks: %v", err)
+			result.Sinks = u.current.Sinks
+			result.Syslog = u.current.Syslog
+			result.Webhook = u.current.Webhook
+			result.Target = u.current.Target
+			break
+		}
+		result.Target = u.current.Target
+		result.Target.(*swappableLog).swap(target)
+	default:
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
 		// because enabled is false.
@@ -107,6 +139,63 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 	return result, nil
 }
 
+// swappableLog is an AuditLog that forwards records to a target that
+// can be replaced while connections are writing to it.
+type swappableLog struct {
+	mu     sync.RWMutex
+	target auditlog.AuditLog
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (l *swappableLog) AddConversation(c auditlog.Conversation) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.AddConversation(c)
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (l *swappableLog) AddRequest(r auditlog.Request) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.AddRequest(r)
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (l *swappableLog) AddResponse(r auditlog.ResponseErrors) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.AddResponse(r)
+}
+
+// Close implements auditlog.AuditLog.
+func (l *swappableLog) Close() error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.Close()
+}
+
+// swap replaces the target. Taking the write lock waits for writes
+// already in flight to the old target to finish, so it's only closed
+// once nothing is using it.
+func (l *swappableLog) swap(target auditlog.AuditLog) {
+	l.mu.Lock()
+	old := l.target
+	l.target = target
+	l.mu.Unlock()
+	if err := old.Close(); err != nil {
+		logger.Warningf("closing previous audit log target: %v", err)
+	}
+}
+
+// sinksEqual returns whether the two configs would produce the same
+// audit log sinks.
+func sinksEqual(a, b auditlog.Config) bool {
+	return a.Sinks.Difference(b.Sinks).IsEmpty() &&
+		b.Sinks.Difference(a.Sinks).IsEmpty() &&
+		a.Syslog == b.Syslog &&
+		a.Webhook == b.Webhook
+}
+
 func (u *updater) update(newConfig auditlog.Config) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -43,9 +44,9 @@ func (s *updaterSuite) TestWorker(c *gc.C) {
 
 	fakeTarget := apitesting.FakeAuditLog{}
 	var calls []auditlog.Config
-	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+	factory := func(cfg auditlog.Config) (auditlog.AuditLog, error) {
 		calls = append(calls, cfg)
-		return &fakeTarget
+		return &fakeTarget, nil
 	}
 
 	w, err := auditconfigupdater.New(&source, initial, factory)
@@ -62,8 +63,10 @@ func (s *updaterSuite) TestWorker(c *gc.C) {
 	c.Assert(newConfig.Enabled, gc.Equals, true)
 	c.Assert(newConfig.CaptureAPIArgs, gc.Equals, false)
 	c.Assert(newConfig.ExcludeMethods, gc.DeepEquals, set.NewStrings())
-	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(&fakeTarget))
 	c.Assert(calls, gc.HasLen, 1)
+	err = newConfig.Target.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+	fakeTarget.CheckCallNames(c, "AddConversation")
 }
 
 func waitForConfig(c *gc.C, w worker.Worker, predicate func(auditlog.Config) bool) auditlog.Config {
@@ -79,10 +82,10 @@ func waitForConfig(c *gc.C, w worker.Worker, predicate func(auditlog.Config) boo
 
 func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultSinks(auditlog.Config{
 		Enabled: true,
 		Target:  &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false),
@@ -93,6 +96,7 @@ func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	w, err := auditconfigupdater.New(&source, initial, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	defer workertest.CleanKill(c, w)
+	initialTarget := getWorkerConfig(c, w).Target
 
 	source.setConfig(makeControllerConfig(false, false))
 	configChanged <- ding
@@ -102,15 +106,15 @@ func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	})
 
 	c.Assert(newConfig.Enabled, gc.Equals, false)
-	c.Assert(newConfig.Target, gc.Equals, initial.Target)
+	c.Assert(newConfig.Target, gc.Equals, initialTarget)
 }
 
 func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultSinks(auditlog.Config{
 		Enabled: false,
 		Target:  &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(false, false),
@@ -121,6 +125,7 @@ func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	w, err := auditconfigupdater.New(&source, initial, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	defer workertest.CleanKill(c, w)
+	initialTarget := getWorkerConfig(c, w).Target
 
 	source.setConfig(makeControllerConfig(true, false))
 	configChanged <- ding
@@ -130,16 +135,16 @@ func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	})
 
 	c.Assert(newConfig.Enabled, gc.Equals, true)
-	c.Assert(newConfig.Target, gc.Equals, initial.Target)
+	c.Assert(newConfig.Target, gc.Equals, initialTarget)
 }
 
 func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultSinks(auditlog.Config{
 		Enabled:        true,
 		ExcludeMethods: set.NewStrings("Pink.Floyd"),
 		Target:         &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false, "Pink.Floyd"),
@@ -166,11 +171,11 @@ func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
 
 func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultSinks(auditlog.Config{
 		Enabled:        true,
 		CaptureAPIArgs: false,
 		Target:         &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false, "Pink.Floyd"),
@@ -188,6 +193,98 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestReplacesTargetWhenSinksChange(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := apitesting.FakeAuditLog{}
+	initial := withDefaultSinks(auditlog.Config{
+		Enabled: true,
+		Target:  &oldTarget,
+	})
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) (auditlog.AuditLog, error) {
+		calls = append(calls, cfg)
+		return &newTarget, nil
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	initialTarget := getWorkerConfig(c, w).Target
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-sinks"] = []interface{}{"file", "webhook"}
+	cfg["audit-log-webhook-url"] = "https://audit.example.com/ingest"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Sinks.Contains(auditlog.WebhookSink)
+	})
+
+	c.Assert(newConfig.Webhook.URL, gc.Equals, "https://audit.example.com/ingest")
+	c.Assert(calls, gc.HasLen, 1)
+	oldTarget.CheckCallNames(c, "Close")
+
+	// Connections holding the initial target now write to the new
+	// sinks.
+	c.Assert(newConfig.Target, gc.Equals, initialTarget)
+	err = initialTarget.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+	oldTarget.CheckCallNames(c, "Close")
+	newTarget.CheckCallNames(c, "AddConversation")
+}
+
+func (s *updaterSuite) TestKeepsTargetWhenNewSinksFail(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := apitesting.FakeAuditLog{}
+	initial := withDefaultSinks(auditlog.Config{
+		Enabled: true,
+		Target:  &oldTarget,
+	})
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	factory := func(cfg auditlog.Config) (auditlog.AuditLog, error) {
+		return nil, errors.New("bad sink")
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, true)
+	cfg["audit-log-sinks"] = []interface{}{"file", "webhook"}
+	cfg["audit-log-webhook-url"] = "https://audit.example.com/ingest"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.CaptureAPIArgs
+	})
+	c.Assert(newConfig.Sinks, gc.DeepEquals, initial.Sinks)
+	err = newConfig.Target.AddConversation(auditlog.Conversation{Who: "deerhoof"})
+	c.Assert(err, jc.ErrorIsNil)
+	oldTarget.CheckCallNames(c, "AddConversation")
+}
+
+// withDefaultSinks fills in the sink settings that a controller
+// config without any audit-log-sinks keys produces.
+func withDefaultSinks(cfg auditlog.Config) auditlog.Config {
+	defaults := controller.Config{}
+	cfg.Sinks = defaults.AuditLogSinks()
+	cfg.Syslog = defaults.AuditLogSyslog()
+	cfg.Webhook = defaults.AuditLogWebhook()
+	return cfg
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/state"
+)
+
+const metricsNamespace = "juju_backups"
+
+// Collector is a prometheus.Collector that collects metrics about
+// scheduled backups.
+type Collector struct {
+	lastSuccess  prometheus.Gauge
+	lastFailure  prometheus.Gauge
+	lastDuration prometheus.Gauge
+	lastSize     prometheus.Gauge
+	next         prometheus.Gauge
+	total        *prometheus.CounterVec
+}
+
+// NewMetricsCollector returns a new Collector.
+func NewMetricsCollector() *Collector {
+	return &Collector{
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "The time the last successful scheduled backup finished.",
+		}),
+		lastFailure: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_failure_timestamp_seconds",
+			Help:      "The time the last failed scheduled backup was attempted.",
+		}),
+		lastDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_duration_seconds",
+			Help:      "The time taken by the last successful scheduled backup.",
+		}),
+		lastSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_size_bytes",
+			Help:      "The size of the last successful scheduled backup.",
+		}),
+		next: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "next_timestamp_seconds",
+			Help:      "The time the next scheduled backup is due, or 0 if none is scheduled.",
+		}),
+		total: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "scheduled_total",
+			Help:      "The number of scheduled backups attempted, by result.",
+		}, []string{"result"}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.lastSuccess.Describe(ch)
+	c.lastFailure.Describe(ch)
+	c.lastDuration.Describe(ch)
+	c.lastSize.Describe(ch)
+	c.next.Describe(ch)
+	c.total.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.lastSuccess.Collect(ch)
+	c.lastFailure.Collect(ch)
+	c.lastDuration.Collect(ch)
+	c.lastSize.Collect(ch)
+	c.next.Collect(ch)
+	c.total.Collect(ch)
+}
+
+// setStatus sets the last success and failure times from the recorded
+// status, so they survive the worker restarting.
+func (c *Collector) setStatus(status state.ScheduledBackupStatus) {
+	c.lastSuccess.Set(timestamp(status.LastSuccess))
+	c.lastFailure.Set(timestamp(status.LastFailure))
+}
+
+func (c *Collector) recordSuccess(when time.Time, duration time.Duration, size int64) {
+	c.lastSuccess.Set(timestamp(when))
+	c.lastDuration.Set(duration.Seconds())
+	c.lastSize.Set(float64(size))
+	c.total.WithLabelValues("success").Inc()
+}
+
+func (c *Collector) recordFailure(when time.Time) {
+	c.lastFailure.Set(timestamp(when))
+	c.total.WithLabelValues("failure").Inc()
+}
+
+func (c *Collector) setNext(when time.Time) {
+	c.next.Set(timestamp(when))
+}
+
+func timestamp(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano()) / float64(time.Second)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// scheduledBackupNotes is the annotation given to scheduled backups.
+const scheduledBackupNotes = "scheduled backup"
+
+// stateBackups implements Backups, and takes backups, using the
+// controller's backup storage. The storage is opened for each call so
+// that the long-lived worker doesn't hold on to a mongo session, and
+// picks up changes to the backup storage config.
+type stateBackups struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// List is part of the Backups interface.
+func (b stateBackups) List() ([]*backups.Metadata, error) {
+	stor, err := backups.OpenStorage(b.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+	result, err := backups.NewBackups(stor).List()
+	return result, errors.Trace(err)
+}
+
+// Remove is part of the Backups interface.
+func (b stateBackups) Remove(id string) error {
+	stor, err := backups.OpenStorage(b.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	return errors.Trace(backups.NewBackups(stor).Remove(id))
+}
+
+// Create takes a backup of the controller in the same way as the
+// Backups facade's Create method, marking it as scheduled.
+func (b stateBackups) Create() (*backups.Metadata, error) {
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotate(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info in agent config")
+	}
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotate(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	m, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, machineID, m.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = scheduledBackupNotes
+	meta.Scheduled = true
+	meta.Controller.MachineID = machineID
+	instanceID, err := m.InstanceId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Controller.MachineInstanceID = string(instanceID)
+	nodes, err := b.st.ControllerNodes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Controller.HANodes = int64(len(nodes))
+
+	modelConfig, err := b.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+
+	stor, err := backups.OpenStorage(b.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+	// Keep the archive in the controller, and don't leave a copy
+	// behind for downloading.
+	if _, err := backups.NewBackups(stor).Create(meta, &paths, dbInfo, true, true, backups.EncryptionKey{}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that takes backups of the
+// controller on the schedule set in the controller configuration, and
+// removes old scheduled backups according to the retention policy.
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/schedule"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend provides the controller configuration and records the
+// outcome of scheduled backups. (Primary implementation is State.)
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() state.NotifyWatcher
+	ScheduledBackupStatus() (state.ScheduledBackupStatus, error)
+	RecordScheduledBackupSuccess(id string, when time.Time) error
+	RecordScheduledBackupFailure(cause error, when time.Time) error
+}
+
+// Backups lists and removes stored backups.
+type Backups interface {
+	List() ([]*backups.Metadata, error)
+	Remove(id string) error
+}
+
+// Config holds the configuration and dependencies for a worker.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+
+	// CreateBackup takes and stores a backup of the controller,
+	// returning its metadata.
+	CreateBackup func() (*backups.Metadata, error)
+
+	PrometheusRegisterer prometheus.Registerer
+}
+
+// Validate returns an error if the config cannot be expected to
+// run a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.CreateBackup == nil {
+		return errors.NotValidf("nil CreateBackup")
+	}
+	if config.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
+	return nil
+}
+
+// Worker takes scheduled backups of the controller.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	metrics  *Collector
+
+	schedule  *schedule.Schedule
+	retention backups.RetentionPolicy
+	due       time.Time
+}
+
+// NewWorker returns a worker that takes backups of the controller on
+// the configured schedule.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:  config,
+		metrics: NewMetricsCollector(),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	_ = w.config.PrometheusRegisterer.Register(w.metrics)
+	defer w.config.PrometheusRegisterer.Unregister(w.metrics)
+
+	status, err := w.config.Backend.ScheduledBackupStatus()
+	if err != nil {
+		return errors.Annotate(err, "getting scheduled backup status")
+	}
+	w.metrics.setStatus(status)
+
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			if err := w.updateConfig(); err != nil {
+				return errors.Trace(err)
+			}
+			timer = w.nextBackup(time.Time{})
+		case <-timer:
+			if err := w.backup(); err != nil {
+				return errors.Trace(err)
+			}
+			timer = w.nextBackup(w.due)
+		}
+	}
+}
+
+func (w *Worker) updateConfig() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	w.retention = backups.RetentionPolicy{
+		Daily:  cfg.BackupRetentionDaily(),
+		Weekly: cfg.BackupRetentionWeekly(),
+	}
+	spec := cfg.BackupSchedule()
+	if spec == "" {
+		if w.schedule != nil {
+			logger.Infof("scheduled backups disabled")
+		}
+		w.schedule = nil
+		return nil
+	}
+	if w.schedule != nil && w.schedule.String() == spec {
+		return nil
+	}
+	sched, err := schedule.Parse(spec)
+	if err != nil {
+		// The config is validated when set, so this should
+		// never happen; don't take backups we weren't asked for.
+		logger.Errorf("scheduled backups disabled: %v", err)
+		w.schedule = nil
+		return nil
+	}
+	logger.Infof("taking scheduled backups on schedule %q", spec)
+	w.schedule = sched
+	return nil
+}
+
+// nextBackup returns a channel that fires when the next backup after
+// both now and the given time is due, or nil if no backup is
+// scheduled. Schedules are interpreted in UTC.
+func (w *Worker) nextBackup(after time.Time) <-chan time.Time {
+	w.due = time.Time{}
+	if w.schedule == nil {
+		w.metrics.setNext(time.Time{})
+		return nil
+	}
+	now := w.config.Clock.Now().UTC()
+	from := now
+	if after.After(from) {
+		// Don't take the same scheduled backup twice if the
+		// timer fired early.
+		from = after
+	}
+	next := w.schedule.Next(from)
+	w.due = next
+	w.metrics.setNext(next)
+	if next.IsZero() {
+		logger.Warningf("backup schedule %q never fires", w.schedule)
+		return nil
+	}
+	logger.Debugf("next scheduled backup at %s", next.Format(time.RFC3339))
+	return w.config.Clock.After(next.Sub(now))
+}
+
+// backup takes a backup and removes those no longer retained. Failing
+// to take a backup is recorded rather than stopping the worker; only
+// failing to record the outcome is returned as an error.
+func (w *Worker) backup() error {
+	started := w.config.Clock.Now()
+	meta, err := w.config.CreateBackup()
+	finished := w.config.Clock.Now()
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+		w.metrics.recordFailure(finished)
+		return errors.Trace(w.config.Backend.RecordScheduledBackupFailure(err, finished))
+	}
+	logger.Infof("scheduled backup %q created", meta.ID())
+	w.metrics.recordSuccess(finished, finished.Sub(started), meta.Size())
+	if err := w.config.Backend.RecordScheduledBackupSuccess(meta.ID(), finished); err != nil {
+		return errors.Trace(err)
+	}
+	w.prune(finished)
+	return nil
+}
+
+// prune removes the scheduled backups that the retention policy no
+// longer keeps. Errors are logged and the backups are retried on the
+// next pass.
+func (w *Worker) prune(now time.Time) {
+	metadata, err := w.config.Backups.List()
+	if err != nil {
+		logger.Errorf("listing backups to prune: %v", err)
+		return
+	}
+	for _, meta := range w.retention.Expired(metadata, now) {
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			logger.Errorf("removing expired backup %q: %v", meta.ID(), err)
+			continue
+		}
+		logger.Infof("removed expired backup %q", meta.ID())
+	}
+}
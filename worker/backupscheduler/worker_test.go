@@ -0,0 +1,263 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock         *testclock.Clock
+	configChanged chan struct{}
+	backend       *fakeBackend
+	backups       *fakeBackups
+	registry      *prometheus.Registry
+	createErr     error
+	config        backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var ding = struct{}{}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(parseTime("2020-03-18 01:00"))
+	s.configChanged = make(chan struct{}, 1)
+	s.backend = &fakeBackend{
+		watcher: watchertest.NewNotifyWatcher(s.configChanged),
+		cfg: controller.Config{
+			controller.BackupSchedule:        "0 2 * * *",
+			controller.BackupRetentionDaily:  1,
+			controller.BackupRetentionWeekly: 0,
+		},
+	}
+	manual := backups.NewMetadata()
+	manual.SetID("manual")
+	manual.Started = parseTime("2020-01-01 00:00")
+	old := backups.NewMetadata()
+	old.SetID("old")
+	old.Started = parseTime("2020-03-10 02:00")
+	old.Scheduled = true
+	s.backups = &fakeBackups{metadata: []*backups.Metadata{manual, old}}
+	s.registry = prometheus.NewRegistry()
+	s.createErr = nil
+	s.config = backupscheduler.Config{
+		Backend:              s.backend,
+		Backups:              s.backups,
+		Clock:                s.clock,
+		CreateBackup:         s.createBackup,
+		PrometheusRegisterer: s.registry,
+	}
+}
+
+func (s *WorkerSuite) createBackup() (*backups.Metadata, error) {
+	if s.createErr != nil {
+		return nil, s.createErr
+	}
+	meta := backups.NewMetadata()
+	meta.SetID("new")
+	meta.Started = s.clock.Now()
+	meta.Scheduled = true
+	s.backups.add(meta)
+	return meta, nil
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *backupscheduler.Worker {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.configChanged <- ding
+	return w
+}
+
+// waitForTimer waits for the worker to be waiting for the next
+// scheduled backup.
+func (s *WorkerSuite) waitForTimer(c *gc.C) {
+	err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Backend = nil
+	_, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestScheduledBackup(c *gc.C) {
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForTimer(c)
+
+	finished := parseTime("2020-03-18 02:00")
+	s.backend.CheckCall(c, 0, "ScheduledBackupStatus")
+	s.backend.CheckCall(c, 1, "ControllerConfig")
+	s.backend.CheckCall(c, 2, "RecordScheduledBackupSuccess", "new", finished)
+	// The old scheduled backup is past the retention period; the
+	// manual backup is kept regardless.
+	s.backups.CheckCalls(c, []testing.StubCall{
+		{FuncName: "List"},
+		{FuncName: "Remove", Args: []interface{}{"old"}},
+	})
+}
+
+func (s *WorkerSuite) TestBackupFailure(c *gc.C) {
+	s.createErr = errors.New("disk full")
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	// The worker carries on to the next scheduled backup.
+	s.waitForTimer(c)
+
+	s.backend.CheckCall(c, 2, "RecordScheduledBackupFailure", s.createErr, parseTime("2020-03-18 02:00"))
+	s.backups.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestNoScheduleDisablesBackups(c *gc.C) {
+	s.backend.setConfig(controller.Config{})
+	s.startWorker(c)
+
+	err := s.clock.WaitAdvance(0, coretesting.ShortWait, 1)
+	c.Assert(err, gc.NotNil)
+
+	s.backend.setConfig(controller.Config{controller.BackupSchedule: "@hourly"})
+	s.configChanged <- ding
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForTimer(c)
+	s.backend.CheckCall(c, 3, "RecordScheduledBackupSuccess", "new", parseTime("2020-03-18 02:00"))
+}
+
+func (s *WorkerSuite) TestMetrics(c *gc.C) {
+	s.backend.status = state.ScheduledBackupStatus{
+		LastFailure: parseTime("2020-03-17 02:00"),
+	}
+	s.startWorker(c)
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForTimer(c)
+
+	values := s.gatherMetrics(c)
+	c.Check(values["juju_backups_last_success_timestamp_seconds"], gc.Equals,
+		float64(parseTime("2020-03-18 02:00").Unix()))
+	c.Check(values["juju_backups_last_failure_timestamp_seconds"], gc.Equals,
+		float64(parseTime("2020-03-17 02:00").Unix()))
+	c.Check(values["juju_backups_next_timestamp_seconds"], gc.Equals,
+		float64(parseTime("2020-03-19 02:00").Unix()))
+	c.Check(values["juju_backups_scheduled_total"], gc.Equals, float64(1))
+}
+
+// gatherMetrics returns the value of each metric, summed across labels.
+func (s *WorkerSuite) gatherMetrics(c *gc.C) map[string]float64 {
+	families, err := s.registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if gauge := metric.GetGauge(); gauge != nil {
+				values[family.GetName()] += gauge.GetValue()
+			}
+			if counter := metric.GetCounter(); counter != nil {
+				values[family.GetName()] += counter.GetValue()
+			}
+		}
+	}
+	return values
+}
+
+type fakeBackend struct {
+	testing.Stub
+
+	mu      sync.Mutex
+	watcher state.NotifyWatcher
+	cfg     controller.Config
+	status  state.ScheduledBackupStatus
+}
+
+func (b *fakeBackend) setConfig(cfg controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = cfg
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg, b.NextErr()
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *fakeBackend) ScheduledBackupStatus() (state.ScheduledBackupStatus, error) {
+	b.MethodCall(b, "ScheduledBackupStatus")
+	return b.status, b.NextErr()
+}
+
+func (b *fakeBackend) RecordScheduledBackupSuccess(id string, when time.Time) error {
+	b.MethodCall(b, "RecordScheduledBackupSuccess", id, when)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) RecordScheduledBackupFailure(cause error, when time.Time) error {
+	b.MethodCall(b, "RecordScheduledBackupFailure", cause, when)
+	return b.NextErr()
+}
+
+type fakeBackups struct {
+	testing.Stub
+
+	mu       sync.Mutex
+	metadata []*backups.Metadata
+}
+
+func (b *fakeBackups) add(meta *backups.Metadata) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.metadata = append(b.metadata, meta)
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.MethodCall(b, "List")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*backups.Metadata(nil), b.metadata...), b.NextErr()
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.MethodCall(b, "Remove", id)
+	return b.NextErr()
+}
@@ -135,6 +135,10 @@ type Config struct {
 	// StartUniterFunc starts a uniter worker using the given runner.
 	StartUniterFunc func(runner *worker.Runner, params *uniter.UniterParams) error
 
+	// StartDebugShellFunc, if set, starts a worker using the given
+	// runner that serves debug shell sessions for the named unit.
+	StartDebugShellFunc func(runner *worker.Runner, unitName string) error
+
 	// RunListenerSocketFunc returns a socket used for the juju run listener.
 	RunListenerSocketFunc func(*uniter.SocketConfig) (*sockets.Socket, error)
 
@@ -514,6 +518,11 @@ func (op *caasOperator) loop() (err error) {
 					if err := op.runner.StopWorker(unitID); err != nil {
 						return err
 					}
+					if op.config.StartDebugShellFunc != nil {
+						if err := op.runner.StopWorker(debugShellWorkerID(unitID)); err != nil {
+							return err
+						}
+					}
 					// Remove the unit's directory
 					if err := op.removeUnitDir(unitTag); err != nil {
 						return err
@@ -556,11 +565,22 @@ func (op *caasOperator) loop() (err error) {
 				if err := op.config.StartUniterFunc(op.runner, params); err != nil {
 					return errors.Trace(err)
 				}
+				if op.config.StartDebugShellFunc != nil {
+					if err := op.config.StartDebugShellFunc(op.runner, unitID); err != nil {
+						return errors.Trace(err)
+					}
+				}
 			}
 		}
 	}
 }
 
+// debugShellWorkerID returns the runner ID of the debug shell
+// worker for the unit.
+func debugShellWorkerID(unitID string) string {
+	return unitID + "-debug-shell"
+}
+
 func charmModified(local *LocalState, remote remotestate.Snapshot) bool {
 	// CAAS models may not yet have read the charm url from state.
 	if remote.CharmURL == nil {
@@ -424,6 +424,23 @@ func (s *WorkerSuite) TestRemovedUnit(c *gc.C) {
 	s.client.CheckCall(c, 1, "RemoveUnit", "gitlab/0")
 }
 
+func (s *WorkerSuite) TestDebugShellStarted(c *gc.C) {
+	debugShellUnits := make(chan string, 1)
+	s.config.StartDebugShellFunc = func(runner *worker.Runner, unitName string) error {
+		debugShellUnits <- unitName
+		return nil
+	}
+	w, _ := s.assertUniterStarted(c)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case unitName := <-debugShellUnits:
+		c.Assert(unitName, gc.Equals, "gitlab/0")
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for debug shell worker to start")
+	}
+}
+
 func (s *WorkerSuite) TestRemovedApplication(c *gc.C) {
 	s.client.SetErrors(errors.NotFoundf("app"))
 	w, err := caasoperator.NewWorker(s.config)
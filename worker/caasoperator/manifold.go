@@ -20,6 +20,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
+	apidebugshell "github.com/juju/juju/api/debugshell"
 	apileadership "github.com/juju/juju/api/leadership"
 	apiuniter "github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
@@ -29,10 +30,12 @@ import (
 	coreleadership "github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/debugshell"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/leadership"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a
@@ -164,6 +167,22 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return leadership.NewTracker(unitTag, claimer, clock, config.LeadershipGuarantee)
 			}
 
+			// Operators debugging the hooks of a unit over the API
+			// are served by the operator, which runs the hooks. The
+			// sessions are shared by the debug shell workers and the
+			// uniters of all the application's units.
+			debugSessions := debug.NewRemoteSessions()
+			startDebugShellFunc := func(runner *worker.Runner, unitName string) error {
+				err := runner.StartWorker(debugShellWorkerID(unitName), func() (worker.Worker, error) {
+					return debugshell.NewWorker(debugshell.Config{
+						Facade:   apidebugshell.NewAPI(apiCaller),
+						UnitName: unitName,
+						Sessions: debugSessions,
+					})
+				})
+				return errors.Annotate(err, "starting debug shell worker")
+			}
+
 			runListenerSocketFunc := config.RunListenerSocket
 			if runListenerSocketFunc == nil {
 				runListenerSocketFunc = runListenerSocket
@@ -192,6 +211,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				ContainerStartWatcher: containerStartWatcherClient(client),
 				VersionSetter:         client,
 				StartUniterFunc:       uniter.StartUniter,
+				StartDebugShellFunc:   startDebugShellFunc,
 				RunListenerSocketFunc: runListenerSocketFunc,
 				LeadershipTrackerFunc: leadershipTrackerFunc,
 				UniterFacadeFunc:      newUniterFunc,
@@ -220,6 +240,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				CharmDirGuard:           charmDirGuard,
 				UpdateStatusSignal:      uniter.NewUpdateStatusTimer(),
 				HookRetryStrategy:       hookRetryStrategy,
+				DebugSessions:           debugSessions,
 				TranslateResolverErr:    config.TranslateResolverErr,
 			}
 			wCfg.UniterParams.SocketConfig, err = socketConfig(operatorInfo)
@@ -175,17 +175,21 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	c.Assert(config.LeadershipTrackerFunc, gc.NotNil)
 	c.Assert(config.UniterFacadeFunc, gc.NotNil)
 	c.Assert(config.StartUniterFunc, gc.NotNil)
+	c.Assert(config.StartDebugShellFunc, gc.NotNil)
 	c.Assert(config.RunListenerSocketFunc, gc.NotNil)
 	c.Assert(config.UniterParams.UpdateStatusSignal, gc.NotNil)
 	c.Assert(config.UniterParams.NewOperationExecutor, gc.NotNil)
 	c.Assert(config.UniterParams.NewRemoteRunnerExecutor, gc.NotNil)
+	c.Assert(config.UniterParams.DebugSessions, gc.NotNil)
 	config.LeadershipTrackerFunc = nil
 	config.StartUniterFunc = nil
+	config.StartDebugShellFunc = nil
 	config.UniterFacadeFunc = nil
 	config.RunListenerSocketFunc = nil
 	config.UniterParams.UpdateStatusSignal = nil
 	config.UniterParams.NewOperationExecutor = nil
 	config.UniterParams.NewRemoteRunnerExecutor = nil
+	config.UniterParams.DebugSessions = nil
 
 	c.Assert(config.UniterParams.SocketConfig.TLSConfig, gc.NotNil)
 	config.UniterParams.SocketConfig.TLSConfig = nil
@@ -5,6 +5,7 @@ package charmrunner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -47,3 +48,21 @@ func IsMissingHookError(err error) bool {
 func NewMissingHookError(hookName string) error {
 	return &missingHookError{hookName}
 }
+
+type hookTimedOutError struct {
+	hookName string
+	timeout  time.Duration
+}
+
+func (e *hookTimedOutError) Error() string {
+	return fmt.Sprintf("hook %q timed out after %v", e.hookName, e.timeout)
+}
+
+func IsHookTimedOutError(err error) bool {
+	_, ok := err.(*hookTimedOutError)
+	return ok
+}
+
+func NewHookTimedOutError(hookName string, timeout time.Duration) error {
+	return &hookTimedOutError{hookName, timeout}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debugshell
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/debugshell"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a
+// debug shell worker depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	// Sessions is shared with the uniter, which runs the
+	// sessions started by the worker.
+	Sessions *debug.RemoteSessions
+
+	NewFacade func(base.APICaller) Facade
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the manifold config is not valid.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Sessions == nil {
+		return errors.NotValidf("nil Sessions")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency manifold that runs a debug shell
+// worker for the unit of the agent.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	tag, ok := agent.CurrentConfig().Tag().(names.UnitTag)
+	if !ok {
+		return nil, errors.New("debug shell worker may only be used with a unit agent")
+	}
+	w, err := config.NewWorker(Config{
+		Facade:   config.NewFacade(apiCaller),
+		UnitName: tag.Id(),
+		Sessions: config.Sessions,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// NewFacade returns the API facade used by the worker.
+func NewFacade(apiCaller base.APICaller) Facade {
+	return debugshell.NewAPI(apiCaller)
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debugshell_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	workerdebugshell "github.com/juju/juju/worker/debugshell"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config workerdebugshell.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	sessions := debug.NewRemoteSessions()
+	s.config = workerdebugshell.ManifoldConfig{
+		AgentName:     "agent",
+		APICallerName: "api-caller",
+		Sessions:      sessions,
+		NewFacade: func(base.APICaller) workerdebugshell.Facade {
+			return &fakeFacade{}
+		},
+		NewWorker: func(config workerdebugshell.Config) (worker.Worker, error) {
+			c.Check(config.UnitName, gc.Equals, "mysql/0")
+			c.Check(config.Sessions, gc.Equals, sessions)
+			return workertest.NewDeadWorker(nil), nil
+		},
+	}
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := workerdebugshell.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.SameContents, []string{"agent", "api-caller"})
+}
+
+func (s *ManifoldSuite) TestValidate(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+
+	config := s.config
+	config.AgentName = ""
+	c.Check(config.Validate(), gc.ErrorMatches, "empty AgentName not valid")
+
+	config = s.config
+	config.APICallerName = ""
+	c.Check(config.Validate(), gc.ErrorMatches, "empty APICallerName not valid")
+
+	config = s.config
+	config.Sessions = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Sessions not valid")
+
+	config = s.config
+	config.NewFacade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil NewFacade not valid")
+
+	config = s.config
+	config.NewWorker = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewUnitTag("mysql/0")},
+		"api-caller": dependency.ErrMissing,
+	})
+	w, err := workerdebugshell.Manifold(s.config).Start(context)
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.Equals, dependency.ErrMissing)
+}
+
+func (s *ManifoldSuite) TestStartMachineAgent(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewMachineTag("0")},
+		"api-caller": struct{ base.APICaller }{},
+	})
+	w, err := workerdebugshell.Manifold(s.config).Start(context)
+	c.Check(w, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "debug shell worker may only be used with a unit agent")
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewUnitTag("mysql/0")},
+		"api-caller": struct{ base.APICaller }{},
+	})
+	w, err := workerdebugshell.Manifold(s.config).Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(w, gc.NotNil)
+}
+
+type fakeAgent struct {
+	agent.Agent
+	tag names.Tag
+}
+
+func (a *fakeAgent) CurrentConfig() agent.Config {
+	return fakeConfig{tag: a.tag}
+}
+
+type fakeConfig struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (c fakeConfig) Tag() names.Tag {
+	return c.tag
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package debugshell provides a worker that waits for operators to
+// start debug shell sessions for a unit over the API, and hands each
+// session to the unit's hook runner.
+package debugshell
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/debugshell"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+var logger = loggo.GetLogger("juju.worker.debugshell")
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	// Wait blocks until an operator starts a debug session for
+	// the unit, and returns the session's stream and the hooks
+	// to debug.
+	Wait(unitName string) (debugshell.Stream, []string, error)
+}
+
+// Config holds the configuration for a debug shell worker.
+type Config struct {
+	Facade   Facade
+	UnitName string
+
+	// Sessions holds the sessions started by the worker until
+	// the unit's hook runner claims them.
+	Sessions *debug.RemoteSessions
+}
+
+// Validate returns an error if the config cannot drive a worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if !names.IsValidUnit(config.UnitName) {
+		return errors.NotValidf("unit name %q", config.UnitName)
+	}
+	if config.Sessions == nil {
+		return errors.NotValidf("nil Sessions")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that serves the debug sessions started
+// by operators for the configured unit, one at a time.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &debugShellWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type debugShellWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *debugShellWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *debugShellWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+type session struct {
+	stream debugshell.Stream
+	hooks  []string
+	err    error
+}
+
+func (w *debugShellWorker) loop() error {
+	hooksContext := debug.NewHooksContext(w.config.UnitName)
+	for {
+		s, err := w.waitForOperator()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("debug session started for %s, hooks %v", w.config.UnitName, s.hooks)
+		remote := w.config.Sessions.Start(hooksContext, s.hooks, s.stream)
+		select {
+		case <-w.catacomb.Dying():
+			remote.Close()
+			return w.catacomb.ErrDying()
+		case <-remote.Done():
+			logger.Infof("debug session ended for %s", w.config.UnitName)
+		}
+	}
+}
+
+// waitForOperator blocks until an operator starts a session, or the
+// worker is killed.
+func (w *debugShellWorker) waitForOperator() (session, error) {
+	// The facade cannot be interrupted, so it is called in the
+	// background; a session started after the worker is killed
+	// is closed as soon as it arrives.
+	sessions := make(chan session, 1)
+	go func() {
+		var s session
+		s.stream, s.hooks, s.err = w.config.Facade.Wait(w.config.UnitName)
+		sessions <- s
+	}()
+	select {
+	case <-w.catacomb.Dying():
+		go func() {
+			if s := <-sessions; s.err == nil {
+				s.stream.Close()
+			}
+		}()
+		return session{}, w.catacomb.ErrDying()
+	case s := <-sessions:
+		if s.err != nil {
+			return session{}, errors.Annotate(s.err, "waiting for debug session")
+		}
+		return s, nil
+	}
+}
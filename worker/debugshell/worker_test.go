@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debugshell_test
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/debugshell"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	workerdebugshell "github.com/juju/juju/worker/debugshell"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	facade *fakeFacade
+	config workerdebugshell.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		calls:    make(chan string, 10),
+		sessions: make(chan fakeSession),
+	}
+	s.config = workerdebugshell.Config{
+		Facade:   s.facade,
+		UnitName: "mysql/0",
+		Sessions: debug.NewRemoteSessions(),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+
+	config := s.config
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config
+	config.UnitName = "mysql"
+	c.Check(config.Validate(), gc.ErrorMatches, `unit name "mysql" not valid`)
+
+	config = s.config
+	config.Sessions = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Sessions not valid")
+}
+
+func (s *WorkerSuite) TestStartsSession(c *gc.C) {
+	w, err := workerdebugshell.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.expectWait(c)
+	stream := newFakeStream()
+	s.facade.sessions <- fakeSession{stream: stream, hooks: []string{"install"}}
+
+	// The session is handed to the hook runner once started.
+	ctx := debug.NewHooksContext("mysql/0")
+	var session *debug.RemoteSession
+	for a := coretesting.LongAttempt.Start(); session == nil && a.Next(); {
+		session = s.config.Sessions.Claim(ctx, "install")
+	}
+	c.Assert(session, gc.NotNil)
+
+	// Once the session ends, the worker waits for another.
+	session.Close()
+	s.expectWait(c)
+}
+
+func (s *WorkerSuite) TestKillClosesSession(c *gc.C) {
+	w, err := workerdebugshell.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.expectWait(c)
+	stream := newFakeStream()
+	s.facade.sessions <- fakeSession{stream: stream}
+	workertest.CleanKill(c, w)
+	s.assertClosed(c, stream)
+}
+
+func (s *WorkerSuite) TestKillWhileWaiting(c *gc.C) {
+	w, err := workerdebugshell.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.expectWait(c)
+	workertest.CleanKill(c, w)
+
+	// A session that arrives after the worker stops is closed.
+	stream := newFakeStream()
+	s.facade.sessions <- fakeSession{stream: stream}
+	s.assertClosed(c, stream)
+}
+
+func (s *WorkerSuite) TestWaitError(c *gc.C) {
+	w, err := workerdebugshell.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.expectWait(c)
+	s.facade.sessions <- fakeSession{err: errors.New("boom")}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "waiting for debug session: boom")
+}
+
+func (s *WorkerSuite) expectWait(c *gc.C) {
+	select {
+	case unitName := <-s.facade.calls:
+		c.Assert(unitName, gc.Equals, "mysql/0")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Wait call")
+	}
+}
+
+func (s *WorkerSuite) assertClosed(c *gc.C, stream *fakeStream) {
+	select {
+	case <-stream.closed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for stream to close")
+	}
+}
+
+type fakeSession struct {
+	stream debugshell.Stream
+	hooks  []string
+	err    error
+}
+
+type fakeFacade struct {
+	calls    chan string
+	sessions chan fakeSession
+}
+
+func (f *fakeFacade) Wait(unitName string) (debugshell.Stream, []string, error) {
+	f.calls <- unitName
+	s := <-f.sessions
+	return s.stream, s.hooks, s.err
+}
+
+type fakeStream struct {
+	closed chan struct{}
+}
+
+func newFakeStream() *fakeStream {
+	return &fakeStream{closed: make(chan struct{})}
+}
+
+func (f *fakeStream) Send(params.DebugShellMessage) error {
+	return nil
+}
+
+func (f *fakeStream) Receive() (params.DebugShellMessage, error) {
+	<-f.closed
+	return params.DebugShellMessage{}, io.EOF
+}
+
+func (f *fakeStream) Close() error {
+	select {
+	case <-f.closed:
+	default:
+		close(f.closed)
+	}
+	return nil
+}
@@ -28,6 +28,7 @@ const (
 
 	typeDisk = "disk"
 	typeLoop = "loop"
+	typeLVM  = "lvm"
 	typePart = "part"
 )
 
@@ -97,11 +98,13 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 			}
 		}
 
-		// We may later want to expand this, e.g. to handle lvm,
-		// dmraid, crypt, etc., but this is enough to cover bases
-		// for now.
+		// We may later want to expand this, e.g. to handle dmraid,
+		// crypt, etc., but this is enough to cover bases for now.
+		// Logical volumes are included so that those created by
+		// the lvm storage provider can be matched to volumes.
 		switch deviceType {
 		case typeLoop:
+		case typeLVM:
 		case typePart:
 		case typeDisk:
 			// Floppy disks, which have major device number 2,
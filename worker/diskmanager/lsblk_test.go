@@ -255,7 +255,8 @@ KNAME="sda" SIZE="240057409536" LABEL="" UUID="" TYPE="disk"
 KNAME="sda1" SIZE="254803968" LABEL="" UUID="" TYPE="part"
 KNAME="loop0" SIZE="254803968" LABEL="" UUID="" TYPE="loop"
 KNAME="sr0" SIZE="254803968" LABEL="" UUID="" TYPE="rom"
-KNAME="whatever" SIZE="254803968" LABEL="" UUID="" TYPE="lvm"
+KNAME="whatever" SIZE="254803968" LABEL="" UUID="" TYPE="crypt"
+KNAME="dm-0" SIZE="254803968" LABEL="" UUID="" TYPE="lvm"
 EOF`)
 
 	devices, err := diskmanager.ListBlockDevices()
@@ -269,5 +270,8 @@ EOF`)
 	}, {
 		DeviceName: "loop0",
 		Size:       243,
+	}, {
+		DeviceName: "dm-0",
+		Size:       243,
 	}})
 }
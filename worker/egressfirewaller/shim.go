@@ -0,0 +1,41 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/base"
+	apiegressfirewaller "github.com/juju/juju/api/egressfirewaller"
+)
+
+// NewFacade returns a Facade for the specified machine.
+func NewFacade(apiCaller base.APICaller, tag names.MachineTag) (Facade, error) {
+	return apiegressfirewaller.NewFacade(apiCaller, tag), nil
+}
+
+// NewWorker returns an egressfirewaller worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	worker, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
+
+// RunCommand runs the bash script on the local machine.
+func RunCommand(script string) (string, error) {
+	command := exec.Command("/bin/bash")
+	command.Stdin = strings.NewReader(script)
+	output, err := command.CombinedOutput()
+	if err != nil {
+		return string(output), errors.Trace(err)
+	}
+	return string(output), nil
+}
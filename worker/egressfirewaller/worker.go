@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/network/iptables"
+)
+
+var logger = loggo.GetLogger("juju.worker.egressfirewaller")
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	Watch() (watcher.NotifyWatcher, error)
+	EgressRules() ([]network.EgressRule, bool, error)
+}
+
+// Config defines the parameters of the egressfirewaller worker.
+type Config struct {
+	Facade Facade
+
+	// RunCommand runs the given bash script on the machine, and
+	// returns its combined output.
+	RunCommand func(script string) (string, error)
+}
+
+// Validate returns an error if Config cannot drive an egressfirewaller.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.RunCommand == nil {
+		return errors.NotValidf("nil RunCommand")
+	}
+	return nil
+}
+
+// New returns a worker which enforces the egress rules of the machine
+// with iptables, for machines whose provider can't enforce them. The
+// firewaller decides which machines that is, and records their egress
+// rules for the worker.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &egressFirewaller{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type egressFirewaller struct {
+	config Config
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (w *egressFirewaller) SetUp() (watcher.NotifyWatcher, error) {
+	return w.config.Facade.Watch()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (w *egressFirewaller) Handle(_ <-chan struct{}) error {
+	want, wantRestricted, err := w.config.Facade.EgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output, err := w.config.RunCommand("sudo iptables -L OUTPUT -n")
+	if err != nil {
+		if !wantRestricted && len(want) == 0 {
+			// Nothing to enforce, and nothing could have been
+			// enforced without iptables.
+			logger.Debugf("cannot list egress rules: %v: %s", err, output)
+			return nil
+		}
+		return errors.Annotatef(err, "cannot list egress rules: %s", output)
+	}
+	current, restricted, err := iptables.ParseEgressRules(strings.NewReader(output))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Open egress rules before restricting the egress or closing any,
+	// and lift the restriction before closing any, so that traffic
+	// which remains allowed is never dropped in between. Without a
+	// source address, the rules apply to all of the machine's traffic.
+	toOpen, toClose := network.DiffEgressRules(current, want)
+	var cmds []string
+	for _, rule := range toOpen {
+		cmds = append(cmds, iptables.EgressRuleCommand{Rule: rule}.Render())
+	}
+	if restricted != wantRestricted {
+		cmds = append(cmds, iptables.DropEgressCommand{Delete: !wantRestricted}.Render())
+	}
+	for _, rule := range toClose {
+		cmds = append(cmds, iptables.EgressRuleCommand{Rule: rule, Delete: true}.Render())
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	script := strings.Join(append([]string{"set -e"}, cmds...), "\n")
+	if output, err := w.config.RunCommand(script); err != nil {
+		return errors.Annotatef(err, "cannot change egress rules: %s", output)
+	}
+	logger.Infof("egress rules changed: opened %v, closed %v, restricted %v", toOpen, toClose, wantRestricted)
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (w *egressFirewaller) TearDown() error {
+	return nil
+}
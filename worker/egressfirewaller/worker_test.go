@@ -0,0 +1,205 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package egressfirewaller_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/egressfirewaller"
+)
+
+type workerSuite struct {
+	jujutesting.IsolationSuite
+
+	stub    *jujutesting.Stub
+	changes chan struct{}
+	facade  *stubFacade
+	output  string
+	scripts chan string
+	config  egressfirewaller.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = new(jujutesting.Stub)
+	s.changes = make(chan struct{}, 1)
+	s.facade = &stubFacade{stub: s.stub, changes: s.changes}
+	s.output = `
+Chain OUTPUT (policy ACCEPT)
+target     prot opt source               destination         
+`[1:]
+	s.scripts = make(chan string, 10)
+	s.config = egressfirewaller.Config{
+		Facade:     s.facade,
+		RunCommand: s.runCommand,
+	}
+}
+
+func (s *workerSuite) runCommand(script string) (string, error) {
+	s.stub.AddCall("RunCommand", script)
+	if err := s.stub.NextErr(); err != nil {
+		return "sudo: iptables: command not found", err
+	}
+	if strings.HasPrefix(script, "sudo iptables -L") {
+		return s.output, nil
+	}
+	s.scripts <- script
+	return "", nil
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Facade = nil
+	_, err := egressfirewaller.New(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config
+	config.RunCommand = nil
+	_, err = egressfirewaller.New(config)
+	c.Assert(err, gc.ErrorMatches, "nil RunCommand not valid")
+}
+
+func (s *workerSuite) TestRestrict(c *gc.C) {
+	s.facade.rules = []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	}
+	s.facade.restricted = true
+	s.changes <- struct{}{}
+
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	script := s.waitScript(c)
+	c.Assert(script, gc.Equals, strings.Join([]string{
+		"set -e",
+		"(sudo iptables -C OUTPUT -j ACCEPT -p tcp --dport 443 -d 10.0.0.0/24 -m comment --comment 'juju egress') || " +
+			"(sudo iptables -I OUTPUT -j ACCEPT -p tcp --dport 443 -d 10.0.0.0/24 -m comment --comment 'juju egress')",
+		"(sudo iptables -C OUTPUT -m state --state NEW -j DROP ! -o lo -m comment --comment 'juju egress drop') || " +
+			"(sudo iptables -A OUTPUT -m state --state NEW -j DROP ! -o lo -m comment --comment 'juju egress drop')",
+	}, "\n"))
+}
+
+func (s *workerSuite) TestUnrestrict(c *gc.C) {
+	s.output = `
+Chain OUTPUT (policy ACCEPT)
+target     prot opt source               destination         
+ACCEPT     tcp  --  0.0.0.0/0            10.0.0.0/24          tcp dpt:443 /* juju egress */
+DROP       all  --  0.0.0.0/0            0.0.0.0/0            state NEW /* juju egress drop */
+`[1:]
+	s.changes <- struct{}{}
+
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The restriction is lifted before any rule is closed.
+	script := s.waitScript(c)
+	c.Assert(script, gc.Equals, strings.Join([]string{
+		"set -e",
+		"(sudo iptables -C OUTPUT -m state --state NEW -j DROP ! -o lo -m comment --comment 'juju egress drop') && " +
+			"(sudo iptables -D OUTPUT -m state --state NEW -j DROP ! -o lo -m comment --comment 'juju egress drop')",
+		"(sudo iptables -C OUTPUT -j ACCEPT -p tcp --dport 443 -d 10.0.0.0/24 -m comment --comment 'juju egress') && " +
+			"(sudo iptables -D OUTPUT -j ACCEPT -p tcp --dport 443 -d 10.0.0.0/24 -m comment --comment 'juju egress')",
+	}, "\n"))
+}
+
+func (s *workerSuite) TestNoChange(c *gc.C) {
+	s.changes <- struct{}{}
+
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "RunCommand")
+	select {
+	case script := <-s.scripts:
+		c.Fatalf("unexpected script %q", script)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestListErrorUnrestricted(c *gc.C) {
+	// Without anything to enforce, failing to list the rules
+	// (e.g. in a container without iptables) is not an error.
+	s.stub.SetErrors(nil, nil, errors.New("exit status 1"))
+	s.changes <- struct{}{}
+
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "RunCommand")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *workerSuite) TestListErrorRestricted(c *gc.C) {
+	s.facade.restricted = true
+	s.stub.SetErrors(nil, nil, errors.New("exit status 1"))
+	s.changes <- struct{}{}
+
+	w, err := egressfirewaller.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot list egress rules: sudo: iptables: command not found: exit status 1")
+}
+
+func (s *workerSuite) waitScript(c *gc.C) string {
+	select {
+	case script := <-s.scripts:
+		return script
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for script")
+	}
+	panic("unreachable")
+}
+
+func (s *workerSuite) waitCall(c *gc.C, name string) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		for _, call := range s.stub.Calls() {
+			if call.FuncName == name {
+				return
+			}
+		}
+	}
+	c.Fatalf("timed out waiting for %s call", name)
+}
+
+type stubFacade struct {
+	stub       *jujutesting.Stub
+	changes    chan struct{}
+	rules      []network.EgressRule
+	restricted bool
+}
+
+func (f *stubFacade) Watch() (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("Watch")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *stubFacade) EgressRules() ([]network.EgressRule, bool, error) {
+	f.stub.AddCall("EgressRules")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, false, err
+	}
+	return f.rules, f.restricted, nil
+}
@@ -5,6 +5,8 @@ package firewaller
 
 import (
 	"io"
+	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,6 +48,7 @@ type FirewallerAPI interface {
 	WatchEgressAddressesForRelation(tag names.RelationTag) (watcher.StringsWatcher, error)
 	WatchIngressAddressesForRelation(tag names.RelationTag) (watcher.StringsWatcher, error)
 	ControllerAPIInfoForModel(modelUUID string) (*api.Info, error)
+	WatchAPIHostPorts() (watcher.NotifyWatcher, error)
 	MacaroonForRelation(relationKey string) (*macaroon.Macaroon, error)
 	SetRelationStatus(relationKey string, status relation.Status, message string) error
 	FirewallRules(applicationNames ...string) ([]params.FirewallRule, error)
@@ -139,6 +142,7 @@ type Firewaller struct {
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	apiHostPortsWatcher  watcher.NotifyWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -147,6 +151,14 @@ type Firewaller struct {
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 
+	// requiredEgressRules are added to the egress rules of every
+	// machine with restricted egress, so that its agents can reach
+	// the controller and resolve names. If the controller can't be
+	// reached through egress rules, canRestrictEgress is false and
+	// no machine's egress is restricted.
+	requiredEgressRules []network.EgressRule
+	canRestrictEgress   bool
+
 	modelUUID                  string
 	newRemoteFirewallerAPIFunc newCrossModelFacadeFunc
 	remoteRelationsWatcher     watcher.StringsWatcher
@@ -183,6 +195,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		exposedChange:              make(chan *exposedChange),
 		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
 		localRelationsChange:       make(chan *remoteRelationNetworkChange),
+		canRestrictEgress:          true,
 		pollClock:                  clk,
 		logger:                     cfg.Logger,
 		relationWorkerRunner: worker.NewRunner(worker.RunnerParams{
@@ -236,6 +249,19 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.apiHostPortsWatcher, err = fw.firewallerApi.WatchAPIHostPorts()
+	if err != nil {
+		return errors.Annotatef(err, "failed to start API host ports watcher")
+	}
+	if err := fw.catacomb.Add(fw.apiHostPortsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	// Know how to reach the controller before flushing any machines;
+	// the watcher's initial event then finds nothing has changed.
+	if err := fw.apiHostPortsChanged(); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -292,6 +318,13 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-fw.apiHostPortsWatcher.Changes():
+			if !ok {
+				return errors.New("API host ports watcher closed")
+			}
+			if err := fw.apiHostPortsChanged(); err != nil {
+				return errors.Trace(err)
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -314,6 +347,8 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.egressRestricted = change.egressRestricted
+			change.applicationd.egressRules = change.egressRules
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -474,24 +509,31 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 }
 
 // startApplication creates a new data value for tracking details of the
-// application and starts watching the application for exposure changes.
+// application and starts watching the application for exposure and
+// egress rule changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	exposed, err := app.IsExposed()
 	if err != nil {
 		return err
 	}
+	egressRules, egressRestricted, err := applicationEgressRules(app)
+	if err != nil {
+		return err
+	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		egressRestricted: egressRestricted,
+		egressRules:      egressRules,
+		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, egressRestricted, egressRules)
 		},
 	})
 	if err != nil {
@@ -534,6 +576,14 @@ func (fw *Firewaller) reconcileGlobal() error {
 			return err
 		}
 	}
+
+	// The global firewall can't restrict the egress of a single
+	// machine, so it is left to the machine agents.
+	for _, machined := range machines {
+		if err := fw.flushAgentEgressRules(machined); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -569,6 +619,10 @@ func (fw *Firewaller) reconcileInstances() error {
 		}
 		machineId := machined.tag.Id()
 
+		if err := fw.reconcileInstanceEgress(machined, envInstances[0]); err != nil {
+			return err
+		}
+
 		fwInstance, ok := envInstances[0].(instances.InstanceFirewaller)
 		if !ok {
 			return nil
@@ -601,6 +655,31 @@ func (fw *Firewaller) reconcileInstances() error {
 	return nil
 }
 
+// reconcileInstanceEgress compares the egress rules wanted for the
+// machine with those applied to its instance, and opens and closes the
+// appropriate egress rules. If the provider can't enforce egress rules
+// on the instance, they are left to the machine agent.
+func (fw *Firewaller) reconcileInstanceEgress(machined *machineData, inst instances.Instance) error {
+	fwEgress, ok := inst.(instances.InstanceEgressFirewaller)
+	if !ok {
+		return fw.flushAgentEgressRules(machined)
+	}
+	machineId := machined.tag.Id()
+	initialRules, initialRestricted, err := fwEgress.EgressRules(fw.cloudCallContext, machineId)
+	if errors.IsNotSupported(err) {
+		fw.logger.Debugf("egress rules for %q not supported by the provider: %v", machined.tag, err)
+		return fw.flushAgentEgressRules(machined)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Check which egress rules to open or to close.
+	toOpen, toClose := network.DiffEgressRules(initialRules, machined.egressRules)
+	restrictedChanged := initialRestricted != machined.egressRestricted
+	return fw.applyInstanceEgressRules(fwEgress, machined, toOpen, toClose, restrictedChanged)
+}
+
 // unitsChanged responds to changes to the assigned units.
 func (fw *Firewaller) unitsChanged(change *unitsChange) error {
 	changed := []*unitData{}
@@ -742,7 +821,8 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	return nil
 }
 
-// flushMachine opens and closes ports for the passed machine.
+// flushMachine opens and closes ports and egress rules for the
+// passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
 	want, err := fw.gatherIngressRules(machined)
 	if err != nil {
@@ -750,10 +830,134 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	}
 	toOpen, toClose := diffRanges(machined.ingressRules, want)
 	machined.ingressRules = want
+
+	wantEgress, wantRestricted := fw.gatherEgressRules(machined)
+	if wantRestricted && !fw.canRestrictEgress {
+		fw.logger.Debugf("not restricting egress of %q", machined.tag)
+		wantEgress, wantRestricted = nil, false
+	}
+	if wantRestricted {
+		wantEgress = append(wantEgress, fw.requiredEgressRules...)
+	}
+	toOpenEgress, toCloseEgress := network.DiffEgressRules(machined.egressRules, wantEgress)
+	restrictedChanged := wantRestricted != machined.egressRestricted
+	machined.egressRules = wantEgress
+	machined.egressRestricted = wantRestricted
+	egressChanged := len(toOpenEgress) > 0 || len(toCloseEgress) > 0 || restrictedChanged
+
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		if err := fw.flushGlobalPorts(toOpen, toClose); err != nil {
+			return errors.Trace(err)
+		}
+		// The global firewall can't restrict the egress of a single
+		// machine, so leave it to the machine agent.
+		if !egressChanged {
+			return nil
+		}
+		return fw.flushAgentEgressRules(machined)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	if err := fw.flushInstancePorts(machined, toOpen, toClose); err != nil {
+		return errors.Trace(err)
+	}
+	return fw.flushInstanceEgressRules(machined, toOpenEgress, toCloseEgress, restrictedChanged)
+}
+
+// gatherEgressRules returns the egress rules wanted for the specified
+// machine, and whether its egress is restricted to them. Egress rules
+// are enforced per machine, so the egress of a machine is restricted
+// only if the egress of all applications with units on it is, and its
+// rules are the union of theirs.
+func (fw *Firewaller) gatherEgressRules(machined *machineData) ([]network.EgressRule, bool) {
+	var want []network.EgressRule
+	seen := set.NewStrings()
+	for _, unitd := range machined.unitds {
+		applicationd := unitd.applicationd
+		if seen.Contains(applicationd.application.Name()) {
+			continue
+		}
+		seen.Add(applicationd.application.Name())
+		if !applicationd.egressRestricted {
+			return nil, false
+		}
+		want = append(want, applicationd.egressRules...)
+	}
+	return want, seen.Size() > 0
+}
+
+// dnsEgressRules allow machines with restricted egress to resolve
+// names, wherever their resolvers are.
+var dnsEgressRules = []network.EgressRule{
+	network.MustNewEgressRule("udp", 53, 53),
+	network.MustNewEgressRule("tcp", 53, 53),
+}
+
+// controllerEgressRules returns the egress rules allowing the agents
+// of a machine with restricted egress to reach the controller and
+// resolve names. Egress rules only hold IPv4 CIDRs, so if the
+// controller has IPv6 addresses, or no IPv4 ones, agents may not be
+// able to reach it through the rules and it returns false.
+func (fw *Firewaller) controllerEgressRules() ([]network.EgressRule, bool, error) {
+	info, err := fw.firewallerApi.ControllerAPIInfoForModel(fw.modelUUID)
+	if err != nil {
+		return nil, false, errors.Annotate(err, "cannot get controller addresses")
+	}
+	var rules []network.EgressRule
+	for _, addr := range info.Addrs {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			fw.logger.Debugf("not allowing egress to controller address %q", addr)
+			continue
+		}
+		if ip.To4() == nil {
+			fw.logger.Debugf("controller address %q can't be allowed by egress rules", addr)
+			return nil, false, nil
+		}
+		portNum, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, false, errors.Annotatef(err, "invalid controller address %q", addr)
+		}
+		rule, err := network.NewEgressRule("tcp", portNum, portNum, host+"/32")
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return nil, false, nil
+	}
+	return append(rules, dnsEgressRules...), true, nil
+}
+
+// apiHostPortsChanged updates the egress rules allowing machines with
+// restricted egress to reach the controller, and flushes the machines
+// whose egress is or should be restricted if they have changed.
+func (fw *Firewaller) apiHostPortsChanged() error {
+	rules, canRestrict, err := fw.controllerEgressRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	toOpen, toClose := network.DiffEgressRules(fw.requiredEgressRules, rules)
+	if canRestrict == fw.canRestrictEgress && len(toOpen) == 0 && len(toClose) == 0 {
+		return nil
+	}
+	if !canRestrict {
+		fw.logger.Warningf("egress rules can't allow the controller's addresses; not restricting the egress of any machine")
+	}
+	fw.requiredEgressRules = rules
+	fw.canRestrictEgress = canRestrict
+	for _, machined := range fw.machineds {
+		if _, wantRestricted := fw.gatherEgressRules(machined); !wantRestricted && !machined.egressRestricted {
+			continue
+		}
+		if err := fw.flushMachine(machined); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 // gatherIngressRules returns the ingress rules to open and close
@@ -951,6 +1155,110 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// flushInstanceEgressRules opens and closes egress rules on the machine,
+// and restricts its egress or lifts the restriction. If the provider
+// can't enforce egress rules on its instance, they are left to the
+// machine agent.
+func (fw *Firewaller) flushInstanceEgressRules(machined *machineData, toOpen, toClose []network.EgressRule, restrictedChanged bool) (err error) {
+	defer func() {
+		if params.IsCodeNotFound(err) {
+			err = nil
+		}
+	}()
+
+	fw.logger.Debugf("flush instance egress rules: to open %v, to close %v", toOpen, toClose)
+	if len(toOpen) == 0 && len(toClose) == 0 && !restrictedChanged {
+		return nil
+	}
+	m, err := machined.machine()
+	if err != nil {
+		return err
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		// Not provisioned yet, so nothing to do for this instance
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	envInstances, err := fw.environInstances.Instances(fw.cloudCallContext, []instance.Id{instanceId})
+	if err != nil {
+		return err
+	}
+	fwEgress, ok := envInstances[0].(instances.InstanceEgressFirewaller)
+	if !ok {
+		return fw.flushAgentEgressRules(machined)
+	}
+	err = fw.applyInstanceEgressRules(fwEgress, machined, toOpen, toClose, restrictedChanged)
+	if errors.IsNotSupported(err) {
+		fw.logger.Debugf("egress rules for %q not supported by the provider: %v", machined.tag, err)
+		return fw.flushAgentEgressRules(machined)
+	}
+	return err
+}
+
+// applyInstanceEgressRules opens and closes egress rules on the
+// instance of the machine, and restricts its egress or lifts the
+// restriction.
+func (fw *Firewaller) applyInstanceEgressRules(
+	fwEgress instances.InstanceEgressFirewaller,
+	machined *machineData,
+	toOpen, toClose []network.EgressRule,
+	restrictedChanged bool,
+) error {
+	machineId := machined.tag.Id()
+	// Open egress rules before restricting the egress or closing any,
+	// and lift the restriction before closing any, so that traffic
+	// which remains allowed is never dropped in between.
+	if len(toOpen) > 0 {
+		if err := fwEgress.OpenEgressRules(fw.cloudCallContext, machineId, toOpen); err != nil {
+			return err
+		}
+		fw.logger.Infof("opened egress rules %v on %q", toOpen, machined.tag)
+	}
+	if restrictedChanged {
+		if err := fwEgress.SetEgressRestricted(fw.cloudCallContext, machineId, machined.egressRestricted); err != nil {
+			return err
+		}
+		fw.logger.Infof("set egress restricted to %v on %q", machined.egressRestricted, machined.tag)
+	}
+	if len(toClose) > 0 {
+		if err := fwEgress.CloseEgressRules(fw.cloudCallContext, machineId, toClose); err != nil {
+			return err
+		}
+		fw.logger.Infof("closed egress rules %v on %q", toClose, machined.tag)
+	}
+	return nil
+}
+
+// flushAgentEgressRules records the egress rules of the machine for its
+// machine agent to enforce, for machines whose provider can't.
+func (fw *Firewaller) flushAgentEgressRules(machined *machineData) error {
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = m.SetEgressRules(machined.egressRestricted, machined.egressRules)
+	if errors.IsNotSupported(err) {
+		if machined.egressRestricted {
+			fw.logger.Warningf("egress rules for %q not enforced: %v", machined.tag, err)
+		}
+		return nil
+	}
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fw.logger.Debugf("set egress rules %v (restricted %v) for the agent of %q", machined.egressRules, machined.egressRestricted, machined.tag)
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -1045,6 +1353,10 @@ type machineData struct {
 	tag          names.MachineTag
 	unitds       map[names.UnitTag]*unitData
 	ingressRules []network.IngressRule
+	egressRules  []network.EgressRule
+	// egressRestricted is true if the outgoing traffic of the
+	// machine is restricted to its egress rules.
+	egressRestricted bool
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
 }
@@ -1094,23 +1406,44 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and egress rules for
+// one specific application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd     *applicationData
+	exposed          bool
+	egressRestricted bool
+	egressRules      []network.EgressRule
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb         catacomb.Catacomb
+	fw               *Firewaller
+	application      *firewaller.Application
+	exposed          bool
+	egressRestricted bool
+	egressRules      []network.EgressRule
+	unitds           map[names.UnitTag]*unitData
+}
+
+// applicationEgressRules returns the egress rules of the application,
+// and whether its egress is restricted to them. If the controller
+// doesn't support egress rules, the egress is unrestricted.
+func applicationEgressRules(app *firewaller.Application) ([]network.EgressRule, bool, error) {
+	rules, restricted, err := app.EgressRules()
+	if errors.IsNotSupported(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	network.SortEgressRules(rules)
+	return rules, restricted, nil
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and egress rules
+// for changes.
+func (ad *applicationData) watchLoop(exposed, egressRestricted bool, egressRules []network.EgressRule) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1137,17 +1470,29 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return errors.Trace(err)
 			}
-			if change == exposed {
-				ad.fw.logger.Tracef("application(%q).IsExposed() == %v (unchanged)", ad.application.Name(), exposed)
+			egressChange, restrictedChange, err := applicationEgressRules(ad.application)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					ad.fw.logger.Debugf("application(%q).EgressRules() returned NotFound: %v", ad.application.Name(), err)
+					return nil
+				}
+				return errors.Trace(err)
+			}
+			if change == exposed && restrictedChange == egressRestricted && egressRulesEqual(egressChange, egressRules) {
+				ad.fw.logger.Tracef("application(%q).IsExposed() == %v, egress rules unchanged", ad.application.Name(), exposed)
 				continue
 			}
 			ad.fw.logger.Tracef("application(%q).IsExposed() changed %v => %v", ad.application.Name(), exposed, change)
+			ad.fw.logger.Tracef("application(%q).EgressRules() changed %v => %v (restricted %v => %v)",
+				ad.application.Name(), egressRules, egressChange, egressRestricted, restrictedChange)
 
 			exposed = change
+			egressRestricted = restrictedChange
+			egressRules = egressChange
 			select {
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.exposedChange <- &exposedChange{ad, change, restrictedChange, egressChange}:
 			}
 		}
 	}
@@ -1239,6 +1584,20 @@ func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClos
 	return toOpen, toClose
 }
 
+// egressRulesEqual reports whether the sorted egress rules a and b
+// are the same.
+func egressRulesEqual(a, b []network.EgressRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
 // relationLifeChanged manages the workers to process ingress changes for
 // the specified relation.
 func (fw *Firewaller) relationLifeChanged(tag names.RelationTag) error {
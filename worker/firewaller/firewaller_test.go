@@ -160,6 +160,60 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Ing
 	}
 }
 
+// assertEgressRules retrieves the egress rules of the instance and
+// compares them and its restriction to the expected. The rules allowing
+// agents to reach the controller and resolve names are ignored.
+func (s *firewallerBaseSuite) assertEgressRules(c *gc.C, inst instances.Instance, machineId string, expected []network.EgressRule, restricted bool) {
+	fwInst, ok := inst.(instances.InstanceEgressFirewaller)
+	c.Assert(ok, gc.Equals, true)
+
+	apiPort := s.ControllerConfig.APIPort()
+	start := time.Now()
+	for {
+		s.BackingState.StartSync()
+		rules, gotRestricted, err := fwInst.EgressRules(s.callCtx, machineId)
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		var got []network.EgressRule
+		for _, rule := range rules {
+			if rule.Protocol == "tcp" && rule.FromPort == apiPort || rule.FromPort == 53 {
+				continue
+			}
+			got = append(got, rule)
+		}
+		network.SortEgressRules(got)
+		network.SortEgressRules(expected)
+		if reflect.DeepEqual(got, expected) && gotRestricted == restricted {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q (restricted %v); got %q (restricted %v)", expected, restricted, got, gotRestricted)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertHasEgressRules waits for the instance to have each of the
+// expected egress rules, along with any others.
+func (s *firewallerBaseSuite) assertHasEgressRules(c *gc.C, inst instances.Instance, machineId string, expected ...network.EgressRule) {
+	fwInst, ok := inst.(instances.InstanceEgressFirewaller)
+	c.Assert(ok, gc.Equals, true)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.BackingState.StartSync()
+		rules, _, err := fwInst.EgressRules(s.callCtx, machineId)
+		c.Assert(err, jc.ErrorIsNil)
+		if toOpen, _ := network.DiffEgressRules(rules, expected); len(toOpen) == 0 {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for egress rules %q", expected)
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, app *state.Application) (*state.Unit, *state.Machine) {
 	u, err := app.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -541,6 +595,110 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestAllowRevokeEgress(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	_, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	// No egress rules, so egress is unrestricted.
+	s.assertEgressRules(c, inst, m.Id(), nil, false)
+
+	err := app.AllowEgress(
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+		network.MustNewEgressRule("udp", 123, 123),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEgressRules(c, inst, m.Id(), []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+		network.MustNewEgressRule("udp", 123, 123, "0.0.0.0/0"),
+	}, true)
+
+	err = app.RevokeEgress(network.MustNewEgressRule("udp", 123, 123))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEgressRules(c, inst, m.Id(), []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+	}, true)
+
+	// Revoking the last rule leaves the egress restricted.
+	err = app.RevokeEgress(network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEgressRules(c, inst, m.Id(), nil, true)
+
+	err = app.ResetEgress()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEgressRules(c, inst, m.Id(), nil, false)
+}
+
+func (s *InstanceModeSuite) TestEgressUnrestrictedApplicationOnMachine(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.AllowEgress(network.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	s.assertEgressRules(c, inst, m.Id(), []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+	}, true)
+
+	// The other application on the machine has unrestricted egress,
+	// so the machine must stay unrestricted.
+	other := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	u, err := other.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEgressRules(c, inst, m.Id(), nil, false)
+}
+
+func (s *InstanceModeSuite) TestEgressFollowsControllerAddresses(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.AllowEgress(network.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	// The agents can always resolve names.
+	s.assertHasEgressRules(c, inst, m.Id(),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+		network.MustNewEgressRule("tcp", 53, 53, "0.0.0.0/0"),
+	)
+
+	// When the controller's addresses change, the agents can reach
+	// the new ones.
+	err = s.State.SetAPIHostPorts([]corenetwork.SpaceHostPorts{
+		corenetwork.NewSpaceHostPorts(17070, "10.1.2.3"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertHasEgressRules(c, inst, m.Id(),
+		network.MustNewEgressRule("tcp", 17070, 17070, "10.1.2.3/32"),
+	)
+
+	// Egress rules can't allow an IPv6 address, so the egress is no
+	// longer restricted.
+	err = s.State.SetAPIHostPorts([]corenetwork.SpaceHostPorts{
+		corenetwork.NewSpaceHostPorts(17070, "10.1.2.3", "2001:db8::1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, inst, m.Id(), nil, false)
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -1354,6 +1512,42 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestEgressRulesLeftToMachineAgent(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	_, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+
+	err := app.AllowEgress(network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The global firewall can't restrict the egress of the machine,
+	// so the rules are recorded for its agent to enforce.
+	apiPort := s.ControllerConfig.APIPort()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.BackingState.StartSync()
+		err := m.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		if !m.EgressRestricted() {
+			continue
+		}
+		var rules []network.EgressRule
+		for _, rule := range m.EgressRules() {
+			if rule.Protocol == "tcp" && rule.FromPort == apiPort || rule.FromPort == 53 {
+				continue
+			}
+			rules = append(rules, rule)
+		}
+		c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/24"),
+		})
+		return
+	}
+	c.Fatalf("timed out waiting for the egress of the machine to be restricted")
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedApplication(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
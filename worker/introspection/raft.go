@@ -0,0 +1,189 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/lease"
+)
+
+// LeaseReporter provides the contents of the raft lease store.
+type LeaseReporter interface {
+	// GlobalTime returns the time of the global clock that leases
+	// are measured against.
+	GlobalTime() time.Time
+
+	// Leases returns the current leases, with their expiry times
+	// relative to the time returned by getLocalTime.
+	Leases(getLocalTime func() time.Time, keys ...lease.Key) map[lease.Key]lease.Info
+
+	// Pinned returns the entities pinning each pinned lease.
+	Pinned() map[lease.Key][]string
+}
+
+// RaftReporter provides details of the agent's raft node.
+type RaftReporter interface {
+	// RaftStats returns statistics of the local raft node, such as
+	// its state and the index and term of its log.
+	RaftStats() (map[string]string, error)
+
+	// WriteRaftSnapshot takes a snapshot of the raft FSM and writes
+	// it to w.
+	WriteRaftSnapshot(w io.Writer) error
+}
+
+type leasesHandler struct {
+	leases LeaseReporter
+
+	// raft is the node that keeps the lease store up to date.
+	// Machine agents always have a lease store, but it only holds
+	// leases while the agent runs raft.
+	raft RaftReporter
+}
+
+// leaseDetails is the yaml representation of a lease.
+type leaseDetails struct {
+	Namespace string        `yaml:"namespace"`
+	ModelUUID string        `yaml:"model-uuid"`
+	Lease     string        `yaml:"lease"`
+	Holder    string        `yaml:"holder"`
+	ExpiresIn time.Duration `yaml:"expires-in"`
+	PinnedBy  []string      `yaml:"pinned-by,omitempty"`
+}
+
+// ServeHTTP is part of the http.Handler interface. The leases may be
+// filtered with the namespace, model (a model UUID prefix) and lease
+// query parameters.
+func (h leasesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.leases == nil || h.raft == nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "agent is not a controller")
+		return
+	}
+	if _, err := h.raft.RaftStats(); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "lease store not available: %v\n", err)
+		return
+	}
+	q := r.URL.Query()
+	now := time.Now()
+	pinned := h.leases.Pinned()
+	var details []leaseDetails
+	for key, info := range h.leases.Leases(func() time.Time { return now }) {
+		if ns := q.Get("namespace"); ns != "" && key.Namespace != ns {
+			continue
+		}
+		if model := q.Get("model"); model != "" && !strings.HasPrefix(key.ModelUUID, model) {
+			continue
+		}
+		if name := q.Get("lease"); name != "" && key.Lease != name {
+			continue
+		}
+		details = append(details, leaseDetails{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+			Holder:    info.Holder,
+			ExpiresIn: info.Expiry.Sub(now).Round(time.Second),
+			PinnedBy:  pinned[key],
+		})
+	}
+	sort.Slice(details, func(i, j int) bool {
+		a, b := details[i], details[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ModelUUID != b.ModelUUID {
+			return a.ModelUUID < b.ModelUUID
+		}
+		return a.Lease < b.Lease
+	})
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if q.Get("yaml") != "" {
+		bytes, err := yaml.Marshal(map[string]interface{}{
+			"global-time": h.leases.GlobalTime(),
+			"leases":      details,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "error: %v\n", err)
+			return
+		}
+		w.Write(bytes)
+		return
+	}
+
+	fmt.Fprintf(w, "Lease Report (global time %s):\n\n", h.leases.GlobalTime().UTC().Format(time.RFC3339))
+	tw := output.TabWriter(w)
+	wrapper := output.Wrapper{tw}
+	wrapper.Println("NAMESPACE", "MODEL", "LEASE", "HOLDER", "EXPIRES IN", "PINNED BY")
+	for _, d := range details {
+		expiry := d.ExpiresIn.String()
+		if len(d.PinnedBy) > 0 {
+			expiry = "pinned"
+		}
+		wrapper.Println(d.Namespace, d.ModelUUID, d.Lease, d.Holder, expiry, strings.Join(d.PinnedBy, ", "))
+	}
+	tw.Flush()
+}
+
+type raftHandler struct {
+	raft RaftReporter
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h raftHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.raft == nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "agent is not a controller")
+		return
+	}
+	stats, err := h.raft.RaftStats()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+	bytes, err := yaml.Marshal(stats)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, "Raft Report:\n\n")
+	w.Write(bytes)
+}
+
+type raftSnapshotHandler struct {
+	raft RaftReporter
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h raftSnapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.raft == nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "agent is not a controller")
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := h.raft.WriteRaftSnapshot(w); err != nil {
+		// If any of the snapshot has been written, the status can
+		// no longer be changed; the error is logged either way.
+		logger.Errorf("writing raft snapshot: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+	}
+}
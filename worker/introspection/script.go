@@ -125,6 +125,29 @@ juju_statetracker_report () {
   juju_agent debug/pprof/juju/state/tracker?debug=1 $@
 }
 
+# Lists the leases in the raft lease store. Leases may be filtered by
+# passing query parameters, e.g.
+#   juju_leases "namespace=application-leadership&model=<uuid>"
+# and shown as yaml with "yaml=1".
+juju_leases () {
+  local query=
+  if [ -n "$1" ]; then
+    query="?$1"
+    shift
+  fi
+  juju_agent "leases$query" $@
+}
+
+juju_raft_report () {
+  juju_agent raft $@
+}
+
+# Takes a snapshot of the raft lease store and writes it to stdout, e.g.
+#   juju_raft_snapshot > raft-snapshot.yaml
+juju_raft_snapshot () {
+  juju_agent raft/snapshot $@
+}
+
 juju_machine_lock () {
   for agent in $(ls /var/lib/juju/agents); do
     juju_agent machinelock $agent 2> /dev/null
@@ -149,6 +172,9 @@ if [ "$shell" = "bash" ]; then
   export -f juju_statetracker_report
   export -f juju_pubsub_report
   export -f juju_presence_report
+  export -f juju_leases
+  export -f juju_raft_report
+  export -f juju_raft_snapshot
   export -f juju_machine_lock
 fi
 `
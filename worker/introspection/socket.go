@@ -48,6 +48,8 @@ type Config struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
+	Leases             LeaseReporter
+	Raft               RaftReporter
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -71,6 +73,8 @@ type socketListener struct {
 	machineLock        machinelock.Lock
 	prometheusGatherer prometheus.Gatherer
 	presence           presence.Recorder
+	leases             LeaseReporter
+	raft               RaftReporter
 	done               chan struct{}
 }
 
@@ -104,6 +108,8 @@ func NewWorker(config Config) (worker.Worker, error) {
 		machineLock:        config.MachineLock,
 		prometheusGatherer: config.PrometheusGatherer,
 		presence:           config.Presence,
+		leases:             config.Leases,
+		raft:               config.Raft,
 		done:               make(chan struct{}),
 	}
 	go w.serve()
@@ -121,7 +127,13 @@ func (w *socketListener) serve() {
 			MachineLock:        w.machineLock,
 			PrometheusGatherer: w.prometheusGatherer,
 			Presence:           w.presence,
+			Leases:             w.leases,
+			Raft:               w.raft,
 		}, mux.Handle)
+	// Taking a raft snapshot is only served on the local socket,
+	// as it makes the controller do work rather than just report;
+	// it is not registered with the API server's handlers.
+	mux.Handle("/raft/snapshot", raftSnapshotHandler{w.raft})
 
 	srv := http.Server{Handler: mux}
 	logger.Debugf("stats worker now serving")
@@ -159,6 +171,8 @@ type ReportSources struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
+	Leases             LeaseReporter
+	Raft               RaftReporter
 }
 
 // AddHandlers calls the given function with http.Handlers
@@ -189,6 +203,8 @@ func RegisterHTTPHandlers(
 		handle("/presence/", presenceHandler{sources.Presence})
 	}
 	handle("/machinelock/", machineLockHandler{sources.MachineLock})
+	handle("/leases", leasesHandler{leases: sources.Leases, raft: sources.Raft})
+	handle("/raft", raftHandler{sources.Raft})
 }
 
 type depengineHandler struct {
@@ -7,14 +7,18 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"regexp"
 	"runtime"
 	"time"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,6 +26,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/core/lease"
 	// Bring in the state package for the tracker profile.
 	"github.com/juju/juju/core/presence"
 	_ "github.com/juju/juju/state"
@@ -53,6 +58,19 @@ func (s *suite) TestStartStop(c *gc.C) {
 	workertest.CheckKill(c, w)
 }
 
+func (s *suite) TestRegisterHTTPHandlersOmitsRaftSnapshot(c *gc.C) {
+	// Taking a snapshot is only served on the local socket, not by
+	// the API server, which registers the handlers with this.
+	paths := set.NewStrings()
+	introspection.RegisterHTTPHandlers(introspection.ReportSources{
+		PrometheusGatherer: prometheus.NewRegistry(),
+	}, func(path string, _ http.Handler) {
+		paths.Add(path)
+	})
+	c.Assert(paths.Contains("/raft"), jc.IsTrue)
+	c.Assert(paths.Contains("/raft/snapshot"), jc.IsFalse)
+}
+
 type introspectionSuite struct {
 	testing.IsolationSuite
 
@@ -61,6 +79,8 @@ type introspectionSuite struct {
 	reporter introspection.DepEngineReporter
 	gatherer prometheus.Gatherer
 	recorder presence.Recorder
+	leases   introspection.LeaseReporter
+	raft     introspection.RaftReporter
 }
 
 var _ = gc.Suite(&introspectionSuite{})
@@ -73,6 +93,8 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	s.reporter = nil
 	s.worker = nil
 	s.recorder = nil
+	s.leases = nil
+	s.raft = nil
 	s.gatherer = newPrometheusGatherer()
 	s.startWorker(c)
 }
@@ -84,6 +106,8 @@ func (s *introspectionSuite) startWorker(c *gc.C) {
 		DepEngine:          s.reporter,
 		PrometheusGatherer: s.gatherer,
 		Presence:           s.recorder,
+		Leases:             s.leases,
+		Raft:               s.raft,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.worker = w
@@ -211,6 +235,81 @@ func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	matches(c, buf, "tau 6.283185")
 }
 
+func (s *introspectionSuite) TestMissingLeaseReporter(c *gc.C) {
+	buf := s.call(c, "/leases")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "agent is not a controller")
+}
+
+func (s *introspectionSuite) TestLeaseReporterRaftNotRunning(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.leases = newFakeLeases()
+	s.raft = &fakeRaft{err: errors.New("agent is not running raft")}
+	s.startWorker(c)
+
+	buf := s.call(c, "/leases")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "lease store not available: agent is not running raft")
+}
+
+func (s *introspectionSuite) TestLeaseReporter(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.leases = newFakeLeases()
+	s.raft = &fakeRaft{}
+	s.startWorker(c)
+
+	buf := s.call(c, "/leases")
+	matches(c, buf, "200 OK")
+	matches(c, buf, `^Lease Report \(global time 2020-03-01T12:00:00Z\):$`)
+	matches(c, buf, `^NAMESPACE +MODEL +LEASE +HOLDER +EXPIRES IN +PINNED BY$`)
+	matches(c, buf, `^application-leadership +deadbeef-0bad-400d-8000-4b1d0d06f00d +mysql +mysql/0 +pinned +machine-0$`)
+	matches(c, buf, `^application-leadership +deadbeef-0bad-400d-8000-4b1d0d06f00d +wordpress +wordpress/1 +45s *$`)
+	matches(c, buf, `^singular-controller +deadbeef-0bad-400d-8000-4b1d0d06f00d +deadbeef-0bad-400d-8000-4b1d0d06f00d +machine-1 +1m0s *$`)
+}
+
+func (s *introspectionSuite) TestLeaseReporterFiltered(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.leases = newFakeLeases()
+	s.raft = &fakeRaft{}
+	s.startWorker(c)
+
+	buf := s.call(c, "/leases?namespace=application-leadership&model=deadbeef&yaml=1")
+	matches(c, buf, "200 OK")
+	matches(c, buf, `^- namespace: application-leadership$`)
+	matches(c, buf, `^  lease: wordpress$`)
+	c.Assert(bytes.Contains(buf, []byte("singular-controller")), jc.IsFalse)
+}
+
+func (s *introspectionSuite) TestMissingRaftReporter(c *gc.C) {
+	buf := s.call(c, "/raft")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "agent is not a controller")
+	buf = s.call(c, "/raft/snapshot")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "agent is not a controller")
+}
+
+func (s *introspectionSuite) TestRaftReporter(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.raft = &fakeRaft{
+		stats:    map[string]string{"state": "Leader", "last_log_index": "42", "last_log_term": "3"},
+		snapshot: "version: 1\n",
+	}
+	s.startWorker(c)
+
+	buf := s.call(c, "/raft")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "^Raft Report:$")
+	matches(c, buf, `^last_log_index: "42"$`)
+	matches(c, buf, `^last_log_term: "3"$`)
+	matches(c, buf, "^state: Leader$")
+
+	buf = s.call(c, "/raft/snapshot")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "^Content-Type: application/octet-stream")
+	matches(c, buf, "^version: 1$")
+}
+
 // matches fails if regex is not found in the contents of b.
 // b is expected to be the response from the pprof http server, and will
 // contain some HTTP preamble that should be ignored.
@@ -235,6 +334,66 @@ func (r *reporter) Report() map[string]interface{} {
 	return r.values
 }
 
+type fakeLeases struct {
+	leases map[lease.Key]time.Duration
+	holder map[lease.Key]string
+	pinned map[lease.Key][]string
+}
+
+func newFakeLeases() *fakeLeases {
+	uuid := "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	mysql := lease.Key{Namespace: "application-leadership", ModelUUID: uuid, Lease: "mysql"}
+	wordpress := lease.Key{Namespace: "application-leadership", ModelUUID: uuid, Lease: "wordpress"}
+	controller := lease.Key{Namespace: "singular-controller", ModelUUID: uuid, Lease: uuid}
+	return &fakeLeases{
+		leases: map[lease.Key]time.Duration{
+			mysql:      30 * time.Second,
+			wordpress:  45 * time.Second,
+			controller: time.Minute,
+		},
+		holder: map[lease.Key]string{
+			mysql:      "mysql/0",
+			wordpress:  "wordpress/1",
+			controller: "machine-1",
+		},
+		pinned: map[lease.Key][]string{
+			mysql: {"machine-0"},
+		},
+	}
+}
+
+func (f *fakeLeases) GlobalTime() time.Time {
+	return time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (f *fakeLeases) Leases(getLocalTime func() time.Time, keys ...lease.Key) map[lease.Key]lease.Info {
+	now := getLocalTime()
+	result := make(map[lease.Key]lease.Info)
+	for key, remaining := range f.leases {
+		result[key] = lease.Info{Holder: f.holder[key], Expiry: now.Add(remaining)}
+	}
+	return result
+}
+
+func (f *fakeLeases) Pinned() map[lease.Key][]string {
+	return f.pinned
+}
+
+type fakeRaft struct {
+	stats    map[string]string
+	snapshot string
+	err      error
+}
+
+func (f *fakeRaft) RaftStats() (map[string]string, error) {
+	return f.stats, f.err
+}
+
+func (f *fakeRaft) WriteRaftSnapshot(w io.Writer) error {
+	_, err := io.WriteString(w, f.snapshot)
+	return err
+}
+
 func newPrometheusGatherer() prometheus.Gatherer {
 	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "tau", Help: "Tau."})
 	counter.Add(6.283185)
@@ -31,21 +31,37 @@ func (b block) invoke(ch chan<- block) error {
 	}
 }
 
+// blockedLease holds the expiry-notification channels for a lease key,
+// and the holder of the lease when they were first added.
+type blockedLease struct {
+	holder   string
+	unblocks []chan struct{}
+}
+
 // blocks is used to keep track of expiry-notification channels for
 // each lease key.
-type blocks map[lease.Key][]chan struct{}
+type blocks map[lease.Key]*blockedLease
 
 // add records the block's unblock channel under the block's lease key.
-func (b blocks) add(block block) {
-	b[block.leaseKey] = append(b[block.leaseKey], block.unblock)
+// The holder is the current holder of the lease.
+func (b blocks) add(block block, holder string) {
+	blocked, found := b[block.leaseKey]
+	if !found {
+		blocked = &blockedLease{holder: holder}
+		b[block.leaseKey] = blocked
+	}
+	blocked.unblocks = append(blocked.unblocks, block.unblock)
 }
 
 // unblock closes all channels added under the supplied key and removes
 // them from blocks.
 func (b blocks) unblock(lease lease.Key) {
-	unblocks := b[lease]
+	blocked, found := b[lease]
+	if !found {
+		return
+	}
 	delete(b, lease)
-	for _, unblock := range unblocks {
+	for _, unblock := range blocked.unblocks {
 		close(unblock)
 	}
 }
@@ -17,6 +17,7 @@ type broker interface {
 	lease.Claimer
 	lease.Pinner
 	lease.Reader
+	lease.Transferer
 }
 
 // boundManager implements the broker interface.
@@ -98,6 +99,32 @@ func (b *boundManager) Leases() map[string]string {
 	return b.manager.leases(b.namespace, b.modelUUID)
 }
 
+// Transfer (lease.Transferer) sends a transfer message to the worker loop.
+func (b *boundManager) Transfer(leaseName, holderName, newHolderName string, duration time.Duration) error {
+	key := b.leaseKey(leaseName)
+	if err := b.secretary.CheckLease(key); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease %q", leaseName)
+	}
+	if err := b.secretary.CheckHolder(holderName); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease from holder %q", holderName)
+	}
+	if err := b.secretary.CheckHolder(newHolderName); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease to holder %q", newHolderName)
+	}
+	if err := b.secretary.CheckDuration(duration); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease for %s", duration)
+	}
+
+	return errors.Trace(transfer{
+		leaseKey:      key,
+		holderName:    holderName,
+		newHolderName: newHolderName,
+		duration:      duration,
+		response:      make(chan error),
+		stop:          b.manager.catacomb.Dying(),
+	}.invoke(b.manager.transfers))
+}
+
 // pinOp creates a pin instance from the input lease name,
 // then sends it on the input channel.
 func (b *boundManager) pinOp(leaseName string, entity string, ch chan pin) error {
@@ -93,6 +93,7 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		expireDone: make(chan struct{}),
 		pins:       make(chan pin),
 		unpins:     make(chan pin),
+		transfers:  make(chan transfer),
 		logContext: logContext,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
@@ -146,6 +147,9 @@ type Manager struct {
 	// unpins is used to deliver lease unpin requests to the loop.
 	unpins chan pin
 
+	// transfers is used to deliver lease transfer requests to the loop.
+	transfers chan transfer
+
 	// wg is used to ensure that all child goroutines are finished
 	// before we stop.
 	wg sync.WaitGroup
@@ -212,12 +216,15 @@ func (manager *Manager) choose(blocks blocks) error {
 		manager.handlePin(pin)
 	case unpin := <-manager.unpins:
 		manager.handleUnpin(unpin)
+	case transfer := <-manager.transfers:
+		manager.handleTransfer(transfer)
 	case block := <-manager.blocks:
 		// TODO(raftlease): Include the other key items.
 		manager.config.Logger.Tracef("[%s] adding block for: %s", manager.logContext, block.leaseKey.Lease)
-		blocks.add(block)
+		info, exists := manager.lookupLease(block.leaseKey)
+		blocks.add(block, info.Holder)
 
-		if _, exists := manager.lookupLease(block.leaseKey); !exists {
+		if !exists {
 			// Nobody holds this lease, so immediately unblock it.
 			blocks.unblock(block.leaseKey)
 		}
@@ -258,6 +265,11 @@ func (manager *Manager) Reader(namespace, modelUUID string) (lease.Reader, error
 	return manager.bind(namespace, modelUUID)
 }
 
+// Transferer returns a lease.Transferer for the specified namespace and model.
+func (manager *Manager) Transferer(namespace, modelUUID string) (lease.Transferer, error) {
+	return manager.bind(namespace, modelUUID)
+}
+
 // retryingClaim handles timeouts when claiming, and responds to the
 // claiming party when it eventually succeeds or fails, or if it times
 // out after a number of retries.
@@ -425,10 +437,16 @@ func (manager *Manager) checkBlocks(blocks blocks) {
 	now := manager.config.Clock.Now()
 	manager.config.Logger.Tracef("[%s] evaluating %d blocks", manager.logContext, len(blocks))
 	leases := manager.config.Store.Leases()
-	for leaseName := range blocks {
-		if _, found := leases[leaseName]; !found {
+	for leaseName, blocked := range blocks {
+		if info, found := leases[leaseName]; !found {
 			manager.config.Logger.Tracef("[%s] unblocking: %s", manager.logContext, leaseName)
 			blocks.unblock(leaseName)
+		} else if info.Holder != blocked.holder {
+			// The lease changed hands without expiring, because it was
+			// transferred. Any of the waiters may be the new holder.
+			manager.config.Logger.Tracef("[%s] unblocking: %s (transferred to %s)",
+				manager.logContext, leaseName, info.Holder)
+			blocks.unblock(leaseName)
 		}
 	}
 	manager.computeNextTimeout(now, leases)
@@ -612,6 +630,40 @@ func (manager *Manager) handleUnpin(p pin) {
 	p.respond(errors.Trace(manager.config.Store.UnpinLease(p.leaseKey, p.entity, manager.catacomb.Dying())))
 }
 
+// handleTransfer processes the supplied transfer. The current holder
+// keeps the lease for the rest of the time it was promised, but can't
+// extend it, so its agent finds out it is no longer the holder when it
+// next tries to. Once that claim runs out the lease passes straight to
+// the new holder, so nobody else can claim it in between; waiters are
+// unblocked when checkBlocks sees the change of holder.
+func (manager *Manager) handleTransfer(t transfer) {
+	info, found := manager.lookupLease(t.leaseKey)
+	if !found || info.Holder != t.holderName {
+		t.respond(lease.ErrNotHeld)
+		return
+	}
+	err := manager.config.Store.TransferLease(t.leaseKey, t.holderName, lease.Request{
+		Holder:   t.newHolderName,
+		Duration: t.duration,
+	}, manager.catacomb.Dying())
+	if lease.IsInvalid(err) {
+		// Either the lease is pinned, or the holder has changed
+		// since we looked.
+		if _, pinned := manager.config.Store.Pinned()[t.leaseKey]; pinned {
+			err = lease.ErrPinned
+		} else {
+			err = lease.ErrNotHeld
+		}
+	}
+	if err != nil {
+		t.respond(errors.Trace(err))
+		return
+	}
+	manager.config.Logger.Debugf("[%s] transferring lease %s from %s to %s for %s",
+		manager.logContext, t.leaseKey.Lease, t.holderName, t.newHolderName, t.duration)
+	t.respond(nil)
+}
+
 // pinned returns lease names and the entities requiring their pinned
 // behaviour, from the input namespace/model for which leases are pinned.
 func (manager *Manager) pinned(namespace, modelUUID string) map[string][]string {
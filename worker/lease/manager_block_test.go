@@ -95,9 +95,11 @@ func (s *WaitUntilExpiredSuite) TestLeadershipChanged(c *gc.C) {
 		blockTest := newBlockTest(c, manager, key("redis"))
 		blockTest.assertBlocked(c)
 
-		// Trigger abortive expiry.
+		// Trigger abortive expiry; the lease has changed hands, so
+		// redis/0's hold on it is over.
 		clock.Advance(time.Second)
-		blockTest.assertBlocked(c)
+		err := blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
 	})
 }
 
@@ -0,0 +1,158 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type TransferSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TransferSuite{})
+
+func (s *TransferSuite) TestTransferLease_Success(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args:   []interface{}{key("redis"), "redis/0", corelease.Request{"redis/1", time.Minute}},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferer(c, manager).Transfer("redis", "redis/0", "redis/1", time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransferLease_UnblocksWaitersOnHandover(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args:   []interface{}{key("redis"), "redis/0", corelease.Request{"redis/1", time.Minute}},
+		}, {
+			method: "Refresh",
+		}, {
+			method: "ExpireLease",
+			args:   []interface{}{key("redis")},
+			err:    corelease.ErrInvalid,
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[key("redis")] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testclock.Clock) {
+		blockTest := newBlockTest(c, manager, key("redis"))
+		blockTest.assertBlocked(c)
+
+		err := getTransferer(c, manager).Transfer("redis", "redis/0", "redis/1", time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+
+		// The current holder keeps the lease until it runs out.
+		blockTest.assertBlocked(c)
+
+		// It then passes straight to the new holder, so waiters are
+		// woken to find out whether it's theirs.
+		c.Assert(clock.WaitAdvance(time.Second, testing.ShortWait, 1), jc.ErrorIsNil)
+		err = blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransferLease_NotHeld(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/1",
+				Expiry: offset(time.Minute),
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferer(c, manager).Transfer("redis", "redis/0", "redis/2", time.Minute)
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrNotHeld)
+	})
+}
+
+func (s *TransferSuite) TestTransferLease_Pinned(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args:   []interface{}{key("redis"), "redis/0", corelease.Request{"redis/1", time.Minute}},
+			err:    corelease.ErrInvalid,
+		}, {
+			method: "Pinned",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferer(c, manager).Transfer("redis", "redis/0", "redis/1", time.Minute)
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrPinned)
+	})
+}
+
+func (s *TransferSuite) TestTransferLease_Error(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args:   []interface{}{key("redis"), "redis/0", corelease.Request{"redis/1", time.Minute}},
+			err:    errors.New("boom"),
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferer(c, manager).Transfer("redis", "redis/0", "redis/1", time.Minute)
+		c.Check(err, gc.ErrorMatches, "boom")
+	})
+}
+
+func (s *TransferSuite) TestTransferLease_InvalidHolder(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferer(c, manager).Transfer("redis", "INVALID", "redis/1", time.Minute)
+		c.Check(err, gc.ErrorMatches, `cannot transfer lease from holder "INVALID": name not valid`)
+		err = getTransferer(c, manager).Transfer("redis", "redis/0", "INVALID", time.Minute)
+		c.Check(err, gc.ErrorMatches, `cannot transfer lease to holder "INVALID": name not valid`)
+	})
+}
+
+func getTransferer(c *gc.C, manager *lease.Manager) corelease.Transferer {
+	transferer, err := manager.Transferer("namespace", "modelUUID")
+	c.Assert(err, jc.ErrorIsNil)
+	return transferer
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"time"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// transfer is used to deliver lease transfer requests to a manager's
+// worker loop on behalf of TransferLeadership.
+type transfer struct {
+	leaseKey      lease.Key
+	holderName    string
+	newHolderName string
+	duration      time.Duration
+	response      chan error
+	stop          <-chan struct{}
+}
+
+// invoke sends the transfer on the supplied channel and waits for a
+// response.
+func (t transfer) invoke(ch chan<- transfer) error {
+	for {
+		select {
+		case <-t.stop:
+			return errStopped
+		case ch <- t:
+			ch = nil
+		case err := <-t.response:
+			return err
+		}
+	}
+}
+
+// respond causes the supplied error to be sent back to invoke.
+func (t transfer) respond(err error) {
+	select {
+	case <-t.stop:
+	case t.response <- err:
+	}
+}
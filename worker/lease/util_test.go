@@ -207,6 +207,11 @@ func (store *Store) ExpireLease(key lease.Key) error {
 	return store.call("ExpireLease", []interface{}{key})
 }
 
+// TransferLease is part of the corelease.Store interface.
+func (store *Store) TransferLease(key lease.Key, holder string, request lease.Request, stop <-chan struct{}) error {
+	return store.call("TransferLease", []interface{}{key, holder, request})
+}
+
 // Refresh is part of the lease.Store interface.
 func (store *Store) Refresh() error {
 	return store.call("Refresh", nil)
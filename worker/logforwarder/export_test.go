@@ -0,0 +1,16 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"gopkg.in/juju/worker.v1"
+)
+
+func NewOrchestratorForController(args OrchestratorArgs) (worker.Worker, error) {
+	o, err := newOrchestratorForController(args)
+	if o == nil {
+		return nil, err
+	}
+	return o, err
+}
@@ -41,9 +41,6 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
@@ -68,6 +65,10 @@ type OpenLogForwarderArgs struct {
 	// Name is the name given to the log sink.
 	Name string
 
+	// Kind identifies the kind of log sink, and so which log
+	// forwarding config applies to it.
+	Kind string
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -79,7 +80,7 @@ type OpenLogForwarderArgs struct {
 	Logger Logger
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -94,13 +95,13 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	cfg, ok, err := lf.args.LogForwardConfig.LogForwardSinkConfig(lf.args.Kind)
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		lf.args.Logger.Infof("config change - log forwarding not enabled")
+	if !ok || !cfg.IsEnabled() {
+		lf.args.Logger.Infof("config change - log forwarding to %s not enabled", lf.args.Name)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
@@ -108,7 +109,7 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	// config change to come through.
 	// We'll continue sending using the current sink.
 	if err := cfg.Validate(); err != nil {
-		lf.args.Logger.Errorf("invalid log forward config change: %v", err)
+		lf.args.Logger.Errorf("invalid log forward config change for %s: %v", lf.args.Name, err)
 		return currentSender, nil
 	}
 
@@ -148,7 +149,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		lf.args.Logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		lf.args.Logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.Kind)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -233,7 +234,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
@@ -85,8 +85,10 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		Name:             "juju-log-forward",
+		Kind:             logfwd.SinkSyslog,
+		OpenSink: func(cfg logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -207,6 +209,18 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestUnknownKind(c *gc.C) {
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.Kind = "carrier-pigeon"
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	err = workertest.CheckKilled(c, lf)
+	c.Check(err, gc.ErrorMatches, `log forwarding sink kind "carrier-pigeon" not valid`)
+	s.sender.stub.CheckCallNames(c)
+}
+
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
@@ -249,7 +263,10 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+func (c *mockLogForwardConfig) LogForwardSinkConfig(kind string) (logfwd.SinkConfig, bool, error) {
+	if kind != logfwd.SinkSyslog {
+		return nil, false, errors.NotValidf("log forwarding sink kind %q", kind)
+	}
 	return &syslog.RawConfig{
 		Enabled:    c.enabled,
 		Host:       c.host,
@@ -5,12 +5,16 @@ package logforwarder
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/api/base"
 )
 
+// orchestrator runs a log forwarder for each log sink, and fails if
+// any of them do.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -40,22 +44,51 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
 	if len(args.Sinks) == 0 {
 		return nil, nil
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
+	var forwarders []worker.Worker
+	for _, spec := range args.Sinks {
+		lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+			ControllerUUID:   args.ControllerUUID,
+			LogForwardConfig: args.LogForwardConfig,
+			Caller:           args.Caller,
+			Name:             spec.Name,
+			Kind:             spec.Kind,
+			OpenSink:         spec.OpenFn,
+			OpenLogStream:    args.OpenLogStream,
+			Logger:           args.Logger,
+		})
+		if err != nil {
+			for _, w := range forwarders {
+				_ = worker.Stop(w)
+			}
+			return nil, errors.Annotatef(err, "opening log forwarder %q", spec.Name)
+		}
+		forwarders = append(forwarders, lf)
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
-		Logger:           args.Logger,
+
+	o := &orchestrator{}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: func() error {
+			<-o.catacomb.Dying()
+			return o.catacomb.ErrDying()
+		},
+		Init: forwarders,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
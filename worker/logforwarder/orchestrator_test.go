@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type OrchestratorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&OrchestratorSuite{})
+
+func (s *OrchestratorSuite) args(open func(logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error)) logforwarder.OrchestratorArgs {
+	return logforwarder.OrchestratorArgs{
+		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		LogForwardConfig: &mockLogForwardConfig{},
+		Caller:           &mockCaller{},
+		Sinks: []logforwarder.LogSinkSpec{
+			{Name: "juju-log-forward", Kind: logfwd.SinkSyslog},
+			{Name: "juju-log-forward-json", Kind: logfwd.SinkJSON},
+		},
+		OpenLogForwarder: open,
+		Logger:           loggo.GetLogger("test"),
+	}
+}
+
+func (s *OrchestratorSuite) TestNoSinks(c *gc.C) {
+	args := s.args(nil)
+	args.Sinks = nil
+	w, err := logforwarder.NewOrchestratorForController(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *OrchestratorSuite) TestForwarderPerSink(c *gc.C) {
+	var opened []logforwarder.OpenLogForwarderArgs
+	w, err := logforwarder.NewOrchestratorForController(s.args(
+		func(args logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+			opened = append(opened, args)
+			// The config is never enabled, so the forwarders
+			// just wait for it to change.
+			return logforwarder.NewLogForwarder(logforwarder.OpenLogForwarderArgs{
+				LogForwardConfig: &mockLogForwardConfig{},
+				Kind:             logfwd.SinkSyslog,
+				Logger:           args.Logger,
+			})
+		},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+
+	c.Assert(opened, gc.HasLen, 2)
+	c.Check(opened[0].Name, gc.Equals, "juju-log-forward")
+	c.Check(opened[0].Kind, gc.Equals, logfwd.SinkSyslog)
+	c.Check(opened[1].Name, gc.Equals, "juju-log-forward-json")
+	c.Check(opened[1].Kind, gc.Equals, logfwd.SinkJSON)
+	c.Check(opened[1].ControllerUUID, gc.Equals, "feebdaed-2f18-4fd2-967d-db9663db7bea")
+}
+
+func (s *OrchestratorSuite) TestOpenError(c *gc.C) {
+	var first *logforwarder.LogForwarder
+	_, err := logforwarder.NewOrchestratorForController(s.args(
+		func(args logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+			if first != nil {
+				return nil, errors.New("boom")
+			}
+			var err error
+			first, err = logforwarder.NewLogForwarder(logforwarder.OpenLogForwarderArgs{
+				LogForwardConfig: &mockLogForwardConfig{},
+				Kind:             logfwd.SinkSyslog,
+				Logger:           args.Logger,
+			})
+			return first, err
+		},
+	))
+	c.Assert(err, gc.ErrorMatches, `opening log forwarder "juju-log-forward-json": boom`)
+	// The forwarder that was opened has been stopped.
+	workertest.CheckKilled(c, first)
+}
@@ -5,7 +5,7 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd"
 )
 
 // LogForwardConfig provides access to the log forwarding config for a model.
@@ -14,20 +14,27 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	// LogForwardSinkConfig returns the current log forward
+	// configuration for the given kind of sink.
+	LogForwardSinkConfig(kind string) (logfwd.SinkConfig, bool, error)
 }
 
+// LogSinkSpec describes a log sink to which records may be forwarded.
 type LogSinkSpec struct {
-	// Name is the name of the log sink.
+	// Name is the name of the log sink. It is used to track the
+	// last record forwarded to the sink, so must not change.
 	Name string
 
+	// Kind identifies the kind of sink (e.g. logfwd.SinkSyslog), and
+	// so which model config applies to it.
+	Kind string
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg logfwd.SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
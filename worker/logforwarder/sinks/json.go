@@ -0,0 +1,31 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/jsonlines"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenJSON returns a sink that forwards log messages as
+// newline-delimited JSON over TCP or TLS.
+func OpenJSON(sinkCfg logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*jsonlines.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected JSON log config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := jsonlines.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
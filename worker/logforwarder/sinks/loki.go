@@ -0,0 +1,30 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/loki"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenLoki returns a sink that pushes log messages to a Loki endpoint.
+func OpenLoki(sinkCfg logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*loki.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected Loki config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := loki.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -1203,6 +1203,12 @@ func (task *provisionerTask) startMachine(
 		if err2 := task.broker.StopInstances(task.cloudCallCtx, instanceID); err2 != nil {
 			task.logger.Errorf("%v", errors.Annotate(err2, "after failing to set instance info"))
 		}
+		if params.IsCodeQuotaExceeded(err) {
+			// The instance's hardware takes the model over one of its
+			// quotas. The machine is left in error, as with any other
+			// failure to start it, and the other machines carry on.
+			return nil
+		}
 		return errors.Annotate(err, "cannot set instance info")
 	}
 
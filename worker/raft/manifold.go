@@ -27,6 +27,13 @@ type ManifoldConfig struct {
 	Logger               Logger
 	PrometheusRegisterer prometheus.Registerer
 	NewWorker            func(Config) (worker.Worker, error)
+
+	// SetWorker, if non-nil, is called with each raft worker when it
+	// is started. This is used for publishing the raft node to the
+	// agent's introspection worker, which runs outside of the
+	// dependency engine; hence the manifold's Output cannot be relied
+	// upon.
+	SetWorker func(*Worker)
 }
 
 // Validate validates the manifold configuration.
@@ -91,7 +98,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	agentConfig := agent.CurrentConfig()
 	raftDir := filepath.Join(agentConfig.DataDir(), "raft")
 
-	return config.NewWorker(Config{
+	w, err := config.NewWorker(Config{
 		FSM:                  config.FSM,
 		Logger:               config.Logger,
 		StorageDir:           raftDir,
@@ -100,6 +107,13 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Clock:                clk,
 		PrometheusRegisterer: config.PrometheusRegisterer,
 	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if raftWorker, ok := w.(*Worker); ok && config.SetWorker != nil {
+		config.SetWorker(raftWorker)
+	}
+	return w, nil
 }
 
 func raftOutput(in worker.Worker, out interface{}) error {
@@ -4,6 +4,8 @@
 package raftclusterer
 
 import (
+	"sort"
+
 	"github.com/hashicorp/raft"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -12,6 +14,7 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/pubsub/controller"
 )
 
 var (
@@ -43,17 +46,31 @@ func NewWorker(config Config) (worker.Worker, error) {
 		return nil, errors.Trace(err)
 	}
 	w := &Worker{
-		config:        config,
-		serverDetails: make(chan apiserver.Details),
+		config:         config,
+		serverDetails:  make(chan apiserver.Details),
+		statusRequests: make(chan controller.RaftStatusRequest),
 	}
 	// Subscribe to API server address changes.
-	unsubscribe, err := config.Hub.Subscribe(
+	unsubscribeDetails, err := config.Hub.Subscribe(
 		apiserver.DetailsTopic,
 		w.apiserverDetailsChanged,
 	)
 	if err != nil {
 		return nil, errors.Annotate(err, "subscribing to apiserver details")
 	}
+	// Subscribe to requests for the cluster status.
+	unsubscribeStatus, err := config.Hub.Subscribe(
+		controller.RaftStatusRequestTopic,
+		w.raftStatusRequested,
+	)
+	if err != nil {
+		unsubscribeDetails()
+		return nil, errors.Annotate(err, "subscribing to raft status requests")
+	}
+	unsubscribe := func() {
+		unsubscribeDetails()
+		unsubscribeStatus()
+	}
 	// Now that we're subscribed, request the current API server details.
 	req := apiserver.DetailsRequest{
 		Requester: "raft-clusterer",
@@ -81,7 +98,8 @@ type Worker struct {
 	catacomb catacomb.Catacomb
 	config   Config
 
-	serverDetails chan apiserver.Details
+	serverDetails  chan apiserver.Details
+	statusRequests chan controller.RaftStatusRequest
 }
 
 // Kill is part of the worker.Worker interface.
@@ -110,6 +128,10 @@ func (w *Worker) loop() error {
 			if err != nil {
 				return errors.Annotate(err, "updating raft configuration")
 			}
+		case req := <-w.statusRequests:
+			if err := w.publishStatus(req); err != nil {
+				return errors.Annotate(err, "publishing raft status")
+			}
 		}
 	}
 }
@@ -129,6 +151,34 @@ func (w *Worker) getConfiguration() (map[raft.ServerID]*raft.Server, uint64, err
 	return servers, prevIndex, nil
 }
 
+// publishStatus publishes the current raft cluster configuration in
+// response to the given request.
+func (w *Worker) publishStatus(req controller.RaftStatusRequest) error {
+	servers, _, err := w.getConfiguration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// This worker can only run on the raft leader, ergo Leader
+	// returns the local address.
+	leaderAddr := w.config.Raft.Leader()
+	status := controller.RaftStatus{RequestID: req.RequestID}
+	for id, server := range servers {
+		if server.Address == leaderAddr {
+			status.Leader = string(id)
+		}
+		status.Servers = append(status.Servers, controller.RaftServer{
+			ID:      string(id),
+			Address: string(server.Address),
+			Voter:   server.Suffrage == raft.Voter,
+		})
+	}
+	sort.Slice(status.Servers, func(i, j int) bool {
+		return status.Servers[i].ID < status.Servers[j].ID
+	})
+	_, err = w.config.Hub.Publish(controller.RaftStatusTopic, status)
+	return errors.Trace(err)
+}
+
 func (w *Worker) updateConfiguration(
 	configuredServers map[raft.ServerID]*raft.Server,
 	prevIndex uint64,
@@ -318,3 +368,15 @@ func (w *Worker) apiserverDetailsChanged(topic string, details apiserver.Details
 	case <-w.catacomb.Dying():
 	}
 }
+
+func (w *Worker) raftStatusRequested(topic string, req controller.RaftStatusRequest, err error) {
+	if err != nil {
+		// This should never happen, so treat it as fatal.
+		w.catacomb.Kill(errors.Annotate(err, "raft status request callback failed"))
+		return
+	}
+	select {
+	case w.statusRequests <- req:
+	case <-w.catacomb.Dying():
+	}
+}
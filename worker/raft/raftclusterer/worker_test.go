@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/pubsub/controller"
 	coretesting "github.com/juju/juju/testing"
 	jujuraft "github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/raft/raftclusterer"
@@ -292,6 +293,64 @@ func (s *WorkerSuite) TestRequestsDetails(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestPublishesStatus(c *gc.C) {
+	_, _, transport1, _, _ := s.NewRaft(c, "1", &jujuraft.SimpleFSM{})
+	_, _, transport2, _, _ := s.NewRaft(c, "2", &jujuraft.SimpleFSM{})
+	connectTransports(s.Transport, transport1, transport2)
+
+	machine0Address := string(s.Transport.LocalAddr())
+	machine1Address := string(transport1.LocalAddr())
+	machine2Address := string(transport2.LocalAddr())
+	s.publishDetails(c, map[string]string{
+		"0": machine0Address,
+		"1": machine1Address,
+		"2": machine2Address,
+	})
+	rafttest.CheckConfiguration(c, s.Raft, []raft.Server{{
+		ID:       "0",
+		Address:  raft.ServerAddress(machine0Address),
+		Suffrage: raft.Voter,
+	}, {
+		ID:       "1",
+		Address:  raft.ServerAddress(machine1Address),
+		Suffrage: raft.Voter,
+	}, {
+		ID:       "2",
+		Address:  raft.ServerAddress(machine2Address),
+		Suffrage: raft.Voter,
+	}})
+
+	statuses := make(chan controller.RaftStatus, 1)
+	unsubscribe, err := s.hub.Subscribe(
+		controller.RaftStatusTopic,
+		func(topic string, status controller.RaftStatus, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			statuses <- status
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	_, err = s.hub.Publish(controller.RaftStatusRequestTopic, controller.RaftStatusRequest{
+		RequestID: "req-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case status := <-statuses:
+		c.Assert(status, jc.DeepEquals, controller.RaftStatus{
+			RequestID: "req-1",
+			Leader:    "0",
+			Servers: []controller.RaftServer{
+				{ID: "0", Address: machine0Address, Voter: true},
+				{ID: "1", Address: machine1Address, Voter: true},
+				{ID: "2", Address: machine2Address, Voter: true},
+			},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for raft status")
+	}
+}
+
 func (s *WorkerSuite) TestDemotesAServerWhenThereAre2(c *gc.C) {
 	// Create 3 servers: 0, 1 and 2, where all servers can connect
 	// bidirectionally.
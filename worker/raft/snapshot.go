@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"io"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+)
+
+// Stats returns statistics of the local raft node, such as its state
+// and the index and term of its log.
+func (w *Worker) Stats() (map[string]string, error) {
+	r, err := w.Raft()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r.Stats(), nil
+}
+
+// WriteSnapshot takes a snapshot of the FSM and writes it to out, so
+// that it may be inspected offline. If nothing has been applied to the
+// FSM since the last snapshot, the last snapshot is written. It
+// returns the metadata of the written snapshot.
+func (w *Worker) WriteSnapshot(out io.Writer) (*raft.SnapshotMeta, error) {
+	r, err := w.Raft()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := r.Snapshot().Error(); err != nil && err != raft.ErrNothingNewToSnapshot {
+		return nil, errors.Annotate(err, "taking snapshot")
+	}
+	store, err := w.SnapshotStore()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Snapshots are listed newest first.
+	snapshots, err := store.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	if len(snapshots) == 0 {
+		return nil, errors.NotFoundf("snapshot")
+	}
+	meta, reader, err := store.Open(snapshots[0].ID)
+	if err != nil {
+		return nil, errors.Annotatef(err, "opening snapshot %q", snapshots[0].ID)
+	}
+	defer reader.Close()
+	if _, err := io.Copy(out, reader); err != nil {
+		return nil, errors.Annotate(err, "writing snapshot")
+	}
+	return meta, nil
+}
@@ -221,9 +221,10 @@ func newWorker(config Config) (*Worker, error) {
 		return nil, errors.Trace(err)
 	}
 	w := &Worker{
-		config:     config,
-		raftCh:     make(chan *raft.Raft),
-		logStoreCh: make(chan raft.LogStore),
+		config:          config,
+		raftCh:          make(chan *raft.Raft),
+		logStoreCh:      make(chan raft.LogStore),
+		snapshotStoreCh: make(chan raft.SnapshotStore),
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -248,8 +249,9 @@ type Worker struct {
 	catacomb catacomb.Catacomb
 	config   Config
 
-	raftCh     chan *raft.Raft
-	logStoreCh chan raft.LogStore
+	raftCh          chan *raft.Raft
+	logStoreCh      chan raft.LogStore
+	snapshotStoreCh chan raft.SnapshotStore
 }
 
 // Raft returns the raft.Raft managed by this worker, or
@@ -282,6 +284,21 @@ func (w *Worker) LogStore() (raft.LogStore, error) {
 	}
 }
 
+// SnapshotStore returns the raft.SnapshotStore managed by this
+// worker, or an error if the worker has stopped.
+func (w *Worker) SnapshotStore() (raft.SnapshotStore, error) {
+	select {
+	case <-w.catacomb.Dying():
+		err := w.catacomb.Err()
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrWorkerStopped
+	case snapshotStore := <-w.snapshotStoreCh:
+		return snapshotStore, nil
+	}
+}
+
 // Kill is part of the worker.Worker interface.
 func (w *Worker) Kill() {
 	w.catacomb.Kill(nil)
@@ -374,6 +391,7 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 			}
 		case w.raftCh <- r:
 		case w.logStoreCh <- logStore:
+		case w.snapshotStoreCh <- snapshotStore:
 		}
 	}
 }
@@ -4,6 +4,8 @@
 package raft_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"log"
 	"time"
 
@@ -232,6 +234,39 @@ func (s *WorkerSuite) TestRestoreSnapshot(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestStats(c *gc.C) {
+	s.waitLeader(c)
+
+	stats, err := s.worker.Stats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats["state"], gc.Equals, "Leader")
+	c.Assert(stats["last_log_index"], gc.Not(gc.Equals), "")
+	c.Assert(stats["last_log_term"], gc.Not(gc.Equals), "")
+}
+
+func (s *WorkerSuite) TestWriteSnapshot(c *gc.C) {
+	r := s.waitLeader(c)
+
+	f := r.Apply([]byte("command1"), time.Minute)
+	c.Assert(f.Error(), jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	meta, err := s.worker.WriteSnapshot(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(meta.Index, gc.Equals, uint64(3))
+	var logs [][]byte
+	err = gob.NewDecoder(&buf).Decode(&logs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(logs, jc.DeepEquals, [][]byte{[]byte("command1")})
+
+	// With nothing new to snapshot, the last snapshot is written.
+	buf.Reset()
+	meta, err = s.worker.WriteSnapshot(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(meta.Index, gc.Equals, uint64(3))
+	c.Assert(buf.Len(), gc.Not(gc.Equals), 0)
+}
+
 func (s *WorkerSuite) TestStartStop(c *gc.C) {
 	workertest.CleanKill(c, s.worker)
 }
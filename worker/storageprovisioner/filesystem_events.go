@@ -65,6 +65,57 @@ func filesystemsChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// filesystemResizesChanged is called when resizes have been requested,
+// or completed, for the filesystems with the provided IDs.
+func filesystemResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.FilesystemTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewFilesystemTag(change)
+	}
+	results, err := ctx.config.Filesystems.ResizeFilesystemParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem resize params")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		key := resizeFilesystemKey{tags[i]}
+		ctx.schedule.Remove(key)
+		if result.Error != nil {
+			// There is no resize pending for a filesystem that has
+			// been resized, or is no longer alive.
+			if params.IsCodeNotFound(result.Error) ||
+				params.IsCodeNotProvisioned(result.Error) ||
+				params.IsCodeUnauthorized(result.Error) {
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize params for filesystem %q", tags[i].Id(),
+			)
+		}
+		args := storage.FilesystemResizeParams{
+			Tag:          tags[i],
+			FilesystemId: result.Result.FilesystemId,
+			Size:         result.Result.Size,
+		}
+		if result.Result.VolumeTag != "" {
+			volumeTag, err := names.ParseVolumeTag(result.Result.VolumeTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			args.Volume = volumeTag
+		}
+		ctx.config.Logger.Debugf(
+			"filesystem %q resize to %dMiB requested", tags[i].Id(), result.Result.Size,
+		)
+		ops = append(ops, &resizeFilesystemOp{
+			args:     args,
+			provider: storage.ProviderType(result.Result.Provider),
+		})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // filesystemAttachmentsChanged is called when the lifecycle states of the filesystem
 // attachments with the provided IDs have been seen to have changed.
 func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -4,6 +4,7 @@
 package storageprovisioner
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/juju/errors"
@@ -356,6 +357,159 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 	return nil
 }
 
+// resizeFilesystems grows filesystems with the specified parameters.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	waiting, err := filesystemsWaitingForVolumeResize(ctx, ops)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	filesystemParams := make([]storage.FilesystemParams, 0, len(ops))
+	for tag, op := range ops {
+		if volumeTag, ok := waiting[tag]; ok {
+			// The filesystem can't grow until its backing
+			// volume has been resized, so try again later.
+			reschedule = append(reschedule, op)
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.Pending.String(),
+				Info:   fmt.Sprintf("waiting for volume %s to be resized", volumeTag.Id()),
+			})
+			continue
+		}
+		filesystemParams = append(filesystemParams, storage.FilesystemParams{
+			Tag:      tag,
+			Volume:   op.args.Volume,
+			Provider: op.provider,
+		})
+	}
+	paramsBySource, filesystemSources, err := filesystemParamsBySource(
+		ctx.config.StorageDir,
+		filesystemParams,
+		ctx.managedFilesystemSource,
+		ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var filesystems []storage.Filesystem
+	for sourceName, filesystemParams := range paramsBySource {
+		resizeParams := make([]storage.FilesystemResizeParams, len(filesystemParams))
+		for i, p := range filesystemParams {
+			resizeParams[i] = ops[p.Tag].args
+		}
+		ctx.config.Logger.Debugf("resizing filesystems: %v", resizeParams)
+		resizer, ok := filesystemSources[sourceName].(storage.FilesystemResizer)
+		if !ok {
+			// There's no point retrying, the resize
+			// can never succeed.
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.NotSupportedf("resizing filesystems with storage provider %q", sourceName).Error(),
+				})
+			}
+			continue
+		}
+		results, err := resizer.ResizeFilesystems(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing filesystems from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := resizeParams[i]
+			if result.Error != nil {
+				// Reschedule the resize, and report the failure.
+				reschedule = append(reschedule, ops[p.Tag])
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotate(result.Error, "resizing filesystem").Error(),
+				})
+				ctx.config.Logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(p.Tag),
+					result.Error,
+				)
+				continue
+			}
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    p.Tag.String(),
+				Status: status.Attached.String(),
+			})
+			filesystems = append(filesystems, storage.Filesystem{
+				Tag:            p.Tag,
+				Volume:         p.Volume,
+				FilesystemInfo: *result.FilesystemInfo,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(filesystems) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystemsFromStorage(filesystems))
+	if err != nil {
+		return errors.Annotate(err, "publishing resized filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			ctx.config.Logger.Errorf(
+				"publishing resized filesystem %s to state: %v",
+				filesystems[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, f := range filesystems {
+		updateFilesystem(ctx, f)
+	}
+	return nil
+}
+
+// filesystemsWaitingForVolumeResize returns the backing volumes of the
+// volume-backed filesystems in ops whose volumes are not yet at least
+// the size requested for the filesystem, keyed by filesystem tag.
+func filesystemsWaitingForVolumeResize(
+	ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp,
+) (map[names.FilesystemTag]names.VolumeTag, error) {
+	var filesystemTags []names.FilesystemTag
+	var volumeTags []names.VolumeTag
+	for tag, op := range ops {
+		if op.args.Volume == (names.VolumeTag{}) {
+			continue
+		}
+		filesystemTags = append(filesystemTags, tag)
+		volumeTags = append(volumeTags, op.args.Volume)
+	}
+	if len(volumeTags) == 0 {
+		return nil, nil
+	}
+	results, err := ctx.config.Volumes.Volumes(volumeTags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting backing volumes")
+	}
+	waiting := make(map[names.FilesystemTag]names.VolumeTag)
+	for i, result := range results {
+		tag := filesystemTags[i]
+		if result.Error != nil {
+			if !params.IsCodeNotFound(result.Error) && !params.IsCodeNotProvisioned(result.Error) {
+				return nil, errors.Annotatef(
+					result.Error, "getting volume %q", volumeTags[i].Id(),
+				)
+			}
+			waiting[tag] = volumeTags[i]
+			continue
+		}
+		if result.Result.Info.Size < ops[tag].args.Size {
+			waiting[tag] = volumeTags[i]
+		}
+	}
+	return waiting, nil
+}
+
 // filesystemParamsBySource separates the filesystem parameters by filesystem source.
 func filesystemParamsBySource(
 	baseStorageDir string,
@@ -573,3 +727,19 @@ func (op *detachFilesystemOp) key() interface{} {
 		AttachmentTag: op.args.Filesystem.String(),
 	}
 }
+
+// resizeFilesystemKey is the schedule key for resizeFilesystemOp,
+// distinct from the keys of the filesystem's other operations.
+type resizeFilesystemKey struct {
+	tag names.FilesystemTag
+}
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args     storage.FilesystemResizeParams
+	provider storage.ProviderType
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeFilesystemKey{op.args.Tag}
+}
@@ -140,14 +140,21 @@ type mockVolumeAccessor struct {
 	attachmentsWatcher     *mockAttachmentsWatcher
 	attachmentPlansWatcher *mockAttachmentPlansWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
+	resizesWatcher         *mockStringsWatcher
+	snapshotsWatcher       *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
+	volumeSnapshots        map[string]params.VolumeSnapshotParams
+	requestedSizes         map[string]uint64
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
 
 	setVolumeInfo               func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo     func([]params.VolumeAttachment) ([]params.ErrorResult, error)
 	createVolumeAttachmentPlans func([]params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo       func([]params.VolumeSnapshotInfo) ([]params.ErrorResult, error)
+	setVolumeSnapshotStatus     func([]params.VolumeSnapshotStatus) ([]params.ErrorResult, error)
+	removeVolumeSnapshots       func([]string) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -177,6 +184,14 @@ func (w *mockVolumeAccessor) WatchVolumeAttachmentPlans(names.Tag) (watcher.Mach
 	return w.attachmentPlansWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeSnapshots(names.Tag) (watcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
 func (v *mockVolumeAccessor) Volumes(volumes []names.VolumeTag) ([]params.VolumeResult, error) {
 	var result []params.VolumeResult
 	for _, tag := range volumes {
@@ -267,6 +282,32 @@ func (v *mockVolumeAccessor) RemoveVolumeParams(volumes []names.VolumeTag) ([]pa
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) ResizeVolumeParams(volumes []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	var result []params.ResizeVolumeParamsResult
+	for _, tag := range volumes {
+		volume, ok := v.provisionedVolumes[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeVolumeParamsResult{
+				Error: &params.Error{Code: params.CodeNotProvisioned},
+			})
+			continue
+		}
+		size, ok := v.requestedSizes[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeVolumeParamsResult{
+				Error: &params.Error{Code: params.CodeNotFound},
+			})
+			continue
+		}
+		result = append(result, params.ResizeVolumeParamsResult{Result: params.ResizeVolumeParams{
+			Provider: "dummy",
+			VolumeId: volume.Info.VolumeId,
+			Size:     size,
+		}})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
 	var result []params.VolumeAttachmentParamsResult
 	for _, id := range ids {
@@ -284,6 +325,42 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, id := range ids {
+		snapshotParams, ok := v.volumeSnapshots[id]
+		if !ok {
+			result = append(result, params.VolumeSnapshotParamsResult{
+				Error: &params.Error{Code: params.CodeNotFound},
+			})
+			continue
+		}
+		result = append(result, params.VolumeSnapshotParamsResult{Result: snapshotParams})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshotInfo) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotInfo != nil {
+		return v.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotStatus(statuses []params.VolumeSnapshotStatus) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotStatus != nil {
+		return v.setVolumeSnapshotStatus(statuses)
+	}
+	return make([]params.ErrorResult, len(statuses)), nil
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(ids []string) ([]params.ErrorResult, error) {
+	if v.removeVolumeSnapshots != nil {
+		return v.removeVolumeSnapshots(ids)
+	}
+	return make([]params.ErrorResult, len(ids)), nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -323,8 +400,12 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		attachmentPlansWatcher: newMockAttachmentPlansWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
+		snapshotsWatcher:       newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
+		volumeSnapshots:        make(map[string]params.VolumeSnapshotParams),
+		requestedSizes:         make(map[string]uint64),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
 	}
@@ -334,8 +415,10 @@ type mockFilesystemAccessor struct {
 	testing.Stub
 	filesystemsWatcher     *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
+	resizesWatcher         *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedFilesystems map[string]params.Filesystem
+	requestedSizes         map[string]uint64
 	provisionedAttachments map[params.MachineStorageId]params.FilesystemAttachment
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
@@ -362,6 +445,10 @@ func (w *mockFilesystemAccessor) WatchFilesystemAttachments(names.Tag) (watcher.
 	return w.attachmentsWatcher, nil
 }
 
+func (w *mockFilesystemAccessor) WatchFilesystemResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (v *mockFilesystemAccessor) Filesystems(filesystems []names.FilesystemTag) ([]params.FilesystemResult, error) {
 	var result []params.FilesystemResult
 	for _, tag := range filesystems {
@@ -431,6 +518,29 @@ func (v *mockFilesystemAccessor) RemoveFilesystemParams(filesystems []names.File
 	return results, nil
 }
 
+func (v *mockFilesystemAccessor) ResizeFilesystemParams(filesystems []names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error) {
+	results := make([]params.ResizeFilesystemParamsResult, len(filesystems))
+	for i, tag := range filesystems {
+		f, ok := v.provisionedFilesystems[tag.String()]
+		if !ok {
+			results[i].Error = &params.Error{Code: params.CodeNotProvisioned}
+			continue
+		}
+		size, ok := v.requestedSizes[tag.String()]
+		if !ok {
+			results[i].Error = &params.Error{Code: params.CodeNotFound}
+			continue
+		}
+		results[i].Result = params.ResizeFilesystemParams{
+			Provider:     "dummy",
+			FilesystemId: f.Info.FilesystemId,
+			VolumeTag:    f.VolumeTag,
+			Size:         size,
+		}
+	}
+	return results, nil
+}
+
 func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error) {
 	var result []params.FilesystemAttachmentParamsResult
 	for _, id := range ids {
@@ -466,8 +576,10 @@ func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedFilesystems: make(map[string]params.Filesystem),
+		requestedSizes:         make(map[string]uint64),
 		provisionedAttachments: make(map[params.MachineStorageId]params.FilesystemAttachment),
 	}
 }
@@ -555,6 +667,10 @@ type dummyProvider struct {
 	releaseVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
 	releaseFilesystemsFunc       func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	resizeFilesystemsFunc        func([]storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error)
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	destroyVolumeSnapshotsFunc   func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -672,6 +788,41 @@ func (s *dummyVolumeSource) DetachVolumes(ctx context.ProviderCallContext, param
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes grows volumes.
+func (s *dummyVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: p.VolumeId,
+			Size:     p.Size,
+		}
+	}
+	return results, nil
+}
+
+func (s *dummyVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].VolumeSnapshotInfo = &storage.VolumeSnapshotInfo{
+			SnapshotId: "snap-" + p.VolumeId,
+		}
+	}
+	return results, nil
+}
+
+func (s *dummyVolumeSource) DestroyVolumeSnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	if s.provider.destroyVolumeSnapshotsFunc != nil {
+		return s.provider.destroyVolumeSnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -751,6 +902,21 @@ func (s *dummyFilesystemSource) DetachFilesystems(ctx context.ProviderCallContex
 	return make([]error, len(params)), nil
 }
 
+// ResizeFilesystems grows filesystems.
+func (s *dummyFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, params []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	if s.provider.resizeFilesystemsFunc != nil {
+		return s.provider.resizeFilesystemsFunc(params)
+	}
+	results := make([]storage.ResizeFilesystemsResult, len(params))
+	for i, p := range params {
+		results[i].FilesystemInfo = &storage.FilesystemInfo{
+			FilesystemId: p.FilesystemId,
+			Size:         p.Size,
+		}
+	}
+	return results, nil
+}
+
 type mockManagedFilesystemSource struct {
 	blockDevices map[names.VolumeTag]storage.BlockDevice
 	filesystems  map[names.FilesystemTag]storage.Filesystem
@@ -779,6 +945,17 @@ func (s *mockManagedFilesystemSource) CreateFilesystems(ctx context.ProviderCall
 	return results, nil
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		results[i].FilesystemInfo = &storage.FilesystemInfo{
+			FilesystemId: arg.FilesystemId,
+			Size:         arg.Size,
+		}
+	}
+	return results, nil
+}
+
 func (s *mockManagedFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
 	return make([]error, len(filesystemIds)), nil
 }
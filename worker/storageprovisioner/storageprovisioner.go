@@ -65,6 +65,14 @@ type VolumeAccessor interface {
 	// initialization of the attachment, such as logging into the iSCSI target
 	WatchVolumeAttachmentPlans(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for resize requests on volumes that
+	// this storage provisioner is responsible for.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// WatchVolumeSnapshots watches for changes to volume snapshots
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -86,6 +94,14 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// ResizeVolumeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	ResizeVolumeParams([]names.VolumeTag) ([]params.ResizeVolumeParamsResult, error)
+
+	// VolumeSnapshotParams returns the parameters for taking or
+	// destroying the volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -97,6 +113,17 @@ type VolumeAccessor interface {
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
 
+	// SetVolumeSnapshotInfo records the details of newly taken
+	// volume snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshotInfo) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotStatus sets the status of volume snapshots.
+	SetVolumeSnapshotStatus([]params.VolumeSnapshotStatus) ([]params.ErrorResult, error)
+
+	// RemoveVolumeSnapshots removes destroyed volume snapshots
+	// from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
+
 	CreateVolumeAttachmentPlans(volumeAttachmentPlans []params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
 	RemoveVolumeAttachmentPlan([]params.MachineStorageId) ([]params.ErrorResult, error)
 	SetVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans []params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
@@ -113,6 +140,10 @@ type FilesystemAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchFilesystemAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchFilesystemResizes watches for resize requests on filesystems
+	// that this storage provisioner is responsible for.
+	WatchFilesystemResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// Filesystems returns details of filesystems with the specified tags.
 	Filesystems([]names.FilesystemTag) ([]params.FilesystemResult, error)
 
@@ -128,6 +159,10 @@ type FilesystemAccessor interface {
 	// releasing the filesystems with the specified tags.
 	RemoveFilesystemParams([]names.FilesystemTag) ([]params.RemoveFilesystemParamsResult, error)
 
+	// ResizeFilesystemParams returns the parameters for resizing the
+	// filesystems with the specified tags.
+	ResizeFilesystemParams([]names.FilesystemTag) ([]params.ResizeFilesystemParamsResult, error)
+
 	// FilesystemAttachmentParams returns the parameters for creating the
 	// filesystem attachments with the specified tags.
 	FilesystemAttachmentParams([]params.MachineStorageId) ([]params.FilesystemAttachmentParamsResult, error)
@@ -222,6 +257,9 @@ func (w *storageProvisioner) loop() error {
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		volumeAttachmentPlansChanges watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		filesystemResizesChanges     watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -315,6 +353,51 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	// Resizing is not supported for application storage, nor by
+	// older controllers; in either case the resize channels are
+	// left nil.
+	if !ctx.isApplicationKind() {
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if errors.IsNotSupported(err) {
+			w.config.Logger.Debugf("not watching volume resizes: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		} else {
+			if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
+
+		filesystemResizesWatcher, err := w.config.Filesystems.WatchFilesystemResizes(w.config.Scope)
+		if errors.IsNotSupported(err) {
+			w.config.Logger.Debugf("not watching filesystem resizes: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching filesystem resizes")
+		} else {
+			if err := w.catacomb.Add(filesystemResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			filesystemResizesChanges = filesystemResizesWatcher.Changes()
+		}
+	}
+
+	// Likewise, snapshots are not supported for application storage
+	// nor by older controllers.
+	if !ctx.isApplicationKind() {
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots(w.config.Scope)
+		if errors.IsNotSupported(err) {
+			w.config.Logger.Debugf("not watching volume snapshots: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		} else {
+			if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+		}
+	}
+
 	for {
 
 		// Check if block devices need to be refreshed.
@@ -360,6 +443,27 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-filesystemResizesChanges:
+			if !ok {
+				return errors.New("filesystem resizes watcher closed")
+			}
+			if err := filesystemResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -387,10 +491,14 @@ func processSchedule(ctx *context) error {
 	removeVolumeOps := make(map[names.VolumeTag]*removeVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	removeFilesystemOps := make(map[names.FilesystemTag]*removeFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
+	removeVolumeSnapshotOps := make(map[string]*removeVolumeSnapshotOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -403,6 +511,8 @@ func processSchedule(ctx *context) error {
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[key.(resizeVolumeKey).tag] = op
 		case *createFilesystemOp:
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *removeFilesystemOp:
@@ -411,6 +521,12 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[key.(resizeFilesystemKey).tag] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[key.(volumeSnapshotKey).id] = op
+		case *removeVolumeSnapshotOp:
+			removeVolumeSnapshotOps[key.(volumeSnapshotKey).id] = op
 		}
 	}
 	if len(removeVolumeOps) > 0 {
@@ -433,6 +549,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
 	if len(removeFilesystemOps) > 0 {
 		if err := removeFilesystems(ctx, removeFilesystemOps); err != nil {
 			return errors.Annotate(err, "removing filesystems")
@@ -453,6 +574,21 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
+	if len(removeVolumeSnapshotOps) > 0 {
+		if err := removeVolumeSnapshots(ctx, removeVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "removing volume snapshots")
+		}
+	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
 	return nil
 }
 
@@ -1850,6 +1850,344 @@ func (s *storageProvisionerSuite) TestDestroyFilesystemsRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.provisionVolume(names.NewVolumeTag("2"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, arg := range args {
+			results[i].VolumeInfo = &storage.VolumeInfo{
+				VolumeId:   arg.VolumeId,
+				HardwareId: "serial-" + arg.Tag.Id(),
+				Size:       arg.Size,
+			}
+		}
+		return results, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Only volume-1 has a pending resize; volume-2 has none, and
+	// volume-3 is not provisioned.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2", "3"}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId:   "vol-1",
+			HardwareId: "serial-1",
+			Size:       2048,
+		},
+	}})
+	assertNoEvent(c, resizedChan, "volumes resized")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesRetry(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		if len(resizeVolumeTimes) < 3 {
+			return []storage.ResizeVolumesResult{{Error: errors.New("badness")}}, nil
+		}
+		return []storage.ResizeVolumesResult{{
+			VolumeInfo: &storage.VolumeInfo{VolumeId: "vol-1", Size: 2048},
+		}}, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(resizeVolumeTimes, gc.HasLen, 3)
+	c.Assert(resizeVolumeTimes[1].Sub(resizeVolumeTimes[0]), gc.Equals, 30*time.Second)
+	c.Assert(resizeVolumeTimes[2].Sub(resizeVolumeTimes[1]), gc.Equals, time.Minute)
+
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+		{Tag: "volume-1", Status: "attached"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestResizeFilesystems(c *gc.C) {
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionFilesystem(names.NewFilesystemTag("1"))
+	filesystemAccessor.requestedSizes["filesystem-1"] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeFilesystemsFunc = func(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+		resizedChan <- args
+		return []storage.ResizeFilesystemsResult{{
+			FilesystemInfo: &storage.FilesystemInfo{FilesystemId: "fs-1", Size: 2048},
+		}}, nil
+	}
+
+	filesystemInfoSet := make(chan interface{}, 1)
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return make([]params.ErrorResult, len(filesystems)), nil
+	}
+
+	args := &workerArgs{filesystems: filesystemAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"1"}
+
+	resized := waitChannel(c, resizedChan, "waiting for filesystem to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("1"),
+		FilesystemId: "fs-1",
+		Size:         2048,
+	}})
+	filesystems := waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
+	c.Assert(filesystems, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-1",
+		Info: params.FilesystemInfo{
+			FilesystemId: "fs-1",
+			Size:         2048,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeFilesystemsWaitsForVolume(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volume := volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volume.Info.Size = 1024
+	volumeAccessor.provisionedVolumes["volume-1"] = volume
+
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystem := filesystemAccessor.provisionFilesystem(names.NewFilesystemTag("1"))
+	filesystem.VolumeTag = "volume-1"
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = filesystem
+	filesystemAccessor.requestedSizes["filesystem-1"] = 2048
+
+	clock := &mockClock{}
+	clock.onAfter = func(d time.Duration) <-chan time.Time {
+		// The backing volume is resized while the
+		// filesystem resize is waiting to be retried.
+		volume.Info.Size = 2048
+		volumeAccessor.provisionedVolumes["volume-1"] = volume
+		clock.now = clock.now.Add(d)
+		ch := make(chan time.Time, 1)
+		ch <- clock.now
+		return ch
+	}
+
+	filesystemInfoSet := make(chan interface{}, 1)
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return make([]params.ErrorResult, len(filesystems)), nil
+	}
+
+	args := &workerArgs{
+		volumes:     volumeAccessor,
+		filesystems: filesystemAccessor,
+		clock:       clock,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.resizesWatcher.changes <- []string{"1"}
+	filesystems := waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
+	c.Assert(filesystems, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-1",
+		VolumeTag:     "volume-1",
+		Info: params.FilesystemInfo{
+			FilesystemId: "fs-1",
+			Size:         2048,
+		},
+	}})
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "filesystem-1", Status: "pending", Info: "waiting for volume 1 to be resized"},
+		{Tag: "filesystem-1", Status: "attached"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["0"] = params.VolumeSnapshotParams{
+		Id:        "0",
+		Life:      life.Alive,
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Provider:  "dummy",
+		Tags:      map[string]string{"foo": "bar"},
+	}
+	// Snapshot 1 has already been taken.
+	volumeAccessor.volumeSnapshots["1"] = params.VolumeSnapshotParams{
+		Id:         "1",
+		Life:       life.Alive,
+		VolumeTag:  "volume-1",
+		VolumeId:   "vol-1",
+		Provider:   "dummy",
+		SnapshotId: "snap-1",
+	}
+
+	createdChan := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		createdChan <- args
+		return []storage.CreateVolumeSnapshotsResult{{
+			VolumeSnapshotInfo: &storage.VolumeSnapshotInfo{
+				SnapshotId: "snap-0",
+				Size:       1024,
+			},
+		}}, nil
+	}
+
+	snapshotInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshotInfo) ([]params.ErrorResult, error) {
+		snapshotInfoSet <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+	snapshotStatusSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshotStatus = func(statuses []params.VolumeSnapshotStatus) ([]params.ErrorResult, error) {
+		snapshotStatusSet <- statuses
+		return make([]params.ErrorResult, len(statuses)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshot 2 has been removed.
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1", "2"}
+
+	created := waitChannel(c, createdChan, "waiting for volume snapshot to be created")
+	c.Assert(created, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Id:           "0",
+		Volume:       names.NewVolumeTag("1"),
+		VolumeId:     "vol-1",
+		ResourceTags: map[string]string{"foo": "bar"},
+	}})
+	snapshots := waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshotInfo{{
+		Id:         "0",
+		SnapshotId: "snap-0",
+		Size:       1024,
+	}})
+	statuses := waitChannel(c, snapshotStatusSet, "waiting for volume snapshot status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.VolumeSnapshotStatus{{
+		Id:     "0",
+		Status: "available",
+	}})
+	assertNoEvent(c, createdChan, "volume snapshots created")
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshotsVolumeRemoved(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["0"] = params.VolumeSnapshotParams{
+		Id:        "0",
+		Life:      life.Alive,
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+	}
+
+	createdChan := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		createdChan <- args
+		return nil, errors.New("unexpected call")
+	}
+	snapshotStatusSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshotStatus = func(statuses []params.VolumeSnapshotStatus) ([]params.ErrorResult, error) {
+		snapshotStatusSet <- statuses
+		return make([]params.ErrorResult, len(statuses)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0"}
+	statuses := waitChannel(c, snapshotStatusSet, "waiting for volume snapshot status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.VolumeSnapshotStatus{{
+		Id:     "0",
+		Status: "error",
+		Info:   "volume 1 not found",
+	}})
+	assertNoEvent(c, createdChan, "volume snapshots created")
+}
+
+func (s *storageProvisionerSuite) TestRemoveVolumeSnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeSnapshots["0"] = params.VolumeSnapshotParams{
+		Id:         "0",
+		Life:       life.Dying,
+		VolumeTag:  "volume-1",
+		Provider:   "dummy",
+		SnapshotId: "snap-0",
+	}
+	// Snapshot 1 was never taken, so there's nothing to destroy.
+	volumeAccessor.volumeSnapshots["1"] = params.VolumeSnapshotParams{
+		Id:        "1",
+		Life:      life.Dying,
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+	}
+
+	destroyedChan := make(chan interface{}, 1)
+	s.provider.destroyVolumeSnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		destroyedChan <- snapshotIds
+		return make([]error, len(snapshotIds)), nil
+	}
+	removedChan := make(chan interface{}, 1)
+	volumeAccessor.removeVolumeSnapshots = func(ids []string) ([]params.ErrorResult, error) {
+		removedChan <- ids
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"0", "1"}
+	destroyed := waitChannel(c, destroyedChan, "waiting for volume snapshot to be destroyed")
+	c.Assert(destroyed, jc.DeepEquals, []string{"snap-0"})
+	removed := waitChannel(c, removedChan, "waiting for volume snapshots to be removed")
+	c.Assert(removed, jc.DeepEquals, []string{"1", "0"})
+}
+
 type caasStorageProvisionerSuite struct {
 	coretesting.BaseSuite
 	provider *dummyProvider
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/plans"
@@ -56,6 +57,114 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when resizes have been requested, or
+// completed, for the volumes with the provided IDs.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	var ops []scheduleOp
+	for i, result := range results {
+		key := resizeVolumeKey{tags[i]}
+		ctx.schedule.Remove(key)
+		if result.Error != nil {
+			// There is no resize pending for a volume that has
+			// been resized, or is no longer alive.
+			if params.IsCodeNotFound(result.Error) ||
+				params.IsCodeNotProvisioned(result.Error) ||
+				params.IsCodeUnauthorized(result.Error) {
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize params for volume %q", tags[i].Id(),
+			)
+		}
+		ctx.config.Logger.Debugf(
+			"volume %q resize to %dMiB requested", tags[i].Id(), result.Result.Size,
+		)
+		ops = append(ops, &resizeVolumeOp{
+			args: storage.VolumeResizeParams{
+				Tag:      tags[i],
+				VolumeId: result.Result.VolumeId,
+				Size:     result.Result.Size,
+			},
+			provider: storage.ProviderType(result.Result.Provider),
+		})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
+// volumeSnapshotsChanged is called when the lifecycle states of the
+// volume snapshots with the provided IDs have been seen to have changed.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	results, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	var ops []scheduleOp
+	var statuses []params.VolumeSnapshotStatus
+	for i, result := range results {
+		id := changes[i]
+		ctx.schedule.Remove(volumeSnapshotKey{id})
+		if result.Error != nil {
+			// The snapshot has been removed.
+			if params.IsCodeNotFound(result.Error) {
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting params for volume snapshot %q", id,
+			)
+		}
+		snapshotParams := result.Result
+		provider := storage.ProviderType(snapshotParams.Provider)
+		switch snapshotParams.Life {
+		case life.Alive:
+			if snapshotParams.SnapshotId != "" {
+				// The snapshot has already been taken.
+				continue
+			}
+			volumeTag, err := names.ParseVolumeTag(snapshotParams.VolumeTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if snapshotParams.VolumeId == "" {
+				// The volume was removed before the snapshot
+				// could be taken; there's no point retrying.
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     id,
+					Status: status.Error.String(),
+					Info:   errors.NotFoundf("%s", names.ReadableString(volumeTag)).Error(),
+				})
+				continue
+			}
+			ops = append(ops, &createVolumeSnapshotOp{
+				args: storage.VolumeSnapshotParams{
+					Id:           id,
+					Volume:       volumeTag,
+					VolumeId:     snapshotParams.VolumeId,
+					ResourceTags: snapshotParams.Tags,
+				},
+				provider: provider,
+			})
+		case life.Dying:
+			ops = append(ops, &removeVolumeSnapshotOp{
+				id:         id,
+				snapshotId: snapshotParams.SnapshotId,
+				provider:   provider,
+			})
+		}
+	}
+	scheduleOperations(ctx, ops...)
+	setVolumeSnapshotStatus(ctx, statuses)
+	return nil
+}
+
 func sortVolumeAttachmentPlans(ctx *context, ids []params.MachineStorageId) (
 	alive, dying, dead []params.VolumeAttachmentPlanResult, err error) {
 	plans, err := ctx.config.Volumes.VolumeAttachmentPlans(ids)
@@ -645,6 +754,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		providerType,
 		in.Attributes,
 		in.Tags,
+		in.SnapshotId,
 		attachment,
 	}, nil
 }
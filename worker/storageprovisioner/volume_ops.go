@@ -415,6 +415,290 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 	return nil
 }
 
+// resizeVolumes grows volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for tag, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      tag,
+			Provider: op.provider,
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for sourceName, volumeParams := range paramsBySource {
+		resizeParams := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, p := range volumeParams {
+			resizeParams[i] = ops[p.Tag].args
+		}
+		ctx.config.Logger.Debugf("resizing volumes: %v", resizeParams)
+		resizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			// There's no point retrying, the resize
+			// can never succeed.
+			for _, p := range resizeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.NotSupportedf("resizing volumes with storage provider %q", sourceName).Error(),
+				})
+			}
+			continue
+		}
+		results, err := resizer.ResizeVolumes(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				// Reschedule the resize, and report the failure.
+				reschedule = append(reschedule, ops[tag])
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotate(result.Error, "resizing volume").Error(),
+				})
+				ctx.config.Logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(tag),
+					result.Error,
+				)
+				continue
+			}
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.Attached.String(),
+			})
+			volumes = append(volumes, storage.Volume{
+				Tag:        tag,
+				VolumeInfo: *result.VolumeInfo,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			ctx.config.Logger.Errorf(
+				"publishing resized volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		updateVolume(ctx, v)
+	}
+	return nil
+}
+
+// createVolumeSnapshots takes volume snapshots with the specified
+// parameters.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	paramsByProvider := make(map[storage.ProviderType][]storage.VolumeSnapshotParams)
+	for _, op := range ops {
+		paramsByProvider[op.provider] = append(paramsByProvider[op.provider], op.args)
+	}
+	var reschedule []scheduleOp
+	var snapshots []params.VolumeSnapshotInfo
+	var statuses []params.VolumeSnapshotStatus
+	for providerType, snapshotParams := range paramsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if errors.IsNotSupported(err) {
+			// There's no point retrying, the snapshot
+			// can never be taken.
+			for _, p := range snapshotParams {
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     p.Id,
+					Status: status.Error.String(),
+					Info:   err.Error(),
+				})
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.config.Logger.Debugf("creating volume snapshots: %v", snapshotParams)
+		results, err := snapshotter.CreateVolumeSnapshots(ctx.config.CloudCallContext, snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots with storage provider %q", providerType)
+		}
+		for i, result := range results {
+			id := snapshotParams[i].Id
+			if result.Error != nil {
+				// Reschedule the snapshot, and report the failure.
+				reschedule = append(reschedule, ops[id])
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     id,
+					Status: status.Error.String(),
+					Info:   errors.Annotate(result.Error, "creating volume snapshot").Error(),
+				})
+				ctx.config.Logger.Debugf("failed to create volume snapshot %q: %v", id, result.Error)
+				continue
+			}
+			statuses = append(statuses, params.VolumeSnapshotStatus{
+				Id:     id,
+				Status: status.Available.String(),
+			})
+			snapshots = append(snapshots, params.VolumeSnapshotInfo{
+				Id:         id,
+				SnapshotId: result.VolumeSnapshotInfo.SnapshotId,
+				Size:       result.VolumeSnapshotInfo.Size,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	// Record the snapshot info before reporting the snapshots as
+	// available, so that they may be restored from immediately.
+	if len(snapshots) > 0 {
+		errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(snapshots)
+		if err != nil {
+			return errors.Annotate(err, "publishing volume snapshots to state")
+		}
+		for i, result := range errorResults {
+			if result.Error != nil {
+				ctx.config.Logger.Errorf(
+					"publishing volume snapshot %q to state: %v",
+					snapshots[i].Id, result.Error,
+				)
+			}
+		}
+	}
+	setVolumeSnapshotStatus(ctx, statuses)
+	return nil
+}
+
+// removeVolumeSnapshots destroys dying volume snapshots in the storage
+// provider, and then removes them from state.
+func removeVolumeSnapshots(ctx *context, ops map[string]*removeVolumeSnapshotOp) error {
+	var remove []string
+	idsByProvider := make(map[storage.ProviderType][]string)
+	for id, op := range ops {
+		if op.snapshotId == "" {
+			// The snapshot was never taken, so there
+			// is nothing to destroy.
+			remove = append(remove, id)
+			continue
+		}
+		idsByProvider[op.provider] = append(idsByProvider[op.provider], id)
+	}
+	var reschedule []scheduleOp
+	var statuses []params.VolumeSnapshotStatus
+	for providerType, ids := range idsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if errors.IsNotSupported(err) {
+			for _, id := range ids {
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     id,
+					Status: status.Error.String(),
+					Info:   err.Error(),
+				})
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		snapshotIds := make([]string, len(ids))
+		for i, id := range ids {
+			snapshotIds[i] = ops[id].snapshotId
+		}
+		ctx.config.Logger.Debugf("destroying volume snapshots: %v", snapshotIds)
+		errs, err := snapshotter.DestroyVolumeSnapshots(ctx.config.CloudCallContext, snapshotIds)
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume snapshots with storage provider %q", providerType)
+		}
+		for i, err := range errs {
+			id := ids[i]
+			if err != nil {
+				// Reschedule the removal, and report the failure.
+				reschedule = append(reschedule, ops[id])
+				statuses = append(statuses, params.VolumeSnapshotStatus{
+					Id:     id,
+					Status: status.Error.String(),
+					Info:   errors.Annotate(err, "destroying volume snapshot").Error(),
+				})
+				ctx.config.Logger.Debugf("failed to destroy volume snapshot %q: %v", id, err)
+				continue
+			}
+			remove = append(remove, id)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setVolumeSnapshotStatus(ctx, statuses)
+	if len(remove) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.RemoveVolumeSnapshots(remove)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			ctx.config.Logger.Errorf(
+				"removing volume snapshot %q from state: %v",
+				remove[i], result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotter returns the VolumeSnapshotter for the volume source
+// of the specified storage provider. A NotSupported error is returned
+// if the volume source cannot take snapshots.
+func volumeSnapshotter(ctx *context, providerType storage.ProviderType) (storage.VolumeSnapshotter, error) {
+	source, err := volumeSource(
+		ctx.config.StorageDir, string(providerType), providerType, ctx.config.Registry,
+	)
+	if errors.Cause(err) == errNonDynamic {
+		source = nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("volume snapshots with storage provider %q", providerType)
+	}
+	return snapshotter, nil
+}
+
+// setVolumeSnapshotStatus sets the given volume snapshot statuses, if
+// any. If setting the status fails the error is logged but otherwise
+// ignored.
+func setVolumeSnapshotStatus(ctx *context, statuses []params.VolumeSnapshotStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotStatus(statuses)
+	if err != nil {
+		ctx.config.Logger.Errorf("failed to set volume snapshot status: %v", err)
+		return
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			ctx.config.Logger.Errorf(
+				"failed to set status of volume snapshot %q: %v",
+				statuses[i].Id, result.Error,
+			)
+		}
+	}
+}
+
 // volumeParamsBySource separates the volume parameters by volume source.
 func volumeParamsBySource(
 	baseStorageDir string,
@@ -576,3 +860,46 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+// resizeVolumeKey is the schedule key for resizeVolumeOp, distinct
+// from the keys of the volume's other operations.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args     storage.VolumeResizeParams
+	provider storage.ProviderType
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
+
+// volumeSnapshotKey is the schedule key for the operations on a
+// volume snapshot.
+type volumeSnapshotKey struct {
+	id string
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	args     storage.VolumeSnapshotParams
+	provider storage.ProviderType
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.args.Id}
+}
+
+type removeVolumeSnapshotOp struct {
+	exponentialBackoff
+	id         string
+	snapshotId string
+	provider   storage.ProviderType
+}
+
+func (op *removeVolumeSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.id}
+}
@@ -61,6 +61,8 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0", StorageSize: 2048}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
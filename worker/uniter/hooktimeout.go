@@ -0,0 +1,105 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/watcher"
+)
+
+// hookTimeoutPolicyCache is a worker that holds the hook timeout
+// policy of the unit's application, so that it needn't be fetched
+// from the controller for every hook. The policy is fetched again
+// whenever the application's config changes.
+type hookTimeoutPolicyCache struct {
+	catacomb catacomb.Catacomb
+	changes  watcher.StringsWatcher
+	fetch    func() (application.HookTimeoutPolicy, error)
+
+	mu     sync.Mutex
+	policy *application.HookTimeoutPolicy
+}
+
+// newHookTimeoutPolicyCache returns a hookTimeoutPolicyCache which
+// calls fetch to get the policy whenever the supplied watcher of the
+// application's config reports a change.
+func newHookTimeoutPolicyCache(
+	changes watcher.StringsWatcher,
+	fetch func() (application.HookTimeoutPolicy, error),
+) (*hookTimeoutPolicyCache, error) {
+	c := &hookTimeoutPolicyCache{
+		changes: changes,
+		fetch:   fetch,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &c.catacomb,
+		Work: c.loop,
+		Init: []worker.Worker{changes},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c, nil
+}
+
+func (c *hookTimeoutPolicyCache) loop() error {
+	for {
+		select {
+		case <-c.catacomb.Dying():
+			return c.catacomb.ErrDying()
+		case _, ok := <-c.changes.Changes():
+			if !ok {
+				return errors.New("application config watcher closed")
+			}
+			if err := c.refresh(); err != nil {
+				return errors.Annotate(err, "getting hook timeout policy")
+			}
+		}
+	}
+}
+
+// refresh fetches the policy. The lock is held while fetching, so
+// that hooks started meanwhile get the new policy.
+func (c *hookTimeoutPolicyCache) refresh() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	policy, err := c.fetch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.policy = &policy
+	return nil
+}
+
+// HookTimeoutPolicy is part of the runner.HookTimeoutPolicyGetter
+// interface. If the watcher hasn't reported the application's config
+// yet, the policy is fetched directly.
+func (c *hookTimeoutPolicyCache) HookTimeoutPolicy() (application.HookTimeoutPolicy, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.policy == nil {
+		policy, err := c.fetch()
+		if err != nil {
+			return application.HookTimeoutPolicy{}, errors.Trace(err)
+		}
+		c.policy = &policy
+	}
+	return *c.policy, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (c *hookTimeoutPolicyCache) Kill() {
+	c.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (c *hookTimeoutPolicyCache) Wait() error {
+	return c.catacomb.Wait()
+}
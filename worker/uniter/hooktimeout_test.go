@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+)
+
+type hookTimeoutPolicyCacheSuite struct{}
+
+var _ = gc.Suite(&hookTimeoutPolicyCacheSuite{})
+
+func (s *hookTimeoutPolicyCacheSuite) TestCachesUntilConfigChanges(c *gc.C) {
+	changes := make(chan []string)
+	fetched := make(chan struct{}, 10)
+	var calls int
+	fetch := func() (application.HookTimeoutPolicy, error) {
+		calls++
+		fetched <- struct{}{}
+		return application.HookTimeoutPolicy{Default: time.Duration(calls) * time.Minute}, nil
+	}
+	cache, err := uniter.NewHookTimeoutPolicyCache(watchertest.NewMockStringsWatcher(changes), fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, cache)
+
+	sendChange := func() {
+		select {
+		case changes <- []string{"hash"}:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out sending change")
+		}
+		select {
+		case <-fetched:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for policy to be fetched")
+		}
+	}
+
+	sendChange()
+	for i := 0; i < 2; i++ {
+		policy, err := cache.HookTimeoutPolicy()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(policy.Default, gc.Equals, time.Minute)
+	}
+
+	sendChange()
+	policy, err := cache.HookTimeoutPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Default, gc.Equals, 2*time.Minute)
+}
+
+func (s *hookTimeoutPolicyCacheSuite) TestFetchesBeforeFirstChange(c *gc.C) {
+	fetch := func() (application.HookTimeoutPolicy, error) {
+		return application.HookTimeoutPolicy{Default: time.Minute}, nil
+	}
+	cache, err := uniter.NewHookTimeoutPolicyCache(watchertest.NewMockStringsWatcher(nil), fetch)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, cache)
+
+	policy, err := cache.HookTimeoutPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Default, gc.Equals, time.Minute)
+}
+
+func (s *hookTimeoutPolicyCacheSuite) TestFetchError(c *gc.C) {
+	changes := make(chan []string, 1)
+	changes <- []string{"hash"}
+	fetch := func() (application.HookTimeoutPolicy, error) {
+		return application.HookTimeoutPolicy{}, errors.New("boom")
+	}
+	cache, err := uniter.NewHookTimeoutPolicyCache(watchertest.NewMockStringsWatcher(changes), fetch)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, cache)
+	c.Assert(err, gc.ErrorMatches, "getting hook timeout policy: boom")
+}
@@ -4,6 +4,9 @@
 package uniter
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
@@ -20,6 +23,7 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a
@@ -28,6 +32,7 @@ type ManifoldConfig struct {
 	AgentName             string
 	APICallerName         string
 	MachineLock           machinelock.Lock
+	DebugSessions         *debug.RemoteSessions
 	Clock                 clock.Clock
 	LeadershipTrackerName string
 	CharmDirName          string
@@ -102,6 +107,9 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				CharmDirGuard:        charmDirGuard,
 				UpdateStatusSignal:   NewUpdateStatusTimer(),
 				HookRetryStrategy:    hookRetryStrategy,
+				HookTraceLimit:       hookTraceLimit(agentConfig),
+				HookTraceRedactEnv:   hookTraceRedactEnv(agentConfig),
+				DebugSessions:        manifoldConfig.DebugSessions,
 				NewOperationExecutor: operation.NewExecutor,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
@@ -114,6 +122,35 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 	}
 }
 
+// hookTraceLimit returns the number of hook execution traces the
+// agent is configured to keep, or zero if hooks are not traced.
+// An invalid setting is logged and hooks are not traced, rather
+// than stopping the uniter.
+func hookTraceLimit(agentConfig agent.Config) int {
+	value := agentConfig.Value(agent.HookTraceLimit)
+	if value == "" {
+		return 0
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		logger.Warningf("invalid %s %q, not tracing hooks", agent.HookTraceLimit, value)
+		return 0
+	}
+	return limit
+}
+
+// hookTraceRedactEnv returns the names of the environment variables
+// the agent is configured to remove from hook traces.
+func hookTraceRedactEnv(agentConfig agent.Config) []string {
+	var names []string
+	for _, name := range strings.Split(agentConfig.Value(agent.HookTraceRedactEnv), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // TranslateFortressErrors turns errors returned by dependent
 // manifolds due to fortress lockdown (i.e. model migration) into an
 // error which causes the resolver loop to be restarted. When this
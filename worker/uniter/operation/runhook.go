@@ -44,7 +44,7 @@ func (rh *runHook) String() string {
 		} else {
 			suffix = fmt.Sprintf(" (%d; %s)", rh.info.RelationId, rh.info.RemoteUnit)
 		}
-	case rh.info.Kind.IsStorage():
+	case hook.IsStorage(rh.info.Kind):
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
@@ -129,6 +129,17 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case charmrunner.IsHookTimedOutError(cause):
+		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		// Record the timeout, so that it can be reported in
+		// place of the usual hook failure.
+		return stateChange{
+			Kind:         RunHook,
+			Step:         Pending,
+			Hook:         &rh.info,
+			HookTimedOut: true,
+		}.apply(state), ErrHookFailed
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -299,6 +301,30 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteTimedOut(c *gc.C) {
+	runErr := charmrunner.NewHookTimedOutError("some-hook-name", time.Minute)
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:         operation.RunHook,
+		Step:         operation.Pending,
+		Hook:         &hook.Info{Kind: hooks.ConfigChanged},
+		HookTimedOut: true,
+	})
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+
+	// Running the hook again clears the timeout.
+	midState, err := op.Prepare(*newState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(midState.HookTimedOut, jc.IsFalse)
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
@@ -88,6 +88,10 @@ type State struct {
 	// upgrade is complete (instead of running an upgrade-charm hook).
 	Hook *hook.Info `yaml:"hook,omitempty"`
 
+	// HookTimedOut indicates that the hook recorded in Hook failed
+	// because it ran for longer than its timeout.
+	HookTimedOut bool `yaml:"hook-timed-out,omitempty"`
+
 	// ActionId holds action information relevant to the current operation. If
 	// Kind is Continue, it holds the last action that was executed; if Kind is
 	// RunAction, it holds the running action.
@@ -183,6 +187,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimedOut    bool
 }
 
 func (change stateChange) apply(state State) *State {
@@ -192,6 +197,7 @@ func (change stateChange) apply(state State) *State {
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
+	state.HookTimedOut = change.HookTimedOut
 	return &state
 }
 
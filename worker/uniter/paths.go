@@ -136,6 +136,10 @@ type StatePaths struct {
 	// MetricsSpoolDir acts as temporary storage for metrics being sent from
 	// the uniter to state.
 	MetricsSpoolDir string
+
+	// HookTracesDir holds traces of recent hook executions, if hook
+	// tracing is enabled.
+	HookTracesDir string
 }
 
 // SocketConfig specifies information for remote sockets.
@@ -213,6 +217,7 @@ func NewWorkerPaths(dataDir string, unitTag names.UnitTag, worker string, socket
 			DeployerDir:     join(stateDir, "deployer"),
 			StorageDir:      join(stateDir, "storage"),
 			MetricsSpoolDir: join(stateDir, "spool", "metrics"),
+			HookTracesDir:   join(stateDir, "hook-traces"),
 		},
 	}
 }
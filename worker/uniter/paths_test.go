@@ -56,6 +56,7 @@ func (s *PathsSuite) TestWindows(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookTracesDir:   relAgent("state", "hook-traces"),
 		},
 	})
 }
@@ -88,6 +89,7 @@ func (s *PathsSuite) TestWorkerPathsWindows(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookTracesDir:   relAgent("state", "hook-traces"),
 		},
 	})
 }
@@ -120,6 +122,7 @@ func (s *PathsSuite) TestOther(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookTracesDir:   relAgent("state", "hook-traces"),
 		},
 	})
 }
@@ -163,6 +166,7 @@ func (s *PathsSuite) TestTCPRemote(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookTracesDir:   relAgent("state", "hook-traces"),
 		},
 	})
 }
@@ -194,6 +198,7 @@ func (s *PathsSuite) TestWorkerPaths(c *gc.C) {
 			DeployerDir:     relAgent("state", "deployer"),
 			StorageDir:      relAgent("state", "storage"),
 			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			HookTracesDir:   relAgent("state", "hook-traces"),
 		},
 	})
 }
@@ -201,6 +201,7 @@ type mockUnit struct {
 	life                             life.Value
 	providerID                       string
 	resolved                         params.ResolvedMode
+	maintenanceMode                  bool
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockStringsWatcher
@@ -228,6 +229,10 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) MaintenanceMode() bool {
+	return u.maintenanceMode
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
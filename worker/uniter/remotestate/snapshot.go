@@ -45,6 +45,10 @@ type Snapshot struct {
 	// ProviderID is the cloud container's provider ID.
 	ProviderID string
 
+	// MaintenanceMode reports whether the unit is in maintenance
+	// mode, in which case hooks should not be run.
+	MaintenanceMode bool
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -118,4 +122,7 @@ type StorageSnapshot struct {
 	Life     life.Value
 	Attached bool
 	Location string
+
+	// Size is the size of the storage in MiB, if known.
+	Size uint64
 }
@@ -38,6 +38,7 @@ type Unit interface {
 	Refresh() error
 	ProviderID() string
 	Resolved() params.ResolvedMode
+	MaintenanceMode() bool
 	Application() (Application, error)
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
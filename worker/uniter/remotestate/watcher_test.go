@@ -296,6 +296,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedRetryHooks)
 
+	s.st.unit.maintenanceMode = true
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().MaintenanceMode, jc.IsTrue)
+
 	s.st.unit.addressesWatcher.changes <- []string{"addresseshash2"}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().AddressesHash, gc.Equals, "addresseshash2")
@@ -438,6 +443,7 @@ func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 		Life:       life.Dying,
 		Kind:       params.StorageKindBlock,
 		Location:   "malta",
+		Size:       1024,
 	}
 
 	// We should not see any event until the storage attachment watchers
@@ -457,6 +463,7 @@ func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 			Kind:     params.StorageKindBlock,
 			Attached: true,
 			Location: "malta",
+			Size:     1024,
 		},
 	})
 
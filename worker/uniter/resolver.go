@@ -21,7 +21,7 @@ import (
 type ResolverConfig struct {
 	ModelType           model.ModelType
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ReportHookError     func(hookInfo hook.Info, timedOut bool) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -100,6 +100,13 @@ func (s *uniterResolver) NextOp(
 		s.retryHookTimerStarted = false
 	}
 
+	// Hooks aren't run while the unit is in maintenance mode. Any
+	// changes arriving in the meantime are reconciled once it ends,
+	// except that a dying unit is always allowed to tear down.
+	if remoteState.MaintenanceMode && remoteState.Life != life.Dying {
+		return s.nextOpMaintenance(localState, remoteState, opFactory)
+	}
+
 	op, err = s.config.Leadership.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
@@ -153,6 +160,32 @@ func (s *uniterResolver) NextOp(
 	}
 }
 
+// nextOpMaintenance is called when the unit is in maintenance mode. Actions
+// and commands may still be run, and a hook that has already completed is
+// committed, but no other hooks are run.
+func (s *uniterResolver) nextOpMaintenance(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	op, err := s.config.Actions.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
+	op, err = s.config.Commands.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
+	if localState.Kind == operation.RunHook && localState.Step == operation.Done {
+		logger.Infof("committing %q hook", localState.Hook.Kind)
+		return opFactory.NewSkipHook(*localState.Hook)
+	}
+	logger.Debugf("unit in maintenance mode; not running hooks")
+	return nil, resolver.ErrNoOperation
+}
+
 // nextOpConflicted is called after an upgrade operation has failed, and hasn't
 // yet been resolved or reverted. When in this mode, the resolver will only
 // consider those two possibilities for progressing.
@@ -188,7 +221,7 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	if err := s.config.ReportHookError(*localState.Hook, localState.HookTimedOut); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter"
 	uniteractions "github.com/juju/juju/worker/uniter/actions"
@@ -37,7 +38,7 @@ type resolverSuite struct {
 	modelType            model.ModelType
 
 	clearResolved   func() error
-	reportHookError func(hook.Info) error
+	reportHookError func(hook.Info, bool) error
 }
 
 type caasResolverSuite struct {
@@ -78,13 +79,13 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		return errors.New("unexpected resolved")
 	}
 
-	s.reportHookError = func(hook.Info) error {
+	s.reportHookError = func(hook.Info, bool) error {
 		return errors.New("unexpected report hook error")
 	}
 
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
-		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
+		ReportHookError:     func(info hook.Info, timedOut bool) error { return s.reportHookError(info, timedOut) },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
 		ShouldRetryHooks:    true,
@@ -210,7 +211,7 @@ func (s *iaasResolverSuite) TestUniterIdlesWhenRemoteStateIsUpgradeSeriesComplet
 func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c *gc.C) {
 	s.resolverConfig.ShouldRetryHooks = false
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, bool) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -230,7 +231,7 @@ func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c
 }
 
 func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, bool) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -256,7 +257,7 @@ func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
 }
 
 func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, bool) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -287,6 +288,38 @@ func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
 }
 
+func (s *resolverSuite) TestHookTimedOutReported(c *gc.C) {
+	var reported []bool
+	s.reportHookError = func(_ hook.Info, timedOut bool) error {
+		reported = append(reported, timedOut)
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:         operation.RunHook,
+			Step:         operation.Pending,
+			Installed:    true,
+			Started:      true,
+			HookTimedOut: true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(reported, jc.DeepEquals, []bool{true})
+
+	// A timed out hook is retried like any other failed hook.
+	s.remoteState.RetryHookVersion = 1
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
+
 func (s *resolverSuite) TestResolvedRetryHooksStopRetryTimer(c *gc.C) {
 	// Resolving a failed hook should stop the retry timer.
 	s.testResolveHookErrorStopRetryTimer(c, params.ResolvedRetryHooks)
@@ -300,7 +333,7 @@ func (s *resolverSuite) TestResolvedNoHooksStopRetryTimer(c *gc.C) {
 func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.ResolvedMode) {
 	s.stub.ResetCalls()
 	s.clearResolved = func() error { return nil }
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, bool) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -326,7 +359,7 @@ func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.
 }
 
 func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, bool) error { return nil }
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
 		CharmURL:             s.charmURL,
@@ -425,3 +458,83 @@ func (s *resolverSuite) TestNoOperationIfHashesAllMatch(c *gc.C) {
 	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 }
+
+func (s *resolverSuite) TestMaintenanceModeQueuesHooks(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:       operation.Continue,
+			Installed:  true,
+			Started:    true,
+			ConfigHash: "somehash",
+		},
+	}
+	s.remoteState.ConfigHash = "differenthash"
+	s.remoteState.UpdateStatusVersion = 1
+	s.remoteState.MaintenanceMode = true
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// The queued config change is run once maintenance ends.
+	s.remoteState.MaintenanceMode = false
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
+
+func (s *resolverSuite) TestMaintenanceModeCommitsCompletedHook(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Done,
+			Installed: true,
+			Started:   true,
+			Hook:      &hook.Info{Kind: hooks.ConfigChanged},
+		},
+	}
+	s.remoteState.MaintenanceMode = true
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "skip run config-changed hook")
+}
+
+func (s *resolverSuite) TestMaintenanceModeDoesNotRunQueuedHook(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Queued,
+			Installed: true,
+			Started:   true,
+			Hook:      &hook.Info{Kind: hooks.ConfigChanged},
+		},
+	}
+	s.remoteState.MaintenanceMode = true
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestMaintenanceModeDyingUnitStops(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.MaintenanceMode = true
+	s.remoteState.Life = life.Dying
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
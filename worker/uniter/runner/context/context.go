@@ -128,6 +128,7 @@ type HookUnit interface {
 	Name() string
 	NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error)
 	OpenPorts(protocol string, fromPort, toPort int) error
+	PublishCharmMetrics([]params.CharmMetric) error
 	RequestReboot() error
 	SetState(map[string]string) error
 	SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error
@@ -290,6 +291,10 @@ type HookContext struct {
 	// A flag that keeps track of whether the unit's state has been mutated.
 	cacheDirty bool
 
+	// charmMetrics holds the metrics published by the charm, to be sent
+	// to the controller once the context is flushed.
+	charmMetrics []params.CharmMetric
+
 	mu sync.Mutex
 }
 
@@ -872,6 +877,21 @@ func (ctx *HookContext) AddMetricLabels(key, value string, created time.Time, la
 	return errors.New("metrics not allowed in this context")
 }
 
+// PublishCharmMetric records a metric to be sent to the controller when
+// the context is flushed.
+// Implements jujuc.HookContext.ContextMetrics, part of runner.Context.
+func (ctx *HookContext) PublishCharmMetric(metric jujuc.CharmMetric) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.charmMetrics = append(ctx.charmMetrics, params.CharmMetric{
+		Name:  metric.Name,
+		Type:  metric.Type,
+		Help:  metric.Help,
+		Value: metric.Value,
+	})
+	return nil
+}
+
 // ActionData returns the context's internal action data. It's meant to be
 // transitory; it exists to allow uniter and runner code to keep working as
 // it did; it should be considered deprecated, and not used by new clients.
@@ -1079,6 +1099,15 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	// Charm metrics are only monitoring data, so failing to publish
+	// them does not fail the hook.
+	if len(ctx.charmMetrics) > 0 && writeChanges {
+		if err := ctx.unit.PublishCharmMetrics(ctx.charmMetrics); err != nil {
+			logger.Warningf("cannot publish charm metrics: %v", err)
+		}
+		ctx.charmMetrics = nil
+	}
+
 	if ctx.podSpecYaml != nil && writeChanges {
 		err := ctx.commitPodSpec()
 		if ctxErr == nil {
@@ -722,6 +722,55 @@ func (s *mockHookContextSuite) TestSequentialFlushOfCacheValues(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *mockHookContextSuite) TestFlushPublishesCharmMetrics(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	hookContext := context.NewMockUnitHookContext(s.mockUnit)
+
+	s.mockUnit.EXPECT().PublishCharmMetrics([]params.CharmMetric{
+		{Name: "queue_depth", Type: "gauge", Value: 4},
+		{Name: "jobs_ok", Type: "counter", Help: "Jobs done", Value: 2},
+	}).Return(nil)
+
+	err := hookContext.PublishCharmMetric(jujuc.CharmMetric{Name: "queue_depth", Type: "gauge", Value: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	err = hookContext.PublishCharmMetric(jujuc.CharmMetric{Name: "jobs_ok", Type: "counter", Help: "Jobs done", Value: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	err = hookContext.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush again; the metrics have already been sent.
+	err = hookContext.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *mockHookContextSuite) TestFlushCharmMetricsErrorDoesNotFailHook(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	hookContext := context.NewMockUnitHookContext(s.mockUnit)
+
+	s.mockUnit.EXPECT().PublishCharmMetrics([]params.CharmMetric{
+		{Name: "queue_depth", Type: "gauge", Value: 4},
+	}).Return(errors.New("too many metrics"))
+
+	err := hookContext.PublishCharmMetric(jujuc.CharmMetric{Name: "queue_depth", Type: "gauge", Value: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	err = hookContext.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The metrics are dropped rather than sent again.
+	err = hookContext.Flush("success", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *mockHookContextSuite) TestFlushFailureDropsCharmMetrics(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	hookContext := context.NewMockUnitHookContext(s.mockUnit)
+
+	err := hookContext.PublishCharmMetric(jujuc.CharmMetric{Name: "queue_depth", Type: "gauge", Value: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	err = hookContext.Flush("failure", errors.New("hook failed"))
+	c.Assert(err, gc.ErrorMatches, "hook failed")
+}
+
 func (s *mockHookContextSuite) setupMocks(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 	s.mockUnit = mocks.NewMockHookUnit(ctrl)
@@ -228,7 +228,7 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", relation.Name(), hookInfo.Kind)
 	}
-	if hookInfo.Kind.IsStorage() {
+	if hook.IsStorage(hookInfo.Kind) {
 		ctx.storageTag = names.NewStorageTag(hookInfo.StorageId)
 		if _, err := ctx.storage.Storage(ctx.storageTag); err != nil {
 			return nil, errors.Annotatef(err, "could not retrieve storage for id: %v", hookInfo.StorageId)
@@ -148,6 +148,18 @@ func (mr *MockHookUnitMockRecorder) OpenPorts(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenPorts", reflect.TypeOf((*MockHookUnit)(nil).OpenPorts), arg0, arg1, arg2)
 }
 
+// PublishCharmMetrics mocks base method
+func (m *MockHookUnit) PublishCharmMetrics(arg0 []params.CharmMetric) error {
+	ret := m.ctrl.Call(m, "PublishCharmMetrics", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishCharmMetrics indicates an expected call of PublishCharmMetrics
+func (mr *MockHookUnitMockRecorder) PublishCharmMetrics(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishCharmMetrics", reflect.TypeOf((*MockHookUnit)(nil).PublishCharmMetrics), arg0)
+}
+
 // RequestReboot mocks base method
 func (m *MockHookUnit) RequestReboot() error {
 	ret := m.ctrl.Call(m, "RequestReboot")
@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"unsafe"
+
+	"github.com/juju/errors"
+)
+
+// startPTY starts the command with a new pseudo-terminal as its
+// controlling terminal and standard streams, and returns the
+// terminal's master side.
+func startPTY(cmd *exec.Cmd) (*os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	slave, err := openPTYSlave(master)
+	if err != nil {
+		master.Close()
+		return nil, errors.Trace(err)
+	}
+	defer slave.Close()
+
+	cmd.Stdin = slave
+	cmd.Stdout = slave
+	cmd.Stderr = slave
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+	if err := cmd.Start(); err != nil {
+		master.Close()
+		return nil, errors.Trace(err)
+	}
+	return master, nil
+}
+
+func openPTYSlave(master *os.File) (*os.File, error) {
+	var unlock int32
+	if err := ioctl(master, syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); err != nil {
+		return nil, errors.Annotate(err, "unlocking pseudo-terminal")
+	}
+	var n uint32
+	if err := ioctl(master, syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); err != nil {
+		return nil, errors.Annotate(err, "getting pseudo-terminal number")
+	}
+	return os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+}
+
+// setPTYSize sets the size of the pseudo-terminal.
+func setPTYSize(master *os.File, rows, cols uint16) error {
+	size := struct{ rows, cols, x, y uint16 }{rows: rows, cols: cols}
+	return errors.Trace(ioctl(master, syscall.TIOCSWINSZ, uintptr(unsafe.Pointer(&size))))
+}
+
+func ioctl(f *os.File, req, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !linux
+
+package debug
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/juju/errors"
+)
+
+func startPTY(cmd *exec.Cmd) (*os.File, error) {
+	return nil, errors.NotSupportedf("debug shells on %s", runtime.GOOS)
+}
+
+func setPTYSize(master *os.File, rows, cols uint16) error {
+	return errors.NotSupportedf("debug shells on %s", runtime.GOOS)
+}
@@ -0,0 +1,358 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.runner.debug")
+
+// outputDrainTimeout is how long to wait for the output of a debug
+// shell after it exits, in case a background process it started
+// still holds the terminal open.
+const outputDrainTimeout = time.Second
+
+// RemoteStream carries a debug session between the unit agent and
+// an operator, relayed by the controller.
+type RemoteStream interface {
+	// Send sends the message to the operator.
+	Send(params.DebugShellMessage) error
+
+	// Receive blocks until a message is received from the operator.
+	Receive() (params.DebugShellMessage, error)
+
+	// Close ends the session.
+	Close() error
+}
+
+// RemoteSession represents a debug session requested by an operator
+// over the API, rather than by "juju debug-hooks" over SSH. Instead
+// of running the next matching hook, the unit agent runs a shell
+// with the hook's environment in a pseudo-terminal, and relays the
+// terminal to the operator.
+type RemoteSession struct {
+	*HooksContext
+	sessions *RemoteSessions
+	hooks    set.Strings
+	stream   RemoteStream
+
+	// sendMu serialises messages sent to the operator.
+	sendMu sync.Mutex
+
+	// input receives the operator's messages once the shell
+	// has started. It is closed when the stream breaks.
+	input chan params.DebugShellMessage
+
+	// started is closed when the session is claimed to run a hook.
+	started chan struct{}
+
+	// done is closed when the session ends.
+	done chan struct{}
+}
+
+// RemoteSessions holds the sessions waiting for a hook to run, by
+// unit name. An agent shares one between the worker that accepts
+// the operators' sessions and the uniters that run their units' hooks.
+type RemoteSessions struct {
+	mu     sync.Mutex
+	byUnit map[string]*RemoteSession
+}
+
+// NewRemoteSessions returns a new, empty, set of remote sessions.
+func NewRemoteSessions() *RemoteSessions {
+	return &RemoteSessions{byUnit: make(map[string]*RemoteSession)}
+}
+
+// Start records that an operator is waiting, over the supplied
+// stream, to debug the named hooks of the unit specified in the
+// context, or its next hook if none are named. It replaces any
+// session already waiting for the unit.
+func (r *RemoteSessions) Start(c *HooksContext, hooks []string, stream RemoteStream) *RemoteSession {
+	s := &RemoteSession{
+		HooksContext: c,
+		sessions:     r,
+		hooks:        set.NewStrings(hooks...),
+		stream:       stream,
+		input:        make(chan params.DebugShellMessage),
+		started:      make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	r.mu.Lock()
+	existing := r.byUnit[c.Unit]
+	r.byUnit[c.Unit] = s
+	r.mu.Unlock()
+	if existing != nil {
+		existing.Close()
+	}
+	go s.receive()
+	return s
+}
+
+// Claim returns the session waiting to debug the named hook of the
+// unit specified in the context, if there is one. The session is
+// removed, so it runs no other hook.
+func (r *RemoteSessions) Claim(c *HooksContext, hookName string) *RemoteSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.byUnit[c.Unit]
+	if s == nil || !s.MatchHook(hookName) {
+		return nil
+	}
+	delete(r.byUnit, c.Unit)
+	close(s.started)
+	return s
+}
+
+// MatchHook returns true if the specified hook name matches
+// the hooks requested by the operator.
+func (s *RemoteSession) MatchHook(hookName string) bool {
+	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
+}
+
+// Done returns a channel that is closed when the session ends.
+func (s *RemoteSession) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close ends the session, closing its stream.
+func (s *RemoteSession) Close() error {
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
+	return s.closeLocked()
+}
+
+func (s *RemoteSession) closeLocked() error {
+	if s.sessions.byUnit[s.Unit] == s {
+		delete(s.sessions.byUnit, s.Unit)
+	}
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	close(s.done)
+	return s.stream.Close()
+}
+
+// receive reads the operator's messages until the stream breaks.
+// Messages sent before the shell starts are discarded. If the
+// stream breaks before then, the session ends.
+func (s *RemoteSession) receive() {
+	defer close(s.input)
+	for {
+		m, err := s.stream.Receive()
+		if err != nil {
+			logger.Debugf("debug session for %s ended: %v", s.Unit, err)
+			s.abandon()
+			return
+		}
+		select {
+		case <-s.started:
+		default:
+			continue
+		}
+		select {
+		case s.input <- m:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// abandon ends the session if it has not been claimed.
+func (s *RemoteSession) abandon() {
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
+	select {
+	case <-s.started:
+	default:
+		s.closeLocked()
+	}
+}
+
+// RunHook "runs" the hook with the specified name by starting a shell
+// with the hook's environment, which the operator uses to run the hook
+// or investigate as they see fit. The session ends when the shell
+// exits, and the shell's exit status is reported as the hook's.
+func (s *RemoteSession) RunHook(hookName, charmDir string, env []string) error {
+	defer s.Close()
+	debugDir, err := ioutil.TempDir("", "juju-debug-shell-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(debugDir)
+	if err := s.writeDebugFiles(debugDir); err != nil {
+		return errors.Trace(err)
+	}
+
+	env = utils.Setenv(env, "JUJU_HOOK_NAME="+hookName)
+	env = utils.Setenv(env, "JUJU_DEBUG="+debugDir)
+	env = utils.Setenv(env, "PS1="+s.Unit+":"+hookName+" % ")
+	if !hasEnv(env, "TERM") {
+		env = append(env, "TERM=xterm")
+	}
+
+	cmd := exec.Command("/bin/bash", "--noprofile", "--init-file", filepath.Join(debugDir, "init.sh"))
+	cmd.Env = env
+	cmd.Dir = charmDir
+	master, err := startPTY(cmd)
+	if err != nil {
+		return errors.Annotate(err, "starting debug shell")
+	}
+	defer master.Close()
+
+	if err := s.send(params.DebugShellMessage{Hook: hookName}); err != nil {
+		logger.Debugf("cannot start debug session for %s: %v", s.Unit, err)
+		cmd.Process.Kill()
+	}
+	output := make(chan struct{})
+	go func() {
+		defer close(output)
+		s.sendOutput(master)
+	}()
+	go s.forwardInput(master, cmd.Process)
+
+	err = cmd.Wait()
+	select {
+	case <-output:
+	case <-time.After(outputDrainTimeout):
+	}
+	code := shellExitCode(err)
+	if sendErr := s.send(params.DebugShellMessage{ExitCode: &code}); sendErr != nil {
+		logger.Debugf("cannot send debug shell exit code for %s: %v", s.Unit, sendErr)
+	}
+	return err
+}
+
+func (s *RemoteSession) send(m params.DebugShellMessage) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.stream.Send(m)
+}
+
+func (s *RemoteSession) writeDebugFiles(debugDir string) error {
+	files := []struct {
+		filename string
+		contents string
+		mode     os.FileMode
+	}{
+		{"welcome.msg", debugShellWelcomeMessage, 0644},
+		{"init.sh", debugShellInitScript, 0755},
+	}
+	for _, file := range files {
+		if err := ioutil.WriteFile(
+			filepath.Join(debugDir, file.filename),
+			[]byte(file.contents),
+			file.mode,
+		); err != nil {
+			return errors.Annotatef(err, "writing %q", file.filename)
+		}
+	}
+	return nil
+}
+
+// sendOutput sends the shell's terminal output to the operator,
+// until the terminal is closed.
+func (s *RemoteSession) sendOutput(master *os.File) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := master.Read(buf)
+		if n > 0 {
+			data := append([]byte(nil), buf[:n]...)
+			if sendErr := s.send(params.DebugShellMessage{Data: data}); sendErr != nil {
+				return
+			}
+		}
+		if err != nil {
+			// Reading the terminal fails with EIO once
+			// the shell and its children have exited.
+			return
+		}
+	}
+}
+
+// forwardInput writes the operator's input to the shell's terminal
+// until the session ends. If the operator goes away, the shell is
+// killed so the hook does not wait for them forever.
+func (s *RemoteSession) forwardInput(master *os.File, proc *os.Process) {
+	for {
+		select {
+		case <-s.done:
+			return
+		case m, ok := <-s.input:
+			if !ok {
+				proc.Kill()
+				return
+			}
+			if m.Rows > 0 && m.Cols > 0 {
+				if err := setPTYSize(master, m.Rows, m.Cols); err != nil {
+					logger.Debugf("cannot resize debug shell terminal: %v", err)
+				}
+			}
+			if len(m.Data) > 0 {
+				if _, err := master.Write(m.Data); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func hasEnv(env []string, name string) bool {
+	for _, kv := range env {
+		if strings.HasPrefix(kv, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// shellExitCode returns the exit code for the error returned by
+// running the shell.
+func shellExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
+
+const debugShellWelcomeMessage = `This is a Juju debug shell for $JUJU_UNIT_NAME, started in place of
+the $JUJU_HOOK_NAME hook or action. Remember:
+1. The hook or action has not run; you need to run it manually if you want
+it to take effect:
+
+./hooks/$JUJU_HOOK_NAME # or, equivalently, ./actions/$JUJU_HOOK_NAME
+
+2. When you are finished, run 'exit' to allow Juju to continue processing
+events for this unit. The exit status of the shell is reported as the
+result of the hook or action, so use 'exit 1' to mark it as failed.
+
+More help and info is available in the online documentation:
+https://discourse.jujucharms.com/t/debugging-charm-hooks
+
+`
+
+const debugShellInitScript = `#!/bin/bash
+envsubst < $JUJU_DEBUG/welcome.msg
+`
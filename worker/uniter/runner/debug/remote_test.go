@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type RemoteSessionSuite struct {
+	testing.BaseSuite
+	ctx      *HooksContext
+	sessions *RemoteSessions
+}
+
+var _ = gc.Suite(&RemoteSessionSuite{})
+
+func (s *RemoteSessionSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.ctx = NewHooksContext("foo/1")
+	s.sessions = NewRemoteSessions()
+}
+
+// fakeStream is a RemoteStream connected to a test acting as the
+// operator.
+type fakeStream struct {
+	toOperator   chan params.DebugShellMessage
+	fromOperator chan params.DebugShellMessage
+	closed       chan struct{}
+}
+
+func newFakeStream() *fakeStream {
+	return &fakeStream{
+		toOperator:   make(chan params.DebugShellMessage, 100),
+		fromOperator: make(chan params.DebugShellMessage),
+		closed:       make(chan struct{}),
+	}
+}
+
+func (f *fakeStream) Send(m params.DebugShellMessage) error {
+	select {
+	case <-f.closed:
+		return io.ErrClosedPipe
+	case f.toOperator <- m:
+		return nil
+	}
+}
+
+func (f *fakeStream) Receive() (params.DebugShellMessage, error) {
+	select {
+	case <-f.closed:
+		return params.DebugShellMessage{}, io.EOF
+	case m, ok := <-f.fromOperator:
+		if !ok {
+			return params.DebugShellMessage{}, io.EOF
+		}
+		return m, nil
+	}
+}
+
+func (f *fakeStream) Close() error {
+	select {
+	case <-f.closed:
+	default:
+		close(f.closed)
+	}
+	return nil
+}
+
+func (s *RemoteSessionSuite) TestClaimMatchingHook(c *gc.C) {
+	c.Assert(s.sessions.Claim(s.ctx, "install"), gc.IsNil)
+
+	session := s.sessions.Start(s.ctx, []string{"config-changed"}, newFakeStream())
+	defer session.Close()
+	c.Assert(s.sessions.Claim(s.ctx, "install"), gc.IsNil)
+	c.Assert(s.sessions.Claim(s.ctx, "config-changed"), gc.Equals, session)
+
+	// A session only runs a single hook.
+	c.Assert(s.sessions.Claim(s.ctx, "config-changed"), gc.IsNil)
+}
+
+func (s *RemoteSessionSuite) TestClaimAnyHook(c *gc.C) {
+	session := s.sessions.Start(s.ctx, nil, newFakeStream())
+	defer session.Close()
+	c.Assert(s.sessions.Claim(NewHooksContext("foo/2"), "install"), gc.IsNil)
+	c.Assert(s.sessions.Claim(s.ctx, "install"), gc.Equals, session)
+}
+
+func (s *RemoteSessionSuite) TestSessionsAreSeparate(c *gc.C) {
+	session := s.sessions.Start(s.ctx, nil, newFakeStream())
+	defer session.Close()
+	c.Assert(NewRemoteSessions().Claim(s.ctx, "install"), gc.IsNil)
+	c.Assert(s.sessions.Claim(s.ctx, "install"), gc.Equals, session)
+}
+
+func (s *RemoteSessionSuite) TestStartReplacesSession(c *gc.C) {
+	stream := newFakeStream()
+	first := s.sessions.Start(s.ctx, nil, stream)
+	second := s.sessions.Start(s.ctx, nil, newFakeStream())
+	defer second.Close()
+	s.assertDone(c, first)
+	c.Assert(s.sessions.Claim(s.ctx, "install"), gc.Equals, second)
+}
+
+func (s *RemoteSessionSuite) TestOperatorGoneBeforeHook(c *gc.C) {
+	stream := newFakeStream()
+	session := s.sessions.Start(s.ctx, nil, stream)
+	close(stream.fromOperator)
+	s.assertDone(c, session)
+	c.Assert(s.sessions.Claim(s.ctx, "install"), gc.IsNil)
+}
+
+func (s *RemoteSessionSuite) TestRunHook(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("debug shells are only supported on linux")
+	}
+	stream := newFakeStream()
+	s.sessions.Start(s.ctx, nil, stream)
+	session := s.sessions.Claim(s.ctx, "config-changed")
+	c.Assert(session, gc.NotNil)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- session.RunHook("config-changed", c.MkDir(), []string{"PATH=/usr/bin:/bin"})
+	}()
+
+	m := s.nextMessage(c, stream)
+	c.Assert(m.Hook, gc.Equals, "config-changed")
+	stream.fromOperator <- params.DebugShellMessage{Rows: 24, Cols: 80}
+	stream.fromOperator <- params.DebugShellMessage{Data: []byte("echo $JUJU_HOOK_NAME; stty size; exit 3\n")}
+
+	var output bytes.Buffer
+	for {
+		m := s.nextMessage(c, stream)
+		if m.ExitCode != nil {
+			c.Check(*m.ExitCode, gc.Equals, 3)
+			break
+		}
+		output.Write(m.Data)
+	}
+	c.Check(output.String(), jc.Contains, "\nconfig-changed\r\n24 80\r\n")
+
+	select {
+	case err := <-result:
+		c.Assert(err, gc.ErrorMatches, "exit status 3")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for hook")
+	}
+	s.assertDone(c, session)
+}
+
+func (s *RemoteSessionSuite) TestRunHookOperatorGone(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("debug shells are only supported on linux")
+	}
+	stream := newFakeStream()
+	s.sessions.Start(s.ctx, nil, stream)
+	session := s.sessions.Claim(s.ctx, "install")
+	c.Assert(session, gc.NotNil)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- session.RunHook("install", c.MkDir(), nil)
+	}()
+	c.Assert(s.nextMessage(c, stream).Hook, gc.Equals, "install")
+	close(stream.fromOperator)
+
+	select {
+	case err := <-result:
+		c.Assert(err, gc.ErrorMatches, "signal: killed")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for hook")
+	}
+}
+
+func (s *RemoteSessionSuite) nextMessage(c *gc.C, stream *fakeStream) params.DebugShellMessage {
+	select {
+	case m := <-stream.toOperator:
+		return m
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for message")
+	}
+	panic("unreachable")
+}
+
+func (s *RemoteSessionSuite) assertDone(c *gc.C, session *RemoteSession) {
+	select {
+	case <-session.Done():
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for session to end")
+	}
+}
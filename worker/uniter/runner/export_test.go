@@ -4,7 +4,10 @@
 package runner
 
 import (
+	"time"
+
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
 )
 
 var (
@@ -17,3 +20,14 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func NewTracingRunner(context Context, paths context.Paths, remoteExecutor ExecFunc, traces *hooktrace.Store) Runner {
+	return newTracingRunner(context, paths, remoteExecutor, traces, hookTimeout{}, nil)
+}
+
+func NewTimeoutRunner(context Context, paths context.Paths, remoteExecutor ExecFunc, timeout, killGracePeriod time.Duration) Runner {
+	return newTracingRunner(context, paths, remoteExecutor, nil, hookTimeout{
+		timeout:         timeout,
+		killGracePeriod: killGracePeriod,
+	}, nil)
+}
@@ -11,9 +11,12 @@ import (
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
 )
 
 // Factory represents a long-lived object that can create runners
@@ -33,13 +36,26 @@ type Factory interface {
 	NewActionRunner(actionId string) (Runner, error)
 }
 
+// HookTimeoutPolicyGetter provides the hook timeout policy of the
+// unit's application.
+type HookTimeoutPolicyGetter interface {
+	HookTimeoutPolicy() (application.HookTimeoutPolicy, error)
+}
+
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands. If hookTraces is not nil, the hook
+// runners record a trace of each hook they run to it. The hook runners
+// stop hooks according to the policy provided by hookTimeouts, and run
+// any debug session in debugSessions, if it is not nil, that is
+// waiting for the hook.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
 	remoteExecutor ExecFunc,
+	hookTraces *hooktrace.Store,
+	hookTimeouts HookTimeoutPolicyGetter,
+	debugSessions *debug.RemoteSessions,
 ) (
 	Factory, error,
 ) {
@@ -48,6 +64,9 @@ func NewFactory(
 		paths:          paths,
 		contextFactory: contextFactory,
 		remoteExecutor: remoteExecutor,
+		hookTraces:     hookTraces,
+		hookTimeouts:   hookTimeouts,
+		debugSessions:  debugSessions,
 	}
 
 	return f, nil
@@ -62,6 +81,9 @@ type factory struct {
 	// Fields that shouldn't change in a factory's lifetime.
 	paths          context.Paths
 	remoteExecutor ExecFunc
+	hookTraces     *hooktrace.Store
+	hookTimeouts   HookTimeoutPolicyGetter
+	debugSessions  *debug.RemoteSessions
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -84,7 +106,15 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths, f.remoteExecutor)
+	policy, err := f.hookTimeouts.HookTimeoutPolicy()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting hook timeout policy")
+	}
+	timeout := hookTimeout{
+		timeout:         policy.Timeout(hookInfo.Kind),
+		killGracePeriod: policy.KillGracePeriod,
+	}
+	runner := newTracingRunner(ctx, f.paths, f.remoteExecutor, f.hookTraces, timeout, f.debugSessions)
 	return runner, nil
 }
 
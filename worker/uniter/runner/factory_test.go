@@ -179,6 +179,9 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		s.paths,
 		contextFactory,
 		nil,
+		nil,
+		uniter,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
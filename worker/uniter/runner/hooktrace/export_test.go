@@ -0,0 +1,16 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace
+
+import (
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+func NewReplayHandler(calls []ToolCall) jujuc.Handler {
+	return newReplayHandler(calls)
+}
+
+func ReplayHandlerResult(h jujuc.Handler) *ReplayResult {
+	return h.(*replayHandler).result()
+}
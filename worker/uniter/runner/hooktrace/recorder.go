@@ -0,0 +1,105 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace
+
+import (
+	"sync"
+
+	"github.com/juju/clock"
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Recorder records the execution of a single hook.
+type Recorder struct {
+	clock clock.Clock
+
+	mu    sync.Mutex
+	trace Trace
+}
+
+// NewRecorder returns a Recorder for an execution of the
+// named hook by the named unit, starting now.
+func NewRecorder(unitName, hookName string, clock clock.Clock) *Recorder {
+	started := clock.Now()
+	return &Recorder{
+		clock: clock,
+		trace: Trace{
+			ID:      newTraceID(hookName, started),
+			Unit:    unitName,
+			Hook:    hookName,
+			Started: started,
+		},
+	}
+}
+
+// SetRelation records the relation and remote unit that
+// a relation hook runs for.
+func (r *Recorder) SetRelation(relation, remoteUnit string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace.Relation = relation
+	r.trace.RemoteUnit = remoteUnit
+}
+
+// SetEnv records the environment the hook runs with. The values of
+// sensitive variables are removed when the trace is saved.
+func (r *Recorder) SetEnv(env []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace.Env = append([]string(nil), env...)
+}
+
+// Handler returns a jujuc.Handler that passes hook tool
+// invocations to the supplied handler, recording each
+// invocation along with its result.
+func (r *Recorder) Handler(handler jujuc.Handler) jujuc.Handler {
+	return &recordingHandler{recorder: r, handler: handler}
+}
+
+// Finish records the outcome of running the hook and
+// returns the completed trace.
+func (r *Recorder) Finish(err error) *Trace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace.Duration = r.clock.Now().Sub(r.trace.Started)
+	r.trace.ExitCode = exitCode(err)
+	if err != nil {
+		r.trace.Error = err.Error()
+	}
+	trace := r.trace
+	return &trace
+}
+
+func (r *Recorder) addToolCall(call ToolCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace.ToolCalls = append(r.trace.ToolCalls, call)
+}
+
+// recordingHandler is a jujuc.Handler that records the
+// hook tool invocations passed to another handler.
+type recordingHandler struct {
+	recorder *Recorder
+	handler  jujuc.Handler
+}
+
+// Main is part of the jujuc.Handler interface.
+func (h *recordingHandler) Main(req jujuc.Request, resp *exec.ExecResponse) error {
+	err := h.handler.Main(req, resp)
+	call := ToolCall{
+		Command: req.CommandName,
+		Args:    req.Args,
+		Stdin:   req.Stdin,
+		Code:    resp.Code,
+		Stdout:  resp.Stdout,
+		Stderr:  resp.Stderr,
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	h.recorder.addToolCall(call)
+	return err
+}
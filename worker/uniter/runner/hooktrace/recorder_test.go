@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type recorderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&recorderSuite{})
+
+type stubHandler struct {
+	*testing.Stub
+}
+
+func (h stubHandler) Main(req jujuc.Request, resp *exec.ExecResponse) error {
+	h.AddCall("Main", req.CommandName, req.Args)
+	if err := h.NextErr(); err != nil {
+		return err
+	}
+	resp.Code = 2
+	resp.Stdout = []byte("out")
+	resp.Stderr = []byte("err")
+	return nil
+}
+
+func (s *recorderSuite) TestRecord(c *gc.C) {
+	clock := testclock.NewClock(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	recorder := hooktrace.NewRecorder("mysql/0", "db-relation-changed", clock)
+	recorder.SetRelation("db:2", "wordpress/1")
+	recorder.SetEnv([]string{"JUJU_UNIT_NAME=mysql/0", "JUJU_AGENT_TOKEN=secret"})
+
+	stub := &testing.Stub{}
+	stub.SetErrors(jujuc.ErrNoStdin)
+	handler := recorder.Handler(stubHandler{stub})
+
+	var resp exec.ExecResponse
+	req := jujuc.Request{CommandName: "relation-set", Args: []string{"--file", "-"}}
+	err := handler.Main(req, &resp)
+	c.Assert(err, gc.Equals, jujuc.ErrNoStdin)
+
+	req.StdinSet = true
+	req.Stdin = []byte("foo: bar")
+	err = handler.Main(req, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCallNames(c, "Main", "Main")
+
+	clock.Advance(3 * time.Second)
+	trace := recorder.Finish(errors.New("boom"))
+	c.Check(trace, jc.DeepEquals, &hooktrace.Trace{
+		ID:         "20200102-030405.000000-db-relation-changed",
+		Unit:       "mysql/0",
+		Hook:       "db-relation-changed",
+		Relation:   "db:2",
+		RemoteUnit: "wordpress/1",
+		Started:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Duration:   3 * time.Second,
+		Env:        []string{"JUJU_UNIT_NAME=mysql/0", "JUJU_AGENT_TOKEN=secret"},
+		ToolCalls: []hooktrace.ToolCall{{
+			Command: "relation-set",
+			Args:    []string{"--file", "-"},
+			Error:   jujuc.ErrNoStdin.Error(),
+		}, {
+			Command: "relation-set",
+			Args:    []string{"--file", "-"},
+			Stdin:   []byte("foo: bar"),
+			Code:    2,
+			Stdout:  []byte("out"),
+			Stderr:  []byte("err"),
+		}},
+		ExitCode: -1,
+		Error:    "boom",
+	})
+}
+
+func (s *recorderSuite) TestFinishSuccess(c *gc.C) {
+	clock := testclock.NewClock(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	trace := hooktrace.NewRecorder("mysql/0", "install", clock).Finish(nil)
+	c.Check(trace.ExitCode, gc.Equals, 0)
+	c.Check(trace.Error, gc.Equals, "")
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	utilexec "github.com/juju/utils/exec"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// ReplayParams holds the parameters for replaying a traced hook.
+type ReplayParams struct {
+	// Trace is the hook execution to replay.
+	Trace *Trace
+
+	// CharmDir is the directory holding the charm whose hook is run.
+	CharmDir string
+
+	// Socket is where the stub hook tool server listens.
+	Socket sockets.Socket
+
+	// Stdout and Stderr receive the hook's output.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Validate returns an error if the parameters cannot be used
+// to replay a hook.
+func (p ReplayParams) Validate() error {
+	if p.Trace == nil {
+		return errors.NotValidf("missing Trace")
+	}
+	if p.CharmDir == "" {
+		return errors.NotValidf("missing CharmDir")
+	}
+	if p.Socket.Address == "" {
+		return errors.NotValidf("missing Socket")
+	}
+	return nil
+}
+
+// ReplayResult describes the outcome of replaying a traced hook.
+type ReplayResult struct {
+	// ExitCode is the exit code of the replayed hook.
+	ExitCode int
+
+	// Unexpected are the hook tool commands invoked by the replayed
+	// hook that do not match any recorded invocation.
+	Unexpected []ToolCall
+
+	// Unused are the recorded hook tool invocations that the
+	// replayed hook did not make.
+	Unused []ToolCall
+}
+
+// Diverged reports whether the replayed hook behaved
+// differently to the traced one.
+func (r *ReplayResult) Diverged(trace *Trace) bool {
+	return r.ExitCode != trace.ExitCode || len(r.Unexpected) > 0 || len(r.Unused) > 0
+}
+
+// Replay runs the traced hook again, with the environment it originally
+// ran with. Its hook tool commands are served by a stub server that
+// returns the recorded results instead of acting on the model. Anything
+// else the hook does, such as installing packages, writing files or
+// restarting services, really happens again, so hooks should not be
+// replayed on machines running production workloads.
+//
+// Values redacted from the trace are replayed as "<redacted>".
+func Replay(p ReplayParams) (*ReplayResult, error) {
+	if err := p.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	handler := newReplayHandler(p.Trace.ToolCalls)
+	srv, err := jujuc.NewHandlerServer(handler, p.Socket)
+	if err != nil {
+		return nil, errors.Annotate(err, "starting stub hook tool server")
+	}
+	go func() { _ = srv.Run() }()
+	defer srv.Close()
+
+	ps := exec.Command(filepath.Join(p.CharmDir, "hooks", p.Trace.Hook))
+	ps.Env = replayEnv(p.Trace.Env, p.CharmDir, p.Socket)
+	ps.Dir = p.CharmDir
+	ps.Stdout = p.Stdout
+	ps.Stderr = p.Stderr
+	err = ps.Run()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		return nil, errors.Annotatef(err, "running hook %q", p.Trace.Hook)
+	}
+
+	result := handler.result()
+	result.ExitCode = exitCode(err)
+	return result, nil
+}
+
+// replayEnv returns the traced environment, altered so that hook
+// tools are served by the stub server for the input socket.
+func replayEnv(env []string, charmDir string, socket sockets.Socket) []string {
+	overrides := map[string]string{
+		"JUJU_CHARM_DIR":            charmDir,
+		"JUJU_AGENT_SOCKET_ADDRESS": socket.Address,
+		"JUJU_AGENT_SOCKET_NETWORK": socket.Network,
+	}
+	dropped := map[string]bool{
+		"JUJU_AGENT_TOKEN":   true,
+		"JUJU_AGENT_CA_CERT": true,
+	}
+	var result []string
+	for _, kv := range env {
+		name := strings.SplitN(kv, "=", 2)[0]
+		if _, ok := overrides[name]; ok || dropped[name] {
+			continue
+		}
+		result = append(result, kv)
+	}
+	for _, name := range []string{"JUJU_CHARM_DIR", "JUJU_AGENT_SOCKET_ADDRESS", "JUJU_AGENT_SOCKET_NETWORK"} {
+		result = append(result, name+"="+overrides[name])
+	}
+	return result
+}
+
+// replayHandler is a jujuc.Handler that answers hook tool
+// invocations with recorded results.
+type replayHandler struct {
+	mu         sync.Mutex
+	calls      []ToolCall
+	used       []bool
+	unexpected []ToolCall
+}
+
+func newReplayHandler(calls []ToolCall) *replayHandler {
+	return &replayHandler{
+		calls: calls,
+		used:  make([]bool, len(calls)),
+	}
+}
+
+// Main is part of the jujuc.Handler interface. Each invocation is
+// answered with the result of the earliest unused recorded invocation
+// of the same command with the same arguments.
+func (h *replayHandler) Main(req jujuc.Request, resp *utilexec.ExecResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, call := range h.calls {
+		if h.used[i] || call.Command != req.CommandName || !argsEqual(call.Args, req.Args) {
+			continue
+		}
+		h.used[i] = true
+		if call.Error != "" {
+			return errors.New(call.Error)
+		}
+		resp.Code = call.Code
+		resp.Stdout = call.Stdout
+		resp.Stderr = call.Stderr
+		return nil
+	}
+
+	call := ToolCall{
+		Command: req.CommandName,
+		Args:    req.Args,
+		Stdin:   req.Stdin,
+	}
+	h.unexpected = append(h.unexpected, call)
+	resp.Code = 1
+	resp.Stderr = []byte(fmt.Sprintf("no recorded result for %q\n", call.String()))
+	return nil
+}
+
+func (h *replayHandler) result() *ReplayResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result := &ReplayResult{Unexpected: h.unexpected}
+	for i, call := range h.calls {
+		if !h.used[i] {
+			result.Unused = append(result.Unused, call)
+		}
+	}
+	return result
+}
+
+func argsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type replaySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&replaySuite{})
+
+var recordedCalls = []hooktrace.ToolCall{{
+	Command: "config-get",
+	Args:    []string{"port"},
+	Stdout:  []byte("8080\n"),
+}, {
+	Command: "relation-set",
+	Args:    []string{"--file", "-"},
+	Error:   jujuc.ErrNoStdin.Error(),
+}, {
+	Command: "relation-set",
+	Args:    []string{"--file", "-"},
+	Stdin:   []byte("port: 8080"),
+}, {
+	Command: "config-get",
+	Args:    []string{"port"},
+	Stdout:  []byte("8081\n"),
+}, {
+	Command: "status-set",
+	Args:    []string{"active"},
+}}
+
+func (s *replaySuite) TestReplayHandler(c *gc.C) {
+	handler := hooktrace.NewReplayHandler(recordedCalls)
+	call := func(command string, args ...string) (exec.ExecResponse, error) {
+		var resp exec.ExecResponse
+		err := handler.Main(jujuc.Request{CommandName: command, Args: args}, &resp)
+		return resp, err
+	}
+
+	resp, err := call("config-get", "port")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(resp.Stdout), gc.Equals, "8080\n")
+
+	_, err = call("relation-set", "--file", "-")
+	c.Check(err, gc.ErrorMatches, jujuc.ErrNoStdin.Error())
+	resp, err = call("relation-set", "--file", "-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resp.Code, gc.Equals, 0)
+
+	// Repeated invocations get the results in recorded order.
+	resp, err = call("config-get", "port")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(resp.Stdout), gc.Equals, "8081\n")
+
+	resp, err = call("leader-get")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resp.Code, gc.Equals, 1)
+	c.Check(string(resp.Stderr), gc.Equals, "no recorded result for \"leader-get\"\n")
+
+	result := hooktrace.ReplayHandlerResult(handler)
+	c.Check(result.Unexpected, jc.DeepEquals, []hooktrace.ToolCall{{Command: "leader-get"}})
+	c.Check(result.Unused, jc.DeepEquals, recordedCalls[4:])
+}
+
+func (s *replaySuite) TestReplay(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("replaying hooks uses a shell script")
+	}
+	charmDir := c.MkDir()
+	err := os.Mkdir(filepath.Join(charmDir, "hooks"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	hook := "#!/bin/sh\necho $JUJU_UNIT_NAME $JUJU_AGENT_SOCKET_NETWORK $JUJU_AGENT_TOKEN\nexit 3\n"
+	err = ioutil.WriteFile(filepath.Join(charmDir, "hooks", "install"), []byte(hook), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	trace := &hooktrace.Trace{
+		ID:        "20200102-030405.000000-install",
+		Unit:      "mysql/0",
+		Hook:      "install",
+		Env:       []string{"JUJU_UNIT_NAME=mysql/0", "JUJU_AGENT_TOKEN=<redacted>"},
+		ToolCalls: recordedCalls[4:],
+		ExitCode:  3,
+	}
+	var stdout bytes.Buffer
+	result, err := hooktrace.Replay(hooktrace.ReplayParams{
+		Trace:    trace,
+		CharmDir: charmDir,
+		Socket:   sockets.Socket{Network: "unix", Address: filepath.Join(c.MkDir(), "replay.socket")},
+		Stdout:   &stdout,
+		Stderr:   &stdout,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdout.String(), gc.Equals, "mysql/0 unix\n")
+	c.Check(result.ExitCode, gc.Equals, 3)
+	c.Check(result.Unexpected, gc.HasLen, 0)
+	c.Check(result.Unused, jc.DeepEquals, recordedCalls[4:])
+	c.Check(result.Diverged(trace), jc.IsTrue)
+}
+
+func (s *replaySuite) TestReplayParamsValidate(c *gc.C) {
+	err := hooktrace.ReplayParams{}.Validate()
+	c.Check(err, gc.ErrorMatches, "missing Trace not valid")
+	err = hooktrace.ReplayParams{Trace: &hooktrace.Trace{}}.Validate()
+	c.Check(err, gc.ErrorMatches, "missing CharmDir not valid")
+	err = hooktrace.ReplayParams{Trace: &hooktrace.Trace{}, CharmDir: "/charm"}.Validate()
+	c.Check(err, gc.ErrorMatches, "missing Socket not valid")
+}
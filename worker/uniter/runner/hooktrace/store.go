@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+const traceSuffix = ".json"
+
+// Store keeps a bounded number of hook traces in a directory,
+// discarding the oldest when the limit is exceeded.
+type Store struct {
+	dir       string
+	limit     int
+	redactEnv []string
+}
+
+// NewStore returns a Store that keeps at most limit traces in dir.
+// The values of the named environment variables are removed from the
+// traces it saves, along with those of variables whose names suggest
+// they hold credentials, such as JUJU_AGENT_TOKEN. The output of hook
+// tools that read configuration and relation data is removed too.
+func NewStore(dir string, limit int, redactEnv []string) *Store {
+	return &Store{dir: dir, limit: limit, redactEnv: redactEnv}
+}
+
+// Save writes the trace to the store, then discards the oldest
+// traces so that no more than the store's limit are kept.
+func (s *Store) Save(trace *Trace) error {
+	if err := validateTraceID(trace.ID); err != nil {
+		return errors.Trace(err)
+	}
+	redacted := *trace
+	redacted.Env = redactEnv(trace.Env, s.redactEnv)
+	redacted.ToolCalls = redactToolCalls(trace.ToolCalls)
+	data, err := json.MarshalIndent(&redacted, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Traces may hold sensitive configuration, so they
+	// are only readable by the agent.
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	if err := utils.AtomicWriteFile(s.path(trace.ID), data, 0600); err != nil {
+		return errors.Annotatef(err, "writing hook trace %q", trace.ID)
+	}
+	return errors.Trace(s.prune())
+}
+
+// List returns the IDs of the traces in the store, oldest first.
+func (s *Store) List() ([]string, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Mode().IsRegular() && strings.HasSuffix(name, traceSuffix) {
+			ids = append(ids, strings.TrimSuffix(name, traceSuffix))
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Load returns the trace with the input ID.
+// A NotFound error is returned if there is no such trace.
+func (s *Store) Load(id string) (*Trace, error) {
+	if err := validateTraceID(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("hook trace %q", id)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var trace Trace
+	if err := json.Unmarshal(data, &trace); err != nil {
+		return nil, errors.Annotatef(err, "reading hook trace %q", id)
+	}
+	return &trace, nil
+}
+
+func (s *Store) prune() error {
+	ids, err := s.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for len(ids) > s.limit {
+		if err := os.Remove(s.path(ids[0])); err != nil && !os.IsNotExist(err) {
+			return errors.Annotatef(err, "removing hook trace %q", ids[0])
+		}
+		ids = ids[1:]
+	}
+	return nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+traceSuffix)
+}
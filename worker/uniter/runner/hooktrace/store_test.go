@@ -0,0 +1,169 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hooktrace_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
+)
+
+type storeSuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&storeSuite{})
+
+func (s *storeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "hook-traces")
+}
+
+func (s *storeSuite) TestSaveAndLoad(c *gc.C) {
+	store := hooktrace.NewStore(s.dir, 5, nil)
+	trace := &hooktrace.Trace{
+		ID:       "20200102-030405.000000-install",
+		Unit:     "mysql/0",
+		Hook:     "install",
+		Started:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Duration: time.Second,
+		Env:      []string{"JUJU_UNIT_NAME=mysql/0"},
+		ToolCalls: []hooktrace.ToolCall{{
+			Command: "unit-get",
+			Args:    []string{"private-address"},
+			Stdout:  []byte("10.0.0.1\n"),
+		}},
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}
+	c.Assert(store.Save(trace), jc.ErrorIsNil)
+
+	info, err := os.Stat(filepath.Join(s.dir, trace.ID+".json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	loaded, err := store.Load(trace.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loaded, jc.DeepEquals, trace)
+}
+
+func (s *storeSuite) TestSaveRedactsEnv(c *gc.C) {
+	store := hooktrace.NewStore(s.dir, 5, []string{"DB_URL"})
+	trace := &hooktrace.Trace{
+		ID: "20200102-030405.000000-install",
+		Env: []string{
+			"JUJU_UNIT_NAME=mysql/0",
+			"JUJU_AGENT_TOKEN=token",
+			"db_password=hunter2",
+			"AWS_SECRET_ACCESS_KEY=abc=def",
+			"DB_URL=postgres://admin:pw@db",
+			"DB_URL_HELP=see docs",
+		},
+	}
+	c.Assert(store.Save(trace), jc.ErrorIsNil)
+
+	loaded, err := store.Load(trace.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loaded.Env, jc.DeepEquals, []string{
+		"JUJU_UNIT_NAME=mysql/0",
+		"JUJU_AGENT_TOKEN=<redacted>",
+		"db_password=<redacted>",
+		"AWS_SECRET_ACCESS_KEY=<redacted>",
+		"DB_URL=<redacted>",
+		"DB_URL_HELP=see docs",
+	})
+	// The saved trace is redacted, not the one passed in.
+	c.Check(trace.Env[1], gc.Equals, "JUJU_AGENT_TOKEN=token")
+}
+
+func (s *storeSuite) TestSaveRedactsToolOutput(c *gc.C) {
+	store := hooktrace.NewStore(s.dir, 5, nil)
+	trace := &hooktrace.Trace{
+		ID: "20200102-030405.000000-install",
+		ToolCalls: []hooktrace.ToolCall{{
+			Command: "config-get",
+			Args:    []string{"db-password"},
+			Stdout:  []byte("hunter2\n"),
+		}, {
+			Command: "relation-get",
+			Args:    []string{"-"},
+			Stdout:  []byte("password: hunter2\n"),
+			Stderr:  []byte("warning\n"),
+		}, {
+			Command: "leader-get",
+			Args:    []string{"missing"},
+		}, {
+			Command: "unit-get",
+			Args:    []string{"private-address"},
+			Stdout:  []byte("10.0.0.1\n"),
+		}},
+	}
+	c.Assert(store.Save(trace), jc.ErrorIsNil)
+
+	loaded, err := store.Load(trace.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loaded.ToolCalls, jc.DeepEquals, []hooktrace.ToolCall{{
+		Command: "config-get",
+		Args:    []string{"db-password"},
+		Stdout:  []byte("<redacted>\n"),
+	}, {
+		Command: "relation-get",
+		Args:    []string{"-"},
+		Stdout:  []byte("<redacted>\n"),
+		Stderr:  []byte("warning\n"),
+	}, {
+		Command: "leader-get",
+		Args:    []string{"missing"},
+	}, {
+		Command: "unit-get",
+		Args:    []string{"private-address"},
+		Stdout:  []byte("10.0.0.1\n"),
+	}})
+	// The saved trace is redacted, not the one passed in.
+	c.Check(string(trace.ToolCalls[0].Stdout), gc.Equals, "hunter2\n")
+}
+
+func (s *storeSuite) TestLoadNotFound(c *gc.C) {
+	store := hooktrace.NewStore(s.dir, 5, nil)
+	_, err := store.Load("20200102-030405.000000-install")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storeSuite) TestLoadInvalidID(c *gc.C) {
+	store := hooktrace.NewStore(s.dir, 5, nil)
+	_, err := store.Load("../agent")
+	c.Check(err, gc.ErrorMatches, `trace ID "../agent" not valid`)
+}
+
+func (s *storeSuite) TestListEmpty(c *gc.C) {
+	ids, err := hooktrace.NewStore(s.dir, 5, nil).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestSavePrunesOldest(c *gc.C) {
+	store := hooktrace.NewStore(s.dir, 2, nil)
+	for _, id := range []string{
+		"20200102-030405.000000-install",
+		"20200102-030406.000000-config-changed",
+		"20200102-030407.000000-start",
+	} {
+		c.Assert(store.Save(&hooktrace.Trace{ID: id}), jc.ErrorIsNil)
+	}
+	ids, err := store.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []string{
+		"20200102-030406.000000-config-changed",
+		"20200102-030407.000000-start",
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hooktrace records the execution of charm hooks, including the
+// hook tool commands they invoke, so that a failed hook can be inspected
+// and replayed offline without waiting for it to fire again.
+package hooktrace
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// redactedEnvPatterns holds the substrings of the names of environment
+// variables whose values are never written to a trace. Names are
+// matched regardless of case.
+var redactedEnvPatterns = []string{"TOKEN", "PASSWORD", "SECRET", "KEY"}
+
+// redactedToolCommands holds the names of the hook tools whose output
+// is never written to a trace, because it may include credentials
+// from the charm's configuration, relations or cloud.
+var redactedToolCommands = map[string]bool{
+	"action-get":     true,
+	"config-get":     true,
+	"credential-get": true,
+	"leader-get":     true,
+	"relation-get":   true,
+	"state-get":      true,
+}
+
+// redacted replaces the values removed from a trace.
+const redacted = "<redacted>"
+
+// ToolCall records a single hook tool command invoked by a hook.
+type ToolCall struct {
+	// Command is the name of the hook tool.
+	Command string `json:"command"`
+
+	// Args are the arguments the hook tool was invoked with.
+	Args []string `json:"args,omitempty"`
+
+	// Stdin holds the input supplied to the hook tool, if any.
+	Stdin []byte `json:"stdin,omitempty"`
+
+	// Code is the hook tool's exit code.
+	Code int `json:"code"`
+
+	// Stdout and Stderr hold the hook tool's output.
+	Stdout []byte `json:"stdout,omitempty"`
+	Stderr []byte `json:"stderr,omitempty"`
+
+	// Error holds the error returned to the hook tool client instead
+	// of a result, for example when the tool requires stdin.
+	Error string `json:"error,omitempty"`
+}
+
+// String returns the command line of the hook tool invocation.
+func (c ToolCall) String() string {
+	return strings.Join(append([]string{c.Command}, c.Args...), " ")
+}
+
+// Trace records a single execution of a charm hook.
+type Trace struct {
+	// ID uniquely identifies the trace amongst those of the unit.
+	// IDs sort in the order in which the hooks ran.
+	ID string `json:"id"`
+
+	// Unit is the name of the unit that ran the hook.
+	Unit string `json:"unit"`
+
+	// Hook is the name of the hook.
+	Hook string `json:"hook"`
+
+	// Relation and RemoteUnit identify the relation and remote unit
+	// that a relation hook ran for.
+	Relation   string `json:"relation,omitempty"`
+	RemoteUnit string `json:"remote-unit,omitempty"`
+
+	// Started is when the hook started running.
+	Started time.Time `json:"started"`
+
+	// Duration is how long the hook took to run.
+	Duration time.Duration `json:"duration"`
+
+	// Env holds the environment the hook ran with.
+	Env []string `json:"env"`
+
+	// ToolCalls are the hook tool commands invoked by the hook,
+	// in the order in which they were invoked.
+	ToolCalls []ToolCall `json:"tool-calls,omitempty"`
+
+	// ExitCode is the exit code of the hook, or -1 if the hook
+	// could not be run.
+	ExitCode int `json:"exit-code"`
+
+	// Error describes why the hook failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// newTraceID returns a trace ID for the named hook started at the
+// supplied time.
+func newTraceID(hookName string, started time.Time) string {
+	return fmt.Sprintf("%s-%s", started.UTC().Format("20060102-150405.000000"), hookName)
+}
+
+// validateTraceID returns an error if the input cannot be a trace ID.
+func validateTraceID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return errors.NotValidf("trace ID %q", id)
+	}
+	return nil
+}
+
+// redactEnv returns a copy of the input environment with the values of
+// sensitive variables removed. A variable is sensitive if its name
+// matches one of redactedEnvPatterns, or is one of the supplied names.
+func redactEnv(env []string, names []string) []string {
+	result := make([]string, len(env))
+	for i, kv := range env {
+		result[i] = kv
+		name := strings.SplitN(kv, "=", 2)[0]
+		if isRedactedEnv(name, names) {
+			result[i] = name + "=" + redacted
+		}
+	}
+	return result
+}
+
+// redactToolCalls returns a copy of the input tool calls with the
+// output of those in redactedToolCommands removed.
+func redactToolCalls(calls []ToolCall) []ToolCall {
+	if calls == nil {
+		return nil
+	}
+	result := make([]ToolCall, len(calls))
+	for i, call := range calls {
+		result[i] = call
+		if redactedToolCommands[call.Command] && len(call.Stdout) > 0 {
+			result[i].Stdout = []byte(redacted + "\n")
+		}
+	}
+	return result
+}
+
+func isRedactedEnv(name string, names []string) bool {
+	for _, redacted := range names {
+		if name == redacted {
+			return true
+		}
+	}
+	upper := strings.ToUpper(name)
+	for _, pattern := range redactedEnvPatterns {
+		if strings.Contains(upper, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// exitCode returns the exit code indicated by the error
+// returned from running a hook.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
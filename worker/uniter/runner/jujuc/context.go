@@ -215,6 +215,9 @@ type ContextMetrics interface {
 	AddMetric(string, string, time.Time) error
 	// AddMetricLabels records a metric with tags to return after hook execution.
 	AddMetricLabels(string, string, time.Time, map[string]string) error
+	// PublishCharmMetric records a metric to send to the controller's
+	// Prometheus endpoint after hook execution.
+	PublishCharmMetric(CharmMetric) error
 }
 
 // ContextStorage is the part of a hook context related to storage
@@ -13,7 +13,8 @@ import (
 
 // Metrics holds the values for the hook sub-context.
 type Metrics struct {
-	Metrics []jujuc.Metric
+	Metrics      []jujuc.Metric
+	CharmMetrics []jujuc.CharmMetric
 }
 
 // AddMetric adds a Metric for the provided data.
@@ -35,6 +36,11 @@ func (m *Metrics) AddMetricLabels(key, value string, created time.Time, labels m
 	})
 }
 
+// PublishCharmMetric adds a CharmMetric for the provided data.
+func (m *Metrics) PublishCharmMetric(metric jujuc.CharmMetric) {
+	m.CharmMetrics = append(m.CharmMetrics, metric)
+}
+
 // ContextMetrics is a test double for jujuc.ContextMetrics.
 type ContextMetrics struct {
 	contextBase
@@ -62,3 +68,14 @@ func (c *ContextMetrics) AddMetricLabels(key, value string, created time.Time, l
 	c.info.AddMetricLabels(key, value, created, labels)
 	return nil
 }
+
+// PublishCharmMetric implements jujuc.ContextMetrics.
+func (c *ContextMetrics) PublishCharmMetric(metric jujuc.CharmMetric) error {
+	c.stub.AddCall("PublishCharmMetric", metric)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.PublishCharmMetric(metric)
+	return nil
+}
@@ -356,6 +356,18 @@ func (mr *MockContextMockRecorder) PublicAddress() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicAddress", reflect.TypeOf((*MockContext)(nil).PublicAddress))
 }
 
+// PublishCharmMetric mocks base method
+func (m *MockContext) PublishCharmMetric(arg0 jujuc.CharmMetric) error {
+	ret := m.ctrl.Call(m, "PublishCharmMetric", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishCharmMetric indicates an expected call of PublishCharmMetric
+func (mr *MockContextMockRecorder) PublishCharmMetric(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishCharmMetric", reflect.TypeOf((*MockContext)(nil).PublishCharmMetric), arg0)
+}
+
 // Relation mocks base method
 func (m *MockContext) Relation(arg0 int) (jujuc.ContextRelation, error) {
 	ret := m.ctrl.Call(m, "Relation", arg0)
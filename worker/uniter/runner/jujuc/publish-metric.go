@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+const (
+	// CharmMetricGauge is the type of charm metrics whose published
+	// value replaces any earlier one.
+	CharmMetricGauge = "gauge"
+
+	// CharmMetricCounter is the type of charm metrics whose published
+	// values are added together by the controller.
+	CharmMetricCounter = "counter"
+)
+
+var validCharmMetricName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// CharmMetric represents a single value published by the charm to the
+// controller's Prometheus endpoint.
+type CharmMetric struct {
+	Name  string
+	Type  string
+	Help  string
+	Value float64
+}
+
+// PublishMetricCommand implements the publish-metric command.
+type PublishMetricCommand struct {
+	cmd.CommandBase
+	ctx         Context
+	counter     bool
+	description string
+	Metrics     []CharmMetric
+}
+
+// NewPublishMetricCommand returns a publish-metric command.
+func NewPublishMetricCommand(ctx Context) (cmd.Command, error) {
+	return &PublishMetricCommand{ctx: ctx}, nil
+}
+
+// Info returns information about the Command.
+// Info implements part of the cmd.Command interface.
+func (c *PublishMetricCommand) Info() *cmd.Info {
+	doc := `
+publish-metric publishes values that the controller exposes on its
+Prometheus endpoint, labelled with the model, application and unit.
+Each metric is exported as juju_charm_<name>.
+
+Metrics are gauges by default, and the latest value published replaces
+any earlier one. With --counter, the values published are added to the
+unit's running total instead, and must not be negative.
+
+Values are sent to the controller when the hook completes successfully.
+A unit may publish at most 100 differently named metrics; if the values
+can't be published, the agent logs a warning and the hook still succeeds.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "publish-metric",
+		Args:    "name=value [name=value ...]",
+		Purpose: "publish metrics to the controller",
+		Doc:     doc,
+	})
+}
+
+// SetFlags adds command specific flags to the flag set.
+// SetFlags implements part of the cmd.Command interface.
+func (c *PublishMetricCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.counter, "counter", false, "publish the metrics as counters")
+	f.StringVar(&c.description, "description", "", "help text describing the metrics")
+}
+
+// Init initializes the Command before running.
+// Init implements part of the cmd.Command interface.
+func (c *PublishMetricCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no metrics specified")
+	}
+	kvs, err := keyvalues.Parse(args, false)
+	if err != nil {
+		return errors.Annotate(err, "invalid metrics")
+	}
+	metricType := CharmMetricGauge
+	if c.counter {
+		metricType = CharmMetricCounter
+	}
+	names := make([]string, 0, len(kvs))
+	for name := range kvs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !validCharmMetricName.MatchString(name) {
+			return errors.NotValidf("metric name %q", name)
+		}
+		value, err := strconv.ParseFloat(kvs[name], 64)
+		if err != nil {
+			return errors.NotValidf("value %q for metric %q", kvs[name], name)
+		}
+		if c.counter && value < 0 {
+			return errors.NotValidf("negative value %v for counter %q", value, name)
+		}
+		c.Metrics = append(c.Metrics, CharmMetric{
+			Name:  name,
+			Type:  metricType,
+			Help:  c.description,
+			Value: value,
+		})
+	}
+	return nil
+}
+
+// Run records the metrics in the hook context.
+// Run implements part of the cmd.Command interface.
+func (c *PublishMetricCommand) Run(ctx *cmd.Context) error {
+	for _, metric := range c.Metrics {
+		if err := c.ctx.PublishCharmMetric(metric); err != nil {
+			return errors.Annotate(err, "cannot publish metric")
+		}
+	}
+	return nil
+}
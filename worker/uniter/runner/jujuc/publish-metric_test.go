@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type PublishMetricSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&PublishMetricSuite{})
+
+func (s *PublishMetricSuite) TestHelp(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("publish-metric"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `
+Usage: publish-metric [options] name=value [name=value ...]
+
+Summary:
+publish metrics to the controller
+
+Options:
+--counter  (= false)
+    publish the metrics as counters
+--description (= "")
+    help text describing the metrics
+
+Details:
+publish-metric publishes values that the controller exposes on its
+Prometheus endpoint, labelled with the model, application and unit.
+Each metric is exported as juju_charm_<name>.
+
+Metrics are gauges by default, and the latest value published replaces
+any earlier one. With --counter, the values published are added to the
+unit's running total instead, and must not be negative.
+
+Values are sent to the controller when the hook completes successfully.
+`[1:])
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *PublishMetricSuite) TestPublishMetric(c *gc.C) {
+	for i, t := range []struct {
+		about  string
+		args   []string
+		code   int
+		stderr string
+		expect []jujuc.CharmMetric
+	}{{
+		about: "single gauge",
+		args:  []string{"queue_depth=4.5"},
+		expect: []jujuc.CharmMetric{
+			{Name: "queue_depth", Type: jujuc.CharmMetricGauge, Value: 4.5},
+		},
+	}, {
+		about: "counters with description",
+		args:  []string{"--counter", "--description", "Jobs done", "jobs_ok=3", "jobs_failed=1"},
+		expect: []jujuc.CharmMetric{
+			{Name: "jobs_failed", Type: jujuc.CharmMetricCounter, Help: "Jobs done", Value: 1},
+			{Name: "jobs_ok", Type: jujuc.CharmMetricCounter, Help: "Jobs done", Value: 3},
+		},
+	}, {
+		about:  "no metrics",
+		code:   2,
+		stderr: "ERROR no metrics specified\n",
+	}, {
+		about:  "invalid name",
+		args:   []string{"queue-depth=1"},
+		code:   2,
+		stderr: "ERROR metric name \"queue-depth\" not valid\n",
+	}, {
+		about:  "invalid value",
+		args:   []string{"queue_depth=lots"},
+		code:   2,
+		stderr: "ERROR value \"lots\" for metric \"queue_depth\" not valid\n",
+	}, {
+		about:  "negative counter",
+		args:   []string{"--counter", "jobs_ok=-1"},
+		code:   2,
+		stderr: "ERROR negative value -1 for counter \"jobs_ok\" not valid\n",
+	}} {
+		c.Logf("test %d: %s", i, t.about)
+		hctx := s.GetHookContext(c, -1, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("publish-metric"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.stderr)
+		c.Check(hctx.info.CharmMetrics, jc.DeepEquals, t.expect)
+	}
+}
@@ -134,6 +134,9 @@ func (*RestrictedContext) AddMetricLabels(string, string, time.Time, map[string]
 	return ErrRestrictedContext
 }
 
+// PublishCharmMetric implements hooks.Context.
+func (*RestrictedContext) PublishCharmMetric(CharmMetric) error { return ErrRestrictedContext }
+
 // StorageTags implements hooks.Context.
 func (*RestrictedContext) StorageTags() ([]names.StorageTag, error) { return nil, ErrRestrictedContext }
 
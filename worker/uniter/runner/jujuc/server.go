@@ -74,6 +74,8 @@ var baseCommands = map[string]creator{
 	"state-get" + cmdSuffix:    NewStateGetCommand,
 	"state-delete" + cmdSuffix: NewStateDeleteCommand,
 	"state-set" + cmdSuffix:    NewStateSetCommand,
+
+	"publish-metric" + cmdSuffix: NewPublishMetricCommand,
 }
 
 type functionCmdCreator func(Context, string) (cmd.Command, error)
@@ -152,6 +154,12 @@ type Request struct {
 // CmdGetter looks up a Command implementation connected to a particular Context.
 type CmdGetter func(contextId, cmdName string) (cmd.Command, error)
 
+// Handler handles the hook tool invocations received by a Server.
+type Handler interface {
+	// Main handles the invocation described by req, filling in resp.
+	Main(req Request, resp *exec.ExecResponse) error
+}
+
 // Jujuc implements the jujuc command in the form required by net/rpc.
 type Jujuc struct {
 	mu     sync.Mutex
@@ -159,6 +167,12 @@ type Jujuc struct {
 	token  string
 }
 
+// NewJujuc returns a Jujuc that runs the commands looked up by getCmd,
+// for requests bearing the supplied token.
+func NewJujuc(getCmd CmdGetter, token string) *Jujuc {
+	return &Jujuc{getCmd: getCmd, token: token}
+}
+
 // badReqErrorf returns an error indicating a bad Request.
 func badReqErrorf(format string, v ...interface{}) error {
 	return fmt.Errorf("bad request: "+format, v...)
@@ -227,8 +241,16 @@ type Server struct {
 // remote command invocations against an appropriate Context. It will not
 // actually do so until Run is called.
 func NewServer(getCmd CmdGetter, socket sockets.Socket, token string) (*Server, error) {
+	return NewHandlerServer(NewJujuc(getCmd, token), socket)
+}
+
+// NewHandlerServer creates an RPC server bound to socketPath, which passes
+// remote command invocations to the supplied handler. It will not actually
+// do so until Run is called.
+func NewHandlerServer(handler Handler, socket sockets.Socket) (*Server, error) {
 	server := rpc.NewServer()
-	if err := server.Register(&Jujuc{getCmd: getCmd, token: token}); err != nil {
+	// Clients call "Jujuc.Main" whatever the handler is.
+	if err := server.RegisterName("Jujuc", handler); err != nil {
 		return nil, err
 	}
 	listener, err := sockets.Listen(socket)
@@ -242,6 +242,38 @@ func (s *ServerSuite) TestBrokenCommand(c *gc.C) {
 	c.Assert(string(resp.Stderr), gc.Equals, "ERROR blam\n")
 }
 
+type echoHandler struct{}
+
+func (echoHandler) Main(req jujuc.Request, resp *exec.ExecResponse) error {
+	resp.Stdout = []byte(fmt.Sprintf("%s %q", req.CommandName, req.Args))
+	resp.Code = 3
+	return nil
+}
+
+func (s *ServerSuite) TestHandlerServer(c *gc.C) {
+	socket := s.osDependentSockPath(c)
+	srv, err := jujuc.NewHandlerServer(echoHandler{}, socket)
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error)
+	go func() { done <- srv.Run() }()
+	defer func() {
+		srv.Close()
+		c.Check(<-done, jc.ErrorIsNil)
+	}()
+
+	client, err := sockets.Dial(socket)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	var resp exec.ExecResponse
+	err = client.Call("Jujuc.Main", jujuc.Request{
+		CommandName: "remote",
+		Args:        []string{"--value", "something"},
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resp.Code, gc.Equals, 3)
+	c.Check(string(resp.Stdout), gc.Equals, `remote ["--value" "something"]`)
+}
+
 type NewCommandSuite struct {
 	relationSuite
 }
@@ -0,0 +1,28 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setHookProcessGroup arranges for the hook command to be started
+// as the leader of a new process group.
+func setHookProcessGroup(ps *exec.Cmd) {
+	ps.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// terminateHook asks the hook's process group to exit.
+func terminateHook(p *os.Process) error {
+	return syscall.Kill(-p.Pid, syscall.SIGTERM)
+}
+
+// killHook kills the hook's process group.
+func killHook(p *os.Process) error {
+	return syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,24 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setHookProcessGroup does nothing on windows, where hook
+// processes cannot be stopped as a group.
+func setHookProcessGroup(ps *exec.Cmd) {}
+
+// terminateHook kills the hook process; windows processes
+// cannot be asked to exit.
+func terminateHook(p *os.Process) error {
+	return p.Kill()
+}
+
+// killHook kills the hook process.
+func killHook(p *os.Process) error {
+	return p.Kill()
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -75,7 +76,34 @@ type Context interface {
 
 // NewRunner returns a Runner backed by the supplied context and paths.
 func NewRunner(context Context, paths context.Paths, remoteExecutor ExecFunc) Runner {
-	return &runner{context, paths, remoteExecutor}
+	return &runner{context: context, paths: paths, remoteExecutor: remoteExecutor}
+}
+
+// newTracingRunner returns a Runner like NewRunner, which also records
+// a trace of each hook it runs to the supplied store, if it is not nil,
+// stops any hook that runs for longer than the supplied timeout, and
+// runs the debug sessions in debugSessions, if it is not nil, in place
+// of the hooks they are waiting for.
+func newTracingRunner(
+	context Context, paths context.Paths, remoteExecutor ExecFunc,
+	traces *hooktrace.Store, timeout hookTimeout, debugSessions *debug.RemoteSessions,
+) Runner {
+	return &runner{
+		context:        context,
+		paths:          paths,
+		remoteExecutor: remoteExecutor,
+		traces:         traces,
+		timeout:        timeout,
+		debugSessions:  debugSessions,
+	}
+}
+
+// hookTimeout holds how long a hook may run before it is stopped, and
+// how long a stopped hook has to exit after it is asked to terminate
+// before it is killed. A zero timeout means hooks may run forever.
+type hookTimeout struct {
+	timeout         time.Duration
+	killGracePeriod time.Duration
 }
 
 // ExecParams holds all the necessary parameters for ExecFunc.
@@ -121,6 +149,13 @@ type runner struct {
 	paths   context.Paths
 	// remoteExecutor executes commands on a remote workload pod for CAAS.
 	remoteExecutor ExecFunc
+	// traces records hook executions, if it is not nil.
+	traces *hooktrace.Store
+	// timeout limits how long hooks may run.
+	timeout hookTimeout
+	// debugSessions holds the sessions started by operators to
+	// debug hooks over the API, if it is not nil.
+	debugSessions *debug.RemoteSessions
 }
 
 func (runner *runner) Context() Context {
@@ -160,7 +195,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 			return nil, errors.Trace(err)
 		}
 	}
-	srv, err := runner.startJujucServer(token, rMode)
+	srv, err := runner.startJujucServer(token, rMode, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +350,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 			return errors.Trace(err)
 		}
 	}
-	srv, err := runner.startJujucServer(token, rMode)
+	// Only hooks are traced, not actions.
+	var recorder *hooktrace.Recorder
+	if runner.traces != nil && charmLocation == "hooks" {
+		recorder = runner.newHookRecorder(hookName)
+	}
+	srv, err := runner.startJujucServer(token, rMode, recorder)
 	if err != nil {
 		return err
 	}
@@ -338,18 +378,72 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 	defer func() {
 		err = runner.context.Flush(hookName, err)
 	}()
+	if recorder != nil {
+		recorder.SetEnv(env)
+		// Record the outcome of the hook itself, before the
+		// context is flushed. Hooks the charm doesn't have
+		// are not worth a trace.
+		defer func() {
+			if charmrunner.IsMissingHookError(err) {
+				return
+			}
+			if saveErr := runner.traces.Save(recorder.Finish(err)); saveErr != nil {
+				logger.Warningf("cannot save trace of %s hook: %v", hookName, saveErr)
+			}
+		}()
+	}
 
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
 		logger.Infof("executing %s via debug-hooks", hookName)
 		return session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	}
+	if runner.debugSessions != nil {
+		if session := runner.debugSessions.Claim(debugctx, hookName); session != nil {
+			logger.Infof("executing %s via debug shell", hookName)
+			return session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+		}
+	}
 	if rMode == runOnRemote {
 		return runner.runCharmHookOnRemote(hookName, env, charmLocation)
 	}
 	return runner.runCharmHookOnLocal(hookName, env, charmLocation)
 }
 
+// waitForLocalHook blocks until the started hook process exits. If the
+// hook runs for longer than the runner's timeout, its process group is
+// sent SIGTERM and, failing that, SIGKILL once the grace period has
+// passed; a timed out error is returned once it has exited.
+func (runner *runner) waitForLocalHook(hookName string, ps *exec.Cmd) error {
+	if runner.timeout.timeout <= 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-clock.WallClock.After(runner.timeout.timeout):
+	}
+
+	logger.Warningf("%s hook timed out after %v, terminating it", hookName, runner.timeout.timeout)
+	if err := terminateHook(ps.Process); err != nil {
+		logger.Warningf("cannot terminate %s hook: %v", hookName, err)
+	}
+	select {
+	case <-done:
+	case <-clock.WallClock.After(runner.timeout.killGracePeriod):
+		logger.Warningf("%s hook still running after %v, killing it", hookName, runner.timeout.killGracePeriod)
+		if err := killHook(ps.Process); err != nil {
+			logger.Warningf("cannot kill %s hook: %v", hookName, err)
+		}
+		<-done
+	}
+	return charmrunner.NewHookTimedOutError(hookName, runner.timeout.timeout)
+}
+
 // loggerAdaptor implements MessageReceiver and
 // sends messages to a logger.
 type loggerAdaptor struct {
@@ -395,7 +489,18 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 	charmDir := runner.paths.GetCharmDir()
 	hook := filepath.Join(charmDir, filepath.Join(charmLocation, hookName))
 
+	// The remote executor stops the hook when it is cancelled,
+	// so the kill grace period is left to the executor.
 	var cancel chan struct{}
+	var timer clock.Timer
+	if runner.timeout.timeout > 0 {
+		cancel = make(chan struct{})
+		timer = clock.WallClock.AfterFunc(runner.timeout.timeout, func() {
+			logger.Warningf("%s hook timed out after %v, cancelling it", hookName, runner.timeout.timeout)
+			close(cancel)
+		})
+	}
+
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make stdout logging pipe: %v", err)
@@ -448,6 +553,10 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 			StderrLogger: hookErrLogger,
 		},
 	)
+	if timer != nil && !timer.Stop() {
+		// The timer has fired, so the hook was cancelled.
+		err = charmrunner.NewHookTimedOutError(hookName, runner.timeout.timeout)
+	}
 
 	// If we are running an action, record stdout and stderr.
 	if runningAction && resp != nil {
@@ -468,6 +577,11 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	if runner.timeout.timeout > 0 {
+		// Run the hook in its own process group, so that
+		// any processes it starts are stopped with it.
+		setHookProcessGroup(ps)
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -514,7 +628,7 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
 		// Block until execution finishes
-		exitErr = ps.Wait()
+		exitErr = runner.waitForLocalHook(hookName, ps)
 	} else {
 		exitErr = err
 	}
@@ -555,7 +669,18 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	return errors.Trace(exitErr)
 }
 
-func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Server, error) {
+// newHookRecorder returns a recorder for an execution of the named hook
+// in the runner's context.
+func (runner *runner) newHookRecorder(hookName string) *hooktrace.Recorder {
+	recorder := hooktrace.NewRecorder(runner.context.UnitName(), hookName, clock.WallClock)
+	if relation, err := runner.context.HookRelation(); err == nil {
+		remoteUnit, _ := runner.context.RemoteUnitName()
+		recorder.SetRelation(relation.FakeId(), remoteUnit)
+	}
+	return recorder
+}
+
+func (runner *runner) startJujucServer(token string, rMode runMode, recorder *hooktrace.Recorder) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
 		if ctxId != runner.context.Id() {
@@ -566,7 +691,11 @@ func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Serv
 
 	socket := runner.paths.GetJujucServerSocket(rMode == runOnRemote)
 	logger.Debugf("starting jujuc server %s %v", token, socket)
-	srv, err := jujuc.NewServer(getCmd, socket, token)
+	var handler jujuc.Handler = jujuc.NewJujuc(getCmd, token)
+	if recorder != nil {
+		handler = recorder.Handler(handler)
+	}
+	srv, err := jujuc.NewHandlerServer(handler, socket)
 	if err != nil {
 		return nil, errors.Annotate(err, "starting jujuc server")
 	}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -21,10 +22,13 @@ import (
 	"gopkg.in/juju/charm.v6/hooks"
 
 	"github.com/juju/juju/core/model"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -174,6 +178,10 @@ func (ctx *MockContext) UnitName() string {
 	return "some-unit/999"
 }
 
+func (ctx *MockContext) HookRelation() (jujuc.ContextRelation, error) {
+	return nil, errors.NotFoundf("hook relation")
+}
+
 func (ctx *MockContext) HookVars(paths context.Paths, _ bool) ([]string, error) {
 	return []string{"VAR=value"}, nil
 }
@@ -272,6 +280,107 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTraced(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 123,
+	}, s.paths.GetCharmDir())
+	traces := hooktrace.NewStore(filepath.Join(c.MkDir(), "hook-traces"), 5, nil)
+	err := runner.NewTracingRunner(ctx, s.paths, nil, traces).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+
+	ids, err := traces.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, gc.HasLen, 1)
+	trace, err := traces.Load(ids[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(trace.Unit, gc.Equals, "some-unit/999")
+	c.Check(trace.Hook, gc.Equals, "something-happened")
+	c.Check(trace.Relation, gc.Equals, "")
+	c.Check(trace.Env[0], gc.Equals, "VAR=value")
+	c.Check(trace.ExitCode, gc.Equals, 123)
+	c.Check(trace.Error, gc.Equals, "exit status 123")
+}
+
+func (s *RunMockContextSuite) TestRunHookMissingNotTraced(c *gc.C) {
+	ctx := &MockContext{}
+	traces := hooktrace.NewStore(filepath.Join(c.MkDir(), "hook-traces"), 5, nil)
+	err := runner.NewTracingRunner(ctx, s.paths, nil, traces).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmrunner.IsMissingHookError(ctx.flushFailure), jc.IsTrue)
+
+	ids, err := traces.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook processes cannot be terminated on windows")
+	}
+	ctx := &MockContext{}
+	dir := filepath.Join(s.paths.GetCharmDir(), "hooks")
+	err := os.Mkdir(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	// The hook ignores SIGTERM, so it must be killed.
+	script := "#!/bin/bash\ntrap '' TERM\nsleep 60\n"
+	err = ioutil.WriteFile(filepath.Join(dir, hookName), []byte(script), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+
+	start := time.Now()
+	err = runner.NewTimeoutRunner(ctx, s.paths, nil, 100*time.Millisecond, 100*time.Millisecond).RunHook(hookName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(time.Since(start) < 30*time.Second, jc.IsTrue)
+	c.Assert(ctx.flushBadge, gc.Equals, hookName)
+	c.Assert(charmrunner.IsHookTimedOutError(ctx.flushFailure), jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 100ms`)
+}
+
+func (s *RunMockContextSuite) TestRunCharmActionOnRemoteTimeout(c *gc.C) {
+	ctx := &MockContext{
+		actionData:    &context.ActionData{},
+		actionResults: map[string]interface{}{},
+		modelType:     model.CAAS,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "actions",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		select {
+		case <-params.Cancel:
+			return nil, exec.ErrCancelled
+		case <-time.After(coretesting.LongWait):
+			return nil, errors.New("hook not cancelled")
+		}
+	}
+	err := runner.NewTimeoutRunner(ctx, s.paths, execFunc, 100*time.Millisecond, time.Second).RunAction(hookName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, hookName)
+	c.Assert(charmrunner.IsHookTimedOutError(ctx.flushFailure), jc.IsTrue)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `hook "something-happened" timed out after 100ms`)
+}
+
+func (s *RunMockContextSuite) TestRunHookWithinTimeout(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 123,
+	}, s.paths.GetCharmDir())
+	err := runner.NewTimeoutRunner(ctx, s.paths, nil, time.Minute, time.Second).RunHook(hookName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -118,6 +118,9 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 		s.paths,
 		s.contextFactory,
 		nil,
+		nil,
+		s.uniter,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.factory = factory
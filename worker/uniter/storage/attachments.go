@@ -130,6 +130,13 @@ func (a *Attachments) init() error {
 				storageTag.Id(),
 			)
 		}
+		if stateFile.size == 0 && attachment.Size > 0 {
+			// The state file predates size tracking, so take
+			// the current size as the one known to the charm.
+			if err := stateFile.RecordSize(attachment.Size); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		a.storageAttachments[storageTag] = storageAttachment{
 			stateFile,
 			&contextStorage{
@@ -234,7 +241,7 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 }
 
 func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return nil, errors.Errorf("not a storage hook: %#v", hi)
 	}
 	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
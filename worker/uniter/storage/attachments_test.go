@@ -388,6 +388,70 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	c.Assert(removed, jc.IsTrue)
 }
 
+func (s *attachmentsSuite) TestAttachmentsStorageResized(c *gc.C) {
+	stateDir := c.MkDir()
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, stateDir, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(att, s.modelType)
+
+	storageTag := names.NewStorageTag("data/0")
+	localState := resolver.LocalState{State: operation.State{
+		Kind:      operation.Continue,
+		Installed: true,
+		Started:   true,
+	}}
+	nextOp := func(size uint64) (operation.Operation, error) {
+		return r.NextOp(localState, remotestate.Snapshot{
+			Life: life.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindBlock,
+					Life:     life.Alive,
+					Location: "/dev/sdb",
+					Attached: true,
+					Size:     size,
+				},
+			},
+		}, &mockOperations{})
+	}
+
+	op, err := nextOp(1024)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+	err = att.CommitHook(hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   storageTag.Id(),
+		StorageSize: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing to do until the storage grows.
+	_, err = nextOp(1024)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	op, err = nextOp(2048)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-resized")
+	err = att.CommitHook(hook.Info{
+		Kind:        hook.StorageResized,
+		StorageId:   storageTag.Id(),
+		StorageSize: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = nextOp(2048)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
 func (s *attachmentsSuite) TestAttachmentsSetDying(c *gc.C) {
 	stateDir := c.MkDir()
 	unitTag := names.NewUnitTag("mysql/0")
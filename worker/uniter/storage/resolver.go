@@ -171,8 +171,15 @@ func (s *storageResolver) nextHookOp(
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if ok && storageAttachment.attached {
 			// Once the storage is attached, we only care about
-			// lifecycle state changes.
-			return nil, resolver.ErrNoOperation
+			// lifecycle state changes and growth of the storage.
+			if storageAttachment.size == 0 || snap.Size <= storageAttachment.size {
+				return nil, resolver.ErrNoOperation
+			}
+			// The storage has grown since the charm was last
+			// told its size. Run the "storage-resized" hook.
+			hookInfo.Kind = hook.StorageResized
+			hookInfo.StorageSize = snap.Size
+			break
 		}
 		// The storage-attached hook has not been committed, so add the
 		// storage to the pending set.
@@ -186,6 +193,7 @@ func (s *storageResolver) nextHookOp(
 		// The storage is alive, but we haven't previously run the
 		// "storage-attached" hook. Do so now.
 		hookInfo.Kind = hooks.StorageAttached
+		hookInfo.StorageSize = snap.Size
 	case life.Dying:
 		storageAttachment, ok := s.storage.storageAttachments[tag]
 		if !ok || !storageAttachment.attached {
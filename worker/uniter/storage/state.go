@@ -25,6 +25,10 @@ type state struct {
 	// attached records the uniter's knowledge of the
 	// storage attachment state.
 	attached bool
+
+	// size records the size in MiB of the storage as last
+	// reported to the charm, or zero if unknown.
+	size uint64
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -41,7 +45,7 @@ func (s *state) ValidateHook(hi hook.Info) (err error) {
 		if s.attached {
 			return errors.New("storage already attached")
 		}
-	case hooks.StorageDetaching:
+	case hooks.StorageDetaching, hook.StorageResized:
 		if !s.attached {
 			return errors.New("storage not attached")
 		}
@@ -85,6 +89,7 @@ func readStateFile(dirPath string, tag names.StorageTag) (d *stateFile, err erro
 		return nil, errors.Errorf("invalid storage state file %q: missing 'attached'", d.path)
 	}
 	d.state.attached = *info.Attached
+	d.state.size = info.Size
 	return d, nil
 }
 
@@ -135,16 +140,39 @@ func (d *stateFile) CommitHook(hi hook.Info) (err error) {
 	if hi.Kind == hooks.StorageDetaching {
 		return d.Remove()
 	}
-	attached := true
-	di := diskInfo{&attached}
-	if err := utils.WriteYaml(d.path, &di); err != nil {
+	size := d.state.size
+	if hi.StorageSize > 0 {
+		size = hi.StorageSize
+	}
+	if err := d.write(size); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
 	d.state.attached = true
+	d.state.size = size
 	return nil
 }
 
+// RecordSize writes the size of attached storage to disk, without
+// running a hook. It is used to record the size of storage attached
+// before sizes were tracked, so that later growth can be detected.
+func (d *stateFile) RecordSize(size uint64) error {
+	if !d.state.attached {
+		return errors.Errorf("storage %q not attached", d.state.storage.Id())
+	}
+	if err := d.write(size); err != nil {
+		return errors.Annotatef(err, "recording size of storage %q", d.state.storage.Id())
+	}
+	d.state.size = size
+	return nil
+}
+
+func (d *stateFile) write(size uint64) error {
+	attached := true
+	di := diskInfo{Attached: &attached, Size: size}
+	return utils.WriteYaml(d.path, &di)
+}
+
 // Remove removes the directory if it exists and is empty.
 func (d *stateFile) Remove() error {
 	if err := os.Remove(d.path); err != nil && !os.IsNotExist(err) {
@@ -157,5 +185,6 @@ func (d *stateFile) Remove() error {
 
 // diskInfo defines the storage attachment data serialization.
 type diskInfo struct {
-	Attached *bool `yaml:"attached,omitempty"`
+	Attached *bool  `yaml:"attached,omitempty"`
+	Size     uint64 `yaml:"size,omitempty"`
 }
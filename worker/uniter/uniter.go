@@ -41,6 +41,8 @@ import (
 	"github.com/juju/juju/worker/uniter/runcommands"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+	"github.com/juju/juju/worker/uniter/runner/hooktrace"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"github.com/juju/juju/worker/uniter/storage"
 	"github.com/juju/juju/worker/uniter/upgradeseries"
@@ -123,6 +125,19 @@ type Uniter struct {
 	// hookRetryStrategy represents configuration for hook retries
 	hookRetryStrategy params.RetryStrategy
 
+	// hookTraceLimit is the number of hook execution traces to keep.
+	// Hooks are not traced if it is not positive.
+	hookTraceLimit int
+
+	// hookTraceRedactEnv holds the names of additional environment
+	// variables whose values are removed from hook traces.
+	hookTraceRedactEnv []string
+
+	// debugSessions holds the debug sessions started by operators
+	// over the API, which are run in place of the hooks they are
+	// waiting for. No such sessions are run if it is nil.
+	debugSessions *debug.RemoteSessions
+
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
@@ -140,6 +155,9 @@ type UniterParams struct {
 	CharmDirGuard           fortress.Guard
 	UpdateStatusSignal      remotestate.UpdateStatusTimerFunc
 	HookRetryStrategy       params.RetryStrategy
+	HookTraceLimit          int
+	HookTraceRedactEnv      []string
+	DebugSessions           *debug.RemoteSessions
 	NewOperationExecutor    NewOperationExecutorFunc
 	NewRemoteRunnerExecutor NewRunnerExecutorFunc
 	RunListener             *RunListener
@@ -199,6 +217,9 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 		charmDirGuard:           uniterParams.CharmDirGuard,
 		updateStatusAt:          uniterParams.UpdateStatusSignal,
 		hookRetryStrategy:       uniterParams.HookRetryStrategy,
+		hookTraceLimit:          uniterParams.HookTraceLimit,
+		hookTraceRedactEnv:      uniterParams.HookTraceRedactEnv,
+		debugSessions:           uniterParams.DebugSessions,
 		newOperationExecutor:    uniterParams.NewOperationExecutor,
 		newRemoteRunnerExecutor: uniterParams.NewRemoteRunnerExecutor,
 		translateResolverErr:    translateResolverErr,
@@ -600,8 +621,23 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if u.newRemoteRunnerExecutor != nil {
 		remoteExecutor = u.newRemoteRunnerExecutor(u.unit, u.paths)
 	}
+	var hookTraces *hooktrace.Store
+	if u.hookTraceLimit > 0 {
+		hookTraces = hooktrace.NewStore(u.paths.State.HookTracesDir, u.hookTraceLimit, u.hookTraceRedactEnv)
+	}
+	appConfigWatcher, err := u.unit.WatchTrustConfigSettingsHash()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hookTimeouts, err := newHookTimeoutPolicyCache(appConfigWatcher, u.st.HookTimeoutPolicy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := u.catacomb.Add(hookTimeouts); err != nil {
+		return errors.Trace(err)
+	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory, remoteExecutor,
+		u.st, u.paths, contextFactory, remoteExecutor, hookTraces, hookTimeouts, u.debugSessions,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -713,7 +749,7 @@ func (u *Uniter) acquireExecutionLock(action string) (func(), error) {
 	return releaser, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+func (u *Uniter) reportHookError(hookInfo hook.Info, timedOut bool) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
@@ -732,5 +768,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if timedOut {
+		statusData["timed-out"] = true
+		statusMessage = fmt.Sprintf("hook timed out: %q", hookName)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }